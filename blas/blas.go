@@ -119,6 +119,19 @@ type Float32Level3 interface {
 	Strsm(s Side, ul Uplo, tA Transpose, d Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int)
 }
 
+// Float32Batched implements batched single precision real BLAS routines that
+// perform the same operation on many independent matrices or vectors with
+// equal dimensions. Implementations of Float32 are not required to implement
+// Float32Batched.
+type Float32Batched interface {
+	SgemvBatched(tA Transpose, m, n int, alpha float32, a [][]float32, lda int, x [][]float32, incX int, beta float32, y [][]float32, incY int)
+	SgemvStridedBatched(tA Transpose, m, n int, alpha float32, a []float32, lda, strideA int, x []float32, incX, strideX int, beta float32, y []float32, incY, strideY int, batchCount int)
+	SgemmBatched(tA, tB Transpose, m, n, k int, alpha float32, a [][]float32, lda int, b [][]float32, ldb int, beta float32, c [][]float32, ldc int)
+	SgemmStridedBatched(tA, tB Transpose, m, n, k int, alpha float32, a []float32, lda, strideA int, b []float32, ldb, strideB int, beta float32, c []float32, ldc, strideC int, batchCount int)
+	StrsmBatched(s Side, ul Uplo, tA Transpose, d Diag, m, n int, alpha float32, a [][]float32, lda int, b [][]float32, ldb int)
+	StrsmStridedBatched(s Side, ul Uplo, tA Transpose, d Diag, m, n int, alpha float32, a []float32, lda, strideA int, b []float32, ldb, strideB int, batchCount int)
+}
+
 // Float64 implements the single precision real BLAS routines.
 type Float64 interface {
 	Float64Level1
@@ -172,6 +185,19 @@ type Float64Level3 interface {
 	Dtrsm(s Side, ul Uplo, tA Transpose, d Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int)
 }
 
+// Float64Batched implements batched double precision real BLAS routines that
+// perform the same operation on many independent matrices or vectors with
+// equal dimensions. Implementations of Float64 are not required to implement
+// Float64Batched.
+type Float64Batched interface {
+	DgemvBatched(tA Transpose, m, n int, alpha float64, a [][]float64, lda int, x [][]float64, incX int, beta float64, y [][]float64, incY int)
+	DgemvStridedBatched(tA Transpose, m, n int, alpha float64, a []float64, lda, strideA int, x []float64, incX, strideX int, beta float64, y []float64, incY, strideY int, batchCount int)
+	DgemmBatched(tA, tB Transpose, m, n, k int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int, beta float64, c [][]float64, ldc int)
+	DgemmStridedBatched(tA, tB Transpose, m, n, k int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, beta float64, c []float64, ldc, strideC int, batchCount int)
+	DtrsmBatched(s Side, ul Uplo, tA Transpose, d Diag, m, n int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int)
+	DtrsmStridedBatched(s Side, ul Uplo, tA Transpose, d Diag, m, n int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, batchCount int)
+}
+
 // Complex64 implements the single precision complex BLAS routines.
 type Complex64 interface {
 	Complex64Level1
@@ -0,0 +1,300 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blas32
+
+import "gonum.org/v1/gonum/blas"
+
+// Batched routines
+
+// The batched routines pass the batch to the current implementation if it
+// implements blas.Float32Batched, and otherwise perform the operations one
+// after another.
+
+const (
+	badBatch       = "blas32: mismatched batch shape"
+	badBatchCount  = "blas32: negative batch count"
+	badBatchStride = "blas32: bad batch stride"
+	shortBatch     = "blas32: insufficient batch data"
+)
+
+// GemvBatched computes
+//  y_i = alpha * A_i * x_i + beta * y_i,   if t == blas.NoTrans,
+//  y_i = alpha * A_i^T * x_i + beta * y_i, if t == blas.Trans or blas.ConjTrans,
+// for each i, where A_i = a[i] are m×n dense matrices, x_i = x[i] and y_i = y[i]
+// are vectors, and alpha and beta are scalars. The lengths of a, x and y must be
+// equal, all of the A_i must have the same dimensions and stride, and all of the
+// x_i and y_i must have the same increment.
+//
+// The y_i must not share memory.
+func GemvBatched(t blas.Transpose, alpha float32, a []General, x []Vector, beta float32, y []Vector) {
+	if len(x) != len(a) || len(y) != len(a) {
+		panic(badBatch)
+	}
+	if len(a) == 0 {
+		return
+	}
+	for i := 1; i < len(a); i++ {
+		if !sameShapeGeneral(a[i], a[0]) || !sameShapeVector(x[i], x[0]) || !sameShapeVector(y[i], y[0]) {
+			panic(badBatch)
+		}
+	}
+	bi, ok := blas32.(blas.Float32Batched)
+	if !ok {
+		for i := range a {
+			Gemv(t, alpha, a[i], x[i], beta, y[i])
+		}
+		return
+	}
+	ad := make([][]float32, len(a))
+	xd := make([][]float32, len(a))
+	yd := make([][]float32, len(a))
+	for i := range a {
+		ad[i] = a[i].Data
+		xd[i] = x[i].Data
+		yd[i] = y[i].Data
+	}
+	bi.SgemvBatched(t, a[0].Rows, a[0].Cols, alpha, ad, a[0].Stride, xd, x[0].Inc, beta, yd, y[0].Inc)
+}
+
+// GemvStridedBatched computes
+//  y_i = alpha * A_i * x_i + beta * y_i,   if t == blas.NoTrans,
+//  y_i = alpha * A_i^T * x_i + beta * y_i, if t == blas.Trans or blas.ConjTrans,
+// for each i in [0, batchCount), where A_i is the m×n dense matrix with the
+// dimensions and stride of a stored in a.Data[i*strideA:], x_i and y_i are the
+// vectors with the increment of x and y stored in x.Data[i*strideX:]
+// and y.Data[i*strideY:], and alpha and beta are scalars.
+//
+// The strides must not be negative, and a stride of zero shares A or x among
+// all batch entries. The y_i must not overlap.
+func GemvStridedBatched(t blas.Transpose, alpha float32, a General, strideA int, x Vector, strideX int, beta float32, y Vector, strideY int, batchCount int) {
+	if batchCount < 0 {
+		panic(badBatchCount)
+	}
+	lenX, lenY := a.Cols, a.Rows
+	if t != blas.NoTrans {
+		lenX, lenY = a.Rows, a.Cols
+	}
+	checkStrided(len(a.Data), matLen(a.Rows, a.Cols, a.Stride), strideA, batchCount, false)
+	checkStrided(len(x.Data), vecLen(lenX, x.Inc), strideX, batchCount, false)
+	checkStrided(len(y.Data), vecLen(lenY, y.Inc), strideY, batchCount, true)
+	if batchCount == 0 {
+		return
+	}
+	bi, ok := blas32.(blas.Float32Batched)
+	if !ok {
+		for i := 0; i < batchCount; i++ {
+			blas32.Sgemv(t, a.Rows, a.Cols, alpha, a.Data[i*strideA:], a.Stride, x.Data[i*strideX:], x.Inc, beta, y.Data[i*strideY:], y.Inc)
+		}
+		return
+	}
+	bi.SgemvStridedBatched(t, a.Rows, a.Cols, alpha, a.Data, a.Stride, strideA, x.Data, x.Inc, strideX, beta, y.Data, y.Inc, strideY, batchCount)
+}
+
+// GemmBatched computes
+//  C_i = alpha * A_i * B_i + beta * C_i,
+// for each i, where A_i = a[i], B_i = b[i] and C_i = c[i] are dense matrices,
+// and alpha and beta are scalars. tA and tB specify whether the A_i or the B_i
+// are transposed. The lengths of a, b and c must be equal, and all of the
+// matrices in each of a, b and c must have the same dimensions and stride.
+//
+// The C_i must not share memory.
+func GemmBatched(tA, tB blas.Transpose, alpha float32, a, b []General, beta float32, c []General) {
+	if len(b) != len(a) || len(c) != len(a) {
+		panic(badBatch)
+	}
+	if len(a) == 0 {
+		return
+	}
+	for i := 1; i < len(a); i++ {
+		if !sameShapeGeneral(a[i], a[0]) || !sameShapeGeneral(b[i], b[0]) || !sameShapeGeneral(c[i], c[0]) {
+			panic(badBatch)
+		}
+	}
+	bi, ok := blas32.(blas.Float32Batched)
+	if !ok {
+		for i := range a {
+			Gemm(tA, tB, alpha, a[i], b[i], beta, c[i])
+		}
+		return
+	}
+	m, n, k := gemmDims(tA, tB, a[0], b[0])
+	ad := make([][]float32, len(a))
+	bd := make([][]float32, len(a))
+	cd := make([][]float32, len(a))
+	for i := range a {
+		ad[i] = a[i].Data
+		bd[i] = b[i].Data
+		cd[i] = c[i].Data
+	}
+	bi.SgemmBatched(tA, tB, m, n, k, alpha, ad, a[0].Stride, bd, b[0].Stride, beta, cd, c[0].Stride)
+}
+
+// GemmStridedBatched computes
+//  C_i = alpha * A_i * B_i + beta * C_i,
+// for each i in [0, batchCount), where A_i, B_i and C_i are the dense matrices
+// with the dimensions and strides of a, b and c stored in a.Data[i*strideA:],
+// b.Data[i*strideB:] and c.Data[i*strideC:], and alpha and beta are scalars.
+// tA and tB specify whether the A_i or the B_i are transposed.
+//
+// The strides must not be negative, and a stride of zero shares A or B among
+// all batch entries. The C_i must not overlap.
+func GemmStridedBatched(tA, tB blas.Transpose, alpha float32, a General, strideA int, b General, strideB int, beta float32, c General, strideC int, batchCount int) {
+	if batchCount < 0 {
+		panic(badBatchCount)
+	}
+	checkStrided(len(a.Data), matLen(a.Rows, a.Cols, a.Stride), strideA, batchCount, false)
+	checkStrided(len(b.Data), matLen(b.Rows, b.Cols, b.Stride), strideB, batchCount, false)
+	checkStrided(len(c.Data), matLen(c.Rows, c.Cols, c.Stride), strideC, batchCount, true)
+	if batchCount == 0 {
+		return
+	}
+	m, n, k := gemmDims(tA, tB, a, b)
+	bi, ok := blas32.(blas.Float32Batched)
+	if !ok {
+		for i := 0; i < batchCount; i++ {
+			blas32.Sgemm(tA, tB, m, n, k, alpha, a.Data[i*strideA:], a.Stride, b.Data[i*strideB:], b.Stride, beta, c.Data[i*strideC:], c.Stride)
+		}
+		return
+	}
+	bi.SgemmStridedBatched(tA, tB, m, n, k, alpha, a.Data, a.Stride, strideA, b.Data, b.Stride, strideB, beta, c.Data, c.Stride, strideC, batchCount)
+}
+
+// TrsmBatched solves
+//  A_i * X_i = alpha * B_i,   if tA == blas.NoTrans and s == blas.Left,
+//  A_i^T * X_i = alpha * B_i, if tA == blas.Trans or blas.ConjTrans, and s == blas.Left,
+//  X_i * A_i = alpha * B_i,   if tA == blas.NoTrans and s == blas.Right,
+//  X_i * A_i^T = alpha * B_i, if tA == blas.Trans or blas.ConjTrans, and s == blas.Right,
+// for each i, where A_i = a[i] are n×n or m×m triangular matrices, X_i and
+// B_i = b[i] are m×n matrices, and alpha is a scalar. The lengths of a and b
+// must be equal, all of the A_i must have the same triangle, diagonal kind,
+// order and stride, and all of the B_i must have the same dimensions and
+// stride.
+//
+// At entry to the function, X_i contains the values of B_i, and the result is
+// stored in-place into X_i. The B_i must not share memory.
+//
+// No check is made that the A_i are invertible.
+func TrsmBatched(s blas.Side, tA blas.Transpose, alpha float32, a []Triangular, b []General) {
+	if len(b) != len(a) {
+		panic(badBatch)
+	}
+	if len(a) == 0 {
+		return
+	}
+	for i := 1; i < len(a); i++ {
+		if !sameShapeTriangular(a[i], a[0]) || !sameShapeGeneral(b[i], b[0]) {
+			panic(badBatch)
+		}
+	}
+	bi, ok := blas32.(blas.Float32Batched)
+	if !ok {
+		for i := range a {
+			Trsm(s, tA, alpha, a[i], b[i])
+		}
+		return
+	}
+	ad := make([][]float32, len(a))
+	bd := make([][]float32, len(a))
+	for i := range a {
+		ad[i] = a[i].Data
+		bd[i] = b[i].Data
+	}
+	bi.StrsmBatched(s, a[0].Uplo, tA, a[0].Diag, b[0].Rows, b[0].Cols, alpha, ad, a[0].Stride, bd, b[0].Stride)
+}
+
+// TrsmStridedBatched solves
+//  A_i * X_i = alpha * B_i,   if tA == blas.NoTrans and s == blas.Left,
+//  A_i^T * X_i = alpha * B_i, if tA == blas.Trans or blas.ConjTrans, and s == blas.Left,
+//  X_i * A_i = alpha * B_i,   if tA == blas.NoTrans and s == blas.Right,
+//  X_i * A_i^T = alpha * B_i, if tA == blas.Trans or blas.ConjTrans, and s == blas.Right,
+// for each i in [0, batchCount), where A_i is the triangular matrix with the
+// triangle, diagonal kind, order and stride of a stored in a.Data[i*strideA:],
+// X_i and B_i are the matrices with the dimensions and stride of b stored in
+// b.Data[i*strideB:], and alpha is a scalar.
+//
+// At entry to the function, X_i contains the values of B_i, and the result is
+// stored in-place into X_i. The strides must not be negative, and a stride of
+// zero shares A among all batch entries. The B_i must not overlap.
+//
+// No check is made that the A_i are invertible.
+func TrsmStridedBatched(s blas.Side, tA blas.Transpose, alpha float32, a Triangular, strideA int, b General, strideB int, batchCount int) {
+	if batchCount < 0 {
+		panic(badBatchCount)
+	}
+	checkStrided(len(a.Data), matLen(a.N, a.N, a.Stride), strideA, batchCount, false)
+	checkStrided(len(b.Data), matLen(b.Rows, b.Cols, b.Stride), strideB, batchCount, true)
+	if batchCount == 0 {
+		return
+	}
+	bi, ok := blas32.(blas.Float32Batched)
+	if !ok {
+		for i := 0; i < batchCount; i++ {
+			blas32.Strsm(s, a.Uplo, tA, a.Diag, b.Rows, b.Cols, alpha, a.Data[i*strideA:], a.Stride, b.Data[i*strideB:], b.Stride)
+		}
+		return
+	}
+	bi.StrsmStridedBatched(s, a.Uplo, tA, a.Diag, b.Rows, b.Cols, alpha, a.Data, a.Stride, strideA, b.Data, b.Stride, strideB, batchCount)
+}
+
+// gemmDims returns the dimensions m, n and k of the product op(a) * op(b).
+func gemmDims(tA, tB blas.Transpose, a, b General) (m, n, k int) {
+	if tA == blas.NoTrans {
+		m, k = a.Rows, a.Cols
+	} else {
+		m, k = a.Cols, a.Rows
+	}
+	if tB == blas.NoTrans {
+		n = b.Cols
+	} else {
+		n = b.Rows
+	}
+	return m, n, k
+}
+
+// checkStrided panics if count entries of size elements stored at intervals of
+// stride do not fit in a slice of length n. Input entries may overlap, so that
+// for example a matrix can be shared by all entries with a zero stride, but
+// output entries must not overlap.
+func checkStrided(n, size, stride, count int, output bool) {
+	if stride < 0 || (output && count > 1 && stride < size) {
+		panic(badBatchStride)
+	}
+	if size > 0 && count > 0 && n < (count-1)*stride+size {
+		panic(shortBatch)
+	}
+}
+
+// matLen returns the minimum length of a slice that stores an r×c matrix with
+// leading dimension ld.
+func matLen(r, c, ld int) int {
+	if r == 0 || c == 0 {
+		return 0
+	}
+	return (r-1)*ld + c
+}
+
+// vecLen returns the minimum length of a slice that stores a vector of n
+// elements with increment inc.
+func vecLen(n, inc int) int {
+	if n == 0 {
+		return 0
+	}
+	if inc < 0 {
+		inc = -inc
+	}
+	return (n-1)*inc + 1
+}
+
+func sameShapeVector(x, y Vector) bool {
+	return x.Inc == y.Inc
+}
+
+func sameShapeGeneral(a, b General) bool {
+	return a.Rows == b.Rows && a.Cols == b.Cols && a.Stride == b.Stride
+}
+
+func sameShapeTriangular(a, b Triangular) bool {
+	return a.Uplo == b.Uplo && a.Diag == b.Diag && a.N == b.N && a.Stride == b.Stride
+}
@@ -0,0 +1,391 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blas32
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/gonum"
+)
+
+// unbatched hides the batched methods of a blas.Float32 implementation so
+// that the batched routines fall back to performing the operations one after
+// another.
+type unbatched struct {
+	blas.Float32
+}
+
+var batchedImpls = []struct {
+	name string
+	impl blas.Float32
+}{
+	{name: "Batched", impl: gonum.Implementation{}},
+	{name: "Unbatched", impl: unbatched{gonum.Implementation{}}},
+}
+
+func randomSlice(rnd *rand.Rand, n int) []float32 {
+	s := make([]float32, n)
+	for i := range s {
+		s[i] = float32(rnd.NormFloat64())
+	}
+	return s
+}
+
+func equalApprox(a, b []float32, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if math.Abs(float64(v-b[i])) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func panics(fn func()) (panicked bool, message string) {
+	defer func() {
+		r := recover()
+		panicked = r != nil
+		message = fmt.Sprint(r)
+	}()
+	fn()
+	return
+}
+
+func TestGemvBatched(t *testing.T) {
+	defer Use(Implementation())
+	rnd := rand.New(rand.NewSource(1))
+	const m, n, lda, count = 3, 4, 5, 4
+	for _, b := range batchedImpls {
+		Use(b.impl)
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			lenX, lenY := n, m
+			if tA != blas.NoTrans {
+				lenX, lenY = m, n
+			}
+			a := make([]General, count)
+			x := make([]Vector, count)
+			y := make([]Vector, count)
+			want := make([][]float32, count)
+			for i := range a {
+				a[i] = General{Rows: m, Cols: n, Stride: lda, Data: randomSlice(rnd, (m-1)*lda+n)}
+				x[i] = Vector{Inc: 2, Data: randomSlice(rnd, 2*lenX-1)}
+				y[i] = Vector{Inc: 1, Data: randomSlice(rnd, lenY)}
+				want[i] = make([]float32, lenY)
+				copy(want[i], y[i].Data)
+				Gemv(tA, 2, a[i], x[i], -0.5, Vector{Inc: 1, Data: want[i]})
+			}
+			GemvBatched(tA, 2, a, x, -0.5, y)
+			for i := range y {
+				if !equalApprox(y[i].Data, want[i], 1e-5) {
+					t.Errorf("%s: unexpected result for tA=%c, entry %d: got %v, want %v", b.name, tA, i, y[i].Data, want[i])
+				}
+			}
+
+			// Store the same batch contiguously, with the x shared by all
+			// entries.
+			const strideA, strideY = m * lda, 5
+			sa := General{Rows: m, Cols: n, Stride: lda, Data: make([]float32, (count-1)*strideA+(m-1)*lda+n)}
+			sy := Vector{Inc: 1, Data: make([]float32, (count-1)*strideY+lenY)}
+			for i := 0; i < count; i++ {
+				copy(sa.Data[i*strideA:], a[i].Data)
+				copy(want[i], sy.Data[i*strideY:i*strideY+lenY])
+				Gemv(tA, 2, a[i], x[0], -0.5, Vector{Inc: 1, Data: want[i]})
+			}
+			GemvStridedBatched(tA, 2, sa, strideA, x[0], 0, -0.5, sy, strideY, count)
+			for i := 0; i < count; i++ {
+				got := sy.Data[i*strideY : i*strideY+lenY]
+				if !equalApprox(got, want[i], 1e-5) {
+					t.Errorf("%s: unexpected strided result for tA=%c, entry %d: got %v, want %v", b.name, tA, i, got, want[i])
+				}
+			}
+		}
+	}
+}
+
+func TestGemmBatched(t *testing.T) {
+	defer Use(Implementation())
+	rnd := rand.New(rand.NewSource(1))
+	const m, n, k, count = 3, 4, 2, 4
+	for _, b := range batchedImpls {
+		Use(b.impl)
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				ra, ca := m, k
+				if tA != blas.NoTrans {
+					ra, ca = k, m
+				}
+				rb, cb := k, n
+				if tB != blas.NoTrans {
+					rb, cb = n, k
+				}
+				a := make([]General, count)
+				bm := make([]General, count)
+				c := make([]General, count)
+				want := make([]General, count)
+				for i := range a {
+					a[i] = General{Rows: ra, Cols: ca, Stride: ca, Data: randomSlice(rnd, ra*ca)}
+					bm[i] = General{Rows: rb, Cols: cb, Stride: cb + 1, Data: randomSlice(rnd, (rb-1)*(cb+1)+cb)}
+					c[i] = General{Rows: m, Cols: n, Stride: n, Data: randomSlice(rnd, m*n)}
+					want[i] = General{Rows: m, Cols: n, Stride: n, Data: make([]float32, m*n)}
+					copy(want[i].Data, c[i].Data)
+					Gemm(tA, tB, 2, a[i], bm[i], -0.5, want[i])
+				}
+				GemmBatched(tA, tB, 2, a, bm, -0.5, c)
+				for i := range c {
+					if !equalApprox(c[i].Data, want[i].Data, 1e-5) {
+						t.Errorf("%s: unexpected result for tA=%c, tB=%c, entry %d: got %v, want %v", b.name, tA, tB, i, c[i].Data, want[i].Data)
+					}
+				}
+
+				// Store the same batch contiguously, with the B shared by
+				// all entries.
+				const strideC = m*n + 3
+				strideA := ra * ca
+				sa := General{Rows: ra, Cols: ca, Stride: ca, Data: make([]float32, count*strideA)}
+				sc := General{Rows: m, Cols: n, Stride: n, Data: make([]float32, (count-1)*strideC+m*n)}
+				for i := 0; i < count; i++ {
+					copy(sa.Data[i*strideA:], a[i].Data)
+					copy(want[i].Data, sc.Data[i*strideC:i*strideC+m*n])
+					Gemm(tA, tB, 2, a[i], bm[0], -0.5, want[i])
+				}
+				GemmStridedBatched(tA, tB, 2, sa, strideA, bm[0], 0, -0.5, sc, strideC, count)
+				for i := 0; i < count; i++ {
+					got := sc.Data[i*strideC : i*strideC+m*n]
+					if !equalApprox(got, want[i].Data, 1e-5) {
+						t.Errorf("%s: unexpected strided result for tA=%c, tB=%c, entry %d: got %v, want %v", b.name, tA, tB, i, got, want[i].Data)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestTrsmBatched(t *testing.T) {
+	defer Use(Implementation())
+	rnd := rand.New(rand.NewSource(1))
+	const m, n, count = 3, 4, 4
+	for _, b := range batchedImpls {
+		Use(b.impl)
+		for _, s := range []blas.Side{blas.Left, blas.Right} {
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					k := n
+					if s == blas.Left {
+						k = m
+					}
+					a := make([]Triangular, count)
+					bm := make([]General, count)
+					want := make([]General, count)
+					for i := range a {
+						a[i] = Triangular{Uplo: ul, Diag: blas.NonUnit, N: k, Stride: k, Data: randomSlice(rnd, k*k)}
+						for j := 0; j < k; j++ {
+							a[i].Data[j*k+j] += 4
+						}
+						bm[i] = General{Rows: m, Cols: n, Stride: n, Data: randomSlice(rnd, m*n)}
+						want[i] = General{Rows: m, Cols: n, Stride: n, Data: make([]float32, m*n)}
+						copy(want[i].Data, bm[i].Data)
+						Trsm(s, tA, 2, a[i], want[i])
+					}
+					TrsmBatched(s, tA, 2, a, bm)
+					for i := range bm {
+						if !equalApprox(bm[i].Data, want[i].Data, 1e-5) {
+							t.Errorf("%s: unexpected result for s=%c, ul=%c, tA=%c, entry %d: got %v, want %v", b.name, s, ul, tA, i, bm[i].Data, want[i].Data)
+						}
+					}
+
+					// Store the same batch contiguously.
+					strideA, strideB := k*k, m*n+1
+					sa := Triangular{Uplo: ul, Diag: blas.NonUnit, N: k, Stride: k, Data: make([]float32, count*strideA)}
+					sb := General{Rows: m, Cols: n, Stride: n, Data: make([]float32, (count-1)*strideB+m*n)}
+					for i := 0; i < count; i++ {
+						copy(sa.Data[i*strideA:], a[i].Data)
+						copy(sb.Data[i*strideB:], bm[i].Data)
+						copy(want[i].Data, bm[i].Data)
+						Trsm(s, tA, 2, a[i], want[i])
+					}
+					TrsmStridedBatched(s, tA, 2, sa, strideA, sb, strideB, count)
+					for i := 0; i < count; i++ {
+						got := sb.Data[i*strideB : i*strideB+m*n]
+						if !equalApprox(got, want[i].Data, 1e-5) {
+							t.Errorf("%s: unexpected strided result for s=%c, ul=%c, tA=%c, entry %d: got %v, want %v", b.name, s, ul, tA, i, got, want[i].Data)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBatchedPanics(t *testing.T) {
+	defer Use(Implementation())
+
+	a := General{Rows: 2, Cols: 3, Stride: 3, Data: make([]float32, 6)}
+	aSq := General{Rows: 2, Cols: 2, Stride: 2, Data: make([]float32, 4)}
+	aWide := General{Rows: 2, Cols: 3, Stride: 4, Data: make([]float32, 7)}
+	c := General{Rows: 2, Cols: 2, Stride: 2, Data: make([]float32, 4)}
+	tri := Triangular{Uplo: blas.Upper, Diag: blas.Unit, N: 2, Stride: 2, Data: make([]float32, 4)}
+	triLower := Triangular{Uplo: blas.Lower, Diag: blas.Unit, N: 2, Stride: 2, Data: make([]float32, 4)}
+	x := Vector{Inc: 1, Data: make([]float32, 3)}
+	xInc := Vector{Inc: 2, Data: make([]float32, 5)}
+	y := Vector{Inc: 1, Data: make([]float32, 2)}
+
+	for _, b := range batchedImpls {
+		Use(b.impl)
+		for _, test := range []struct {
+			name string
+			fn   func()
+			want string
+		}{
+			// The slice-based routines require a uniform batch.
+			{
+				name: "GemvBatched length",
+				fn:   func() { GemvBatched(blas.NoTrans, 1, []General{a, a}, []Vector{x}, 0, []Vector{y, y}) },
+				want: badBatch,
+			},
+			{
+				name: "GemvBatched stride",
+				fn:   func() { GemvBatched(blas.NoTrans, 1, []General{a, aWide}, []Vector{x, x}, 0, []Vector{y, y}) },
+				want: badBatch,
+			},
+			{
+				name: "GemvBatched increment",
+				fn:   func() { GemvBatched(blas.NoTrans, 1, []General{a, a}, []Vector{x, xInc}, 0, []Vector{y, y}) },
+				want: badBatch,
+			},
+			{
+				name: "GemmBatched length",
+				fn:   func() { GemmBatched(blas.NoTrans, blas.Trans, 1, []General{a}, []General{a, a}, 0, []General{c}) },
+				want: badBatch,
+			},
+			{
+				name: "GemmBatched shape",
+				fn: func() {
+					GemmBatched(blas.NoTrans, blas.Trans, 1, []General{a, a}, []General{a, aWide}, 0, []General{c, c})
+				},
+				want: badBatch,
+			},
+			{
+				name: "TrsmBatched length",
+				fn:   func() { TrsmBatched(blas.Left, blas.NoTrans, 1, []Triangular{tri, tri}, []General{c}) },
+				want: badBatch,
+			},
+			{
+				name: "TrsmBatched uplo",
+				fn:   func() { TrsmBatched(blas.Left, blas.NoTrans, 1, []Triangular{tri, triLower}, []General{c, c}) },
+				want: badBatch,
+			},
+
+			// The strided routines require valid strides and enough data
+			// for the whole batch.
+			{
+				name: "GemvStridedBatched count",
+				fn:   func() { GemvStridedBatched(blas.NoTrans, 1, a, 6, x, 3, 0, y, 2, -1) },
+				want: badBatchCount,
+			},
+			{
+				name: "GemvStridedBatched negative stride",
+				fn:   func() { GemvStridedBatched(blas.NoTrans, 1, a, -6, x, 3, 0, y, 2, 1) },
+				want: badBatchStride,
+			},
+			{
+				name: "GemvStridedBatched overlapping output",
+				fn:   func() { GemvStridedBatched(blas.NoTrans, 1, a, 0, x, 0, 0, y, 1, 2) },
+				want: badBatchStride,
+			},
+			{
+				name: "GemvStridedBatched short A",
+				fn: func() {
+					GemvStridedBatched(blas.NoTrans, 1, a, 6, x, 0, 0, Vector{Inc: 1, Data: make([]float32, 4)}, 2, 2)
+				},
+				want: shortBatch,
+			},
+			{
+				name: "GemvStridedBatched short y",
+				fn:   func() { GemvStridedBatched(blas.NoTrans, 1, a, 0, x, 0, 0, y, 2, 2) },
+				want: shortBatch,
+			},
+			{
+				name: "GemmStridedBatched count",
+				fn:   func() { GemmStridedBatched(blas.NoTrans, blas.Trans, 1, a, 0, a, 0, 0, c, 4, -1) },
+				want: badBatchCount,
+			},
+			{
+				name: "GemmStridedBatched overlapping output",
+				fn: func() {
+					GemmStridedBatched(blas.NoTrans, blas.Trans, 1, a, 0, a, 0, 0, General{Rows: 2, Cols: 2, Stride: 2, Data: make([]float32, 8)}, 2, 2)
+				},
+				want: badBatchStride,
+			},
+			{
+				name: "GemmStridedBatched short B",
+				fn: func() {
+					GemmStridedBatched(blas.NoTrans, blas.Trans, 1, a, 0, a, 6, 0, General{Rows: 2, Cols: 2, Stride: 2, Data: make([]float32, 8)}, 4, 2)
+				},
+				want: shortBatch,
+			},
+			{
+				name: "TrsmStridedBatched negative stride",
+				fn:   func() { TrsmStridedBatched(blas.Left, blas.NoTrans, 1, tri, 0, aSq, -4, 1) },
+				want: badBatchStride,
+			},
+			{
+				name: "TrsmStridedBatched short A",
+				fn: func() {
+					TrsmStridedBatched(blas.Left, blas.NoTrans, 1, tri, 4, General{Rows: 2, Cols: 2, Stride: 2, Data: make([]float32, 8)}, 4, 2)
+				},
+				want: shortBatch,
+			},
+		} {
+			panicked, msg := panics(test.fn)
+			if !panicked || msg != test.want {
+				t.Errorf("%s: %s: unexpected panic: got %q, want %q", b.name, test.name, msg, test.want)
+			}
+		}
+	}
+}
+
+func TestBatchedEmpty(t *testing.T) {
+	defer Use(Implementation())
+	for _, b := range batchedImpls {
+		Use(b.impl)
+		for _, test := range []struct {
+			name string
+			fn   func()
+		}{
+			{name: "GemvBatched", fn: func() { GemvBatched(blas.NoTrans, 1, nil, nil, 0, nil) }},
+			{name: "GemmBatched", fn: func() { GemmBatched(blas.NoTrans, blas.NoTrans, 1, nil, nil, 0, nil) }},
+			{name: "TrsmBatched", fn: func() { TrsmBatched(blas.Left, blas.NoTrans, 1, nil, nil) }},
+			{
+				name: "GemvStridedBatched",
+				fn: func() {
+					GemvStridedBatched(blas.NoTrans, 1, General{Rows: 2, Cols: 3, Stride: 3}, 6, Vector{Inc: 1}, 3, 0, Vector{Inc: 1}, 2, 0)
+				},
+			},
+			{
+				name: "GemmStridedBatched",
+				fn: func() {
+					GemmStridedBatched(blas.NoTrans, blas.NoTrans, 1, General{Rows: 2, Cols: 2, Stride: 2}, 4, General{Rows: 2, Cols: 2, Stride: 2}, 4, 0, General{Rows: 2, Cols: 2, Stride: 2}, 4, 0)
+				},
+			},
+			{
+				name: "TrsmStridedBatched",
+				fn: func() {
+					TrsmStridedBatched(blas.Left, blas.NoTrans, 1, Triangular{Uplo: blas.Upper, Diag: blas.Unit, N: 2, Stride: 2}, 4, General{Rows: 2, Cols: 2, Stride: 2}, 4, 0)
+				},
+			},
+		} {
+			if panicked, msg := panics(test.fn); panicked {
+				t.Errorf("%s: %s: unexpected panic for empty batch: %s", b.name, test.name, msg)
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"sync"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// forBatch calls fn(i) for each i in [0, count). The calls are distributed in
+// contiguous ranges of i over at most maxWorkers() goroutines, so that a batch of
+// many small independent operations is computed concurrently instead of
+// parallelizing each of the operations.
+func forBatch(count int, fn func(i int)) {
+	nWorkers := min(maxWorkers(), count)
+	if nWorkers < 2 {
+		for i := 0; i < count; i++ {
+			fn(i)
+		}
+		return
+	}
+	chunk := blocks(count, nWorkers)
+	var wg sync.WaitGroup
+	for lo := 0; lo < count; lo += chunk {
+		hi := min(lo+chunk, count)
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			for i := lo; i < hi; i++ {
+				fn(i)
+			}
+		}(lo, hi)
+	}
+	wg.Wait()
+}
+
+// checkGemvBatch checks the parameters shared by all entries of a batched
+// Gemv and returns the lengths of the vectors x and y.
+func checkGemvBatch(tA blas.Transpose, m, n, lda, incX, incY int) (lenX, lenY int) {
+	switch {
+	case tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans:
+		panic(badTranspose)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case incX == 0:
+		panic(zeroIncX)
+	case incY == 0:
+		panic(zeroIncY)
+	}
+	if tA == blas.NoTrans {
+		return n, m
+	}
+	return m, n
+}
+
+// checkGemmBatch checks the parameters shared by all entries of a batched
+// Gemm and returns the dimensions of the matrices A and B as they are stored.
+func checkGemmBatch(tA, tB blas.Transpose, m, n, k, lda, ldb, ldc int) (rowA, colA, rowB, colB int) {
+	switch tA {
+	default:
+		panic(badTranspose)
+	case blas.NoTrans, blas.Trans, blas.ConjTrans:
+	}
+	switch tB {
+	default:
+		panic(badTranspose)
+	case blas.NoTrans, blas.Trans, blas.ConjTrans:
+	}
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case k < 0:
+		panic(kLT0)
+	}
+	rowA, colA = m, k
+	if tA != blas.NoTrans {
+		rowA, colA = k, m
+	}
+	rowB, colB = k, n
+	if tB != blas.NoTrans {
+		rowB, colB = n, k
+	}
+	switch {
+	case lda < max(1, colA):
+		panic(badLdA)
+	case ldb < max(1, colB):
+		panic(badLdB)
+	case ldc < max(1, n):
+		panic(badLdC)
+	}
+	return rowA, colA, rowB, colB
+}
+
+// checkTrsmBatch checks the parameters shared by all entries of a batched
+// Trsm and returns the order of the triangular matrix A.
+func checkTrsmBatch(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n, lda, ldb int) (k int) {
+	switch {
+	case s != blas.Left && s != blas.Right:
+		panic(badSide)
+	case ul != blas.Lower && ul != blas.Upper:
+		panic(badUplo)
+	case tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans:
+		panic(badTranspose)
+	case d != blas.NonUnit && d != blas.Unit:
+		panic(badDiag)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	}
+	k = n
+	if s == blas.Left {
+		k = m
+	}
+	switch {
+	case lda < max(1, k):
+		panic(badLdA)
+	case ldb < max(1, n):
+		panic(badLdB)
+	}
+	return k
+}
+
+// checkBatchStride panics with msg if stride is not a valid distance between
+// the consecutive entries of a batch of count entries that each occupy size
+// elements. Input entries may overlap, so that for example a matrix can be
+// shared by all entries with a zero stride, but output entries must not
+// overlap since they are written concurrently.
+func checkBatchStride(stride, size, count int, output bool, msg string) {
+	if stride < 0 || (output && count > 1 && stride < size) {
+		panic(msg)
+	}
+}
+
+// matLen returns the minimum length of a slice that stores an r×c matrix with
+// leading dimension ld.
+func matLen(r, c, ld int) int {
+	if r == 0 || c == 0 {
+		return 0
+	}
+	return (r-1)*ld + c
+}
+
+// vecLen returns the minimum length of a slice that stores a vector of n
+// elements with increment inc.
+func vecLen(n, inc int) int {
+	if n == 0 {
+		return 0
+	}
+	if inc < 0 {
+		inc = -inc
+	}
+	return (n-1)*inc + 1
+}
+
+// stridedLen returns the minimum length of a slice that stores count entries
+// of size elements at intervals of stride.
+func stridedLen(size, stride, count int) int {
+	if size == 0 || count == 0 {
+		return 0
+	}
+	return (count-1)*stride + size
+}
@@ -0,0 +1,296 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+)
+
+var _ blas.Float64Batched = Implementation{}
+
+// DgemvBatched computes
+//  y_i = alpha * A_i * x_i + beta * y_i    if tA = blas.NoTrans
+//  y_i = alpha * A_i^T * x_i + beta * y_i  if tA = blas.Trans or blas.ConjTrans
+// for each i in [0, len(a)), where A_i is the m×n dense matrix stored in a[i],
+// x_i and y_i are the vectors stored in x[i] and y[i], and alpha and beta are
+// scalars. The lengths of a, x and y must be equal.
+//
+// The batch entries are computed concurrently, so the y_i must not share
+// memory.
+func (Implementation) DgemvBatched(tA blas.Transpose, m, n int, alpha float64, a [][]float64, lda int, x [][]float64, incX int, beta float64, y [][]float64, incY int) {
+	lenX, lenY := checkGemvBatch(tA, m, n, lda, incX, incY)
+	if len(x) != len(a) || len(y) != len(a) {
+		panic(badBatchLen)
+	}
+
+	// Quick return if possible.
+	if len(a) == 0 || m == 0 || n == 0 {
+		return
+	}
+
+	for i := range a {
+		switch {
+		case len(a[i]) < matLen(m, n, lda):
+			panic(shortA)
+		case len(x[i]) < vecLen(lenX, incX):
+			panic(shortX)
+		case len(y[i]) < vecLen(lenY, incY):
+			panic(shortY)
+		}
+	}
+
+	// Quick return if possible.
+	if alpha == 0 && beta == 1 {
+		return
+	}
+
+	forBatch(len(a), func(i int) {
+		Implementation{}.Dgemv(tA, m, n, alpha, a[i], lda, x[i], incX, beta, y[i], incY)
+	})
+}
+
+// DgemvStridedBatched computes
+//  y_i = alpha * A_i * x_i + beta * y_i    if tA = blas.NoTrans
+//  y_i = alpha * A_i^T * x_i + beta * y_i  if tA = blas.Trans or blas.ConjTrans
+// for each i in [0, batchCount), where A_i is the m×n dense matrix stored in
+// a[i*strideA:], x_i and y_i are the vectors stored in x[i*strideX:] and
+// y[i*strideY:], and alpha and beta are scalars.
+//
+// The strides must not be negative. A stride of zero shares the matrix or the
+// vector x among all batch entries. The batch entries are computed
+// concurrently, so strideY must be large enough that the y_i do not overlap.
+func (Implementation) DgemvStridedBatched(tA blas.Transpose, m, n int, alpha float64, a []float64, lda, strideA int, x []float64, incX, strideX int, beta float64, y []float64, incY, strideY int, batchCount int) {
+	lenX, lenY := checkGemvBatch(tA, m, n, lda, incX, incY)
+	if batchCount < 0 {
+		panic(batchCountLT0)
+	}
+	sizeA := matLen(m, n, lda)
+	sizeX := vecLen(lenX, incX)
+	sizeY := vecLen(lenY, incY)
+	checkBatchStride(strideA, sizeA, batchCount, false, badStrideA)
+	checkBatchStride(strideX, sizeX, batchCount, false, badStrideX)
+	checkBatchStride(strideY, sizeY, batchCount, true, badStrideY)
+
+	// Quick return if possible.
+	if batchCount == 0 || m == 0 || n == 0 {
+		return
+	}
+
+	switch {
+	case len(a) < stridedLen(sizeA, strideA, batchCount):
+		panic(shortA)
+	case len(x) < stridedLen(sizeX, strideX, batchCount):
+		panic(shortX)
+	case len(y) < stridedLen(sizeY, strideY, batchCount):
+		panic(shortY)
+	}
+
+	// Quick return if possible.
+	if alpha == 0 && beta == 1 {
+		return
+	}
+
+	forBatch(batchCount, func(i int) {
+		Implementation{}.Dgemv(tA, m, n, alpha, a[i*strideA:], lda, x[i*strideX:], incX, beta, y[i*strideY:], incY)
+	})
+}
+
+// DgemmBatched computes
+//  C_i = alpha * op(A_i) * op(B_i) + beta * C_i
+// for each i in [0, len(a)), where op(X) is one of
+//  op(X) = X  or  op(X) = X^T,
+// A_i, B_i and C_i are the dense matrices stored in a[i], b[i] and c[i] with
+// op(A_i) an m×k matrix, op(B_i) a k×n matrix and C_i an m×n matrix, and alpha
+// and beta are scalars. The lengths of a, b and c must be equal.
+//
+// The batch entries are computed concurrently and each product is computed
+// serially, so the C_i must not share memory.
+func (Implementation) DgemmBatched(tA, tB blas.Transpose, m, n, k int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int, beta float64, c [][]float64, ldc int) {
+	rowA, colA, rowB, colB := checkGemmBatch(tA, tB, m, n, k, lda, ldb, ldc)
+	if len(b) != len(a) || len(c) != len(a) {
+		panic(badBatchLen)
+	}
+
+	// Quick return if possible.
+	if len(a) == 0 || m == 0 || n == 0 {
+		return
+	}
+
+	for i := range a {
+		switch {
+		case len(a[i]) < matLen(rowA, colA, lda):
+			panic(shortA)
+		case len(b[i]) < matLen(rowB, colB, ldb):
+			panic(shortB)
+		case len(c[i]) < matLen(m, n, ldc):
+			panic(shortC)
+		}
+	}
+
+	// Quick return if possible.
+	if (alpha == 0 || k == 0) && beta == 1 {
+		return
+	}
+
+	aTrans := tA != blas.NoTrans
+	bTrans := tB != blas.NoTrans
+	forBatch(len(a), func(i int) {
+		dgemmBatchEntry(aTrans, bTrans, m, n, k, alpha, a[i], lda, b[i], ldb, beta, c[i], ldc)
+	})
+}
+
+// DgemmStridedBatched computes
+//  C_i = alpha * op(A_i) * op(B_i) + beta * C_i
+// for each i in [0, batchCount), where op(X) is one of
+//  op(X) = X  or  op(X) = X^T,
+// A_i, B_i and C_i are the dense matrices stored in a[i*strideA:],
+// b[i*strideB:] and c[i*strideC:] with op(A_i) an m×k matrix, op(B_i) a k×n
+// matrix and C_i an m×n matrix, and alpha and beta are scalars.
+//
+// The strides must not be negative. A stride of zero shares the matrix A or B
+// among all batch entries. The batch entries are computed concurrently and each
+// product is computed serially, so strideC must be large enough that the C_i
+// do not overlap.
+func (Implementation) DgemmStridedBatched(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, beta float64, c []float64, ldc, strideC int, batchCount int) {
+	rowA, colA, rowB, colB := checkGemmBatch(tA, tB, m, n, k, lda, ldb, ldc)
+	if batchCount < 0 {
+		panic(batchCountLT0)
+	}
+	sizeA := matLen(rowA, colA, lda)
+	sizeB := matLen(rowB, colB, ldb)
+	sizeC := matLen(m, n, ldc)
+	checkBatchStride(strideA, sizeA, batchCount, false, badStrideA)
+	checkBatchStride(strideB, sizeB, batchCount, false, badStrideB)
+	checkBatchStride(strideC, sizeC, batchCount, true, badStrideC)
+
+	// Quick return if possible.
+	if batchCount == 0 || m == 0 || n == 0 {
+		return
+	}
+
+	switch {
+	case len(a) < stridedLen(sizeA, strideA, batchCount):
+		panic(shortA)
+	case len(b) < stridedLen(sizeB, strideB, batchCount):
+		panic(shortB)
+	case len(c) < stridedLen(sizeC, strideC, batchCount):
+		panic(shortC)
+	}
+
+	// Quick return if possible.
+	if (alpha == 0 || k == 0) && beta == 1 {
+		return
+	}
+
+	aTrans := tA != blas.NoTrans
+	bTrans := tB != blas.NoTrans
+	forBatch(batchCount, func(i int) {
+		dgemmBatchEntry(aTrans, bTrans, m, n, k, alpha, a[i*strideA:], lda, b[i*strideB:], ldb, beta, c[i*strideC:], ldc)
+	})
+}
+
+// dgemmBatchEntry computes a single product of a batched matrix multiplication
+// serially. The parameters must have been checked by the caller.
+func dgemmBatchEntry(aTrans, bTrans bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	if beta != 1 {
+		for i := 0; i < m; i++ {
+			ctmp := c[i*ldc : i*ldc+n]
+			if beta == 0 {
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+			} else {
+				for j := range ctmp {
+					ctmp[j] *= beta
+				}
+			}
+		}
+	}
+	if alpha == 0 || k == 0 {
+		return
+	}
+	dgemmSerial(aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+}
+
+// DtrsmBatched solves one of the matrix equations
+//  A_i * X_i = alpha * B_i    if tA == blas.NoTrans and side == blas.Left
+//  A_i^T * X_i = alpha * B_i  if tA == blas.Trans or blas.ConjTrans, and side == blas.Left
+//  X_i * A_i = alpha * B_i    if tA == blas.NoTrans and side == blas.Right
+//  X_i * A_i^T = alpha * B_i  if tA == blas.Trans or blas.ConjTrans, and side == blas.Right
+// for each i in [0, len(a)), where A_i is the n×n or m×m triangular matrix
+// stored in a[i], X_i and B_i are m×n matrices stored in b[i], and alpha is a
+// scalar. The lengths of a and b must be equal.
+//
+// At entry to the function, b[i] contains the values of B_i, and the result is
+// stored in-place into b[i]. The batch entries are computed concurrently, so the
+// B_i must not share memory.
+//
+// No check is made that the A_i are invertible.
+func (Implementation) DtrsmBatched(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int) {
+	k := checkTrsmBatch(s, ul, tA, d, m, n, lda, ldb)
+	if len(b) != len(a) {
+		panic(badBatchLen)
+	}
+
+	// Quick return if possible.
+	if len(a) == 0 || m == 0 || n == 0 {
+		return
+	}
+
+	for i := range a {
+		switch {
+		case len(a[i]) < matLen(k, k, lda):
+			panic(shortA)
+		case len(b[i]) < matLen(m, n, ldb):
+			panic(shortB)
+		}
+	}
+
+	forBatch(len(a), func(i int) {
+		Implementation{}.Dtrsm(s, ul, tA, d, m, n, alpha, a[i], lda, b[i], ldb)
+	})
+}
+
+// DtrsmStridedBatched solves one of the matrix equations
+//  A_i * X_i = alpha * B_i    if tA == blas.NoTrans and side == blas.Left
+//  A_i^T * X_i = alpha * B_i  if tA == blas.Trans or blas.ConjTrans, and side == blas.Left
+//  X_i * A_i = alpha * B_i    if tA == blas.NoTrans and side == blas.Right
+//  X_i * A_i^T = alpha * B_i  if tA == blas.Trans or blas.ConjTrans, and side == blas.Right
+// for each i in [0, batchCount), where A_i is the n×n or m×m triangular matrix
+// stored in a[i*strideA:], X_i and B_i are m×n matrices stored in
+// b[i*strideB:], and alpha is a scalar.
+//
+// At entry to the function, b contains the values of the B_i, and the result is
+// stored in-place into b. The strides must not be negative. A stride of zero
+// shares the matrix A among all batch entries. The batch entries are computed
+// concurrently, so strideB must be large enough that the B_i do not overlap.
+//
+// No check is made that the A_i are invertible.
+func (Implementation) DtrsmStridedBatched(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, batchCount int) {
+	k := checkTrsmBatch(s, ul, tA, d, m, n, lda, ldb)
+	if batchCount < 0 {
+		panic(batchCountLT0)
+	}
+	sizeA := matLen(k, k, lda)
+	sizeB := matLen(m, n, ldb)
+	checkBatchStride(strideA, sizeA, batchCount, false, badStrideA)
+	checkBatchStride(strideB, sizeB, batchCount, true, badStrideB)
+
+	// Quick return if possible.
+	if batchCount == 0 || m == 0 || n == 0 {
+		return
+	}
+
+	switch {
+	case len(a) < stridedLen(sizeA, strideA, batchCount):
+		panic(shortA)
+	case len(b) < stridedLen(sizeB, strideB, batchCount):
+		panic(shortB)
+	}
+
+	forBatch(batchCount, func(i int) {
+		Implementation{}.Dtrsm(s, ul, tA, d, m, n, alpha, a[i*strideA:], lda, b[i*strideB:], ldb)
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/testblas"
+)
+
+// The batched routines are tested with one worker and with more workers than
+// the runtime may provide so that the batch is always split over goroutines.
+
+func TestDgemvBatched(t *testing.T) {
+	for _, nw := range []int{1, 3} {
+		func() {
+			defer SetWorkers(SetWorkers(nw))
+			testblas.DgemvBatchedTest(t, impl)
+		}()
+	}
+}
+
+func TestDgemmBatched(t *testing.T) {
+	for _, nw := range []int{1, 3} {
+		func() {
+			defer SetWorkers(SetWorkers(nw))
+			testblas.DgemmBatchedTest(t, impl)
+		}()
+	}
+}
+
+func TestDtrsmBatched(t *testing.T) {
+	for _, nw := range []int{1, 3} {
+		func() {
+			defer SetWorkers(SetWorkers(nw))
+			testblas.DtrsmBatchedTest(t, impl)
+		}()
+	}
+}
+
+func BenchmarkDgemmStridedBatched8(b *testing.B)  { benchmarkDgemmStridedBatched(b, 8, 1000) }
+func BenchmarkDgemmStridedBatched32(b *testing.B) { benchmarkDgemmStridedBatched(b, 32, 1000) }
+func BenchmarkDgemmStridedBatched64(b *testing.B) { benchmarkDgemmStridedBatched(b, 64, 100) }
+
+func BenchmarkDgemmLoop8(b *testing.B)  { benchmarkDgemmLoop(b, 8, 1000) }
+func BenchmarkDgemmLoop32(b *testing.B) { benchmarkDgemmLoop(b, 32, 1000) }
+func BenchmarkDgemmLoop64(b *testing.B) { benchmarkDgemmLoop(b, 64, 100) }
+
+func benchmarkDgemmStridedBatched(b *testing.B, n, count int) {
+	stride := n * n
+	a := make([]float64, count*stride)
+	for i := range a {
+		a[i] = float64(i%7) - 3
+	}
+	c := make([]float64, count*stride)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		impl.DgemmStridedBatched(blas.NoTrans, blas.NoTrans, n, n, n, 1, a, n, stride, a, n, stride, 0, c, n, stride, count)
+	}
+}
+
+func benchmarkDgemmLoop(b *testing.B, n, count int) {
+	stride := n * n
+	a := make([]float64, count*stride)
+	for i := range a {
+		a[i] = float64(i%7) - 3
+	}
+	c := make([]float64, count*stride)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < count; j++ {
+			impl.Dgemm(blas.NoTrans, blas.NoTrans, n, n, n, 1, a[j*stride:], n, a[j*stride:], n, 0, c[j*stride:], n)
+		}
+	}
+}
@@ -5,7 +5,6 @@
 package gonum
 
 import (
-	"runtime"
 	"sync"
 
 	"gonum.org/v1/gonum/blas"
@@ -151,14 +150,15 @@ func dgemmParallel(aTrans, bTrans bool, m, n, k int, a []float64, lda int, b []f
 
 	maxKLen := k
 	parBlocks := blocks(m, blockSize) * blocks(n, blockSize)
-	if parBlocks < minParBlock {
+	nWorkers := maxWorkers()
+	if parBlocks < minParBlock || nWorkers < 2 {
 		// The matrix multiplication is small in the dimensions where it can be
-		// computed concurrently. Just do it in serial.
+		// computed concurrently, or only one worker is allowed. Just do it in
+		// serial.
 		dgemmSerial(aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
 		return
 	}
 
-	nWorkers := runtime.GOMAXPROCS(0)
 	if parBlocks < nWorkers {
 		nWorkers = parBlocks
 	}
@@ -16,6 +16,9 @@ const (
 	kLLT0 = "blas: kL < 0"
 	kULT0 = "blas: kU < 0"
 
+	batchCountLT0 = "blas: batchCount < 0"
+	badBatchLen   = "blas: mismatched batch lengths"
+
 	badUplo      = "blas: illegal triangle"
 	badTranspose = "blas: illegal transpose"
 	badDiag      = "blas: illegal diagonal"
@@ -26,6 +29,12 @@ const (
 	badLdB = "blas: bad leading dimension of B"
 	badLdC = "blas: bad leading dimension of C"
 
+	badStrideA = "blas: bad batch stride of A"
+	badStrideB = "blas: bad batch stride of B"
+	badStrideC = "blas: bad batch stride of C"
+	badStrideX = "blas: bad batch stride of x"
+	badStrideY = "blas: bad batch stride of y"
+
 	shortX  = "blas: insufficient length of x"
 	shortY  = "blas: insufficient length of y"
 	shortAP = "blas: insufficient length of ap"
@@ -8,6 +8,8 @@ package gonum
 
 import (
 	"math"
+	"runtime"
+	"sync/atomic"
 
 	"gonum.org/v1/gonum/internal/math32"
 )
@@ -22,6 +24,48 @@ const (
 	buffMul     = 4  // how big is the buffer relative to the number of workers
 )
 
+// workers is the maximum number of goroutines used by the parallel
+// routines. A value less than 1 means that it has not been set.
+var workers int64
+
+// SetWorkers sets the maximum number of goroutines that are used by the
+// parallel routines of this package, such as Dgemm. If n is less than 1, the
+// setting is cleared and the number of goroutines is limited by
+// runtime.GOMAXPROCS(0), which is the default. Setting n to 1 makes all
+// computations serial.
+//
+// The number of goroutines used by the blocked factorizations in
+// gonum.org/v1/gonum/lapack/gonum is set by SetWorkers in that package.
+//
+// SetWorkers returns the previous setting, so that
+//  defer gonum.SetWorkers(gonum.SetWorkers(n))
+// temporarily changes the number of workers.
+func SetWorkers(n int) int {
+	if n < 1 {
+		n = 0
+	}
+	return int(atomic.SwapInt64(&workers, int64(n)))
+}
+
+// Workers returns the number of workers set by SetWorkers, or zero if it has
+// not been set.
+func Workers() int {
+	n := int(atomic.LoadInt64(&workers))
+	if n < 1 {
+		return 0
+	}
+	return n
+}
+
+// maxWorkers returns the maximum number of goroutines that are used by the
+// parallel routines.
+func maxWorkers() int {
+	if n := Workers(); n > 0 {
+		return n
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
 // subMul is a common type shared by [SD]gemm.
 type subMul struct {
 	i, j int // index of block
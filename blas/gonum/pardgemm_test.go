@@ -5,6 +5,7 @@
 package gonum
 
 import (
+	"runtime"
 	"testing"
 
 	"golang.org/x/exp/rand"
@@ -127,6 +128,30 @@ func TestDgemmParallel(t *testing.T) {
 	}
 }
 
+func TestSetWorkers(t *testing.T) {
+	prev := SetWorkers(3)
+	defer SetWorkers(prev)
+	if got := Workers(); got != 3 {
+		t.Errorf("unexpected number of workers: got %d, want 3", got)
+	}
+	if got := SetWorkers(0); got != 3 {
+		t.Errorf("unexpected previous setting: got %d, want 3", got)
+	}
+	if got := Workers(); got != 0 {
+		t.Errorf("unexpected number of workers after clearing: got %d, want 0", got)
+	}
+	if got, want := maxWorkers(), runtime.GOMAXPROCS(0); got != want {
+		t.Errorf("unexpected default number of workers: got %d, want %d", got, want)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, nw := range []int{1, 3} {
+		SetWorkers(nw)
+		n := blockSize*minParBlock + 7
+		testMatchParallelSerial(t, rnd, nw, blas.NoTrans, blas.Trans, n, n, blockSize+3, 1.5)
+	}
+}
+
 func testMatchParallelSerial(t *testing.T, rnd *rand.Rand, i int, tA, tB blas.Transpose, m, n, k int, alpha float64) {
 	var (
 		rowA, colA int
@@ -0,0 +1,135 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"fmt"
+	"math/cmplx"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+func TestZgemmParallel(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, k int
+	}{
+		{3, 4, 2},
+		{blockSize*2 + 5, 3, 2},
+		{3, blockSize * 2, 2},
+		{2, 3, blockSize*3 - 2},
+		{blockSize * minParBlock, 3, 2},
+		{3, blockSize*minParBlock + 2, blockSize * 3},
+		{blockSize*minParBlock + 1, blockSize * minParBlock, 3},
+		{blockSize + blockSize/2, blockSize + blockSize/2, blockSize + blockSize/2},
+	} {
+		for _, nw := range []int{1, 3} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+				for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+					testZgemmParallel(t, rnd, nw, tA, tB, test.m, test.n, test.k, 2.5-0.5i)
+				}
+			}
+		}
+	}
+}
+
+func testZgemmParallel(t *testing.T, rnd *rand.Rand, nw int, tA, tB blas.Transpose, m, n, k int, alpha complex128) {
+	defer SetWorkers(SetWorkers(nw))
+
+	prefix := fmt.Sprintf("workers=%v,tA=%v,tB=%v,m=%v,n=%v,k=%v", nw, tA, tB, m, n, k)
+
+	rowA, colA := m, k
+	if tA != blas.NoTrans {
+		rowA, colA = k, m
+	}
+	rowB, colB := k, n
+	if tB != blas.NoTrans {
+		rowB, colB = n, k
+	}
+	lda := colA + 3
+	a := randCmplxMat(rowA, colA, lda, rnd)
+	aCopy := make([]complex128, len(a))
+	copy(aCopy, a)
+	ldb := colB + 2
+	b := randCmplxMat(rowB, colB, ldb, rnd)
+	bCopy := make([]complex128, len(b))
+	copy(bCopy, b)
+	ldc := n + 1
+	c := randCmplxMat(m, n, ldc, rnd)
+
+	// Compute the reference result with a naive triple loop.
+	opA := func(i, l int) complex128 {
+		switch tA {
+		case blas.Trans:
+			return a[l*lda+i]
+		case blas.ConjTrans:
+			return cmplx.Conj(a[l*lda+i])
+		}
+		return a[i*lda+l]
+	}
+	opB := func(l, j int) complex128 {
+		switch tB {
+		case blas.Trans:
+			return b[j*ldb+l]
+		case blas.ConjTrans:
+			return cmplx.Conj(b[j*ldb+l])
+		}
+		return b[l*ldb+j]
+	}
+	want := make([]complex128, len(c))
+	copy(want, c)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			var sum complex128
+			for l := 0; l < k; l++ {
+				sum += opA(i, l) * opB(l, j)
+			}
+			want[i*ldc+j] += alpha * sum
+		}
+	}
+
+	zgemmParallel(tA, tB, m, n, k, alpha, a, lda, b, ldb, c, ldc)
+
+	for i, v := range a {
+		if v != aCopy[i] {
+			t.Errorf("%v: a changed during call to zgemmParallel", prefix)
+			break
+		}
+	}
+	for i, v := range b {
+		if v != bCopy[i] {
+			t.Errorf("%v: b changed during call to zgemmParallel", prefix)
+			break
+		}
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if cmplx.Abs(c[i*ldc+j]-want[i*ldc+j]) > 1e-12*float64(k) {
+				t.Errorf("%v: unexpected result at [%v,%v]: got %v, want %v", prefix, i, j, c[i*ldc+j], want[i*ldc+j])
+				return
+			}
+		}
+	}
+	// Elements outside the m×n matrix must not be modified.
+	for i := 0; i < m; i++ {
+		for j := n; j < ldc && i*ldc+j < len(c); j++ {
+			if c[i*ldc+j] != want[i*ldc+j] {
+				t.Errorf("%v: out-of-range write to c at [%v,%v]", prefix, i, j)
+				return
+			}
+		}
+	}
+}
+
+func randCmplxMat(r, c, stride int, rnd *rand.Rand) []complex128 {
+	data := make([]complex128, r*stride+c)
+	for i := range data {
+		data[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+	}
+	return data
+}
@@ -216,3 +216,44 @@ cat level3cmplx128.go \
       -e 's_"gonum.org/v1/gonum/internal/asm/c128"_"gonum.org/v1/gonum/internal/asm/c64"_' \
       -e 's_"math/cmplx"_cmplx "gonum.org/v1/gonum/internal/cmplx64"_' \
 >> level3cmplx64.go
+
+echo Generating cgemm.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > cgemm.go
+cat zgemm.go \
+| gofmt -r 'float64 -> float32' \
+| gofmt -r 'complex128 -> complex64' \
+\
+| gofmt -r 'zgemmParallel -> cgemmParallel' \
+| gofmt -r 'zgemmSerial -> cgemmSerial' \
+| gofmt -r 'zgemmBlock -> cgemmBlock' \
+| gofmt -r 'zpackA -> cpackA' \
+| gofmt -r 'zpackB -> cpackB' \
+\
+| gofmt -r 'c128.GemvTUnitary -> c64.GemvTUnitary' \
+| gofmt -r 'c128.ScalUnitary -> c64.ScalUnitary' \
+\
+| sed -e "s_^\(func (Implementation) \)Z\(.*\)\$_$WARNINGC64\1C\2_" \
+      -e 's_^// Z_// C_' \
+      -e 's_^// z_// c_' \
+      -e 's_"gonum.org/v1/gonum/internal/asm/c128"_"gonum.org/v1/gonum/internal/asm/c64"_' \
+      -e 's_"math/cmplx"_cmplx "gonum.org/v1/gonum/internal/cmplx64"_' \
+>> cgemm.go
+
+# Batched routines.
+
+echo Generating batchedfloat32.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > batchedfloat32.go
+cat batchedfloat64.go \
+| gofmt -r 'blas.Float64Batched -> blas.Float32Batched' \
+\
+| gofmt -r 'float64 -> float32' \
+\
+| gofmt -r 'Implementation{}.Dgemv -> Implementation{}.Sgemv' \
+| gofmt -r 'Implementation{}.Dtrsm -> Implementation{}.Strsm' \
+| gofmt -r 'dgemmBatchEntry -> sgemmBatchEntry' \
+| gofmt -r 'dgemmSerial -> sgemmSerial' \
+\
+| sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNINGF32\1S\2_" \
+      -e 's_^// D_// S_' \
+      -e 's_^// d_// s_' \
+>> batchedfloat32.go
@@ -0,0 +1,248 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+	"sync"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/internal/asm/c128"
+)
+
+// Zgemm performs one of the matrix-matrix operations
+//  C = alpha * op(A) * op(B) + beta * C
+// where op(X) is one of
+//  op(X) = X  or  op(X) = X^T  or  op(X) = X^H,
+// alpha and beta are scalars, and A, B and C are matrices, with op(A) an m×k matrix,
+// op(B) a k×n matrix and C an m×n matrix.
+func (Implementation) Zgemm(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	switch tA {
+	default:
+		panic(badTranspose)
+	case blas.NoTrans, blas.Trans, blas.ConjTrans:
+	}
+	switch tB {
+	default:
+		panic(badTranspose)
+	case blas.NoTrans, blas.Trans, blas.ConjTrans:
+	}
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case k < 0:
+		panic(kLT0)
+	}
+	rowA, colA := m, k
+	if tA != blas.NoTrans {
+		rowA, colA = k, m
+	}
+	if lda < max(1, colA) {
+		panic(badLdA)
+	}
+	rowB, colB := k, n
+	if tB != blas.NoTrans {
+		rowB, colB = n, k
+	}
+	if ldb < max(1, colB) {
+		panic(badLdB)
+	}
+	if ldc < max(1, n) {
+		panic(badLdC)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return
+	}
+
+	// For zero matrix size the following slice length checks are trivially satisfied.
+	if len(a) < (rowA-1)*lda+colA {
+		panic(shortA)
+	}
+	if len(b) < (rowB-1)*ldb+colB {
+		panic(shortB)
+	}
+	if len(c) < (m-1)*ldc+n {
+		panic(shortC)
+	}
+
+	// Quick return if possible.
+	if (alpha == 0 || k == 0) && beta == 1 {
+		return
+	}
+
+	// scale c
+	if beta != 1 {
+		if beta == 0 {
+			for i := 0; i < m; i++ {
+				ctmp := c[i*ldc : i*ldc+n]
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+			}
+		} else {
+			for i := 0; i < m; i++ {
+				c128.ScalUnitary(beta, c[i*ldc:i*ldc+n])
+			}
+		}
+	}
+
+	// Quick return if possible.
+	if alpha == 0 || k == 0 {
+		return
+	}
+
+	zgemmParallel(tA, tB, m, n, k, alpha, a, lda, b, ldb, c, ldc)
+}
+
+func zgemmParallel(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, c []complex128, ldc int) {
+	// zgemmParallel partitions C into blockSize×blockSize blocks in the same
+	// way as dgemmParallel. Each {i, j} block of C is updated by a single
+	// worker sequentially along the k dimension, and all of the {i, j}
+	// blocks are computed concurrently, so that C can be updated in-place
+	// without race conditions.
+	//
+	// Unlike dgemmParallel, the sub-blocks of op(A) and op(B) are copied
+	// into packed panels before they are multiplied. The packing applies
+	// the (conjugate) transposes and the scaling by alpha, so that a single
+	// inner kernel that reads both panels with unit stride is used for all
+	// combinations of tA and tB.
+
+	parBlocks := blocks(m, blockSize) * blocks(n, blockSize)
+	nWorkers := maxWorkers()
+	if parBlocks < minParBlock || nWorkers < 2 {
+		// The matrix multiplication is small in the dimensions where it can be
+		// computed concurrently, or only one worker is allowed. Just do it in
+		// serial.
+		zgemmSerial(tA, tB, m, n, k, alpha, a, lda, b, ldb, c, ldc)
+		return
+	}
+
+	if parBlocks < nWorkers {
+		nWorkers = parBlocks
+	}
+	// There is a tradeoff between the workers having to wait for work
+	// and a large buffer making operations slow.
+	buf := buffMul * nWorkers
+	if buf > parBlocks {
+		buf = parBlocks
+	}
+
+	sendChan := make(chan subMul, buf)
+
+	// Launch workers. A worker receives an {i, j} submatrix of c, and computes
+	// op(A)_ik op(B)_kj for all k storing the result in c_ij. Each worker has
+	// its own packed panels. When the channel is finally closed, it signals to
+	// the waitgroup that it has finished computing.
+	var wg sync.WaitGroup
+	for i := 0; i < nWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ap := make([]complex128, blockSize*blockSize)
+			bp := make([]complex128, blockSize*blockSize)
+			for sub := range sendChan {
+				leni := min(blockSize, m-sub.i)
+				lenj := min(blockSize, n-sub.j)
+				zgemmBlock(tA, tB, sub.i, sub.j, leni, lenj, k, alpha, a, lda, b, ldb, c, ldc, ap, bp)
+			}
+		}()
+	}
+
+	// Send out all of the {i, j} subblocks for computation.
+	for i := 0; i < m; i += blockSize {
+		for j := 0; j < n; j += blockSize {
+			sendChan <- subMul{
+				i: i,
+				j: j,
+			}
+		}
+	}
+	close(sendChan)
+	wg.Wait()
+}
+
+// zgemmSerial is serial matrix multiply
+func zgemmSerial(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, c []complex128, ldc int) {
+	ap := make([]complex128, min(m, blockSize)*min(k, blockSize))
+	bp := make([]complex128, min(k, blockSize)*min(n, blockSize))
+	for i := 0; i < m; i += blockSize {
+		leni := min(blockSize, m-i)
+		for j := 0; j < n; j += blockSize {
+			lenj := min(blockSize, n-j)
+			zgemmBlock(tA, tB, i, j, leni, lenj, k, alpha, a, lda, b, ldb, c, ldc, ap, bp)
+		}
+	}
+}
+
+// zgemmBlock computes
+//  C[i:i+leni, j:j+lenj] += alpha * op(A)[i:i+leni, :] * op(B)[:, j:j+lenj]
+// one block of the k dimension at a time. The blocks of alpha*op(A) and op(B)
+// are packed into ap and bp which must have length at least
+// leni*min(k,blockSize) and min(k,blockSize)*lenj respectively.
+func zgemmBlock(tA, tB blas.Transpose, i, j, leni, lenj, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, c []complex128, ldc int, ap, bp []complex128) {
+	for l := 0; l < k; l += blockSize {
+		lenl := min(blockSize, k-l)
+		zpackA(tA, i, l, leni, lenl, alpha, a, lda, ap)
+		zpackB(tB, l, j, lenl, lenj, b, ldb, bp)
+		for ii := 0; ii < leni; ii++ {
+			ctmp := c[(i+ii)*ldc+j : (i+ii)*ldc+j+lenj]
+			c128.GemvTUnitary(ap[ii*lenl:(ii+1)*lenl], bp, uintptr(lenj), ctmp)
+		}
+	}
+}
+
+// zpackA stores the r×s block alpha*op(A)[i:i+r, l:l+s] into ap in row-major
+// order with stride s.
+func zpackA(tA blas.Transpose, i, l, r, s int, alpha complex128, a []complex128, lda int, ap []complex128) {
+	switch tA {
+	case blas.NoTrans:
+		for ii := 0; ii < r; ii++ {
+			atmp := a[(i+ii)*lda+l : (i+ii)*lda+l+s]
+			ptmp := ap[ii*s : ii*s+s]
+			for ll, v := range atmp {
+				ptmp[ll] = alpha * v
+			}
+		}
+	case blas.Trans:
+		for ll := 0; ll < s; ll++ {
+			for ii, v := range a[(l+ll)*lda+i : (l+ll)*lda+i+r] {
+				ap[ii*s+ll] = alpha * v
+			}
+		}
+	case blas.ConjTrans:
+		for ll := 0; ll < s; ll++ {
+			for ii, v := range a[(l+ll)*lda+i : (l+ll)*lda+i+r] {
+				ap[ii*s+ll] = alpha * cmplx.Conj(v)
+			}
+		}
+	}
+}
+
+// zpackB stores the r×s block op(B)[l:l+r, j:j+s] into bp in row-major order
+// with stride s.
+func zpackB(tB blas.Transpose, l, j, r, s int, b []complex128, ldb int, bp []complex128) {
+	switch tB {
+	case blas.NoTrans:
+		for ll := 0; ll < r; ll++ {
+			copy(bp[ll*s:ll*s+s], b[(l+ll)*ldb+j:(l+ll)*ldb+j+s])
+		}
+	case blas.Trans:
+		for jj := 0; jj < s; jj++ {
+			for ll, v := range b[(j+jj)*ldb+l : (j+jj)*ldb+l+r] {
+				bp[ll*s+jj] = v
+			}
+		}
+	case blas.ConjTrans:
+		for jj := 0; jj < s; jj++ {
+			for ll, v := range b[(j+jj)*ldb+l : (j+jj)*ldb+l+r] {
+				bp[ll*s+jj] = cmplx.Conj(v)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/testblas"
+)
+
+func BenchmarkZgemmSmSmSm(b *testing.B) {
+	testblas.ZgemmBenchmark(b, impl, Sm, Sm, Sm, NT, NT)
+}
+
+func BenchmarkZgemmMedMedMed(b *testing.B) {
+	testblas.ZgemmBenchmark(b, impl, Med, Med, Med, NT, NT)
+}
+
+func BenchmarkZgemmMedLgMed(b *testing.B) {
+	testblas.ZgemmBenchmark(b, impl, Med, Lg, Med, NT, NT)
+}
+
+func BenchmarkZgemmLgLgLg(b *testing.B) {
+	testblas.ZgemmBenchmark(b, impl, Lg, Lg, Lg, NT, NT)
+}
+
+func BenchmarkZgemmMedMedMedTNT(b *testing.B) {
+	testblas.ZgemmBenchmark(b, impl, Med, Med, Med, T, NT)
+}
+
+func BenchmarkZgemmMedMedMedNTT(b *testing.B) {
+	testblas.ZgemmBenchmark(b, impl, Med, Med, Med, NT, T)
+}
+
+func BenchmarkZgemmMedMedMedCC(b *testing.B) {
+	testblas.ZgemmBenchmark(b, impl, Med, Med, Med, blas.ConjTrans, blas.ConjTrans)
+}
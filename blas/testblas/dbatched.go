@@ -0,0 +1,319 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+)
+
+type DgemvBatcheder interface {
+	Dgemver
+	DgemvBatched(tA blas.Transpose, m, n int, alpha float64, a [][]float64, lda int, x [][]float64, incX int, beta float64, y [][]float64, incY int)
+	DgemvStridedBatched(tA blas.Transpose, m, n int, alpha float64, a []float64, lda, strideA int, x []float64, incX, strideX int, beta float64, y []float64, incY, strideY int, batchCount int)
+}
+
+type DgemmBatcheder interface {
+	Dgemmer
+	DgemmBatched(tA, tB blas.Transpose, m, n, k int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int, beta float64, c [][]float64, ldc int)
+	DgemmStridedBatched(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, beta float64, c []float64, ldc, strideC int, batchCount int)
+}
+
+type DtrsmBatcheder interface {
+	Dtrsmer
+	DtrsmBatched(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int)
+	DtrsmStridedBatched(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, batchCount int)
+}
+
+// The batched routines are tested against the results of the corresponding
+// unbatched routine called for each batch entry, which must be equal.
+
+var batchCounts = []int{0, 1, 2, 13}
+
+func DgemvBatchedTest(t *testing.T, impl DgemvBatcheder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, mn := range []struct{ m, n int }{{0, 0}, {0, 3}, {1, 1}, {3, 4}, {8, 8}, {17, 9}} {
+			for _, inc := range []struct{ x, y int }{{1, 1}, {2, -3}} {
+				for _, count := range batchCounts {
+					for _, ab := range []struct{ alpha, beta float64 }{{0, 1}, {0, 0.5}, {1.5, 0}, {-0.5, 2}} {
+						dgemvBatchedTest(t, impl, rnd, tA, mn.m, mn.n, inc.x, inc.y, count, ab.alpha, ab.beta)
+					}
+				}
+			}
+		}
+	}
+
+	// Check that invalid batches are rejected.
+	a := make([]float64, 4)
+	if !panics(func() {
+		impl.DgemvBatched(blas.NoTrans, 2, 2, 1, [][]float64{a}, 2, [][]float64{a, a}, 1, 0, [][]float64{a}, 1)
+	}) {
+		t.Errorf("DgemvBatched did not panic with mismatched batch lengths")
+	}
+	if !panics(func() { impl.DgemvStridedBatched(blas.NoTrans, 2, 2, 1, a, 2, 0, a, 1, 0, 0, a, 1, 1, 2) }) {
+		t.Errorf("DgemvStridedBatched did not panic with overlapping y")
+	}
+}
+
+func dgemvBatchedTest(t *testing.T, impl DgemvBatcheder, rnd *rand.Rand, tA blas.Transpose, m, n, incX, incY, count int, alpha, beta float64) {
+	lenX, lenY := n, m
+	if tA != blas.NoTrans {
+		lenX, lenY = m, n
+	}
+	lda := max(1, n) + 2
+	sizeA := batchMatSize(m, n, lda)
+	sizeX := batchVecSize(lenX, incX)
+	sizeY := batchVecSize(lenY, incY)
+	strideY := sizeY + 2
+
+	for _, strideA := range []int{0, sizeA + 3} {
+		for _, strideX := range []int{0, sizeX + 1} {
+			prefix := fmt.Sprintf("tA=%v,m=%v,n=%v,incX=%v,incY=%v,count=%v,alpha=%v,beta=%v,strideA=%v,strideX=%v",
+				transString(tA), m, n, incX, incY, count, alpha, beta, strideA, strideX)
+
+			a := batchRandom(batchLen(sizeA, strideA, count), rnd)
+			x := batchRandom(batchLen(sizeX, strideX, count), rnd)
+			y := batchRandom(batchLen(sizeY, strideY, count), rnd)
+
+			want := make([]float64, len(y))
+			copy(want, y)
+			for i := 0; i < count; i++ {
+				if m == 0 || n == 0 {
+					break
+				}
+				impl.Dgemv(tA, m, n, alpha, a[i*strideA:], lda, x[i*strideX:], incX, beta, want[i*strideY:], incY)
+			}
+
+			got := make([]float64, len(y))
+			copy(got, y)
+			impl.DgemvStridedBatched(tA, m, n, alpha, a, lda, strideA, x, incX, strideX, beta, got, incY, strideY, count)
+			if !floats.Equal(got, want) {
+				t.Errorf("%v: unexpected result of DgemvStridedBatched", prefix)
+			}
+
+			copy(got, y)
+			impl.DgemvBatched(tA, m, n, alpha, batchViews(a, sizeA, strideA, count), lda,
+				batchViews(x, sizeX, strideX, count), incX, beta, batchViews(got, sizeY, strideY, count), incY)
+			if !floats.Equal(got, want) {
+				t.Errorf("%v: unexpected result of DgemvBatched", prefix)
+			}
+		}
+	}
+}
+
+func DgemmBatchedTest(t *testing.T, impl DgemmBatcheder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, mnk := range []struct{ m, n, k int }{{0, 0, 0}, {0, 2, 3}, {1, 1, 1}, {2, 3, 0}, {3, 4, 5}, {8, 8, 8}, {17, 9, 13}} {
+				for _, count := range batchCounts {
+					for _, ab := range []struct{ alpha, beta float64 }{{0, 1}, {0, 0.5}, {1.5, 0}, {-0.5, 2}} {
+						dgemmBatchedTest(t, impl, rnd, tA, tB, mnk.m, mnk.n, mnk.k, count, ab.alpha, ab.beta)
+					}
+				}
+			}
+		}
+	}
+
+	// Check that invalid batches are rejected.
+	a := make([]float64, 4)
+	if !panics(func() {
+		impl.DgemmBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, [][]float64{a}, 2, [][]float64{a}, 2, 0, nil, 2)
+	}) {
+		t.Errorf("DgemmBatched did not panic with mismatched batch lengths")
+	}
+	if !panics(func() {
+		impl.DgemmStridedBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, a, 2, 0, a, 2, 0, 0, make([]float64, 8), 2, 2, 2)
+	}) {
+		t.Errorf("DgemmStridedBatched did not panic with overlapping C")
+	}
+}
+
+func dgemmBatchedTest(t *testing.T, impl DgemmBatcheder, rnd *rand.Rand, tA, tB blas.Transpose, m, n, k, count int, alpha, beta float64) {
+	rowA, colA := m, k
+	if tA != blas.NoTrans {
+		rowA, colA = k, m
+	}
+	rowB, colB := k, n
+	if tB != blas.NoTrans {
+		rowB, colB = n, k
+	}
+	lda := max(1, colA) + 2
+	ldb := max(1, colB) + 3
+	ldc := max(1, n) + 1
+	sizeA := batchMatSize(rowA, colA, lda)
+	sizeB := batchMatSize(rowB, colB, ldb)
+	sizeC := batchMatSize(m, n, ldc)
+	strideC := sizeC + 2
+
+	for _, strideA := range []int{0, sizeA + 3} {
+		for _, strideB := range []int{0, sizeB + 1} {
+			prefix := fmt.Sprintf("tA=%v,tB=%v,m=%v,n=%v,k=%v,count=%v,alpha=%v,beta=%v,strideA=%v,strideB=%v",
+				transString(tA), transString(tB), m, n, k, count, alpha, beta, strideA, strideB)
+
+			a := batchRandom(batchLen(sizeA, strideA, count), rnd)
+			b := batchRandom(batchLen(sizeB, strideB, count), rnd)
+			c := batchRandom(batchLen(sizeC, strideC, count), rnd)
+
+			want := make([]float64, len(c))
+			copy(want, c)
+			for i := 0; i < count; i++ {
+				if m == 0 || n == 0 {
+					break
+				}
+				impl.Dgemm(tA, tB, m, n, k, alpha, a[i*strideA:], lda, b[i*strideB:], ldb, beta, want[i*strideC:], ldc)
+			}
+
+			got := make([]float64, len(c))
+			copy(got, c)
+			impl.DgemmStridedBatched(tA, tB, m, n, k, alpha, a, lda, strideA, b, ldb, strideB, beta, got, ldc, strideC, count)
+			if !floats.Equal(got, want) {
+				t.Errorf("%v: unexpected result of DgemmStridedBatched", prefix)
+			}
+
+			copy(got, c)
+			impl.DgemmBatched(tA, tB, m, n, k, alpha, batchViews(a, sizeA, strideA, count), lda,
+				batchViews(b, sizeB, strideB, count), ldb, beta, batchViews(got, sizeC, strideC, count), ldc)
+			if !floats.Equal(got, want) {
+				t.Errorf("%v: unexpected result of DgemmBatched", prefix)
+			}
+		}
+	}
+}
+
+func DtrsmBatchedTest(t *testing.T, impl DtrsmBatcheder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, s := range []blas.Side{blas.Left, blas.Right} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+					for _, mn := range []struct{ m, n int }{{0, 0}, {0, 3}, {1, 1}, {3, 4}, {8, 8}, {17, 9}} {
+						for _, count := range batchCounts {
+							for _, alpha := range []float64{0, 1, -1.5} {
+								dtrsmBatchedTest(t, impl, rnd, s, ul, tA, d, mn.m, mn.n, count, alpha)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Check that invalid batches are rejected.
+	a := make([]float64, 4)
+	if !panics(func() {
+		impl.DtrsmBatched(blas.Left, blas.Upper, blas.NoTrans, blas.Unit, 2, 2, 1, [][]float64{a, a}, 2, [][]float64{a}, 2)
+	}) {
+		t.Errorf("DtrsmBatched did not panic with mismatched batch lengths")
+	}
+	if !panics(func() {
+		impl.DtrsmStridedBatched(blas.Left, blas.Upper, blas.NoTrans, blas.Unit, 2, 2, 1, a, 2, 0, a, 2, 0, 2)
+	}) {
+		t.Errorf("DtrsmStridedBatched did not panic with overlapping B")
+	}
+}
+
+func dtrsmBatchedTest(t *testing.T, impl DtrsmBatcheder, rnd *rand.Rand, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n, count int, alpha float64) {
+	k := n
+	if s == blas.Left {
+		k = m
+	}
+	lda := max(1, k) + 2
+	ldb := max(1, n) + 1
+	sizeA := batchMatSize(k, k, lda)
+	sizeB := batchMatSize(m, n, ldb)
+	strideB := sizeB + 2
+
+	for _, strideA := range []int{0, sizeA + 3} {
+		prefix := fmt.Sprintf("s=%v,ul=%v,tA=%v,d=%v,m=%v,n=%v,count=%v,alpha=%v,strideA=%v",
+			sideString(s), uploString(ul), transString(tA), diagString(d), m, n, count, alpha, strideA)
+
+		// Make the triangular matrices well conditioned.
+		a := batchRandom(batchLen(sizeA, strideA, count), rnd)
+		for i := 0; i < count; i++ {
+			for j := 0; j < k; j++ {
+				a[i*strideA+j*lda+j] = math.Copysign(float64(k+1), a[i*strideA+j*lda+j])
+			}
+		}
+		b := batchRandom(batchLen(sizeB, strideB, count), rnd)
+
+		want := make([]float64, len(b))
+		copy(want, b)
+		for i := 0; i < count; i++ {
+			if m == 0 || n == 0 {
+				break
+			}
+			impl.Dtrsm(s, ul, tA, d, m, n, alpha, a[i*strideA:], lda, want[i*strideB:], ldb)
+		}
+
+		got := make([]float64, len(b))
+		copy(got, b)
+		impl.DtrsmStridedBatched(s, ul, tA, d, m, n, alpha, a, lda, strideA, got, ldb, strideB, count)
+		if !floats.Equal(got, want) {
+			t.Errorf("%v: unexpected result of DtrsmStridedBatched", prefix)
+		}
+
+		copy(got, b)
+		impl.DtrsmBatched(s, ul, tA, d, m, n, alpha, batchViews(a, sizeA, strideA, count), lda,
+			batchViews(got, sizeB, strideB, count), ldb)
+		if !floats.Equal(got, want) {
+			t.Errorf("%v: unexpected result of DtrsmBatched", prefix)
+		}
+	}
+}
+
+// batchMatSize returns the number of elements spanned by an r×c matrix with
+// leading dimension ld. The unbatched routines require this length also if
+// the matrix has no columns.
+func batchMatSize(r, c, ld int) int {
+	if r == 0 {
+		return 0
+	}
+	return (r-1)*ld + c
+}
+
+// batchVecSize returns the number of elements spanned by a vector of n
+// elements with increment inc.
+func batchVecSize(n, inc int) int {
+	if n == 0 {
+		return 0
+	}
+	return (n-1)*abs(inc) + 1
+}
+
+// batchLen returns the length of a slice that stores count entries of size
+// elements at intervals of stride, with some trailing padding.
+func batchLen(size, stride, count int) int {
+	if count == 0 {
+		return 1
+	}
+	return (count-1)*stride + size + 3
+}
+
+// batchViews returns the count entries of size elements stored at intervals
+// of stride in s as separate slices.
+func batchViews(s []float64, size, stride, count int) [][]float64 {
+	v := make([][]float64, count)
+	for i := range v {
+		v[i] = s[i*stride : i*stride+size]
+	}
+	return v
+}
+
+// batchRandom returns a slice of n random elements.
+func batchRandom(n int, rnd *rand.Rand) []float64 {
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = rnd.NormFloat64()
+	}
+	return s
+}
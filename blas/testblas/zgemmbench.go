@@ -0,0 +1,44 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+func ZgemmBenchmark(b *testing.B, zgemm Zgemmer, m, n, k int, tA, tB blas.Transpose) {
+	a := make([]complex128, m*k)
+	for i := range a {
+		a[i] = complex(rand.Float64(), rand.Float64())
+	}
+	bv := make([]complex128, k*n)
+	for i := range bv {
+		bv[i] = complex(rand.Float64(), rand.Float64())
+	}
+	c := make([]complex128, m*n)
+	for i := range c {
+		c[i] = complex(rand.Float64(), rand.Float64())
+	}
+	var lda, ldb int
+	if tA != blas.NoTrans {
+		lda = m
+	} else {
+		lda = k
+	}
+	if tB != blas.NoTrans {
+		ldb = k
+	} else {
+		ldb = n
+	}
+	ldc := n
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		zgemm.Zgemm(tA, tB, m, n, k, 3+1i, a, lda, bv, ldb, 1, c, ldc)
+	}
+}
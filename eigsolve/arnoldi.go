@@ -0,0 +1,175 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eigsolve
+
+import (
+	"math/cmplx"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// ArnoldiResult holds the result of a general eigenvalue computation.
+type ArnoldiResult struct {
+	// Values holds the computed eigenvalues of A ordered as requested,
+	// with the most wanted eigenvalue first.
+	Values []complex128
+
+	// Vectors holds the corresponding eigenvectors of A of unit norm
+	// in its columns.
+	Vectors *mat.CDense
+
+	// Residuals holds estimates of the residual norms |Op*x - θ*x| of
+	// the Ritz pairs (θ, x) of the Operator corresponding to Values.
+	Residuals []float64
+
+	// Converged is the number of eigenpairs that satisfy the
+	// convergence criterion.
+	Converged int
+
+	Stats
+}
+
+// Arnoldi computes k eigenvalues and eigenvectors of the n×n matrix A
+// represented by the Operator a using the implicitly restarted Arnoldi
+// method. The eigenvalues are selected by which. In ShiftInvert mode, a must
+// represent (A - σ*I)^{-1} and which applies to the eigenvalues of this
+// operator, see ShiftInvert for details.
+//
+// The restarts use the Krylov-Schur formulation, which is mathematically
+// equivalent to restarting with implicitly shifted QR iterations using the
+// unwanted Ritz values as shifts, but is numerically more stable. The
+// computation is carried out in real arithmetic, and complex conjugate pairs
+// of Ritz values are kept or discarded together.
+//
+// If settings is nil, default settings are used. Arnoldi panics if k is not
+// in the range [1, n-1) or if the settings are invalid. NumVectors must be at
+// least k+2.
+//
+// Arnoldi returns the computed eigenpairs together with any error. If the
+// iteration limit is reached before all k eigenpairs converge, the result
+// holds the current approximations and ErrIterationLimit is returned.
+func Arnoldi(a Operator, k int, which Which, settings *Settings) (*ArnoldiResult, error) {
+	start := time.Now()
+
+	n, c := a.Dims()
+	if n != c {
+		panic(mat.ErrSquare)
+	}
+	if k < 1 || n-1 <= k {
+		panic("eigsolve: invalid number of eigenpairs")
+	}
+	s := settings.defaults(n, k, 2)
+	m := s.NumVectors
+
+	var stats Stats
+	kr := newKrylov(a, n, m, &s, &stats)
+	kr.start(s.InitVector)
+
+	var (
+		hm   = mat.NewDense(m, m, nil)
+		ed   mat.Eigen
+		sf   mat.Schur
+		y    mat.CDense
+		vals = make([]complex128, m)
+		res  = make([]float64, m)
+		p    int
+	)
+	for {
+		kr.expand(p)
+		stats.Iterations++
+
+		// Compute the Ritz pairs of the projected matrix.
+		hm.Copy(kr.h)
+		ok := ed.Factorize(hm, mat.EigenRight)
+		if !ok {
+			return nil, ErrBreakdown
+		}
+		ed.Values(vals)
+		ed.VectorsTo(&y)
+		for i := range vals {
+			var r complex128
+			for j := 0; j < m; j++ {
+				r += complex(kr.h.At(m, j), 0) * y.At(j, i)
+			}
+			res[i] = cmplx.Abs(r)
+		}
+		idx := order(vals, which)
+
+		var nconv int
+		for _, i := range idx[:k] {
+			if converged(vals[i], res[i], s.Tolerance) {
+				nconv++
+			}
+		}
+		if nconv == k || stats.Iterations >= s.MaxIterations {
+			r := &ArnoldiResult{
+				Values:    make([]complex128, k),
+				Vectors:   mat.NewCDense(n, k, nil),
+				Residuals: make([]float64, k),
+				Converged: nconv,
+			}
+			yr := mat.NewDense(m, k, nil)
+			yi := mat.NewDense(m, k, nil)
+			for j, i := range idx[:k] {
+				r.Values[j] = s.transform(vals[i])
+				r.Residuals[j] = res[i]
+				for l := 0; l < m; l++ {
+					v := y.At(l, i)
+					yr.Set(l, j, real(v))
+					yi.Set(l, j, imag(v))
+				}
+			}
+			var xr, xi mat.Dense
+			qm := kr.q.Slice(0, m, 0, n).T()
+			xr.Mul(qm, yr)
+			xi.Mul(qm, yi)
+			for i := 0; i < n; i++ {
+				for j := 0; j < k; j++ {
+					r.Vectors.Set(i, j, complex(xr.At(i, j), xi.At(i, j)))
+				}
+			}
+			stats.Runtime = time.Since(start)
+			r.Stats = stats
+			if nconv < k {
+				return r, ErrIterationLimit
+			}
+			return r, nil
+		}
+
+		// Restart with the Schur vectors of the most wanted Ritz values.
+		ok = sf.Factorize(hm, true)
+		if !ok {
+			return nil, ErrBreakdown
+		}
+		sv := sf.Values(nil)
+		sidx := order(sv, which)
+		np := numKeep(k, m, nconv)
+		if isConjPair(sv[sidx[np-1]], sv[sidx[np]]) {
+			// Do not split a complex conjugate pair.
+			np++
+			if np >= m {
+				np -= 2
+			}
+		}
+		selected := make(map[complex128]bool, np)
+		for _, i := range sidx[:np] {
+			selected[sv[i]] = true
+		}
+		np, _ = sf.Reorder(func(v complex128) bool { return selected[v] })
+		if np == 0 {
+			return nil, ErrBreakdown
+		}
+		var t, z mat.Dense
+		sf.TTo(&t)
+		sf.ZTo(&z)
+		p = kr.restart(z.Slice(0, m, 0, np), t.Slice(0, np, 0, np))
+	}
+}
+
+// isConjPair returns whether a and b are a complex conjugate pair.
+func isConjPair(a, b complex128) bool {
+	return imag(a) != 0 && a == cmplx.Conj(b)
+}
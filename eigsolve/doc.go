@@ -0,0 +1,19 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package eigsolve provides iterative methods for computing a few eigenvalues
+// and eigenvectors of large matrices.
+//
+// The methods in eigsolve only access the matrix through matrix-vector
+// products, so they are suitable for large sparse matrices and for
+// matrix-free operators. Lanczos computes eigenpairs of symmetric matrices and
+// Arnoldi computes eigenpairs of general square matrices. Both methods are
+// restarted implicitly, so the memory they use is bounded by the dimension of
+// the Krylov subspace given in Settings.
+//
+// Eigenvalues in the interior of the spectrum, or those of smallest magnitude,
+// are found most efficiently in shift-invert mode, in which the method is
+// applied to the operator (A - σ*I)^{-1} such as the one returned by
+// NewShiftInvert.
+package eigsolve // import "gonum.org/v1/gonum/eigsolve"
@@ -0,0 +1,404 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eigsolve
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	defaultTolerance     = 1e-10
+	defaultMaxIterations = 300
+
+	// eps is the machine epsilon.
+	eps = 1.0 / (1 << 53)
+)
+
+var (
+	// ErrIterationLimit is returned when the maximum number of restarts
+	// was reached before all the requested eigenpairs converged.
+	ErrIterationLimit = errors.New("eigsolve: iteration limit reached")
+
+	// ErrBreakdown is returned when the eigendecomposition of the
+	// projected matrix fails.
+	ErrBreakdown = errors.New("eigsolve: method breakdown")
+)
+
+// Operator represents a square matrix A by means of a matrix-vector
+// multiplication.
+type Operator interface {
+	// Dims returns the dimensions of A.
+	Dims() (r, c int)
+
+	// MulVecTo computes A*x or A^T*x and stores the result into dst.
+	MulVecTo(dst *mat.VecDense, trans bool, x mat.Vector)
+}
+
+// FromMatrix returns an Operator that computes products with a. If a
+// implements Operator, it is returned directly.
+func FromMatrix(a mat.Matrix) Operator {
+	if op, ok := a.(Operator); ok {
+		return op
+	}
+	return matrix{a}
+}
+
+// matrix is an Operator that computes products with a mat.Matrix.
+type matrix struct {
+	mat.Matrix
+}
+
+func (m matrix) MulVecTo(dst *mat.VecDense, trans bool, x mat.Vector) {
+	if trans {
+		dst.MulVec(m.Matrix.T(), x)
+		return
+	}
+	dst.MulVec(m.Matrix, x)
+}
+
+// Which specifies which eigenvalues are computed.
+type Which int
+
+const (
+	// LargestMagnitude selects the eigenvalues of largest magnitude.
+	LargestMagnitude Which = iota
+	// SmallestMagnitude selects the eigenvalues of smallest magnitude.
+	SmallestMagnitude
+	// LargestAlgebraic selects the eigenvalues with the largest real
+	// part.
+	LargestAlgebraic
+	// SmallestAlgebraic selects the eigenvalues with the smallest real
+	// part.
+	SmallestAlgebraic
+)
+
+func (w Which) String() string {
+	switch w {
+	case LargestMagnitude:
+		return "LargestMagnitude"
+	case SmallestMagnitude:
+		return "SmallestMagnitude"
+	case LargestAlgebraic:
+		return "LargestAlgebraic"
+	case SmallestAlgebraic:
+		return "SmallestAlgebraic"
+	}
+	return fmt.Sprintf("Which(%d)", int(w))
+}
+
+// before returns whether the eigenvalue a is wanted more than b.
+func (w Which) before(a, b complex128) bool {
+	switch w {
+	case LargestMagnitude, SmallestMagnitude:
+		ma, mb := abs(a), abs(b)
+		if ma != mb {
+			return (ma > mb) == (w == LargestMagnitude)
+		}
+	case LargestAlgebraic, SmallestAlgebraic:
+		if real(a) != real(b) {
+			return (real(a) > real(b)) == (w == LargestAlgebraic)
+		}
+	default:
+		panic("eigsolve: invalid Which")
+	}
+	// Keep complex conjugate pairs together with the eigenvalue with
+	// positive imaginary part first.
+	return imag(a) > imag(b)
+}
+
+func abs(v complex128) float64 {
+	return math.Hypot(real(v), imag(v))
+}
+
+// Mode specifies the spectral transformation applied by the Operator.
+type Mode int
+
+const (
+	// Regular indicates that the Operator computes products with A.
+	Regular Mode = iota
+
+	// ShiftInvert indicates that the Operator computes products with
+	// (A - σ*I)^{-1}, where σ is given by Settings.Shift. The
+	// eigenvalues θ of the Operator are related to the eigenvalues λ of A
+	// by
+	//  λ = σ + 1/θ,
+	// so selecting the eigenvalues θ of largest magnitude finds the
+	// eigenvalues λ closest to σ.
+	ShiftInvert
+)
+
+// Settings holds the settings for computing eigenpairs.
+type Settings struct {
+	// NumVectors is the dimension of the Krylov subspace. It must be
+	// greater than the number of requested eigenpairs and at most the
+	// dimension of the matrix. If NumVectors is zero, a default of
+	// min(n, max(2*k+1, 20)) is used, where k is the number of requested
+	// eigenpairs and n is the dimension of the matrix.
+	NumVectors int
+
+	// Tolerance is the relative tolerance for the residual norm of the
+	// computed eigenpairs. A Ritz pair (θ, x) of the Operator has
+	// converged when
+	//  |Op*x - θ*x| <= Tolerance * max(ε^(2/3), |θ|),
+	// where ε is the machine epsilon. If Tolerance is zero, a default
+	// value of 1e-10 is used.
+	Tolerance float64
+
+	// MaxIterations is the maximum number of restarts. If it is zero,
+	// a default value of 300 is used.
+	MaxIterations int
+
+	// InitVector is the starting vector of the Krylov subspace. If it is
+	// nil, a random vector is used.
+	InitVector mat.Vector
+
+	// Src is the source of randomness used for the starting vector and
+	// for extending the Krylov subspace if it becomes invariant. If it is
+	// nil, a fixed seed is used.
+	Src rand.Source
+
+	// Mode is the spectral transformation applied by the Operator.
+	Mode Mode
+
+	// Shift is the shift σ used in ShiftInvert mode.
+	Shift float64
+}
+
+// Stats contains the statistics of the computation.
+type Stats struct {
+	Iterations int           // Number of restarts.
+	MulVec     int           // Number of matrix-vector products.
+	Runtime    time.Duration // Total runtime.
+}
+
+// defaults returns a copy of s with default values filled in for an n×n
+// operator and k requested eigenpairs, and checks that the settings are valid.
+func (s *Settings) defaults(n, k, minExtra int) Settings {
+	var d Settings
+	if s != nil {
+		d = *s
+	}
+	if d.NumVectors == 0 {
+		d.NumVectors = min(n, max(2*k+1, 20))
+	}
+	if d.NumVectors < k+minExtra || n < d.NumVectors {
+		panic("eigsolve: invalid number of vectors")
+	}
+	if d.Tolerance == 0 {
+		d.Tolerance = defaultTolerance
+	}
+	if d.Tolerance < 0 {
+		panic("eigsolve: negative tolerance")
+	}
+	if d.MaxIterations == 0 {
+		d.MaxIterations = defaultMaxIterations
+	}
+	if d.MaxIterations < 0 {
+		panic("eigsolve: negative iteration limit")
+	}
+	if d.Mode != Regular && d.Mode != ShiftInvert {
+		panic("eigsolve: invalid mode")
+	}
+	if d.InitVector != nil && d.InitVector.Len() != n {
+		panic("eigsolve: mismatched length of initial vector")
+	}
+	if d.Src == nil {
+		d.Src = rand.NewSource(1)
+	}
+	return d
+}
+
+// converged returns whether a Ritz value theta with the given residual norm
+// estimate has converged.
+func converged(theta complex128, resid, tol float64) bool {
+	return resid <= tol*math.Max(math.Pow(eps, 2.0/3), abs(theta))
+}
+
+// transform returns the eigenvalue of A corresponding to the eigenvalue theta
+// of the Operator.
+func (s *Settings) transform(theta complex128) complex128 {
+	if s.Mode == ShiftInvert {
+		return complex(s.Shift, 0) + 1/theta
+	}
+	return theta
+}
+
+// krylov holds a Krylov decomposition
+//  Op * Q_m^T = Q_{m+1}^T * H,
+// where the rows of the (m+1)×n matrix Q hold an orthonormal basis and H is
+// an (m+1)×m matrix. After a restart the leading p×p block of H is in Schur
+// form and the row p of H is full.
+type krylov struct {
+	op   Operator
+	n, m int
+
+	q *mat.Dense
+	h *mat.Dense
+
+	w, c, tmp *mat.VecDense
+
+	rnd   *rand.Rand
+	stats *Stats
+}
+
+func newKrylov(op Operator, n, m int, s *Settings, stats *Stats) *krylov {
+	return &krylov{
+		op:    op,
+		n:     n,
+		m:     m,
+		q:     mat.NewDense(m+1, n, nil),
+		h:     mat.NewDense(m+1, m, nil),
+		w:     mat.NewVecDense(n, nil),
+		c:     mat.NewVecDense(m+1, nil),
+		tmp:   mat.NewVecDense(n, nil),
+		rnd:   rand.New(s.Src),
+		stats: stats,
+	}
+}
+
+// start sets the first basis vector to the normalized x or to a random
+// vector if x is nil.
+func (kr *krylov) start(x mat.Vector) {
+	q0 := kr.q.RowView(0).(*mat.VecDense)
+	if x == nil {
+		kr.randomVector(0)
+		return
+	}
+	q0.CopyVec(x)
+	norm := mat.Norm(q0, 2)
+	if norm == 0 {
+		panic("eigsolve: zero initial vector")
+	}
+	q0.ScaleVec(1/norm, q0)
+}
+
+// randomVector sets the basis vector j to a random unit vector orthogonal to
+// the preceding basis vectors, or to zero if they span the whole space.
+func (kr *krylov) randomVector(j int) {
+	qj := kr.q.RowView(j).(*mat.VecDense)
+	for i := 0; i < kr.n; i++ {
+		qj.SetVec(i, kr.rnd.NormFloat64())
+	}
+	norm0 := mat.Norm(qj, 2)
+	if j > 0 {
+		kr.orthogonalize(qj, j)
+	}
+	norm := mat.Norm(qj, 2)
+	if norm <= 1e3*eps*norm0 {
+		qj.Zero()
+		return
+	}
+	qj.ScaleVec(1/norm, qj)
+}
+
+// orthogonalize orthogonalizes w against the first j basis vectors using
+// classical Gram-Schmidt with one step of reorthogonalization, and returns
+// the projection coefficients in kr.c[:j].
+func (kr *krylov) orthogonalize(w *mat.VecDense, j int) {
+	qj := kr.q.Slice(0, j, 0, kr.n)
+	c := kr.c.SliceVec(0, j).(*mat.VecDense)
+	c.Zero()
+	d := kr.tmp
+	for pass := 0; pass < 2; pass++ {
+		var cp mat.VecDense
+		cp.MulVec(qj, w)
+		d.MulVec(qj.T(), &cp)
+		w.SubVec(w, d)
+		c.AddVec(c, &cp)
+	}
+}
+
+// expand extends the Krylov decomposition from p to m basis vectors.
+func (kr *krylov) expand(p int) {
+	for j := p; j < kr.m; j++ {
+		kr.op.MulVecTo(kr.w, false, kr.q.RowView(j))
+		kr.stats.MulVec++
+		norm0 := mat.Norm(kr.w, 2)
+		kr.orthogonalize(kr.w, j+1)
+		for i := 0; i <= j; i++ {
+			kr.h.Set(i, j, kr.c.AtVec(i))
+		}
+		beta := mat.Norm(kr.w, 2)
+		if beta <= 1e3*eps*norm0 {
+			// The Krylov subspace is invariant so continue with
+			// a new random direction.
+			kr.h.Set(j+1, j, 0)
+			kr.randomVector(j + 1)
+			continue
+		}
+		kr.h.Set(j+1, j, beta)
+		kr.q.RowView(j+1).(*mat.VecDense).ScaleVec(1/beta, kr.w)
+	}
+}
+
+// restart truncates the Krylov decomposition to the p×p leading block using
+// the m×p matrix y with orthonormal columns spanning an invariant subspace
+// of the leading m×m block of H, and t = y^T * H_m * y.
+func (kr *krylov) restart(y, t mat.Matrix) int {
+	np, _ := t.Dims()
+
+	// Q[:np] = y^T * Q[:m], Q[np] = Q[m].
+	var qp mat.Dense
+	qp.Mul(y.T(), kr.q.Slice(0, kr.m, 0, kr.n))
+	kr.q.Slice(0, np, 0, kr.n).(*mat.Dense).Copy(&qp)
+	kr.q.RowView(np).(*mat.VecDense).CopyVec(kr.q.RowView(kr.m))
+
+	// H[np, :np] = H[m, :m] * y.
+	var b mat.VecDense
+	b.MulVec(y.T(), kr.h.RowView(kr.m))
+	kr.h.Zero()
+	kr.h.Slice(0, np, 0, np).(*mat.Dense).Copy(t)
+	for j := 0; j < np; j++ {
+		kr.h.Set(np, j, b.AtVec(j))
+	}
+	return np
+}
+
+// order returns the indices of values sorted by which.
+func order(values []complex128, which Which) []int {
+	idx := make([]int, len(values))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return which.before(values[idx[i]], values[idx[j]])
+	})
+	return idx
+}
+
+// numKeep returns the number of Ritz vectors to keep at a restart when k
+// eigenpairs are requested from a Krylov subspace of dimension m and nconv
+// have converged.
+func numKeep(k, m, nconv int) int {
+	// Keeping more vectors than requested accelerates convergence.
+	// This is the heuristic used by ARPACK.
+	p := k + min(nconv, (m-k)/2)
+	if p == 1 && m > 3 {
+		p = m / 2
+	}
+	return min(p, m-1)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
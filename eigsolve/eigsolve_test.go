@@ -0,0 +1,335 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eigsolve
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// randomSymmetric returns an n×n symmetric matrix with the given eigenvalues.
+func randomSymmetric(values []float64, rnd *rand.Rand) *mat.SymDense {
+	n := len(values)
+	a := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	var qr mat.QR
+	qr.Factorize(a)
+	var q mat.Dense
+	qr.QTo(&q)
+	var qd mat.Dense
+	qd.Mul(&q, mat.NewDiagDense(n, values))
+	var b mat.Dense
+	b.Mul(&qd, q.T())
+	s := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			s.SetSym(i, j, (b.At(i, j)+b.At(j, i))/2)
+		}
+	}
+	return s
+}
+
+// laplacian returns the n×n matrix of the one-dimensional discrete Laplace
+// operator as a CSR matrix together with its eigenvalues in ascending order.
+func laplacian(n int) (*mat.CSR, []float64) {
+	coo := mat.NewCOO(n, n, nil, nil, nil)
+	for i := 0; i < n; i++ {
+		coo.Append(i, i, 2)
+		if i > 0 {
+			coo.Append(i, i-1, -1)
+		}
+		if i < n-1 {
+			coo.Append(i, i+1, -1)
+		}
+	}
+	values := make([]float64, n)
+	for k := range values {
+		values[k] = 2 - 2*math.Cos(float64(k+1)*math.Pi/float64(n+1))
+	}
+	return coo.ToCSR(), values
+}
+
+// wantedReal returns the k most wanted values sorted by which.
+func wantedReal(values []float64, k int, which Which) []float64 {
+	cv := make([]complex128, len(values))
+	for i, v := range values {
+		cv[i] = complex(v, 0)
+	}
+	want := make([]float64, k)
+	for j, i := range order(cv, which)[:k] {
+		want[j] = values[i]
+	}
+	return want
+}
+
+func checkSymResult(t *testing.T, name string, a mat.Matrix, r *LanczosResult, want []float64, tol float64) {
+	t.Helper()
+	if !floats.EqualApprox(r.Values, want, tol) {
+		t.Errorf("%s: unexpected eigenvalues:\ngot: %v\nwant:%v", name, r.Values, want)
+	}
+	n, _ := a.Dims()
+	for j, lambda := range r.Values {
+		x := r.Vectors.ColView(j)
+		if math.Abs(mat.Norm(x, 2)-1) > 1e-10 {
+			t.Errorf("%s: eigenvector %d not of unit norm: %v", name, j, mat.Norm(x, 2))
+		}
+		ax := mat.NewVecDense(n, nil)
+		ax.MulVec(a, x)
+		ax.AddScaledVec(ax, -lambda, x)
+		if resid := mat.Norm(ax, 2); resid > tol*math.Max(1, math.Abs(lambda)) {
+			t.Errorf("%s: residual too large for eigenpair %d: %v", name, j, resid)
+		}
+	}
+}
+
+func TestLanczos(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{10, 50, 150} {
+		values := make([]float64, n)
+		for i := range values {
+			values[i] = float64(i-n/3) + 0.1*rnd.Float64()
+		}
+		a := randomSymmetric(values, rnd)
+		for _, k := range []int{1, 3, 6} {
+			if k >= n {
+				continue
+			}
+			for _, which := range []Which{LargestMagnitude, SmallestMagnitude, LargestAlgebraic, SmallestAlgebraic} {
+				name := fmt.Sprintf("n=%d,k=%d,which=%v", n, k, which)
+				s := &Settings{MaxIterations: 1000}
+				r, err := Lanczos(FromMatrix(a), k, which, s)
+				if err != nil {
+					t.Errorf("%s: unexpected error: %v", name, err)
+					continue
+				}
+				if r.Converged != k {
+					t.Errorf("%s: unexpected number of converged eigenpairs: got:%d want:%d", name, r.Converged, k)
+				}
+				if r.MulVec == 0 || r.Iterations == 0 {
+					t.Errorf("%s: missing statistics: %+v", name, r.Stats)
+				}
+				checkSymResult(t, name, a, r, wantedReal(values, k, which), 1e-8)
+			}
+		}
+	}
+}
+
+func TestLanczosSparse(t *testing.T) {
+	t.Parallel()
+	const n = 400
+	a, values := laplacian(n)
+
+	// The largest eigenvalues converge quickly in regular mode.
+	r, err := Lanczos(a, 4, LargestAlgebraic, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSymResult(t, "largest", a, r, wantedReal(values, 4, LargestAlgebraic), 1e-8)
+
+	// The smallest eigenvalues are found using shift-invert mode.
+	op, err := NewShiftInvert(a, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err = Lanczos(op, 4, LargestMagnitude, &Settings{Mode: ShiftInvert})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSymResult(t, "shift-invert", a, r, values[:4], 1e-8)
+
+	// Interior eigenvalues closest to a shift.
+	const sigma = 1.0
+	op, err = NewShiftInvert(a, sigma)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err = Lanczos(op, 3, LargestMagnitude, &Settings{Mode: ShiftInvert, Shift: sigma})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := append([]float64(nil), values...)
+	sort.Slice(want, func(i, j int) bool {
+		return math.Abs(want[i]-sigma) < math.Abs(want[j]-sigma)
+	})
+	checkSymResult(t, "interior", a, r, want[:3], 1e-8)
+}
+
+func TestLanczosInvariant(t *testing.T) {
+	t.Parallel()
+	// A matrix with few distinct eigenvalues makes the Krylov subspace
+	// invariant before it reaches its full dimension.
+	const n = 30
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(i % 3)
+	}
+	a := mat.NewDiagDense(n, values)
+	r, err := Lanczos(FromMatrix(a), 2, LargestAlgebraic, &Settings{NumVectors: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkSymResult(t, "invariant", a, r, []float64{2, 2}, 1e-10)
+}
+
+func TestLanczosIterationLimit(t *testing.T) {
+	t.Parallel()
+	a, _ := laplacian(500)
+	r, err := Lanczos(a, 5, SmallestAlgebraic, &Settings{MaxIterations: 2, NumVectors: 12})
+	if err != ErrIterationLimit {
+		t.Fatalf("unexpected error: got:%v want:%v", err, ErrIterationLimit)
+	}
+	if r == nil || len(r.Values) != 5 || r.Converged >= 5 || r.Iterations != 2 {
+		t.Errorf("unexpected result for iteration limit: %+v", r)
+	}
+}
+
+func TestArnoldi(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{10, 60, 120} {
+		a := mat.NewDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		var ed mat.Eigen
+		if !ed.Factorize(a, mat.EigenNone) {
+			t.Fatal("unexpected eigendecomposition failure")
+		}
+		values := ed.Values(nil)
+
+		for _, k := range []int{1, 4} {
+			for _, which := range []Which{LargestMagnitude, LargestAlgebraic, SmallestAlgebraic} {
+				name := fmt.Sprintf("n=%d,k=%d,which=%v", n, k, which)
+				r, err := Arnoldi(FromMatrix(a), k, which, &Settings{MaxIterations: 2000})
+				if err != nil {
+					t.Errorf("%s: unexpected error: %v", name, err)
+					continue
+				}
+				want := make([]complex128, k)
+				for j, i := range order(values, which)[:k] {
+					want[j] = values[i]
+				}
+				checkResult(t, name, a, r, want, 1e-8)
+			}
+		}
+	}
+}
+
+func TestArnoldiShiftInvert(t *testing.T) {
+	t.Parallel()
+	const n = 100
+	rnd := rand.New(rand.NewSource(2))
+	a := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		a.Set(i, i, float64(i))
+		for j := 0; j < n; j++ {
+			if i != j {
+				a.Set(i, j, a.At(i, j)+0.1*rnd.NormFloat64())
+			}
+		}
+	}
+	var ed mat.Eigen
+	if !ed.Factorize(a, mat.EigenNone) {
+		t.Fatal("unexpected eigendecomposition failure")
+	}
+	values := ed.Values(nil)
+
+	const sigma = 42.3
+	op, err := NewShiftInvert(a, sigma)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err := Arnoldi(op, 3, LargestMagnitude, &Settings{Mode: ShiftInvert, Shift: sigma})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return cmplx.Abs(values[i]-sigma) < cmplx.Abs(values[j]-sigma)
+	})
+	checkResult(t, "shift-invert", a, r, values[:3], 1e-8)
+}
+
+func checkResult(t *testing.T, name string, a mat.Matrix, r *ArnoldiResult, want []complex128, tol float64) {
+	t.Helper()
+	if r.Converged != len(want) {
+		t.Errorf("%s: unexpected number of converged eigenpairs: got:%d want:%d", name, r.Converged, len(want))
+	}
+	for j, v := range r.Values {
+		if cmplx.Abs(v-want[j]) > tol*math.Max(1, cmplx.Abs(want[j])) {
+			t.Errorf("%s: unexpected eigenvalues:\ngot: %v\nwant:%v", name, r.Values, want)
+			break
+		}
+	}
+	n, _ := a.Dims()
+	for j, lambda := range r.Values {
+		var norm, resid float64
+		for i := 0; i < n; i++ {
+			var ax complex128
+			for l := 0; l < n; l++ {
+				ax += complex(a.At(i, l), 0) * r.Vectors.At(l, j)
+			}
+			x := r.Vectors.At(i, j)
+			norm += real(x)*real(x) + imag(x)*imag(x)
+			d := cmplx.Abs(ax - lambda*x)
+			resid += d * d
+		}
+		if math.Abs(math.Sqrt(norm)-1) > 1e-10 {
+			t.Errorf("%s: eigenvector %d not of unit norm: %v", name, j, math.Sqrt(norm))
+		}
+		if math.Sqrt(resid) > tol*math.Max(1, cmplx.Abs(lambda)) {
+			t.Errorf("%s: residual too large for eigenpair %d: %v", name, j, math.Sqrt(resid))
+		}
+	}
+}
+
+func TestNewShiftInvertSingular(t *testing.T) {
+	t.Parallel()
+	a := mat.NewDiagDense(3, []float64{1, 2, 3})
+	_, err := NewShiftInvert(a, 2)
+	if err == nil {
+		t.Error("expected error for singular shifted matrix")
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	t.Parallel()
+	a := FromMatrix(mat.NewDiagDense(5, []float64{1, 2, 3, 4, 5}))
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{"Lanczos k=0", func() { Lanczos(a, 0, LargestMagnitude, nil) }},
+		{"Lanczos k=n", func() { Lanczos(a, 5, LargestMagnitude, nil) }},
+		{"Lanczos NumVectors=k", func() { Lanczos(a, 2, LargestMagnitude, &Settings{NumVectors: 2}) }},
+		{"Lanczos NumVectors>n", func() { Lanczos(a, 2, LargestMagnitude, &Settings{NumVectors: 6}) }},
+		{"Arnoldi k=n-1", func() { Arnoldi(a, 4, LargestMagnitude, nil) }},
+		{"Arnoldi NumVectors=k+1", func() { Arnoldi(a, 2, LargestMagnitude, &Settings{NumVectors: 3}) }},
+		{"invalid which", func() { Lanczos(a, 2, Which(-1), nil) }},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s: expected panic", test.name)
+				}
+			}()
+			test.fn()
+		}()
+	}
+}
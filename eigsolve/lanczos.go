@@ -0,0 +1,141 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eigsolve
+
+import (
+	"math"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// LanczosResult holds the result of a symmetric eigenvalue computation.
+type LanczosResult struct {
+	// Values holds the computed eigenvalues of A ordered as requested,
+	// with the most wanted eigenvalue first.
+	Values []float64
+
+	// Vectors holds the corresponding eigenvectors of A of unit norm
+	// in its columns.
+	Vectors *mat.Dense
+
+	// Residuals holds estimates of the residual norms |Op*x - θ*x| of
+	// the Ritz pairs (θ, x) of the Operator corresponding to Values.
+	Residuals []float64
+
+	// Converged is the number of eigenpairs that satisfy the
+	// convergence criterion.
+	Converged int
+
+	Stats
+}
+
+// Lanczos computes k eigenvalues and eigenvectors of the n×n symmetric matrix
+// A represented by the Operator a using the implicitly restarted Lanczos
+// method. The eigenvalues are selected by which. In ShiftInvert mode, a must
+// represent (A - σ*I)^{-1} and which applies to the eigenvalues of this
+// operator, see ShiftInvert for details.
+//
+// The restarts use the Krylov-Schur formulation, which is mathematically
+// equivalent to restarting with implicitly shifted QR iterations using the
+// unwanted Ritz values as shifts, but is numerically more stable. The Lanczos
+// vectors are fully reorthogonalized.
+//
+// If settings is nil, default settings are used. Lanczos panics if k is not
+// in the range [1, n) or if the settings are invalid.
+//
+// Lanczos returns the computed eigenpairs together with any error. If the
+// iteration limit is reached before all k eigenpairs converge, the result
+// holds the current approximations and ErrIterationLimit is returned.
+func Lanczos(a Operator, k int, which Which, settings *Settings) (*LanczosResult, error) {
+	start := time.Now()
+
+	n, c := a.Dims()
+	if n != c {
+		panic(mat.ErrSquare)
+	}
+	if k < 1 || n <= k {
+		panic("eigsolve: invalid number of eigenpairs")
+	}
+	s := settings.defaults(n, k, 1)
+	m := s.NumVectors
+
+	var stats Stats
+	kr := newKrylov(a, n, m, &s, &stats)
+	kr.start(s.InitVector)
+
+	var (
+		sym  = mat.NewSymDense(m, nil)
+		ed   mat.EigenSym
+		y    mat.Dense
+		vals = make([]float64, m)
+		cvs  = make([]complex128, m)
+		res  = make([]float64, m)
+		b    mat.VecDense
+		p    int
+	)
+	for {
+		kr.expand(p)
+		stats.Iterations++
+
+		// Compute the Ritz pairs from the symmetric part of the
+		// projected matrix.
+		for i := 0; i < m; i++ {
+			for j := i; j < m; j++ {
+				sym.SetSym(i, j, (kr.h.At(i, j)+kr.h.At(j, i))/2)
+			}
+		}
+		ok := ed.Factorize(sym, true)
+		if !ok {
+			return nil, ErrBreakdown
+		}
+		ed.Values(vals)
+		ed.VectorsTo(&y)
+		b.MulVec(y.T(), kr.h.RowView(m))
+		for i, v := range vals {
+			cvs[i] = complex(v, 0)
+			res[i] = math.Abs(b.AtVec(i))
+		}
+		idx := order(cvs, which)
+
+		var nconv int
+		for _, i := range idx[:k] {
+			if converged(cvs[i], res[i], s.Tolerance) {
+				nconv++
+			}
+		}
+		if nconv == k || stats.Iterations >= s.MaxIterations {
+			r := &LanczosResult{
+				Values:    make([]float64, k),
+				Vectors:   mat.NewDense(n, k, nil),
+				Residuals: make([]float64, k),
+				Converged: nconv,
+			}
+			yk := mat.NewDense(m, k, nil)
+			for j, i := range idx[:k] {
+				r.Values[j] = real(s.transform(cvs[i]))
+				r.Residuals[j] = res[i]
+				yk.SetCol(j, mat.Col(nil, i, &y))
+			}
+			r.Vectors.Mul(kr.q.Slice(0, m, 0, n).T(), yk)
+			stats.Runtime = time.Since(start)
+			r.Stats = stats
+			if nconv < k {
+				return r, ErrIterationLimit
+			}
+			return r, nil
+		}
+
+		// Restart with the most wanted Ritz vectors.
+		np := numKeep(k, m, nconv)
+		yp := mat.NewDense(m, np, nil)
+		t := mat.NewDense(np, np, nil)
+		for j, i := range idx[:np] {
+			yp.SetCol(j, mat.Col(nil, i, &y))
+			t.Set(j, j, vals[i])
+		}
+		p = kr.restart(yp, t)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eigsolve
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// shiftInvert is an Operator that computes products with (A - σ*I)^{-1}
+// using the LU factorization of A - σ*I.
+type shiftInvert struct {
+	lu mat.LU
+	n  int
+}
+
+// NewShiftInvert returns an Operator that computes products with
+// (A - σ*I)^{-1} for the n×n matrix a and the shift sigma, for use in
+// ShiftInvert mode. The returned Operator holds a dense LU factorization of
+// A - σ*I, so it requires O(n²) storage.
+//
+// NewShiftInvert returns an error if A - σ*I is singular. NewShiftInvert
+// panics if a is not square.
+func NewShiftInvert(a mat.Matrix, sigma float64) (Operator, error) {
+	n, c := a.Dims()
+	if n != c {
+		panic(mat.ErrSquare)
+	}
+	b := mat.DenseCopyOf(a)
+	for i := 0; i < n; i++ {
+		b.Set(i, i, b.At(i, i)-sigma)
+	}
+	op := &shiftInvert{n: n}
+	op.lu.Factorize(b)
+	if math.IsInf(op.lu.Cond(), 1) {
+		return nil, errors.New("eigsolve: shifted matrix is singular")
+	}
+	return op, nil
+}
+
+func (s *shiftInvert) Dims() (r, c int) { return s.n, s.n }
+
+func (s *shiftInvert) MulVecTo(dst *mat.VecDense, trans bool, x mat.Vector) {
+	err := s.lu.SolveVecTo(dst, trans, x)
+	if err != nil {
+		// Singular matrices are rejected by NewShiftInvert, so only
+		// a mat.Condition error is possible here, and the solution
+		// is returned regardless.
+		if _, ok := err.(mat.Condition); !ok {
+			panic(err)
+		}
+	}
+}
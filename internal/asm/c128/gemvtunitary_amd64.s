@@ -0,0 +1,140 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !noasm,!appengine,!safe
+
+#include "textflag.h"
+
+#define X_PTR SI
+#define A_PTR R9
+#define A_ROW R12
+#define Y_PTR DI
+#define LDA R10
+#define K R8
+#define IDX AX
+#define LEN CX
+#define TAIL BX
+#define XI R11
+#define L DX
+
+// func GemvTUnitary(x, a []complex128, lda uintptr, y []complex128)
+TEXT ·GemvTUnitary(SB), NOSPLIT, $0
+	MOVQ x_base+0(FP), X_PTR  // X_PTR = &x
+	MOVQ x_len+8(FP), K       // K = len(x)
+	MOVQ a_base+24(FP), A_PTR // A_PTR = &a
+	MOVQ lda+48(FP), LDA      // LDA = lda * sizeof(complex128)
+	SHLQ $4, LDA
+	MOVQ y_base+56(FP), Y_PTR // Y_PTR = &y
+	MOVQ y_len+64(FP), LEN    // LEN = len(y)
+	CMPQ K, $0                // if K == 0 { return }
+	JE   end
+	CMPQ LEN, $0              // if LEN == 0 { return }
+	JE   end
+	XORQ IDX, IDX             // IDX = 0, byte offset of the current columns
+	MOVQ LEN, TAIL
+	ANDQ $3, TAIL             // TAIL = len(y) % 4
+	SHRQ $2, LEN              // LEN = floor( len(y) / 4 )
+	JZ   tail_start           // if LEN == 0 { goto tail_start }
+
+loop: // do {
+	// Hold four elements of y in the accumulators X0-X3.
+	MOVUPS (Y_PTR)(IDX*1), X0
+	MOVUPS 16(Y_PTR)(IDX*1), X1
+	MOVUPS 32(Y_PTR)(IDX*1), X2
+	MOVUPS 48(Y_PTR)(IDX*1), X3
+	MOVQ   X_PTR, XI              // XI = &x[0]
+	LEAQ   (A_PTR)(IDX*1), A_ROW  // A_ROW = &a[0*lda+j]
+	MOVQ   K, L
+
+inner: // do {
+	MOVDDUP (XI), X4  // X4 = { real(x[l]), real(x[l]) }
+	MOVDDUP 8(XI), X5 // X5 = { imag(x[l]), imag(x[l]) }
+
+	// X_i = { imag(a[l*lda+j]), real(a[l*lda+j]) }
+	MOVUPS (A_ROW), X6
+	MOVUPS 16(A_ROW), X7
+	MOVUPS 32(A_ROW), X8
+	MOVUPS 48(A_ROW), X9
+
+	// X_(i+4) = { real(a[l*lda+j]), imag(a[l*lda+j]) }
+	MOVAPS X6, X10
+	MOVAPS X7, X11
+	MOVAPS X8, X12
+	MOVAPS X9, X13
+	SHUFPD $0x1, X10, X10
+	SHUFPD $0x1, X11, X11
+	SHUFPD $0x1, X12, X12
+	SHUFPD $0x1, X13, X13
+
+	// X_i     = { imag(a)*real(x), real(a)*real(x) }
+	// X_(i+4) = { real(a)*imag(x), imag(a)*imag(x) }
+	MULPD X4, X6
+	MULPD X4, X7
+	MULPD X4, X8
+	MULPD X4, X9
+	MULPD X5, X10
+	MULPD X5, X11
+	MULPD X5, X12
+	MULPD X5, X13
+
+	// X_i = {
+	//	imag(result): imag(a)*real(x) + real(a)*imag(x),
+	//	real(result): real(a)*real(x) - imag(a)*imag(x)
+	// }
+	ADDSUBPD X10, X6
+	ADDSUBPD X11, X7
+	ADDSUBPD X12, X8
+	ADDSUBPD X13, X9
+
+	// Accumulate the products.
+	ADDPD X6, X0
+	ADDPD X7, X1
+	ADDPD X8, X2
+	ADDPD X9, X3
+
+	ADDQ $16, XI     // XI = &x[l+1]
+	ADDQ LDA, A_ROW  // A_ROW = &a[(l+1)*lda+j]
+	DECQ L
+	JNZ  inner       // } while --L > 0
+
+	MOVUPS X0, (Y_PTR)(IDX*1) // y[j:j+4] = X0-X3
+	MOVUPS X1, 16(Y_PTR)(IDX*1)
+	MOVUPS X2, 32(Y_PTR)(IDX*1)
+	MOVUPS X3, 48(Y_PTR)(IDX*1)
+	ADDQ   $64, IDX           // j += 4
+	DECQ   LEN
+	JNZ    loop               // } while --LEN > 0
+
+tail_start:
+	CMPQ TAIL, $0 // if TAIL == 0 { return }
+	JE   end
+
+tail: // do {
+	MOVUPS (Y_PTR)(IDX*1), X0
+	MOVQ   X_PTR, XI
+	LEAQ   (A_PTR)(IDX*1), A_ROW
+	MOVQ   K, L
+
+tail_inner: // do {
+	MOVDDUP  (XI), X4
+	MOVDDUP  8(XI), X5
+	MOVUPS   (A_ROW), X6
+	MOVAPS   X6, X10
+	SHUFPD   $0x1, X10, X10
+	MULPD    X4, X6
+	MULPD    X5, X10
+	ADDSUBPD X10, X6
+	ADDPD    X6, X0
+	ADDQ     $16, XI
+	ADDQ     LDA, A_ROW
+	DECQ     L
+	JNZ      tail_inner  // } while --L > 0
+
+	MOVUPS X0, (Y_PTR)(IDX*1) // y[j] = X0
+	ADDQ   $16, IDX           // j++
+	DECQ   TAIL
+	JNZ    tail               // } while --TAIL > 0
+
+end:
+	RET
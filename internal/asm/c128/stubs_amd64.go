@@ -94,3 +94,13 @@ func DotuUnitary(x, y []complex128) (sum complex128)
 //  }
 //  return sum
 func DotuInc(x, y []complex128, n, incX, incY, ix, iy uintptr) (sum complex128)
+
+// GemvTUnitary is
+//  for l, v := range x {
+//  	for j := range y {
+//  		y[j] += v * a[l*lda+j]
+//  	}
+//  }
+// that is, it computes y += A^T * x for the len(x)×len(y) matrix A stored
+// in row-major order in a.
+func GemvTUnitary(x, a []complex128, lda uintptr, y []complex128)
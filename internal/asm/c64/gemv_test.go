@@ -0,0 +1,57 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package c64
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGemvTUnitary(t *testing.T) {
+	const (
+		gd = 1 + 1i
+		gl = 4
+	)
+	for _, k := range []int{0, 1, 2, 3, 4, 7} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 8, 9, 15} {
+			for _, extra := range []int{0, 3} {
+				lda := n + extra
+				prefix := fmt.Sprintf("k=%v,n=%v,lda=%v", k, n, lda)
+
+				x := make([]complex64, k)
+				for i := range x {
+					x[i] = complex(float32(i+1), float32(-i))
+				}
+				a := make([]complex64, k*lda)
+				for i := range a {
+					a[i] = complex(float32(i%7)-3, float32(i%5)+0.5)
+				}
+				y := make([]complex64, n)
+				for j := range y {
+					y[j] = complex(0.5*float32(j), 1)
+				}
+
+				want := make([]complex64, n)
+				copy(want, y)
+				for l, v := range x {
+					for j := range want {
+						want[j] += v * a[l*lda+j]
+					}
+				}
+
+				yg := guardVector(y, gd, gl)
+				GemvTUnitary(x, a, uintptr(lda), yg[gl:len(yg)-gl])
+				for j, got := range yg[gl : len(yg)-gl] {
+					if !same(got, want[j]) {
+						t.Errorf(msgVal, prefix, j, got, want[j])
+					}
+				}
+				if !isValidGuard(yg, gd, gl) {
+					t.Errorf(msgGuard, prefix, "y", yg[:gl], yg[len(yg)-gl:])
+				}
+			}
+		}
+	}
+}
@@ -66,3 +66,13 @@ func DotuUnitary(x, y []complex64) (sum complex64)
 //  }
 //  return sum
 func DotuInc(x, y []complex64, n, incX, incY, ix, iy uintptr) (sum complex64)
+
+// GemvTUnitary is
+//  for l, v := range x {
+//  	for j := range y {
+//  		y[j] += v * a[l*lda+j]
+//  	}
+//  }
+// that is, it computes y += A^T * x for the len(x)×len(y) matrix A stored
+// in row-major order in a.
+func GemvTUnitary(x, a []complex64, lda uintptr, y []complex64)
@@ -608,3 +608,31 @@ func dgemvcomp(t *testing.T, test DgemvCase, trans bool, cas DgemvSubcase, i int
 		}
 	}
 }
+
+// TestGemvTLongY checks that GemvT only updates the first n elements of y
+// when y is longer than n.
+func TestGemvTLongY(t *testing.T) {
+	const yGdVal, gdLn = 1.5, 4
+	for _, test := range DgemvCases {
+		for i, cas := range test.Trans {
+			prefix := fmt.Sprintf("Test (%vx%v) case %v (a:%v,b:%v)", test.m, test.n, i, cas.alpha, cas.beta)
+			y := make([]float64, test.n+gdLn)
+			copy(y, test.x)
+			for j := test.n; j < len(y); j++ {
+				y[j] = yGdVal
+			}
+			GemvT(uintptr(test.m), uintptr(test.n), cas.alpha, test.A, uintptr(test.n), test.y, 1, cas.beta, y, 1)
+			for j := range cas.want {
+				if !within(y[j], cas.want[j]) {
+					t.Errorf(msgVal, prefix, j, y[j], cas.want[j])
+				}
+			}
+			for _, v := range y[test.n:] {
+				if v != yGdVal {
+					t.Errorf("%v: elements of y beyond n modified: %v", prefix, y[test.n:])
+					break
+				}
+			}
+		}
+	}
+}
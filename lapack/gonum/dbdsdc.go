@@ -0,0 +1,405 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dbdsdc computes the singular value decomposition of an n×n upper or lower
+// bidiagonal matrix B using the divide and conquer method,
+//  B = U * S * VT,
+// where S is a diagonal matrix of singular values, and U and VT are orthogonal
+// matrices of left and right singular vectors respectively.
+//
+// The matrix is recursively split into two halves whose singular value
+// decompositions are merged by solving the secular equation of a rank-one
+// modification of a diagonal matrix. Blocks of order at most 25 are solved by
+// Dbdsqr.
+//
+// d and e contain the diagonal and off-diagonal elements of B. d must have
+// length at least n and e must have length at least n-1, and Dbdsdc will panic
+// otherwise. On exit, d contains the singular values of B in decreasing order
+// and e is overwritten.
+//
+// If compq == lapack.OrthoExplicit, the left and right singular vectors of B
+// are stored on exit into u and vt which must have dimensions n×n. If
+// compq == lapack.OrthoNone, only the singular values are computed and u and
+// vt are not referenced. compq must not be lapack.OrthoPostmul.
+//
+// work must have length at least 4*n if compq == lapack.OrthoNone or n <= 25,
+// and at least 3*n*n+7*n+1 otherwise. iwork must have length at least 4*n.
+// Dbdsdc will panic if the workspace is insufficient.
+//
+// Dbdsdc returns whether the decomposition was successful.
+//
+// Dbdsdc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dbdsdc(uplo blas.Uplo, compq lapack.OrthoComp, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool) {
+	wantq := compq == lapack.OrthoExplicit
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case compq != lapack.OrthoNone && !wantq:
+		panic(badOrthoComp)
+	case n < 0:
+		panic(nLT0)
+	case ldu < 1, wantq && ldu < n:
+		panic(badLdU)
+	case ldvt < 1, wantq && ldvt < n:
+		panic(badLdVT)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	smlsiz := impl.Ilaenv(9, "DBDSDC", " ", 0, 0, 0, 0)
+	lwmin := 4 * n
+	if wantq && n > smlsiz {
+		lwmin = 3*n*n + 7*n + 1
+	}
+	switch {
+	case len(d) < n:
+		panic(shortD)
+	case len(e) < n-1:
+		panic(shortE)
+	case wantq && len(u) < (n-1)*ldu+n:
+		panic(shortU)
+	case wantq && len(vt) < (n-1)*ldvt+n:
+		panic(shortVT)
+	case len(work) < lwmin:
+		panic(shortWork)
+	case len(iwork) < 4*n:
+		panic(shortIWork)
+	}
+
+	if n == 1 {
+		if wantq {
+			u[0] = math.Copysign(1, d[0])
+			vt[0] = 1
+		}
+		d[0] = math.Abs(d[0])
+		return true
+	}
+
+	if !wantq {
+		return impl.Dbdsqr(uplo, n, 0, 0, 0, d, e, nil, 1, nil, 1, nil, 1, work)
+	}
+
+	if n <= smlsiz {
+		impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+		impl.Dlaset(blas.All, n, n, 0, 1, vt, ldvt)
+		return impl.Dbdsqr(uplo, n, n, n, 0, d, e, vt, ldvt, u, ldu, nil, 1, work)
+	}
+
+	wrk := work[:3*n*n+5*n+1]
+	rot := work[3*n*n+5*n+1 : 3*n*n+7*n+1]
+
+	// If the matrix is lower bidiagonal, rotate it to be upper bidiagonal
+	// by applying Givens rotations on the left. The rotations are applied
+	// to the left singular vectors at the end.
+	if uplo == blas.Lower {
+		for i := 0; i < n-1; i++ {
+			cs, sn, r := impl.Dlartg(d[i], e[i])
+			d[i] = r
+			e[i] = sn * d[i+1]
+			d[i+1] *= cs
+			rot[2*i] = cs
+			rot[2*i+1] = sn
+		}
+	}
+
+	impl.Dlaset(blas.All, n, n, 0, 0, u, ldu)
+	impl.Dlaset(blas.All, n, n, 0, 0, vt, ldvt)
+
+	// Scale the matrix so that its largest element is one.
+	orgnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	if orgnrm == 0 {
+		impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+		impl.Dlaset(blas.All, n, n, 0, 1, vt, ldvt)
+		return true
+	}
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n-1, 1, e, 1)
+
+	// The left singular vectors are computed as the rows of u and are
+	// transposed at the end.
+	ok = impl.dbdsdcSolve(n, 0, d, e, u, ldu, vt, ldvt, smlsiz, wrk, iwork)
+	impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+	if !ok {
+		return false
+	}
+
+	// Reverse the singular values into decreasing order.
+	bi := blas64.Implementation()
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		d[i], d[j] = d[j], d[i]
+		bi.Dswap(n, u[i*ldu:], 1, u[j*ldu:], 1)
+		bi.Dswap(n, vt[i*ldvt:], 1, vt[j*ldvt:], 1)
+	}
+	transposeSquare(n, u, ldu)
+
+	if uplo == blas.Lower {
+		for i := n - 2; i >= 0; i-- {
+			bi.Drot(n, u[i*ldu:], 1, u[(i+1)*ldu:], 1, rot[2*i], -rot[2*i+1])
+		}
+	}
+	return true
+}
+
+// dbdsdcSolve computes the singular value decomposition of the n×(n+sqre)
+// upper bidiagonal matrix with diagonal d and off-diagonal e, where sqre is 0
+// or 1. If sqre is 1, e has length n and its last element is in column n.
+//
+// The singular values are stored in ascending order in d, the left singular
+// vectors in the rows of ut and the right singular vectors in the first n rows
+// of vt. If sqre is 1, the last row of vt holds a vector spanning the null
+// space of the matrix. ut and vt must be zero on entry.
+func (impl Implementation) dbdsdcSolve(n, sqre int, d, e, ut []float64, ldut int, vt []float64, ldvt int, smlsiz int, work []float64, iwork []int) bool {
+	bi := blas64.Implementation()
+	m := n + sqre
+
+	if n <= smlsiz {
+		impl.Dlaset(blas.All, n, n, 0, 1, ut, ldut)
+		impl.Dlaset(blas.All, m, m, 0, 1, vt, ldvt)
+		if sqre == 1 {
+			// Rotate the last column into the preceding columns to
+			// obtain a square upper bidiagonal matrix.
+			f := e[n-1]
+			for k := n - 1; k >= 0; k-- {
+				cs, sn, r := impl.Dlartg(d[k], f)
+				d[k] = r
+				if k > 0 {
+					f = -sn * e[k-1]
+					e[k-1] *= cs
+				}
+				bi.Drot(m, vt[k*ldvt:], 1, vt[n*ldvt:], 1, cs, sn)
+			}
+		}
+		if !impl.Dbdsqr(blas.Upper, n, m, 0, n, d, e, vt, ldvt, nil, 1, ut, ldut, work) {
+			return false
+		}
+		for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+			d[i], d[j] = d[j], d[i]
+			bi.Dswap(n, ut[i*ldut:], 1, ut[j*ldut:], 1)
+			bi.Dswap(m, vt[i*ldvt:], 1, vt[j*ldvt:], 1)
+		}
+		return true
+	}
+
+	// Split the matrix into a leading nl×(nl+1) block, the row nl and a
+	// trailing nr×(nr+sqre) block.
+	nl := n / 2
+	nr := n - nl - 1
+	alpha := d[nl]
+	beta := e[nl]
+	if !impl.dbdsdcSolve(nl, 1, d[:nl], e[:nl], ut, ldut, vt, ldvt, smlsiz, work, iwork) {
+		return false
+	}
+	off := nl + 1
+	if !impl.dbdsdcSolve(nr, sqre, d[off:n], e[off:], ut[off*ldut+off:], ldut, vt[off*ldvt+off:], ldvt, smlsiz, work, iwork) {
+		return false
+	}
+	ut[nl*ldut+nl] = 1
+	return impl.dbdsdcMerge(n, nl, sqre, d, alpha, beta, ut, ldut, vt, ldvt, work, iwork)
+}
+
+// dbdsdcMerge computes the singular value decomposition of the n×(n+sqre)
+// upper bidiagonal matrix whose leading nl×(nl+1) and trailing blocks have
+// singular values in d and singular vectors in the rows of the corresponding
+// diagonal blocks of ut and vt, and that are coupled by the row nl with
+// diagonal element alpha and off-diagonal element beta. On return d holds the
+// singular values in ascending order and ut and vt the singular vectors in
+// their rows.
+//
+// work must have length at least 3*n*n+5*n+1 and iwork at least 4*n.
+func (impl Implementation) dbdsdcMerge(n, nl, sqre int, d []float64, alpha, beta float64, ut []float64, ldut int, vt []float64, ldvt int, work []float64, iwork []int) bool {
+	bi := blas64.Implementation()
+	eps := dlamchE
+	m := n + sqre
+
+	z := work[:n+1]
+	dsigma := work[n+1 : 2*n+1]
+	w := work[2*n+1 : 3*n+1]
+	sigma := work[3*n+1 : 4*n+1]
+	a := work[4*n+1 : 4*n+1+n*n]
+	b := work[4*n+1+n*n : 4*n+1+2*n*n]
+	buf := work[4*n+1+2*n*n : 3*n*n+5*n+1]
+
+	perm := iwork[:n]
+	keep := iwork[n : 2*n]
+	defl := iwork[2*n : 3*n]
+	order := iwork[3*n : 4*n]
+
+	// Form the coupling row in the basis of the right singular vectors of
+	// the two blocks. The row nl of vt spans the null space of the leading
+	// block.
+	for j := 0; j <= nl; j++ {
+		z[j] = alpha * vt[j*ldvt+nl]
+	}
+	for j := nl + 1; j < m; j++ {
+		z[j] = beta * vt[j*ldvt+nl+1]
+	}
+	if sqre == 1 {
+		// Combine the null space vectors of the two blocks so that the
+		// last row of vt is orthogonal to the coupling row.
+		cs, sn, r := impl.Dlartg(z[nl], z[m-1])
+		bi.Drot(m, vt[nl*ldvt:], 1, vt[(m-1)*ldvt:], 1, cs, sn)
+		z[nl] = r
+	}
+	d[nl] = 0
+
+	// The matrix is now, up to a permutation, of the form
+	//  [ z_0 z_1 ... z_k ]
+	//  [     d_1         ]
+	//  [         ...     ]
+	//  [             d_k ]
+	// Order the rows with the coupling row first followed by the singular
+	// values of the two blocks merged into ascending order.
+	perm[0] = nl
+	i, j := 0, nl+1
+	for k := 1; k < n; k++ {
+		if j == n || (i < nl && d[i] <= d[j]) {
+			perm[k] = i
+			i++
+		} else {
+			perm[k] = j
+			j++
+		}
+	}
+
+	dmax := math.Max(math.Abs(alpha), math.Abs(beta))
+	for j := 0; j < n; j++ {
+		dmax = math.Max(dmax, math.Abs(d[j]))
+	}
+	tol := 8 * eps * dmax
+	if math.Abs(z[nl]) <= tol {
+		z[nl] = math.Copysign(tol, z[nl])
+	}
+
+	// Deflate singular values whose component in the coupling row is
+	// negligible, and singular value pairs that are close enough to be
+	// combined by a rotation that zeros one of their components.
+	keep[0] = nl
+	k, nd := 1, 0
+	pj := -1
+	for _, j := range perm[1:] {
+		if math.Abs(z[j]) <= tol {
+			defl[nd] = j
+			nd++
+			continue
+		}
+		if pj < 0 {
+			pj = j
+			continue
+		}
+		if math.Abs(d[j]-d[pj]) <= tol {
+			sn := z[pj]
+			cs := z[j]
+			tau := impl.Dlapy2(cs, sn)
+			cs /= tau
+			sn = -sn / tau
+			z[j] = tau
+			z[pj] = 0
+			bi.Drot(n, ut[pj*ldut:], 1, ut[j*ldut:], 1, cs, sn)
+			bi.Drot(m, vt[pj*ldvt:], 1, vt[j*ldvt:], 1, cs, sn)
+			defl[nd] = pj
+			nd++
+		} else {
+			keep[k] = pj
+			k++
+		}
+		pj = j
+	}
+	if pj >= 0 {
+		keep[k] = pj
+		k++
+	}
+
+	// Solve the secular equation for the singular values that are not
+	// deflated and compute the singular vectors of the arrow matrix, the
+	// left vectors in the rows of a and the right vectors in the rows of b.
+	for i := 0; i < k; i++ {
+		dsigma[i] = d[keep[i]]
+		w[i] = z[keep[i]]
+	}
+	if k == 1 {
+		sigma[0] = math.Abs(w[0])
+		a[0] = math.Copysign(1, w[0])
+		b[0] = 1
+	} else {
+		// Keep the smallest non-zero pole away from zero.
+		if dsigma[1] <= tol/2 {
+			dsigma[1] = tol / 2
+		}
+		rho := bi.Dnrm2(k, w, 1)
+		bi.Dscal(k, 1/rho, w, 1)
+		rho *= rho
+		for j := 0; j < k; j++ {
+			var ok bool
+			sigma[j], ok = impl.Dlasd4(k, j, dsigma, w, a[j*k:(j+1)*k], rho, b[j*k:(j+1)*k])
+			if !ok {
+				return false
+			}
+		}
+		// Recompute the coupling row from the computed singular values
+		// so that the singular vectors are numerically orthogonal.
+		for i := 0; i < k; i++ {
+			zi := a[(k-1)*k+i] * b[(k-1)*k+i]
+			for j := 0; j < i; j++ {
+				zi *= a[j*k+i] * b[j*k+i] / (dsigma[i] - dsigma[j]) / (dsigma[i] + dsigma[j])
+			}
+			for j := i; j < k-1; j++ {
+				zi *= a[j*k+i] * b[j*k+i] / (dsigma[i] - dsigma[j+1]) / (dsigma[i] + dsigma[j+1])
+			}
+			w[i] = math.Copysign(math.Sqrt(math.Abs(zi)), w[i])
+		}
+		for j := 0; j < k; j++ {
+			ua := a[j*k : (j+1)*k]
+			vb := b[j*k : (j+1)*k]
+			for i := range vb {
+				vb[i] = w[i] / (ua[i] * vb[i])
+				ua[i] = dsigma[i] * vb[i]
+			}
+			ua[0] = -1
+			bi.Dscal(k, 1/bi.Dnrm2(k, ua, 1), ua, 1)
+			bi.Dscal(k, 1/bi.Dnrm2(k, vb, 1), vb, 1)
+		}
+	}
+
+	// Update the singular vectors of the non-deflated singular values and
+	// append the deflated singular triplets.
+	for i := 0; i < k; i++ {
+		copy(buf[i*n:(i+1)*n], ut[keep[i]*ldut:keep[i]*ldut+n])
+	}
+	for i := 0; i < nd; i++ {
+		copy(buf[(k+i)*n:(k+i+1)*n], ut[defl[i]*ldut:defl[i]*ldut+n])
+		sigma[k+i] = d[defl[i]]
+	}
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, k, n, k, 1, a, k, buf, n, 0, ut, ldut)
+	if nd > 0 {
+		impl.Dlacpy(blas.All, nd, n, buf[k*n:], n, ut[k*ldut:], ldut)
+	}
+	for i := 0; i < k; i++ {
+		copy(buf[i*m:(i+1)*m], vt[keep[i]*ldvt:keep[i]*ldvt+m])
+	}
+	for i := 0; i < nd; i++ {
+		copy(buf[(k+i)*m:(k+i+1)*m], vt[defl[i]*ldvt:defl[i]*ldvt+m])
+	}
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, k, m, k, 1, b, k, buf, m, 0, vt, ldvt)
+	if nd > 0 {
+		impl.Dlacpy(blas.All, nd, m, buf[k*m:], m, vt[k*ldvt:], ldvt)
+	}
+
+	// Sort the singular triplets into ascending order.
+	sortRows(n, n, sigma, d, ut, ldut, order, buf)
+	sortRows(n, m, sigma, d, vt, ldvt, order, buf)
+	return true
+}
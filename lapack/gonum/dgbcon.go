@@ -0,0 +1,97 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgbcon estimates the reciprocal of the condition number of an n×n band
+// matrix A with kl sub-diagonals and ku super-diagonals, in either the 1-norm
+// or the ∞-norm, using the LU factorization computed by Dgbtrf. The condition
+// number computed is
+//  rcond = 1 / (norm(A) * norm(inv(A))).
+//
+// ab and ipiv must contain the LU factorization and the pivot indices as
+// returned by Dgbtrf. anorm is the corresponding 1-norm or ∞-norm of the
+// original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Dgbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dgbcon will panic otherwise.
+func (impl Implementation) Dgbcon(norm lapack.MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	switch {
+	case norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum:
+		panic(badNorm)
+	case n < 0:
+		panic(nLT0)
+	case kl < 0:
+		panic(klLT0)
+	case ku < 0:
+		panic(kuLT0)
+	case ldab < 2*kl+ku+1:
+		panic(badLdA)
+	case anorm < 0:
+		panic(negANorm)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1
+	}
+
+	switch {
+	case len(ab) < (n-1)*ldab+2*kl+ku+1:
+		panic(shortAB)
+	case len(ipiv) != n:
+		panic(badLenIpiv)
+	case len(work) < 2*n:
+		panic(shortWork)
+	case len(iwork) < n:
+		panic(shortIWork)
+	}
+
+	if anorm == 0 {
+		return 0
+	}
+
+	// Check that U is nonsingular.
+	for i := 0; i < n; i++ {
+		if ab[i*ldab+kl] == 0 {
+			return 0
+		}
+	}
+
+	// Estimate the norm of the inverse.
+	kase1 := 2
+	if norm == lapack.MaxColumnSum {
+		kase1 = 1
+	}
+	var (
+		ainvnm float64
+		kase   int
+		isave  [3]int
+	)
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, &isave)
+		if kase == 0 {
+			break
+		}
+		if kase == kase1 {
+			// Multiply by inv(L*U).
+			impl.Dgbtrs(blas.NoTrans, n, kl, ku, 1, ab, ldab, ipiv, work, 1)
+		} else {
+			// Multiply by inv(U^T*L^T).
+			impl.Dgbtrs(blas.Trans, n, kl, ku, 1, ab, ldab, ipiv, work, 1)
+		}
+	}
+
+	// Compute the estimate of the reciprocal condition number.
+	if ainvnm == 0 {
+		return 0
+	}
+	return (1 / ainvnm) / anorm
+}
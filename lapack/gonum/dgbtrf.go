@@ -0,0 +1,124 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas/blas64"
+
+// Dgbtrf computes an LU factorization of an m×n band matrix A with kl
+// sub-diagonals and ku super-diagonals using partial pivoting with row
+// interchanges. The form of the factorization is
+//  A = P * L * U,
+// where P is a permutation matrix, L is lower triangular with unit diagonal
+// elements and at most kl non-zero elements below the diagonal in each column,
+// and U is upper triangular with kl+ku super-diagonals.
+//
+// On entry, ab contains the matrix A in band storage. Row i of ab holds the
+// elements of row i of A from column i-kl to i+ku, so that
+//  A[i][j] = ab[i*ldab+kl+j-i]  for max(0,i-kl) <= j <= min(n-1,i+ku).
+// Row i of ab must also have space for kl further elements on the right which
+// are used to store the fill-in of U caused by the row interchanges, so ldab
+// must be at least 2*kl+ku+1. These elements need not be set on entry.
+//
+// The band storage scheme is illustrated below when m = n = 6, kl = 2 and
+// ku = 1. Elements marked * are not referenced and elements marked + are used
+// for fill-in.
+//
+//  On entry:               On exit:
+//   *   *  a11 a12  +   +   *   *  u11 u12 u13 u14
+//   *  a21 a22 a23  +   +   *  l21 u22 u23 u24 u25
+//  a31 a32 a33 a34  +   +  l31 l32 u33 u34 u35 u36
+//  a42 a43 a44 a45  +   +  l42 l43 u44 u45 u46  *
+//  a53 a54 a55 a56  +   +  l53 l54 u55 u56  *   *
+//  a64 a65 a66  *   +   +  l64 l65 u66  *   *   *
+//
+// On return, the upper triangle of ab contains U in band storage with kl+ku
+// super-diagonals, and the multipliers used during the factorization are
+// stored in the sub-diagonal elements. The row interchanges are recorded in
+// ipiv, row i of the matrix was interchanged with row ipiv[i].
+//
+// ipiv must have length min(m,n), otherwise Dgbtrf will panic.
+//
+// Dgbtrf returns whether U is nonsingular. If ok is false, U has an exactly
+// zero diagonal element and the factorization has been completed, but U is
+// exactly singular, and division by zero will occur if it is used to solve a
+// system of equations.
+//
+// Dgbtrf uses an unblocked algorithm which is efficient when kl and ku are
+// small compared to n.
+func (Implementation) Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool) {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case kl < 0:
+		panic(klLT0)
+	case ku < 0:
+		panic(kuLT0)
+	case ldab < 2*kl+ku+1:
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return true
+	}
+
+	rows := min(m, n+kl)
+	switch {
+	case len(ab) < (rows-1)*ldab+2*kl+ku+1:
+		panic(shortAB)
+	case len(ipiv) != min(m, n):
+		panic(badLenIpiv)
+	}
+
+	bi := blas64.Implementation()
+
+	// kv is the number of super-diagonals of U.
+	kv := kl + ku
+	// Moving down a column of A moves back by one in ab.
+	kld := max(1, ldab-1)
+
+	// Set the fill-in elements to zero.
+	for i := 0; i < rows; i++ {
+		for j := ku + 1; j <= kv; j++ {
+			ab[i*ldab+kl+j] = 0
+		}
+	}
+
+	ok = true
+	// ju is the index of the last column affected by the current stage
+	// of the factorization.
+	var ju int
+	for j := 0; j < min(m, n); j++ {
+		// Find the pivot and test for singularity. km is the number of
+		// sub-diagonal elements in the current column.
+		km := min(kl, m-j-1)
+		jp := bi.Idamax(km+1, ab[j*ldab+kl:], kld)
+		ipiv[j] = j + jp
+		if ab[(j+jp)*ldab+kl-jp] == 0 {
+			// The column is exactly zero, so the factorization
+			// proceeds with the next column.
+			ok = false
+			continue
+		}
+		ju = max(ju, min(j+ku+jp, n-1))
+
+		// Apply the interchange to columns j:ju.
+		if jp != 0 {
+			bi.Dswap(ju-j+1, ab[(j+jp)*ldab+kl-jp:], 1, ab[j*ldab+kl:], 1)
+		}
+		if km > 0 {
+			// Compute the multipliers.
+			bi.Dscal(km, 1/ab[j*ldab+kl], ab[(j+1)*ldab+kl-1:], kld)
+			// Update the trailing submatrix within the band.
+			if ju > j {
+				bi.Dger(km, ju-j, -1, ab[(j+1)*ldab+kl-1:], kld, ab[j*ldab+kl+1:], 1,
+					ab[(j+1)*ldab+kl:], kld)
+			}
+		}
+	}
+	return ok
+}
@@ -0,0 +1,107 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dgeequ computes row and column scalings intended to equilibrate the m×n
+// matrix A and reduce its condition number. The scaling factors are returned
+// in r and c so that the elements of
+//  B = diag(r) * A * diag(c)
+// have a largest absolute value of 1 in each row and column. The scaling
+// factors are not powers of the radix so the scaling may introduce rounding
+// errors.
+//
+// rowcnd is the ratio of the smallest to the largest r[i]. If rowcnd ≥ 0.1 and
+// amax is neither too large nor too small, it is not worth scaling by r.
+//
+// colcnd is the ratio of the smallest to the largest c[j]. If colcnd ≥ 0.1, it
+// is not worth scaling by c.
+//
+// amax is the absolute value of the largest element of A. If amax is very
+// close to overflow or very close to underflow, the matrix should be scaled.
+//
+// If a row or a column of A is exactly zero, ok will be false and the returned
+// scaling factors are not valid.
+//
+// r must have length at least m and c must have length at least n, otherwise
+// Dgeequ will panic.
+func (impl Implementation) Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool) {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 1, 1, 0, true
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case len(r) < m:
+		panic(shortR)
+	case len(c) < n:
+		panic(shortC)
+	}
+
+	smlnum := dlamchS
+	bignum := 1 / smlnum
+
+	// Compute the row scale factors.
+	for i := 0; i < m; i++ {
+		var rmax float64
+		for _, v := range a[i*lda : i*lda+n] {
+			rmax = math.Max(rmax, math.Abs(v))
+		}
+		r[i] = rmax
+	}
+	rcmin := bignum
+	var rcmax float64
+	for _, v := range r[:m] {
+		rcmax = math.Max(rcmax, v)
+		rcmin = math.Min(rcmin, v)
+	}
+	amax = rcmax
+	if rcmin == 0 {
+		// A has a zero row.
+		return 0, 0, amax, false
+	}
+	for i := 0; i < m; i++ {
+		r[i] = 1 / math.Min(math.Max(r[i], smlnum), bignum)
+	}
+	rowcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+
+	// Compute the column scale factors assuming the row scaling.
+	for j := 0; j < n; j++ {
+		c[j] = 0
+	}
+	for i := 0; i < m; i++ {
+		ri := r[i]
+		for j, v := range a[i*lda : i*lda+n] {
+			c[j] = math.Max(c[j], math.Abs(v)*ri)
+		}
+	}
+	rcmin = bignum
+	rcmax = 0
+	for _, v := range c[:n] {
+		rcmin = math.Min(rcmin, v)
+		rcmax = math.Max(rcmax, v)
+	}
+	if rcmin == 0 {
+		// A has a zero column.
+		return rowcnd, 0, amax, false
+	}
+	for j := 0; j < n; j++ {
+		c[j] = 1 / math.Min(math.Max(c[j], smlnum), bignum)
+	}
+	colcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+	return rowcnd, colcnd, amax, true
+}
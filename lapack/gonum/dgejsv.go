@@ -0,0 +1,259 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgejsv computes the singular value decomposition of an m×n matrix A with
+// m >= n using the preconditioned one-sided Jacobi method. The singular value
+// decomposition is
+//  A = U * Σ * V^T
+// where Σ is an m×n diagonal matrix containing the singular values of A, U is
+// an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first n
+// columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// The rows of A are first sorted by decreasing norm and A is factorized as
+//  A*P = Q*R
+// by a QR factorization with column pivoting. The LQ factorization
+// R = L*Q2 of the leading rows of R that are not negligible then yields a
+// matrix L with nearly orthogonal columns, whose singular value decomposition
+// is computed by Dgesvj in a few sweeps. If A = B*D or A = D*B where D is
+// diagonal and B is well-conditioned, the singular values are computed to
+// high relative accuracy, that is, also the smallest singular values have
+// almost all of their digits correct.
+//
+// jobU specifies which left singular vectors are computed. If jobU is
+// lapack.SVDAll, the m×m matrix U is stored in u, if jobU is lapack.SVDStore,
+// the first n columns of U are stored in u, and if jobU is lapack.SVDNone, u is
+// not referenced.
+//
+// jobV specifies whether the right singular vectors are computed. If jobV is
+// lapack.SVDAll, the n×n matrix V is stored in v, otherwise jobV must be
+// lapack.SVDNone and v is not referenced. Note that v contains V and not V^T
+// on return.
+//
+// On entry, a contains the m×n matrix A and on return the contents of a are
+// destroyed.
+//
+// sva must have length at least n and on return it contains the singular
+// values of A in decreasing order.
+//
+// work must have length at least max(1, lwork) and lwork must be at least
+//  2*n*n + 2*n + max(m, 3*n+1),
+// otherwise Dgejsv will panic. If lwork == -1, instead of computing the
+// decomposition, the optimal length of work is stored into work[0].
+//
+// iwork must have length at least m+n, and Dgejsv will panic otherwise.
+//
+// Dgejsv returns whether the Jacobi iteration converged.
+func (impl Implementation) Dgejsv(jobU, jobV lapack.SVDJob, m, n int, a []float64, lda int, sva, u []float64, ldu int, v []float64, ldv int, work []float64, lwork int, iwork []int) (ok bool) {
+	wantua := jobU == lapack.SVDAll
+	wantus := jobU == lapack.SVDStore
+	wantu := wantua || wantus
+	wantv := jobV == lapack.SVDAll
+	switch {
+	case !wantu && jobU != lapack.SVDNone:
+		panic(badSVDJob)
+	case !wantv && jobV != lapack.SVDNone:
+		panic(badSVDJob)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case m < n:
+		panic(mLTN)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldu < 1, wantua && ldu < m, wantus && ldu < n:
+		panic(badLdU)
+	case ldv < 1, wantv && ldv < n:
+		panic(badLdV)
+	}
+
+	// The first 2*n*n + 2*n elements of work hold the scalar factors of the
+	// elementary reflectors, the LQ factorization of R and the matrix L
+	// that is orthogonalized by Dgesvj.
+	ncu := n
+	if wantua {
+		ncu = m
+	}
+	nwork := 2*n*n + 2*n
+	lwmin := 1
+	lwopt := 1
+	if n > 0 {
+		lwmin = nwork + max(m, 3*n+1)
+		impl.Dgeqp3(m, n, a, lda, nil, nil, work, -1)
+		lwopt = max(lwmin, nwork+int(work[0]))
+		impl.Dgelqf(n, n, a, lda, nil, work, -1)
+		lwopt = max(lwopt, nwork+int(work[0]))
+		if wantu {
+			impl.Dormqr(blas.Left, blas.NoTrans, m, ncu, n, a, lda, nil, u, ldu, work, -1)
+			lwopt = max(lwopt, nwork+int(work[0]))
+		}
+		if wantv {
+			impl.Dormlq(blas.Left, blas.Trans, n, n, n, a, lda, nil, v, ldv, work, -1)
+			lwopt = max(lwopt, nwork+int(work[0]))
+		}
+	}
+	switch {
+	case lwork < lwmin && lwork != -1:
+		panic(badLWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+
+	if lwork == -1 {
+		work[0] = float64(lwopt)
+		return true
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return true
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case len(sva) < n:
+		panic(shortSVA)
+	case wantu && len(u) < (m-1)*ldu+ncu:
+		panic(shortU)
+	case wantv && len(v) < (n-1)*ldv+n:
+		panic(shortV)
+	case len(iwork) < m+n:
+		panic(shortIWork)
+	}
+
+	bi := blas64.Implementation()
+
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	if anrm == 0 {
+		for i := range sva[:n] {
+			sva[i] = 0
+		}
+		if wantu {
+			impl.Dlaset(blas.All, m, ncu, 0, 1, u, ldu)
+		}
+		if wantv {
+			impl.Dlaset(blas.All, n, n, 0, 1, v, ldv)
+		}
+		work[0] = float64(lwopt)
+		return true
+	}
+
+	// Scale A if max element outside range [smlnum, bignum].
+	smlnum := math.Sqrt(dlamchS) / dlamchP
+	bignum := 1 / smlnum
+	var scale float64
+	if anrm < smlnum {
+		scale = smlnum
+	} else if anrm > bignum {
+		scale = bignum
+	}
+	if scale != 0 {
+		impl.Dlascl(lapack.General, 0, 0, anrm, scale, m, n, a, lda)
+	}
+
+	ipiv := iwork[:m]
+	jpvt := iwork[m : m+n]
+	tau1 := work[:n]
+	tau2 := work[n : 2*n]
+	lq := work[2*n : 2*n+n*n]
+	l := work[2*n+n*n : nwork]
+	wrk := work[nwork:]
+	lwrk := lwork - nwork
+
+	// Sort the rows of A by decreasing norm so that the Householder QR
+	// factorization is accurate also for matrices with badly scaled rows.
+	for i := 0; i < m; i++ {
+		wrk[i] = bi.Dnrm2(n, a[i*lda:], 1)
+	}
+	for i := 0; i < m; i++ {
+		k := i + bi.Idamax(m-i, wrk[i:], 1)
+		ipiv[i] = k
+		if k != i {
+			wrk[i], wrk[k] = wrk[k], wrk[i]
+			bi.Dswap(n, a[i*lda:], 1, a[k*lda:], 1)
+		}
+	}
+
+	// Compute the QR factorization with column pivoting A*P = Q*R.
+	for j := range jpvt {
+		jpvt[j] = -1
+	}
+	impl.Dgeqp3(m, n, a, lda, jpvt, tau1, wrk, lwrk)
+
+	// Determine the number nr of rows of R that are not negligible. The
+	// diagonal elements of R are non-increasing in magnitude.
+	small := dlamchS / dlamchP * math.Abs(a[0])
+	nr := 1
+	for nr < n && math.Abs(a[nr*lda+nr]) > small {
+		nr++
+	}
+
+	// Compute the LQ factorization R[:nr,:] = L*Q2.
+	impl.Dlaset(blas.Lower, nr, n, 0, 0, lq, n)
+	impl.Dlacpy(blas.Upper, nr, n, a, lda, lq, n)
+	impl.Dgelqf(nr, n, lq, n, tau2, wrk, lwrk)
+
+	// Compute the singular value decomposition L = U_L * Σ * V_L^T with the
+	// one-sided Jacobi method.
+	impl.Dlaset(blas.Upper, nr, nr, 0, 0, l, n)
+	impl.Dlacpy(blas.Lower, nr, nr, lq, n, l, n)
+	jobUL := lapack.SVDNone
+	if wantu {
+		jobUL = lapack.SVDOverwrite
+	}
+	ok = impl.Dgesvj(jobUL, jobV, nr, nr, l, n, sva, v, ldv, wrk, lwrk)
+	for i := nr; i < n; i++ {
+		sva[i] = 0
+	}
+
+	// A*P = Q*R = Q * [U_L*Σ*V_L^T*Q2; 0], so the left singular vectors are
+	//  U = Pr^T * Q * diag(U_L, I),
+	// where Pr is the permutation of the rows of A, and the right singular
+	// vectors are
+	//  V = P * Q2^T * diag(V_L, I).
+	if wantu {
+		impl.Dlaset(blas.All, m, ncu, 0, 1, u, ldu)
+		impl.Dlacpy(blas.All, nr, nr, l, n, u, ldu)
+		impl.Dormqr(blas.Left, blas.NoTrans, m, ncu, n, a, lda, tau1, u, ldu, wrk, lwrk)
+		impl.Dlaswp(ncu, u, ldu, 0, m-1, ipiv, -1)
+	}
+	if wantv {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i >= nr || j >= nr {
+					v[i*ldv+j] = 0
+				}
+			}
+			if i >= nr {
+				v[i*ldv+i] = 1
+			}
+		}
+		impl.Dormlq(blas.Left, blas.Trans, n, n, nr, lq, n, tau2, v, ldv, wrk, lwrk)
+
+		// Apply P to the rows of V by permuting the columns of V^T.
+		transposeSquare(n, v, ldv)
+		impl.Dlapmt(false, n, n, v, ldv, jpvt)
+		transposeSquare(n, v, ldv)
+	}
+
+	// Undo the scaling of A.
+	if scale != 0 {
+		impl.Dlascl(lapack.General, 0, 0, scale, anrm, 1, n, sva, n)
+	}
+	work[0] = float64(lwopt)
+	return ok
+}
@@ -0,0 +1,211 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgelss computes the minimum-norm solution to the linear least squares
+// problem
+//  minimize ||B - A*X||_2
+// using the singular value decomposition of the m×n matrix A. A may be rank
+// deficient.
+//
+// Each column of B is treated as a separate right-hand side, so the solution
+// for the j-th column of B is the minimum-norm vector x that minimizes
+//  ||b_j - A*x||_2.
+//
+// The effective rank of A is determined by treating as zero those singular
+// values s[i] that satisfy
+//  s[i] <= rcond * s[0],
+// where s[0] is the largest singular value. If rcond < 0, machine precision
+// is used instead.
+//
+// On entry, a contains the m×n matrix A. On return, the first min(m,n) rows
+// of a are overwritten with the right singular vectors of A, stored row-wise.
+//
+// On entry, b contains the m×nrhs right-hand side matrix B. b must have
+// max(m,n) rows. On return, the first n rows of b contain the n×nrhs solution
+// matrix X. If m >= n and the effective rank is n, the residual sum of squares
+// for the solution in the j-th column is given by the sum of squares of the
+// elements in rows n to m-1 of that column.
+//
+// On return, s contains the singular values of A in decreasing order. s must
+// have length min(m,n).
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// If min(m,n) > 0, lwork must be at least 3*min(m,n) + max(m, n, nrhs, 2*min(m,n)),
+// otherwise lwork must be at least 1, and Dgelss will panic otherwise. A longer
+// work will enable blocked algorithms to be called. In the special case that lwork == -1, work[0] will
+// be set to the optimal working length.
+//
+// Dgelss returns the effective rank of A and whether the singular value
+// decomposition converged. If ok is false, the values in b are not the
+// solution.
+func (impl Implementation) Dgelss(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int) (rank int, ok bool) {
+	mn := min(m, n)
+	maxmn := max(m, n)
+	minwrk := 1
+	if mn > 0 {
+		minwrk = 3*mn + max(max(maxmn, nrhs), 2*mn)
+	}
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic(nrhsLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldb < max(1, nrhs):
+		panic(badLdB)
+	case lwork < minwrk && lwork != -1:
+		panic(badLWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+
+	// Quick return if possible.
+	if mn == 0 {
+		impl.Dlaset(blas.All, maxmn, nrhs, 0, 0, b, ldb)
+		work[0] = 1
+		return 0, true
+	}
+
+	// Compute the optimal workspace size.
+	impl.Dgebrd(m, n, a, lda, nil, nil, nil, nil, work, -1)
+	lwrk := int(work[0])
+	impl.Dormbr(lapack.ApplyQ, blas.Left, blas.Trans, m, nrhs, n, a, lda, nil, b, ldb, work, -1)
+	lwrk = max(lwrk, int(work[0]))
+	impl.Dorgbr(lapack.GeneratePT, mn, n, m, a, lda, nil, work, -1)
+	lwrk = max(lwrk, int(work[0]))
+	maxwrk := max(minwrk, max(3*mn+lwrk, n*nrhs))
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return 0, true
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case nrhs > 0 && len(b) < (maxmn-1)*ldb+nrhs:
+		panic(shortB)
+	case len(s) < mn:
+		panic(shortS)
+	}
+
+	// Scale A if max element outside range [smlnum,bignum].
+	smlnum := dlamchS / dlamchP
+	bignum := 1 / smlnum
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	var iascl int
+	switch {
+	case anrm == 0:
+		// Matrix is all zeros.
+		impl.Dlaset(blas.All, maxmn, nrhs, 0, 0, b, ldb)
+		for i := range s[:mn] {
+			s[i] = 0
+		}
+		work[0] = float64(maxwrk)
+		return 0, true
+	case anrm < smlnum:
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, m, n, a, lda)
+		iascl = 1
+	case anrm > bignum:
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, m, n, a, lda)
+		iascl = 2
+	}
+
+	// Scale B if max element outside range [smlnum,bignum].
+	bnrm := impl.Dlange(lapack.MaxAbs, m, nrhs, b, ldb, nil)
+	var ibscl int
+	switch {
+	case bnrm > 0 && bnrm < smlnum:
+		impl.Dlascl(lapack.General, 0, 0, bnrm, smlnum, m, nrhs, b, ldb)
+		ibscl = 1
+	case bnrm > bignum:
+		impl.Dlascl(lapack.General, 0, 0, bnrm, bignum, m, nrhs, b, ldb)
+		ibscl = 2
+	}
+
+	// Bidiagonalize A, A = Q * B * P^T.
+	ie := 0
+	itauq := ie + mn
+	itaup := itauq + mn
+	iwork := itaup + mn
+	impl.Dgebrd(m, n, a, lda, s, work[ie:itauq], work[itauq:itaup], work[itaup:iwork], work[iwork:], lwork-iwork)
+
+	// Multiply B by the transpose of the left bidiagonalizing vectors.
+	impl.Dormbr(lapack.ApplyQ, blas.Left, blas.Trans, m, nrhs, n, a, lda, work[itauq:itaup], b, ldb, work[iwork:], lwork-iwork)
+
+	// Generate the right bidiagonalizing vectors in the first mn rows of A.
+	impl.Dorgbr(lapack.GeneratePT, mn, n, m, a, lda, work[itaup:iwork], work[iwork:], lwork-iwork)
+
+	// Perform the bidiagonal QR iteration, computing the right singular
+	// vectors of A in A and multiplying B by the transpose of the left
+	// singular vectors.
+	uplo := blas.Upper
+	if m < n {
+		uplo = blas.Lower
+	}
+	ok = impl.Dbdsqr(uplo, mn, n, 0, nrhs, s, work[ie:itauq], a, lda, nil, 1, b, ldb, work[itauq:])
+	if !ok {
+		return 0, false
+	}
+
+	// Multiply B by the reciprocals of the singular values.
+	thr := rcond * s[0]
+	if rcond < 0 {
+		thr = dlamchE * s[0]
+	}
+	thr = math.Max(thr, dlamchS)
+	bi := blas64.Implementation()
+	for i := 0; i < mn; i++ {
+		if s[i] > thr {
+			rank++
+			if nrhs > 0 {
+				bi.Dscal(nrhs, 1/s[i], b[i*ldb:], 1)
+			}
+		} else {
+			for j := 0; j < nrhs; j++ {
+				b[i*ldb+j] = 0
+			}
+		}
+	}
+
+	// Multiply B by the right singular vectors, X = V * B, in blocks of
+	// columns using work as temporary storage.
+	chunk := max(1, min(nrhs, lwork/n))
+	for j := 0; j < nrhs; j += chunk {
+		bl := min(chunk, nrhs-j)
+		bi.Dgemm(blas.Trans, blas.NoTrans, n, bl, mn, 1, a, lda, b[j:], ldb, 0, work, bl)
+		impl.Dlacpy(blas.All, n, bl, work, bl, b[j:], ldb)
+	}
+
+	// Undo scaling.
+	switch iascl {
+	case 1:
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, n, nrhs, b, ldb)
+		impl.Dlascl(lapack.General, 0, 0, smlnum, anrm, mn, 1, s, 1)
+	case 2:
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, n, nrhs, b, ldb)
+		impl.Dlascl(lapack.General, 0, 0, bignum, anrm, mn, 1, s, 1)
+	}
+	switch ibscl {
+	case 1:
+		impl.Dlascl(lapack.General, 0, 0, smlnum, bnrm, n, nrhs, b, ldb)
+	case 2:
+		impl.Dlascl(lapack.General, 0, 0, bignum, bnrm, n, nrhs, b, ldb)
+	}
+
+	work[0] = float64(maxwrk)
+	return rank, true
+}
@@ -6,6 +6,7 @@ package gonum
 
 import (
 	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
 	"gonum.org/v1/gonum/lapack"
 )
 
@@ -20,6 +21,13 @@ import (
 // by the temporary space available. If lwork == -1, instead of performing Dgeqrf,
 // the optimal work length will be stored into work[0].
 //
+// If Ilaenv(7, ...) reports more than one worker, which is only the case when
+// the number of workers has been set explicitly, the triangular products in
+// the application of the block reflectors to the trailing columns are
+// computed concurrently in blocks of columns by that number of workers. The
+// matrix multiplications are computed by Dgemm in either case, and the result
+// is the same as that of the serial algorithm.
+//
 // tau must have length at least min(m,n), and this function will panic otherwise.
 func (impl Implementation) Dgeqrf(m, n int, a []float64, lda int, tau, work []float64, lwork int) {
 	switch {
@@ -79,6 +87,7 @@ func (impl Implementation) Dgeqrf(m, n int, a []float64, lda int, tau, work []fl
 	// Compute QR using a blocked algorithm.
 	var i int
 	if nbmin <= nb && nb < k && nx < k {
+		nw := impl.Ilaenv(7, "DGEQRF", " ", m, n, -1, -1)
 		ldwork := nb
 		for i = 0; i < k-nx; i += nb {
 			ib := min(k-i, nb)
@@ -91,12 +100,20 @@ func (impl Implementation) Dgeqrf(m, n int, a []float64, lda int, tau, work []fl
 					a[i*lda+i:], lda,
 					tau[i:],
 					work, ldwork)
-				impl.Dlarfb(blas.Left, blas.Trans, lapack.Forward, lapack.ColumnWise,
-					m-i, n-i-ib, ib,
-					a[i*lda+i:], lda,
-					work, ldwork,
-					a[i*lda+i+ib:], lda,
-					work[ib*ldwork:], ldwork)
+				if nw > 1 {
+					impl.dlarfbParallel(nw, nb, m-i, n-i-ib, ib,
+						a[i*lda+i:], lda,
+						work, ldwork,
+						a[i*lda+i+ib:], lda,
+						work[ib*ldwork:], ldwork)
+				} else {
+					impl.Dlarfb(blas.Left, blas.Trans, lapack.Forward, lapack.ColumnWise,
+						m-i, n-i-ib, ib,
+						a[i*lda+i:], lda,
+						work, ldwork,
+						a[i*lda+i+ib:], lda,
+						work[ib*ldwork:], ldwork)
+				}
 			}
 		}
 	}
@@ -106,3 +123,54 @@ func (impl Implementation) Dgeqrf(m, n int, a []float64, lda int, tau, work []fl
 	}
 	work[0] = float64(iws)
 }
+
+// dlarfbParallel applies the transpose of the block reflector H defined by
+// the k elementary reflectors stored column-wise in v and the triangular
+// factor t to the m×n matrix C from the left, as
+//  Dlarfb(blas.Left, blas.Trans, lapack.Forward, lapack.ColumnWise, ...)
+// does. The rows of the work matrix W = C^T * V that correspond to each block
+// of nb columns of C are formed and transformed concurrently by at most nw
+// goroutines, while the two matrix multiplications are computed by Dgemm in
+// the calling goroutine.
+func (impl Implementation) dlarfbParallel(nw, nb, m, n, k int, v []float64, ldv int, t []float64, ldt int, c []float64, ldc int, work []float64, ldwork int) {
+	bi := blas64.Implementation()
+
+	// W = C1^T * V1.
+	parallelBlocks(nw, 0, n, nb, func(j0, j1 int) {
+		for j := 0; j < k; j++ {
+			bi.Dcopy(j1-j0, c[j*ldc+j0:], 1, work[j0*ldwork+j:], ldwork)
+		}
+		bi.Dtrmm(blas.Right, blas.Lower, blas.NoTrans, blas.Unit, j1-j0, k,
+			1, v, ldv,
+			work[j0*ldwork:], ldwork)
+	})
+	if m > k {
+		// W += C2^T * V2.
+		bi.Dgemm(blas.Trans, blas.NoTrans, n, k, m-k,
+			1, c[k*ldc:], ldc, v[k*ldv:], ldv,
+			1, work, ldwork)
+	}
+	// W *= T.
+	parallelBlocks(nw, 0, n, nb, func(j0, j1 int) {
+		bi.Dtrmm(blas.Right, blas.Upper, blas.NoTrans, blas.NonUnit, j1-j0, k,
+			1, t, ldt,
+			work[j0*ldwork:], ldwork)
+	})
+	if m > k {
+		// C2 -= V2 * W^T.
+		bi.Dgemm(blas.NoTrans, blas.Trans, m-k, n, k,
+			-1, v[k*ldv:], ldv, work, ldwork,
+			1, c[k*ldc:], ldc)
+	}
+	// C1 -= (W * V1^T)^T.
+	parallelBlocks(nw, 0, n, nb, func(j0, j1 int) {
+		bi.Dtrmm(blas.Right, blas.Lower, blas.Trans, blas.Unit, j1-j0, k,
+			1, v, ldv,
+			work[j0*ldwork:], ldwork)
+		for i := j0; i < j1; i++ {
+			for j := 0; j < k; j++ {
+				c[j*ldc+i] -= work[i*ldwork+j]
+			}
+		}
+	})
+}
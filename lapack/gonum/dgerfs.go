@@ -0,0 +1,221 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dgerfs improves the computed solution to a system of linear equations
+//  op(A) * X = B
+// where A is an n×n general matrix and op(A) is A or A^T depending on trans,
+// and provides error bounds and backward error estimates for the solution.
+//
+// a contains the original matrix A and af contains its LU factorization as
+// computed by Dgetrf, with the pivot indices in ipiv. The factorization in af
+// need only be an approximation to the factorization of A, for example it may
+// be computed from a lower precision copy of A; iterative refinement uses
+// residuals computed with A in double precision.
+//
+// On entry, x contains the solution matrix X as computed by Dgetrs. On return
+// it contains the improved solution.
+//
+// ferr contains on return the estimated forward error bound for each solution
+// vector, that is an estimate of
+//  max_i |X_j[i] - Xtrue_j[i]| / max_i |X_j[i]|
+// for the j-th column. The estimate is as reliable as the estimate for the
+// condition number and is almost always a slight overestimate of the true
+// error.
+//
+// berr contains on return the componentwise relative backward error of each
+// solution vector, that is the smallest relative change in any element of A
+// or B that makes X_j an exact solution.
+//
+// ferr and berr must have length at least nrhs, work must have length at least
+// 3*n and iwork must have length at least n, otherwise Dgerfs will panic.
+func (impl Implementation) Dgerfs(trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) {
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic(nrhsLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldaf < max(1, n):
+		panic(badLdAF)
+	case ldb < max(1, nrhs):
+		panic(badLdB)
+	case ldx < max(1, nrhs):
+		panic(badLdX)
+	}
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		switch {
+		case len(ferr) < nrhs:
+			panic(shortFerr)
+		case len(berr) < nrhs:
+			panic(shortBerr)
+		}
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(af) < (n-1)*ldaf+n:
+		panic(shortAF)
+	case len(ipiv) != n:
+		panic(badLenIpiv)
+	case len(b) < (n-1)*ldb+nrhs:
+		panic(shortB)
+	case len(x) < (n-1)*ldx+nrhs:
+		panic(shortX)
+	case len(ferr) < nrhs:
+		panic(shortFerr)
+	case len(berr) < nrhs:
+		panic(shortBerr)
+	case len(work) < 3*n:
+		panic(shortWork)
+	case len(iwork) < n:
+		panic(shortIWork)
+	}
+
+	const itmax = 5
+
+	notran := trans == blas.NoTrans
+	transt := blas.NoTrans
+	if notran {
+		transt = blas.Trans
+	}
+
+	// nz is the maximum number of non-zero entries in each row of A plus 1.
+	nz := float64(n + 1)
+	eps := dlamchE
+	safmin := dlamchS
+	safe1 := nz * safmin
+	safe2 := safe1 / eps
+
+	bi := blas64.Implementation()
+	isave := new([3]int)
+	for j := 0; j < nrhs; j++ {
+		xj := x[j:]
+		lstres := 3.0
+		for count := 1; ; count++ {
+			// Compute the residual R = B - op(A) * X in work[n:2*n].
+			bi.Dcopy(n, b[j:], ldb, work[n:2*n], 1)
+			bi.Dgemv(trans, n, n, -1, a, lda, xj, ldx, 1, work[n:2*n], 1)
+
+			// Compute componentwise relative backward error from the formula
+			//  max_i (|R[i]| / (|op(A)|*|X| + |B|)[i])
+			// where abs(Z) is the componentwise absolute value of the matrix
+			// or vector Z. If the i-th component of the denominator is less
+			// than safe2, then safe1 is added to the i-th components of the
+			// numerator and denominator before dividing.
+			for i := 0; i < n; i++ {
+				work[i] = math.Abs(b[i*ldb+j])
+			}
+			if notran {
+				for i := 0; i < n; i++ {
+					var s float64
+					for k, v := range a[i*lda : i*lda+n] {
+						s += math.Abs(v) * math.Abs(xj[k*ldx])
+					}
+					work[i] += s
+				}
+			} else {
+				for i := 0; i < n; i++ {
+					xi := math.Abs(xj[i*ldx])
+					for k, v := range a[i*lda : i*lda+n] {
+						work[k] += math.Abs(v) * xi
+					}
+				}
+			}
+			var s float64
+			for i := 0; i < n; i++ {
+				if work[i] > safe2 {
+					s = math.Max(s, math.Abs(work[n+i])/work[i])
+				} else {
+					s = math.Max(s, (math.Abs(work[n+i])+safe1)/(work[i]+safe1))
+				}
+			}
+			berr[j] = s
+
+			// Test stopping criterion. Continue iterating if
+			//  1) the residual berr[j] is larger than machine epsilon, and
+			//  2) berr[j] decreased by at least a factor of 2 during the
+			//     last iteration, and
+			//  3) at most itmax iterations tried.
+			if berr[j] <= eps || 2*berr[j] > lstres || count > itmax {
+				break
+			}
+			// Update solution and try again.
+			impl.Dgetrs(trans, n, 1, af, ldaf, ipiv, work[n:2*n], 1)
+			bi.Daxpy(n, 1, work[n:2*n], 1, xj, ldx)
+			lstres = berr[j]
+		}
+
+		// Bound error from formula
+		//  norm(X - XTRUE) / norm(X) ≤ ferr = norm(|inv(op(A))|*
+		//     (|R| + nz*eps*(|op(A)|*|X|+|B|))) / norm(X)
+		// where norm(Z) is the magnitude of the largest component of Z,
+		// inv(op(A)) is the inverse of op(A), |Z| is the componentwise
+		// absolute value of the matrix or vector Z, nz is the maximum number
+		// of nonzeros in any row of A plus 1, and eps is machine epsilon.
+		//
+		// The i-th component of |R| + nz*eps*(|op(A)|*|X|+|B|) must be
+		// increased by safe1 if the i-th component of |op(A)|*|X|+|B| is
+		// less than safe2.
+		//
+		// Use Dlacn2 to estimate the infinity-norm of the matrix
+		//  inv(op(A)) * diag(W),
+		// where W = |R| + nz*eps*(|op(A)|*|X|+|B|).
+		for i := 0; i < n; i++ {
+			if work[i] > safe2 {
+				work[i] = math.Abs(work[n+i]) + nz*eps*work[i]
+			} else {
+				work[i] = math.Abs(work[n+i]) + nz*eps*work[i] + safe1
+			}
+		}
+		var kase int
+		for {
+			ferr[j], kase = impl.Dlacn2(n, work[2*n:3*n], work[n:2*n], iwork, ferr[j], kase, isave)
+			if kase == 0 {
+				break
+			}
+			if kase == 1 {
+				// Multiply by diag(W)*inv(op(A)^T).
+				impl.Dgetrs(transt, n, 1, af, ldaf, ipiv, work[n:2*n], 1)
+				for i := 0; i < n; i++ {
+					work[n+i] *= work[i]
+				}
+			} else {
+				// Multiply by inv(op(A))*diag(W).
+				for i := 0; i < n; i++ {
+					work[n+i] *= work[i]
+				}
+				impl.Dgetrs(trans, n, 1, af, ldaf, ipiv, work[n:2*n], 1)
+			}
+		}
+
+		// Normalize error.
+		var xmax float64
+		for i := 0; i < n; i++ {
+			xmax = math.Max(xmax, math.Abs(xj[i*ldx]))
+		}
+		if xmax != 0 {
+			ferr[j] /= xmax
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgesdd computes the singular value decomposition of the input matrix A
+// using the divide and conquer method.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// Dgesdd is usually considerably faster than Dgesvd for large matrices when
+// the singular vectors are computed.
+//
+// jobz specifies which singular vectors are computed. The behavior is as
+// follows
+//  jobz == lapack.SVDAll   All m columns of U and all n rows of V^T are
+//                          returned in u and vt
+//  jobz == lapack.SVDStore The first min(m,n) columns of U and the first
+//                          min(m,n) rows of V^T are returned in u and vt
+//  jobz == lapack.SVDNone  The singular vectors are not computed.
+// Dgesdd will panic if jobz is lapack.SVDOverwrite.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Dgesdd
+// the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored column-wise. If
+// jobz == lapack.SVDAll, u is of size m×m. If jobz == lapack.SVDStore, u is of
+// size m×min(m,n). If jobz == lapack.SVDNone, u is not used.
+//
+// vt contains the right singular vectors on exit, stored row-wise. If
+// jobz == lapack.SVDAll, vt is of size n×n. If jobz == lapack.SVDStore, vt is
+// of size min(m,n)×n. If jobz == lapack.SVDNone, vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. With mn = min(m,n) and mx = max(m,n), lwork must be at least
+//  4*mn + max(mx, 4*mn)                         if jobz == lapack.SVDNone,
+//  mn*mn + 4*mn + max(3*mn*mn + 7*mn + 1, mx)   otherwise.
+// If lwork == -1, instead of performing Dgesdd, the optimal work length will be
+// stored into work[0]. Dgesdd will panic if the working memory has insufficient
+// storage.
+//
+// iwork must have length at least 4*min(m,n) and Dgesdd will panic otherwise.
+//
+// Dgesdd returns whether the decomposition successfully completed.
+func (impl Implementation) Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool) {
+	wantqa := jobz == lapack.SVDAll
+	wantqs := jobz == lapack.SVDStore
+	wantqas := wantqa || wantqs
+	wantqn := jobz == lapack.SVDNone
+
+	minmn := min(m, n)
+	maxmn := max(m, n)
+	minwrk := 1
+	if minmn > 0 {
+		if wantqn {
+			minwrk = 4*minmn + max(maxmn, 4*minmn)
+		} else {
+			minwrk = minmn*minmn + 4*minmn + max(3*minmn*minmn+7*minmn+1, maxmn)
+		}
+	}
+	switch {
+	case !wantqas && !wantqn:
+		panic(badSVDJob)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldu < 1, wantqa && ldu < m, wantqs && ldu < minmn:
+		panic(badLdU)
+	case ldvt < 1, wantqas && ldvt < n:
+		panic(badLdVT)
+	case lwork < minwrk && lwork != -1:
+		panic(badLWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+
+	// Quick return if possible.
+	if minmn == 0 {
+		work[0] = 1
+		return true
+	}
+
+	// The bidiagonal matrices are reduced from a triangular factor of A if
+	// A has many more rows than columns or vice versa.
+	mnthr := int(float64(minmn) * 11 / 6)
+	ncu := minmn
+	nrvt := minmn
+	if wantqa {
+		ncu = m
+		nrvt = n
+	}
+
+	// Compute the optimal workspace size.
+	maxwrk := minwrk
+	if m >= n {
+		if m >= mnthr {
+			impl.Dgeqrf(m, n, a, lda, nil, work, -1)
+			maxwrk = max(maxwrk, 4*n+int(work[0]))
+			impl.Dgebrd(n, n, a, lda, nil, nil, nil, nil, work, -1)
+			wrkbl := 4*n + int(work[0])
+			if wantqas {
+				wrkbl += n * n
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, n, n, n, a, lda, nil, u, ldu, work, -1)
+				wrkbl = max(wrkbl, n*n+4*n+int(work[0]))
+				impl.Dormqr(blas.Left, blas.NoTrans, m, ncu, n, a, lda, nil, u, ldu, work, -1)
+				wrkbl = max(wrkbl, n*n+4*n+int(work[0]))
+			}
+			maxwrk = max(maxwrk, wrkbl)
+		} else {
+			impl.Dgebrd(m, n, a, lda, nil, nil, nil, nil, work, -1)
+			maxwrk = max(maxwrk, 3*n+int(work[0]))
+			if wantqas {
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, ncu, n, a, lda, nil, u, ldu, work, -1)
+				maxwrk = max(maxwrk, 3*n+int(work[0]))
+			}
+		}
+		if wantqas {
+			impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, a, lda, nil, vt, ldvt, work, -1)
+			maxwrk = max(maxwrk, n*n+4*n+int(work[0]))
+		}
+	} else {
+		if n >= mnthr {
+			impl.Dgelqf(m, n, a, lda, nil, work, -1)
+			maxwrk = max(maxwrk, 4*m+int(work[0]))
+			impl.Dgebrd(m, m, a, lda, nil, nil, nil, nil, work, -1)
+			wrkbl := 4*m + int(work[0])
+			if wantqas {
+				wrkbl += m * m
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, m, m, a, lda, nil, vt, ldvt, work, -1)
+				wrkbl = max(wrkbl, m*m+4*m+int(work[0]))
+				impl.Dormlq(blas.Right, blas.NoTrans, nrvt, n, m, a, lda, nil, vt, ldvt, work, -1)
+				wrkbl = max(wrkbl, m*m+4*m+int(work[0]))
+			}
+			maxwrk = max(maxwrk, wrkbl)
+		} else {
+			impl.Dgebrd(m, n, a, lda, nil, nil, nil, nil, work, -1)
+			maxwrk = max(maxwrk, 3*m+int(work[0]))
+			if wantqas {
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, nrvt, n, m, a, lda, nil, vt, ldvt, work, -1)
+				maxwrk = max(maxwrk, 3*m+int(work[0]))
+			}
+		}
+		if wantqas {
+			impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, m, a, lda, nil, u, ldu, work, -1)
+			maxwrk = max(maxwrk, m*m+4*m+int(work[0]))
+		}
+	}
+
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return true
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case len(s) < minmn:
+		panic(shortS)
+	case wantqas && len(u) < (m-1)*ldu+ncu:
+		panic(shortU)
+	case wantqas && len(vt) < (nrvt-1)*ldvt+n:
+		panic(shortVT)
+	case len(iwork) < 4*minmn:
+		panic(shortIWork)
+	}
+
+	compq := lapack.OrthoNone
+	if wantqas {
+		compq = lapack.OrthoExplicit
+	}
+
+	// Scale A if max element outside range [smlnum, bignum].
+	eps := dlamchP
+	smlnum := math.Sqrt(dlamchS) / eps
+	bignum := 1 / smlnum
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	if math.IsNaN(anrm) {
+		return false
+	}
+	var iscl bool
+	if anrm > 0 && anrm < smlnum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, m, n, a, lda)
+	} else if anrm > bignum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, m, n, a, lda)
+	}
+
+	if m >= n {
+		if m >= mnthr {
+			// Compute A = Q*R and the singular value decomposition of R.
+			itau := 0
+			ie := itau + n
+			itauq := ie + n
+			itaup := itauq + n
+			nwork := itaup + n
+			impl.Dgeqrf(m, n, a, lda, work[itau:ie], work[nwork:], lwork-nwork)
+
+			r, ldr := a, lda
+			if wantqas {
+				r, ldr = work[nwork:nwork+n*n], n
+				nwork += n * n
+				impl.Dlacpy(blas.Upper, n, n, a, lda, r, ldr)
+			}
+			impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, r[ldr:], ldr)
+
+			impl.Dgebrd(n, n, r, ldr, s, work[ie:itauq], work[itauq:itaup], work[itaup:itaup+n], work[nwork:], lwork-nwork)
+			ok = impl.Dbdsdc(blas.Upper, compq, n, s, work[ie:itauq], u, ldu, vt, ldvt, work[nwork:], iwork)
+			if ok && wantqas {
+				// Multiply the left singular vectors of the bidiagonal
+				// matrix by Q_R and then by the Q of the QR factorization.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, n, n, n, r, ldr, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, r, ldr, work[itaup:itaup+n], vt, ldvt, work[nwork:], lwork-nwork)
+				if m > n {
+					impl.Dlaset(blas.All, m-n, n, 0, 0, u[n*ldu:], ldu)
+					if wantqa {
+						impl.Dlaset(blas.All, n, m-n, 0, 0, u[n:], ldu)
+						impl.Dlaset(blas.All, m-n, m-n, 0, 1, u[n*ldu+n:], ldu)
+					}
+				}
+				impl.Dormqr(blas.Left, blas.NoTrans, m, ncu, n, a, lda, work[itau:ie], u, ldu, work[nwork:], lwork-nwork)
+			}
+		} else {
+			// Reduce A directly to upper bidiagonal form.
+			ie := 0
+			itauq := ie + n
+			itaup := itauq + n
+			nwork := itaup + n
+			impl.Dgebrd(m, n, a, lda, s, work[ie:itauq], work[itauq:itaup], work[itaup:nwork], work[nwork:], lwork-nwork)
+			ok = impl.Dbdsdc(blas.Upper, compq, n, s, work[ie:itauq], u, ldu, vt, ldvt, work[nwork:], iwork)
+			if ok && wantqas {
+				if m > n {
+					impl.Dlaset(blas.All, m-n, n, 0, 0, u[n*ldu:], ldu)
+					if wantqa {
+						impl.Dlaset(blas.All, n, m-n, 0, 0, u[n:], ldu)
+						impl.Dlaset(blas.All, m-n, m-n, 0, 1, u[n*ldu+n:], ldu)
+					}
+				}
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, ncu, n, a, lda, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, a, lda, work[itaup:nwork], vt, ldvt, work[nwork:], lwork-nwork)
+			}
+		}
+	} else {
+		if n >= mnthr {
+			// Compute A = L*Q and the singular value decomposition of L.
+			itau := 0
+			ie := itau + m
+			itauq := ie + m
+			itaup := itauq + m
+			nwork := itaup + m
+			impl.Dgelqf(m, n, a, lda, work[itau:ie], work[nwork:], lwork-nwork)
+
+			l, ldl := a, lda
+			if wantqas {
+				l, ldl = work[nwork:nwork+m*m], m
+				nwork += m * m
+				impl.Dlacpy(blas.Lower, m, m, a, lda, l, ldl)
+			}
+			impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, l[1:], ldl)
+
+			impl.Dgebrd(m, m, l, ldl, s, work[ie:itauq], work[itauq:itaup], work[itaup:itaup+m], work[nwork:], lwork-nwork)
+			ok = impl.Dbdsdc(blas.Upper, compq, m, s, work[ie:itauq], u, ldu, vt, ldvt, work[nwork:], iwork)
+			if ok && wantqas {
+				// Multiply the right singular vectors of the bidiagonal
+				// matrix by P_L^T and then by the Q of the LQ
+				// factorization.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, m, l, ldl, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, m, m, l, ldl, work[itaup:itaup+m], vt, ldvt, work[nwork:], lwork-nwork)
+				impl.Dlaset(blas.All, m, n-m, 0, 0, vt[m:], ldvt)
+				if wantqa {
+					impl.Dlaset(blas.All, n-m, m, 0, 0, vt[m*ldvt:], ldvt)
+					impl.Dlaset(blas.All, n-m, n-m, 0, 1, vt[m*ldvt+m:], ldvt)
+				}
+				impl.Dormlq(blas.Right, blas.NoTrans, nrvt, n, m, a, lda, work[itau:ie], vt, ldvt, work[nwork:], lwork-nwork)
+			}
+		} else {
+			// Reduce A directly to lower bidiagonal form.
+			ie := 0
+			itauq := ie + m
+			itaup := itauq + m
+			nwork := itaup + m
+			impl.Dgebrd(m, n, a, lda, s, work[ie:itauq], work[itauq:itaup], work[itaup:nwork], work[nwork:], lwork-nwork)
+			ok = impl.Dbdsdc(blas.Lower, compq, m, s, work[ie:itauq], u, ldu, vt, ldvt, work[nwork:], iwork)
+			if ok && wantqas {
+				impl.Dlaset(blas.All, m, n-m, 0, 0, vt[m:], ldvt)
+				if wantqa {
+					impl.Dlaset(blas.All, n-m, m, 0, 0, vt[m*ldvt:], ldvt)
+					impl.Dlaset(blas.All, n-m, n-m, 0, 1, vt[m*ldvt+m:], ldvt)
+				}
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, n, a, lda, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, nrvt, n, m, a, lda, work[itaup:nwork], vt, ldvt, work[nwork:], lwork-nwork)
+			}
+		}
+	}
+
+	// Undo scaling if necessary.
+	if iscl {
+		if anrm > bignum {
+			impl.Dlascl(lapack.General, 0, 0, bignum, anrm, minmn, 1, s, 1)
+		}
+		if anrm < smlnum {
+			impl.Dlascl(lapack.General, 0, 0, smlnum, anrm, minmn, 1, s, 1)
+		}
+	}
+	work[0] = float64(maxwrk)
+	return ok
+}
@@ -0,0 +1,259 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgesvj computes the singular value decomposition of an m×n matrix A with
+// m >= n using the one-sided Jacobi method. The singular value decomposition is
+//  A = U * Σ * V^T
+// where Σ is an n×n diagonal matrix containing the singular values of A, U is
+// an m×n matrix with orthonormal columns and V is an n×n orthogonal matrix.
+//
+// Dgesvj orthogonalizes the columns of A by applying a sequence of plane
+// rotations from the right, so that A*V = U*Σ. If A = B*D or A = D*B where D
+// is diagonal and B is well-conditioned, the singular values are computed to
+// high relative accuracy, that is, also the smallest singular values have
+// almost all of their digits correct. Dgejsv is usually faster and should be
+// preferred for general matrices.
+//
+// jobU specifies whether the left singular vectors are computed. If jobU is
+// lapack.SVDOverwrite, the n columns of U are stored in a on return. If jobU
+// is lapack.SVDNone, U is not computed and the contents of a are destroyed.
+// The columns of U that correspond to zero singular values are zero.
+//
+// jobV specifies whether the right singular vectors are computed. If jobV is
+// lapack.SVDAll, the n×n matrix V is stored in v on return, otherwise jobV
+// must be lapack.SVDNone and v is not referenced.
+//
+// sva must have length at least n and on return it contains the singular
+// values of A in decreasing order.
+//
+// work must have length at least max(1, lwork) and lwork must be at least
+// max(1, m), otherwise Dgesvj will panic. If lwork == -1, instead of computing
+// the decomposition, the optimal length of work is stored into work[0].
+//
+// Dgesvj returns whether the iteration converged. If it did not, the singular
+// values and vectors on return are those computed by the last sweep.
+func (impl Implementation) Dgesvj(jobU, jobV lapack.SVDJob, m, n int, a []float64, lda int, sva, v []float64, ldv int, work []float64, lwork int) (ok bool) {
+	wantu := jobU == lapack.SVDOverwrite
+	wantv := jobV == lapack.SVDAll
+	switch {
+	case !wantu && jobU != lapack.SVDNone:
+		panic(badSVDJob)
+	case !wantv && jobV != lapack.SVDNone:
+		panic(badSVDJob)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case m < n:
+		panic(mLTN)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldv < 1, wantv && ldv < n:
+		panic(badLdV)
+	case lwork < max(1, m) && lwork != -1:
+		panic(badLWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+
+	if lwork == -1 {
+		work[0] = float64(max(1, m))
+		return true
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case len(sva) < n:
+		panic(shortSVA)
+	case wantv && len(v) < (n-1)*ldv+n:
+		panic(shortV)
+	}
+
+	const (
+		eps    = dlamchE
+		sfmin  = dlamchS
+		small  = sfmin / eps
+		big    = 1 / sfmin
+		sweeps = 30
+	)
+	rooteps := math.Sqrt(eps)
+	bigtheta := 1 / rooteps
+	ctol := float64(m)
+	if wantu || wantv {
+		ctol = math.Sqrt(float64(m))
+	}
+	tol := ctol * eps
+
+	bi := blas64.Implementation()
+
+	if wantv {
+		impl.Dlaset(blas.All, n, n, 0, 1, v, ldv)
+	}
+
+	var amax float64
+	for j := 0; j < n; j++ {
+		sva[j] = bi.Dnrm2(m, a[j:], lda)
+		amax = math.Max(amax, sva[j])
+	}
+	if amax == 0 {
+		return true
+	}
+
+	// Scale A so that the products of the column norms are representable
+	// throughout the iteration. The sum of the squares of the column norms is
+	// invariant under the rotations, so no column norm can grow larger than
+	// sqrt(n)*amax.
+	hi := math.Sqrt(big/float64(n)) / 2
+	lo := math.Sqrt(sfmin)
+	var scale float64
+	if amax > hi {
+		scale = hi
+	} else if amax < lo {
+		scale = lo
+	}
+	if scale != 0 {
+		impl.Dlascl(lapack.General, 0, 0, amax, scale, m, n, a, lda)
+		for j := 0; j < n; j++ {
+			sva[j] = bi.Dnrm2(m, a[j:], lda)
+		}
+	}
+
+	for sweep := 0; sweep < sweeps; sweep++ {
+		var rotated bool
+		for p := 0; p < n-1; p++ {
+			// Move the column with the largest norm among the columns
+			// p through n-1 to position p (de Rijk's pivoting).
+			q := p + bi.Idamax(n-p, sva[p:], 1)
+			if q != p {
+				bi.Dswap(m, a[p:], lda, a[q:], lda)
+				if wantv {
+					bi.Dswap(n, v[p:], ldv, v[q:], ldv)
+				}
+				sva[p], sva[q] = sva[q], sva[p]
+			}
+			aapp := sva[p]
+			if aapp == 0 {
+				// All the remaining columns are zero.
+				break
+			}
+			for q := p + 1; q < n; q++ {
+				aaqq := sva[q]
+				if aaqq == 0 {
+					continue
+				}
+
+				// Compute the cosine of the angle between the columns p
+				// and q, avoiding overflow and underflow of the inner
+				// product.
+				var rotok, fast bool
+				if aaqq >= 1 {
+					rotok = small*aapp <= aaqq
+					fast = aapp < big/aaqq
+				} else {
+					rotok = aapp <= aaqq/small
+					fast = aapp > small/aaqq
+				}
+				var aapq float64
+				if fast {
+					aapq = bi.Ddot(m, a[p:], lda, a[q:], lda) / aaqq / aapp
+				} else {
+					bi.Dcopy(m, a[p:], lda, work, 1)
+					impl.Dlascl(lapack.General, 0, 0, aapp, 1, m, 1, work, 1)
+					aapq = bi.Ddot(m, work, 1, a[q:], lda) / aaqq
+				}
+				if !rotok || math.Abs(aapq) <= tol {
+					continue
+				}
+				rotated = true
+
+				// Compute the rotation that makes the columns p and q
+				// orthogonal and update their norms.
+				aqoap := aaqq / aapp
+				apoaq := aapp / aaqq
+				zeta := 0.5 * (aqoap - apoaq) / aapq
+				var t, c, s float64
+				if math.Abs(zeta) > bigtheta {
+					t = 0.5 / zeta
+					c = 1
+					s = t
+				} else {
+					t = math.Copysign(1, zeta) / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+					c = 1 / math.Sqrt(1+t*t)
+					s = t * c
+				}
+				bi.Drot(m, a[p:], lda, a[q:], lda, c, -s)
+				if wantv {
+					bi.Drot(n, v[p:], ldv, v[q:], ldv, c, -s)
+				}
+				sva[q] = aaqq * math.Sqrt(math.Max(0, 1+t*apoaq*aapq))
+				aapp0 := aapp
+				aapp *= math.Sqrt(math.Max(0, 1-t*aqoap*aapq))
+
+				// Recompute the norms if the updates suffered from
+				// cancellation.
+				if r := sva[q] / aaqq; r*r <= rooteps {
+					sva[q] = bi.Dnrm2(m, a[q:], lda)
+				}
+				if r := aapp / aapp0; r*r <= rooteps {
+					aapp = bi.Dnrm2(m, a[p:], lda)
+				}
+			}
+			sva[p] = aapp
+		}
+
+		// Recompute the column norms to avoid the accumulation of errors
+		// in their updates.
+		for j := 0; j < n; j++ {
+			sva[j] = bi.Dnrm2(m, a[j:], lda)
+		}
+		if !rotated {
+			ok = true
+			break
+		}
+	}
+
+	// Sort the singular values into decreasing order together with the
+	// columns of A and V.
+	for p := 0; p < n-1; p++ {
+		q := p + bi.Idamax(n-p, sva[p:], 1)
+		if q != p {
+			bi.Dswap(m, a[p:], lda, a[q:], lda)
+			if wantv {
+				bi.Dswap(n, v[p:], ldv, v[q:], ldv)
+			}
+			sva[p], sva[q] = sva[q], sva[p]
+		}
+	}
+
+	// Normalize the columns of A to obtain the left singular vectors.
+	if wantu {
+		for j := 0; j < n; j++ {
+			if sva[j] != 0 {
+				impl.Dlascl(lapack.General, 0, 0, sva[j], 1, m, 1, a[j:], lda)
+			}
+		}
+	}
+
+	// Undo the scaling of A.
+	if scale != 0 {
+		impl.Dlascl(lapack.General, 0, 0, scale, amax, 1, n, sva, n)
+	}
+	return ok
+}
@@ -0,0 +1,252 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgesvx uses the LU factorization to compute the solution to a system of
+// linear equations
+//  op(A) * X = B
+// where A is an n×n general matrix, op(A) is A or A^T depending on trans, and
+// X and B are n×nrhs matrices. Error bounds on the solution and a condition
+// estimate are also provided.
+//
+// Dgesvx performs the following steps:
+//  1. If fact is lapack.EquilibrateFactorize, real scaling factors are
+//     computed by Dgeequ to equilibrate the system and, if the scaling is
+//     worthwhile, A is overwritten by diag(r)*A*diag(c) and B by diag(r)*B
+//     or diag(c)*B depending on trans.
+//  2. If fact is not lapack.Factored, the LU decomposition of A is computed
+//     by Dgetrf and stored in af and ipiv.
+//  3. If some U[i,i] is exactly zero, so that U is singular, Dgesvx returns
+//     ok=false with rcond zero and no solution is computed. Otherwise the
+//     factored form of A is used to estimate the reciprocal condition number
+//     of A.
+//  4. The system is solved by Dgetrs and improved by iterative refinement in
+//     Dgerfs which also computes error bounds and backward error estimates.
+//  5. If equilibration was used, the solution is premultiplied by diag(c) or
+//     diag(r) so that it solves the original system before equilibration.
+//
+// If fact is lapack.Factored, af and ipiv must contain on entry the LU
+// factorization of A, and equed specifies the form of equilibration that was
+// applied to A with the scaling factors in r and c. In this case a must
+// contain the equilibrated matrix. The scaling factors must be positive when
+// they are used. For other values of fact equed is ignored.
+//
+// On return, if fact is lapack.EquilibrateFactorize, a contains the
+// equilibrated matrix and b contains the equilibrated right-hand side if
+// equilibration was done, and af and ipiv contain the LU factorization of the
+// equilibrated matrix. equedOut is the form of equilibration that was used.
+//
+// x contains on return the n×nrhs solution matrix. ferr and berr contain the
+// forward error bounds and componentwise backward errors of the solution
+// columns. See the documentation for Dgerfs for details.
+//
+// rcond is the estimate of the reciprocal condition number of A after
+// equilibration, if done. If rcond is less than machine precision, the matrix
+// is singular to working precision and ok is false, but the solution and the
+// error bounds are still computed.
+//
+// rpvgrw is the reciprocal pivot growth factor
+//  max_j ||A_j||_max / max_j ||U_j||_max.
+// If rpvgrw is much less than 1, the stability of the LU factorization could
+// be poor and the solution, condition estimator and forward error bound may be
+// unreliable. If U is singular, rpvgrw is computed for the leading columns of
+// A and U up to the first zero pivot.
+//
+// r and c must have length at least n, ferr and berr must have length at least
+// nrhs, work must have length at least 4*n and iwork must have length at least
+// n, otherwise Dgesvx will panic.
+func (impl Implementation) Dgesvx(fact lapack.FactJob, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed lapack.Equilibration, r, c []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.Equilibration, rcond, rpvgrw float64, ok bool) {
+	factored := fact == lapack.Factored
+	switch {
+	case fact != lapack.Factored && fact != lapack.Factorize && fact != lapack.EquilibrateFactorize:
+		panic(badFactJob)
+	case factored && equed != lapack.EquilibrateNone && equed != lapack.EquilibrateRows && equed != lapack.EquilibrateCols && equed != lapack.EquilibrateBoth:
+		panic(badEquilibration)
+	case trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic(nrhsLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldaf < max(1, n):
+		panic(badLdAF)
+	case ldb < max(1, nrhs):
+		panic(badLdB)
+	case ldx < max(1, nrhs):
+		panic(badLdX)
+	}
+
+	if !factored {
+		equed = lapack.EquilibrateNone
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		impl.Dgerfs(trans, n, nrhs, a, lda, af, ldaf, ipiv, b, ldb, x, ldx, ferr, berr, work, iwork)
+		return equed, 1, 1, true
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(af) < (n-1)*ldaf+n:
+		panic(shortAF)
+	case len(ipiv) != n:
+		panic(badLenIpiv)
+	case len(r) < n:
+		panic(shortR)
+	case len(c) < n:
+		panic(shortC)
+	case nrhs > 0 && len(b) < (n-1)*ldb+nrhs:
+		panic(shortB)
+	case nrhs > 0 && len(x) < (n-1)*ldx+nrhs:
+		panic(shortX)
+	case len(ferr) < nrhs:
+		panic(shortFerr)
+	case len(berr) < nrhs:
+		panic(shortBerr)
+	case len(work) < 4*n:
+		panic(shortWork)
+	case len(iwork) < n:
+		panic(shortIWork)
+	}
+
+	rowequ := equed == lapack.EquilibrateRows || equed == lapack.EquilibrateBoth
+	colequ := equed == lapack.EquilibrateCols || equed == lapack.EquilibrateBoth
+	var rowcnd, colcnd float64
+	if rowequ {
+		rowcnd = scaleCond(r[:n])
+	}
+	if colequ {
+		colcnd = scaleCond(c[:n])
+	}
+
+	if fact == lapack.EquilibrateFactorize {
+		// Compute row and column scalings to equilibrate the matrix A.
+		var amax float64
+		var okequ bool
+		rowcnd, colcnd, amax, okequ = impl.Dgeequ(n, n, a, lda, r[:n], c[:n])
+		if okequ {
+			// Equilibrate the matrix.
+			equed = impl.Dlaqge(n, n, a, lda, r[:n], c[:n], rowcnd, colcnd, amax)
+			rowequ = equed == lapack.EquilibrateRows || equed == lapack.EquilibrateBoth
+			colequ = equed == lapack.EquilibrateCols || equed == lapack.EquilibrateBoth
+		}
+	}
+
+	// Scale the right-hand side.
+	notran := trans == blas.NoTrans
+	if notran && rowequ {
+		for i := 0; i < n; i++ {
+			ri := r[i]
+			for j := 0; j < nrhs; j++ {
+				b[i*ldb+j] *= ri
+			}
+		}
+	} else if !notran && colequ {
+		for i := 0; i < n; i++ {
+			ci := c[i]
+			for j := 0; j < nrhs; j++ {
+				b[i*ldb+j] *= ci
+			}
+		}
+	}
+
+	if !factored {
+		// Compute the LU factorization of A.
+		impl.Dlacpy(blas.All, n, n, a, lda, af, ldaf)
+		if !impl.Dgetrf(n, n, af, ldaf, ipiv) {
+			// Compute the reciprocal pivot growth factor of the leading
+			// columns up to the first zero pivot.
+			var k int
+			for k < n && af[k*ldaf+k] != 0 {
+				k++
+			}
+			rpvgrw = impl.Dlantr(lapack.MaxAbs, blas.Upper, blas.NonUnit, k, k, af, ldaf, nil)
+			if rpvgrw == 0 {
+				rpvgrw = 1
+			} else {
+				rpvgrw = impl.Dlange(lapack.MaxAbs, n, k, a, lda, nil) / rpvgrw
+			}
+			return equed, 0, rpvgrw, false
+		}
+	}
+
+	// Compute the norm of the matrix A and the reciprocal pivot growth factor.
+	norm := lapack.MaxColumnSum
+	if !notran {
+		norm = lapack.MaxRowSum
+	}
+	anorm := impl.Dlange(norm, n, n, a, lda, work)
+	rpvgrw = impl.Dlantr(lapack.MaxAbs, blas.Upper, blas.NonUnit, n, n, af, ldaf, nil)
+	if rpvgrw == 0 {
+		rpvgrw = 1
+	} else {
+		rpvgrw = impl.Dlange(lapack.MaxAbs, n, n, a, lda, nil) / rpvgrw
+	}
+
+	// Compute the reciprocal of the condition number of A.
+	rcond = impl.Dgecon(norm, n, af, ldaf, anorm, work, iwork)
+
+	// Compute the solution matrix X.
+	impl.Dlacpy(blas.All, n, nrhs, b, ldb, x, ldx)
+	impl.Dgetrs(trans, n, nrhs, af, ldaf, ipiv, x, ldx)
+
+	// Use iterative refinement to improve the computed solution and compute
+	// error bounds and backward error estimates for it.
+	impl.Dgerfs(trans, n, nrhs, a, lda, af, ldaf, ipiv, b, ldb, x, ldx, ferr, berr, work, iwork)
+
+	// Transform the solution matrix X to a solution of the original system.
+	if notran && colequ {
+		for i := 0; i < n; i++ {
+			ci := c[i]
+			for j := 0; j < nrhs; j++ {
+				x[i*ldx+j] *= ci
+			}
+		}
+		for j := 0; j < nrhs; j++ {
+			ferr[j] /= colcnd
+		}
+	} else if !notran && rowequ {
+		for i := 0; i < n; i++ {
+			ri := r[i]
+			for j := 0; j < nrhs; j++ {
+				x[i*ldx+j] *= ri
+			}
+		}
+		for j := 0; j < nrhs; j++ {
+			ferr[j] /= rowcnd
+		}
+	}
+
+	return equed, rcond, rpvgrw, rcond >= dlamchE
+}
+
+// scaleCond returns the ratio of the smallest to the largest of the scaling
+// factors in s, bounded away from underflow and overflow. It panics if any
+// of the scaling factors is not positive.
+func scaleCond(s []float64) float64 {
+	smlnum := dlamchS
+	bignum := 1 / smlnum
+	smin := bignum
+	var smax float64
+	for _, v := range s {
+		if v <= 0 {
+			panic(badScale)
+		}
+		smin = math.Min(smin, v)
+		smax = math.Max(smax, v)
+	}
+	return math.Max(smin, smlnum) / math.Min(smax, bignum)
+}
@@ -20,7 +20,10 @@ import (
 // changed with ipiv[i]. ipiv must have length at least min(m,n), and will panic
 // otherwise. ipiv is zero-indexed.
 //
-// Dgetrf is the blocked version of the algorithm.
+// Dgetrf is the blocked version of the algorithm. The row interchanges and
+// triangular solves for the trailing columns are computed concurrently by the
+// number of workers given by Ilaenv(7, ...), and the update of the trailing
+// submatrix is computed by Dgemm.
 //
 // Dgetrf returns whether the matrix A is singular. The LU decomposition will
 // be computed regardless of the singularity of A, but division by zero
@@ -56,6 +59,7 @@ func (impl Implementation) Dgetrf(m, n int, a []float64, lda int, ipiv []int) (o
 		// Use the unblocked algorithm.
 		return impl.Dgetf2(m, n, a, lda, ipiv)
 	}
+	nw := impl.Ilaenv(7, "DGETRF", " ", m, n, -1, -1)
 	ok = true
 	for j := 0; j < mn; j += nb {
 		jb := min(mn-j, nb)
@@ -68,11 +72,17 @@ func (impl Implementation) Dgetrf(m, n int, a []float64, lda int, ipiv []int) (o
 		}
 		impl.Dlaswp(j, a, lda, j, j+jb-1, ipiv[:j+jb], 1)
 		if j+jb < n {
-			impl.Dlaswp(n-j-jb, a[j+jb:], lda, j, j+jb-1, ipiv[:j+jb], 1)
-			bi.Dtrsm(blas.Left, blas.Lower, blas.NoTrans, blas.Unit,
-				jb, n-j-jb, 1,
-				a[j*lda+j:], lda,
-				a[j*lda+j+jb:], lda)
+			// The trailing columns are independent, so the row
+			// interchanges and the triangular solve are computed in
+			// blocks of nb columns concurrently when more than one
+			// worker is available.
+			parallelBlocks(nw, j+jb, n, nb, func(c0, c1 int) {
+				impl.Dlaswp(c1-c0, a[c0:], lda, j, j+jb-1, ipiv[:j+jb], 1)
+				bi.Dtrsm(blas.Left, blas.Lower, blas.NoTrans, blas.Unit,
+					jb, c1-c0, 1,
+					a[j*lda+j:], lda,
+					a[j*lda+c0:], lda)
+			})
 			if j+jb < m {
 				bi.Dgemm(blas.NoTrans, blas.NoTrans, m-j-jb, n-j-jb, jb, -1,
 					a[(j+jb)*lda+j:], lda,
@@ -0,0 +1,217 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlaed4 computes the i-th updated eigenvalue of a symmetric rank-one
+// modification to a diagonal matrix whose elements are given in d,
+//  diag(d) + rho * z * z^T.
+// The eigenvalue is the i-th root, counting from zero, of the secular equation
+//  1 + rho * \sum_j z_j^2 / (d_j - λ) = 0.
+//
+// The elements of d must be in strictly increasing order, the elements of z
+// must be non-zero and rho must be positive.
+//
+// On return, delta contains the differences d_j - λ_i which are computed
+// accurately relative to the distance of λ_i from the nearest d_j. If n == 1,
+// delta[0] is set to 1. They are used to compute the eigenvectors of the
+// rank-one modification.
+//
+// d, z and delta must have length at least n, and i must be in [0, n),
+// otherwise Dlaed4 will panic.
+//
+// Dlaed4 returns the eigenvalue and whether the iteration for the root of the
+// secular equation converged.
+//
+// Dlaed4 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed4(n, i int, d, z, delta []float64, rho float64) (dlam float64, ok bool) {
+	switch {
+	case n < 1:
+		panic(nLT1)
+	case i < 0 || n <= i:
+		panic(badI)
+	case len(d) < n:
+		panic(shortD)
+	case len(z) < n:
+		panic(shortZ)
+	case len(delta) < n:
+		panic(shortDelta)
+	}
+
+	if n == 1 {
+		delta[0] = 1
+		return d[0] + rho*z[0]*z[0], true
+	}
+
+	// Shift the origin to the pole nearest to the root so that the
+	// differences d_j - λ_i can be computed without cancellation.
+	var org int
+	var lo, hi float64
+	if i == n-1 {
+		org = i
+		var zz float64
+		for j := 0; j < n; j++ {
+			delta[j] = d[j] - d[org]
+			zz += z[j] * z[j]
+		}
+		lo, hi = 0, rho*zz
+	} else {
+		for j := 0; j < n; j++ {
+			delta[j] = d[j] - d[i]
+		}
+		mid := delta[i+1] / 2
+		if secularValue(delta[:n], z, rho, mid) >= 0 {
+			org = i
+			lo, hi = 0, mid
+		} else {
+			org = i + 1
+			for j := 0; j < n; j++ {
+				delta[j] = d[j] - d[org]
+			}
+			lo, hi = delta[i]/2, 0
+		}
+	}
+
+	tau, ok := secularRoot(i, delta[:n], z, rho, lo, hi)
+	for j := 0; j < n; j++ {
+		delta[j] -= tau
+	}
+	return d[org] + tau, ok
+}
+
+// secularValue returns the value of the secular function
+//  1/rho + \sum_j z_j^2 / (s_j - tau).
+func secularValue(s, z []float64, rho, tau float64) float64 {
+	f := 1 / rho
+	for j, sj := range s {
+		f += z[j] * z[j] / (sj - tau)
+	}
+	return f
+}
+
+// secularRoot returns the root of the secular function
+//  f(tau) = 1/rho + \sum_j z_j^2 / (s_j - tau)
+// that lies in the interval (lo, hi), where s holds the poles of f in
+// increasing order relative to an origin that is one of the poles. The root
+// lies between the poles s[i] and s[i+1], or to the right of s[n-1] if
+// i == n-1. f is increasing on the interval, negative at lo and positive at
+// hi.
+//
+// The root is found using rational interpolation of f by its two poles
+// nearest to the root, safeguarded by bisection. secularRoot returns whether
+// the iteration converged.
+func secularRoot(i int, s, z []float64, rho, lo, hi float64) (tau float64, ok bool) {
+	const maxIter = 100
+
+	n := len(s)
+	eps := dlamchE
+
+	// The two poles used for the rational interpolation. The terms of f are
+	// split into the part from the poles up to ia and from ib onwards.
+	ia, ib := i, i+1
+	if i == n-1 {
+		ia, ib = n-2, n-1
+	}
+
+	tau = (lo + hi) / 2
+	width := hi - lo
+	var slow int
+	for iter := 0; iter < maxIter; iter++ {
+		// Evaluate f and the derivatives of its two parts, and bound the
+		// rounding error in the computed value of f.
+		var psi, dpsi, phi, dphi, erretm float64
+		for j := 0; j <= ia; j++ {
+			t := z[j] / (s[j] - tau)
+			psi += z[j] * t
+			dpsi += t * t
+			erretm += math.Abs(z[j] * t)
+		}
+		for j := ib; j < n; j++ {
+			t := z[j] / (s[j] - tau)
+			phi += z[j] * t
+			dphi += t * t
+			erretm += math.Abs(z[j] * t)
+		}
+		w := 1/rho + psi + phi
+		erretm = 8*erretm + 2/rho + 3*math.Abs(tau)*(dpsi+dphi)
+		if math.Abs(w) <= eps*erretm {
+			return tau, true
+		}
+
+		// Update the bracket of the root.
+		if w < 0 {
+			lo = tau
+		} else {
+			hi = tau
+		}
+		if hi-lo <= 4*eps*math.Max(math.Abs(lo), math.Abs(hi)) {
+			return tau, true
+		}
+
+		// Model f near tau by
+		//  c + s_a/(da - eta) + s_b/(db - eta)
+		// matching its value and the derivatives of the two parts, and
+		// compute the zero of the model that moves towards the root.
+		da := s[ia] - tau
+		db := s[ib] - tau
+		c := w - da*dpsi - db*dphi
+		a := (da+db)*w - da*db*(dpsi+dphi)
+		b := da * db * w
+		eta := math.NaN()
+		if c == 0 {
+			if a != 0 {
+				eta = b / a
+			}
+		} else {
+			disc := math.Sqrt(math.Abs(a*a - 4*b*c))
+			q := (a + disc) / 2
+			if a < 0 {
+				q = (a - disc) / 2
+			}
+			eta1 := q / c
+			eta2 := b / q
+			in1 := lo < tau+eta1 && tau+eta1 < hi
+			in2 := lo < tau+eta2 && tau+eta2 < hi
+			switch {
+			case in1 && in2:
+				eta = eta1
+				if math.Abs(eta2) < math.Abs(eta1) {
+					eta = eta2
+				}
+			case in1:
+				eta = eta1
+			case in2:
+				eta = eta2
+			}
+		}
+		if math.IsNaN(eta) || w*eta >= 0 || !(lo < tau+eta && tau+eta < hi) {
+			// Fall back to a Newton step.
+			eta = -w / (dpsi + dphi)
+		}
+		next := tau + eta
+		if !(lo < next && next < hi) {
+			next = (lo + hi) / 2
+		}
+
+		// Bisect if the bracket is not shrinking fast enough.
+		if hi-lo > width/2 {
+			slow++
+			if slow > 4 {
+				next = (lo + hi) / 2
+				slow = 0
+				width = hi - lo
+			}
+		} else {
+			slow = 0
+			width = hi - lo
+		}
+		if next == tau {
+			return tau, true
+		}
+		tau = next
+	}
+	return tau, false
+}
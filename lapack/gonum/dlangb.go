@@ -0,0 +1,97 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlangb returns the given norm of an m×n band matrix with kl sub-diagonals
+// and ku super-diagonals. The band matrix is stored in ab as described in the
+// documentation of Dgbtrf, but ldab need only be at least kl+ku+1.
+//
+// If norm == lapack.MaxColumnSum, work must have length at least n, otherwise
+// work is unused.
+func (impl Implementation) Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64 {
+	switch {
+	case norm != lapack.MaxAbs && norm != lapack.MaxRowSum && norm != lapack.MaxColumnSum && norm != lapack.Frobenius:
+		panic(badNorm)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case kl < 0:
+		panic(klLT0)
+	case ku < 0:
+		panic(kuLT0)
+	case ldab < kl+ku+1:
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 0
+	}
+
+	switch {
+	case len(ab) < (min(m, n+kl)-1)*ldab+kl+ku+1:
+		panic(shortAB)
+	case norm == lapack.MaxColumnSum && len(work) < n:
+		panic(shortWork)
+	}
+
+	var value float64
+	switch norm {
+	case lapack.MaxAbs:
+		for i := 0; i < min(m, n+kl); i++ {
+			for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+				v := math.Abs(ab[i*ldab+kl+j-i])
+				if math.IsNaN(v) {
+					return math.NaN()
+				}
+				value = math.Max(value, v)
+			}
+		}
+	case lapack.MaxRowSum:
+		for i := 0; i < min(m, n+kl); i++ {
+			var sum float64
+			for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+				sum += math.Abs(ab[i*ldab+kl+j-i])
+			}
+			if math.IsNaN(sum) {
+				return math.NaN()
+			}
+			value = math.Max(value, sum)
+		}
+	case lapack.MaxColumnSum:
+		work = work[:n]
+		for j := range work {
+			work[j] = 0
+		}
+		for i := 0; i < min(m, n+kl); i++ {
+			for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+				work[j] += math.Abs(ab[i*ldab+kl+j-i])
+			}
+		}
+		for _, sum := range work {
+			if math.IsNaN(sum) {
+				return math.NaN()
+			}
+			value = math.Max(value, sum)
+		}
+	case lapack.Frobenius:
+		scale := 0.0
+		sum := 1.0
+		for i := 0; i < min(m, n+kl); i++ {
+			jl := max(0, i-kl)
+			ju := min(n-1, i+ku)
+			scale, sum = impl.Dlassq(ju-jl+1, ab[i*ldab+kl+jl-i:], 1, scale, sum)
+		}
+		value = scale * math.Sqrt(sum)
+	}
+	return value
+}
@@ -0,0 +1,114 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlansb returns the given norm of an n×n symmetric band matrix with kd
+// super-diagonals if uplo == blas.Upper and kd sub-diagonals otherwise. See
+// the documentation for Dpbtrf for a description of the band storage format.
+//
+// If norm == lapack.MaxColumnSum or norm == lapack.MaxRowSum, work must have
+// length at least n, otherwise work is unused.
+func (impl Implementation) Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64 {
+	switch {
+	case norm != lapack.MaxAbs && norm != lapack.MaxRowSum && norm != lapack.MaxColumnSum && norm != lapack.Frobenius:
+		panic(badNorm)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case kd < 0:
+		panic(kdLT0)
+	case ldab < kd+1:
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0
+	}
+
+	switch {
+	case len(ab) < (n-1)*ldab+kd+1:
+		panic(shortAB)
+	case (norm == lapack.MaxColumnSum || norm == lapack.MaxRowSum) && len(work) < n:
+		panic(shortWork)
+	}
+
+	var value float64
+	switch norm {
+	case lapack.MaxAbs:
+		for i := 0; i < n; i++ {
+			jl, ju := i, min(n-1, i+kd)
+			off := -i
+			if uplo == blas.Lower {
+				jl, ju = max(0, i-kd), i
+				off = kd - i
+			}
+			for j := jl; j <= ju; j++ {
+				v := math.Abs(ab[i*ldab+off+j])
+				if math.IsNaN(v) {
+					return math.NaN()
+				}
+				value = math.Max(value, v)
+			}
+		}
+	case lapack.MaxRowSum, lapack.MaxColumnSum:
+		// A symmetric matrix has the same 1-norm and ∞-norm.
+		work = work[:n]
+		for i := range work {
+			work[i] = 0
+		}
+		for i := 0; i < n; i++ {
+			jl, ju := i+1, min(n-1, i+kd)
+			off := -i
+			if uplo == blas.Lower {
+				jl, ju = max(0, i-kd), i-1
+				off = kd - i
+			}
+			work[i] += math.Abs(ab[i*ldab+off+i])
+			for j := jl; j <= ju; j++ {
+				v := math.Abs(ab[i*ldab+off+j])
+				work[i] += v
+				work[j] += v
+			}
+		}
+		for _, sum := range work {
+			if math.IsNaN(sum) {
+				return math.NaN()
+			}
+			value = math.Max(value, sum)
+		}
+	case lapack.Frobenius:
+		scale := 0.0
+		sum := 1.0
+		// Sum the off-diagonal elements, which appear twice in A.
+		if kd > 0 {
+			for i := 0; i < n; i++ {
+				if uplo == blas.Upper {
+					scale, sum = impl.Dlassq(min(n-1, i+kd)-i, ab[i*ldab+1:], 1, scale, sum)
+				} else {
+					jl := max(0, i-kd)
+					scale, sum = impl.Dlassq(i-jl, ab[i*ldab+kd+jl-i:], 1, scale, sum)
+				}
+			}
+			sum *= 2
+		}
+		// Sum the diagonal.
+		diag := 0
+		if uplo == blas.Lower {
+			diag = kd
+		}
+		scale, sum = impl.Dlassq(n, ab[diag:], ldab, scale, sum)
+		value = scale * math.Sqrt(sum)
+	}
+	return value
+}
@@ -0,0 +1,136 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlantb returns the given norm of an n×n triangular band matrix with kd
+// super-diagonals if uplo == blas.Upper and kd sub-diagonals otherwise. If
+// diag == blas.Unit, the diagonal elements of the matrix are not referenced
+// and are assumed to be one. See the documentation for Dpbtrf for a
+// description of the band storage format.
+//
+// If norm == lapack.MaxColumnSum, work must have length at least n, otherwise
+// work is unused.
+func (impl Implementation) Dlantb(norm lapack.MatrixNorm, uplo blas.Uplo, diag blas.Diag, n, kd int, ab []float64, ldab int, work []float64) float64 {
+	switch {
+	case norm != lapack.MaxAbs && norm != lapack.MaxRowSum && norm != lapack.MaxColumnSum && norm != lapack.Frobenius:
+		panic(badNorm)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case diag != blas.NonUnit && diag != blas.Unit:
+		panic(badDiag)
+	case n < 0:
+		panic(nLT0)
+	case kd < 0:
+		panic(kdLT0)
+	case ldab < kd+1:
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0
+	}
+
+	switch {
+	case len(ab) < (n-1)*ldab+kd+1:
+		panic(shortAB)
+	case norm == lapack.MaxColumnSum && len(work) < n:
+		panic(shortWork)
+	}
+
+	// Row i of A has non-zero elements in columns jl(i)..ju(i) which are
+	// stored at ab[i*ldab+off(i)+j]. If A has unit diagonal, the diagonal
+	// is excluded and accounted for separately.
+	unit := diag == blas.Unit
+	bounds := func(i int) (jl, ju, off int) {
+		if uplo == blas.Upper {
+			jl, ju, off = i, min(n-1, i+kd), -i
+			if unit {
+				jl++
+			}
+			return jl, ju, off
+		}
+		jl, ju, off = max(0, i-kd), i, kd-i
+		if unit {
+			ju--
+		}
+		return jl, ju, off
+	}
+
+	var value float64
+	switch norm {
+	case lapack.MaxAbs:
+		if unit {
+			value = 1
+		}
+		for i := 0; i < n; i++ {
+			jl, ju, off := bounds(i)
+			for j := jl; j <= ju; j++ {
+				v := math.Abs(ab[i*ldab+off+j])
+				if math.IsNaN(v) {
+					return math.NaN()
+				}
+				value = math.Max(value, v)
+			}
+		}
+	case lapack.MaxRowSum:
+		for i := 0; i < n; i++ {
+			var sum float64
+			if unit {
+				sum = 1
+			}
+			jl, ju, off := bounds(i)
+			for j := jl; j <= ju; j++ {
+				sum += math.Abs(ab[i*ldab+off+j])
+			}
+			if math.IsNaN(sum) {
+				return math.NaN()
+			}
+			value = math.Max(value, sum)
+		}
+	case lapack.MaxColumnSum:
+		work = work[:n]
+		for j := range work {
+			work[j] = 0
+			if unit {
+				work[j] = 1
+			}
+		}
+		for i := 0; i < n; i++ {
+			jl, ju, off := bounds(i)
+			for j := jl; j <= ju; j++ {
+				work[j] += math.Abs(ab[i*ldab+off+j])
+			}
+		}
+		for _, sum := range work {
+			if math.IsNaN(sum) {
+				return math.NaN()
+			}
+			value = math.Max(value, sum)
+		}
+	case lapack.Frobenius:
+		scale := 0.0
+		sum := 1.0
+		if unit {
+			scale = 1
+			sum = float64(n)
+		}
+		for i := 0; i < n; i++ {
+			jl, ju, off := bounds(i)
+			if ju >= jl {
+				scale, sum = impl.Dlassq(ju-jl+1, ab[i*ldab+off+jl:], 1, scale, sum)
+			}
+		}
+		value = scale * math.Sqrt(sum)
+	}
+	return value
+}
@@ -0,0 +1,78 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/lapack"
+
+// Dlaqge equilibrates the m×n matrix A using the row and column scaling
+// factors in r and c computed by Dgeequ. The scaling is only applied when it
+// is worthwhile, that is when rowcnd or colcnd is less than 0.1 or amax is
+// close to overflow or underflow. The returned value indicates the form of
+// equilibration that was applied.
+//
+// r must have length at least m and c must have length at least n, otherwise
+// Dlaqge will panic.
+//
+// Dlaqge is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaqge(m, n int, a []float64, lda int, r, c []float64, rowcnd, colcnd, amax float64) lapack.Equilibration {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return lapack.EquilibrateNone
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case len(r) < m:
+		panic(shortR)
+	case len(c) < n:
+		panic(shortC)
+	}
+
+	const thresh = 0.1
+	small := dlamchS / dlamchP
+	large := 1 / small
+
+	rows := rowcnd < thresh || amax < small || large < amax
+	cols := colcnd < thresh
+	switch {
+	case !rows && !cols:
+		return lapack.EquilibrateNone
+	case !rows:
+		for i := 0; i < m; i++ {
+			row := a[i*lda : i*lda+n]
+			for j := range row {
+				row[j] *= c[j]
+			}
+		}
+		return lapack.EquilibrateCols
+	case !cols:
+		for i := 0; i < m; i++ {
+			ri := r[i]
+			row := a[i*lda : i*lda+n]
+			for j := range row {
+				row[j] *= ri
+			}
+		}
+		return lapack.EquilibrateRows
+	}
+	for i := 0; i < m; i++ {
+		ri := r[i]
+		row := a[i*lda : i*lda+n]
+		for j := range row {
+			row[j] *= ri * c[j]
+		}
+	}
+	return lapack.EquilibrateBoth
+}
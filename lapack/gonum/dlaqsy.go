@@ -0,0 +1,70 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlaqsy equilibrates the n×n symmetric matrix A using the scaling factors in
+// s computed by Dpoequ, replacing A by
+//  diag(s) * A * diag(s).
+// The scaling is only applied when it is worthwhile, that is when scond is
+// less than 0.1 or amax is close to overflow or underflow. Dlaqsy returns
+// lapack.EquilibrateBoth if the scaling was applied and
+// lapack.EquilibrateNone otherwise.
+//
+// Only the triangle of A specified by uplo is referenced and updated.
+//
+// s must have length at least n, otherwise Dlaqsy will panic.
+//
+// Dlaqsy is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaqsy(uplo blas.Uplo, n int, a []float64, lda int, s []float64, scond, amax float64) lapack.Equilibration {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return lapack.EquilibrateNone
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(s) < n:
+		panic(shortS)
+	}
+
+	const thresh = 0.1
+	small := dlamchS / dlamchP
+	large := 1 / small
+
+	if scond >= thresh && small <= amax && amax <= large {
+		return lapack.EquilibrateNone
+	}
+	if uplo == blas.Upper {
+		for i := 0; i < n; i++ {
+			si := s[i]
+			for j := i; j < n; j++ {
+				a[i*lda+j] *= si * s[j]
+			}
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			si := s[i]
+			for j := 0; j <= i; j++ {
+				a[i*lda+j] *= si * s[j]
+			}
+		}
+	}
+	return lapack.EquilibrateBoth
+}
@@ -0,0 +1,95 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlasd4 computes the i-th updated singular value of a matrix whose square
+// is a symmetric rank-one modification to a diagonal matrix,
+//  diag(d)^2 + rho * z * z^T.
+// The singular value is the square root of the i-th root, counting from zero,
+// of the secular equation
+//  1 + rho * \sum_j z_j^2 / (d_j^2 - σ^2) = 0.
+//
+// The elements of d must be non-negative and in strictly increasing order, the
+// elements of z must be non-zero and rho must be positive.
+//
+// On return, delta and work contain the differences d_j - σ_i and the sums
+// d_j + σ_i respectively. The differences are computed accurately relative to
+// the distance of σ_i from the nearest d_j. If n == 1, delta[0] and work[0]
+// are set to 1. They are used to compute the singular vectors of the matrix.
+//
+// d, z, delta and work must have length at least n, and i must be in [0, n),
+// otherwise Dlasd4 will panic.
+//
+// Dlasd4 returns the singular value and whether the iteration for the root of
+// the secular equation converged.
+//
+// Dlasd4 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd4(n, i int, d, z, delta []float64, rho float64, work []float64) (sigma float64, ok bool) {
+	switch {
+	case n < 1:
+		panic(nLT1)
+	case i < 0 || n <= i:
+		panic(badI)
+	case len(d) < n:
+		panic(shortD)
+	case len(z) < n:
+		panic(shortZ)
+	case len(delta) < n:
+		panic(shortDelta)
+	case len(work) < n:
+		panic(shortWork)
+	}
+
+	if n == 1 {
+		delta[0] = 1
+		work[0] = 1
+		return math.Sqrt(d[0]*d[0] + rho*z[0]*z[0]), true
+	}
+
+	// Solve the secular equation for σ^2 with the origin shifted to the
+	// square of the nearest d_j. The shifted poles d_j^2 - d_org^2 are
+	// stored in work.
+	var org int
+	var lo, hi float64
+	if i == n-1 {
+		org = i
+		var zz float64
+		for j := 0; j < n; j++ {
+			work[j] = (d[j] - d[org]) * (d[j] + d[org])
+			zz += z[j] * z[j]
+		}
+		lo, hi = 0, rho*zz
+	} else {
+		for j := 0; j < n; j++ {
+			work[j] = (d[j] - d[i]) * (d[j] + d[i])
+		}
+		mid := work[i+1] / 2
+		if secularValue(work[:n], z, rho, mid) >= 0 {
+			org = i
+			lo, hi = 0, mid
+		} else {
+			org = i + 1
+			for j := 0; j < n; j++ {
+				work[j] = (d[j] - d[org]) * (d[j] + d[org])
+			}
+			lo, hi = work[i]/2, 0
+		}
+	}
+
+	tau, ok := secularRoot(i, work[:n], z, rho, lo, hi)
+
+	// σ = d_org + mu where mu is computed from tau = σ^2 - d_org^2 without
+	// cancellation.
+	dorg := d[org]
+	sigma = math.Sqrt(dorg*dorg + tau)
+	mu := tau / (dorg + sigma)
+	for j := 0; j < n; j++ {
+		delta[j] = (d[j] - dorg) - mu
+		work[j] = (d[j] + dorg) + mu
+	}
+	return sigma, ok
+}
@@ -16,9 +16,13 @@
 // Most LAPACK functions are built on top the routines defined in the BLAS API,
 // and as such the computation time for many LAPACK functions is
 // dominated by BLAS calls. Here, BLAS is accessed through the
-// blas64 package (https://godoc.org/golang.org/v1/gonum/blas/blas64). In particular,
-// this implies that an external BLAS library will be used if it is
-// registered in blas64.
+// blas64 package (https://godoc.org/golang.org/v1/gonum/blas/blas64), for the
+// single precision routines through the blas32 package
+// (https://godoc.org/golang.org/v1/gonum/blas/blas32), and for the complex
+// routines through the cblas128 package
+// (https://godoc.org/golang.org/v1/gonum/blas/cblas128). In particular, this
+// implies that an external BLAS library will be used if it is registered in
+// blas64, blas32 or cblas128.
 //
 // The full LAPACK capability has not been implemented at present. The full
 // API is very large, containing approximately 200 functions for double precision
@@ -0,0 +1,74 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dpbcon estimates the reciprocal of the condition number of an n×n symmetric
+// positive definite band matrix A using the Cholesky factorization
+//  A = U^T * U  if uplo == blas.Upper
+//  A = L * L^T  if uplo == blas.Lower
+// computed by Dpbtrf. The condition number computed is based on the 1-norm
+// and the ∞-norm, which are equal for a symmetric matrix.
+//
+// ab must contain the Cholesky factor of A as returned by Dpbtrf. anorm is the
+// 1-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Dpbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dpbcon will panic otherwise.
+func (impl Implementation) Dpbcon(uplo blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64 {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case kd < 0:
+		panic(kdLT0)
+	case ldab < kd+1:
+		panic(badLdA)
+	case anorm < 0:
+		panic(negANorm)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1
+	}
+
+	switch {
+	case len(ab) < (n-1)*ldab+kd+1:
+		panic(shortAB)
+	case len(work) < 2*n:
+		panic(shortWork)
+	case len(iwork) < n:
+		panic(shortIWork)
+	}
+
+	if anorm == 0 {
+		return 0
+	}
+
+	// Estimate the 1-norm of the inverse.
+	var (
+		ainvnm float64
+		kase   int
+		isave  [3]int
+	)
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, &isave)
+		if kase == 0 {
+			break
+		}
+		// Multiply by inv(A), which is symmetric.
+		impl.Dpbtrs(uplo, n, kd, 1, ab, ldab, work, 1)
+	}
+
+	// Compute the estimate of the reciprocal condition number.
+	if ainvnm == 0 {
+		return 0
+	}
+	return (1 / ainvnm) / anorm
+}
@@ -0,0 +1,197 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dporfs improves the computed solution to a system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite matrix, and provides error
+// bounds and backward error estimates for the solution.
+//
+// a contains the original matrix A and af contains its Cholesky factorization
+// as computed by Dpotrf. Only the triangle of a and af specified by uplo is
+// referenced. The factorization in af need only be an approximation to the
+// factorization of A, for example it may be computed from a lower precision
+// copy of A; iterative refinement uses residuals computed with A in double
+// precision.
+//
+// On entry, x contains the solution matrix X as computed by Dpotrs. On return
+// it contains the improved solution.
+//
+// ferr contains on return the estimated forward error bound for each solution
+// vector and berr contains the componentwise relative backward error of each
+// solution vector. See the documentation for Dgerfs for more details.
+//
+// ferr and berr must have length at least nrhs, work must have length at least
+// 3*n and iwork must have length at least n, otherwise Dporfs will panic.
+func (impl Implementation) Dporfs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic(nrhsLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldaf < max(1, n):
+		panic(badLdAF)
+	case ldb < max(1, nrhs):
+		panic(badLdB)
+	case ldx < max(1, nrhs):
+		panic(badLdX)
+	}
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		switch {
+		case len(ferr) < nrhs:
+			panic(shortFerr)
+		case len(berr) < nrhs:
+			panic(shortBerr)
+		}
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(af) < (n-1)*ldaf+n:
+		panic(shortAF)
+	case len(b) < (n-1)*ldb+nrhs:
+		panic(shortB)
+	case len(x) < (n-1)*ldx+nrhs:
+		panic(shortX)
+	case len(ferr) < nrhs:
+		panic(shortFerr)
+	case len(berr) < nrhs:
+		panic(shortBerr)
+	case len(work) < 3*n:
+		panic(shortWork)
+	case len(iwork) < n:
+		panic(shortIWork)
+	}
+
+	const itmax = 5
+
+	// nz is the maximum number of non-zero entries in each row of A plus 1.
+	nz := float64(n + 1)
+	eps := dlamchE
+	safmin := dlamchS
+	safe1 := nz * safmin
+	safe2 := safe1 / eps
+
+	bi := blas64.Implementation()
+	isave := new([3]int)
+	for j := 0; j < nrhs; j++ {
+		xj := x[j:]
+		lstres := 3.0
+		for count := 1; ; count++ {
+			// Compute the residual R = B - A * X in work[n:2*n].
+			bi.Dcopy(n, b[j:], ldb, work[n:2*n], 1)
+			bi.Dsymv(uplo, n, -1, a, lda, xj, ldx, 1, work[n:2*n], 1)
+
+			// Compute componentwise relative backward error from the formula
+			//  max_i (|R[i]| / (|A|*|X| + |B|)[i])
+			// where abs(Z) is the componentwise absolute value of the matrix
+			// or vector Z. If the i-th component of the denominator is less
+			// than safe2, then safe1 is added to the i-th components of the
+			// numerator and denominator before dividing.
+			for i := 0; i < n; i++ {
+				work[i] = math.Abs(b[i*ldb+j])
+			}
+			for i := 0; i < n; i++ {
+				xi := math.Abs(xj[i*ldx])
+				work[i] += math.Abs(a[i*lda+i]) * xi
+				var jlo, jhi int
+				if uplo == blas.Upper {
+					jlo, jhi = i+1, n
+				} else {
+					jlo, jhi = 0, i
+				}
+				for k := jlo; k < jhi; k++ {
+					aik := math.Abs(a[i*lda+k])
+					work[i] += aik * math.Abs(xj[k*ldx])
+					work[k] += aik * xi
+				}
+			}
+			var s float64
+			for i := 0; i < n; i++ {
+				if work[i] > safe2 {
+					s = math.Max(s, math.Abs(work[n+i])/work[i])
+				} else {
+					s = math.Max(s, (math.Abs(work[n+i])+safe1)/(work[i]+safe1))
+				}
+			}
+			berr[j] = s
+
+			// Test stopping criterion. Continue iterating if
+			//  1) the residual berr[j] is larger than machine epsilon, and
+			//  2) berr[j] decreased by at least a factor of 2 during the
+			//     last iteration, and
+			//  3) at most itmax iterations tried.
+			if berr[j] <= eps || 2*berr[j] > lstres || count > itmax {
+				break
+			}
+			// Update solution and try again.
+			impl.Dpotrs(uplo, n, 1, af, ldaf, work[n:2*n], 1)
+			bi.Daxpy(n, 1, work[n:2*n], 1, xj, ldx)
+			lstres = berr[j]
+		}
+
+		// Bound error from formula
+		//  norm(X - XTRUE) / norm(X) ≤ ferr = norm(|inv(A)|*
+		//     (|R| + nz*eps*(|A|*|X|+|B|))) / norm(X)
+		// and use Dlacn2 to estimate the infinity-norm of the matrix
+		//  inv(A) * diag(W),
+		// where W = |R| + nz*eps*(|A|*|X|+|B|).
+		for i := 0; i < n; i++ {
+			if work[i] > safe2 {
+				work[i] = math.Abs(work[n+i]) + nz*eps*work[i]
+			} else {
+				work[i] = math.Abs(work[n+i]) + nz*eps*work[i] + safe1
+			}
+		}
+		var kase int
+		for {
+			ferr[j], kase = impl.Dlacn2(n, work[2*n:3*n], work[n:2*n], iwork, ferr[j], kase, isave)
+			if kase == 0 {
+				break
+			}
+			if kase == 1 {
+				// Multiply by diag(W)*inv(A^T).
+				impl.Dpotrs(uplo, n, 1, af, ldaf, work[n:2*n], 1)
+				for i := 0; i < n; i++ {
+					work[n+i] *= work[i]
+				}
+			} else {
+				// Multiply by inv(A)*diag(W).
+				for i := 0; i < n; i++ {
+					work[n+i] *= work[i]
+				}
+				impl.Dpotrs(uplo, n, 1, af, ldaf, work[n:2*n], 1)
+			}
+		}
+
+		// Normalize error.
+		var xmax float64
+		for i := 0; i < n; i++ {
+			xmax = math.Max(xmax, math.Abs(xj[i*ldx]))
+		}
+		if xmax != 0 {
+			ferr[j] /= xmax
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dposvx uses the Cholesky factorization to compute the solution to a system
+// of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite matrix and X and B are n×nrhs
+// matrices. Error bounds on the solution and a condition estimate are also
+// provided.
+//
+// Dposvx performs the following steps:
+//  1. If fact is lapack.EquilibrateFactorize, real scaling factors are
+//     computed by Dpoequ to equilibrate the system and, if the scaling is
+//     worthwhile, A is overwritten by diag(s)*A*diag(s) and B by diag(s)*B.
+//  2. If fact is not lapack.Factored, the Cholesky decomposition of A is
+//     computed by Dpotrf and stored in af.
+//  3. If the leading principal minor of some order of A is not positive
+//     definite, Dposvx returns ok=false with rcond zero and no solution is
+//     computed. Otherwise the factored form of A is used to estimate the
+//     reciprocal condition number of A.
+//  4. The system is solved by Dpotrs and improved by iterative refinement in
+//     Dporfs which also computes error bounds and backward error estimates.
+//  5. If equilibration was used, the solution is premultiplied by diag(s)
+//     so that it solves the original system before equilibration.
+//
+// Only the triangle of A and of its factorization specified by uplo is
+// referenced.
+//
+// If fact is lapack.Factored, af must contain on entry the Cholesky
+// factorization of A, and equed specifies whether A was equilibrated with the
+// scaling factors in s, in which case it must be lapack.EquilibrateBoth and a
+// must contain the equilibrated matrix, or not, in which case it must be
+// lapack.EquilibrateNone. The scaling factors must be positive when they are
+// used. For other values of fact equed is ignored.
+//
+// On return, if fact is lapack.EquilibrateFactorize, a contains the
+// equilibrated matrix and b contains the equilibrated right-hand side if
+// equilibration was done, and af contains the Cholesky factorization of the
+// equilibrated matrix. equedOut is the form of equilibration that was used.
+//
+// x contains on return the n×nrhs solution matrix. ferr and berr contain the
+// forward error bounds and componentwise backward errors of the solution
+// columns. See the documentation for Dgerfs for details.
+//
+// rcond is the estimate of the reciprocal condition number of A after
+// equilibration, if done. If rcond is less than machine precision, the matrix
+// is singular to working precision and ok is false, but the solution and the
+// error bounds are still computed.
+//
+// s must have length at least n, ferr and berr must have length at least nrhs,
+// work must have length at least 3*n and iwork must have length at least n,
+// otherwise Dposvx will panic.
+func (impl Implementation) Dposvx(fact lapack.FactJob, uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, equed lapack.Equilibration, s []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.Equilibration, rcond float64, ok bool) {
+	factored := fact == lapack.Factored
+	switch {
+	case fact != lapack.Factored && fact != lapack.Factorize && fact != lapack.EquilibrateFactorize:
+		panic(badFactJob)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case factored && equed != lapack.EquilibrateNone && equed != lapack.EquilibrateBoth:
+		panic(badEquilibration)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic(nrhsLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldaf < max(1, n):
+		panic(badLdAF)
+	case ldb < max(1, nrhs):
+		panic(badLdB)
+	case ldx < max(1, nrhs):
+		panic(badLdX)
+	}
+
+	if !factored {
+		equed = lapack.EquilibrateNone
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		impl.Dporfs(uplo, n, nrhs, a, lda, af, ldaf, b, ldb, x, ldx, ferr, berr, work, iwork)
+		return equed, 1, true
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(af) < (n-1)*ldaf+n:
+		panic(shortAF)
+	case len(s) < n:
+		panic(shortS)
+	case nrhs > 0 && len(b) < (n-1)*ldb+nrhs:
+		panic(shortB)
+	case nrhs > 0 && len(x) < (n-1)*ldx+nrhs:
+		panic(shortX)
+	case len(ferr) < nrhs:
+		panic(shortFerr)
+	case len(berr) < nrhs:
+		panic(shortBerr)
+	case len(work) < 3*n:
+		panic(shortWork)
+	case len(iwork) < n:
+		panic(shortIWork)
+	}
+
+	rcequ := equed == lapack.EquilibrateBoth
+	var scond float64
+	if rcequ {
+		scond = scaleCond(s[:n])
+	}
+
+	if fact == lapack.EquilibrateFactorize {
+		// Compute row and column scalings to equilibrate the matrix A.
+		var amax float64
+		var okequ bool
+		scond, amax, okequ = impl.Dpoequ(n, a, lda, s[:n])
+		if okequ {
+			// Equilibrate the matrix.
+			equed = impl.Dlaqsy(uplo, n, a, lda, s[:n], scond, amax)
+			rcequ = equed == lapack.EquilibrateBoth
+		}
+	}
+
+	// Scale the right-hand side.
+	if rcequ {
+		for i := 0; i < n; i++ {
+			si := s[i]
+			for j := 0; j < nrhs; j++ {
+				b[i*ldb+j] *= si
+			}
+		}
+	}
+
+	if !factored {
+		// Compute the Cholesky factorization of A.
+		impl.Dlacpy(uplo, n, n, a, lda, af, ldaf)
+		if !impl.Dpotrf(uplo, n, af, ldaf) {
+			return equed, 0, false
+		}
+	}
+
+	// Compute the norm of the matrix A.
+	anorm := impl.Dlansy(lapack.MaxColumnSum, uplo, n, a, lda, work)
+
+	// Compute the reciprocal of the condition number of A.
+	rcond = impl.Dpocon(uplo, n, af, ldaf, anorm, work, iwork)
+
+	// Compute the solution matrix X.
+	impl.Dlacpy(blas.All, n, nrhs, b, ldb, x, ldx)
+	impl.Dpotrs(uplo, n, nrhs, af, ldaf, x, ldx)
+
+	// Use iterative refinement to improve the computed solution and compute
+	// error bounds and backward error estimates for it.
+	impl.Dporfs(uplo, n, nrhs, a, lda, af, ldaf, b, ldb, x, ldx, ferr, berr, work, iwork)
+
+	// Transform the solution matrix X to a solution of the original system.
+	if rcequ {
+		for i := 0; i < n; i++ {
+			si := s[i]
+			for j := 0; j < nrhs; j++ {
+				x[i*ldx+j] *= si
+			}
+		}
+		for j := 0; j < nrhs; j++ {
+			ferr[j] /= scond
+		}
+	}
+
+	return equed, rcond, rcond >= dlamchE
+}
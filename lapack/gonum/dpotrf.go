@@ -14,6 +14,12 @@ import (
 // and a = U^T U is stored in place into a. If ul == blas.Lower, then a = L L^T
 // is computed and stored in-place into a. If a is not positive definite, false
 // is returned. This is the blocked version of the algorithm.
+//
+// If Ilaenv(7, ...) reports more than one worker, which is only the case when
+// the number of workers has been set explicitly, and a spans at least
+// minParBlocks blocks, Dpotrf uses a right-looking variant of the algorithm in
+// which the updates of the trailing submatrix are computed concurrently. The
+// result may differ from the serial algorithm by rounding.
 func (impl Implementation) Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok bool) {
 	switch {
 	case ul != blas.Upper && ul != blas.Lower:
@@ -37,6 +43,9 @@ func (impl Implementation) Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok
 	if nb <= 1 || n <= nb {
 		return impl.Dpotf2(ul, n, a, lda)
 	}
+	if nw := impl.Ilaenv(7, "DPOTRF", string(ul), n, -1, -1, -1); nw > 1 && n >= minParBlocks*nb {
+		return impl.dpotrfRight(ul, n, a, lda, nb, nw)
+	}
 	bi := blas64.Implementation()
 	if ul == blas.Upper {
 		for j := 0; j < n; j += nb {
@@ -79,3 +88,78 @@ func (impl Implementation) Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok
 	}
 	return true
 }
+
+// dpotrfRight computes the Cholesky decomposition of the symmetric positive
+// definite matrix a using a right-looking blocked algorithm with block size nb.
+// The triangular solves and the updates of the diagonal blocks of the trailing
+// submatrix are split into blocks of nb columns (or rows) which are processed
+// concurrently by at most nw goroutines. The remaining updates of the trailing
+// submatrix are computed by Dgemm, which is itself parallel, so they are
+// issued from the calling goroutine to avoid nesting the two levels.
+func (impl Implementation) dpotrfRight(ul blas.Uplo, n int, a []float64, lda int, nb, nw int) (ok bool) {
+	bi := blas64.Implementation()
+	if ul == blas.Upper {
+		for j := 0; j < n; j += nb {
+			jb := min(nb, n-j)
+			ok = impl.Dpotf2(blas.Upper, jb, a[j*lda+j:], lda)
+			if !ok {
+				return ok
+			}
+			j1 := j + jb
+			if j1 == n {
+				break
+			}
+			// Compute the block row A12 = U11^-T * A12.
+			parallelBlocks(nw, j1, n, nb, func(c0, c1 int) {
+				bi.Dtrsm(blas.Left, blas.Upper, blas.Trans, blas.NonUnit, jb, c1-c0,
+					1, a[j*lda+j:], lda,
+					a[j*lda+c0:], lda)
+			})
+			// Update the upper triangle of A22 -= A12^T * A12, first the
+			// diagonal blocks and then the block rows to their right.
+			parallelBlocks(nw, j1, n, nb, func(c0, c1 int) {
+				bi.Dsyrk(blas.Upper, blas.Trans, c1-c0, jb,
+					-1, a[j*lda+c0:], lda,
+					1, a[c0*lda+c0:], lda)
+			})
+			for r0 := j1; r0+nb < n; r0 += nb {
+				r1 := r0 + nb
+				bi.Dgemm(blas.Trans, blas.NoTrans, nb, n-r1, jb,
+					-1, a[j*lda+r0:], lda, a[j*lda+r1:], lda,
+					1, a[r0*lda+r1:], lda)
+			}
+		}
+		return true
+	}
+	for j := 0; j < n; j += nb {
+		jb := min(nb, n-j)
+		ok = impl.Dpotf2(blas.Lower, jb, a[j*lda+j:], lda)
+		if !ok {
+			return ok
+		}
+		j1 := j + jb
+		if j1 == n {
+			break
+		}
+		// Compute the block column A21 = A21 * L11^-T.
+		parallelBlocks(nw, j1, n, nb, func(r0, r1 int) {
+			bi.Dtrsm(blas.Right, blas.Lower, blas.Trans, blas.NonUnit, r1-r0, jb,
+				1, a[j*lda+j:], lda,
+				a[r0*lda+j:], lda)
+		})
+		// Update the lower triangle of A22 -= A21 * A21^T, first the
+		// diagonal blocks and then the block columns below them.
+		parallelBlocks(nw, j1, n, nb, func(r0, r1 int) {
+			bi.Dsyrk(blas.Lower, blas.NoTrans, r1-r0, jb,
+				-1, a[r0*lda+j:], lda,
+				1, a[r0*lda+r0:], lda)
+		})
+		for c0 := j1; c0+nb < n; c0 += nb {
+			c1 := c0 + nb
+			bi.Dgemm(blas.NoTrans, blas.Trans, n-c1, nb, jb,
+				-1, a[c1*lda+j:], lda, a[c0*lda+j:], lda,
+				1, a[c1*lda+c0:], lda)
+		}
+	}
+	return true
+}
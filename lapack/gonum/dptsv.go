@@ -0,0 +1,57 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Dptsv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite tridiagonal matrix, and X and B
+// are n×nrhs matrices. A is factored as A = L*D*L^T, and the factored form of A
+// is then used to solve the system of equations.
+//
+// On entry, d and e contain the n diagonal and (n-1) sub-diagonal elements,
+// respectively, of A. On return, d and e contain the factorization of A as
+// computed by Dpttrf.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, it is
+// overwritten with the solution matrix X.
+//
+// d must have length at least n and e must have length at least n-1, otherwise
+// Dptsv will panic.
+//
+// Dptsv returns whether the solution X has been successfully computed. If ok
+// is false, the leading minor of some order is not positive definite, and the
+// solution has not been computed.
+func (impl Implementation) Dptsv(n, nrhs int, d, e []float64, b []float64, ldb int) (ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic(nrhsLT0)
+	case ldb < max(1, nrhs):
+		panic(badLdB)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	switch {
+	case len(d) < n:
+		panic(shortD)
+	case len(e) < n-1:
+		panic(shortE)
+	case nrhs > 0 && len(b) < (n-1)*ldb+nrhs:
+		panic(shortB)
+	}
+
+	// Compute the L*D*L^T factorization of A.
+	ok = impl.Dpttrf(n, d, e)
+	if ok {
+		// Solve the system A*X = B, overwriting B with X.
+		impl.Dpttrs(n, nrhs, d, e, b, ldb)
+	}
+	return ok
+}
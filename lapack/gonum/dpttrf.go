@@ -0,0 +1,50 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Dpttrf computes the L*D*L^T factorization of an n×n symmetric positive
+// definite tridiagonal matrix A and returns whether the factorization was
+// successfully completed.
+//
+// On entry, d and e contain the n diagonal and (n-1) sub-diagonal elements,
+// respectively, of A. On return, d contains the n diagonal elements of the
+// diagonal matrix D and e contains the (n-1) sub-diagonal elements of the unit
+// lower bidiagonal matrix L.
+//
+// d must have length at least n and e must have length at least n-1, otherwise
+// Dpttrf will panic.
+//
+// If ok is false, the leading minor of some order is not positive definite and
+// the factorization could not be completed.
+func (Implementation) Dpttrf(n int, d, e []float64) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	switch {
+	case len(d) < n:
+		panic(shortD)
+	case len(e) < n-1:
+		panic(shortE)
+	}
+
+	// Compute the L*D*L^T factorization of A.
+	for i := 0; i < n-1; i++ {
+		// Test for non-positive diagonal elements.
+		if d[i] <= 0 {
+			return false
+		}
+		// Solve for e[i] and d[i+1].
+		ei := e[i]
+		e[i] = ei / d[i]
+		d[i+1] -= e[i] * ei
+	}
+	return d[n-1] > 0
+}
@@ -0,0 +1,52 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Dpttrs solves a system of linear equations A * X = B with an n×n symmetric
+// positive definite tridiagonal matrix A using the L*D*L^T factorization of A
+// computed by Dpttrf.
+//
+// d and e must contain the n diagonal elements of D and the (n-1)
+// sub-diagonal elements of the unit lower bidiagonal matrix L, respectively,
+// as returned by Dpttrf.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, it is
+// overwritten with the solution matrix X.
+func (Implementation) Dpttrs(n, nrhs int, d, e []float64, b []float64, ldb int) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic(nrhsLT0)
+	case ldb < max(1, nrhs):
+		panic(badLdB)
+	}
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	switch {
+	case len(d) < n:
+		panic(shortD)
+	case len(e) < n-1:
+		panic(shortE)
+	case len(b) < (n-1)*ldb+nrhs:
+		panic(shortB)
+	}
+
+	for j := 0; j < nrhs; j++ {
+		// Solve L * x = b.
+		for i := 1; i < n; i++ {
+			b[i*ldb+j] -= b[(i-1)*ldb+j] * e[i-1]
+		}
+		// Solve D * L^T * x = b.
+		b[(n-1)*ldb+j] /= d[n-1]
+		for i := n - 2; i >= 0; i-- {
+			b[i*ldb+j] = b[i*ldb+j]/d[i] - b[(i+1)*ldb+j]*e[i]
+		}
+	}
+}
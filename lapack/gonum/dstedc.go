@@ -0,0 +1,386 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dstedc computes all eigenvalues and, optionally, the eigenvectors of a
+// symmetric tridiagonal matrix using the divide and conquer method. The
+// eigenvectors of a full or band symmetric matrix can also be found if Dsytrd
+// has been used to reduce this matrix to tridiagonal form.
+//
+// The matrix is recursively split into two halves whose eigensystems are
+// merged by solving the secular equation of a symmetric rank-one modification
+// of a diagonal matrix. Blocks of order at most 25 are solved by Dsteqr.
+//
+// d, on entry, contains the diagonal elements of the tridiagonal matrix. On
+// exit, d contains the eigenvalues in ascending order. d must have length n
+// and Dstedc will panic otherwise.
+//
+// e, on entry, contains the off-diagonal elements of the tridiagonal matrix on
+// entry, and is overwritten during the call to Dstedc. e must have length n-1
+// and Dstedc will panic otherwise.
+//
+// z, on entry, contains the n×n orthogonal matrix used in the reduction to
+// tridiagonal form if compz == lapack.EVOrig. On exit, if
+// compz == lapack.EVOrig, z contains the orthonormal eigenvectors of the
+// original symmetric matrix, and if compz == lapack.EVTridiag, z contains the
+// orthonormal eigenvectors of the symmetric tridiagonal matrix. z is not used
+// if compz == lapack.EVCompNone.
+//
+// work must have length at least max(1, lwork) and iwork must have length at
+// least max(1, liwork). If compz == lapack.EVCompNone or n <= 1, lwork and
+// liwork must be at least 1. Otherwise, if n <= 25, lwork must be at least
+// 2*(n-1) and liwork at least 1, and for larger n liwork must be at least
+// 3+5*n and lwork must be at least
+//  1+4*n+2*n*n if compz == lapack.EVTridiag,
+//  1+4*n+3*n*n if compz == lapack.EVOrig.
+// If lwork == -1 or liwork == -1, instead of computing the decomposition, the
+// minimum lengths of work and iwork are stored into work[0] and iwork[0].
+// Dstedc will panic if the workspace is insufficient.
+//
+// Dstedc returns whether the decomposition was successful.
+func (impl Implementation) Dstedc(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	switch {
+	case compz != lapack.EVCompNone && compz != lapack.EVTridiag && compz != lapack.EVOrig:
+		panic(badEVComp)
+	case n < 0:
+		panic(nLT0)
+	case ldz < 1, compz != lapack.EVCompNone && ldz < n:
+		panic(badLdZ)
+	}
+
+	// Compute the workspace requirements.
+	smlsiz := impl.Ilaenv(9, "DSTEDC", " ", 0, 0, 0, 0)
+	lwmin := 1
+	liwmin := 1
+	if compz != lapack.EVCompNone && n > 1 {
+		if n <= smlsiz {
+			lwmin = 2 * (n - 1)
+		} else {
+			lwmin = 1 + 4*n + 2*n*n
+			if compz == lapack.EVOrig {
+				lwmin += n * n
+			}
+			liwmin = 3 + 5*n
+		}
+	}
+	switch {
+	case lwork < lwmin && lwork != -1:
+		panic(badLWork)
+	case liwork < liwmin && liwork != -1:
+		panic(badLIWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case len(iwork) < max(1, liwork):
+		panic(shortIWork)
+	}
+
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return true
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	switch {
+	case len(d) < n:
+		panic(shortD)
+	case len(e) < n-1:
+		panic(shortE)
+	case compz != lapack.EVCompNone && len(z) < (n-1)*ldz+n:
+		panic(shortZ)
+	}
+
+	work[0] = float64(lwmin)
+	iwork[0] = liwmin
+
+	if compz == lapack.EVCompNone {
+		return impl.Dsterf(n, d, e)
+	}
+	if n == 1 {
+		if compz == lapack.EVTridiag {
+			z[0] = 1
+		}
+		return true
+	}
+	if n <= smlsiz {
+		return impl.Dsteqr(compz, n, d, e, z, ldz, work)
+	}
+
+	// Scale the matrix so that its largest element is one.
+	orgnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	if orgnrm == 0 {
+		if compz == lapack.EVTridiag {
+			impl.Dlaset(blas.All, n, n, 0, 1, z, ldz)
+		}
+		return true
+	}
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n-1, 1, e, 1)
+
+	// The eigenvectors of the tridiagonal matrix are computed as the rows of
+	// q, which is z itself if they are requested, and are transposed at the
+	// end.
+	q, ldq := z, ldz
+	wrk := work
+	if compz == lapack.EVOrig {
+		q, ldq = work[:n*n], n
+		wrk = work[n*n:]
+	}
+	impl.Dlaset(blas.All, n, n, 0, 0, q, ldq)
+	ok = impl.dstedcSolve(n, d, e, q, ldq, smlsiz, wrk, iwork)
+	impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+	if !ok {
+		return false
+	}
+
+	if compz == lapack.EVTridiag {
+		transposeSquare(n, z, ldz)
+	} else {
+		bi := blas64.Implementation()
+		bi.Dgemm(blas.NoTrans, blas.Trans, n, n, n, 1, z, ldz, q, ldq, 0, wrk, n)
+		impl.Dlacpy(blas.All, n, n, wrk, n, z, ldz)
+	}
+	return true
+}
+
+// dstedcSolve computes the eigenvalues and eigenvectors of the n×n symmetric
+// tridiagonal matrix with diagonal d and off-diagonal e. The eigenvalues are
+// stored in ascending order in d and the corresponding eigenvectors in the
+// rows of q, which must be zero on entry.
+func (impl Implementation) dstedcSolve(n int, d, e, q []float64, ldq, smlsiz int, work []float64, iwork []int) bool {
+	if n <= smlsiz {
+		if !impl.Dsteqr(lapack.EVTridiag, n, d, e, q, ldq, work) {
+			return false
+		}
+		transposeSquare(n, q, ldq)
+		return true
+	}
+
+	// Split the matrix in two halves modified by a rank-one correction
+	// that restores the coupling element.
+	n1 := n / 2
+	beta := e[n1-1]
+	d[n1-1] -= math.Abs(beta)
+	d[n1] -= math.Abs(beta)
+	if !impl.dstedcSolve(n1, d[:n1], e[:n1-1], q, ldq, smlsiz, work, iwork) {
+		return false
+	}
+	if !impl.dstedcSolve(n-n1, d[n1:n], e[n1:n-1], q[n1*ldq+n1:], ldq, smlsiz, work, iwork) {
+		return false
+	}
+	return impl.dstedcMerge(n, n1, d, q, ldq, beta, work, iwork)
+}
+
+// dstedcMerge computes the eigensystem of the n×n symmetric tridiagonal
+// matrix whose leading n1×n1 and trailing (n-n1)×(n-n1) blocks, modified by
+// the coupling element beta, have eigenvalues in d and eigenvectors in the
+// rows of the corresponding diagonal blocks of q. On return d holds the
+// eigenvalues in ascending order and q the eigenvectors in its rows.
+//
+// work must have length at least 4*n+2*n*n and iwork at least 4*n.
+func (impl Implementation) dstedcMerge(n, n1 int, d, q []float64, ldq int, beta float64, work []float64, iwork []int) bool {
+	bi := blas64.Implementation()
+	eps := dlamchE
+
+	z := work[:n]
+	dlamda := work[n : 2*n]
+	w := work[2*n : 3*n]
+	lam := work[3*n : 4*n]
+	s := work[4*n : 4*n+n*n]
+	buf := work[4*n+n*n : 4*n+2*n*n]
+
+	perm := iwork[:n]
+	keep := iwork[n : 2*n]
+	defl := iwork[2*n : 3*n]
+	order := iwork[3*n : 4*n]
+
+	// Form the updating vector from the last components of the
+	// eigenvectors of the leading block and the first components of the
+	// eigenvectors of the trailing block, normalized to unit length.
+	for j := 0; j < n1; j++ {
+		z[j] = q[j*ldq+n1-1]
+	}
+	for j := n1; j < n; j++ {
+		z[j] = q[j*ldq+n1]
+	}
+	if beta < 0 {
+		bi.Dscal(n-n1, -1, z[n1:], 1)
+	}
+	bi.Dscal(n, 1/math.Sqrt2, z, 1)
+	rho := 2 * math.Abs(beta)
+
+	// Merge the eigenvalues of the two blocks into ascending order.
+	i, j := 0, n1
+	for k := 0; k < n; k++ {
+		if j == n || (i < n1 && d[i] <= d[j]) {
+			perm[k] = i
+			i++
+		} else {
+			perm[k] = j
+			j++
+		}
+	}
+
+	var dmax, zmax float64
+	for j := 0; j < n; j++ {
+		dmax = math.Max(dmax, math.Abs(d[j]))
+		zmax = math.Max(zmax, math.Abs(z[j]))
+	}
+	tol := 8 * eps * math.Max(dmax, zmax)
+
+	// Deflate eigenvalues whose component in the updating vector is
+	// negligible, and eigenvalue pairs that are close enough to be combined
+	// by a rotation that zeros one of their components.
+	var k, nd int
+	if rho*zmax <= tol {
+		for _, j := range perm {
+			defl[nd] = j
+			nd++
+		}
+	} else {
+		pj := -1
+		for _, j := range perm {
+			if rho*math.Abs(z[j]) <= tol {
+				defl[nd] = j
+				nd++
+				continue
+			}
+			if pj < 0 {
+				pj = j
+				continue
+			}
+			sn := z[pj]
+			cs := z[j]
+			tau := impl.Dlapy2(cs, sn)
+			t := d[j] - d[pj]
+			cs /= tau
+			sn = -sn / tau
+			if math.Abs(t*cs*sn) <= tol {
+				z[j] = tau
+				z[pj] = 0
+				bi.Drot(n, q[pj*ldq:], 1, q[j*ldq:], 1, cs, sn)
+				t = d[pj]*cs*cs + d[j]*sn*sn
+				d[j] = d[pj]*sn*sn + d[j]*cs*cs
+				d[pj] = t
+				defl[nd] = pj
+				nd++
+			} else {
+				keep[k] = pj
+				k++
+			}
+			pj = j
+		}
+		if pj >= 0 {
+			keep[k] = pj
+			k++
+		}
+	}
+
+	// Solve the secular equation for the eigenvalues that are not deflated
+	// and compute the eigenvectors of the rank-one modification in the rows
+	// of s.
+	for i := 0; i < k; i++ {
+		dlamda[i] = d[keep[i]]
+		w[i] = z[keep[i]]
+	}
+	switch {
+	case k == 1:
+		lam[0] = dlamda[0] + rho*w[0]*w[0]
+		s[0] = 1
+	case k > 1:
+		for j := 0; j < k; j++ {
+			var ok bool
+			lam[j], ok = impl.Dlaed4(k, j, dlamda, w, s[j*k:(j+1)*k], rho)
+			if !ok {
+				return false
+			}
+		}
+		// Recompute the updating vector from the computed eigenvalues so
+		// that the eigenvectors are numerically orthogonal.
+		for i := 0; i < k; i++ {
+			wi := s[i*k+i]
+			for j := 0; j < k; j++ {
+				if j != i {
+					wi *= s[j*k+i] / (dlamda[i] - dlamda[j])
+				}
+			}
+			w[i] = math.Copysign(math.Sqrt(-wi), w[i])
+		}
+		for j := 0; j < k; j++ {
+			row := s[j*k : (j+1)*k]
+			for i := range row {
+				row[i] = w[i] / row[i]
+			}
+			bi.Dscal(k, 1/bi.Dnrm2(k, row, 1), row, 1)
+		}
+	}
+
+	// Update the eigenvectors of the non-deflated eigenvalues and append
+	// the deflated eigenpairs.
+	for i := 0; i < k; i++ {
+		copy(buf[i*n:(i+1)*n], q[keep[i]*ldq:keep[i]*ldq+n])
+	}
+	for i := 0; i < nd; i++ {
+		copy(buf[(k+i)*n:(k+i+1)*n], q[defl[i]*ldq:defl[i]*ldq+n])
+		lam[k+i] = d[defl[i]]
+	}
+	if k > 0 {
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, k, n, k, 1, s, k, buf, n, 0, q, ldq)
+	}
+	if nd > 0 {
+		impl.Dlacpy(blas.All, nd, n, buf[k*n:], n, q[k*ldq:], ldq)
+	}
+
+	// Sort the eigenpairs into ascending order.
+	sortRows(n, n, lam, d, q, ldq, order, buf)
+	return true
+}
+
+// sortRows stores the values in vals sorted into ascending order in dst and
+// permutes the first n rows of the matrix a with ncol columns accordingly.
+// order must have length at least n, and buf at least n*ncol.
+func sortRows(n, ncol int, vals, dst, a []float64, lda int, order []int, buf []float64) {
+	for i := 0; i < n; i++ {
+		order[i] = i
+	}
+	// The values are usually nearly sorted, so use insertion sort.
+	for i := 1; i < n; i++ {
+		oi := order[i]
+		v := vals[oi]
+		j := i
+		for ; j > 0 && vals[order[j-1]] > v; j-- {
+			order[j] = order[j-1]
+		}
+		order[j] = oi
+	}
+	for i, oi := range order[:n] {
+		copy(buf[i*ncol:(i+1)*ncol], a[oi*lda:oi*lda+ncol])
+		dst[i] = vals[oi]
+	}
+	for i := 0; i < n; i++ {
+		copy(a[i*lda:i*lda+ncol], buf[i*ncol:(i+1)*ncol])
+	}
+}
+
+// transposeSquare transposes the n×n matrix a in place.
+func transposeSquare(n int, a []float64, lda int) {
+	for i := 1; i < n; i++ {
+		for j := 0; j < i; j++ {
+			a[i*lda+j], a[j*lda+i] = a[j*lda+i], a[i*lda+j]
+		}
+	}
+}
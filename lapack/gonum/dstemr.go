@@ -0,0 +1,1087 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Parameters of the representation tree built by Dstemr.
+const (
+	// mrrrLevels is the maximum depth of the representation tree. Clusters
+	// that are not resolved at the deepest level are handled by inverse
+	// iteration with reorthogonalization.
+	mrrrLevels = 7
+	// mrrrMinRelGap is the relative gap below which neighboring eigenvalues
+	// are treated as a cluster.
+	mrrrMinRelGap = 1e-2
+	// mrrrMaxGrowth bounds the element growth of the representation of a
+	// cluster relative to the spectral diameter of the block.
+	mrrrMaxGrowth = 8
+)
+
+// Dstemr computes selected eigenvalues and, optionally, eigenvectors of an n×n
+// symmetric tridiagonal matrix T using the algorithm of Multiple Relatively
+// Robust Representations (MRRR). Computing k eigenvectors with Dstemr takes
+// O(n*k) operations in most cases, which makes it the fastest method for
+// computing all or a subset of the eigenpairs of a tridiagonal matrix.
+//
+// The diagonal elements of T are stored in d and the off-diagonal elements in
+// e. d must have length at least n and e must have length at least n-1, and
+// Dstemr will panic otherwise. On return, d and e are overwritten.
+//
+// rng specifies which eigenvalues are computed. If rng == lapack.EVRangeAll, all
+// eigenvalues are computed. If rng == lapack.EVRangeValue, the eigenvalues in
+// the half-open interval (vl, vu] are computed and vl must be less than vu. If
+// rng == lapack.EVRangeIndex, the eigenvalues with zero-based indices il
+// through iu, inclusive, are computed, and il and iu must satisfy
+//  0 <= il <= iu < n  if n > 0,
+//  il = 0, iu = -1    if n == 0.
+// vl and vu are not referenced unless rng == lapack.EVRangeValue, and il and
+// iu are not referenced unless rng == lapack.EVRangeIndex.
+//
+// The number of eigenvalues found is returned in m and the eigenvalues are
+// stored in ascending order in w[:m]. w must have length at least n.
+//
+// If jobz == lapack.EVCompute, the orthonormal eigenvectors corresponding to
+// w[:m] are stored in the first m columns of the n×nz matrix Z, where nz is
+// iu-il+1 if rng == lapack.EVRangeIndex and n otherwise, and ldz must be at
+// least max(1,nz). The rows of the non-zero elements of the i-th eigenvector
+// are isuppz[2*i] through isuppz[2*i+1], and isuppz must have length at least
+// 2*nz. If jobz == lapack.EVNone, z and isuppz are not referenced.
+//
+// If tryrac is true, Dstemr checks whether T is definite and, if it is,
+// computes the eigenvalues and eigenvectors to high relative accuracy. rac
+// reports whether T was found to define its eigenvalues to high relative
+// accuracy.
+//
+// work must have length at least max(1, lwork) and iwork must have length at
+// least max(1, liwork). lwork must be at least max(1, 7*n) if jobz ==
+// lapack.EVNone and max(1, 19*n) if jobz == lapack.EVCompute, and liwork must
+// be at least max(1, 2*n). If lwork == -1 or liwork == -1, instead of
+// computing the eigenvalues, the minimum lengths of work and iwork are stored
+// into work[0] and iwork[0]. Dstemr will panic if the workspace is
+// insufficient.
+//
+// Dstemr returns whether the computation was successful.
+func (impl Implementation) Dstemr(jobz lapack.EVJob, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, tryrac bool, work []float64, lwork int, iwork []int, liwork int) (m int, rac, ok bool) {
+	wantz := jobz == lapack.EVCompute
+	nz := n
+	if rng == lapack.EVRangeIndex {
+		nz = iu - il + 1
+	}
+	switch {
+	case jobz != lapack.EVNone && !wantz:
+		panic(badEVJob)
+	case rng != lapack.EVRangeAll && rng != lapack.EVRangeValue && rng != lapack.EVRangeIndex:
+		panic(badEVRange)
+	case n < 0:
+		panic(nLT0)
+	case rng == lapack.EVRangeValue && vl >= vu:
+		panic(badVlVu)
+	case rng == lapack.EVRangeIndex && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case rng == lapack.EVRangeIndex && (iu < min(n-1, il) || iu >= n):
+		panic(badIu)
+	case ldz < 1, wantz && ldz < nz:
+		panic(badLdZ)
+	}
+
+	lwmin := max(1, 7*n)
+	if wantz {
+		lwmin = max(1, (5+2*mrrrLevels)*n)
+	}
+	liwmin := max(1, 2*n)
+	switch {
+	case lwork < lwmin && lwork != -1:
+		panic(badLWork)
+	case liwork < liwmin && liwork != -1:
+		panic(badLIWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case len(iwork) < max(1, liwork):
+		panic(shortIWork)
+	}
+
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return 0, tryrac, true
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, tryrac, true
+	}
+
+	switch {
+	case len(d) < n:
+		panic(shortD)
+	case len(e) < n-1:
+		panic(shortE)
+	case len(w) < n:
+		panic(shortW)
+	case wantz && len(z) < (n-1)*ldz+nz:
+		panic(shortZ)
+	case wantz && len(isuppz) < 2*nz:
+		panic(shortIsuppz)
+	}
+
+	if n == 1 {
+		if rng == lapack.EVRangeValue && (d[0] <= vl || vu < d[0]) {
+			return 0, tryrac, true
+		}
+		w[0] = d[0]
+		if wantz {
+			z[0] = 1
+			isuppz[0] = 0
+			isuppz[1] = 0
+		}
+		return 1, tryrac, true
+	}
+
+	const (
+		eps    = dlamchP
+		safmin = dlamchS
+	)
+	bi := blas64.Implementation()
+
+	// Scale the matrix to the allowable range, if necessary.
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+	tnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	scale := 1.0
+	if 0 < tnrm && tnrm < rmin {
+		scale = rmin / tnrm
+	} else if tnrm > rmax {
+		scale = rmax / tnrm
+	}
+	if scale != 1 {
+		bi.Dscal(n, scale, d, 1)
+		bi.Dscal(n-1, scale, e, 1)
+		tnrm *= scale
+		vl *= scale
+		vu *= scale
+	}
+
+	var emax2 float64
+	for _, v := range e[:n-1] {
+		emax2 = math.Max(emax2, v*v)
+	}
+	pivmin := safmin / eps * math.Max(1, emax2)
+
+	// T defines its eigenvalues to high relative accuracy if it is definite,
+	// that is, if all pivots of its LDL^T factorization have the same sign.
+	if tryrac {
+		var npos, nneg int
+		piv := d[0]
+		for i := 0; ; i++ {
+			if piv > 0 {
+				npos++
+			} else if piv < 0 {
+				nneg++
+			}
+			if i == n-1 {
+				break
+			}
+			piv = d[i+1] - e[i]*e[i]/piv
+		}
+		rac = npos == n || nneg == n
+	}
+
+	// Split the matrix into unreduced blocks by setting negligible
+	// off-diagonal elements to zero.
+	for i := 0; i < n-1; i++ {
+		if rac {
+			if math.Abs(e[i]) <= eps*math.Sqrt(math.Abs(d[i]))*math.Sqrt(math.Abs(d[i+1])) {
+				e[i] = 0
+			}
+		} else if math.Abs(e[i]) <= eps*tnrm {
+			e[i] = 0
+		}
+	}
+
+	// Compute all eigenvalues of each block by the dqds algorithm applied to
+	// the root representation L*D*L^T = T - sigma*I of the block. The
+	// eigenvalues of T are stored in lam and, if the eigenvectors are wanted,
+	// the eigenvalues of the root representations in mu.
+	lam := work[:n]
+	var mu, rd, rl, qd []float64
+	if wantz {
+		mu = work[n : 2*n]
+		rd = work[5*n : 6*n]
+		rl = work[6*n : 7*n]
+		qd = work[7*n : 11*n]
+	} else {
+		rd = work[n : 2*n]
+		rl = work[2*n : 3*n]
+		qd = work[3*n : 7*n]
+	}
+	nblock := 0
+	for bs := 0; bs < n; {
+		be := bs + 1
+		for be < n && e[be-1] != 0 {
+			be++
+		}
+		bn := be - bs
+		nblock++
+		if bn == 1 {
+			lam[bs] = d[bs]
+			if wantz {
+				mu[bs] = 0
+			}
+			bs = be
+			continue
+		}
+		sigma, _ := stemrRoot(bn, d[bs:be], e[bs:be-1], rac, pivmin, rd[bs:be], rl[bs:be-1])
+		for i := 0; i < bn; i++ {
+			qd[2*i] = math.Abs(rd[bs+i])
+			qd[2*i+1] = 0
+			if i < bn-1 {
+				qd[2*i+1] = rl[bs+i] * rl[bs+i] * qd[2*i]
+			}
+		}
+		if impl.Dlasq2(bn, qd) != 0 {
+			return 0, rac, false
+		}
+		// Dlasq2 returns the eigenvalues of the positive definite L*|D|*L^T in
+		// decreasing order.
+		pos := rd[bs] > 0
+		for i := 0; i < bn; i++ {
+			v := -qd[i]
+			if pos {
+				v = qd[bn-1-i]
+			}
+			if wantz {
+				mu[bs+i] = v
+			}
+			lam[bs+i] = sigma + v
+		}
+		bs = be
+	}
+
+	// Select the wanted eigenvalues and assign them to the columns of Z in
+	// the order of the blocks.
+	col := iwork[:n]
+	for k := range col {
+		col[k] = -1
+	}
+	switch rng {
+	case lapack.EVRangeAll:
+		for k := range col {
+			col[k] = 0
+		}
+	case lapack.EVRangeValue:
+		for k, v := range lam {
+			if vl < v && v <= vu {
+				col[k] = 0
+			}
+		}
+	case lapack.EVRangeIndex:
+		order := iwork[n : 2*n]
+		for k := range order {
+			order[k] = k
+		}
+		sort.SliceStable(order, func(i, j int) bool { return lam[order[i]] < lam[order[j]] })
+		for _, k := range order[il : iu+1] {
+			col[k] = 0
+		}
+	}
+	for k := range col {
+		if col[k] == 0 {
+			col[k] = m
+			m++
+		}
+	}
+
+	if !wantz {
+		for k, c := range col {
+			if c >= 0 {
+				w[c] = lam[k]
+			}
+		}
+		sort.Float64s(w[:m])
+		if scale != 1 {
+			bi.Dscal(m, 1/scale, w, 1)
+		}
+		return m, rac, true
+	}
+
+	// Compute the eigenvectors block by block.
+	for c := 0; c < m; c++ {
+		for i := 0; i < n; i++ {
+			z[i*ldz+c] = 0
+		}
+	}
+	st := mrrr{
+		impl:   impl,
+		d:      d,
+		e:      e,
+		lo:     lam,
+		hi:     mu,
+		col:    col,
+		w:      w,
+		z:      z,
+		ldz:    ldz,
+		isuppz: isuppz,
+		pivmin: pivmin,
+		sp:     work[2*n : 3*n],
+		dp:     work[3*n : 4*n],
+		dm:     work[4*n : 5*n],
+		rep:    work[5*n : (5+2*mrrrLevels)*n],
+		n:      n,
+	}
+	for bs := 0; bs < n; {
+		be := bs + 1
+		for be < n && e[be-1] != 0 {
+			be++
+		}
+		bn := be - bs
+		var wanted bool
+		for _, c := range col[bs:be] {
+			wanted = wanted || c >= 0
+		}
+		switch {
+		case !wanted:
+		case bn == 1:
+			c := col[bs]
+			z[bs*ldz+c] = 1
+			isuppz[2*c] = bs
+			isuppz[2*c+1] = bs
+			w[c] = d[bs]
+		default:
+			st.bs = bs
+			st.bn = bn
+			rd, rl := st.level(0)
+			sigma, spdiam := stemrRoot(bn, d[bs:be], e[bs:be-1], rac, pivmin, rd, rl)
+			st.spdiam = spdiam
+			for k := bs; k < be; k++ {
+				v := mu[k]
+				delta := 4*float64(bn)*eps*math.Abs(v) + pivmin
+				st.lo[k] = v - delta
+				st.hi[k] = v + delta
+			}
+			st.node(0, 0, bn-1, spdiam, spdiam, sigma)
+		}
+		bs = be
+	}
+
+	// Sort the eigenvalues and eigenvectors if the eigenvalues of different
+	// blocks are interleaved or refinement changed the order of very close
+	// eigenvalues.
+	if nblock > 1 || !sort.Float64sAreSorted(w[:m]) {
+		for i := 0; i < m-1; i++ {
+			j := i
+			for k := i + 1; k < m; k++ {
+				if w[k] < w[j] {
+					j = k
+				}
+			}
+			if j != i {
+				w[i], w[j] = w[j], w[i]
+				bi.Dswap(n, z[i:], ldz, z[j:], ldz)
+				isuppz[2*i], isuppz[2*j] = isuppz[2*j], isuppz[2*i]
+				isuppz[2*i+1], isuppz[2*j+1] = isuppz[2*j+1], isuppz[2*i+1]
+			}
+		}
+	}
+	if scale != 1 {
+		bi.Dscal(m, 1/scale, w, 1)
+	}
+	return m, rac, true
+}
+
+// stemrRoot computes the root representation L*D*L^T = T - sigma*I of the n×n
+// unreduced symmetric tridiagonal block T with diagonal d and off-diagonal e,
+// and returns sigma and the width of the Gerschgorin interval of T. The
+// diagonal of D is stored in rd and the subdiagonal of L in rl.
+//
+// If rac is true, T must be definite and sigma is zero so that the
+// eigenvalues of T are determined to high relative accuracy by the
+// representation. Otherwise sigma is chosen just below the smallest
+// eigenvalue of T so that D is positive.
+func stemrRoot(n int, d, e []float64, rac bool, pivmin float64, rd, rl []float64) (sigma, spdiam float64) {
+	gl := d[0]
+	gu := d[0]
+	for i := 0; i < n; i++ {
+		var r float64
+		if i > 0 {
+			r += math.Abs(e[i-1])
+		}
+		if i < n-1 {
+			r += math.Abs(e[i])
+		}
+		gl = math.Min(gl, d[i]-r)
+		gu = math.Max(gu, d[i]+r)
+	}
+	spdiam = gu - gl
+
+	factor := func(sigma float64) (posdef bool) {
+		rd[0] = d[0] - sigma
+		for i := 0; i < n-1; i++ {
+			if !(rd[i] > 0) {
+				return false
+			}
+			rl[i] = e[i] / rd[i]
+			rd[i+1] = d[i+1] - sigma - rl[i]*e[i]
+		}
+		return rd[n-1] > 0
+	}
+	if rac {
+		if !factor(0) {
+			// T is negative definite.
+			rd[0] = d[0]
+			for i := 0; i < n-1; i++ {
+				rl[i] = e[i] / rd[i]
+				rd[i+1] = d[i+1] - rl[i]*e[i]
+			}
+		}
+		return 0, spdiam
+	}
+
+	// Locate the smallest eigenvalue by bisection and shift just below it.
+	lo := gl
+	hi := gu
+	for iter := 0; iter < 12; iter++ {
+		mid := lo + (hi-lo)/2
+		if stemrSturm(n, d, e, mid, pivmin) == 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	delta := 4 * dlamchP * math.Max(math.Abs(lo), spdiam)
+	sigma = lo
+	for i := 0; i < 64 && !factor(sigma); i++ {
+		sigma -= delta
+		delta *= 4
+	}
+	return sigma, spdiam
+}
+
+// stemrSturm returns the number of eigenvalues less than x of the n×n
+// symmetric tridiagonal matrix with diagonal d and off-diagonal e.
+func stemrSturm(n int, d, e []float64, x, pivmin float64) int {
+	var cnt int
+	q := d[0] - x
+	for i := 0; ; i++ {
+		if math.Abs(q) < pivmin {
+			q = -pivmin
+		}
+		if q < 0 {
+			cnt++
+		}
+		if i == n-1 {
+			return cnt
+		}
+		q = d[i+1] - x - e[i]*e[i]/q
+	}
+}
+
+// stemrNegcount returns the number of eigenvalues less than tau of the n×n
+// matrix L*D*L^T where d is the diagonal of D and l the subdiagonal of the unit
+// lower bidiagonal matrix L.
+func stemrNegcount(n int, d, l []float64, tau, pivmin float64) int {
+	var cnt int
+	s := -tau
+	for i := 0; i < n-1; i++ {
+		dplus := d[i] + s
+		if math.Abs(dplus) < pivmin {
+			dplus = -pivmin
+		}
+		if dplus < 0 {
+			cnt++
+		}
+		t := s / dplus
+		if math.IsNaN(t) {
+			t = 1
+		}
+		s = l[i]*l[i]*d[i]*t - tau
+	}
+	if d[n-1]+s < 0 {
+		cnt++
+	}
+	return cnt
+}
+
+// stemrShift computes the representation L+*D+*L+^T = L*D*L^T - tau*I of the
+// n×n matrix L*D*L^T and stores the diagonal of D+ in dc and the subdiagonal
+// of L+ in lc. It returns the largest magnitude of the elements of D+, or
+// infinity if the factorization broke down.
+func stemrShift(n int, d, l []float64, tau, pivmin float64, dc, lc []float64) (growth float64) {
+	s := -tau
+	for i := 0; i < n-1; i++ {
+		dplus := d[i] + s
+		if math.Abs(dplus) < pivmin {
+			dplus = -pivmin
+		}
+		dc[i] = dplus
+		lc[i] = l[i] * d[i] / dplus
+		t := s / dplus
+		if math.IsNaN(t) {
+			t = 1
+		}
+		s = l[i]*l[i]*d[i]*t - tau
+		growth = math.Max(growth, math.Abs(dplus))
+	}
+	dc[n-1] = d[n-1] + s
+	if math.Abs(dc[n-1]) < pivmin {
+		dc[n-1] = -pivmin
+	}
+	growth = math.Max(growth, math.Abs(dc[n-1]))
+	for _, v := range lc[:n-1] {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return math.Inf(1)
+		}
+	}
+	if math.IsNaN(growth) {
+		return math.Inf(1)
+	}
+	return growth
+}
+
+// mrrr holds the state shared by the nodes of the representation tree built
+// by Dstemr while it computes the eigenvectors of an unreduced block of T.
+type mrrr struct {
+	impl   Implementation
+	n      int
+	d, e   []float64 // Diagonal and off-diagonal of T.
+	lo, hi []float64 // Bounds of the eigenvalues of the current representation.
+	col    []int     // Column of Z holding the eigenvector, or -1 if not wanted.
+	w      []float64
+	z      []float64
+	ldz    int
+	isuppz []int
+	pivmin float64
+	sp     []float64 // Work space for the twisted factorization.
+	dp     []float64
+	dm     []float64
+	rep    []float64 // Representations of the nodes on the current path.
+
+	bs, bn int     // Offset and order of the current block.
+	spdiam float64 // Width of the Gerschgorin interval of the current block.
+}
+
+// level returns the diagonal of D and the subdiagonal of L of the
+// representation L*D*L^T of the current block at the given tree level.
+func (st *mrrr) level(lvl int) (d, l []float64) {
+	off := 2 * lvl * st.n
+	d = st.rep[off+st.bs : off+st.bs+st.bn]
+	l = st.rep[off+st.n+st.bs : off+st.n+st.bs+st.bn-1]
+	return d, l
+}
+
+// close returns whether the eigenvalues i and i+1 of the current
+// representation are too close in the relative sense to be treated
+// independently.
+func (st *mrrr) close(i int) bool {
+	k := st.bs + i
+	gap := st.lo[k+1] - st.hi[k]
+	mag := math.Max(math.Max(math.Abs(st.lo[k]), math.Abs(st.hi[k])), math.Max(math.Abs(st.lo[k+1]), math.Abs(st.hi[k+1])))
+	return gap < mrrrMinRelGap*mag
+}
+
+// refine bisects the bounds of the eigenvalue i of the representation at the
+// given level until their relative distance is at most rtol.
+func (st *mrrr) refine(lvl, i int, rtol float64) {
+	d, l := st.level(lvl)
+	k := st.bs + i
+	lo := st.lo[k]
+	hi := st.hi[k]
+	// Make sure that the bounds enclose the eigenvalue.
+	width := math.Max(hi-lo, 2*dlamchP*math.Max(math.Abs(lo), math.Abs(hi))+st.pivmin)
+	for iter := 0; iter < 64 && stemrNegcount(st.bn, d, l, lo, st.pivmin) > i; iter++ {
+		lo -= width
+		width *= 2
+	}
+	width = math.Max(hi-lo, 2*dlamchP*math.Max(math.Abs(lo), math.Abs(hi))+st.pivmin)
+	for iter := 0; iter < 64 && stemrNegcount(st.bn, d, l, hi, st.pivmin) <= i; iter++ {
+		hi += width
+		width *= 2
+	}
+	for iter := 0; iter < 128; iter++ {
+		if hi-lo <= rtol*math.Max(math.Abs(lo), math.Abs(hi)) || hi-lo <= st.pivmin {
+			break
+		}
+		mid := lo + (hi-lo)/2
+		if mid <= lo || mid >= hi {
+			break
+		}
+		if stemrNegcount(st.bn, d, l, mid, st.pivmin) <= i {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	st.lo[k] = lo
+	st.hi[k] = hi
+}
+
+// node computes the wanted eigenvectors for the eigenvalues f through l of
+// the representation at the given level whose shift relative to T is sigma.
+// lgap and rgap are the distances of the eigenvalues to their neighbors
+// outside the node.
+func (st *mrrr) node(lvl, f, l int, lgap, rgap, sigma float64) {
+	const rtol = 1.5e-8 // About sqrt(eps).
+
+	wf := -1
+	wl := -1
+	for i := f; i <= l; i++ {
+		if st.col[st.bs+i] >= 0 {
+			if wf < 0 {
+				wf = i
+			}
+			wl = i
+		}
+	}
+	if wf < 0 {
+		return
+	}
+
+	// Refine the wanted eigenvalues and those that are close to them.
+	for i := wf; i <= wl; i++ {
+		st.refine(lvl, i, rtol)
+	}
+	for wf > f {
+		st.refine(lvl, wf-1, rtol)
+		if !st.close(wf - 1) {
+			break
+		}
+		wf--
+	}
+	for wl < l {
+		st.refine(lvl, wl+1, rtol)
+		if !st.close(wl) {
+			break
+		}
+		wl++
+	}
+	if wf > f {
+		lgap = st.lo[st.bs+wf] - st.hi[st.bs+wf-1]
+	}
+	if wl < l {
+		rgap = st.lo[st.bs+wl+1] - st.hi[st.bs+wl]
+	}
+
+	// Compute the eigenvectors of the well separated eigenvalues and
+	// descend into the clusters.
+	left := lgap
+	for cs := wf; cs <= wl; {
+		ce := cs
+		for ce < wl && st.close(ce) {
+			ce++
+		}
+		right := rgap
+		if ce < wl {
+			right = st.lo[st.bs+ce+1] - st.hi[st.bs+ce]
+		}
+		if cs == ce {
+			if st.col[st.bs+cs] >= 0 {
+				st.singleton(lvl, cs, math.Min(left, right), sigma)
+			}
+		} else {
+			var wanted bool
+			for _, c := range st.col[st.bs+cs : st.bs+ce+1] {
+				wanted = wanted || c >= 0
+			}
+			if wanted {
+				st.cluster(lvl, cs, ce, left, right, sigma)
+			}
+		}
+		left = right
+		cs = ce + 1
+	}
+}
+
+// cluster computes the wanted eigenvectors for the cluster of eigenvalues cs
+// through ce of the representation at the given level. If a new relatively
+// robust representation can be found for the cluster, the eigenvectors are
+// computed in a child node. Otherwise they are computed by fallback.
+func (st *mrrr) cluster(lvl, cs, ce int, lgap, rgap, sigma float64) {
+	if lvl+1 < mrrrLevels {
+		d, l := st.level(lvl)
+		dc, lc := st.level(lvl + 1)
+		// Locate the ends of the cluster accurately so that the shifts can
+		// be placed close to them.
+		st.refine(lvl, cs, 2*dlamchP)
+		st.refine(lvl, ce, 2*dlamchP)
+		lo := st.lo[st.bs+cs]
+		hi := st.hi[st.bs+ce]
+		width := hi - lo
+		bound := mrrrMaxGrowth * st.spdiam
+		// Try shifts just outside the ends of the cluster first and move
+		// them away from the cluster if there is large element growth in the
+		// new representation.
+		delta := 4*dlamchP*math.Max(math.Abs(lo), math.Abs(hi)) + st.pivmin
+		for try := 0; try < 6; try++ {
+			for _, tau := range [2]float64{lo - delta, hi + delta} {
+				if (tau < lo && delta >= lgap/2) || (tau > hi && delta >= rgap/2) {
+					continue
+				}
+				if stemrShift(st.bn, d, l, tau, st.pivmin, dc, lc) <= bound {
+					for i := cs; i <= ce; i++ {
+						st.lo[st.bs+i] -= tau
+						st.hi[st.bs+i] -= tau
+					}
+					st.node(lvl+1, cs, ce, lgap, rgap, sigma+tau)
+					return
+				}
+			}
+			delta = math.Max(2*delta, width/4)
+		}
+	}
+	st.fallback(lvl, cs, ce, math.Min(lgap, rgap), sigma)
+}
+
+// singleton computes the eigenvector of the isolated eigenvalue i of the
+// representation at the given level by Rayleigh quotient iteration using
+// twisted factorizations. gap is the distance of the eigenvalue to the rest
+// of the spectrum.
+func (st *mrrr) singleton(lvl, i int, gap, sigma float64) {
+	const (
+		maxIter = 10
+		rqtol   = 2 * dlamchP
+	)
+	d, l := st.level(lvl)
+	k := st.bs + i
+	c := st.col[k]
+	zc := st.z[st.bs*st.ldz+c:]
+	tol := 4 * math.Log(float64(st.bn)) * dlamchP
+	gaptol := gap * dlamchP
+
+	lo := st.lo[k]
+	hi := st.hi[k]
+	lambda := lo + (hi-lo)/2
+	var b1, b2 int
+	var ztz float64
+	converged := false
+	for iter := 0; iter < maxIter; iter++ {
+		var negcnt int
+		var gamma float64
+		negcnt, b1, b2, gamma, ztz = st.twisted(d, l, lambda, gaptol, zc)
+		if negcnt <= i {
+			lo = math.Max(lo, lambda)
+		} else {
+			hi = math.Min(hi, lambda)
+		}
+		resid := math.Abs(gamma) / math.Sqrt(ztz)
+		rqcorr := gamma / ztz
+		if resid <= tol*gap || math.Abs(rqcorr) <= rqtol*math.Abs(lambda) {
+			lambda += rqcorr
+			converged = true
+			break
+		}
+		next := lambda + rqcorr
+		if next <= lo || hi <= next {
+			next = lo + (hi-lo)/2
+		}
+		lambda = next
+	}
+	if !converged {
+		// Fall back to bisection to full accuracy.
+		st.lo[k] = lo
+		st.hi[k] = hi
+		st.refine(lvl, i, 2*dlamchP)
+		lambda = st.lo[k] + (st.hi[k]-st.lo[k])/2
+		_, b1, b2, _, ztz = st.twisted(d, l, lambda, gaptol, zc)
+	}
+
+	scl := 1 / math.Sqrt(ztz)
+	for j := b1; j <= b2; j++ {
+		zc[j*st.ldz] *= scl
+	}
+	st.isuppz[2*c] = st.bs + b1
+	st.isuppz[2*c+1] = st.bs + b2
+	st.w[c] = sigma + lambda
+}
+
+// twist computes the twisted factorization
+//  L*D*L^T - lambda*I = N_r*Δ_r*N_r^T
+// with the twist index r where the twist element gamma = Δ_r[r] has the
+// smallest magnitude. N_r has the subdiagonal L+ of the stationary
+// factorization L+*D+*L+^T in its first r columns and the superdiagonal U- of
+// the progressive factorization U-*D-*U-^T in its last n-r-1 columns. The
+// diagonals of D+ and D- are stored in st.dp and st.dm. twist also returns the
+// number of eigenvalues of L*D*L^T less than lambda.
+func (st *mrrr) twist(d, l []float64, lambda float64) (negcnt, r int, gamma float64) {
+	n := st.bn
+	pivmin := st.pivmin
+	sp := st.sp[:n]
+	dp := st.dp[:n]
+	dm := st.dm[:n]
+
+	// Stationary transform. sp holds the auxiliary quantities s_i + lambda.
+	sp[0] = 0
+	s := -lambda
+	for i := 0; i < n-1; i++ {
+		dp[i] = d[i] + s
+		if math.Abs(dp[i]) < pivmin {
+			dp[i] = -pivmin
+		}
+		if dp[i] < 0 {
+			negcnt++
+		}
+		t := s / dp[i]
+		if math.IsNaN(t) {
+			t = 1
+		}
+		sp[i+1] = l[i] * l[i] * d[i] * t
+		s = sp[i+1] - lambda
+	}
+	dp[n-1] = d[n-1] + s
+	if math.Abs(dp[n-1]) < pivmin {
+		dp[n-1] = -pivmin
+	}
+	if dp[n-1] < 0 {
+		negcnt++
+	}
+
+	// Progressive transform and the choice of the twist index.
+	p := d[n-1] - lambda
+	r = n - 1
+	gamma = sp[n-1] + p
+	dm[n-1] = p
+	for i := n - 2; i >= 0; i-- {
+		dm[i+1] = l[i]*l[i]*d[i] + p
+		if math.Abs(dm[i+1]) < pivmin {
+			dm[i+1] = -pivmin
+		}
+		t := p / dm[i+1]
+		if math.IsNaN(t) {
+			t = 1
+		}
+		p = d[i]*t - lambda
+		if g := sp[i] + p; math.Abs(g) < math.Abs(gamma) {
+			gamma = g
+			r = i
+		}
+	}
+	dm[0] = p
+	return negcnt, r, gamma
+}
+
+// twisted computes the solution of
+//  (L*D*L^T - lambda*I) * z = gamma * e_r,  z_r = 1
+// using the twisted factorization computed by twist, and stores it in z.
+// Elements of z that are negligible relative to gaptol are set to zero, so
+// that the non-zero elements of z are b1 through b2. twisted also returns the
+// number of eigenvalues of L*D*L^T less than lambda, gamma and z^T*z.
+func (st *mrrr) twisted(d, l []float64, lambda, gaptol float64, z []float64) (negcnt, b1, b2 int, gamma, ztz float64) {
+	var r int
+	negcnt, r, gamma = st.twist(d, l, lambda)
+	b1, b2, ztz = st.solve(d, l, r, gaptol, z)
+	return negcnt, b1, b2, gamma, ztz
+}
+
+// solve computes the solution of
+//  N_r*Δ_r*N_r^T * z = Δ_r[r] * e_r,  z_r = 1
+// for the twist index r using the factorizations computed by the last call
+// to twist. The truncation of z and the return values are as in twisted.
+func (st *mrrr) solve(d, l []float64, r int, gaptol float64, z []float64) (b1, b2 int, ztz float64) {
+	n := st.bn
+	ldz := st.ldz
+	dp := st.dp[:n]
+	dm := st.dm[:n]
+
+	for j := 0; j < n; j++ {
+		z[j*ldz] = 0
+	}
+	z[r*ldz] = 1
+	ztz = 1
+	b1 = 0
+	for i := r - 1; i >= 0; i-- {
+		ld := l[i] * d[i]
+		zi := -ld / dp[i] * z[(i+1)*ldz]
+		if z[(i+1)*ldz] == 0 && i+2 < n {
+			zi = -(l[i+1] * d[i+1] / ld) * z[(i+2)*ldz]
+		}
+		if (math.Abs(zi)+math.Abs(z[(i+1)*ldz]))*math.Abs(ld) < gaptol {
+			b1 = i + 1
+			break
+		}
+		z[i*ldz] = zi
+		ztz += zi * zi
+	}
+	b2 = n - 1
+	for i := r + 1; i < n; i++ {
+		ld := l[i-1] * d[i-1]
+		zi := -ld / dm[i] * z[(i-1)*ldz]
+		if z[(i-1)*ldz] == 0 && i >= 2 {
+			zi = -(l[i-2] * d[i-2] / ld) * z[(i-2)*ldz]
+		}
+		if (math.Abs(zi)+math.Abs(z[(i-1)*ldz]))*math.Abs(ld) < gaptol {
+			b2 = i - 1
+			break
+		}
+		z[i*ldz] = zi
+		ztz += zi * zi
+	}
+	return b1, b2, ztz
+}
+
+// fallback computes the wanted eigenvectors for the cluster of eigenvalues cs
+// through ce of the representation at the given level when no new
+// representation for the cluster could be found. gap is the distance of the
+// cluster to the rest of the spectrum.
+//
+// Each eigenvector is computed from a twisted factorization at its eigenvalue
+// and orthogonalized against those of the cluster computed before. If the
+// eigenvalues are too close for the result to be accurate, other twist
+// indices are tried, chosen so that the new vector captures a direction of
+// the invariant subspace of the cluster not yet spanned by the computed
+// eigenvectors. Eigenvalues that agree to working precision are separated by
+// moving the shift of the twisted factorization slightly away from them.
+func (st *mrrr) fallback(lvl, cs, ce int, gap, sigma float64) {
+	const maxTry = 8
+	bi := blas64.Implementation()
+	d, l := st.level(lvl)
+	n := st.bn
+	ldz := st.ldz
+	zb := st.z[st.bs*ldz:]
+	tol := 4 * math.Log(float64(n)) * dlamchP * gap
+	for i := cs; i <= ce; i++ {
+		c := st.col[st.bs+i]
+		if c < 0 {
+			continue
+		}
+		st.refine(lvl, i, 2*dlamchP)
+		lambda := st.lo[st.bs+i] + (st.hi[st.bs+i]-st.lo[st.bs+i])/2
+		zc := zb[c:]
+		h := dlamchP*math.Abs(lambda) + st.pivmin
+		// Accept the vector if its residual is small relative to the gap or
+		// close to the attainable accuracy.
+		thresh := math.Max(tol, 4*h)
+		best := math.Inf(1)
+		var tbest float64
+		var rbest int
+		var t float64
+		var r int
+		for _, t = range [...]float64{0, 2 * h, 16 * h, 256 * h} {
+			var tried [maxTry]int
+			var gamma float64
+			_, r, gamma = st.twist(d, l, lambda+t)
+			_, _, ztz := st.solve(d, l, r, 0, zc)
+			// The diagonal element j of the spectral projector of the
+			// cluster is approximately scale/|γ_j|.
+			scale := math.Abs(gamma) / ztz
+			for try := 0; ; try++ {
+				// Estimate the residual of the orthogonalized vector for
+				// the eigenvalue. The residuals of the twisted vector and
+				// of the vectors it is orthogonalized against, which are
+				// not smaller than eps*|lambda|, are amplified by the
+				// cancellation in the orthogonalization.
+				bi.Dscal(n, 1/math.Sqrt(ztz), zc, ldz)
+				nrm := st.orthogonalize(cs, i, zc)
+				res := t + (math.Abs(st.gamma(d, l, r))/math.Sqrt(ztz)+h)/nrm
+				if res < best {
+					best = res
+					tbest = t
+					rbest = r
+				}
+				tried[try] = r
+				if res <= thresh || try == maxTry-1 {
+					break
+				}
+				if scale == 0 {
+					// lambda is an eigenvalue in floating-point arithmetic,
+					// so estimate the scale from the next smallest twist
+					// element.
+					j := -1
+					var gmin float64
+					for k := 0; k < n; k++ {
+						if g := math.Abs(st.gamma(d, l, k)); g != 0 && (j < 0 || g < gmin) {
+							j = k
+							gmin = g
+						}
+					}
+					if j >= 0 {
+						_, _, ztz = st.solve(d, l, j, 0, zc)
+						scale = gmin / ztz
+					}
+				}
+				r = st.pivot(d, l, cs, i, scale, tried[:try+1])
+				_, _, ztz = st.solve(d, l, r, 0, zc)
+			}
+			if best <= thresh {
+				break
+			}
+		}
+		if tbest != t || rbest != r {
+			st.twist(d, l, lambda+tbest)
+			_, _, ztz := st.solve(d, l, rbest, 0, zc)
+			bi.Dscal(n, 1/math.Sqrt(ztz), zc, ldz)
+			st.orthogonalize(cs, i, zc)
+		}
+		bi.Dscal(n, 1/bi.Dnrm2(n, zc, ldz), zc, ldz)
+		st.isuppz[2*c] = st.bs
+		st.isuppz[2*c+1] = st.bs + n - 1
+		st.w[c] = sigma + lambda
+	}
+}
+
+// pivot returns the twist index not in tried that maximizes the estimate
+// scale/|γ_j| of the diagonal element j of the spectral projector of the
+// cluster less the part of it captured by the eigenvectors of the wanted
+// eigenvalues cs through i-1. The twist elements γ_j are those of the
+// factorizations computed by the last call to twist.
+func (st *mrrr) pivot(d, l []float64, cs, i int, scale float64, tried []int) int {
+	n := st.bn
+	ldz := st.ldz
+	zb := st.z[st.bs*ldz:]
+	r := -1
+	var smax float64
+next:
+	for j := 0; j < n; j++ {
+		for _, t := range tried {
+			if j == t {
+				continue next
+			}
+		}
+		score := scale / math.Max(math.Abs(st.gamma(d, l, j)), st.pivmin)
+		for k := cs; k < i; k++ {
+			if ck := st.col[st.bs+k]; ck >= 0 {
+				score -= zb[j*ldz+ck] * zb[j*ldz+ck]
+			}
+		}
+		if r < 0 || score > smax {
+			r = j
+			smax = score
+		}
+	}
+	return r
+}
+
+// gamma returns the twist element γ_j of the twisted factorization with the
+// twist index j computed by the last call to twist.
+func (st *mrrr) gamma(d, l []float64, j int) float64 {
+	p := st.dm[j]
+	if j > 0 {
+		p -= l[j-1] * l[j-1] * d[j-1]
+	}
+	return st.sp[j] + p
+}
+
+// orthogonalize orthogonalizes z against the eigenvectors of the wanted
+// eigenvalues cs through i-1 by the modified Gram-Schmidt process and returns
+// the norm of the result.
+func (st *mrrr) orthogonalize(cs, i int, z []float64) float64 {
+	bi := blas64.Implementation()
+	n := st.bn
+	ldz := st.ldz
+	zb := st.z[st.bs*ldz:]
+	for j := cs; j < i; j++ {
+		if cj := st.col[st.bs+j]; cj >= 0 {
+			dot := bi.Ddot(n, zb[cj:], ldz, z, ldz)
+			bi.Daxpy(n, -dot, zb[cj:], ldz, z, ldz)
+		}
+	}
+	return bi.Dnrm2(n, z, ldz)
+}
@@ -0,0 +1,209 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dsyevr computes selected eigenvalues and, optionally, eigenvectors of a real
+// symmetric matrix A. A is first reduced to tridiagonal form and the
+// eigenpairs of the tridiagonal matrix are then computed by Dstemr using the
+// algorithm of Multiple Relatively Robust Representations. Dsyevr is usually
+// the fastest of the symmetric eigensolvers, in particular when only a subset
+// of the eigenpairs is wanted.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. On exit, the contents of a are destroyed.
+//
+// rng specifies which eigenvalues are computed. If rng == lapack.EVRangeAll, all
+// eigenvalues are computed. If rng == lapack.EVRangeValue, the eigenvalues in
+// the half-open interval (vl, vu] are computed and vl must be less than vu. If
+// rng == lapack.EVRangeIndex, the eigenvalues with zero-based indices il
+// through iu, inclusive, are computed, and il and iu must satisfy
+//  0 <= il <= iu < n  if n > 0,
+//  il = 0, iu = -1    if n == 0.
+// vl and vu are not referenced unless rng == lapack.EVRangeValue, and il and
+// iu are not referenced unless rng == lapack.EVRangeIndex.
+//
+// The number of eigenvalues found is returned in m and the eigenvalues are
+// stored in ascending order in w[:m]. w must have length at least n.
+//
+// If jobz == lapack.EVCompute, the orthonormal eigenvectors corresponding to
+// w[:m] are stored in the first m columns of the n×nz matrix Z, where nz is
+// iu-il+1 if rng == lapack.EVRangeIndex and n otherwise, and ldz must be at
+// least max(1,nz). If jobz == lapack.EVNone, z is not referenced.
+//
+// work must have length at least max(1, lwork) and iwork must have length at
+// least max(1, liwork). lwork must be at least max(1, 10*n) if jobz ==
+// lapack.EVNone and max(1, 22*n) if jobz == lapack.EVCompute, and liwork must
+// be at least max(1, 4*n). If lwork == -1 or liwork == -1, instead of computing
+// the eigenvalues, the optimal lengths of work and iwork are stored into
+// work[0] and iwork[0]. Dsyevr will panic if the workspace is insufficient.
+//
+// Dsyevr returns whether the computation was successful.
+func (impl Implementation) Dsyevr(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, w, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	wantz := jobz == lapack.EVCompute
+	nz := n
+	if rng == lapack.EVRangeIndex {
+		nz = iu - il + 1
+	}
+	switch {
+	case jobz != lapack.EVNone && !wantz:
+		panic(badEVJob)
+	case rng != lapack.EVRangeAll && rng != lapack.EVRangeValue && rng != lapack.EVRangeIndex:
+		panic(badEVRange)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case rng == lapack.EVRangeValue && vl >= vu:
+		panic(badVlVu)
+	case rng == lapack.EVRangeIndex && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case rng == lapack.EVRangeIndex && (iu < min(n-1, il) || iu >= n):
+		panic(badIu)
+	case ldz < 1, wantz && ldz < nz:
+		panic(badLdZ)
+	}
+
+	// Compute the workspace requirements. The first 3*n elements of work
+	// hold the tridiagonal matrix and the scalar factors of the elementary
+	// reflectors, and the remaining elements are used by Dsytrd, Dstemr and
+	// Dorgtr. After Dorgtr, all of work is used for the back-transformation
+	// of the eigenvectors.
+	lwmin := max(1, 10*n)
+	if wantz {
+		lwmin = max(1, 22*n)
+	}
+	liwmin := max(1, 4*n)
+	lwopt := lwmin
+	if n > 0 {
+		impl.Dsytrd(uplo, n, a, lda, nil, nil, nil, work, -1)
+		lwopt = max(lwopt, 3*n+int(work[0]))
+		if wantz {
+			impl.Dorgtr(uplo, n, a, lda, nil, work, -1)
+			lwopt = max(lwopt, 3*n+int(work[0]))
+			lwopt = max(lwopt, n*nz)
+		}
+	}
+	switch {
+	case lwork < lwmin && lwork != -1:
+		panic(badLWork)
+	case liwork < liwmin && liwork != -1:
+		panic(badLIWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case len(iwork) < max(1, liwork):
+		panic(shortIWork)
+	}
+
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lwopt)
+		iwork[0] = liwmin
+		return 0, true
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, true
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(w) < n:
+		panic(shortW)
+	case wantz && len(z) < (n-1)*ldz+nz:
+		panic(shortZ)
+	}
+
+	if n == 1 {
+		if rng == lapack.EVRangeValue && (a[0] <= vl || vu < a[0]) {
+			return 0, true
+		}
+		w[0] = a[0]
+		if wantz {
+			z[0] = 1
+		}
+		return 1, true
+	}
+
+	const (
+		safmin = dlamchS
+		eps    = dlamchP
+	)
+	bi := blas64.Implementation()
+
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+
+	// Scale matrix to allowable range, if necessary.
+	anrm := impl.Dlansy(lapack.MaxAbs, uplo, n, a, lda, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		kind := lapack.LowerTri
+		if uplo == blas.Upper {
+			kind = lapack.UpperTri
+		}
+		impl.Dlascl(kind, 0, 0, 1, sigma, n, n, a, lda)
+		if rng == lapack.EVRangeValue {
+			vl *= sigma
+			vu *= sigma
+		}
+	}
+
+	// Reduce A to tridiagonal form T = Q^T * A * Q.
+	indtau := 0
+	indd := indtau + n
+	inde := indd + n
+	indwrk := inde + n
+	llwork := lwork - indwrk
+	impl.Dsytrd(uplo, n, a, lda, work[indd:inde], work[inde:indwrk], work[indtau:indd], work[indwrk:], llwork)
+
+	// Compute the eigenpairs of T.
+	isuppz := iwork[:2*n]
+	m, _, ok = impl.Dstemr(jobz, rng, n, work[indd:inde], work[inde:indwrk], vl, vu, il, iu, w, z, ldz, isuppz, true, work[indwrk:], llwork, iwork[2*n:], liwork-2*n)
+	if !ok {
+		return m, false
+	}
+
+	// Apply the orthogonal matrix Q to the eigenvectors of T. Q is formed
+	// explicitly in a, and Z is overwritten by Q*Z in blocks of columns
+	// that fit in work.
+	if wantz && m > 0 {
+		impl.Dorgtr(uplo, n, a, lda, work[indtau:indd], work[indwrk:], llwork)
+		nb := min(m, lwork/n)
+		for j := 0; j < m; j += nb {
+			jb := min(nb, m-j)
+			impl.Dlacpy(blas.All, n, jb, z[j:], ldz, work, jb)
+			bi.Dgemm(blas.NoTrans, blas.NoTrans, n, jb, n, 1, a, lda, work, jb, 0, z[j:], ldz)
+		}
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi.Dscal(m, 1/sigma, w, 1)
+	}
+	work[0] = float64(lwopt)
+	iwork[0] = liwmin
+	return m, true
+}
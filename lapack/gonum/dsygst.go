@@ -0,0 +1,160 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dsygst reduces a symmetric-definite generalized eigenproblem to standard
+// form.
+//
+// If itype == lapack.AxLBx, the problem is
+//  A*x = λ*B*x,
+// and A is overwritten by
+//  inv(U^T)*A*inv(U) if uplo == blas.Upper,
+//  inv(L)*A*inv(L^T) if uplo == blas.Lower.
+//
+// If itype == lapack.ABxLx or lapack.BAxLx, the problem is
+//  A*B*x = λ*x or B*A*x = λ*x,
+// and A is overwritten by
+//  U*A*U^T if uplo == blas.Upper,
+//  L^T*A*L if uplo == blas.Lower.
+//
+// On entry, b must contain the Cholesky factor of B as returned by Dpotrf
+// with the same value of uplo, that is, B = U^T*U or B = L*L^T. b is not
+// modified.
+//
+// On entry, the triangle of a specified by uplo contains the triangle of the
+// n×n symmetric matrix A. On return, it is overwritten by the corresponding
+// triangle of the transformed matrix. The other triangle is not referenced.
+func (impl Implementation) Dsygst(itype lapack.GenEVType, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int) {
+	switch {
+	case itype != lapack.AxLBx && itype != lapack.ABxLx && itype != lapack.BAxLx:
+		panic(badGenEVType)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldb < max(1, n):
+		panic(badLdB)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(b) < (n-1)*ldb+n:
+		panic(shortB)
+	}
+
+	nb := impl.Ilaenv(1, "DSYGST", string(uplo), n, -1, -1, -1)
+	if nb <= 1 || n <= nb {
+		impl.Dsygs2(itype, uplo, n, a, lda, b, ldb)
+		return
+	}
+
+	bi := blas64.Implementation()
+	if itype == lapack.AxLBx {
+		if uplo == blas.Upper {
+			// Compute inv(U^T)*A*inv(U).
+			for k := 0; k < n; k += nb {
+				kb := min(n-k, nb)
+				// Update the upper triangle of A[k:n,k:n].
+				impl.Dsygs2(itype, uplo, kb, a[k*lda+k:], lda, b[k*ldb+k:], ldb)
+				if k+kb < n {
+					m := n - k - kb
+					bi.Dtrsm(blas.Left, uplo, blas.Trans, blas.NonUnit, kb, m,
+						1, b[k*ldb+k:], ldb, a[k*lda+k+kb:], lda)
+					bi.Dsymm(blas.Left, uplo, kb, m,
+						-0.5, a[k*lda+k:], lda, b[k*ldb+k+kb:], ldb,
+						1, a[k*lda+k+kb:], lda)
+					bi.Dsyr2k(uplo, blas.Trans, m, kb,
+						-1, a[k*lda+k+kb:], lda, b[k*ldb+k+kb:], ldb,
+						1, a[(k+kb)*lda+k+kb:], lda)
+					bi.Dsymm(blas.Left, uplo, kb, m,
+						-0.5, a[k*lda+k:], lda, b[k*ldb+k+kb:], ldb,
+						1, a[k*lda+k+kb:], lda)
+					bi.Dtrsm(blas.Right, uplo, blas.NoTrans, blas.NonUnit, kb, m,
+						1, b[(k+kb)*ldb+k+kb:], ldb, a[k*lda+k+kb:], lda)
+				}
+			}
+			return
+		}
+		// Compute inv(L)*A*inv(L^T).
+		for k := 0; k < n; k += nb {
+			kb := min(n-k, nb)
+			// Update the lower triangle of A[k:n,k:n].
+			impl.Dsygs2(itype, uplo, kb, a[k*lda+k:], lda, b[k*ldb+k:], ldb)
+			if k+kb < n {
+				m := n - k - kb
+				bi.Dtrsm(blas.Right, uplo, blas.Trans, blas.NonUnit, m, kb,
+					1, b[k*ldb+k:], ldb, a[(k+kb)*lda+k:], lda)
+				bi.Dsymm(blas.Right, uplo, m, kb,
+					-0.5, a[k*lda+k:], lda, b[(k+kb)*ldb+k:], ldb,
+					1, a[(k+kb)*lda+k:], lda)
+				bi.Dsyr2k(uplo, blas.NoTrans, m, kb,
+					-1, a[(k+kb)*lda+k:], lda, b[(k+kb)*ldb+k:], ldb,
+					1, a[(k+kb)*lda+k+kb:], lda)
+				bi.Dsymm(blas.Right, uplo, m, kb,
+					-0.5, a[k*lda+k:], lda, b[(k+kb)*ldb+k:], ldb,
+					1, a[(k+kb)*lda+k:], lda)
+				bi.Dtrsm(blas.Left, uplo, blas.NoTrans, blas.NonUnit, m, kb,
+					1, b[(k+kb)*ldb+k+kb:], ldb, a[(k+kb)*lda+k:], lda)
+			}
+		}
+		return
+	}
+
+	if uplo == blas.Upper {
+		// Compute U*A*U^T.
+		for k := 0; k < n; k += nb {
+			kb := min(n-k, nb)
+			// Update the upper triangle of A[0:k+kb,0:k+kb].
+			bi.Dtrmm(blas.Left, uplo, blas.NoTrans, blas.NonUnit, k, kb,
+				1, b, ldb, a[k:], lda)
+			bi.Dsymm(blas.Right, uplo, k, kb,
+				0.5, a[k*lda+k:], lda, b[k:], ldb,
+				1, a[k:], lda)
+			bi.Dsyr2k(uplo, blas.NoTrans, k, kb,
+				1, a[k:], lda, b[k:], ldb,
+				1, a, lda)
+			bi.Dsymm(blas.Right, uplo, k, kb,
+				0.5, a[k*lda+k:], lda, b[k:], ldb,
+				1, a[k:], lda)
+			bi.Dtrmm(blas.Right, uplo, blas.Trans, blas.NonUnit, k, kb,
+				1, b[k*ldb+k:], ldb, a[k:], lda)
+			impl.Dsygs2(itype, uplo, kb, a[k*lda+k:], lda, b[k*ldb+k:], ldb)
+		}
+		return
+	}
+	// Compute L^T*A*L.
+	for k := 0; k < n; k += nb {
+		kb := min(n-k, nb)
+		// Update the lower triangle of A[0:k+kb,0:k+kb].
+		bi.Dtrmm(blas.Right, uplo, blas.NoTrans, blas.NonUnit, kb, k,
+			1, b, ldb, a[k*lda:], lda)
+		bi.Dsymm(blas.Left, uplo, kb, k,
+			0.5, a[k*lda+k:], lda, b[k*ldb:], ldb,
+			1, a[k*lda:], lda)
+		bi.Dsyr2k(uplo, blas.Trans, k, kb,
+			1, a[k*lda:], lda, b[k*ldb:], ldb,
+			1, a, lda)
+		bi.Dsymm(blas.Left, uplo, kb, k,
+			0.5, a[k*lda+k:], lda, b[k*ldb:], ldb,
+			1, a[k*lda:], lda)
+		bi.Dtrmm(blas.Left, uplo, blas.Trans, blas.NonUnit, kb, k,
+			1, b[k*ldb+k:], ldb, a[k*lda:], lda)
+		impl.Dsygs2(itype, uplo, kb, a[k*lda+k:], lda, b[k*ldb+k:], ldb)
+	}
+}
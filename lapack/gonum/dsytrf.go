@@ -0,0 +1,180 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dsytrf computes the factorization of a real symmetric matrix A using the
+// Bunch-Kaufman diagonal pivoting method. The form of the factorization is
+//  A = U*D*U^T  if uplo == blas.Upper, or
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower) triangular
+// matrices, and D is symmetric and block diagonal with 1×1 and 2×2 diagonal
+// blocks.
+//
+// On entry, a contains the symmetric matrix A in the triangle specified by
+// uplo. On return, a contains the block diagonal matrix D and the multipliers
+// used to obtain the factor U or L.
+//
+// If uplo == blas.Upper, then
+//  U = P[n-1]*U[n-1]* ... *P[k]*U[k]* ...,
+// where k decreases from n-1 to 0 in steps of 1 or 2, D is block diagonal
+// with 1×1 and 2×2 diagonal blocks D[k], P[k] is a permutation matrix as
+// defined by ipiv[k], and U[k] is a unit upper triangular matrix, such that
+// if the diagonal block D[k] is of order s (s = 1 or 2), then
+//  U[k] = [ I  v  0 ]   k-s+1
+//         [ 0  I  0 ]   s
+//         [ 0  0  I ]   n-k-1
+//            k-s+1 s n-k-1
+// If s == 1, D[k] overwrites A[k,k], and v overwrites A[0:k,k].
+// If s == 2, the upper triangle of D[k] overwrites A[k-1,k-1], A[k-1,k], and
+// A[k,k], and v overwrites A[0:k-1,k-1:k+1].
+//
+// If uplo == blas.Lower, then
+//  L = P[0]*L[0]* ... *P[k]*L[k]* ...,
+// where k increases from 0 to n-1 in steps of 1 or 2, D is block diagonal
+// with 1×1 and 2×2 diagonal blocks D[k], P[k] is a permutation matrix as
+// defined by ipiv[k], and L[k] is a unit lower triangular matrix, such that
+// if the diagonal block D[k] is of order s (s = 1 or 2), then
+//  L[k] = [ I  0  0 ]   k
+//         [ 0  I  0 ]   s
+//         [ 0  v  I ]   n-k-s
+//            k  s n-k-s
+// If s == 1, D[k] overwrites A[k,k], and v overwrites A[k+1:n,k].
+// If s == 2, the lower triangle of D[k] overwrites A[k,k], A[k+1,k], and
+// A[k+1,k+1], and v overwrites A[k+2:n,k:k+2].
+//
+// ipiv contains details of the interchanges and the block structure of D.
+// If ipiv[k] >= 0, then rows and columns k and ipiv[k] were interchanged and
+// D[k,k] is a 1×1 diagonal block. If uplo == blas.Upper and
+// ipiv[k] = ipiv[k-1] < 0, then rows and columns k-1 and -ipiv[k]-1 were
+// interchanged and D[k-1:k+1,k-1:k+1] is a 2×2 diagonal block. If
+// uplo == blas.Lower and ipiv[k] = ipiv[k+1] < 0, then rows and columns k+1
+// and -ipiv[k]-1 were interchanged and D[k:k+2,k:k+2] is a 2×2 diagonal
+// block. ipiv must have length n, otherwise Dsytrf will panic.
+//
+// work must have length at least max(1,lwork), and lwork must be at least 1,
+// otherwise Dsytrf will panic. For optimal performance lwork must be at least
+// n*nb, where nb is the optimal blocksize. On return, work[0] will contain the
+// optimal value of lwork.
+//
+// If lwork == -1, instead of performing Dsytrf, only the optimal value of lwork
+// will be stored in work[0].
+//
+// Dsytrf returns whether D is nonsingular. If ok is false, D has an exactly
+// zero diagonal element and the factorization has been completed, but D is
+// exactly singular, and division by zero will occur if it is used to solve a
+// system of equations.
+func (impl Implementation) Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case lwork < 1 && lwork != -1:
+		panic(badLWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return true
+	}
+
+	nb := impl.Ilaenv(1, "DSYTRF", string(uplo), n, -1, -1, -1)
+	if lwork == -1 {
+		work[0] = float64(n * nb)
+		return true
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(ipiv) != n:
+		panic(badLenIpiv)
+	}
+
+	nbmin := 2
+	if 1 < nb && nb < n {
+		if lwork < n*nb {
+			nb = max(lwork/n, 1)
+			nbmin = max(2, impl.Ilaenv(2, "DSYTRF", string(uplo), n, -1, -1, -1))
+		}
+	}
+	if nb < nbmin {
+		nb = n
+	}
+	// The workspace for Dlasyf is an n×nb matrix.
+	ldw := nb
+
+	ok = true
+	if uplo == blas.Upper {
+		// Factorize A as U*D*U^T using the upper triangle of A.
+		//
+		// k is the main loop index, decreasing from n-1 in steps of
+		// kb, where kb is the number of columns factorized by Dlasyf.
+		// kb is either nb or nb-1, or k+1 for the last block.
+		for k := n - 1; k >= 0; {
+			var (
+				kb      int
+				blockOk bool
+			)
+			if k+1 > nb {
+				// Factorize columns k-kb+1:k+1 of A and use blocked
+				// code to update columns 0:k-kb+1.
+				kb, blockOk = impl.Dlasyf(uplo, k+1, nb, a, lda, ipiv[:k+1], work, ldw)
+			} else {
+				// Use unblocked code to factorize columns 0:k+1 of A.
+				blockOk = impl.Dsytf2(uplo, k+1, a, lda, ipiv[:k+1])
+				kb = k + 1
+			}
+			if !blockOk {
+				ok = false
+			}
+			k -= kb
+		}
+		work[0] = float64(n * nb)
+		return ok
+	}
+
+	// Factorize A as L*D*L^T using the lower triangle of A.
+	//
+	// k is the main loop index, increasing from 0 in steps of kb,
+	// where kb is the number of columns factorized by Dlasyf.
+	// kb is either nb or nb-1, or n-k for the last block.
+	for k := 0; k < n; {
+		var (
+			kb      int
+			blockOk bool
+		)
+		if k < n-nb {
+			// Factorize columns k:k+kb of A and use blocked code to
+			// update columns k+kb:n.
+			kb, blockOk = impl.Dlasyf(uplo, n-k, nb, a[k*lda+k:], lda, ipiv[k:], work, ldw)
+		} else {
+			// Use unblocked code to factorize columns k:n of A.
+			blockOk = impl.Dsytf2(uplo, n-k, a[k*lda+k:], lda, ipiv[k:])
+			kb = n - k
+		}
+		if !blockOk {
+			ok = false
+		}
+		// Adjust ipiv.
+		for j := k; j < k+kb; j++ {
+			if ipiv[j] >= 0 {
+				ipiv[j] += k
+			} else {
+				ipiv[j] -= k
+			}
+		}
+		k += kb
+	}
+	work[0] = float64(n * nb)
+	return ok
+}
@@ -0,0 +1,218 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dsytrs solves a system of linear equations A*X = B with a real symmetric
+// matrix A using the factorization
+//  A = U*D*U^T  if uplo == blas.Upper, or
+//  A = L*D*L^T  if uplo == blas.Lower,
+// computed by Dsytrf.
+//
+// a and ipiv must contain the block diagonal matrix D and the multipliers and
+// the pivot indices as returned by Dsytrf. b contains the right-hand side
+// matrix B on entry, and is overwritten by the solution matrix X on return.
+// ipiv must have length n, otherwise Dsytrs will panic.
+func (Implementation) Dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic(nrhsLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldb < max(1, nrhs):
+		panic(badLdB)
+	}
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(ipiv) != n:
+		panic(badLenIpiv)
+	case len(b) < (n-1)*ldb+nrhs:
+		panic(shortB)
+	}
+
+	bi := blas64.Implementation()
+
+	if uplo == blas.Upper {
+		// Solve A*X = B, where A = U*D*U^T.
+		//
+		// First solve U*D*X = B, overwriting B with X.
+		// k is the main loop index, decreasing from n-1 in steps of
+		// 1 or 2.
+		for k := n - 1; k >= 0; {
+			if ipiv[k] >= 0 {
+				// 1×1 diagonal block.
+				//
+				// Interchange rows k and ipiv[k].
+				kp := ipiv[k]
+				if kp != k {
+					bi.Dswap(nrhs, b[k*ldb:], 1, b[kp*ldb:], 1)
+				}
+				// Multiply by inv(U[k]), where U[k] is the
+				// transformation stored in column k of A.
+				bi.Dger(k, nrhs, -1, a[k:], lda, b[k*ldb:], 1, b, ldb)
+				// Multiply by the inverse of the diagonal block.
+				bi.Dscal(nrhs, 1/a[k*lda+k], b[k*ldb:], 1)
+				k--
+				continue
+			}
+			// 2×2 diagonal block.
+			//
+			// Interchange rows k-1 and -ipiv[k]-1.
+			kp := -ipiv[k] - 1
+			if kp != k-1 {
+				bi.Dswap(nrhs, b[(k-1)*ldb:], 1, b[kp*ldb:], 1)
+			}
+			// Multiply by inv(U[k]), where U[k] is the transformation
+			// stored in columns k-1 and k of A.
+			bi.Dger(k-1, nrhs, -1, a[k:], lda, b[k*ldb:], 1, b, ldb)
+			bi.Dger(k-1, nrhs, -1, a[k-1:], lda, b[(k-1)*ldb:], 1, b, ldb)
+			// Multiply by the inverse of the diagonal block.
+			akm1k := a[(k-1)*lda+k]
+			akm1 := a[(k-1)*lda+k-1] / akm1k
+			ak := a[k*lda+k] / akm1k
+			denom := akm1*ak - 1
+			for j := 0; j < nrhs; j++ {
+				bkm1 := b[(k-1)*ldb+j] / akm1k
+				bk := b[k*ldb+j] / akm1k
+				b[(k-1)*ldb+j] = (ak*bkm1 - bk) / denom
+				b[k*ldb+j] = (akm1*bk - bkm1) / denom
+			}
+			k -= 2
+		}
+
+		// Next solve U^T*X = B, overwriting B with X.
+		// k is the main loop index, increasing from 0 in steps of
+		// 1 or 2.
+		for k := 0; k < n; {
+			if ipiv[k] >= 0 {
+				// 1×1 diagonal block.
+				//
+				// Multiply by inv(U^T[k]), where U[k] is the
+				// transformation stored in column k of A.
+				bi.Dgemv(blas.Trans, k, nrhs, -1, b, ldb, a[k:], lda, 1, b[k*ldb:], 1)
+				// Interchange rows k and ipiv[k].
+				kp := ipiv[k]
+				if kp != k {
+					bi.Dswap(nrhs, b[k*ldb:], 1, b[kp*ldb:], 1)
+				}
+				k++
+				continue
+			}
+			// 2×2 diagonal block.
+			//
+			// Multiply by inv(U^T[k+1]), where U[k+1] is the
+			// transformation stored in columns k and k+1 of A.
+			bi.Dgemv(blas.Trans, k, nrhs, -1, b, ldb, a[k:], lda, 1, b[k*ldb:], 1)
+			bi.Dgemv(blas.Trans, k, nrhs, -1, b, ldb, a[k+1:], lda, 1, b[(k+1)*ldb:], 1)
+			// Interchange rows k and -ipiv[k]-1.
+			kp := -ipiv[k] - 1
+			if kp != k {
+				bi.Dswap(nrhs, b[k*ldb:], 1, b[kp*ldb:], 1)
+			}
+			k += 2
+		}
+		return
+	}
+
+	// Solve A*X = B, where A = L*D*L^T.
+	//
+	// First solve L*D*X = B, overwriting B with X.
+	// k is the main loop index, increasing from 0 in steps of 1 or 2.
+	for k := 0; k < n; {
+		if ipiv[k] >= 0 {
+			// 1×1 diagonal block.
+			//
+			// Interchange rows k and ipiv[k].
+			kp := ipiv[k]
+			if kp != k {
+				bi.Dswap(nrhs, b[k*ldb:], 1, b[kp*ldb:], 1)
+			}
+			// Multiply by inv(L[k]), where L[k] is the
+			// transformation stored in column k of A.
+			if k < n-1 {
+				bi.Dger(n-k-1, nrhs, -1, a[(k+1)*lda+k:], lda, b[k*ldb:], 1, b[(k+1)*ldb:], ldb)
+			}
+			// Multiply by the inverse of the diagonal block.
+			bi.Dscal(nrhs, 1/a[k*lda+k], b[k*ldb:], 1)
+			k++
+			continue
+		}
+		// 2×2 diagonal block.
+		//
+		// Interchange rows k+1 and -ipiv[k]-1.
+		kp := -ipiv[k] - 1
+		if kp != k+1 {
+			bi.Dswap(nrhs, b[(k+1)*ldb:], 1, b[kp*ldb:], 1)
+		}
+		// Multiply by inv(L[k]), where L[k] is the transformation
+		// stored in columns k and k+1 of A.
+		if k < n-2 {
+			bi.Dger(n-k-2, nrhs, -1, a[(k+2)*lda+k:], lda, b[k*ldb:], 1, b[(k+2)*ldb:], ldb)
+			bi.Dger(n-k-2, nrhs, -1, a[(k+2)*lda+k+1:], lda, b[(k+1)*ldb:], 1, b[(k+2)*ldb:], ldb)
+		}
+		// Multiply by the inverse of the diagonal block.
+		akm1k := a[(k+1)*lda+k]
+		akm1 := a[k*lda+k] / akm1k
+		ak := a[(k+1)*lda+k+1] / akm1k
+		denom := akm1*ak - 1
+		for j := 0; j < nrhs; j++ {
+			bkm1 := b[k*ldb+j] / akm1k
+			bk := b[(k+1)*ldb+j] / akm1k
+			b[k*ldb+j] = (ak*bkm1 - bk) / denom
+			b[(k+1)*ldb+j] = (akm1*bk - bkm1) / denom
+		}
+		k += 2
+	}
+
+	// Next solve L^T*X = B, overwriting B with X.
+	// k is the main loop index, decreasing from n-1 in steps of 1 or 2.
+	for k := n - 1; k >= 0; {
+		if ipiv[k] >= 0 {
+			// 1×1 diagonal block.
+			//
+			// Multiply by inv(L^T[k]), where L[k] is the
+			// transformation stored in column k of A.
+			if k < n-1 {
+				bi.Dgemv(blas.Trans, n-k-1, nrhs, -1, b[(k+1)*ldb:], ldb, a[(k+1)*lda+k:], lda, 1, b[k*ldb:], 1)
+			}
+			// Interchange rows k and ipiv[k].
+			kp := ipiv[k]
+			if kp != k {
+				bi.Dswap(nrhs, b[k*ldb:], 1, b[kp*ldb:], 1)
+			}
+			k--
+			continue
+		}
+		// 2×2 diagonal block.
+		//
+		// Multiply by inv(L^T[k-1]), where L[k-1] is the transformation
+		// stored in columns k-1 and k of A.
+		if k < n-1 {
+			bi.Dgemv(blas.Trans, n-k-1, nrhs, -1, b[(k+1)*ldb:], ldb, a[(k+1)*lda+k:], lda, 1, b[k*ldb:], 1)
+			bi.Dgemv(blas.Trans, n-k-1, nrhs, -1, b[(k+1)*ldb:], ldb, a[(k+1)*lda+k-1:], lda, 1, b[(k-1)*ldb:], 1)
+		}
+		// Interchange rows k and -ipiv[k]-1.
+		kp := -ipiv[k] - 1
+		if kp != k {
+			bi.Dswap(nrhs, b[k*ldb:], 1, b[kp*ldb:], 1)
+		}
+		k -= 2
+	}
+}
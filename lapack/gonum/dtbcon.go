@@ -0,0 +1,101 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dtbcon estimates the reciprocal of the condition number of an n×n
+// triangular band matrix A with kd super-diagonals if uplo == blas.Upper and
+// kd sub-diagonals otherwise. The condition number computed may be based on
+// the 1-norm or the ∞-norm. See the documentation for Dpbtrf for a description
+// of the band storage format.
+//
+// work is a temporary data slice of length at least 2*n and Dtbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dtbcon will panic otherwise.
+func (impl Implementation) Dtbcon(norm lapack.MatrixNorm, uplo blas.Uplo, diag blas.Diag, n, kd int, ab []float64, ldab int, work []float64, iwork []int) float64 {
+	switch {
+	case norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum:
+		panic(badNorm)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case diag != blas.NonUnit && diag != blas.Unit:
+		panic(badDiag)
+	case n < 0:
+		panic(nLT0)
+	case kd < 0:
+		panic(kdLT0)
+	case ldab < kd+1:
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1
+	}
+
+	switch {
+	case len(ab) < (n-1)*ldab+kd+1:
+		panic(shortAB)
+	case len(work) < 2*n:
+		panic(shortWork)
+	case len(iwork) < n:
+		panic(shortIWork)
+	}
+
+	// Compute the norm of the triangular matrix A.
+	anorm := impl.Dlantb(norm, uplo, diag, n, kd, ab, ldab, work)
+	if anorm <= 0 {
+		return 0
+	}
+
+	// Check that A is nonsingular.
+	if diag == blas.NonUnit {
+		d := 0
+		if uplo == blas.Lower {
+			d = kd
+		}
+		for i := 0; i < n; i++ {
+			if ab[i*ldab+d] == 0 {
+				return 0
+			}
+		}
+	}
+
+	// Estimate the norm of the inverse of A.
+	bi := blas64.Implementation()
+	kase1 := 2
+	if norm == lapack.MaxColumnSum {
+		kase1 = 1
+	}
+	var (
+		ainvnm float64
+		kase   int
+		isave  [3]int
+	)
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, &isave)
+		if kase == 0 {
+			break
+		}
+		if kase == kase1 {
+			// Multiply by inv(A).
+			bi.Dtbsv(uplo, blas.NoTrans, diag, n, kd, ab, ldab, work, 1)
+		} else {
+			// Multiply by inv(A^T).
+			bi.Dtbsv(uplo, blas.Trans, diag, n, kd, ab, ldab, work, 1)
+		}
+	}
+
+	// Compute the estimate of the reciprocal condition number.
+	if ainvnm == 0 {
+		return 0
+	}
+	return (1 / anorm) / ainvnm
+}
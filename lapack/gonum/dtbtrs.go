@@ -0,0 +1,75 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dtbtrs solves a triangular system of the form
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans or blas.ConjTrans
+// where A is an n×n triangular band matrix with kd super-diagonals if
+// uplo == blas.Upper and kd sub-diagonals otherwise. See the documentation
+// for Dpbtrf for a description of the band storage format.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, it is
+// overwritten with the solution matrix X.
+//
+// Dtbtrs returns whether A is nonsingular. If A is singular, no solve is
+// performed.
+func (Implementation) Dtbtrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans:
+		panic(badTrans)
+	case diag != blas.NonUnit && diag != blas.Unit:
+		panic(badDiag)
+	case n < 0:
+		panic(nLT0)
+	case kd < 0:
+		panic(kdLT0)
+	case nrhs < 0:
+		panic(nrhsLT0)
+	case ldab < kd+1:
+		panic(badLdA)
+	case ldb < max(1, nrhs):
+		panic(badLdB)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	switch {
+	case len(ab) < (n-1)*ldab+kd+1:
+		panic(shortAB)
+	case len(b) < (n-1)*ldb+nrhs:
+		panic(shortB)
+	}
+
+	// Check for singularity.
+	if diag == blas.NonUnit {
+		d := 0
+		if uplo == blas.Lower {
+			d = kd
+		}
+		for i := 0; i < n; i++ {
+			if ab[i*ldab+d] == 0 {
+				return false
+			}
+		}
+	}
+
+	// Solve A * X = B or A^T * X = B.
+	bi := blas64.Implementation()
+	for j := 0; j < nrhs; j++ {
+		bi.Dtbsv(uplo, trans, diag, n, kd, ab, ldab, b[j:], ldb)
+	}
+	return true
+}
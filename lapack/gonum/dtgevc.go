@@ -0,0 +1,691 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dtgevc computes some or all of the right and/or left eigenvectors of a pair
+// of real matrices (S,P), where S is an n×n upper quasi-triangular matrix and
+// P is an n×n upper triangular matrix. Matrix pairs of this type are produced
+// by the generalized Schur factorization of a matrix pair (A,B)
+//  A = Q*S*Z^T,  B = Q*P*Z^T,
+// as computed by Dhgeqz.
+//
+// The right eigenvector x and the left eigenvector y of (S,P) corresponding
+// to an eigenvalue w are defined by
+//  S*x = w*P*x,  y^H*S = w*y^H*P,
+// where y^H denotes the conjugate transpose of y. The eigenvalues are not
+// input to this routine, but are computed directly from the diagonal blocks
+// of S and P. It is assumed that S and P are in the form returned by Dhgeqz,
+// that is, the 2×2 diagonal blocks of P corresponding to 2×2 blocks of S are
+// diagonal with positive diagonal elements.
+//
+// This routine returns the matrices X and/or Y of right and left eigenvectors
+// of (S,P), or the products Z*X and/or Q*Y, where Z and Q are input matrices.
+// If Q and Z are the orthogonal factors from the generalized Schur
+// factorization of a matrix pair (A,B), then Z*X and Q*Y are the matrices of
+// right and left eigenvectors of (A,B).
+//
+// If side == lapack.EVRight, only right eigenvectors will be computed.
+// If side == lapack.EVLeft, only left eigenvectors will be computed.
+// If side == lapack.EVBoth, both right and left eigenvectors will be computed.
+// For other values of side, Dtgevc will panic.
+//
+// If howmny == lapack.EVAll, all right and/or left eigenvectors will be
+// computed.
+// If howmny == lapack.EVAllMulQ, all right and/or left eigenvectors will be
+// computed and back-transformed by the matrices in VR and/or VL.
+// If howmny == lapack.EVSelected, right and/or left eigenvectors will be
+// computed as indicated by selected.
+// For other values of howmny, Dtgevc will panic.
+//
+// selected specifies which eigenvectors will be computed. It must have length n
+// if howmny == lapack.EVSelected, and it is not referenced otherwise. If w_j is
+// a real eigenvalue, the corresponding real eigenvector will be computed if
+// selected[j] is true. If w_j and w_{j+1} are a complex conjugate pair of
+// eigenvalues, the corresponding complex eigenvector is computed if either
+// selected[j] or selected[j+1] is true.
+//
+// VL and VR are n×mm matrices. If howmny is lapack.EVAll or lapack.EVAllMulQ,
+// mm must be at least n. If howmny is lapack.EVSelected, mm must be large
+// enough to store the selected eigenvectors. Each selected real eigenvector
+// occupies one column and each selected complex eigenvector occupies two
+// columns. If mm is not sufficiently large, Dtgevc will panic.
+//
+// On entry, if howmny is lapack.EVAllMulQ, it is assumed that VL (if side is
+// lapack.EVLeft or lapack.EVBoth) contains an n×n matrix Q, and that VR (if
+// side is lapack.EVRight or lapack.EVBoth) contains an n×n matrix Z. Q and Z
+// are typically the orthogonal matrices of left and right Schur vectors
+// returned by Dhgeqz.
+//
+// Complex eigenvectors corresponding to a complex eigenvalue are stored in VL
+// and VR in two consecutive columns, the first holding the real part, and the
+// second the imaginary part. The stored vector corresponds to the eigenvalue
+// with positive imaginary part.
+//
+// Each eigenvector will be normalized so that the element of largest magnitude
+// has magnitude 1. Here the magnitude of a complex number (x,y) is taken to be
+// |x| + |y|.
+//
+// work must have length at least 6*n, otherwise Dtgevc will panic.
+//
+// Dtgevc returns the number of columns in VL and/or VR actually used to store
+// the eigenvectors. ok will be false if a 2×2 diagonal block of (S,P) does not
+// have a complex eigenvalue, in which case the computation was stopped and the
+// contents of VL and VR are unspecified.
+//
+// Dtgevc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtgevc(side lapack.EVSide, howmny lapack.EVHowMany, selected []bool, n int, s []float64, lds int, p []float64, ldp int, vl []float64, ldvl int, vr []float64, ldvr int, mm int, work []float64) (m int, ok bool) {
+	const safety = 100
+
+	bothv := side == lapack.EVBoth
+	rightv := side == lapack.EVRight || bothv
+	leftv := side == lapack.EVLeft || bothv
+	switch {
+	case !rightv && !leftv:
+		panic(badEVSide)
+	case howmny != lapack.EVAll && howmny != lapack.EVAllMulQ && howmny != lapack.EVSelected:
+		panic(badEVHowMany)
+	case n < 0:
+		panic(nLT0)
+	case lds < max(1, n):
+		panic(badLdS)
+	case ldp < max(1, n):
+		panic(badLdP)
+	case mm < 0:
+		panic(mmLT0)
+	case ldvl < 1, leftv && ldvl < mm:
+		panic(badLdVL)
+	case ldvr < 1, rightv && ldvr < mm:
+		panic(badLdVR)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, true
+	}
+
+	switch {
+	case len(s) < (n-1)*lds+n:
+		panic(shortS)
+	case len(p) < (n-1)*ldp+n:
+		panic(shortP)
+	case len(work) < 6*n:
+		panic(shortWork)
+	}
+
+	// Count the number of eigenvectors to be computed.
+	ilall := howmny != lapack.EVSelected
+	if ilall {
+		m = n
+	} else {
+		if len(selected) != n {
+			panic(badLenSelected)
+		}
+		for j := 0; j < n; j++ {
+			if j < n-1 && s[(j+1)*lds+j] != 0 {
+				if selected[j] || selected[j+1] {
+					m += 2
+				}
+				j++
+			} else if selected[j] {
+				m++
+			}
+		}
+	}
+	if mm < m {
+		panic(badMm)
+	}
+
+	// Quick return if no eigenvectors were selected.
+	if m == 0 {
+		return 0, true
+	}
+
+	switch {
+	case leftv && len(vl) < (n-1)*ldvl+mm:
+		panic(shortVL)
+	case rightv && len(vr) < (n-1)*ldvr+mm:
+		panic(shortVR)
+	}
+
+	ilback := howmny == lapack.EVAllMulQ
+
+	// Machine constants.
+	safmin := dlamchS
+	ulp := dlamchP
+	small := safmin * float64(n) / ulp
+	big := 1 / small
+	bignum := 1 / (safmin * float64(n))
+
+	// Compute the 1-norm of each column of the strictly upper triangular
+	// part of S and P to check for possible overflow in the triangular
+	// solver.
+	anorm := math.Abs(s[0])
+	if n > 1 {
+		anorm += math.Abs(s[lds])
+	}
+	bnorm := math.Abs(p[0])
+	work[0] = 0
+	work[n] = 0
+	for j := 1; j < n; j++ {
+		iend := j
+		if s[j*lds+j-1] != 0 {
+			iend = j - 1
+		}
+		var temp, temp2 float64
+		for i := 0; i < iend; i++ {
+			temp += math.Abs(s[i*lds+j])
+		}
+		for i := 0; i < j; i++ {
+			temp2 += math.Abs(p[i*ldp+j])
+		}
+		work[j] = temp
+		work[n+j] = temp2
+		for i := iend; i < min(j+2, n); i++ {
+			temp += math.Abs(s[i*lds+j])
+			temp2 += math.Abs(p[i*ldp+j])
+		}
+		anorm = math.Max(anorm, temp)
+		bnorm = math.Max(bnorm, temp2)
+	}
+	ascale := 1 / math.Max(anorm, safmin)
+	bscale := 1 / math.Max(bnorm, safmin)
+
+	bi := blas64.Implementation()
+
+	// xr and xi hold the real and imaginary parts of the current
+	// eigenvector. work[4*n:6*n] holds the back-transformed vector.
+	xr := work[2*n : 3*n]
+	xi := work[3*n : 4*n]
+
+	// scaleX scales the elements [lo:hi] of the current eigenvector by f.
+	scaleX := func(nw, lo, hi int, f float64) {
+		bi.Dscal(hi-lo, f, xr[lo:hi], 1)
+		if nw == 2 {
+			bi.Dscal(hi-lo, f, xi[lo:hi], 1)
+		}
+	}
+
+	// coeffs holds the coefficients a and b = br + i*bi in
+	//  (a*S - b*P)*x = 0
+	// for the eigenvalue whose diagonal block starts at je, as computed
+	// by realCoeffs and complexCoeffs.
+	type coeffs struct {
+		acoef, bcoefr, bcoefi float64
+		acoefa, bcoefa        float64
+	}
+	realCoeffs := func(je int) coeffs {
+		temp := 1 / math.Max(math.Max(math.Abs(s[je*lds+je])*ascale, math.Abs(p[je*ldp+je])*bscale), safmin)
+		salfar := (temp * s[je*lds+je]) * ascale
+		sbeta := (temp * p[je*ldp+je]) * bscale
+		acoef := sbeta * ascale
+		bcoefr := salfar * bscale
+
+		// Scale to avoid underflow.
+		scale := 1.0
+		lsa := math.Abs(sbeta) >= safmin && math.Abs(acoef) < small
+		lsb := math.Abs(salfar) >= safmin && math.Abs(bcoefr) < small
+		if lsa {
+			scale = (small / math.Abs(sbeta)) * math.Min(anorm, big)
+		}
+		if lsb {
+			scale = math.Max(scale, (small/math.Abs(salfar))*math.Min(bnorm, big))
+		}
+		if lsa || lsb {
+			scale = math.Min(scale, 1/(safmin*math.Max(1, math.Max(math.Abs(acoef), math.Abs(bcoefr)))))
+			if lsa {
+				acoef = ascale * (scale * sbeta)
+			} else {
+				acoef *= scale
+			}
+			if lsb {
+				bcoefr = bscale * (scale * salfar)
+			} else {
+				bcoefr *= scale
+			}
+		}
+		return coeffs{
+			acoef:  acoef,
+			bcoefr: bcoefr,
+			acoefa: math.Abs(acoef),
+			bcoefa: math.Abs(bcoefr),
+		}
+	}
+	complexCoeffs := func(je int) (c coeffs, ok bool) {
+		acoef, _, bcoefr, _, bcoefi := impl.Dlag2(s[je*lds+je:], lds, p[je*ldp+je:], ldp, safmin*safety)
+		if bcoefi == 0 {
+			return c, false
+		}
+
+		// Scale to avoid over/underflow.
+		acoefa := math.Abs(acoef)
+		bcoefa := math.Abs(bcoefr) + math.Abs(bcoefi)
+		scale := 1.0
+		if acoefa*ulp < safmin && acoefa >= safmin {
+			scale = (safmin / ulp) / acoefa
+		}
+		if bcoefa*ulp < safmin && bcoefa >= safmin {
+			scale = math.Max(scale, (safmin/ulp)/bcoefa)
+		}
+		if safmin*acoefa > ascale {
+			scale = ascale / (safmin * acoefa)
+		}
+		if safmin*bcoefa > bscale {
+			scale = math.Min(scale, bscale/(safmin*bcoefa))
+		}
+		if scale != 1 {
+			acoef *= scale
+			acoefa = math.Abs(acoef)
+			bcoefr *= scale
+			bcoefi *= scale
+			bcoefa = math.Abs(bcoefr) + math.Abs(bcoefi)
+		}
+		return coeffs{
+			acoef:  acoef,
+			bcoefr: bcoefr,
+			bcoefi: bcoefi,
+			acoefa: acoefa,
+			bcoefa: bcoefa,
+		}, true
+	}
+
+	var (
+		bsum [4]float64 // Right-hand side for Dlaln2, na×nw.
+		x    [4]float64 // Solution from Dlaln2, na×nw.
+	)
+
+	if leftv {
+		// Compute left eigenvectors.
+		ieig := 0
+		for je := 0; je < n; je++ {
+			nw := 1
+			if je < n-1 && s[(je+1)*lds+je] != 0 {
+				nw = 2
+			}
+			ilcplx := nw == 2
+			if !ilall {
+				if !selected[je] && (!ilcplx || !selected[je+1]) {
+					je += nw - 1
+					continue
+				}
+			}
+
+			// Decide if (a) singular pencil, (b) real eigenvalue, or
+			// (c) complex eigenvalue.
+			if !ilcplx && math.Abs(s[je*lds+je]) <= safmin && math.Abs(p[je*ldp+je]) <= safmin {
+				// Singular matrix pencil, return unit eigenvector.
+				for jr := 0; jr < n; jr++ {
+					vl[jr*ldvl+ieig] = 0
+				}
+				vl[ieig*ldvl+ieig] = 1
+				ieig++
+				continue
+			}
+
+			// Clear the vector.
+			for jr := 0; jr < n; jr++ {
+				xr[jr] = 0
+				xi[jr] = 0
+			}
+
+			// Compute the coefficients in
+			//  (a*S - b*P)^T*y = 0,
+			// where a is acoef and b is bcoefr + i*bcoefi.
+			var (
+				c    coeffs
+				xmax float64
+			)
+			if !ilcplx {
+				// Real eigenvalue.
+				c = realCoeffs(je)
+
+				// First component is 1.
+				xr[je] = 1
+				xmax = 1
+			} else {
+				// Complex eigenvalue.
+				var ok bool
+				c, ok = complexCoeffs(je)
+				if !ok {
+					return m, false
+				}
+				c.bcoefi = -c.bcoefi
+
+				// Compute the first two components of the eigenvector.
+				temp := c.acoef * s[(je+1)*lds+je]
+				temp2r := c.acoef*s[je*lds+je] - c.bcoefr*p[je*ldp+je]
+				temp2i := -c.bcoefi * p[je*ldp+je]
+				if math.Abs(temp) > math.Abs(temp2r)+math.Abs(temp2i) {
+					xr[je] = 1
+					xi[je] = 0
+					xr[je+1] = -temp2r / temp
+					xi[je+1] = -temp2i / temp
+				} else {
+					xr[je+1] = 1
+					xi[je+1] = 0
+					temp = c.acoef * s[je*lds+je+1]
+					xr[je] = (c.bcoefr*p[(je+1)*ldp+je+1] - c.acoef*s[(je+1)*lds+je+1]) / temp
+					xi[je] = c.bcoefi * p[(je+1)*ldp+je+1] / temp
+				}
+				xmax = math.Max(math.Abs(xr[je])+math.Abs(xi[je]), math.Abs(xr[je+1])+math.Abs(xi[je+1]))
+			}
+
+			dmin := math.Max(math.Max(ulp*c.acoefa*anorm, ulp*c.bcoefa*bnorm), safmin)
+
+			// Triangular solve of
+			//  (a*S - b*P)^T*y = 0,
+			// row-wise in (a*S - b*P)^T, or column-wise in (a*S - b*P).
+			for j := je + nw; j < n; j++ {
+				na := 1
+				bdiag1 := p[j*ldp+j]
+				var bdiag2 float64
+				if j < n-1 && s[(j+1)*lds+j] != 0 {
+					na = 2
+					bdiag2 = p[(j+1)*ldp+j+1]
+				}
+
+				// Check whether scaling is necessary for dot products.
+				xscale := 1 / math.Max(1, xmax)
+				temp := math.Max(math.Max(work[j], work[n+j]), c.acoefa*work[j]+c.bcoefa*work[n+j])
+				if na == 2 {
+					temp = math.Max(temp, math.Max(math.Max(work[j+1], work[n+j+1]), c.acoefa*work[j+1]+c.bcoefa*work[n+j+1]))
+				}
+				if temp > bignum*xscale {
+					scaleX(nw, je, j, xscale)
+					xmax *= xscale
+				}
+
+				// Compute the dot products
+				//        j-1
+				//  sum = sum  conj(a*S[k,j] - b*P[k,j])*x[k]
+				//        k=je
+				// as
+				//            j-1                     j-1
+				//  a*conj(  sum  S[k,j]*x[k] ) - b*conj( sum  P[k,j]*x[k] ).
+				//           k=je                    k=je
+				for ja := 0; ja < na; ja++ {
+					col := j + ja
+					sumsr := bi.Ddot(j-je, s[je*lds+col:], lds, xr[je:], 1)
+					sumpr := bi.Ddot(j-je, p[je*ldp+col:], ldp, xr[je:], 1)
+					if ilcplx {
+						sumsi := bi.Ddot(j-je, s[je*lds+col:], lds, xi[je:], 1)
+						sumpi := bi.Ddot(j-je, p[je*ldp+col:], ldp, xi[je:], 1)
+						bsum[ja*2] = -c.acoef*sumsr + c.bcoefr*sumpr - c.bcoefi*sumpi
+						bsum[ja*2+1] = -c.acoef*sumsi + c.bcoefr*sumpi + c.bcoefi*sumpr
+					} else {
+						bsum[ja*2] = -c.acoef*sumsr + c.bcoefr*sumpr
+					}
+				}
+
+				// Solve
+				//  (a*S - b*P)^T*y = sum
+				// with scaling and perturbation of the denominator.
+				scale, xnorm, _ := impl.Dlaln2(true, na, nw, dmin, c.acoef, s[j*lds+j:], lds, bdiag1, bdiag2,
+					bsum[:], 2, c.bcoefr, c.bcoefi, x[:], 2)
+				if scale < 1 {
+					scaleX(nw, je, j, scale)
+					xmax *= scale
+				}
+				for ja := 0; ja < na; ja++ {
+					xr[j+ja] = x[ja*2]
+					if ilcplx {
+						xi[j+ja] = x[ja*2+1]
+					}
+				}
+				xmax = math.Max(xmax, xnorm)
+				j += na - 1
+			}
+
+			// Copy the eigenvector to VL, back-transforming if
+			// howmny == lapack.EVAllMulQ.
+			ibeg := je
+			src := 2 * n
+			if ilback {
+				for jw := 0; jw < nw; jw++ {
+					bi.Dgemv(blas.NoTrans, n, n-je, 1, vl[je:], ldvl, work[(jw+2)*n+je:], 1, 0, work[(jw+4)*n:], 1)
+				}
+				src = 4 * n
+				ibeg = 0
+			}
+			for jw := 0; jw < nw; jw++ {
+				bi.Dcopy(n, work[src+jw*n:], 1, vl[ieig+jw:], ldvl)
+			}
+
+			// Scale the eigenvector.
+			xmax = 0
+			for j := ibeg; j < n; j++ {
+				v := math.Abs(vl[j*ldvl+ieig])
+				if ilcplx {
+					v += math.Abs(vl[j*ldvl+ieig+1])
+				}
+				xmax = math.Max(xmax, v)
+			}
+			if xmax > safmin {
+				xscale := 1 / xmax
+				for jw := 0; jw < nw; jw++ {
+					bi.Dscal(n-ibeg, xscale, vl[ibeg*ldvl+ieig+jw:], ldvl)
+				}
+			}
+			ieig += nw
+			je += nw - 1
+		}
+	}
+
+	if rightv {
+		// Compute right eigenvectors.
+		ieig := m
+		for je := n - 1; je >= 0; je-- {
+			nw := 1
+			if je > 0 && s[je*lds+je-1] != 0 {
+				nw = 2
+			}
+			ilcplx := nw == 2
+			if !ilall {
+				if !selected[je] && (!ilcplx || !selected[je-1]) {
+					je -= nw - 1
+					continue
+				}
+			}
+
+			// Decide if (a) singular pencil, (b) real eigenvalue, or
+			// (c) complex eigenvalue.
+			if !ilcplx && math.Abs(s[je*lds+je]) <= safmin && math.Abs(p[je*ldp+je]) <= safmin {
+				// Singular matrix pencil, return unit eigenvector.
+				ieig--
+				for jr := 0; jr < n; jr++ {
+					vr[jr*ldvr+ieig] = 0
+				}
+				vr[ieig*ldvr+ieig] = 1
+				continue
+			}
+
+			// Clear the vector.
+			for jr := 0; jr < n; jr++ {
+				xr[jr] = 0
+				xi[jr] = 0
+			}
+
+			// Compute the coefficients in
+			//  (a*S - b*P)*x = 0,
+			// where a is acoef and b is bcoefr + i*bcoefi.
+			var (
+				c    coeffs
+				xmax float64
+			)
+			if !ilcplx {
+				// Real eigenvalue.
+				c = realCoeffs(je)
+
+				// First component is 1.
+				xr[je] = 1
+				xmax = 1
+
+				// Compute the contribution from column je of S and P
+				// to the sum.
+				for jr := 0; jr < je; jr++ {
+					xr[jr] = c.bcoefr*p[jr*ldp+je] - c.acoef*s[jr*lds+je]
+				}
+			} else {
+				// Complex eigenvalue.
+				var ok bool
+				c, ok = complexCoeffs(je - 1)
+				if !ok {
+					return m, false
+				}
+
+				// Compute the first two components of the eigenvector
+				// and the contribution to the sums.
+				temp := c.acoef * s[je*lds+je-1]
+				temp2r := c.acoef*s[je*lds+je] - c.bcoefr*p[je*ldp+je]
+				temp2i := -c.bcoefi * p[je*ldp+je]
+				if math.Abs(temp) >= math.Abs(temp2r)+math.Abs(temp2i) {
+					xr[je] = 1
+					xi[je] = 0
+					xr[je-1] = -temp2r / temp
+					xi[je-1] = -temp2i / temp
+				} else {
+					xr[je-1] = 1
+					xi[je-1] = 0
+					temp = c.acoef * s[(je-1)*lds+je]
+					xr[je] = (c.bcoefr*p[(je-1)*ldp+je-1] - c.acoef*s[(je-1)*lds+je-1]) / temp
+					xi[je] = c.bcoefi * p[(je-1)*ldp+je-1] / temp
+				}
+				xmax = math.Max(math.Abs(xr[je])+math.Abs(xi[je]), math.Abs(xr[je-1])+math.Abs(xi[je-1]))
+
+				// Compute the contribution from columns je and je-1 of
+				// S and P to the sums.
+				creala := c.acoef * xr[je-1]
+				cimaga := c.acoef * xi[je-1]
+				crealb := c.bcoefr*xr[je-1] - c.bcoefi*xi[je-1]
+				cimagb := c.bcoefi*xr[je-1] + c.bcoefr*xi[je-1]
+				cre2a := c.acoef * xr[je]
+				cim2a := c.acoef * xi[je]
+				cre2b := c.bcoefr*xr[je] - c.bcoefi*xi[je]
+				cim2b := c.bcoefi*xr[je] + c.bcoefr*xi[je]
+				for jr := 0; jr < je-1; jr++ {
+					xr[jr] = -creala*s[jr*lds+je-1] + crealb*p[jr*ldp+je-1] - cre2a*s[jr*lds+je] + cre2b*p[jr*ldp+je]
+					xi[jr] = -cimaga*s[jr*lds+je-1] + cimagb*p[jr*ldp+je-1] - cim2a*s[jr*lds+je] + cim2b*p[jr*ldp+je]
+				}
+			}
+
+			dmin := math.Max(math.Max(ulp*c.acoefa*anorm, ulp*c.bcoefa*bnorm), safmin)
+
+			// Column-wise triangular solve of
+			//  (a*S - b*P)*x = 0.
+			for j := je - nw; j >= 0; j-- {
+				// If a 2×2 block is in position j-1:j+1, process it
+				// at the next iteration (when it will be j:j+2).
+				na := 1
+				if j > 0 && s[j*lds+j-1] != 0 {
+					na = 2
+					j--
+				}
+				bdiag1 := p[j*ldp+j]
+				var bdiag2 float64
+				if na == 2 {
+					bdiag2 = p[(j+1)*ldp+j+1]
+				}
+
+				// Compute x[j] (and x[j+1], if 2×2 block).
+				for ja := 0; ja < na; ja++ {
+					bsum[ja*2] = xr[j+ja]
+					bsum[ja*2+1] = xi[j+ja]
+				}
+				scale, xnorm, _ := impl.Dlaln2(false, na, nw, dmin, c.acoef, s[j*lds+j:], lds, bdiag1, bdiag2,
+					bsum[:], 2, c.bcoefr, c.bcoefi, x[:], 2)
+				if scale < 1 {
+					scaleX(nw, 0, je+1, scale)
+				}
+				xmax = math.Max(scale*xmax, xnorm)
+				for ja := 0; ja < na; ja++ {
+					xr[j+ja] = x[ja*2]
+					if ilcplx {
+						xi[j+ja] = x[ja*2+1]
+					}
+				}
+
+				// w = w + x[j]*(a*S[:,j] - b*P[:,j]) with scaling.
+				if j > 0 {
+					// Check whether scaling is necessary for the sum.
+					xscale := 1 / math.Max(1, xmax)
+					temp := c.acoefa*work[j] + c.bcoefa*work[n+j]
+					if na == 2 {
+						temp = math.Max(temp, c.acoefa*work[j+1]+c.bcoefa*work[n+j+1])
+					}
+					temp = math.Max(temp, math.Max(c.acoefa, c.bcoefa))
+					if temp > bignum*xscale {
+						scaleX(nw, 0, je+1, xscale)
+						xmax *= xscale
+					}
+
+					// Compute the contributions of the off-diagonals
+					// of column j (and j+1, if 2×2 block) of S and P
+					// to the sums.
+					for ja := 0; ja < na; ja++ {
+						col := j + ja
+						if ilcplx {
+							creala := c.acoef * xr[col]
+							cimaga := c.acoef * xi[col]
+							crealb := c.bcoefr*xr[col] - c.bcoefi*xi[col]
+							cimagb := c.bcoefi*xr[col] + c.bcoefr*xi[col]
+							for jr := 0; jr < j; jr++ {
+								xr[jr] += -creala*s[jr*lds+col] + crealb*p[jr*ldp+col]
+								xi[jr] += -cimaga*s[jr*lds+col] + cimagb*p[jr*ldp+col]
+							}
+						} else {
+							creala := c.acoef * xr[col]
+							crealb := c.bcoefr * xr[col]
+							for jr := 0; jr < j; jr++ {
+								xr[jr] += -creala*s[jr*lds+col] + crealb*p[jr*ldp+col]
+							}
+						}
+					}
+				}
+			}
+
+			// Copy the eigenvector to VR, back-transforming if
+			// howmny == lapack.EVAllMulQ.
+			ieig -= nw
+			iend := je + 1
+			src := 2 * n
+			if ilback {
+				for jw := 0; jw < nw; jw++ {
+					bi.Dgemv(blas.NoTrans, n, je+1, 1, vr, ldvr, work[(jw+2)*n:], 1, 0, work[(jw+4)*n:], 1)
+				}
+				src = 4 * n
+				iend = n
+			}
+			for jw := 0; jw < nw; jw++ {
+				bi.Dcopy(n, work[src+jw*n:], 1, vr[ieig+jw:], ldvr)
+			}
+
+			// Scale the eigenvector.
+			xmax = 0
+			for j := 0; j < iend; j++ {
+				v := math.Abs(vr[j*ldvr+ieig])
+				if ilcplx {
+					v += math.Abs(vr[j*ldvr+ieig+1])
+				}
+				xmax = math.Max(xmax, v)
+			}
+			if xmax > safmin {
+				xscale := 1 / xmax
+				for jw := 0; jw < nw; jw++ {
+					bi.Dscal(iend, xscale, vr[ieig+jw:], ldvr)
+				}
+			}
+			je -= nw - 1
+		}
+	}
+
+	return m, true
+}
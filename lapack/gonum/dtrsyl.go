@@ -0,0 +1,350 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dtrsyl solves the real Sylvester matrix equation
+//  op(A)*X + isgn*X*op(B) = scale*C
+// where op(A) = A or A^T according to trana, and op(B) = B or B^T according
+// to tranb. A is an m×m and B is an n×n upper quasi-triangular matrix in
+// Schur canonical form, as returned by Dhseqr. C and X are m×n matrices.
+// isgn must be 1 or -1.
+//
+// On entry, c contains the right-hand side matrix C. On return, it is
+// overwritten with the solution matrix X.
+//
+// scale is a scaling factor less than or equal to 1 which is chosen so that X
+// can be computed without overflow.
+//
+// Dtrsyl returns whether the equation has a unique solution. If ok is false,
+// A and -isgn*B have common or very close eigenvalues and perturbed values
+// were used to solve the equation.
+//
+// Dtrsyl uses an unblocked algorithm. See Dtrsyl3 for the blocked variant.
+func (impl Implementation) Dtrsyl(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool) {
+	switch {
+	case trana != blas.NoTrans && trana != blas.Trans && trana != blas.ConjTrans:
+		panic(badTrans)
+	case tranb != blas.NoTrans && tranb != blas.Trans && tranb != blas.ConjTrans:
+		panic(badTrans)
+	case isgn != 1 && isgn != -1:
+		panic(badIsgn)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, m):
+		panic(badLdA)
+	case ldb < max(1, n):
+		panic(badLdB)
+	case ldc < max(1, n):
+		panic(badLdC)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 1, true
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+m:
+		panic(shortA)
+	case len(b) < (n-1)*ldb+n:
+		panic(shortB)
+	case len(c) < (m-1)*ldc+n:
+		panic(shortC)
+	}
+
+	bi := blas64.Implementation()
+
+	notrna := trana == blas.NoTrans
+	notrnb := tranb == blas.NoTrans
+
+	// Set constants to control overflow.
+	eps := dlamchP
+	smlnum := dlamchS * float64(m*n) / eps
+	bignum := 1 / smlnum
+	smin := math.Max(smlnum, math.Max(
+		eps*impl.Dlange(lapack.MaxAbs, m, m, a, lda, nil),
+		eps*impl.Dlange(lapack.MaxAbs, n, n, b, ldb, nil)))
+	sgn := float64(isgn)
+
+	// rhs returns the element (k,l) of the right-hand side of the equation
+	// for the block of X that contains it, given that the blocks of X
+	// which it depends on have already been computed and stored in C.
+	// The current block of X spans rows k1:k2 and columns l1:l2.
+	var k1, k2, l1, l2 int
+	rhs := func(k, l int) float64 {
+		var suml, sumr float64
+		if notrna {
+			if k2 < m-1 {
+				suml = bi.Ddot(m-k2-1, a[k*lda+k2+1:], 1, c[(k2+1)*ldc+l:], ldc)
+			}
+		} else {
+			suml = bi.Ddot(k1, a[k:], lda, c[l:], ldc)
+		}
+		if notrnb {
+			sumr = bi.Ddot(l1, c[k*ldc:], 1, b[l:], ldb)
+		} else {
+			if l2 < n-1 {
+				sumr = bi.Ddot(n-l2-1, c[k*ldc+l2+1:], 1, b[l*ldb+l2+1:], 1)
+			}
+		}
+		return c[k*ldc+l] - (suml + sgn*sumr)
+	}
+
+	scale = 1
+	ok = true
+	var vec, x [4]float64
+	// The blocks of X are computed column block by column block. The
+	// columns are traversed left to right if op(B) = B and right to
+	// left otherwise, and within each column block the rows are traversed
+	// bottom to top if op(A) = A and top to bottom otherwise.
+	for lb := 0; lb < n; lb += l2 - l1 + 1 {
+		if notrnb {
+			l1 = lb
+			l2 = l1
+			if l1 < n-1 && b[(l1+1)*ldb+l1] != 0 {
+				l2++
+			}
+		} else {
+			l2 = n - 1 - lb
+			l1 = l2
+			if l2 > 0 && b[l2*ldb+l2-1] != 0 {
+				l1--
+			}
+		}
+		for kb := 0; kb < m; kb += k2 - k1 + 1 {
+			if notrna {
+				k2 = m - 1 - kb
+				k1 = k2
+				if k2 > 0 && a[k2*lda+k2-1] != 0 {
+					k1--
+				}
+			} else {
+				k1 = kb
+				k2 = k1
+				if k1 < m-1 && a[(k1+1)*lda+k1] != 0 {
+					k2++
+				}
+			}
+
+			scaloc := 1.0
+			switch {
+			case k1 == k2 && l1 == l2:
+				v := rhs(k1, l1)
+				a11 := a[k1*lda+k1] + sgn*b[l1*ldb+l1]
+				da11 := math.Abs(a11)
+				if da11 <= smin {
+					a11 = smin
+					da11 = smin
+					ok = false
+				}
+				db := math.Abs(v)
+				if da11 < 1 && db > 1 && db > bignum*da11 {
+					scaloc = 1 / db
+				}
+				x[0] = (v * scaloc) / a11
+			case k1 != k2 && l1 == l2:
+				// Solve the 2×1 system
+				//  (op(A11) + isgn*b11) * x = vec.
+				vec[0] = rhs(k1, l1)
+				vec[2] = rhs(k2, l1)
+				var okl bool
+				scaloc, _, okl = impl.Dlaln2(!notrna, 2, 1, smin, 1, a[k1*lda+k1:], lda,
+					1, 1, vec[:], 2, -sgn*b[l1*ldb+l1], 0, x[:], 2)
+				ok = ok && okl
+			case k1 == k2 && l1 != l2:
+				// Solve the 1×2 system
+				//  x * (isgn*op(B11) + a11) = vec
+				// in its transposed form.
+				vec[0] = sgn * rhs(k1, l1)
+				vec[2] = sgn * rhs(k1, l2)
+				var okl bool
+				scaloc, _, okl = impl.Dlaln2(notrnb, 2, 1, smin, 1, b[l1*ldb+l1:], ldb,
+					1, 1, vec[:], 2, -sgn*a[k1*lda+k1], 0, x[:], 2)
+				ok = ok && okl
+				x[1] = x[2]
+			default:
+				vec[0] = rhs(k1, l1)
+				vec[1] = rhs(k1, l2)
+				vec[2] = rhs(k2, l1)
+				vec[3] = rhs(k2, l2)
+				var okl bool
+				scaloc, _, okl = impl.Dlasy2(!notrna, !notrnb, isgn, 2, 2, a[k1*lda+k1:], lda,
+					b[l1*ldb+l1:], ldb, vec[:], 2, x[:], 2)
+				ok = ok && okl
+			}
+			if scaloc != 1 {
+				for i := 0; i < m; i++ {
+					bi.Dscal(n, scaloc, c[i*ldc:], 1)
+				}
+				scale *= scaloc
+			}
+			c[k1*ldc+l1] = x[0]
+			if l1 != l2 {
+				c[k1*ldc+l2] = x[1]
+			}
+			if k1 != k2 {
+				c[k2*ldc+l1] = x[2]
+				if l1 != l2 {
+					c[k2*ldc+l2] = x[3]
+				}
+			}
+		}
+	}
+	return scale, ok
+}
+
+// Dtrsyl3 solves the real Sylvester matrix equation
+//  op(A)*X + isgn*X*op(B) = scale*C
+// as described in the documentation of Dtrsyl, using a blocked algorithm.
+//
+// A and B are partitioned into blocks of size roughly nb that do not split
+// the 2×2 diagonal blocks of the Schur canonical form. The equation for each
+// pair of diagonal blocks is solved by Dtrsyl and the solution is used to
+// update the remaining right-hand side using Level 3 BLAS.
+//
+// Dtrsyl3 returns the same values as Dtrsyl. If the solution of any block
+// equation needs to be scaled to avoid overflow, the whole of C is scaled.
+func (impl Implementation) Dtrsyl3(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool) {
+	switch {
+	case trana != blas.NoTrans && trana != blas.Trans && trana != blas.ConjTrans:
+		panic(badTrans)
+	case tranb != blas.NoTrans && tranb != blas.Trans && tranb != blas.ConjTrans:
+		panic(badTrans)
+	case isgn != 1 && isgn != -1:
+		panic(badIsgn)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, m):
+		panic(badLdA)
+	case ldb < max(1, n):
+		panic(badLdB)
+	case ldc < max(1, n):
+		panic(badLdC)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 1, true
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+m:
+		panic(shortA)
+	case len(b) < (n-1)*ldb+n:
+		panic(shortB)
+	case len(c) < (m-1)*ldc+n:
+		panic(shortC)
+	}
+
+	nb := impl.Ilaenv(1, "DTRSYL", " ", m, n, -1, -1)
+	if nb <= 1 || (m <= nb && n <= nb) {
+		// Use unblocked code.
+		return impl.Dtrsyl(trana, tranb, isgn, m, n, a, lda, b, ldb, c, ldc)
+	}
+
+	bi := blas64.Implementation()
+
+	notrna := trana == blas.NoTrans
+	notrnb := tranb == blas.NoTrans
+	sgn := float64(isgn)
+
+	scale = 1
+	ok = true
+	// The blocks are traversed in the same order as in Dtrsyl. The block
+	// of X spanning rows k1:k2 and columns l1:l2, once computed, is used
+	// to update the right-hand side of the blocks that depend on it.
+	var k1, k2, l1, l2 int
+	for lb := 0; lb < n; lb += l2 - l1 {
+		if notrnb {
+			l1 = lb
+			l2 = min(l1+nb, n)
+			if l2 < n && b[l2*ldb+l2-1] != 0 {
+				l2++
+			}
+		} else {
+			l2 = n - lb
+			l1 = max(l2-nb, 0)
+			if l1 > 0 && b[l1*ldb+l1-1] != 0 {
+				l1--
+			}
+		}
+		for kb := 0; kb < m; kb += k2 - k1 {
+			if notrna {
+				k2 = m - kb
+				k1 = max(k2-nb, 0)
+				if k1 > 0 && a[k1*lda+k1-1] != 0 {
+					k1--
+				}
+			} else {
+				k1 = kb
+				k2 = min(k1+nb, m)
+				if k2 < m && a[k2*lda+k2-1] != 0 {
+					k2++
+				}
+			}
+			mk := k2 - k1
+			nl := l2 - l1
+
+			scaloc, okl := impl.Dtrsyl(trana, tranb, isgn, mk, nl, a[k1*lda+k1:], lda,
+				b[l1*ldb+l1:], ldb, c[k1*ldc+l1:], ldc)
+			ok = ok && okl
+			if scaloc != 1 {
+				// Scale all of C except the block that has just
+				// been computed.
+				for i := 0; i < m; i++ {
+					if k1 <= i && i < k2 {
+						bi.Dscal(l1, scaloc, c[i*ldc:], 1)
+						bi.Dscal(n-l2, scaloc, c[i*ldc+l2:], 1)
+						continue
+					}
+					bi.Dscal(n, scaloc, c[i*ldc:], 1)
+				}
+				scale *= scaloc
+			}
+
+			// Update the right-hand side in the current column block.
+			if notrna {
+				if k1 > 0 {
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, k1, nl, mk,
+						-1, a[k1:], lda, c[k1*ldc+l1:], ldc,
+						1, c[l1:], ldc)
+				}
+			} else {
+				if k2 < m {
+					bi.Dgemm(blas.Trans, blas.NoTrans, m-k2, nl, mk,
+						-1, a[k1*lda+k2:], lda, c[k1*ldc+l1:], ldc,
+						1, c[k2*ldc+l1:], ldc)
+				}
+			}
+			// Update the right-hand side in the current row block.
+			if notrnb {
+				if l2 < n {
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, mk, n-l2, nl,
+						-sgn, c[k1*ldc+l1:], ldc, b[l1*ldb+l2:], ldb,
+						1, c[k1*ldc+l2:], ldc)
+				}
+			} else {
+				if l1 > 0 {
+					bi.Dgemm(blas.NoTrans, blas.Trans, mk, l1, nl,
+						-sgn, c[k1*ldc+l1:], ldc, b[l1:], ldb,
+						1, c[k1*ldc:], ldc)
+				}
+			}
+		}
+	}
+	return scale, ok
+}
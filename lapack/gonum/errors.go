@@ -14,12 +14,17 @@ const (
 	badEVComp          = "lapack: bad EVComp"
 	badEVHowMany       = "lapack: bad EVHowMany"
 	badEVJob           = "lapack: bad EVJob"
+	badEVRange         = "lapack: bad EVRange"
 	badEVSide          = "lapack: bad EVSide"
+	badEquilibration   = "lapack: bad Equilibration"
+	badFactJob         = "lapack: bad FactJob"
 	badGSVDJob         = "lapack: bad GSVDJob"
+	badGenEVType       = "lapack: bad GenEVType"
 	badGenOrtho        = "lapack: bad GenOrtho"
 	badLeftEVJob       = "lapack: bad LeftEVJob"
 	badMatrixType      = "lapack: bad MatrixType"
 	badNorm            = "lapack: bad Norm"
+	badOrthoComp       = "lapack: bad OrthoComp"
 	badPivot           = "lapack: bad Pivot"
 	badRightEVJob      = "lapack: bad RightEVJob"
 	badSVDJob          = "lapack: bad SVDJob"
@@ -34,14 +39,18 @@ const (
 	bothSVDOver        = "lapack: both jobU and jobVT are lapack.SVDOverwrite"
 
 	// Panic strings for bad numerical and string values.
+	badI        = "lapack: i out of range"
 	badIfst     = "lapack: ifst out of range"
 	badIhi      = "lapack: ihi out of range"
 	badIhiz     = "lapack: ihiz out of range"
+	badIl       = "lapack: il out of range"
 	badIlo      = "lapack: ilo out of range"
 	badIloz     = "lapack: iloz out of range"
 	badIlst     = "lapack: ilst out of range"
 	badIsave    = "lapack: bad isave value"
+	badIsgn     = "lapack: bad isgn value"
 	badIspec    = "lapack: bad ispec value"
+	badIu       = "lapack: iu out of range"
 	badJ1       = "lapack: j1 out of range"
 	badJpvt     = "lapack: bad element of jpvt"
 	badK1       = "lapack: k1 out of range"
@@ -49,6 +58,7 @@ const (
 	badKacc22   = "lapack: invalid value of kacc22"
 	badKbot     = "lapack: kbot out of range"
 	badKtop     = "lapack: ktop out of range"
+	badLIWork   = "lapack: insufficient declared iwork length"
 	badLWork    = "lapack: insufficient declared workspace length"
 	badMm       = "lapack: mm out of range"
 	badN1       = "lapack: bad value of n1"
@@ -58,15 +68,20 @@ const (
 	badNh       = "lapack: bad value of nh"
 	badNw       = "lapack: bad value of nw"
 	badPp       = "lapack: bad value of pp"
+	badScale    = "lapack: non-positive scale factor"
 	badShifts   = "lapack: bad shifts"
+	badVlVu     = "lapack: vl >= vu"
 	i0LT0       = "lapack: i0 < 0"
 	kGTM        = "lapack: k > m"
 	kGTN        = "lapack: k > n"
 	kLT0        = "lapack: k < 0"
 	kLT1        = "lapack: k < 1"
 	kdLT0       = "lapack: kd < 0"
+	klLT0       = "lapack: kl < 0"
+	kuLT0       = "lapack: ku < 0"
 	mGTN        = "lapack: m > n"
 	mLT0        = "lapack: m < 0"
+	mLTN        = "lapack: m < n"
 	mmLT0       = "lapack: mm < 0"
 	n0LT0       = "lapack: n0 < 0"
 	nGTM        = "lapack: n > m"
@@ -109,49 +124,67 @@ const (
 	badLenWr       = "lapack: bad length of wr"
 
 	// Panic strings for insufficient slice lengths.
-	shortA     = "lapack: insufficient length of a"
-	shortAB    = "lapack: insufficient length of ab"
-	shortAuxv  = "lapack: insufficient length of auxv"
-	shortB     = "lapack: insufficient length of b"
-	shortC     = "lapack: insufficient length of c"
-	shortCNorm = "lapack: insufficient length of cnorm"
-	shortD     = "lapack: insufficient length of d"
-	shortE     = "lapack: insufficient length of e"
-	shortF     = "lapack: insufficient length of f"
-	shortH     = "lapack: insufficient length of h"
-	shortIWork = "lapack: insufficient length of iwork"
-	shortIsgn  = "lapack: insufficient length of isgn"
-	shortQ     = "lapack: insufficient length of q"
-	shortS     = "lapack: insufficient length of s"
-	shortScale = "lapack: insufficient length of scale"
-	shortT     = "lapack: insufficient length of t"
-	shortTau   = "lapack: insufficient length of tau"
-	shortTauP  = "lapack: insufficient length of tauP"
-	shortTauQ  = "lapack: insufficient length of tauQ"
-	shortU     = "lapack: insufficient length of u"
-	shortV     = "lapack: insufficient length of v"
-	shortVL    = "lapack: insufficient length of vl"
-	shortVR    = "lapack: insufficient length of vr"
-	shortVT    = "lapack: insufficient length of vt"
-	shortVn1   = "lapack: insufficient length of vn1"
-	shortVn2   = "lapack: insufficient length of vn2"
-	shortW     = "lapack: insufficient length of w"
-	shortWH    = "lapack: insufficient length of wh"
-	shortWV    = "lapack: insufficient length of wv"
-	shortWi    = "lapack: insufficient length of wi"
-	shortWork  = "lapack: insufficient length of work"
-	shortWr    = "lapack: insufficient length of wr"
-	shortX     = "lapack: insufficient length of x"
-	shortY     = "lapack: insufficient length of y"
-	shortZ     = "lapack: insufficient length of z"
+	shortA      = "lapack: insufficient length of a"
+	shortAB     = "lapack: insufficient length of ab"
+	shortAlphaI = "lapack: insufficient length of alphaI"
+	shortAlphaR = "lapack: insufficient length of alphaR"
+	shortAF     = "lapack: insufficient length of af"
+	shortAuxv   = "lapack: insufficient length of auxv"
+	shortB      = "lapack: insufficient length of b"
+	shortBerr   = "lapack: insufficient length of berr"
+	shortBeta   = "lapack: insufficient length of beta"
+	shortC      = "lapack: insufficient length of c"
+	shortCNorm  = "lapack: insufficient length of cnorm"
+	shortD      = "lapack: insufficient length of d"
+	shortDelta  = "lapack: insufficient length of delta"
+	shortDL     = "lapack: insufficient length of dl"
+	shortDU     = "lapack: insufficient length of du"
+	shortE      = "lapack: insufficient length of e"
+	shortF      = "lapack: insufficient length of f"
+	shortFerr   = "lapack: insufficient length of ferr"
+	shortH      = "lapack: insufficient length of h"
+	shortIWork  = "lapack: insufficient length of iwork"
+	shortIsgn   = "lapack: insufficient length of isgn"
+	shortIsuppz = "lapack: insufficient length of isuppz"
+	shortP      = "lapack: insufficient length of p"
+	shortPiv    = "lapack: insufficient length of piv"
+	shortQ      = "lapack: insufficient length of q"
+	shortR      = "lapack: insufficient length of r"
+	shortRWork  = "lapack: insufficient length of rwork"
+	shortS      = "lapack: insufficient length of s"
+	shortSVA    = "lapack: insufficient length of sva"
+	shortScale  = "lapack: insufficient length of scale"
+	shortT      = "lapack: insufficient length of t"
+	shortTau    = "lapack: insufficient length of tau"
+	shortTauP   = "lapack: insufficient length of tauP"
+	shortTauQ   = "lapack: insufficient length of tauQ"
+	shortU      = "lapack: insufficient length of u"
+	shortV      = "lapack: insufficient length of v"
+	shortVL     = "lapack: insufficient length of vl"
+	shortVR     = "lapack: insufficient length of vr"
+	shortVT     = "lapack: insufficient length of vt"
+	shortVn1    = "lapack: insufficient length of vn1"
+	shortVn2    = "lapack: insufficient length of vn2"
+	shortW      = "lapack: insufficient length of w"
+	shortWH     = "lapack: insufficient length of wh"
+	shortWV     = "lapack: insufficient length of wv"
+	shortWi     = "lapack: insufficient length of wi"
+	shortWork   = "lapack: insufficient length of work"
+	shortWr     = "lapack: insufficient length of wr"
+	shortX      = "lapack: insufficient length of x"
+	shortY      = "lapack: insufficient length of y"
+	shortZ      = "lapack: insufficient length of z"
 
 	// Panic strings for bad leading dimensions of matrices.
 	badLdA    = "lapack: bad leading dimension of A"
+	badLdAF   = "lapack: bad leading dimension of AF"
 	badLdB    = "lapack: bad leading dimension of B"
 	badLdC    = "lapack: bad leading dimension of C"
 	badLdF    = "lapack: bad leading dimension of F"
 	badLdH    = "lapack: bad leading dimension of H"
+	badLdP    = "lapack: bad leading dimension of P"
 	badLdQ    = "lapack: bad leading dimension of Q"
+	badLdS    = "lapack: bad leading dimension of S"
 	badLdT    = "lapack: bad leading dimension of T"
 	badLdU    = "lapack: bad leading dimension of U"
 	badLdV    = "lapack: bad leading dimension of V"
@@ -13,7 +13,8 @@ package gonum
 //  4: The number of shifts.
 //  5: The minimum column dimension for blocking to be used.
 //  6: The crossover point for SVD (to use QR factorization or not).
-//  7: The number of processors.
+//  7: The number of processors. This is the number of workers set by
+//     SetWorkers, or 1 if it has not been set.
 //  8: The crossover point for multi-shift in QR and QZ methods for non-symmetric eigenvalue problems.
 //  9: Maximum size of the subproblems in divide-and-conquer algorithms.
 //  10: ieee NaN arithmetic can be trusted not to trap.
@@ -165,12 +166,12 @@ func (impl Implementation) Ilaenv(ispec int, name string, opts string, n1, n2, n
 				panic(badName)
 			case "TRF":
 				if sname {
-					if n4 <= 64 {
+					if n2 <= 64 {
 						return 1
 					}
 					return 32
 				}
-				if n4 <= 64 {
+				if n2 <= 64 {
 					return 1
 				}
 				return 32
@@ -189,6 +190,11 @@ func (impl Implementation) Ilaenv(ispec int, name string, opts string, n1, n2, n
 					return 64
 				}
 				return 64
+			case "SYL":
+				if sname {
+					return 32
+				}
+				return 32
 			}
 		case "LA":
 			switch c3 {
@@ -366,7 +372,10 @@ func (impl Implementation) Ilaenv(ispec int, name string, opts string, n1, n2, n
 		// Used by xGELSS and xGESVD
 		return int(float64(min(n1, n2)) * 1.6)
 	case 7:
-		// Not used
+		// Used by the parallel blocked factorizations, which are opt-in.
+		if nw := Workers(); nw > 0 {
+			return nw
+		}
 		return 1
 	case 8:
 		// Used by xHSEQR
@@ -4,14 +4,22 @@
 
 package gonum
 
-import "gonum.org/v1/gonum/lapack"
+import (
+	"math"
+
+	"gonum.org/v1/gonum/lapack"
+)
 
 // Implementation is the native Go implementation of LAPACK routines. It
 // is built on top of calls to the return of blas64.Implementation(), so while
 // this code is in pure Go, the underlying BLAS implementation may not be.
 type Implementation struct{}
 
-var _ lapack.Float64 = Implementation{}
+var (
+	_ lapack.Float32    = Implementation{}
+	_ lapack.Float64    = Implementation{}
+	_ lapack.Complex128 = Implementation{}
+)
 
 func min(a, b int) int {
 	if a < b {
@@ -34,6 +42,11 @@ func abs(a int) int {
 	return a
 }
 
+// cabs1 returns |real(z)|+|imag(z)|.
+func cabs1(z complex128) float64 {
+	return math.Abs(real(z)) + math.Abs(imag(z))
+}
+
 const (
 	// dlamchE is the machine epsilon. For IEEE this is 2^{-53}.
 	dlamchE = 1.0 / (1 << 53)
@@ -48,4 +61,11 @@ const (
 	// 1/dlamchS does not overflow, or also the smallest normal number.
 	// For IEEE this is 2^{-1022}.
 	dlamchS = 1.0 / (1 << 256) / (1 << 256) / (1 << 256) / (1 << 254)
+
+	// slamchE is the machine epsilon for float32. For IEEE this is 2^{-24}.
+	slamchE = 1.0 / (1 << 24)
+
+	// slamchS is the "safe minimum" for float32, the smallest normal
+	// float32. For IEEE this is 2^{-126}.
+	slamchS = 1.0 / (1 << 126)
 )
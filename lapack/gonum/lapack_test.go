@@ -16,6 +16,22 @@ func TestDbdsqr(t *testing.T) {
 	testlapack.DbdsqrTest(t, impl)
 }
 
+func TestDbdsdc(t *testing.T) {
+	testlapack.DbdsdcTest(t, impl)
+}
+
+func TestDgbcon(t *testing.T) {
+	testlapack.DgbconTest(t, impl)
+}
+
+func TestDgbtrf(t *testing.T) {
+	testlapack.DgbtrfTest(t, impl)
+}
+
+func TestDgbtrs(t *testing.T) {
+	testlapack.DgbtrsTest(t, impl)
+}
+
 func TestDhseqr(t *testing.T) {
 	testlapack.DhseqrTest(t, impl)
 }
@@ -40,6 +56,10 @@ func TestDgecon(t *testing.T) {
 	testlapack.DgeconTest(t, impl)
 }
 
+func TestDgeequ(t *testing.T) {
+	testlapack.DgeequTest(t, impl)
+}
+
 func TestDgeev(t *testing.T) {
 	testlapack.DgeevTest(t, impl)
 }
@@ -52,6 +72,14 @@ func TestDgehrd(t *testing.T) {
 	testlapack.DgehrdTest(t, impl)
 }
 
+func TestDgejsv(t *testing.T) {
+	testlapack.DgejsvTest(t, impl)
+}
+
+func TestDgejsvNetlib(t *testing.T) {
+	testlapack.DgejsvNetlibTest(t, impl)
+}
+
 func TestDgelqf(t *testing.T) {
 	testlapack.DgelqfTest(t, impl)
 }
@@ -68,6 +96,10 @@ func TestDgels(t *testing.T) {
 	testlapack.DgelsTest(t, impl)
 }
 
+func TestDgelss(t *testing.T) {
+	testlapack.DgelssTest(t, impl)
+}
+
 func TestDgerq2(t *testing.T) {
 	testlapack.Dgerq2Test(t, impl)
 }
@@ -88,11 +120,28 @@ func TestDgerqf(t *testing.T) {
 	testlapack.DgerqfTest(t, impl)
 }
 
+func TestDgesdd(t *testing.T) {
+	const tol = 1e-13
+	testlapack.DgesddTest(t, impl, tol)
+}
+
 func TestDgesvd(t *testing.T) {
 	const tol = 1e-13
 	testlapack.DgesvdTest(t, impl, tol)
 }
 
+func TestDgesvj(t *testing.T) {
+	testlapack.DgesvjTest(t, impl)
+}
+
+func TestDgesvjNetlib(t *testing.T) {
+	testlapack.DgesvjNetlibTest(t, impl)
+}
+
+func TestDgesvx(t *testing.T) {
+	testlapack.DgesvxTest(t, impl)
+}
+
 func TestDgetri(t *testing.T) {
 	testlapack.DgetriTest(t, impl)
 }
@@ -109,6 +158,14 @@ func TestDgetrs(t *testing.T) {
 	testlapack.DgetrsTest(t, impl)
 }
 
+func TestDggev(t *testing.T) {
+	testlapack.DggevTest(t, impl)
+}
+
+func TestDgghrd(t *testing.T) {
+	testlapack.DgghrdTest(t, impl)
+}
+
 func TestDggsvd3(t *testing.T) {
 	testlapack.Dggsvd3Test(t, impl)
 }
@@ -117,6 +174,14 @@ func TestDggsvp3(t *testing.T) {
 	testlapack.Dggsvp3Test(t, impl)
 }
 
+func TestDgtsv(t *testing.T) {
+	testlapack.DgtsvTest(t, impl)
+}
+
+func TestDhgeqz(t *testing.T) {
+	testlapack.DhgeqzTest(t, impl)
+}
+
 func TestDlabrd(t *testing.T) {
 	testlapack.DlabrdTest(t, impl)
 }
@@ -137,6 +202,10 @@ func TestDlaev2(t *testing.T) {
 	testlapack.Dlaev2Test(t, impl)
 }
 
+func TestDlaed4(t *testing.T) {
+	testlapack.Dlaed4Test(t, impl)
+}
+
 func TestDlaexc(t *testing.T) {
 	testlapack.DlaexcTest(t, impl)
 }
@@ -157,10 +226,22 @@ func TestDlaln2(t *testing.T) {
 	testlapack.Dlaln2Test(t, impl)
 }
 
+func TestDlangb(t *testing.T) {
+	testlapack.DlangbTest(t, impl)
+}
+
 func TestDlange(t *testing.T) {
 	testlapack.DlangeTest(t, impl)
 }
 
+func TestDlansb(t *testing.T) {
+	testlapack.DlansbTest(t, impl)
+}
+
+func TestDlantb(t *testing.T) {
+	testlapack.DlantbTest(t, impl)
+}
+
 func TestDlapy2(t *testing.T) {
 	testlapack.Dlapy2Test(t, impl)
 }
@@ -281,6 +362,10 @@ func TestDlasq5(t *testing.T) {
 	testlapack.Dlasq5Test(t, impl)
 }
 
+func TestDlasd4(t *testing.T) {
+	testlapack.Dlasd4Test(t, impl)
+}
+
 func TestDlasr(t *testing.T) {
 	testlapack.DlasrTest(t, impl)
 }
@@ -369,10 +454,22 @@ func TestDorm2r(t *testing.T) {
 	testlapack.Dorm2rTest(t, impl)
 }
 
+func TestDpbcon(t *testing.T) {
+	testlapack.DpbconTest(t, impl)
+}
+
 func TestDpbtf2(t *testing.T) {
 	testlapack.Dpbtf2Test(t, impl)
 }
 
+func TestDpbtrf(t *testing.T) {
+	testlapack.DpbtrfTest(t, impl)
+}
+
+func TestDpbtrs(t *testing.T) {
+	testlapack.DpbtrsTest(t, impl)
+}
+
 func TestDpocon(t *testing.T) {
 	testlapack.DpoconTest(t, impl)
 }
@@ -393,10 +490,34 @@ func TestDpotrs(t *testing.T) {
 	testlapack.DpotrsTest(t, impl)
 }
 
+func TestDposvx(t *testing.T) {
+	testlapack.DposvxTest(t, impl)
+}
+
+func TestDpstf2(t *testing.T) {
+	testlapack.Dpstf2Test(t, impl)
+}
+
+func TestDpstrf(t *testing.T) {
+	testlapack.DpstrfTest(t, impl)
+}
+
+func TestDptsv(t *testing.T) {
+	testlapack.DptsvTest(t, impl)
+}
+
 func TestDrscl(t *testing.T) {
 	testlapack.DrsclTest(t, impl)
 }
 
+func TestDstedc(t *testing.T) {
+	testlapack.DstedcTest(t, impl)
+}
+
+func TestDstemr(t *testing.T) {
+	testlapack.DstemrTest(t, impl)
+}
+
 func TestDsteqr(t *testing.T) {
 	testlapack.DsteqrTest(t, impl)
 }
@@ -405,18 +526,62 @@ func TestDsterf(t *testing.T) {
 	testlapack.DsterfTest(t, impl)
 }
 
+func TestDsycon(t *testing.T) {
+	testlapack.DsyconTest(t, impl)
+}
+
 func TestDsyev(t *testing.T) {
 	testlapack.DsyevTest(t, impl)
 }
 
+func TestDsyevd(t *testing.T) {
+	testlapack.DsyevdTest(t, impl)
+}
+
+func TestDsyevr(t *testing.T) {
+	testlapack.DsyevrTest(t, impl)
+}
+
+func TestDsygs2(t *testing.T) {
+	testlapack.Dsygs2Test(t, impl)
+}
+
+func TestDsygst(t *testing.T) {
+	testlapack.DsygstTest(t, impl)
+}
+
 func TestDsytd2(t *testing.T) {
 	testlapack.Dsytd2Test(t, impl)
 }
 
+func TestDsytf2(t *testing.T) {
+	testlapack.Dsytf2Test(t, impl)
+}
+
 func TestDsytrd(t *testing.T) {
 	testlapack.DsytrdTest(t, impl)
 }
 
+func TestDsytrf(t *testing.T) {
+	testlapack.DsytrfTest(t, impl)
+}
+
+func TestDsytrs(t *testing.T) {
+	testlapack.DsytrsTest(t, impl)
+}
+
+func TestDtbcon(t *testing.T) {
+	testlapack.DtbconTest(t, impl)
+}
+
+func TestDtbtrs(t *testing.T) {
+	testlapack.DtbtrsTest(t, impl)
+}
+
+func TestDtgevc(t *testing.T) {
+	testlapack.DtgevcTest(t, impl)
+}
+
 func TestDtgsja(t *testing.T) {
 	testlapack.DtgsjaTest(t, impl)
 }
@@ -433,6 +598,10 @@ func TestDtrexc(t *testing.T) {
 	testlapack.DtrexcTest(t, impl)
 }
 
+func TestDtrsyl(t *testing.T) {
+	testlapack.DtrsylTest(t, impl)
+}
+
 func TestDtrti2(t *testing.T) {
 	testlapack.Dtrti2Test(t, impl)
 }
@@ -448,3 +617,63 @@ func TestIladlc(t *testing.T) {
 func TestIladlr(t *testing.T) {
 	testlapack.IladlrTest(t, impl)
 }
+
+func TestZgecon(t *testing.T) {
+	testlapack.ZgeconTest(t, impl)
+}
+
+func TestZgeqrf(t *testing.T) {
+	testlapack.ZgeqrfTest(t, impl)
+}
+
+func TestZgesvd(t *testing.T) {
+	testlapack.ZgesvdTest(t, impl)
+}
+
+func TestZgetrf(t *testing.T) {
+	testlapack.ZgetrfTest(t, impl)
+}
+
+func TestZgetrs(t *testing.T) {
+	testlapack.ZgetrsTest(t, impl)
+}
+
+func TestZlatrs(t *testing.T) {
+	testlapack.ZlatrsTest(t, impl)
+}
+
+func TestZpotrf(t *testing.T) {
+	testlapack.ZpotrfTest(t, impl)
+}
+
+func TestZpotrs(t *testing.T) {
+	testlapack.ZpotrsTest(t, impl)
+}
+
+func TestZunmqr(t *testing.T) {
+	testlapack.ZunmqrTest(t, impl)
+}
+
+func TestSgeqrf(t *testing.T) {
+	testlapack.SgeqrfTest(t, impl)
+}
+
+func TestSgetrf(t *testing.T) {
+	testlapack.SgetrfTest(t, impl)
+}
+
+func TestSgetrs(t *testing.T) {
+	testlapack.SgetrsTest(t, impl)
+}
+
+func TestSormqr(t *testing.T) {
+	testlapack.SormqrTest(t, impl)
+}
+
+func TestSpotrf(t *testing.T) {
+	testlapack.SpotrfTest(t, impl)
+}
+
+func TestSpotrs(t *testing.T) {
+	testlapack.SpotrsTest(t, impl)
+}
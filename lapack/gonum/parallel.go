@@ -0,0 +1,80 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// workers is the maximum number of goroutines used by the parallel variants
+// of the blocked factorizations. A value less than 1 means that it has not
+// been set.
+var workers int64
+
+// SetWorkers sets the maximum number of goroutines that are used by the
+// parallel variants of the blocked factorizations in this package, Dgetrf,
+// Dpotrf and Dgeqrf. If n is less than 1, the setting is cleared and the
+// factorizations are serial, which is the default. The number of goroutines
+// used by the BLAS implementation is configured separately.
+//
+// SetWorkers returns the previous setting, so that
+//  defer gonum.SetWorkers(gonum.SetWorkers(n))
+// temporarily changes the number of workers.
+func SetWorkers(n int) int {
+	if n < 1 {
+		n = 0
+	}
+	return int(atomic.SwapInt64(&workers, int64(n)))
+}
+
+// Workers returns the number of workers set by SetWorkers, or zero if it has
+// not been set.
+func Workers() int {
+	return int(atomic.LoadInt64(&workers))
+}
+
+// minParBlocks is the minimum number of blocks that a matrix must span for the
+// parallel variants of the blocked factorizations to be used.
+const minParBlocks = 4
+
+// parallelBlocks partitions the range [start, end) into consecutive blocks of
+// nb indices, the last of which may be shorter, and calls f for each block.
+// The blocks are processed concurrently by at most nw goroutines, so f must
+// be safe to call concurrently for disjoint blocks. If nw is less than 2 or
+// the range holds at most one block, f is called once for the whole range in
+// the calling goroutine.
+//
+// f should only call serial kernels such as Dtrsm and Dsyrk. Work that is done
+// by Dgemm, which is parallel itself, should be issued from the calling
+// goroutine so that the goroutines are not nested.
+func parallelBlocks(nw, start, end, nb int, f func(j0, j1 int)) {
+	nblocks := (end - start + nb - 1) / nb
+	if nw < 2 || nblocks < 2 {
+		f(start, end)
+		return
+	}
+	nw = min(nw, nblocks)
+
+	// The blocks are handed out dynamically because the amount of work
+	// per block is not uniform in the triangular updates.
+	var next int64
+	var wg sync.WaitGroup
+	wg.Add(nw)
+	for w := 0; w < nw; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				b := int(atomic.AddInt64(&next, 1)) - 1
+				if b >= nblocks {
+					return
+				}
+				j0 := start + b*nb
+				f(j0, min(j0+nb, end))
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,162 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	blasgonum "gonum.org/v1/gonum/blas/gonum"
+	"gonum.org/v1/gonum/lapack/testlapack"
+)
+
+func TestParallelFactorizations(t *testing.T) {
+	defer SetWorkers(SetWorkers(0))
+	if got := impl.Ilaenv(7, "DGETRF", " ", 100, 100, -1, -1); got != 1 {
+		t.Errorf("unexpected default number of workers: got %d, want 1", got)
+	}
+	for _, nw := range []int{1, 2, 5} {
+		t.Run(fmt.Sprintf("workers=%d", nw), func(t *testing.T) {
+			defer SetWorkers(SetWorkers(nw))
+			if got := impl.Ilaenv(7, "DGETRF", " ", 100, 100, -1, -1); got != nw {
+				t.Errorf("unexpected number of workers: got %d, want %d", got, nw)
+			}
+			testlapack.DgetrfTest(t, impl)
+			testlapack.DpotrfTest(t, impl)
+			testlapack.DgeqrfTest(t, impl)
+		})
+	}
+}
+
+func TestParallelDgeqrf(t *testing.T) {
+	defer SetWorkers(SetWorkers(0))
+	rnd := rand.New(rand.NewSource(1))
+	for _, dims := range [][2]int{{400, 300}, {300, 400}, {500, 500}} {
+		m, n := dims[0], dims[1]
+		a := make([]float64, m*n)
+		for i := range a {
+			a[i] = rnd.NormFloat64()
+		}
+		k := min(m, n)
+		work := make([]float64, 1)
+		impl.Dgeqrf(m, n, a, n, nil, work, -1)
+		work = make([]float64, int(work[0]))
+
+		want := make([]float64, len(a))
+		copy(want, a)
+		wantTau := make([]float64, k)
+		SetWorkers(0)
+		impl.Dgeqrf(m, n, want, n, wantTau, work, len(work))
+
+		// The concurrent application of the block reflectors performs
+		// the same floating point operations as Dlarfb, so the results
+		// must be identical.
+		for _, nw := range []int{2, 5} {
+			got := make([]float64, len(a))
+			copy(got, a)
+			gotTau := make([]float64, k)
+			SetWorkers(nw)
+			impl.Dgeqrf(m, n, got, n, gotTau, work, len(work))
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("m=%d,n=%d,workers=%d: unexpected factorization at %d: got %v, want %v", m, n, nw, i, got[i], want[i])
+					break
+				}
+			}
+			for i := range wantTau {
+				if gotTau[i] != wantTau[i] {
+					t.Errorf("m=%d,n=%d,workers=%d: unexpected tau at %d: got %v, want %v", m, n, nw, i, gotTau[i], wantTau[i])
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestParallelBlocks(t *testing.T) {
+	for _, test := range []struct {
+		nw, start, end, nb int
+	}{
+		{1, 0, 10, 3},
+		{4, 0, 10, 3},
+		{4, 5, 6, 3},
+		{3, 2, 100, 7},
+		{16, 0, 64, 64},
+		{16, 0, 65, 64},
+	} {
+		seen := make([]int32, test.end)
+		parallelBlocks(test.nw, test.start, test.end, test.nb, func(j0, j1 int) {
+			for j := j0; j < j1; j++ {
+				seen[j]++
+			}
+		})
+		for j := range seen {
+			want := int32(0)
+			if j >= test.start {
+				want = 1
+			}
+			if seen[j] != want {
+				t.Errorf("nw=%d,start=%d,end=%d,nb=%d: index %d visited %d times, want %d",
+					test.nw, test.start, test.end, test.nb, j, seen[j], want)
+			}
+		}
+	}
+}
+
+func BenchmarkParallelFactorizations(b *testing.B) {
+	// A worker count of zero is the default setting, with which the
+	// factorizations are serial apart from their calls to Dgemm.
+	workers := []int{1, 0}
+	if nw := runtime.GOMAXPROCS(0); nw > 1 {
+		workers = append(workers, nw)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{500, 5000} {
+		// Construct a symmetric positive definite matrix so that the same
+		// matrix can be used by all of the factorizations.
+		a := make([]float64, n*n)
+		for i := range a {
+			a[i] = rnd.NormFloat64()
+		}
+		spd := make([]float64, n*n)
+		blasgonum.Implementation{}.Dsyrk(blas.Upper, blas.Trans, n, n, 1, a, n, 0, spd, n)
+		for i := 0; i < n; i++ {
+			spd[i*n+i] += float64(n)
+			for j := i + 1; j < n; j++ {
+				spd[j*n+i] = spd[i*n+j]
+			}
+		}
+		ipiv := make([]int, n)
+		tau := make([]float64, n)
+		work := make([]float64, 1)
+		impl.Dgeqrf(n, n, a, n, tau, work, -1)
+		work = make([]float64, int(work[0]))
+
+		for _, nw := range workers {
+			for _, bm := range []struct {
+				name string
+				f    func()
+			}{
+				{"Dpotrf", func() { impl.Dpotrf(blas.Upper, n, a, n) }},
+				{"Dgetrf", func() { impl.Dgetrf(n, n, a, n, ipiv) }},
+				{"Dgeqrf", func() { impl.Dgeqrf(n, n, a, n, tau, work, len(work)) }},
+			} {
+				b.Run(fmt.Sprintf("%s/n=%d/workers=%d", bm.name, n, nw), func(b *testing.B) {
+					defer SetWorkers(SetWorkers(nw))
+					for i := 0; i < b.N; i++ {
+						b.StopTimer()
+						copy(a, spd)
+						b.StartTimer()
+						bm.f()
+					}
+				})
+			}
+		}
+	}
+}
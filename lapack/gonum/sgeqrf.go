@@ -0,0 +1,52 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Sgeqrf computes the QR factorization of the m×n matrix A. See the
+// documentation for Sgeqr2 for a description of the parameters at entry and
+// exit.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// The length of work must be at least max(1, lwork) and lwork must be -1
+// or at least n, otherwise this function will panic. If lwork == -1, instead
+// of performing Sgeqrf, the optimal work length will be stored into work[0].
+//
+// tau must have length at least min(m,n), and this function will panic otherwise.
+func (impl Implementation) Sgeqrf(m, n int, a []float32, lda int, tau, work []float32, lwork int) {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case lwork < max(1, n) && lwork != -1:
+		panic(badLWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+
+	if lwork == -1 {
+		work[0] = float32(max(1, n))
+		return
+	}
+
+	// Quick return if possible.
+	k := min(m, n)
+	if k == 0 {
+		work[0] = 1
+		return
+	}
+
+	if len(a) < (m-1)*lda+n {
+		panic(shortA)
+	}
+	if len(tau) < k {
+		panic(shortTau)
+	}
+
+	impl.Sgeqr2(m, n, a, lda, tau, work)
+	work[0] = float32(n)
+}
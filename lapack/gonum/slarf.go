@@ -0,0 +1,75 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+// Slarf applies an elementary reflector to a general rectangular matrix c.
+// This computes
+//  c = h * c if side == Left
+//  c = c * h if side == right
+// where
+//  h = 1 - tau * v * v^T
+// and c is an m * n matrix.
+//
+// work is temporary storage of length at least n if side == Left and at least
+// m if side == Right. This function will panic if this length requirement is not met.
+//
+// Slarf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Slarf(side blas.Side, m, n int, v []float32, incv int, tau float32, c []float32, ldc int, work []float32) {
+	switch {
+	case side != blas.Left && side != blas.Right:
+		panic(badSide)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case incv == 0:
+		panic(zeroIncV)
+	case ldc < max(1, n):
+		panic(badLdC)
+	}
+
+	if m == 0 || n == 0 {
+		return
+	}
+
+	applyleft := side == blas.Left
+	lenV := n
+	if applyleft {
+		lenV = m
+	}
+
+	switch {
+	case len(v) < 1+(lenV-1)*abs(incv):
+		panic(shortV)
+	case len(c) < (m-1)*ldc+n:
+		panic(shortC)
+	case (applyleft && len(work) < n) || (!applyleft && len(work) < m):
+		panic(shortWork)
+	}
+
+	if tau == 0 {
+		return
+	}
+
+	bi := blas32.Implementation()
+	if applyleft {
+		// Form H * C.
+		// w := C^T * v
+		bi.Sgemv(blas.Trans, m, n, 1, c, ldc, v, incv, 0, work, 1)
+		// C := C - tau * v * w^T
+		bi.Sger(m, n, -tau, v, incv, work, 1, c, ldc)
+		return
+	}
+	// Form C * H.
+	// w := C * v
+	bi.Sgemv(blas.NoTrans, m, n, 1, c, ldc, v, incv, 0, work, 1)
+	// C := C - tau * w * v^T
+	bi.Sger(m, n, -tau, work, 1, v, incv, c, ldc)
+}
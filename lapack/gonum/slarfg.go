@@ -0,0 +1,77 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+// Slarfg generates an elementary reflector for a Householder matrix. It creates
+// a real elementary reflector of order n such that
+//  H * (alpha) = (beta)
+//      (    x)   (   0)
+//  H^T * H = I
+// H is represented in the form
+//  H = 1 - tau * (1; v) * (1 v^T)
+// where tau is a real scalar.
+//
+// On entry, x contains the vector x, on exit it contains v.
+//
+// Slarfg is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Slarfg(n int, alpha float32, x []float32, incX int) (beta, tau float32) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case incX <= 0:
+		panic(badIncX)
+	}
+
+	if n <= 1 {
+		return alpha, 0
+	}
+
+	if len(x) < 1+(n-2)*abs(incX) {
+		panic(shortX)
+	}
+
+	bi := blas32.Implementation()
+
+	xnorm := bi.Snrm2(n-1, x, incX)
+	if xnorm == 0 {
+		return alpha, 0
+	}
+	beta = -slapy2sign(alpha, xnorm)
+	const safmin = slamchS / slamchE
+	knt := 0
+	if math.Abs(float64(beta)) < safmin {
+		// xnorm and beta may be inaccurate, scale x and recompute.
+		const rsafmn = 1 / safmin
+		for {
+			knt++
+			bi.Sscal(n-1, rsafmn, x, incX)
+			beta *= rsafmn
+			alpha *= rsafmn
+			if math.Abs(float64(beta)) >= safmin {
+				break
+			}
+		}
+		xnorm = bi.Snrm2(n-1, x, incX)
+		beta = -slapy2sign(alpha, xnorm)
+	}
+	tau = (beta - alpha) / beta
+	bi.Sscal(n-1, 1/(alpha-beta), x, incX)
+	for j := 0; j < knt; j++ {
+		beta *= safmin
+	}
+	return beta, tau
+}
+
+// slapy2sign returns sqrt(alpha^2 + xnorm^2) with the sign of alpha. The
+// computation is performed in float64 to avoid unnecessary overflow.
+func slapy2sign(alpha, xnorm float32) float32 {
+	return float32(math.Copysign(math.Hypot(float64(alpha), float64(xnorm)), float64(alpha)))
+}
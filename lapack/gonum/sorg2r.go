@@ -0,0 +1,75 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+// Sorg2r generates an m×n matrix Q with orthonormal columns defined by the
+// product of elementary reflectors as computed by Sgeqrf.
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// len(tau) >= k, 0 <= k <= n, 0 <= n <= m, len(work) >= n.
+// Sorg2r will panic if these conditions are not met.
+//
+// Sorg2r is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Sorg2r(m, n, k int, a []float32, lda int, tau []float32, work []float32) {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case n > m:
+		panic(nGTM)
+	case k < 0:
+		panic(kLT0)
+	case k > n:
+		panic(kGTN)
+	case lda < max(1, n):
+		panic(badLdA)
+	}
+
+	if n == 0 {
+		return
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case len(tau) < k:
+		panic(shortTau)
+	case len(work) < n:
+		panic(shortWork)
+	}
+
+	bi := blas32.Implementation()
+
+	// Initialize columns k+1:n to columns of the unit matrix.
+	for l := 0; l < m; l++ {
+		for j := k; j < n; j++ {
+			a[l*lda+j] = 0
+		}
+	}
+	for j := k; j < n; j++ {
+		a[j*lda+j] = 1
+	}
+	for i := k - 1; i >= 0; i-- {
+		for i := range work {
+			work[i] = 0
+		}
+		if i < n-1 {
+			a[i*lda+i] = 1
+			impl.Slarf(blas.Left, m-i, n-i-1, a[i*lda+i:], lda, tau[i], a[i*lda+i+1:], lda, work)
+		}
+		if i < m-1 {
+			bi.Sscal(m-i-1, -tau[i], a[(i+1)*lda+i:], lda)
+		}
+		a[i*lda+i] = 1 - tau[i]
+		for l := 0; l < i; l++ {
+			a[l*lda+i] = 0
+		}
+	}
+}
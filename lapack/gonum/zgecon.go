@@ -0,0 +1,90 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Zgecon estimates the reciprocal of the condition number of the complex n×n
+// matrix A given the LU decomposition of the matrix. The condition number
+// computed may be based on the 1-norm or the ∞-norm.
+//
+// The slice a contains the result of the LU decomposition of A as computed by Zgetrf.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Zgecon will panic otherwise.
+//
+// rwork is a temporary data slice of length at least 2*n and Zgecon will panic otherwise.
+func (impl Implementation) Zgecon(norm lapack.MatrixNorm, n int, a []complex128, lda int, anorm float64, work []complex128, rwork []float64) float64 {
+	switch {
+	case norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum:
+		panic(badNorm)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(work) < 2*n:
+		panic(shortWork)
+	case len(rwork) < 2*n:
+		panic(shortRWork)
+	}
+
+	// Quick return if possible.
+	if anorm == 0 {
+		return 0
+	}
+
+	bi := cblas128.Implementation()
+	var rcond, ainvnm float64
+	var kase int
+	var normin bool
+	isave := new([3]int)
+	onenrm := norm == lapack.MaxColumnSum
+	smlnum := dlamchS
+	kase1 := 2
+	if onenrm {
+		kase1 = 1
+	}
+	for {
+		ainvnm, kase = impl.Zlacn2(n, work[n:], work, ainvnm, kase, isave)
+		if kase == 0 {
+			if ainvnm != 0 {
+				rcond = (1 / ainvnm) / anorm
+			}
+			return rcond
+		}
+		var sl, su float64
+		if kase == kase1 {
+			sl = impl.Zlatrs(blas.Lower, blas.NoTrans, blas.Unit, normin, n, a, lda, work, rwork)
+			su = impl.Zlatrs(blas.Upper, blas.NoTrans, blas.NonUnit, normin, n, a, lda, work, rwork[n:])
+		} else {
+			su = impl.Zlatrs(blas.Upper, blas.ConjTrans, blas.NonUnit, normin, n, a, lda, work, rwork[n:])
+			sl = impl.Zlatrs(blas.Lower, blas.ConjTrans, blas.Unit, normin, n, a, lda, work, rwork)
+		}
+		scale := sl * su
+		normin = true
+		if scale != 1 {
+			ix := bi.Izamax(n, work, 1)
+			if scale == 0 || scale < cabs1(work[ix])*smlnum {
+				return rcond
+			}
+			bi.Zdscal(n, 1/scale, work, 1)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/lapack"
+)
+
+const noZSVDO = "zgesvd: not coded for overwrite"
+
+// Zgesvd computes the singular value decomposition of the complex input
+// matrix A.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^H
+// where Sigma is an m×n diagonal matrix containing the real non-negative
+// singular values of A, U is an m×m unitary matrix and V is an n×n unitary
+// matrix. The first min(m,n) columns of U and V are the left and right
+// singular vectors of A respectively.
+//
+// jobU and jobVT are options for computing the singular vectors. The behavior
+// is as follows
+//  jobU == lapack.SVDAll       All m columns of U are returned in u
+//  jobU == lapack.SVDStore     The first min(m,n) columns are returned in u
+//  jobU == lapack.SVDNone      The columns of U are not computed.
+// The behavior is the same for jobVT and the rows of V^H. Zgesvd does not
+// support lapack.SVDOverwrite and will panic if either job is equal to it.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Zgesvd
+// the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored column-wise. If
+// jobU == lapack.SVDAll, u is of size m×m. If jobU == lapack.SVDStore u is
+// of size m×min(m,n). If jobU == lapack.SVDNone, u is not used.
+//
+// vt contains the right singular vectors on exit, stored row-wise. If
+// jobVT == lapack.SVDAll, vt is of size n×n. If jobVT == lapack.SVDStore vt is
+// of size min(m,n)×n. If jobVT == lapack.SVDNone, vt is not used.
+//
+// A is first reduced to real bidiagonal form by Zgebd2 and the singular
+// values and vectors of the bidiagonal matrix are computed by Dbdsqr.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. With k = min(m,n), lwork must be at least 2*k + max(m,n), plus
+// k*k + m*k if the left singular vectors are computed, plus k*k + n*k or
+// k*k + n*n if the right singular vectors are computed with
+// lapack.SVDStore or lapack.SVDAll respectively. If lwork == -1, instead of
+// performing Zgesvd, the optimal work length will be stored into work[0].
+// Zgesvd will panic if the working memory has insufficient storage.
+//
+// rwork is real temporary storage of length at least 5*k, plus k*k for each
+// of U and V^H that is computed.
+//
+// Zgesvd returns whether the decomposition successfully completed.
+func (impl Implementation) Zgesvd(jobU, jobVT lapack.SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64) (ok bool) {
+	if jobU == lapack.SVDOverwrite || jobVT == lapack.SVDOverwrite {
+		panic(noZSVDO)
+	}
+
+	wantua := jobU == lapack.SVDAll
+	wantus := jobU == lapack.SVDStore
+	wantu := wantua || wantus
+	if !(wantu || jobU == lapack.SVDNone) {
+		panic(badSVDJob)
+	}
+
+	wantva := jobVT == lapack.SVDAll
+	wantvs := jobVT == lapack.SVDStore
+	wantvt := wantva || wantvs
+	if !(wantvt || jobVT == lapack.SVDNone) {
+		panic(badSVDJob)
+	}
+
+	minmn := min(m, n)
+	ucols := minmn
+	if wantua {
+		ucols = m
+	}
+	vtrows := minmn
+	if wantva {
+		vtrows = n
+	}
+	minwork := 1
+	lrwork := 5 * minmn
+	if minmn > 0 {
+		minwork = 2*minmn + max(m, n)
+		if wantu {
+			minwork += minmn*minmn + m*minmn
+			lrwork += minmn * minmn
+		}
+		if wantvt {
+			minwork += minmn*minmn + n*vtrows
+			lrwork += minmn * minmn
+		}
+	}
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	case ldu < 1, wantua && ldu < m, wantus && ldu < minmn:
+		panic(badLdU)
+	case ldvt < 1 || (wantvt && ldvt < n):
+		panic(badLdVT)
+	case lwork < minwork && lwork != -1:
+		panic(badLWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+
+	// Quick return if possible.
+	if minmn == 0 {
+		work[0] = 1
+		return true
+	}
+
+	if lwork == -1 {
+		work[0] = complex(float64(minwork), 0)
+		return true
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case len(s) < minmn:
+		panic(shortS)
+	case wantu && len(u) < (m-1)*ldu+ucols:
+		panic(shortU)
+	case wantvt && len(vt) < (vtrows-1)*ldvt+n:
+		panic(shortVT)
+	case len(rwork) < lrwork:
+		panic(shortRWork)
+	}
+
+	tauQ := work[:minmn]
+	tauP := work[minmn : 2*minmn]
+	wrk := work[2*minmn : 2*minmn+max(m, n)]
+	cwork := work[2*minmn+max(m, n):]
+	e := rwork[:minmn]
+	bdwork := rwork[minmn : 5*minmn]
+	rwrk := rwork[5*minmn:]
+
+	// Reduce A to real bidiagonal form B = Q^H * A * P.
+	impl.Zgebd2(m, n, a, lda, s, e, tauQ, tauP, wrk)
+
+	// The elementary reflectors defining Q are stored in the columns of A
+	// starting sq rows below the diagonal, and the conjugates of those
+	// defining P are stored in the rows of A starting sp columns right of
+	// the diagonal.
+	uplo := blas.Upper
+	sq, kq := 0, n
+	sp, kp := 1, n-1
+	if m < n {
+		uplo = blas.Lower
+		sq, kq = 1, m-1
+		sp, kp = 0, m
+	}
+
+	var ub, vtb []float64
+	var nru, ncvt int
+	if wantu {
+		// Generate Q in u.
+		for i := 0; i < m; i++ {
+			for j := 0; j < ucols; j++ {
+				u[i*ldu+j] = 0
+			}
+		}
+		if sq == 1 {
+			u[0] = 1
+		}
+		for i := 0; i < kq; i++ {
+			for r := i + sq + 1; r < m; r++ {
+				u[r*ldu+i+sq] = a[r*lda+i]
+			}
+		}
+		if sq < m {
+			impl.Zung2r(m-sq, ucols-sq, kq, u[sq*ldu+sq:], ldu, tauQ, wrk)
+		}
+
+		ub = rwrk[:minmn*minmn]
+		rwrk = rwrk[minmn*minmn:]
+		impl.Dlaset(blas.All, minmn, minmn, 0, 1, ub, minmn)
+		nru = minmn
+	}
+	var w []complex128
+	ldw := vtrows
+	if wantvt {
+		// Generate P in w.
+		w = cwork[:n*ldw]
+		cwork = cwork[n*ldw:]
+		for i := range w {
+			w[i] = 0
+		}
+		if sp == 1 {
+			w[0] = 1
+		}
+		for i := 0; i < kp; i++ {
+			for c := i + sp + 1; c < n; c++ {
+				w[c*ldw+i+sp] = cmplx.Conj(a[i*lda+c])
+			}
+		}
+		if sp < n {
+			impl.Zung2r(n-sp, vtrows-sp, kp, w[sp*ldw+sp:], ldw, tauP, wrk)
+		}
+
+		vtb = rwrk[:minmn*minmn]
+		impl.Dlaset(blas.All, minmn, minmn, 0, 1, vtb, minmn)
+		ncvt = minmn
+	}
+
+	// Compute the singular value decomposition of the real bidiagonal
+	// matrix B = Ub * S * VTb.
+	ok = impl.Dbdsqr(uplo, minmn, ncvt, nru, 0, s, e, vtb, minmn, ub, minmn, nil, 1, bdwork)
+	if !ok {
+		return false
+	}
+
+	bi := cblas128.Implementation()
+	if wantu {
+		// Form the first min(m,n) columns of U = Q * Ub.
+		cub := cwork[:minmn*minmn]
+		tmp := cwork[minmn*minmn : minmn*minmn+m*minmn]
+		for i, v := range ub {
+			cub[i] = complex(v, 0)
+		}
+		bi.Zgemm(blas.NoTrans, blas.NoTrans, m, minmn, minmn, 1, u, ldu, cub, minmn, 0, tmp, minmn)
+		for i := 0; i < m; i++ {
+			copy(u[i*ldu:i*ldu+minmn], tmp[i*minmn:(i+1)*minmn])
+		}
+	}
+	if wantvt {
+		// Form the first min(m,n) rows of V^H = VTb * P^H.
+		cvtb := cwork[:minmn*minmn]
+		for i, v := range vtb {
+			cvtb[i] = complex(v, 0)
+		}
+		bi.Zgemm(blas.NoTrans, blas.ConjTrans, minmn, n, minmn, 1, cvtb, minmn, w, ldw, 0, vt, ldvt)
+		// The remaining rows of V^H are the conjugated columns of P.
+		for i := minmn; i < vtrows; i++ {
+			for j := 0; j < n; j++ {
+				vt[i*ldvt+j] = cmplx.Conj(w[j*ldw+i])
+			}
+		}
+	}
+	work[0] = complex(float64(minwork), 0)
+	return true
+}
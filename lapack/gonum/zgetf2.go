@@ -0,0 +1,84 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zgetf2 computes the LU decomposition of the complex m×n matrix A.
+// The LU decomposition is a factorization of a into
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix, and
+// U is a (usually) non-unit upper triangular matrix. On exit, L and U are stored
+// in place into a.
+//
+// ipiv is a permutation vector. It indicates that row i of the matrix was
+// changed with ipiv[i]. ipiv must have length min(m,n), and Zgetf2 will panic
+// otherwise. ipiv is zero-indexed.
+//
+// Zgetf2 returns whether the matrix A is nonsingular. The LU decomposition will
+// be computed regardless of the singularity of A, but a division by zero
+// will occur if the false is returned and the result is used to solve a
+// system of equations.
+//
+// Zgetf2 is an internal routine. It is exported for testing purposes.
+func (Implementation) Zgetf2(m, n int, a []complex128, lda int, ipiv []int) (ok bool) {
+	mn := min(m, n)
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if mn == 0 {
+		return true
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case len(ipiv) != mn:
+		panic(badLenIpiv)
+	}
+
+	bi := cblas128.Implementation()
+
+	sfmin := dlamchS
+	ok = true
+	for j := 0; j < mn; j++ {
+		// Find a pivot and test for singularity.
+		jp := j + bi.Izamax(m-j, a[j*lda+j:], lda)
+		ipiv[j] = jp
+		if a[jp*lda+j] == 0 {
+			ok = false
+		} else {
+			// Swap the rows if necessary.
+			if jp != j {
+				bi.Zswap(n, a[j*lda:], 1, a[jp*lda:], 1)
+			}
+			if j < m-1 {
+				aj := a[j*lda+j]
+				if cmplx.Abs(aj) >= sfmin {
+					bi.Zscal(m-j-1, 1/aj, a[(j+1)*lda+j:], lda)
+				} else {
+					for i := j + 1; i < m; i++ {
+						a[i*lda+j] /= aj
+					}
+				}
+			}
+		}
+		if j < mn-1 {
+			bi.Zgeru(m-j-1, n-j-1, -1, a[(j+1)*lda+j:], lda, a[j*lda+j+1:], 1, a[(j+1)*lda+j+1:], lda)
+		}
+	}
+	return ok
+}
@@ -0,0 +1,94 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zlarfg generates a complex elementary reflector for a Householder matrix. It
+// creates a complex elementary reflector of order n such that
+//  H^H * (alpha) = (beta)
+//        (    x)   (   0)
+//  H^H * H = I
+// where beta is real. H is represented in the form
+//  H = 1 - tau * (1; v) * (1 v^H)
+// where tau is a complex scalar. Unlike the real case, H is not Hermitian in
+// general.
+//
+// On entry, x contains the vector x, on exit it contains v. beta is returned
+// as a complex value with zero imaginary part.
+//
+// Zlarfg is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Zlarfg(n int, alpha complex128, x []complex128, incX int) (beta, tau complex128) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case incX <= 0:
+		panic(badIncX)
+	}
+
+	if n == 0 {
+		return alpha, 0
+	}
+
+	if len(x) < 1+(n-2)*abs(incX) {
+		panic(shortX)
+	}
+
+	bi := cblas128.Implementation()
+
+	var xnorm float64
+	if n > 1 {
+		xnorm = bi.Dznrm2(n-1, x, incX)
+	}
+	alphr := real(alpha)
+	alphi := imag(alpha)
+	if xnorm == 0 && alphi == 0 {
+		// H is the identity.
+		return alpha, 0
+	}
+	b := -math.Copysign(dlapy3(alphr, alphi, xnorm), alphr)
+	safmin := dlamchS / dlamchE
+	knt := 0
+	if math.Abs(b) < safmin {
+		// xnorm and beta may be inaccurate, scale x and recompute.
+		rsafmn := 1 / safmin
+		for {
+			knt++
+			if n > 1 {
+				bi.Zdscal(n-1, rsafmn, x, incX)
+			}
+			b *= rsafmn
+			alphr *= rsafmn
+			alphi *= rsafmn
+			if math.Abs(b) >= safmin || knt == 20 {
+				break
+			}
+		}
+		if n > 1 {
+			xnorm = bi.Dznrm2(n-1, x, incX)
+		}
+		alpha = complex(alphr, alphi)
+		b = -math.Copysign(dlapy3(alphr, alphi, xnorm), alphr)
+	}
+	tau = complex((b-alphr)/b, -alphi/b)
+	if n > 1 {
+		bi.Zscal(n-1, 1/(alpha-complex(b, 0)), x, incX)
+	}
+	for j := 0; j < knt; j++ {
+		b *= safmin
+	}
+	return complex(b, 0), tau
+}
+
+// zlacgv conjugates the n elements of the vector x with increment incX.
+func zlacgv(n int, x []complex128, incX int) {
+	for i := 0; i < n; i++ {
+		x[i*incX] = complex(real(x[i*incX]), -imag(x[i*incX]))
+	}
+}
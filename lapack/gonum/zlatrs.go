@@ -0,0 +1,456 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zlatrs solves a complex triangular system of equations scaled to prevent
+// overflow. It solves
+//  A * x = scale * b    if trans == blas.NoTrans
+//  A^T * x = scale * b  if trans == blas.Trans
+//  A^H * x = scale * b  if trans == blas.ConjTrans
+// where the scale s is set for numeric stability.
+//
+// A is an n×n triangular matrix. On entry, the slice x contains the values of
+// b, and on exit it contains the solution vector x.
+//
+// If normin == true, cnorm is an input and cnorm[j] contains the norm of the
+// off-diagonal part of the j^th column of A. If trans == blas.NoTrans, cnorm[j]
+// must be greater than or equal to the infinity norm, and greater than or
+// equal to the one-norm otherwise. If normin == false, then cnorm is treated
+// as an output, and is set to contain the 1-norm of the off-diagonal part of
+// the j^th column of A, where the absolute value of an element z is computed
+// as |real(z)|+|imag(z)|.
+//
+// Zlatrs is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Zlatrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, normin bool, n int, a []complex128, lda int, x []complex128, cnorm []float64) (scale float64) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans:
+		panic(badTrans)
+	case diag != blas.Unit && diag != blas.NonUnit:
+		panic(badDiag)
+	case n < 0:
+		panic(nLT0)
+	case lda < max(1, n):
+		panic(badLdA)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1
+	}
+
+	switch {
+	case len(a) < (n-1)*lda+n:
+		panic(shortA)
+	case len(x) < n:
+		panic(shortX)
+	case len(cnorm) < n:
+		panic(shortCNorm)
+	}
+
+	upper := uplo == blas.Upper
+	nonUnit := diag == blas.NonUnit
+	conj := trans == blas.ConjTrans
+
+	smlnum := dlamchS / dlamchP
+	bignum := 1 / smlnum
+	scale = 1
+
+	bi := cblas128.Implementation()
+
+	if !normin {
+		if upper {
+			cnorm[0] = 0
+			for j := 1; j < n; j++ {
+				cnorm[j] = bi.Dzasum(j, a[j:], lda)
+			}
+		} else {
+			for j := 0; j < n-1; j++ {
+				cnorm[j] = bi.Dzasum(n-j-1, a[(j+1)*lda+j:], lda)
+			}
+			cnorm[n-1] = 0
+		}
+	}
+	// Scale the column norms by tscal if the maximum element in cnorm is
+	// greater than bignum/2.
+	bi64 := blas64.Implementation()
+	imax := bi64.Idamax(n, cnorm, 1)
+	tmax := cnorm[imax]
+	var tscal float64
+	switch {
+	case tmax <= bignum/2:
+		tscal = 1
+	case tmax <= math.MaxFloat64:
+		tscal = 0.5 / (smlnum * tmax)
+		bi64.Dscal(n, tscal, cnorm, 1)
+	default:
+		// At least one column norm of A overflows. Use the largest
+		// absolute value of the real and imaginary parts of the
+		// off-diagonal elements to compute tscal instead.
+		tmax = 0
+		for j := 0; j < n; j++ {
+			lo, hi := j+1, n
+			if upper {
+				lo, hi = 0, j
+			}
+			for i := lo; i < hi; i++ {
+				aij := a[i*lda+j]
+				tmax = math.Max(tmax, math.Max(math.Abs(real(aij)), math.Abs(imag(aij))))
+			}
+		}
+		if tmax > math.MaxFloat64 {
+			// At least one element of A is not finite. Rely on
+			// Ztrsv to propagate Inf and NaN.
+			bi.Ztrsv(uplo, trans, diag, n, a, lda, x, 1)
+			return scale
+		}
+		tscal = 1 / (smlnum * tmax)
+		for j := 0; j < n; j++ {
+			if cnorm[j] <= math.MaxFloat64 {
+				cnorm[j] *= tscal
+				continue
+			}
+			// Recompute the 1-norm of the column without
+			// overflowing in the summation.
+			cnorm[j] = 0
+			lo, hi := j+1, n
+			if upper {
+				lo, hi = 0, j
+			}
+			for i := lo; i < hi; i++ {
+				aij := a[i*lda+j]
+				cnorm[j] += tscal*math.Abs(real(aij)) + tscal*math.Abs(imag(aij))
+			}
+		}
+	}
+
+	// Compute a bound on the computed solution vector to see if bi.Ztrsv
+	// can be used.
+	var xmax float64
+	for _, v := range x[:n] {
+		xmax = math.Max(xmax, math.Abs(real(v)/2)+math.Abs(imag(v)/2))
+	}
+	xbnd := xmax
+	var grow float64
+	var jfirst, jlast, jinc int
+	if trans == blas.NoTrans {
+		if upper {
+			jfirst = n - 1
+			jlast = -1
+			jinc = -1
+		} else {
+			jfirst = 0
+			jlast = n
+			jinc = 1
+		}
+		// Compute the growth in A * x = b.
+		if tscal != 1 {
+			grow = 0
+			goto Solve
+		}
+		if nonUnit {
+			grow = 0.5 / math.Max(xbnd, smlnum)
+			xbnd = grow
+			for j := jfirst; j != jlast; j += jinc {
+				if grow <= smlnum {
+					goto Solve
+				}
+				tjj := cabs1(a[j*lda+j])
+				if tjj >= smlnum {
+					xbnd = math.Min(xbnd, math.Min(1, tjj)*grow)
+				} else {
+					xbnd = 0
+				}
+				if tjj+cnorm[j] >= smlnum {
+					grow *= tjj / (tjj + cnorm[j])
+				} else {
+					grow = 0
+				}
+			}
+			grow = xbnd
+		} else {
+			grow = math.Min(1, 0.5/math.Max(xbnd, smlnum))
+			for j := jfirst; j != jlast; j += jinc {
+				if grow <= smlnum {
+					goto Solve
+				}
+				grow *= 1 / (1 + cnorm[j])
+			}
+		}
+	} else {
+		if upper {
+			jfirst = 0
+			jlast = n
+			jinc = 1
+		} else {
+			jfirst = n - 1
+			jlast = -1
+			jinc = -1
+		}
+		// Compute the growth in A^T * x = b or A^H * x = b.
+		if tscal != 1 {
+			grow = 0
+			goto Solve
+		}
+		if nonUnit {
+			grow = 0.5 / math.Max(xbnd, smlnum)
+			xbnd = grow
+			for j := jfirst; j != jlast; j += jinc {
+				if grow <= smlnum {
+					goto Solve
+				}
+				xj := 1 + cnorm[j]
+				grow = math.Min(grow, xbnd/xj)
+				tjj := cabs1(a[j*lda+j])
+				if tjj >= smlnum {
+					if xj > tjj {
+						xbnd *= tjj / xj
+					}
+				} else {
+					xbnd = 0
+				}
+			}
+			grow = math.Min(grow, xbnd)
+		} else {
+			grow = math.Min(1, 0.5/math.Max(xbnd, smlnum))
+			for j := jfirst; j != jlast; j += jinc {
+				if grow <= smlnum {
+					goto Solve
+				}
+				grow /= 1 + cnorm[j]
+			}
+		}
+	}
+
+Solve:
+	if grow*tscal > smlnum {
+		// Use the Level 2 BLAS solve if the reciprocal of the bound on
+		// elements of X is not too small.
+		bi.Ztrsv(uplo, trans, diag, n, a, lda, x, 1)
+		if tscal != 1 {
+			bi64.Dscal(n, 1/tscal, cnorm, 1)
+		}
+		return scale
+	}
+
+	// Use a Level 1 BLAS solve, scaling intermediate results.
+	if xmax > bignum/2 {
+		// Scale X so that its components are less than or equal to
+		// bignum in absolute value.
+		scale = (bignum / 2) / xmax
+		bi.Zdscal(n, scale, x, 1)
+		xmax = bignum
+	} else {
+		xmax *= 2
+	}
+	if trans == blas.NoTrans {
+		for j := jfirst; j != jlast; j += jinc {
+			// Compute x[j] = b[j] / A[j,j], scaling x if necessary.
+			xj := cabs1(x[j])
+			var tjjs complex128
+			if nonUnit {
+				tjjs = a[j*lda+j] * complex(tscal, 0)
+			} else {
+				tjjs = complex(tscal, 0)
+				if tscal == 1 {
+					goto Skip1
+				}
+			}
+			if tjj := cabs1(tjjs); tjj > smlnum {
+				if tjj < 1 {
+					if xj > tjj*bignum {
+						rec := 1 / xj
+						bi.Zdscal(n, rec, x, 1)
+						scale *= rec
+						xmax *= rec
+					}
+				}
+				x[j] /= tjjs
+				xj = cabs1(x[j])
+			} else if tjj > 0 {
+				if xj > tjj*bignum {
+					// Scale x by (1/|x[j]|)*|A[j,j]|*bignum to avoid
+					// overflow when dividing by A[j,j].
+					rec := (tjj * bignum) / xj
+					if cnorm[j] > 1 {
+						// Scale by 1/cnorm[j] to avoid overflow when
+						// multiplying x[j] times column j.
+						rec /= cnorm[j]
+					}
+					bi.Zdscal(n, rec, x, 1)
+					scale *= rec
+					xmax *= rec
+				}
+				x[j] /= tjjs
+				xj = cabs1(x[j])
+			} else {
+				// A[j,j] == 0: set x to the j^th unit vector and
+				// scale to 0, and compute a solution to A*x = 0.
+				for i := 0; i < n; i++ {
+					x[i] = 0
+				}
+				x[j] = 1
+				xj = 1
+				scale = 0
+				xmax = 0
+			}
+		Skip1:
+			// Scale x if necessary to avoid overflow when adding a
+			// multiple of column j of A.
+			if xj > 1 {
+				rec := 1 / xj
+				if cnorm[j] > (bignum-xmax)*rec {
+					rec *= 0.5
+					bi.Zdscal(n, rec, x, 1)
+					scale *= rec
+				}
+			} else if xj*cnorm[j] > bignum-xmax {
+				bi.Zdscal(n, 0.5, x, 1)
+				scale *= 0.5
+			}
+			if upper {
+				if j > 0 {
+					bi.Zaxpy(j, -x[j]*complex(tscal, 0), a[j:], lda, x, 1)
+					i := bi.Izamax(j, x, 1)
+					xmax = cabs1(x[i])
+				}
+			} else {
+				if j < n-1 {
+					bi.Zaxpy(n-j-1, -x[j]*complex(tscal, 0), a[(j+1)*lda+j:], lda, x[j+1:], 1)
+					i := j + 1 + bi.Izamax(n-j-1, x[j+1:], 1)
+					xmax = cabs1(x[i])
+				}
+			}
+		}
+	} else {
+		// Solve A^T * x = b or A^H * x = b.
+		for j := jfirst; j != jlast; j += jinc {
+			// Compute x[j] = b[j] - sum A[k,j]*x[k] for k != j, using
+			// the conjugate of A if trans == blas.ConjTrans.
+			xj := cabs1(x[j])
+			uscal := complex(tscal, 0)
+			rec := 1 / math.Max(xmax, 1)
+			var tjjs complex128
+			if cnorm[j] > (bignum-xj)*rec {
+				// If x[j] could overflow, scale x by 1/(2*xmax).
+				rec *= 0.5
+				if nonUnit {
+					tjjs = a[j*lda+j] * complex(tscal, 0)
+					if conj {
+						tjjs = cmplx.Conj(tjjs)
+					}
+				} else {
+					tjjs = complex(tscal, 0)
+				}
+				if tjj := cabs1(tjjs); tjj > 1 {
+					// Divide by A[j,j] when scaling x if A[j,j] > 1.
+					rec = math.Min(1, rec*tjj)
+					uscal /= tjjs
+				}
+				if rec < 1 {
+					bi.Zdscal(n, rec, x, 1)
+					scale *= rec
+					xmax *= rec
+				}
+			}
+			var csumj complex128
+			if uscal == 1 {
+				// If the scaling needed for A in the dot product is 1,
+				// use the BLAS dot product.
+				switch {
+				case upper && conj:
+					csumj = bi.Zdotc(j, a[j:], lda, x, 1)
+				case upper:
+					csumj = bi.Zdotu(j, a[j:], lda, x, 1)
+				case j < n-1 && conj:
+					csumj = bi.Zdotc(n-j-1, a[(j+1)*lda+j:], lda, x[j+1:], 1)
+				case j < n-1:
+					csumj = bi.Zdotu(n-j-1, a[(j+1)*lda+j:], lda, x[j+1:], 1)
+				}
+			} else {
+				// Otherwise, use in-line code for the dot product.
+				lo, hi := j+1, n
+				if upper {
+					lo, hi = 0, j
+				}
+				for i := lo; i < hi; i++ {
+					aij := a[i*lda+j]
+					if conj {
+						aij = cmplx.Conj(aij)
+					}
+					csumj += (aij * uscal) * x[i]
+				}
+			}
+			if uscal == complex(tscal, 0) {
+				// Compute x[j] = (x[j] - csumj) / A[j,j] if 1/A[j,j]
+				// was not used to scale the dot product.
+				x[j] -= csumj
+				xj = cabs1(x[j])
+				if nonUnit {
+					tjjs = a[j*lda+j] * complex(tscal, 0)
+					if conj {
+						tjjs = cmplx.Conj(tjjs)
+					}
+				} else {
+					tjjs = complex(tscal, 0)
+					if tscal == 1 {
+						goto Skip2
+					}
+				}
+				// Compute x[j] = x[j] / A[j,j], scaling if necessary.
+				if tjj := cabs1(tjjs); tjj > smlnum {
+					if tjj < 1 {
+						if xj > tjj*bignum {
+							rec = 1 / xj
+							bi.Zdscal(n, rec, x, 1)
+							scale *= rec
+							xmax *= rec
+						}
+					}
+					x[j] /= tjjs
+				} else if tjj > 0 {
+					if xj > tjj*bignum {
+						rec = (tjj * bignum) / xj
+						bi.Zdscal(n, rec, x, 1)
+						scale *= rec
+						xmax *= rec
+					}
+					x[j] /= tjjs
+				} else {
+					// A[j,j] == 0: set x to the j^th unit vector and
+					// scale to 0, and compute a solution to A^T*x = 0
+					// or A^H*x = 0.
+					for i := 0; i < n; i++ {
+						x[i] = 0
+					}
+					x[j] = 1
+					scale = 0
+					xmax = 0
+				}
+			} else {
+				// Compute x[j] = x[j] / A[j,j] - csumj if the dot
+				// product has already been divided by 1/A[j,j].
+				x[j] = x[j]/tjjs - csumj
+			}
+		Skip2:
+			xmax = math.Max(xmax, cabs1(x[j]))
+		}
+	}
+	scale /= tscal
+	if tscal != 1 {
+		bi64.Dscal(n, 1/tscal, cnorm, 1)
+	}
+	return scale
+}
@@ -0,0 +1,59 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Zungqr generates an m×n complex matrix Q with orthonormal columns defined
+// by the product of elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// as computed by Zgeqrf.
+//
+// The length of tau must be at least k. It also must be that 0 <= k <= n and
+// 0 <= n <= m.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= n. If lwork == -1, instead of computing Zungqr the optimal
+// work length is stored into work[0].
+//
+// Zungqr will panic if the conditions on input values are not met.
+func (impl Implementation) Zungqr(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int) {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case n > m:
+		panic(nGTM)
+	case k < 0:
+		panic(kLT0)
+	case k > n:
+		panic(kGTN)
+	case lda < max(1, n) && lwork != -1:
+		panic(badLdA)
+	case lwork < max(1, n) && lwork != -1:
+		panic(badLWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+
+	if n == 0 {
+		work[0] = 1
+		return
+	}
+
+	if lwork == -1 {
+		work[0] = complex(float64(n), 0)
+		return
+	}
+
+	switch {
+	case len(a) < (m-1)*lda+n:
+		panic(shortA)
+	case len(tau) < k:
+		panic(shortTau)
+	}
+
+	impl.Zung2r(m, n, k, a, lda, tau, work)
+	work[0] = complex(float64(n), 0)
+}
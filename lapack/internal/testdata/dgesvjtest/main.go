@@ -0,0 +1,137 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This program generates test data for Dgesvj. Test cases are stored in
+// gzip-compressed JSON file testlapack/testdata/dgesvjdata.json.gz which is
+// read during testing by testlapack/dgesvj.go.
+//
+// This program uses cgo to call Fortran version of DGESVJ, which needs the
+// reference LAPACK sources of DGESVJ, DGSVJ0, DGSVJ1 and the routines they
+// call in the netlib directory. Therefore, matrices passed to the Fortran
+// routine are in column-major format but are written into the output file in
+// row-major format.
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/lapack/internal/testdata/netlib"
+)
+
+type Dgesvjtest struct {
+	M, N int
+	A    []float64
+
+	SWant []float64
+	UWant []float64
+	VWant []float64
+}
+
+func main() {
+	file, err := os.Create(filepath.FromSlash("../../../testlapack/testdata/dgesvjdata.json.gz"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	w := gzip.NewWriter(file)
+
+	rnd := rand.New(rand.NewSource(1))
+
+	var tests []Dgesvjtest
+	for _, mn := range []struct{ m, n int }{
+		{1, 1}, {3, 2}, {5, 5}, {10, 4}, {10, 10}, {20, 8}, {30, 30},
+	} {
+		m, n := mn.m, mn.n
+		for grading := 0; grading < 3; grading++ {
+			ain := gengraded(m, n, grading, rnd)
+			a := make([]float64, len(ain))
+			copy(a, ain)
+
+			sva := make([]float64, n)
+			v := make([]float64, n*n)
+			lwork := max(6, m+n)
+			work := make([]float64, lwork)
+
+			info := netlib.Dgesvj('G', 'U', 'V', m, n, a, m, sva, 0, v, n, work, lwork)
+			if info != 0 {
+				log.Fatalf("dgesvj failed for m=%d,n=%d,grading=%d: info=%d", m, n, grading, info)
+			}
+			// The singular values are returned scaled by work[0] to
+			// avoid overflow and underflow.
+			for i := range sva {
+				sva[i] *= work[0]
+			}
+
+			tests = append(tests, Dgesvjtest{
+				M:     m,
+				N:     n,
+				A:     rowMajor(m, n, ain),
+				SWant: sva,
+				UWant: rowMajor(m, n, a),
+				VWant: rowMajor(n, n, v),
+			})
+		}
+	}
+	json.NewEncoder(w).Encode(tests)
+
+	err = w.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// gengraded returns an m×n column-major matrix B*D if grading is 1 or D*B if
+// grading is 2, where B has random entries and D is diagonal with entries
+// decreasing from 1 to 1e-12. If grading is 0, B is returned.
+func gengraded(m, n, grading int, rnd *rand.Rand) []float64 {
+	a := make([]float64, m*n)
+	for i := range a {
+		a[i] = rnd.NormFloat64()
+	}
+	d := func(i, k int) float64 {
+		if k == 1 {
+			return 1
+		}
+		return math.Pow(10, -12*float64(i)/float64(k-1))
+	}
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			switch grading {
+			case 1:
+				a[i+j*m] *= d(j, n)
+			case 2:
+				a[i+j*m] *= d(i, m)
+			}
+		}
+	}
+	return a
+}
+
+// rowMajor returns the given r×c column-major matrix a in row-major format.
+func rowMajor(r, c int, a []float64) []float64 {
+	if len(a) != r*c {
+		panic("testdata: slice length mismatch")
+	}
+	m := make([]float64, len(a))
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			m[i*c+j] = a[i+j*r]
+		}
+	}
+	return m
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
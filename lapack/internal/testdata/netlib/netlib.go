@@ -10,6 +10,23 @@ package netlib
 //              double* sr, double* si, double* h, int* ldh, int* iloz, int* ihiz,
 //              double* z, int* ldz, double* v, int* ldv, double* u, int* ldu,
 //              int* nv, double* wv, int* ldwv, int* nh, double* wh, int* ldwh);
+//
+// #include <stddef.h>
+//
+// /* The trailing size_t arguments are the lengths of the character
+//  * arguments, which gfortran passes by value after the declared arguments. */
+//
+// void dgesvj_(char* joba, char* jobu, char* jobv, int* m, int* n,
+//              double* a, int* lda, double* sva, int* mv, double* v, int* ldv,
+//              double* work, int* lwork, int* info,
+//              size_t ljoba, size_t ljobu, size_t ljobv);
+//
+// void dgejsv_(char* joba, char* jobu, char* jobv, char* jobr, char* jobt, char* jobp,
+//              int* m, int* n, double* a, int* lda, double* sva,
+//              double* u, int* ldu, double* v, int* ldv,
+//              double* work, int* lwork, int* iwork, int* info,
+//              size_t ljoba, size_t ljobu, size_t ljobv,
+//              size_t ljobr, size_t ljobt, size_t ljobp);
 import "C"
 
 func Dlahr2(n, k, nb int, a []float64, lda int, tau, t []float64, ldt int, y []float64, ldy int) {
@@ -66,3 +83,58 @@ func Dlaqr5(wantt, wantz bool, kacc22 int, n, ktop, kbot int, nshfts int, sr, si
 			(*C.int)(&nv), (*C.double)(&wv[0]), (*C.int)(&ldwv))
 	}()
 }
+
+func Dgesvj(joba, jobu, jobv byte, m, n int, a []float64, lda int, sva []float64, mv int, v []float64, ldv int, work []float64, lwork int) (info int) {
+	func() {
+		joba := C.char(joba)
+		jobu := C.char(jobu)
+		jobv := C.char(jobv)
+		m := C.int(m)
+		n := C.int(n)
+		lda := C.int(lda)
+		mv := C.int(mv)
+		ldv := C.int(ldv)
+		lwork := C.int(lwork)
+		var cinfo C.int
+		C.dgesvj_(&joba, &jobu, &jobv, (*C.int)(&m), (*C.int)(&n),
+			(*C.double)(&a[0]), (*C.int)(&lda),
+			(*C.double)(&sva[0]), (*C.int)(&mv),
+			(*C.double)(&v[0]), (*C.int)(&ldv),
+			(*C.double)(&work[0]), (*C.int)(&lwork),
+			&cinfo,
+			1, 1, 1)
+		info = int(cinfo)
+	}()
+	return info
+}
+
+func Dgejsv(joba, jobu, jobv, jobr, jobt, jobp byte, m, n int, a []float64, lda int, sva []float64,
+	u []float64, ldu int, v []float64, ldv int, work []float64, lwork int, iwork []int32) (info int) {
+	func() {
+		joba := C.char(joba)
+		jobu := C.char(jobu)
+		jobv := C.char(jobv)
+		jobr := C.char(jobr)
+		jobt := C.char(jobt)
+		jobp := C.char(jobp)
+		m := C.int(m)
+		n := C.int(n)
+		lda := C.int(lda)
+		ldu := C.int(ldu)
+		ldv := C.int(ldv)
+		lwork := C.int(lwork)
+		var cinfo C.int
+		C.dgejsv_(&joba, &jobu, &jobv, &jobr, &jobt, &jobp,
+			(*C.int)(&m), (*C.int)(&n),
+			(*C.double)(&a[0]), (*C.int)(&lda),
+			(*C.double)(&sva[0]),
+			(*C.double)(&u[0]), (*C.int)(&ldu),
+			(*C.double)(&v[0]), (*C.int)(&ldv),
+			(*C.double)(&work[0]), (*C.int)(&lwork),
+			(*C.int)(&iwork[0]),
+			&cinfo,
+			1, 1, 1, 1, 1, 1)
+		info = int(cinfo)
+	}()
+	return info
+}
@@ -7,32 +7,95 @@ package lapack
 import "gonum.org/v1/gonum/blas"
 
 // Complex128 defines the public complex128 LAPACK API supported by gonum/lapack.
-type Complex128 interface{}
+type Complex128 interface {
+	Zgecon(norm MatrixNorm, n int, a []complex128, lda int, anorm float64, work []complex128, rwork []float64) float64
+	Zgeqrf(m, n int, a []complex128, lda int, tau, work []complex128, lwork int)
+	Zgesvd(jobU, jobVT SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64) (ok bool)
+	Zgetrf(m, n int, a []complex128, lda int, ipiv []int) (ok bool)
+	Zgetrs(trans blas.Transpose, n, nrhs int, a []complex128, lda int, ipiv []int, b []complex128, ldb int)
+	Zpotrf(ul blas.Uplo, n int, a []complex128, lda int) (ok bool)
+	Zpotrs(ul blas.Uplo, n, nrhs int, a []complex128, lda int, b []complex128, ldb int)
+	Ztrtrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, nrhs int, a []complex128, lda int, b []complex128, ldb int) (ok bool)
+	Zungqr(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int)
+	Zunmqr(side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128, lwork int)
+}
+
+// Float32 defines the public float32 LAPACK API supported by gonum/lapack.
+type Float32 interface {
+	Sgeqrf(m, n int, a []float32, lda int, tau, work []float32, lwork int)
+	Sgetrf(m, n int, a []float32, lda int, ipiv []int) (ok bool)
+	Sgetrs(trans blas.Transpose, n, nrhs int, a []float32, lda int, ipiv []int, b []float32, ldb int)
+	Sorgqr(m, n, k int, a []float32, lda int, tau, work []float32, lwork int)
+	Sormqr(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int)
+	Spotrf(ul blas.Uplo, n int, a []float32, lda int) (ok bool)
+	Spotrs(ul blas.Uplo, n, nrhs int, a []float32, lda int, b []float32, ldb int)
+	Strtrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, nrhs int, a []float32, lda int, b []float32, ldb int) (ok bool)
+}
 
 // Float64 defines the public float64 LAPACK API supported by gonum/lapack.
 type Float64 interface {
+	Dgbcon(norm MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+	Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+	Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int)
 	Dgecon(norm MatrixNorm, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64
+	Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool)
 	Dgeev(jobvl LeftEVJob, jobvr RightEVJob, n int, a []float64, lda int, wr, wi []float64, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (first int)
+	Dgejsv(jobU, jobV SVDJob, m, n int, a []float64, lda int, sva, u []float64, ldu int, v []float64, ldv int, work []float64, lwork int, iwork []int) (ok bool)
 	Dgels(trans blas.Transpose, m, n, nrhs int, a []float64, lda int, b []float64, ldb int, work []float64, lwork int) bool
+	Dgelss(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int) (rank int, ok bool)
+	Dgehrd(n, ilo, ihi int, a []float64, lda int, tau, work []float64, lwork int)
 	Dgelqf(m, n int, a []float64, lda int, tau, work []float64, lwork int)
+	Dgeqp3(m, n int, a []float64, lda int, jpvt []int, tau, work []float64, lwork int)
 	Dgeqrf(m, n int, a []float64, lda int, tau, work []float64, lwork int)
+	Dgerfs(trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
+	Dgesdd(jobz SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool)
 	Dgesvd(jobU, jobVT SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int) (ok bool)
+	Dgesvj(jobU, jobV SVDJob, m, n int, a []float64, lda int, sva, v []float64, ldv int, work []float64, lwork int) (ok bool)
+	Dgesvx(fact FactJob, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed Equilibration, r, c []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut Equilibration, rcond, rpvgrw float64, ok bool)
 	Dgetrf(m, n int, a []float64, lda int, ipiv []int) (ok bool)
 	Dgetri(n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
 	Dgetrs(trans blas.Transpose, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+	Dggev(jobvl LeftEVJob, jobvr RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool)
 	Dggsvd3(jobU, jobV, jobQ GSVDJob, m, n, p int, a []float64, lda int, b []float64, ldb int, alpha, beta, u []float64, ldu int, v []float64, ldv int, q []float64, ldq int, work []float64, lwork int, iwork []int) (k, l int, ok bool)
+	Dgtsv(n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool)
+	Dhseqr(job SchurJob, compz SchurComp, n, ilo, ihi int, h []float64, ldh int, wr, wi []float64, z []float64, ldz int, work []float64, lwork int) (unconverged int)
+	Dlantb(norm MatrixNorm, uplo blas.Uplo, diag blas.Diag, n, kd int, ab []float64, ldab int, work []float64) float64
 	Dlantr(norm MatrixNorm, uplo blas.Uplo, diag blas.Diag, m, n int, a []float64, lda int, work []float64) float64
 	Dlange(norm MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+	Dlangb(norm MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64
+	Dlansb(norm MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64
 	Dlansy(norm MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
+	Dlaqge(m, n int, a []float64, lda int, r, c []float64, rowcnd, colcnd, amax float64) Equilibration
+	Dlaqsy(uplo blas.Uplo, n int, a []float64, lda int, s []float64, scond, amax float64) Equilibration
 	Dlapmt(forward bool, m, n int, x []float64, ldx int, k []int)
+	Dorghr(n, ilo, ihi int, a []float64, lda int, tau, work []float64, lwork int)
 	Dormqr(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
 	Dormlq(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
+	Dpbcon(uplo blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64
+	Dpbtrf(uplo blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
+	Dpbtrs(uplo blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int)
 	Dpocon(uplo blas.Uplo, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64
+	Dpoequ(n int, a []float64, lda int, s []float64) (scond, amax float64, ok bool)
+	Dporfs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
+	Dposvx(fact FactJob, uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, equed Equilibration, s []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut Equilibration, rcond float64, ok bool)
 	Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok bool)
 	Dpotri(ul blas.Uplo, n int, a []float64, lda int) (ok bool)
 	Dpotrs(ul blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int)
+	Dpstrf(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+	Dptsv(n, nrhs int, d, e []float64, b []float64, ldb int) (ok bool)
+	Dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64
 	Dsyev(jobz EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int) (ok bool)
+	Dsyevd(jobz EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool)
+	Dsyevr(jobz EVJob, rng EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, w, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool)
+	Dsygst(itype GenEVType, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int)
+	Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
+	Dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+	Dtbcon(norm MatrixNorm, uplo blas.Uplo, diag blas.Diag, n, kd int, ab []float64, ldab int, work []float64, iwork []int) float64
+	Dtbtrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int) (ok bool)
 	Dtrcon(norm MatrixNorm, uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int, work []float64, iwork []int) float64
+	Dtrexc(compq UpdateSchurComp, n int, t []float64, ldt int, q []float64, ldq int, ifst, ilst int, work []float64) (ifstOut, ilstOut int, ok bool)
+	Dtrsyl(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool)
+	Dtrsyl3(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool)
 	Dtrtri(uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int) (ok bool)
 	Dtrtrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, nrhs int, a []float64, lda int, b []float64, ldb int) (ok bool)
 }
@@ -143,6 +206,15 @@ const (
 	EVNone    EVJob = 'N' // Do not compute eigenvectors.
 )
 
+// EVRange specifies which eigenvalues are computed in Dstemr and Dsyevr.
+type EVRange byte
+
+const (
+	EVRangeAll   EVRange = 'A' // Compute all eigenvalues.
+	EVRangeValue EVRange = 'V' // Compute the eigenvalues in the half-open interval (vl, vu].
+	EVRangeIndex EVRange = 'I' // Compute the eigenvalues with indices il through iu.
+)
+
 // LeftEVJob specifies whether left eigenvectors are computed in Dgeev.
 type LeftEVJob byte
 
@@ -194,7 +266,7 @@ const (
 	UpdateSchurNone UpdateSchurComp = 'N' // Do not update the matrix of Schur vectors.
 )
 
-// EVSide specifies what eigenvectors are computed in Dtrevc3.
+// EVSide specifies what eigenvectors are computed in Dtrevc3 and Dtgevc.
 type EVSide byte
 
 const (
@@ -203,7 +275,7 @@ const (
 	EVBoth  EVSide = 'B' // Compute both right and left eigenvectors.
 )
 
-// EVHowMany specifies which eigenvectors are computed in Dtrevc3 and how.
+// EVHowMany specifies which eigenvectors are computed in Dtrevc3 and Dtgevc and how.
 type EVHowMany byte
 
 const (
@@ -211,3 +283,44 @@ const (
 	EVAllMulQ  EVHowMany = 'B' // Compute all right and/or left eigenvectors multiplied by an input matrix.
 	EVSelected EVHowMany = 'S' // Compute selected right and/or left eigenvectors.
 )
+
+// OrthoComp specifies whether and how the orthogonal matrix is computed in Dgghrd,
+// Dhgeqz and Dbdsdc.
+type OrthoComp byte
+
+const (
+	OrthoNone     OrthoComp = 'N' // Do not compute the orthogonal matrix.
+	OrthoExplicit OrthoComp = 'I' // The orthogonal matrix is formed explicitly and returned in the argument.
+	OrthoPostmul  OrthoComp = 'V' // The orthogonal matrix is post-multiplied into the matrix stored in the argument on entry.
+)
+
+// GenEVType specifies the form of a generalized symmetric-definite eigenproblem
+// in Dsygst.
+type GenEVType int
+
+const (
+	AxLBx GenEVType = 1 // A*x = λ*B*x.
+	ABxLx GenEVType = 2 // A*B*x = λ*x.
+	BAxLx GenEVType = 3 // B*A*x = λ*x.
+)
+
+// FactJob specifies whether and how the matrix is factorized by the expert
+// drivers Dgesvx and Dposvx.
+type FactJob byte
+
+const (
+	Factored             FactJob = 'F' // The factored form of the matrix is supplied on entry.
+	Factorize            FactJob = 'N' // Factorize the matrix as supplied.
+	EquilibrateFactorize FactJob = 'E' // Equilibrate the matrix if necessary and then factorize it.
+)
+
+// Equilibration specifies the form of scaling applied to a matrix to improve
+// its condition.
+type Equilibration byte
+
+const (
+	EquilibrateNone Equilibration = 'N' // No equilibration.
+	EquilibrateRows Equilibration = 'R' // Row equilibration, A is replaced by diag(r)*A.
+	EquilibrateCols Equilibration = 'C' // Column equilibration, A is replaced by A*diag(c).
+	EquilibrateBoth Equilibration = 'B' // Row and column equilibration, A is replaced by diag(r)*A*diag(c).
+)
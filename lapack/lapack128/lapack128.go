@@ -0,0 +1,218 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lapack128
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/gonum"
+)
+
+var lapack128 lapack.Complex128 = gonum.Implementation{}
+
+// Use sets the LAPACK complex128 implementation to be used by subsequent BLAS calls.
+// The default implementation is native.Implementation.
+func Use(l lapack.Complex128) {
+	lapack128 = l
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Potrf computes the Cholesky factorization of a.
+// The factorization has the form
+//  A = U^H * U if a.Uplo == blas.Upper, or
+//  A = L * L^H if a.Uplo == blas.Lower,
+// where U is an upper triangular matrix and L is lower triangular.
+// The triangular matrix is returned in t, and the underlying data between
+// a and t is shared. The returned bool indicates whether a is positive
+// definite and the factorization could be finished.
+func Potrf(a cblas128.Hermitian) (t cblas128.Triangular, ok bool) {
+	ok = lapack128.Zpotrf(a.Uplo, a.N, a.Data, max(1, a.Stride))
+	t.Uplo = a.Uplo
+	t.N = a.N
+	t.Data = a.Data
+	t.Stride = a.Stride
+	t.Diag = blas.NonUnit
+	return
+}
+
+// Potrs solves a system of n linear equations A*X = B where A is an n×n
+// Hermitian positive definite matrix and B is an n×nrhs matrix, using the
+// Cholesky factorization A = U^H*U or A = L*L^H. t contains the corresponding
+// triangular factor as returned by Potrf. On entry, B contains the right-hand
+// side matrix B, on return it contains the solution matrix X.
+func Potrs(t cblas128.Triangular, b cblas128.General) {
+	lapack128.Zpotrs(t.Uplo, t.N, b.Cols, t.Data, max(1, t.Stride), b.Data, max(1, b.Stride))
+}
+
+// Gecon estimates the reciprocal of the condition number of the n×n matrix A
+// given the LU decomposition of the matrix. The condition number computed may
+// be based on the 1-norm or the ∞-norm.
+//
+// a contains the result of the LU decomposition of A as computed by Getrf.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Gecon will panic otherwise.
+//
+// rwork is a temporary data slice of length at least 2*n and Gecon will panic otherwise.
+func Gecon(norm lapack.MatrixNorm, a cblas128.General, anorm float64, work []complex128, rwork []float64) float64 {
+	return lapack128.Zgecon(norm, a.Cols, a.Data, max(1, a.Stride), anorm, work, rwork)
+}
+
+// Geqrf computes the QR factorization of the m×n matrix A. A is modified to contain the information to construct Q and R.
+// The upper triangle of a contains the matrix R. The lower triangular elements
+// (not including the diagonal) contain the elementary reflectors. tau is modified
+// to contain the reflector scales. tau must have length at least min(m,n), and
+// this function will panic otherwise.
+//
+// The ith elementary reflector can be explicitly constructed by first extracting
+// the
+//  v[j] = 0           j < i
+//  v[j] = 1           j == i
+//  v[j] = a[j*lda+i]  j > i
+// and computing H_i = I - tau[i] * v * v^H.
+//
+// The unitary matrix Q can be constructed from a product of these elementary
+// reflectors, Q = H_0 * H_1 * ... * H_{k-1}, where k = min(m,n).
+//
+// Work is temporary storage, and lwork specifies the usable memory length.
+// At minimum, lwork >= n and this function will panic otherwise.
+// If lwork == -1, instead of performing Geqrf, the optimal work length will
+// be stored into work[0].
+func Geqrf(a cblas128.General, tau, work []complex128, lwork int) {
+	lapack128.Zgeqrf(a.Rows, a.Cols, a.Data, max(1, a.Stride), tau, work, lwork)
+}
+
+// Gesvd computes the singular value decomposition of the input matrix A.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^H
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m unitary matrix and V is an n×n unitary matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobU and jobVT are options for computing the singular vectors. The behavior
+// is as follows
+//  jobU == lapack.SVDAll       All m columns of U are returned in u
+//  jobU == lapack.SVDStore     The first min(m,n) columns are returned in u
+//  jobU == lapack.SVDNone      The columns of U are not computed.
+// The behavior is the same for jobVT and the rows of V^H.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Gesvd
+// the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored columnwise. If
+// jobU == lapack.SVDAll, u is of size m×m. If jobU == lapack.SVDStore u is
+// of size m×min(m,n). If jobU == lapack.SVDNone, u is not used.
+//
+// vt contains the right singular vectors on exit, stored rowwise. If
+// jobVT == lapack.SVDAll, vt is of size n×n. If jobVT == lapack.SVDStore vt is
+// of size min(m,n)×n. If jobVT == lapack.SVDNone, vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. If lwork == -1, instead of performing Gesvd, the optimal work
+// length will be stored into work[0]. rwork is real temporary storage.
+// Gesvd will panic if the working memory has insufficient storage.
+//
+// Gesvd returns whether the decomposition successfully completed.
+func Gesvd(jobU, jobVT lapack.SVDJob, a, u, vt cblas128.General, s []float64, work []complex128, lwork int, rwork []float64) (ok bool) {
+	return lapack128.Zgesvd(jobU, jobVT, a.Rows, a.Cols, a.Data, max(1, a.Stride), s, u.Data, max(1, u.Stride), vt.Data, max(1, vt.Stride), work, lwork, rwork)
+}
+
+// Getrf computes the LU decomposition of the m×n matrix A.
+// The LU decomposition is a factorization of A into
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix, and
+// U is a (usually) non-unit upper triangular matrix. On exit, L and U are stored
+// in place into a.
+//
+// ipiv is a permutation vector. It indicates that row i of the matrix was
+// changed with ipiv[i]. ipiv must have length at least min(m,n), and will panic
+// otherwise. ipiv is zero-indexed.
+//
+// Getrf is the blocked version of the algorithm.
+//
+// Getrf returns whether the matrix A is singular. The LU decomposition will
+// be computed regardless of the singularity of A, but division by zero
+// will occur if the false is returned and the result is used to solve a
+// system of equations.
+func Getrf(a cblas128.General, ipiv []int) bool {
+	return lapack128.Zgetrf(a.Rows, a.Cols, a.Data, max(1, a.Stride), ipiv)
+}
+
+// Getrs solves a system of equations using an LU factorization.
+// The system of equations solved is
+//  A * X = B   if trans == blas.NoTrans
+//  A^T * X = B if trans == blas.Trans
+//  A^H * X = B if trans == blas.ConjTrans
+// A is a general n×n matrix with stride lda. B is a general matrix of size n×nrhs.
+//
+// On entry b contains the elements of the matrix B. On exit, b contains the
+// elements of X, the solution to the system of equations.
+//
+// a and ipiv contain the LU factorization of A and the permutation indices as
+// computed by Getrf. ipiv is zero-indexed.
+func Getrs(trans blas.Transpose, a cblas128.General, b cblas128.General, ipiv []int) {
+	lapack128.Zgetrs(trans, a.Cols, b.Cols, a.Data, max(1, a.Stride), ipiv, b.Data, max(1, b.Stride))
+}
+
+// Trtrs solves a triangular system of the form A * X = B, A^T * X = B or
+// A^H * X = B. Trtrs returns whether the solve completed successfully.
+// If A is singular, no solve is performed.
+func Trtrs(trans blas.Transpose, a cblas128.Triangular, b cblas128.General) (ok bool) {
+	return lapack128.Ztrtrs(a.Uplo, trans, a.Diag, a.N, b.Cols, a.Data, max(1, a.Stride), b.Data, max(1, b.Stride))
+}
+
+// Ungqr generates an m×n matrix Q with orthonormal columns defined by the
+// product of elementary reflectors as computed by Geqrf.
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// len(tau) >= k, 0 <= k <= n, 0 <= n <= m, len(work) >= lwork.
+// Ungqr will panic if these conditions are not met.
+//
+// The number of columns of A determines the size of the computed Q.
+//
+// Work is temporary storage, and lwork specifies the usable memory length.
+// At minimum, lwork >= n, and Ungqr will panic otherwise.
+// If lwork == -1, instead of computing Ungqr the optimal work length is stored
+// into work[0].
+func Ungqr(a cblas128.General, tau []complex128, work []complex128, lwork int) {
+	lapack128.Zungqr(a.Rows, a.Cols, len(tau), a.Data, max(1, a.Stride), tau, work, lwork)
+}
+
+// Unmqr multiplies an m×n matrix C by a unitary matrix Q as
+//  C = Q * C,   if side == blas.Left  and trans == blas.NoTrans,
+//  C = Q^H * C, if side == blas.Left  and trans == blas.ConjTrans,
+//  C = C * Q,   if side == blas.Right and trans == blas.NoTrans,
+//  C = C * Q^H, if side == blas.Right and trans == blas.ConjTrans,
+// where Q is defined as the product of k elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{k-1}.
+//
+// If side == blas.Left, A is an m×k matrix and 0 <= k <= m.
+// If side == blas.Right, A is an n×k matrix and 0 <= k <= n.
+// The ith column of A contains the vector which defines the elementary
+// reflector H_i and tau[i] contains its scalar factor. tau must have length k
+// and Unmqr will panic otherwise. Geqrf returns A and tau in the required
+// form.
+//
+// work must have length at least max(1,lwork), and lwork must be at least n if
+// side == blas.Left and at least m if side == blas.Right, otherwise Unmqr will
+// panic.
+//
+// If lwork is -1, instead of performing Unmqr, the optimal workspace size will
+// be stored into work[0].
+func Unmqr(side blas.Side, trans blas.Transpose, a cblas128.General, tau []complex128, c cblas128.General, work []complex128, lwork int) {
+	lapack128.Zunmqr(side, trans, c.Rows, c.Cols, a.Cols, a.Data, max(1, a.Stride), tau, c.Data, max(1, c.Stride), work, lwork)
+}
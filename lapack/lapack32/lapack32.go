@@ -0,0 +1,161 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lapack32
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/gonum"
+)
+
+var lapack32 lapack.Float32 = gonum.Implementation{}
+
+// Use sets the LAPACK float32 implementation to be used by subsequent BLAS calls.
+// The default implementation is native.Implementation.
+func Use(l lapack.Float32) {
+	lapack32 = l
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Potrf computes the Cholesky factorization of a.
+// The factorization has the form
+//  A = U^T * U if a.Uplo == blas.Upper, or
+//  A = L * L^T if a.Uplo == blas.Lower,
+// where U is an upper triangular matrix and L is lower triangular.
+// The triangular matrix is returned in t, and the underlying data between
+// a and t is shared. The returned bool indicates whether a is positive
+// definite and the factorization could be finished.
+func Potrf(a blas32.Symmetric) (t blas32.Triangular, ok bool) {
+	ok = lapack32.Spotrf(a.Uplo, a.N, a.Data, max(1, a.Stride))
+	t.Uplo = a.Uplo
+	t.N = a.N
+	t.Data = a.Data
+	t.Stride = a.Stride
+	t.Diag = blas.NonUnit
+	return
+}
+
+// Potrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite matrix and B is an n×nrhs matrix, using the
+// Cholesky factorization A = U^T*U or A = L*L^T. t contains the corresponding
+// triangular factor as returned by Potrf. On entry, B contains the right-hand
+// side matrix B, on return it contains the solution matrix X.
+func Potrs(t blas32.Triangular, b blas32.General) {
+	lapack32.Spotrs(t.Uplo, t.N, b.Cols, t.Data, max(1, t.Stride), b.Data, max(1, b.Stride))
+}
+
+// Geqrf computes the QR factorization of the m×n matrix A. A is modified to contain the information to construct Q and R.
+// The upper triangle of a contains the matrix R. The lower triangular elements
+// (not including the diagonal) contain the elementary reflectors. tau is modified
+// to contain the reflector scales. tau must have length at least min(m,n), and
+// this function will panic otherwise.
+//
+// The ith elementary reflector can be explicitly constructed by first extracting
+// the
+//  v[j] = 0           j < i
+//  v[j] = 1           j == i
+//  v[j] = a[j*lda+i]  j > i
+// and computing H_i = I - tau[i] * v * v^T.
+//
+// The orthogonal matrix Q can be constructed from a product of these elementary
+// reflectors, Q = H_0 * H_1 * ... * H_{k-1}, where k = min(m,n).
+//
+// Work is temporary storage, and lwork specifies the usable memory length.
+// At minimum, lwork >= n and this function will panic otherwise.
+// If lwork == -1, instead of performing Geqrf, the optimal work length will
+// be stored into work[0].
+func Geqrf(a blas32.General, tau, work []float32, lwork int) {
+	lapack32.Sgeqrf(a.Rows, a.Cols, a.Data, max(1, a.Stride), tau, work, lwork)
+}
+
+// Getrf computes the LU decomposition of the m×n matrix A.
+// The LU decomposition is a factorization of A into
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix, and
+// U is a (usually) non-unit upper triangular matrix. On exit, L and U are stored
+// in place into a.
+//
+// ipiv is a permutation vector. It indicates that row i of the matrix was
+// changed with ipiv[i]. ipiv must have length at least min(m,n), and will panic
+// otherwise. ipiv is zero-indexed.
+//
+// Getrf is the blocked version of the algorithm.
+//
+// Getrf returns whether the matrix A is singular. The LU decomposition will
+// be computed regardless of the singularity of A, but division by zero
+// will occur if the false is returned and the result is used to solve a
+// system of equations.
+func Getrf(a blas32.General, ipiv []int) bool {
+	return lapack32.Sgetrf(a.Rows, a.Cols, a.Data, max(1, a.Stride), ipiv)
+}
+
+// Getrs solves a system of equations using an LU factorization.
+// The system of equations solved is
+//  A * X = B   if trans == blas.NoTrans
+//  A^T * X = B if trans == blas.Trans or blas.ConjTrans
+// A is a general n×n matrix with stride lda. B is a general matrix of size n×nrhs.
+//
+// On entry b contains the elements of the matrix B. On exit, b contains the
+// elements of X, the solution to the system of equations.
+//
+// a and ipiv contain the LU factorization of A and the permutation indices as
+// computed by Getrf. ipiv is zero-indexed.
+func Getrs(trans blas.Transpose, a blas32.General, b blas32.General, ipiv []int) {
+	lapack32.Sgetrs(trans, a.Cols, b.Cols, a.Data, max(1, a.Stride), ipiv, b.Data, max(1, b.Stride))
+}
+
+// Trtrs solves a triangular system of the form A * X = B or A^T * X = B. Trtrs returns whether the solve completed successfully.
+// If A is singular, no solve is performed.
+func Trtrs(trans blas.Transpose, a blas32.Triangular, b blas32.General) (ok bool) {
+	return lapack32.Strtrs(a.Uplo, trans, a.Diag, a.N, b.Cols, a.Data, max(1, a.Stride), b.Data, max(1, b.Stride))
+}
+
+// Orgqr generates an m×n matrix Q with orthonormal columns defined by the
+// product of elementary reflectors as computed by Geqrf.
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// len(tau) >= k, 0 <= k <= n, 0 <= n <= m, len(work) >= lwork.
+// Orgqr will panic if these conditions are not met.
+//
+// The number of columns of A determines the size of the computed Q.
+//
+// Work is temporary storage, and lwork specifies the usable memory length.
+// At minimum, lwork >= n, and Orgqr will panic otherwise.
+// If lwork == -1, instead of computing Orgqr the optimal work length is stored
+// into work[0].
+func Orgqr(a blas32.General, tau []float32, work []float32, lwork int) {
+	lapack32.Sorgqr(a.Rows, a.Cols, len(tau), a.Data, max(1, a.Stride), tau, work, lwork)
+}
+
+// Ormqr multiplies an m×n matrix C by an orthogonal matrix Q as
+//  C = Q * C,   if side == blas.Left  and trans == blas.NoTrans,
+//  C = Q^T * C, if side == blas.Left  and trans == blas.Trans,
+//  C = C * Q,   if side == blas.Right and trans == blas.NoTrans,
+//  C = C * Q^T, if side == blas.Right and trans == blas.Trans,
+// where Q is defined as the product of k elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{k-1}.
+//
+// If side == blas.Left, A is an m×k matrix and 0 <= k <= m.
+// If side == blas.Right, A is an n×k matrix and 0 <= k <= n.
+// The ith column of A contains the vector which defines the elementary
+// reflector H_i and tau[i] contains its scalar factor. tau must have length k
+// and Ormqr will panic otherwise. Geqrf returns A and tau in the required
+// form.
+//
+// work must have length at least max(1,lwork), and lwork must be at least n if
+// side == blas.Left and at least m if side == blas.Right, otherwise Ormqr will
+// panic.
+//
+// If lwork is -1, instead of performing Ormqr, the optimal workspace size will
+// be stored into work[0].
+func Ormqr(side blas.Side, trans blas.Transpose, a blas32.General, tau []float32, c blas32.General, work []float32, lwork int) {
+	lapack32.Sormqr(side, trans, c.Rows, c.Cols, a.Cols, a.Data, max(1, a.Stride), tau, c.Data, max(1, c.Stride), work, lwork)
+}
@@ -73,6 +73,88 @@ func Potrs(t blas64.Triangular, b blas64.General) {
 	lapack64.Dpotrs(t.Uplo, t.N, b.Cols, t.Data, max(1, t.Stride), b.Data, max(1, b.Stride))
 }
 
+// Pstrf computes the Cholesky factorization with complete pivoting of an n×n
+// symmetric positive semidefinite matrix A.
+//
+// The factorization has the form
+//  P^T * A * P = U^T * U ,  if a.Uplo = blas.Upper,
+//  P^T * A * P = L   * L^T, if a.Uplo = blas.Lower,
+// where U is an upper triangular matrix, L is lower triangular, and P is a
+// permutation matrix.
+//
+// tol is a user-defined tolerance. The algorithm terminates if the pivot is
+// less than or equal to tol. If tol is negative, then n*eps*max(A[k,k]) will be
+// used instead.
+//
+// The triangular factor U or L from the Cholesky factorization is returned in t
+// and the underlying data between a and t is shared. P is stored on return in
+// vector piv such that P[piv[k],k] = 1.
+//
+// Pstrf returns the computed rank of A and whether the factorization can be
+// used to solve a system. Pstrf does not attempt to check that A is positive
+// semi-definite, so if ok is false, the matrix A is either rank deficient or is
+// not positive semidefinite.
+//
+// piv must have length at least n and work must have length at least 2*n,
+// otherwise Pstrf will panic.
+func Pstrf(a blas64.Symmetric, piv []int, tol float64, work []float64) (t blas64.Triangular, rank int, ok bool) {
+	rank, ok = lapack64.Dpstrf(a.Uplo, a.N, a.Data, max(1, a.Stride), piv, tol, work)
+	t.Uplo = a.Uplo
+	t.N = a.N
+	t.Data = a.Data
+	t.Stride = a.Stride
+	t.Diag = blas.NonUnit
+	return t, rank, ok
+}
+
+// Gbcon estimates the reciprocal of the condition number of the n×n band
+// matrix A given the LU factorization of A computed by Gbtrf. The condition
+// number computed may be based on the 1-norm or the ∞-norm.
+//
+// a and ipiv contain the LU factorization of A and the pivot indices as
+// returned by Gbtrf. anorm is the corresponding 1-norm or ∞-norm of the
+// original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Gbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Gbcon will panic otherwise.
+func Gbcon(norm lapack.MatrixNorm, a blas64.Band, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dgbcon(norm, a.Cols, a.KL, a.KU, a.Data, max(1, a.Stride), ipiv, anorm, work, iwork)
+}
+
+// Gbtrf computes an LU factorization of the m×n band matrix A using partial
+// pivoting with row interchanges. The form of the factorization is
+//  A = P * L * U,
+// where P is a permutation matrix, L is lower triangular with unit diagonal
+// elements and at most a.KL non-zero elements below the diagonal in each
+// column, and U is upper triangular with a.KL+a.KU super-diagonals.
+//
+// The fill-in of U is stored in a.KL additional elements to the right of each
+// row of the band, so a.Stride must be at least 2*a.KL+a.KU+1. These elements
+// need not be set on entry. See the documentation of
+// gonum.Implementation.Dgbtrf for the details of the storage.
+//
+// ipiv must have length min(m,n) and on return it contains the zero-indexed
+// row interchanges, row i of the matrix was interchanged with row ipiv[i].
+//
+// Gbtrf returns whether U is nonsingular. The LU factorization is computed
+// regardless of the singularity of A, but division by zero will occur if
+// false is returned and the result is used to solve a system of equations.
+func Gbtrf(a blas64.Band, ipiv []int) (ok bool) {
+	return lapack64.Dgbtrf(a.Rows, a.Cols, a.KL, a.KU, a.Data, max(1, a.Stride), ipiv)
+}
+
+// Gbtrs solves a system of linear equations
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+// with an n×n band matrix A using the LU factorization computed by Gbtrf. a
+// and ipiv contain the LU factorization of A and the pivot indices as returned
+// by Gbtrf. On entry, b contains the right-hand side matrix B and on return it
+// is overwritten with the solution matrix X.
+func Gbtrs(trans blas.Transpose, a blas64.Band, ipiv []int, b blas64.General) {
+	lapack64.Dgbtrs(trans, a.Cols, a.KL, a.KU, b.Cols, a.Data, max(1, a.Stride), ipiv, b.Data, max(1, b.Stride))
+}
+
 // Gecon estimates the reciprocal of the condition number of the n×n matrix A
 // given the LU decomposition of the matrix. The condition number computed may
 // be based on the 1-norm or the ∞-norm.
@@ -88,6 +170,51 @@ func Gecon(norm lapack.MatrixNorm, a blas64.General, anorm float64, work []float
 	return lapack64.Dgecon(norm, a.Cols, a.Data, max(1, a.Stride), anorm, work, iwork)
 }
 
+// Geequ computes row and column scalings intended to equilibrate the m×n
+// matrix A and reduce its condition number. The scaling factors are returned
+// in r and c so that the elements of diag(r)*A*diag(c) have a largest
+// absolute value of 1 in each row and column.
+//
+// rowcnd and colcnd are the ratios of the smallest to the largest r[i] and
+// c[j], respectively, and amax is the absolute value of the largest element
+// of A. If a row or a column of A is exactly zero, ok is false.
+//
+// r must have length at least m and c must have length at least n, otherwise
+// Geequ will panic.
+func Geequ(a blas64.General, r, c []float64) (rowcnd, colcnd, amax float64, ok bool) {
+	return lapack64.Dgeequ(a.Rows, a.Cols, a.Data, max(1, a.Stride), r, c)
+}
+
+// Gejsv computes the singular value decomposition of an m×n matrix A with
+// m >= n using the preconditioned one-sided Jacobi method. The singular values
+// are computed to high relative accuracy if A = B*D or A = D*B where D is
+// diagonal and B is well-conditioned.
+//
+// jobU specifies which left singular vectors are computed. If jobU is
+// lapack.SVDAll, the m×m matrix U is stored in u, if jobU is lapack.SVDStore,
+// the first n columns of U are stored in u, and if jobU is lapack.SVDNone, u is
+// not used. If jobV is lapack.SVDAll, the n×n matrix V is stored in v,
+// otherwise jobV must be lapack.SVDNone and v is not used.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Gejsv
+// the data is overwritten.
+//
+// sva is a slice of length at least n and on exit contains the singular
+// values in decreasing order.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. lwork must be at least 2*n*n + 2*n + max(m, 3*n+1). If
+// lwork == -1, instead of performing Gejsv, the optimal work length will be
+// stored into work[0]. Gejsv will panic if the working memory has insufficient
+// storage.
+//
+// iwork must have length at least m+n and Gejsv will panic otherwise.
+//
+// Gejsv returns whether the Jacobi iteration converged.
+func Gejsv(jobU, jobV lapack.SVDJob, a, u, v blas64.General, sva, work []float64, lwork int, iwork []int) (ok bool) {
+	return lapack64.Dgejsv(jobU, jobV, a.Rows, a.Cols, a.Data, max(1, a.Stride), sva, u.Data, max(1, u.Stride), v.Data, max(1, v.Stride), work, lwork, iwork)
+}
+
 // Gels finds a minimum-norm solution based on the matrices A and B using the
 // QR or LQ factorization. Gels returns false if the matrix
 // A is singular, and true if this solution was successfully found.
@@ -121,6 +248,54 @@ func Gels(trans blas.Transpose, a blas64.General, b blas64.General, work []float
 	return lapack64.Dgels(trans, a.Rows, a.Cols, b.Cols, a.Data, max(1, a.Stride), b.Data, max(1, b.Stride), work, lwork)
 }
 
+// Gelss computes the minimum-norm solution to the linear least squares
+// problem
+//  minimize ||B - A*X||_2
+// using the singular value decomposition of the m×n matrix A. A may be rank
+// deficient. Each column of B is treated as a separate right-hand side.
+//
+// The effective rank of A is determined by treating as zero those singular
+// values s[i] that satisfy s[i] <= rcond * s[0]. If rcond < 0, machine
+// precision is used instead.
+//
+// On return, the first min(m,n) rows of A are overwritten with the right
+// singular vectors of A. B must have max(m,n) rows, and on return the first
+// n rows of B contain the solution X. s must have length min(m,n) and on
+// return contains the singular values of A in decreasing order.
+//
+// Work is temporary storage, and lwork specifies the usable memory length.
+// If min(m,n) > 0, lwork must be at least 3*min(m,n) + max(m, n, nrhs, 2*min(m,n)),
+// otherwise lwork must be at least 1, and Gelss will panic otherwise.
+// In the special case that lwork == -1, work[0] will be set to the optimal
+// working length.
+//
+// Gelss returns the effective rank of A and whether the singular value
+// decomposition converged.
+func Gelss(a, b blas64.General, s []float64, rcond float64, work []float64, lwork int) (rank int, ok bool) {
+	return lapack64.Dgelss(a.Rows, a.Cols, b.Cols, a.Data, max(1, a.Stride), b.Data, max(1, b.Stride), s, rcond, work, lwork)
+}
+
+// Geqp3 computes a QR factorization with column pivoting of the m×n matrix A
+//  A*P = Q*R
+// using Level 3 BLAS. A is modified to contain the information to construct
+// Q and R. The upper triangle of a contains the matrix R. The elements below
+// the diagonal and the slice tau represent the matrix Q as a product of
+// elementary reflectors, as described in the documentation of Geqrf.
+//
+// jpvt specifies a column pivot to be applied to A. If jpvt[j] is at least
+// zero, the jth column of A is permuted to the front of A*P (a leading
+// column), if jpvt[j] is -1 the jth column of A is a free column. On return,
+// jpvt holds the permutation that was applied; the jth column of A*P was the
+// jpvt[j] column of A. jpvt must have length n and tau must have length
+// min(m,n), otherwise Geqp3 will panic.
+//
+// work must have length at least max(1,lwork), and lwork must be at least
+// 3*n+1, otherwise Geqp3 will panic. If lwork == -1, instead of performing
+// Geqp3, the optimal work length will be stored into work[0].
+func Geqp3(a blas64.General, jpvt []int, tau, work []float64, lwork int) {
+	lapack64.Dgeqp3(a.Rows, a.Cols, a.Data, max(1, a.Stride), jpvt, tau, work, lwork)
+}
+
 // Geqrf computes the QR factorization of the m×n matrix A using a blocked
 // algorithm. A is modified to contain the information to construct Q and R.
 // The upper triangle of a contains the matrix R. The lower triangular elements
@@ -167,6 +342,46 @@ func Gelqf(a blas64.General, tau, work []float64, lwork int) {
 	lapack64.Dgelqf(a.Rows, a.Cols, a.Data, max(1, a.Stride), tau, work, lwork)
 }
 
+// Gesdd computes the singular value decomposition of the input matrix A
+// using the divide and conquer method.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobz specifies which singular vectors are computed. The behavior is as
+// follows
+//  jobz == lapack.SVDAll   All m columns of U and all n rows of V^T are
+//                          returned in u and vt
+//  jobz == lapack.SVDStore The first min(m,n) columns of U and the first
+//                          min(m,n) rows of V^T are returned in u and vt
+//  jobz == lapack.SVDNone  The singular vectors are not computed.
+// Gesdd will panic if jobz is lapack.SVDOverwrite.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Gesdd
+// the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. With mn = min(m,n) and mx = max(m,n), lwork must be at least
+//  4*mn + max(mx, 4*mn)                         if jobz == lapack.SVDNone,
+//  mn*mn + 4*mn + max(3*mn*mn + 7*mn + 1, mx)   otherwise.
+// If lwork == -1, instead of performing Gesdd, the optimal work length will be
+// stored into work[0]. Gesdd will panic if the working memory has insufficient
+// storage.
+//
+// iwork must have length at least 4*min(m,n) and Gesdd will panic otherwise.
+//
+// Gesdd returns whether the decomposition successfully completed.
+func Gesdd(jobz lapack.SVDJob, a, u, vt blas64.General, s, work []float64, lwork int, iwork []int) (ok bool) {
+	return lapack64.Dgesdd(jobz, a.Rows, a.Cols, a.Data, max(1, a.Stride), s, u.Data, max(1, u.Stride), vt.Data, max(1, vt.Stride), work, lwork, iwork)
+}
+
 // Gesvd computes the singular value decomposition of the input matrix A.
 //
 // The singular value decomposition is
@@ -213,6 +428,49 @@ func Gesvd(jobU, jobVT lapack.SVDJob, a, u, vt blas64.General, s, work []float64
 	return lapack64.Dgesvd(jobU, jobVT, a.Rows, a.Cols, a.Data, max(1, a.Stride), s, u.Data, max(1, u.Stride), vt.Data, max(1, vt.Stride), work, lwork)
 }
 
+// Gesvj computes the singular value decomposition of an m×n matrix A with
+// m >= n using the one-sided Jacobi method.
+//
+// If jobU is lapack.SVDOverwrite, the n left singular vectors are stored in a
+// on return, otherwise jobU must be lapack.SVDNone and the contents of a are
+// destroyed. If jobV is lapack.SVDAll, the n×n matrix V is stored in v,
+// otherwise jobV must be lapack.SVDNone and v is not used.
+//
+// sva is a slice of length at least n and on exit contains the singular
+// values in decreasing order.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. lwork must be at least max(1, m). If lwork == -1, instead of
+// performing Gesvj, the optimal work length will be stored into work[0]. Gesvj
+// will panic if the working memory has insufficient storage.
+//
+// Gesvj returns whether the iteration converged.
+func Gesvj(jobU, jobV lapack.SVDJob, a, v blas64.General, sva, work []float64, lwork int) (ok bool) {
+	return lapack64.Dgesvj(jobU, jobV, a.Rows, a.Cols, a.Data, max(1, a.Stride), sva, v.Data, max(1, v.Stride), work, lwork)
+}
+
+// Gesvx uses the LU factorization to compute the solution to a system of
+// linear equations
+//  op(A) * X = B
+// where A is an n×n general matrix and X and B are n×nrhs matrices, with
+// optional equilibration, iterative refinement and error bounds.
+//
+// If fact is lapack.Factored, af and ipiv contain on entry the LU
+// factorization of A, and equed, r and c describe the equilibration that was
+// applied to A. Otherwise af and ipiv are overwritten by the LU factorization
+// of the equilibrated matrix.
+//
+// On return x contains the solution matrix, and ferr and berr contain the
+// forward error bounds and the componentwise backward errors of the solution
+// columns. rcond is the estimate of the reciprocal condition number of the
+// equilibrated A, and rpvgrw is the reciprocal pivot growth factor. ok is
+// false if A is singular or singular to working precision.
+//
+// See the documentation for lapack/gonum.Dgesvx for more details.
+func Gesvx(fact lapack.FactJob, trans blas.Transpose, a, af blas64.General, ipiv []int, equed lapack.Equilibration, r, c []float64, b, x blas64.General, ferr, berr, work []float64, iwork []int) (equedOut lapack.Equilibration, rcond, rpvgrw float64, ok bool) {
+	return lapack64.Dgesvx(fact, trans, a.Cols, b.Cols, a.Data, max(1, a.Stride), af.Data, max(1, af.Stride), ipiv, equed, r, c, b.Data, max(1, b.Stride), x.Data, max(1, x.Stride), ferr, berr, work, iwork)
+}
+
 // Getrf computes the LU decomposition of the m×n matrix A.
 // The LU decomposition is a factorization of A into
 //  A = P * L * U
@@ -265,6 +523,22 @@ func Getrs(trans blas.Transpose, a blas64.General, b blas64.General, ipiv []int)
 	lapack64.Dgetrs(trans, a.Cols, b.Cols, a.Data, max(1, a.Stride), ipiv, b.Data, max(1, b.Stride))
 }
 
+// Gtsv solves the equation
+//  A * X = B
+// where A is an n×n tridiagonal matrix, by Gaussian elimination with partial
+// pivoting. dl, d and du contain the sub-diagonal, the diagonal and the
+// super-diagonal, respectively, of A, and they are overwritten by the factor
+// U on return. On entry, b contains the n×nrhs right-hand side matrix B and
+// on return it is overwritten with the solution matrix X.
+//
+// dl and du must have length at least n-1, and d must have length at least n,
+// otherwise Gtsv will panic.
+//
+// Gtsv returns whether the solution has been successfully computed.
+func Gtsv(dl, d, du []float64, b blas64.General) (ok bool) {
+	return lapack64.Dgtsv(b.Rows, b.Cols, dl, d, du, b.Data, max(1, b.Stride))
+}
+
 // Ggsvd3 computes the generalized singular value decomposition (GSVD)
 // of an m×n matrix A and p×n matrix B:
 //  U^T*A*Q = D1*[ 0 R ]
@@ -377,6 +651,22 @@ func Lange(norm lapack.MatrixNorm, a blas64.General, work []float64) float64 {
 	return lapack64.Dlange(norm, a.Rows, a.Cols, a.Data, max(1, a.Stride), work)
 }
 
+// Langb computes the specified norm of the m×n band matrix A. If
+// norm == lapack.MaxColumnSum work must have length at least n and this
+// function will panic otherwise.
+// There are no restrictions on work for the other matrix norms.
+func Langb(norm lapack.MatrixNorm, a blas64.Band, work []float64) float64 {
+	return lapack64.Dlangb(norm, a.Rows, a.Cols, a.KL, a.KU, a.Data, max(1, a.Stride), work)
+}
+
+// Lansb computes the specified norm of an n×n symmetric band matrix. If
+// norm == lapack.MaxColumnSum or norm == lapack.MaxRowSum work must have
+// length at least n and this function will panic otherwise.
+// There are no restrictions on work for the other matrix norms.
+func Lansb(norm lapack.MatrixNorm, a blas64.SymmetricBand, work []float64) float64 {
+	return lapack64.Dlansb(norm, a.Uplo, a.N, a.K, a.Data, max(1, a.Stride), work)
+}
+
 // Lansy computes the specified norm of an n×n symmetric matrix. If
 // norm == lapack.MaxColumnSum or norm == lapackMaxRowSum work must have length
 // at least n and this function will panic otherwise.
@@ -385,6 +675,14 @@ func Lansy(norm lapack.MatrixNorm, a blas64.Symmetric, work []float64) float64 {
 	return lapack64.Dlansy(norm, a.Uplo, a.N, a.Data, max(1, a.Stride), work)
 }
 
+// Lantb computes the specified norm of an n×n triangular band matrix A. If
+// norm == lapack.MaxColumnSum work must have length at least n and this
+// function will panic otherwise.
+// There are no restrictions on work for the other matrix norms.
+func Lantb(norm lapack.MatrixNorm, a blas64.TriangularBand, work []float64) float64 {
+	return lapack64.Dlantb(norm, a.Uplo, a.Diag, a.N, a.K, a.Data, max(1, a.Stride), work)
+}
+
 // Lantr computes the specified norm of an m×n trapezoidal matrix A. If
 // norm == lapack.MaxColumnSum work must have length at least n and this function
 // will panic otherwise. There are no restrictions on work for the other matrix norms.
@@ -392,6 +690,20 @@ func Lantr(norm lapack.MatrixNorm, a blas64.Triangular, work []float64) float64
 	return lapack64.Dlantr(norm, a.Uplo, a.Diag, a.N, a.N, a.Data, max(1, a.Stride), work)
 }
 
+// Laqge equilibrates the m×n matrix A using the row and column scaling
+// factors in r and c computed by Geequ, if the scaling is worthwhile. The
+// returned value indicates the form of equilibration that was applied.
+func Laqge(a blas64.General, r, c []float64, rowcnd, colcnd, amax float64) lapack.Equilibration {
+	return lapack64.Dlaqge(a.Rows, a.Cols, a.Data, max(1, a.Stride), r, c, rowcnd, colcnd, amax)
+}
+
+// Laqsy equilibrates the symmetric matrix A using the scaling factors in s
+// computed by Poequ, replacing it by diag(s)*A*diag(s) if the scaling is
+// worthwhile. The returned value indicates whether scaling was applied.
+func Laqsy(a blas64.Symmetric, s []float64, scond, amax float64) lapack.Equilibration {
+	return lapack64.Dlaqsy(a.Uplo, a.N, a.Data, max(1, a.Stride), s, scond, amax)
+}
+
 // Lapmt rearranges the columns of the m×n matrix X as specified by the
 // permutation k_0, k_1, ..., k_{n-1} of the integers 0, ..., n-1.
 //
@@ -461,6 +773,50 @@ func Ormqr(side blas.Side, trans blas.Transpose, a blas64.General, tau []float64
 	lapack64.Dormqr(side, trans, c.Rows, c.Cols, a.Cols, a.Data, max(1, a.Stride), tau, c.Data, max(1, c.Stride), work, lwork)
 }
 
+// Pbcon estimates the reciprocal of the condition number of a symmetric
+// positive definite band matrix A given the Cholesky factorization of A
+// computed by Pbtrf. The condition number computed is based on the 1-norm and
+// the ∞-norm.
+//
+// anorm is the 1-norm and the ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Pbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Pbcon will panic otherwise.
+func Pbcon(t blas64.TriangularBand, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dpbcon(t.Uplo, t.N, t.K, t.Data, max(1, t.Stride), anorm, work, iwork)
+}
+
+// Pbtrf computes the Cholesky factorization of the symmetric positive
+// definite band matrix a. The factorization has the form
+//  A = U^T * U  if a.Uplo == blas.Upper, or
+//  A = L * L^T  if a.Uplo == blas.Lower,
+// where U is an upper triangular band matrix and L is lower triangular, both
+// with the same bandwidth as A. The triangular matrix is returned in t, and
+// the underlying data between a and t is shared. The returned bool indicates
+// whether a is positive definite and the factorization could be finished.
+func Pbtrf(a blas64.SymmetricBand) (t blas64.TriangularBand, ok bool) {
+	ok = lapack64.Dpbtrf(a.Uplo, a.N, a.K, a.Data, max(1, a.Stride))
+	t.Uplo = a.Uplo
+	t.Diag = blas.NonUnit
+	t.N = a.N
+	t.K = a.K
+	t.Data = a.Data
+	t.Stride = a.Stride
+	return t, ok
+}
+
+// Pbtrs solves a system of linear equations A*X = B with an n×n symmetric
+// positive definite band matrix A using the Cholesky factorization
+//  A = U^T * U  if t.Uplo == blas.Upper
+//  A = L * L^T  if t.Uplo == blas.Lower
+// t contains the corresponding triangular factor as returned by Pbtrf. On
+// entry, b contains the right-hand side matrix B and on return it is
+// overwritten with the solution matrix X.
+func Pbtrs(t blas64.TriangularBand, b blas64.General) {
+	lapack64.Dpbtrs(t.Uplo, t.N, t.K, b.Cols, t.Data, max(1, t.Stride), b.Data, max(1, b.Stride))
+}
+
 // Pocon estimates the reciprocal of the condition number of a positive-definite
 // matrix A given the Cholesky decmposition of A. The condition number computed
 // is based on the 1-norm and the ∞-norm.
@@ -474,6 +830,57 @@ func Pocon(a blas64.Symmetric, anorm float64, work []float64, iwork []int) float
 	return lapack64.Dpocon(a.Uplo, a.N, a.Data, max(1, a.Stride), anorm, work, iwork)
 }
 
+// Poequ computes scaling factors intended to equilibrate the symmetric
+// positive definite matrix A so that diag(s)*A*diag(s) has ones on the
+// diagonal. scond is the ratio of the smallest to the largest s[i] and amax
+// is the absolute value of the largest diagonal element of A. If a diagonal
+// element of A is not positive, ok is false.
+//
+// s must have length at least n, otherwise Poequ will panic.
+func Poequ(a blas64.Symmetric, s []float64) (scond, amax float64, ok bool) {
+	return lapack64.Dpoequ(a.N, a.Data, max(1, a.Stride), s)
+}
+
+// Posvx uses the Cholesky factorization to compute the solution to a system
+// of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite matrix and X and B are
+// n×nrhs matrices, with optional equilibration, iterative refinement and
+// error bounds.
+//
+// If fact is lapack.Factored, af contains on entry the Cholesky factorization
+// of A, and equed and s describe the equilibration that was applied to A.
+// Otherwise af is overwritten by the Cholesky factorization of the
+// equilibrated matrix. af must have the same triangle as a.
+//
+// On return x contains the solution matrix, and ferr and berr contain the
+// forward error bounds and the componentwise backward errors of the solution
+// columns. rcond is the estimate of the reciprocal condition number of the
+// equilibrated A. ok is false if A is not positive definite or is singular
+// to working precision.
+//
+// See the documentation for lapack/gonum.Dposvx for more details.
+func Posvx(fact lapack.FactJob, a blas64.Symmetric, af blas64.Triangular, equed lapack.Equilibration, s []float64, b, x blas64.General, ferr, berr, work []float64, iwork []int) (equedOut lapack.Equilibration, rcond float64, ok bool) {
+	if a.Uplo != af.Uplo {
+		panic("lapack64: mismatched triangles")
+	}
+	return lapack64.Dposvx(fact, a.Uplo, a.N, b.Cols, a.Data, max(1, a.Stride), af.Data, max(1, af.Stride), equed, s, b.Data, max(1, b.Stride), x.Data, max(1, x.Stride), ferr, berr, work, iwork)
+}
+
+// Ptsv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite tridiagonal matrix. d and e
+// contain the n diagonal and (n-1) sub-diagonal elements, respectively, of A,
+// and they are overwritten by the L*D*L^T factorization of A on return. On
+// entry, b contains the n×nrhs right-hand side matrix B and on return it is
+// overwritten with the solution matrix X.
+//
+// Ptsv returns whether the solution has been successfully computed, which is
+// the case if and only if A is positive definite.
+func Ptsv(d, e []float64, b blas64.General) (ok bool) {
+	return lapack64.Dptsv(b.Rows, b.Cols, d, e, b.Data, max(1, b.Stride))
+}
+
 // Syev computes all eigenvalues and, optionally, the eigenvectors of a real
 // symmetric matrix A.
 //
@@ -493,6 +900,120 @@ func Syev(jobz lapack.EVJob, a blas64.Symmetric, w, work []float64, lwork int) (
 	return lapack64.Dsyev(jobz, a.Uplo, a.N, a.Data, max(1, a.Stride), w, work, lwork)
 }
 
+// Syevd computes all eigenvalues and, optionally, the eigenvectors of a real
+// symmetric matrix A using the divide and conquer method.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Syevd will panic otherwise.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. If jobz == lapack.EVCompute, a contains the
+// orthonormal eigenvectors of A on exit, otherwise jobz must be lapack.EVNone
+// and on exit the specified triangular region is overwritten.
+//
+// work must have length at least max(1, lwork) and iwork must have length at
+// least max(1, liwork). If n <= 1, lwork and liwork must be at least 1. If
+// jobz == lapack.EVNone, lwork must be at least 2*n+1 and liwork at least 1.
+// If jobz == lapack.EVCompute, lwork must be at least 1+6*n+3*n*n and liwork
+// at least 3+5*n. If lwork == -1 or liwork == -1, instead of computing the
+// decomposition, the optimal lengths of work and iwork are stored into work[0]
+// and iwork[0]. Syevd will panic if the workspace is insufficient.
+//
+// Syevd returns whether the decomposition was successful.
+func Syevd(jobz lapack.EVJob, a blas64.Symmetric, w, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	return lapack64.Dsyevd(jobz, a.Uplo, a.N, a.Data, max(1, a.Stride), w, work, lwork, iwork, liwork)
+}
+
+// Syevr computes selected eigenvalues and, optionally, eigenvectors of a real
+// symmetric matrix A using the algorithm of Multiple Relatively Robust
+// Representations.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. On exit, the contents of a are destroyed.
+//
+// rng specifies which eigenvalues are computed. If rng == lapack.EVRangeAll, all
+// eigenvalues are computed. If rng == lapack.EVRangeValue, the eigenvalues in
+// the half-open interval (vl, vu] are computed and vl must be less than vu. If
+// rng == lapack.EVRangeIndex, the eigenvalues with zero-based indices il
+// through iu, inclusive, are computed.
+//
+// The number of eigenvalues found is returned in m and the eigenvalues are
+// stored in ascending order in w[:m]. w must have length at least n. If
+// jobz == lapack.EVCompute, the orthonormal eigenvectors corresponding to w[:m]
+// are stored in the first m columns of z.
+//
+// work must have length at least max(1, lwork) and iwork must have length at
+// least max(1, liwork). lwork must be at least max(1, 10*n) if jobz ==
+// lapack.EVNone and max(1, 22*n) if jobz == lapack.EVCompute, and liwork must
+// be at least max(1, 4*n). If lwork == -1 or liwork == -1, instead of computing
+// the eigenvalues, the optimal lengths of work and iwork are stored into
+// work[0] and iwork[0]. Syevr will panic if the workspace is insufficient.
+//
+// Syevr returns whether the computation was successful.
+func Syevr(jobz lapack.EVJob, rng lapack.EVRange, a blas64.Symmetric, vl, vu float64, il, iu int, w []float64, z blas64.General, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	return lapack64.Dsyevr(jobz, rng, a.Uplo, a.N, a.Data, max(1, a.Stride), vl, vu, il, iu, w, z.Data, max(1, z.Stride), work, lwork, iwork, liwork)
+}
+
+// Sycon estimates the reciprocal of the condition number in the 1-norm of a
+// symmetric matrix A given the Bunch-Kaufman factorization of A computed by
+// Sytrf.
+//
+// anorm is the 1-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Sycon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Sycon will panic otherwise.
+func Sycon(a blas64.Symmetric, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dsycon(a.Uplo, a.N, a.Data, max(1, a.Stride), ipiv, anorm, work, iwork)
+}
+
+// Sytrf computes the factorization of a symmetric matrix A using the
+// Bunch-Kaufman diagonal pivoting method. The form of the factorization is
+//  A = U*D*U^T  if a.Uplo == blas.Upper, or
+//  A = L*D*L^T  if a.Uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower) triangular
+// matrices, and D is symmetric and block diagonal with 1×1 and 2×2 diagonal
+// blocks. On return, a contains D and the multipliers used to obtain the
+// factor U or L, and ipiv contains details of the interchanges and the block
+// structure of D. See the documentation of gonum.Implementation.Dsytrf for the
+// details of the storage. ipiv must have length n.
+//
+// Work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and Sytrf will panic otherwise. The amount of blocking
+// is limited by the usable length. If lwork == -1, instead of computing Sytrf
+// the optimal work length is stored into work[0].
+//
+// Sytrf returns whether D is nonsingular.
+func Sytrf(a blas64.Symmetric, ipiv []int, work []float64, lwork int) (ok bool) {
+	return lapack64.Dsytrf(a.Uplo, a.N, a.Data, max(1, a.Stride), ipiv, work, lwork)
+}
+
+// Sytrs solves a system of linear equations A*X = B with a symmetric matrix A
+// using the Bunch-Kaufman factorization of A computed by Sytrf. On entry, b
+// contains the right-hand side matrix B and on return it is overwritten with
+// the solution matrix X.
+func Sytrs(a blas64.Symmetric, ipiv []int, b blas64.General) {
+	lapack64.Dsytrs(a.Uplo, a.N, b.Cols, a.Data, max(1, a.Stride), ipiv, b.Data, max(1, b.Stride))
+}
+
+// Tbcon estimates the reciprocal of the condition number of a triangular
+// band matrix A. The condition number computed may be based on the 1-norm or
+// the ∞-norm.
+//
+// work is a temporary data slice of length at least 2*n and Tbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Tbcon will panic otherwise.
+func Tbcon(norm lapack.MatrixNorm, a blas64.TriangularBand, work []float64, iwork []int) float64 {
+	return lapack64.Dtbcon(norm, a.Uplo, a.Diag, a.N, a.K, a.Data, max(1, a.Stride), work, iwork)
+}
+
+// Tbtrs solves a triangular system of the form A * X = B or A^T * X = B where
+// A is a triangular band matrix. Tbtrs returns whether the solve completed
+// successfully. If A is singular, no solve is performed.
+func Tbtrs(trans blas.Transpose, a blas64.TriangularBand, b blas64.General) (ok bool) {
+	return lapack64.Dtbtrs(a.Uplo, trans, a.Diag, a.N, a.K, b.Cols, a.Data, max(1, a.Stride), b.Data, max(1, b.Stride))
+}
+
 // Trcon estimates the reciprocal of the condition number of a triangular matrix A.
 // The condition number computed may be based on the 1-norm or the ∞-norm.
 //
@@ -579,3 +1100,192 @@ func Geev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, a blas64.General, wr,
 	}
 	return lapack64.Dgeev(jobvl, jobvr, n, a.Data, max(1, a.Stride), wr, wi, vl.Data, max(1, vl.Stride), vr.Data, max(1, vr.Stride), work, lwork)
 }
+
+// Gehrd reduces a block of a real n×n general matrix A to upper Hessenberg form
+// H by an orthogonal similarity transformation Q^T * A * Q = H.
+//
+// The function does not check that the block is isolated, ilo and ihi are
+// typically set by a previous call to Gebal, otherwise they should be set to
+// 0 and n-1, respectively.
+//
+// On return, the upper triangle and the first subdiagonal of A will be
+// overwritten with the upper Hessenberg matrix H, and the elements below the
+// first subdiagonal, with the slice tau, represent the orthogonal matrix Q as
+// a product of elementary reflectors. tau must have length n-1.
+//
+// work must have length at least lwork and lwork must be at least max(1,n),
+// otherwise Gehrd will panic. If lwork == -1, instead of performing Gehrd,
+// only the optimal value of lwork will be stored in work[0].
+func Gehrd(ilo, ihi int, a blas64.General, tau, work []float64, lwork int) {
+	n := a.Rows
+	if a.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	lapack64.Dgehrd(n, ilo, ihi, a.Data, max(1, a.Stride), tau, work, lwork)
+}
+
+// Orghr generates an n×n orthogonal matrix Q which is defined as the product
+// of ihi-ilo elementary reflectors as returned by Gehrd. On entry, a must
+// contain the vectors which define the elementary reflectors and on return it
+// will be overwritten with the matrix Q.
+//
+// work must have length at least lwork and lwork must be at least ihi-ilo,
+// otherwise Orghr will panic. If lwork == -1, instead of performing Orghr,
+// only the optimal value of lwork will be stored in work[0].
+func Orghr(ilo, ihi int, a blas64.General, tau, work []float64, lwork int) {
+	n := a.Rows
+	if a.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	lapack64.Dorghr(n, ilo, ihi, a.Data, max(1, a.Stride), tau, work, lwork)
+}
+
+// Hseqr computes the eigenvalues of an n×n Hessenberg matrix H and,
+// optionally, the matrices T and Z from the Schur decomposition
+//  H = Z T Z^T,
+// where T is an n×n upper quasi-triangular matrix (the Schur form), and Z is
+// the n×n orthogonal matrix of Schur vectors.
+//
+// If compz == lapack.SchurOrig, on entry z is assumed to contain the
+// orthogonal matrix Q that reduced the original matrix A to the Hessenberg
+// form H, and on return z will contain the Schur vectors of A, Q*Z.
+//
+// wr and wi must have length n and on return contain the real and imaginary
+// parts of the eigenvalues.
+//
+// work must have length at least lwork and lwork must be at least max(1,n),
+// otherwise Hseqr will panic. If lwork == -1, instead of performing Hseqr,
+// only the optimal value of lwork will be stored in work[0].
+//
+// unconverged indicates whether Hseqr computed all the eigenvalues. If
+// unconverged is not zero, some eigenvalues have not converged.
+func Hseqr(job lapack.SchurJob, compz lapack.SchurComp, ilo, ihi int, h blas64.General, wr, wi []float64, z blas64.General, work []float64, lwork int) (unconverged int) {
+	n := h.Rows
+	if h.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if compz != lapack.SchurNone && (z.Rows != n || z.Cols != n) {
+		panic("lapack64: bad size of Z")
+	}
+	return lapack64.Dhseqr(job, compz, n, ilo, ihi, h.Data, max(1, h.Stride), wr, wi, z.Data, max(1, z.Stride), work, lwork)
+}
+
+// Trexc reorders the real Schur factorization of a n×n real matrix
+//  A = Q*T*Q^T
+// so that the diagonal block of T with row index ifst is moved to row ilst.
+// T must be in Schur canonical form.
+//
+// If compq is lapack.UpdateSchur, on return the matrix Q of Schur vectors will
+// be updated by post-multiplying it with the orthogonal transformation that
+// reorders T.
+//
+// ifstOut is the first row of the moved block before the reordering and
+// ilstOut is the first row of the block in its final position. If ok is false,
+// two adjacent blocks were too close to swap and T may have been partially
+// reordered.
+//
+// work must have length at least n, otherwise Trexc will panic.
+func Trexc(compq lapack.UpdateSchurComp, t, q blas64.General, ifst, ilst int, work []float64) (ifstOut, ilstOut int, ok bool) {
+	n := t.Rows
+	if t.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if compq == lapack.UpdateSchur && (q.Rows != n || q.Cols != n) {
+		panic("lapack64: bad size of Q")
+	}
+	return lapack64.Dtrexc(compq, n, t.Data, max(1, t.Stride), q.Data, max(1, q.Stride), ifst, ilst, work)
+}
+
+// Trsyl solves the real Sylvester matrix equation
+//  op(A)*X + isgn*X*op(B) = scale*C
+// where op(A) = A or A^T according to trana, and op(B) = B or B^T according
+// to tranb. A and B must be upper quasi-triangular matrices in Schur canonical
+// form and isgn must be 1 or -1.
+//
+// On return, c is overwritten with the solution X. scale is a scaling factor
+// less than or equal to 1 chosen to avoid overflow. If ok is false, A and
+// -isgn*B have common or very close eigenvalues and perturbed values were used
+// to solve the equation.
+func Trsyl(trana, tranb blas.Transpose, isgn int, a, b, c blas64.General) (scale float64, ok bool) {
+	m := a.Rows
+	n := b.Rows
+	if a.Cols != m || b.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if c.Rows != m || c.Cols != n {
+		panic("lapack64: bad size of C")
+	}
+	return lapack64.Dtrsyl3(trana, tranb, isgn, m, n, a.Data, max(1, a.Stride), b.Data, max(1, b.Stride), c.Data, max(1, c.Stride))
+}
+
+// Sygst reduces a symmetric-definite generalized eigenproblem to standard
+// form.
+//
+// If itype == lapack.AxLBx, the problem A*x = λ*B*x is reduced to
+//  inv(U^T)*A*inv(U) if a.Uplo == blas.Upper,
+//  inv(L)*A*inv(L^T) if a.Uplo == blas.Lower.
+// If itype == lapack.ABxLx or lapack.BAxLx, the problem A*B*x = λ*x or
+// B*A*x = λ*x is reduced to
+//  U*A*U^T if a.Uplo == blas.Upper,
+//  L^T*A*L if a.Uplo == blas.Lower.
+//
+// b must contain the Cholesky factor of B as computed by Potrf, and b.Uplo
+// must be equal to a.Uplo, otherwise Sygst will panic. On return, a is
+// overwritten by the transformed matrix.
+func Sygst(itype lapack.GenEVType, a blas64.Symmetric, b blas64.Triangular) {
+	if a.Uplo != b.Uplo {
+		panic("lapack64: mismatched uplo")
+	}
+	if a.N != b.N {
+		panic("lapack64: mismatched size")
+	}
+	lapack64.Dsygst(itype, a.Uplo, a.N, a.Data, max(1, a.Stride), b.Data, max(1, b.Stride))
+}
+
+// Ggev computes the generalized eigenvalues and, optionally, the left and/or
+// right generalized eigenvectors for a pair of n×n real nonsymmetric matrices
+// (A,B).
+//
+// The right eigenvector v_j corresponding to the eigenvalue λ_j of (A,B)
+// satisfies
+//  A * v_j = λ_j * B * v_j,
+// and the left eigenvector u_j satisfies
+//  u_j^H * A = λ_j * u_j^H * B,
+// where u_j^H is the conjugate transpose of u_j.
+//
+// The eigenvalues are returned as pairs (alpha,beta) with λ_j = alpha_j/beta_j,
+// where the real and imaginary parts of alpha are stored in alphar and alphai.
+// beta may be zero, which corresponds to an infinite eigenvalue. Complex
+// conjugate pairs of eigenvalues appear consecutively with the eigenvalue
+// having the positive imaginary part first. alphar, alphai and beta must have
+// length n.
+//
+// The eigenvectors are stored in the columns of vl and vr in the same order
+// as their eigenvalues, and complex eigenvectors are stored as in Geev. Each
+// eigenvector is scaled so that its largest component has
+// |real part| + |imag. part| = 1.
+//
+// On return, A and B will be overwritten.
+//
+// work must have length at least lwork and lwork must be at least max(1,8*n),
+// otherwise Ggev will panic. If lwork == -1, instead of performing Ggev, the
+// function only calculates the optimal value of lwork and stores it into
+// work[0].
+//
+// The returned bool indicates whether the computation succeeded.
+func Ggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, a, b blas64.General, alphar, alphai, beta []float64, vl, vr blas64.General, work []float64, lwork int) (ok bool) {
+	n := a.Rows
+	if a.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if b.Rows != n || b.Cols != n {
+		panic("lapack64: bad size of B")
+	}
+	if jobvl == lapack.LeftEVCompute && (vl.Rows != n || vl.Cols != n) {
+		panic("lapack64: bad size of VL")
+	}
+	if jobvr == lapack.RightEVCompute && (vr.Rows != n || vr.Cols != n) {
+		panic("lapack64: bad size of VR")
+	}
+	return lapack64.Dggev(jobvl, jobvr, n, a.Data, max(1, a.Stride), b.Data, max(1, b.Stride), alphar, alphai, beta, vl.Data, max(1, vl.Stride), vr.Data, max(1, vr.Stride), work, lwork)
+}
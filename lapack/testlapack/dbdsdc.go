@@ -0,0 +1,111 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dbdsdcer interface {
+	Dbdsdc(uplo blas.Uplo, compq lapack.OrthoComp, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool)
+}
+
+func DbdsdcTest(t *testing.T, impl Dbdsdcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 26, 50, 101} {
+			for _, ld := range []int{n, n + 5} {
+				for kind := 0; kind < 6; kind++ {
+					dbdsdcTest(t, impl, rnd, uplo, n, ld, kind)
+				}
+			}
+		}
+	}
+}
+
+func dbdsdcTest(t *testing.T, impl Dbdsdcer, rnd *rand.Rand, uplo blas.Uplo, n, ld, kind int) {
+	const tol = 1e-13
+
+	ld = max(1, ld)
+	prefix := fmt.Sprintf("uplo=%c,n=%v,ld=%v,kind=%v", uplo, n, ld, kind)
+
+	d, e := symTridiagTestMatrix(kind, n, rnd)
+	dCopy := make([]float64, len(d))
+	copy(dCopy, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+
+	u := nanGeneral(n, n, ld)
+	vt := nanGeneral(n, n, ld)
+	work := nanSlice(max(1, 3*n*n+7*n+1))
+	iwork := make([]int, 4*n)
+
+	ok := impl.Dbdsdc(uplo, lapack.OrthoExplicit, n, d, e, u.Data, u.Stride, vt.Data, vt.Stride, work, iwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	if !sort.IsSorted(sort.Reverse(sort.Float64Slice(d))) {
+		t.Errorf("%v: singular values not sorted in decreasing order", prefix)
+	}
+	if d[n-1] < 0 {
+		t.Errorf("%v: negative singular value", prefix)
+	}
+	if !generalOutsideAllNaN(u) {
+		t.Errorf("%v: out-of-range write to U", prefix)
+	}
+	if !generalOutsideAllNaN(vt) {
+		t.Errorf("%v: out-of-range write to VT", prefix)
+	}
+	if !isOrthogonal(u) {
+		t.Errorf("%v: U is not orthogonal", prefix)
+	}
+	if !isOrthogonal(vt) {
+		t.Errorf("%v: VT is not orthogonal", prefix)
+	}
+
+	// Check that U*S*VT recovers B.
+	b := constructBidiagonal(uplo, n, dCopy, eCopy)
+	bnorm := math.Max(1, floats.Norm(b.Data, math.Inf(1)))
+	us := cloneGeneral(u)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			us.Data[i*us.Stride+j] *= d[j]
+		}
+	}
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, us, vt, -1, b)
+	if resid := floats.Norm(b.Data, math.Inf(1)) / bnorm / float64(n); resid > tol {
+		t.Errorf("%v: unexpected residual |B - U*S*VT| = %v", prefix, resid)
+	}
+
+	// Check that the singular values are the same when the singular vectors
+	// are not computed.
+	s := make([]float64, n)
+	copy(s, dCopy)
+	copy(e, eCopy)
+	work = nanSlice(4 * n)
+	ok = impl.Dbdsdc(uplo, lapack.OrthoNone, n, s, e, nil, 1, nil, 1, work, iwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure when singular vectors not computed", prefix)
+		return
+	}
+	if !floats.EqualApprox(s, d, tol*bnorm) {
+		t.Errorf("%v: singular values differ when singular vectors not computed", prefix)
+	}
+}
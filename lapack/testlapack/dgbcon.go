@@ -0,0 +1,71 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgbconer interface {
+	Dgbtrfer
+	Dgetrier
+	Dlange(norm lapack.MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+	Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64
+	Dgbcon(norm lapack.MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DgbconTest(t *testing.T, impl Dgbconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxColumnSum, lapack.MaxRowSum} {
+		for _, n := range []int{1, 2, 3, 5, 10, 50} {
+			for _, kl := range []int{0, 1, 2, 5} {
+				for _, ku := range []int{0, 1, 3} {
+					ldab := 2*kl + ku + 1 + rnd.Intn(3)
+					name := fmt.Sprintf("norm=%c,n=%v,kl=%v,ku=%v,ldab=%v", norm, n, kl, ku, ldab)
+
+					ab := append(randomBand(n, n, kl, ku, ldab, rnd), nanSlice(kl)...)
+					a := bandToGeneral(n, n, kl, ku, ab, ldab)
+
+					// Compute the exact reciprocal condition number
+					// from the explicit inverse.
+					ipiv := make([]int, n)
+					impl.Dgetrf(n, n, a.Data, a.Stride, ipiv)
+					work := make([]float64, 1)
+					impl.Dgetri(n, a.Data, a.Stride, ipiv, work, -1)
+					work = make([]float64, int(work[0]))
+					impl.Dgetri(n, a.Data, a.Stride, ipiv, work, len(work))
+					work = make([]float64, n)
+					anorm := impl.Dlangb(norm, n, n, kl, ku, ab, ldab, work)
+					ainvnm := impl.Dlange(norm, n, n, a.Data, a.Stride, work)
+					want := 1 / anorm / ainvnm
+
+					ok := impl.Dgbtrf(n, n, kl, ku, ab, ldab, ipiv)
+					if !ok {
+						t.Errorf("%v: bad test, matrix is singular", name)
+						continue
+					}
+
+					work = make([]float64, 2*n)
+					iwork := make([]int, n)
+					got := impl.Dgbcon(norm, n, kl, ku, ab, ldab, ipiv, anorm, work, iwork)
+
+					// The estimate of the norm of the inverse is a
+					// lower bound, so the estimated reciprocal
+					// condition number must not be smaller than the
+					// exact one. It should also be within a small
+					// factor of it.
+					if got < want*(1-1e-8) || got > 10*want {
+						t.Errorf("%v: unexpected rcond; got %v, want %v", name, got, want)
+					}
+				}
+			}
+		}
+	}
+}
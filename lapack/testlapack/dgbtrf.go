@@ -0,0 +1,93 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dgbtrfer interface {
+	Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+}
+
+func DgbtrfTest(t *testing.T, impl Dgbtrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 5, 10, 31} {
+		for _, n := range []int{0, 1, 2, 5, 10, 31} {
+			for _, kl := range []int{0, 1, 2, 5} {
+				for _, ku := range []int{0, 1, 2, 5} {
+					for _, extra := range []int{0, 3} {
+						testDgbtrf(t, impl, m, n, kl, ku, 2*kl+ku+1+extra, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgbtrf(t *testing.T, impl Dgbtrfer, m, n, kl, ku, ldab int, rnd *rand.Rand) {
+	name := fmt.Sprintf("m=%v,n=%v,kl=%v,ku=%v,ldab=%v", m, n, kl, ku, ldab)
+
+	// The fill-in elements of U need not be set on entry.
+	ab := append(randomBand(m, n, kl, ku, ldab, rnd), nanSlice(kl)...)
+	want := bandToGeneral(m, n, kl, ku, ab, ldab)
+
+	ipiv := make([]int, min(m, n))
+	ok := impl.Dgbtrf(m, n, kl, ku, ab, ldab, ipiv)
+	if !ok {
+		t.Errorf("%v: unexpected singular matrix", name)
+		return
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+
+	for j, p := range ipiv {
+		if p < j || min(m-1, j+kl) < p {
+			t.Errorf("%v: ipiv[%v]=%v out of range", name, j, p)
+			return
+		}
+	}
+
+	got := constructPLUBand(m, n, kl, ku, ab, ldab, ipiv)
+	if !equalApproxGeneral(got, want, 1e-13) {
+		t.Errorf("%v: P*L*U does not equal A", name)
+	}
+}
+
+// constructPLUBand returns the product P*L*U from the LU factorization of
+// an m×n band matrix as computed by Dgbtrf.
+func constructPLUBand(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) blas64.General {
+	kv := kl + ku
+	// Start with the upper triangular factor U which has kv super-diagonals.
+	a := zeros(m, n, n)
+	for i := 0; i < min(m, n); i++ {
+		for j := i; j <= min(n-1, i+kv); j++ {
+			a.Data[i*a.Stride+j] = ab[i*ldab+kl+j-i]
+		}
+	}
+	// Apply the elementary transformations in reverse order, so that
+	//  A = P[0]*L[0]*P[1]*L[1]*...*P[k-1]*L[k-1]*U.
+	for j := min(m, n) - 1; j >= 0; j-- {
+		km := min(kl, m-j-1)
+		for r := 1; r <= km; r++ {
+			l := ab[(j+r)*ldab+kl-r]
+			for c := 0; c < n; c++ {
+				a.Data[(j+r)*a.Stride+c] += l * a.Data[j*a.Stride+c]
+			}
+		}
+		if p := ipiv[j]; p != j {
+			for c := 0; c < n; c++ {
+				a.Data[j*a.Stride+c], a.Data[p*a.Stride+c] = a.Data[p*a.Stride+c], a.Data[j*a.Stride+c]
+			}
+		}
+	}
+	return a
+}
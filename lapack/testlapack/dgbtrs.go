@@ -0,0 +1,74 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgbtrser interface {
+	Dgbtrfer
+	Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int)
+	Dlange(norm lapack.MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+}
+
+func DgbtrsTest(t *testing.T, impl Dgbtrser) {
+	rnd := rand.New(rand.NewSource(1))
+	bi := blas64.Implementation()
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{0, 1, 2, 5, 10, 50} {
+			for _, kl := range []int{0, 1, 2, 5} {
+				for _, ku := range []int{0, 1, 3} {
+					for _, nrhs := range []int{0, 1, 3} {
+						for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+							ldab := 2*kl + ku + 1 + rnd.Intn(3)
+							name := fmt.Sprintf("trans=%c,n=%v,kl=%v,ku=%v,nrhs=%v,ldab=%v,ldb=%v", trans, n, kl, ku, nrhs, ldab, ldb)
+
+							ab := append(randomBand(n, n, kl, ku, ldab, rnd), nanSlice(kl)...)
+							a := bandToGeneral(n, n, kl, ku, ab, ldab)
+
+							// Generate a random right-hand side B and
+							// keep a copy for computing the residual.
+							b := randomGeneral(n, nrhs, ldb, rnd)
+							bCopy := cloneGeneral(b)
+
+							ipiv := make([]int, n)
+							ok := impl.Dgbtrf(n, n, kl, ku, ab, ldab, ipiv)
+							if !ok {
+								t.Errorf("%v: bad test, matrix is singular", name)
+								continue
+							}
+
+							impl.Dgbtrs(trans, n, kl, ku, nrhs, ab, ldab, ipiv, b.Data, ldb)
+
+							if n == 0 || nrhs == 0 {
+								continue
+							}
+							// Compute the residual |op(A)*X - B| and
+							// check that it is small relative to
+							// |A|*|X|.
+							x := b
+							bi.Dgemm(trans, blas.NoTrans, n, nrhs, n, 1, a.Data, a.Stride, x.Data, ldb, -1, bCopy.Data, ldb)
+							work := make([]float64, max(n, nrhs))
+							resid := impl.Dlange(lapack.MaxColumnSum, n, nrhs, bCopy.Data, ldb, work)
+							anorm := impl.Dlange(lapack.MaxColumnSum, n, n, a.Data, a.Stride, work)
+							xnorm := impl.Dlange(lapack.MaxColumnSum, n, nrhs, x.Data, ldb, work)
+							if resid > 1e-13*float64(n)*anorm*xnorm {
+								t.Errorf("%v: residual too large; |op(A)*X-B|=%v, |A|=%v, |X|=%v", name, resid, anorm, xnorm)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+type Dgeequer interface {
+	Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool)
+}
+
+func DgeequTest(t *testing.T, impl Dgeequer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 3, 5, 10, 23} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 23} {
+			for _, extra := range []int{0, 3} {
+				testDgeequ(t, impl, rnd, m, n, extra)
+			}
+		}
+	}
+}
+
+func testDgeequ(t *testing.T, impl Dgeequer, rnd *rand.Rand, m, n, extra int) {
+	const tol = 1e-14
+
+	name := fmt.Sprintf("m=%v,n=%v,extra=%v", m, n, extra)
+
+	a := randomGeneral(m, n, n+extra, rnd)
+	scaleRowsCols(a, rnd)
+	aCopy := cloneGeneral(a)
+
+	r := nanSlice(m)
+	c := nanSlice(n)
+	rowcnd, colcnd, amax, ok := impl.Dgeequ(m, n, a.Data, a.Stride, r, c)
+	if !ok {
+		t.Errorf("%v: unexpected failure", name)
+		return
+	}
+	if !equalApproxGeneral(a, aCopy, 0) {
+		t.Errorf("%v: unexpected modification of A", name)
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+
+	var amaxWant float64
+	for _, v := range a.Data {
+		if !math.IsNaN(v) {
+			amaxWant = math.Max(amaxWant, math.Abs(v))
+		}
+	}
+	if amax != amaxWant {
+		t.Errorf("%v: unexpected amax, got %v, want %v", name, amax, amaxWant)
+	}
+	if want := scaleRatio(r); math.Abs(rowcnd-want) > tol*want {
+		t.Errorf("%v: unexpected rowcnd, got %v, want %v", name, rowcnd, want)
+	}
+	if want := scaleRatio(c); math.Abs(colcnd-want) > tol*want {
+		t.Errorf("%v: unexpected colcnd, got %v, want %v", name, colcnd, want)
+	}
+
+	// Check that every row and column of diag(r)*A*diag(c) has a largest
+	// absolute value of at most 1 and that every column attains it.
+	rowMax := make([]float64, m)
+	colMax := make([]float64, n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			v := math.Abs(r[i] * a.Data[i*a.Stride+j] * c[j])
+			rowMax[i] = math.Max(rowMax[i], v)
+			colMax[j] = math.Max(colMax[j], v)
+		}
+	}
+	for i, v := range rowMax {
+		if v > 1+tol {
+			t.Errorf("%v: row %v of the scaled matrix has largest element %v", name, i, v)
+		}
+	}
+	for j, v := range colMax {
+		if math.Abs(v-1) > tol {
+			t.Errorf("%v: column %v of the scaled matrix has largest element %v, want 1", name, j, v)
+		}
+	}
+
+	// Check that a zero row and a zero column are detected.
+	for j := 0; j < n; j++ {
+		a.Data[(m/2)*a.Stride+j] = 0
+	}
+	_, _, _, ok = impl.Dgeequ(m, n, a.Data, a.Stride, r, c)
+	if ok {
+		t.Errorf("%v: zero row not detected", name)
+	}
+	copyGeneral(a, aCopy)
+	for i := 0; i < m; i++ {
+		a.Data[i*a.Stride+n/2] = 0
+	}
+	_, _, _, ok = impl.Dgeequ(m, n, a.Data, a.Stride, r, c)
+	if ok {
+		t.Errorf("%v: zero column not detected", name)
+	}
+}
+
+// scaleRatio returns the ratio of the smallest to the largest element of s.
+func scaleRatio(s []float64) float64 {
+	smin := math.Inf(1)
+	smax := math.Inf(-1)
+	for _, v := range s {
+		smin = math.Min(smin, v)
+		smax = math.Max(smax, v)
+	}
+	return smin / smax
+}
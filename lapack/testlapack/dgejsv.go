@@ -0,0 +1,155 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgejsver interface {
+	Dgejsv(jobU, jobV lapack.SVDJob, m, n int, a []float64, lda int, sva, u []float64, ldu int, v []float64, ldv int, work []float64, lwork int, iwork []int) (ok bool)
+}
+
+func DgejsvTest(t *testing.T, impl Dgejsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, mn := range []struct{ m, n int }{
+		{0, 0}, {1, 0}, {1, 1}, {2, 1}, {2, 2}, {3, 3}, {5, 2}, {5, 5},
+		{10, 3}, {10, 10}, {20, 8}, {30, 30}, {40, 20},
+	} {
+		for mtype := 1; mtype <= 8; mtype++ {
+			for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+				dgejsvTest(t, impl, rnd, mn.m, mn.n, mtype, wl)
+			}
+		}
+	}
+}
+
+// DgejsvNetlibTest compares the singular value decomposition computed by
+// Dgejsv with the one computed by the reference implementation of DGEJSV. The
+// test data is generated by lapack/internal/testdata/dgejsvtest, and the test
+// is skipped if it has not been generated.
+func DgejsvNetlibTest(t *testing.T, impl Dgejsver) {
+	for _, test := range readSVDNetlibTests(t, "dgejsvdata.json.gz") {
+		m, n := test.M, test.N
+		prefix := fmt.Sprintf("m=%v,n=%v", m, n)
+
+		lda := n + 3
+		a := make([]float64, m*lda)
+		copyMatrix(m, n, a, lda, test.A)
+		ldu := n + 5
+		ldv := n + 7
+		sva := nanSlice(n)
+		u := nanSlice(m * ldu)
+		v := nanSlice(n * ldv)
+		work := make([]float64, 1)
+		iwork := make([]int, m+n)
+		impl.Dgejsv(lapack.SVDStore, lapack.SVDAll, m, n, a, lda, sva, u, ldu, v, ldv, work, -1, iwork)
+		work = nanSlice(int(work[0]))
+
+		ok := impl.Dgejsv(lapack.SVDStore, lapack.SVDAll, m, n, a, lda, sva, u, ldu, v, ldv, work, len(work), iwork)
+		if !ok {
+			t.Errorf("%v: unexpected failure", prefix)
+			continue
+		}
+		checkSVDNetlib(t, prefix, test, sva, u, ldu, v, ldv)
+	}
+}
+
+func dgejsvTest(t *testing.T, impl Dgejsver, rnd *rand.Rand, m, n, mtype int, wl worklen) {
+	const tol = 1e-13
+
+	lda := n + 3
+	ldu := m + 5
+	ldv := n + 7
+
+	var a []float64
+	var aNorm float64
+	if mtype == 8 {
+		// Rank-deficient matrix with zero columns.
+		a, aNorm = svdRelativeTestMatrix(m, n, lda, 3, rnd)
+		for i := 0; i < m; i++ {
+			for j := 1; j < n; j += 2 {
+				a[i*lda+j] = 0
+			}
+		}
+	} else {
+		a, aNorm = svdRelativeTestMatrix(m, n, lda, mtype, rnd)
+	}
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+	want := extendedSingularValues(m, n, a, lda)
+
+	for _, jobU := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDStore, lapack.SVDNone} {
+		for _, jobV := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDNone} {
+			prefix := fmt.Sprintf("m=%v,n=%v,mtype=%v,work=%v,jobU=%v,jobV=%v", m, n, mtype, wl, svdJobString(jobU), svdJobString(jobV))
+
+			copy(a, aCopy)
+			sva := nanSlice(n)
+			u := nanSlice(m * ldu)
+			v := nanSlice(n * ldv)
+			iwork := make([]int, m+n)
+
+			minwork := 1
+			if n > 0 {
+				minwork = 2*n*n + 2*n + max(m, 3*n+1)
+			}
+			var lwork int
+			switch wl {
+			case minimumWork:
+				lwork = minwork
+			case mediumWork:
+				work := make([]float64, 1)
+				impl.Dgejsv(jobU, jobV, m, n, a, lda, sva, u, ldu, v, ldv, work, -1, iwork)
+				lwork = (int(work[0]) + minwork) / 2
+			case optimumWork:
+				work := make([]float64, 1)
+				impl.Dgejsv(jobU, jobV, m, n, a, lda, sva, u, ldu, v, ldv, work, -1, iwork)
+				lwork = int(work[0])
+			}
+			work := nanSlice(max(1, lwork))
+
+			ok := impl.Dgejsv(jobU, jobV, m, n, a, lda, sva, u, ldu, v, ldv, work, lwork, iwork)
+			if !ok {
+				t.Errorf("%v: unexpected failure", prefix)
+				continue
+			}
+			if n == 0 {
+				continue
+			}
+
+			if !sort.IsSorted(sort.Reverse(sort.Float64Slice(sva))) {
+				t.Errorf("%v: singular values are not decreasing", prefix)
+			}
+			if resid := svdRelativeError(sva, want); resid > tol {
+				t.Errorf("%v: unexpected relative error in singular values %v", prefix, resid)
+			}
+
+			ncu := n
+			if jobU == lapack.SVDAll {
+				ncu = m
+			}
+			if jobU != lapack.SVDNone && !hasOrthonormalColumns(blas64.General{Rows: m, Cols: ncu, Data: u, Stride: ldu}) {
+				t.Errorf("%v: columns of U are not orthonormal", prefix)
+			}
+			if jobV == lapack.SVDAll && !isOrthogonal(blas64.General{Rows: n, Cols: n, Data: v, Stride: ldv}) {
+				t.Errorf("%v: V is not orthogonal", prefix)
+			}
+			if jobU == lapack.SVDNone || jobV == lapack.SVDNone {
+				continue
+			}
+			vt := transposeGeneral(blas64.General{Rows: n, Cols: n, Data: v, Stride: ldv})
+			if resid := svdFullResidual(m, n, aNorm, aCopy, lda, u, ldu, sva, vt.Data, vt.Stride); resid > tol {
+				t.Errorf("%v: original matrix not recovered, |A - U*D*VT|=%v", prefix, resid)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dgelsser interface {
+	Dgelss(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int) (rank int, ok bool)
+}
+
+func DgelssTest(t *testing.T, impl Dgelsser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 3, 5, 10, 25} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 25} {
+			for _, nrhs := range []int{0, 1, 3, 7} {
+				for _, rank := range []int{0, 1, min(m, n) / 2, min(m, n)} {
+					if rank > min(m, n) {
+						continue
+					}
+					for _, extra := range []int{0, 11} {
+						for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+							dgelssTest(t, impl, rnd, m, n, nrhs, rank, max(1, n)+extra, max(1, nrhs)+extra, wl)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func dgelssTest(t *testing.T, impl Dgelsser, rnd *rand.Rand, m, n, nrhs, rank, lda, ldb int, wl worklen) {
+	const tol = 1e-12
+
+	mn := min(m, n)
+	maxmn := max(m, n)
+
+	// Generate singular values with the requested number of non-zeros.
+	sv := make([]float64, mn)
+	for i := 0; i < rank; i++ {
+		sv[i] = 1 + 9*rnd.Float64()
+	}
+
+	// Construct A = U * D * V with random orthogonal U and V so that its
+	// pseudo-inverse is known, A^+ = V^T * D^+ * U^T.
+	u := randomOrthogonal(m, rnd)
+	v := randomOrthogonal(n, rnd)
+	d := zeros(m, n, max(1, n))
+	dpinv := zeros(n, m, max(1, m))
+	for i, s := range sv {
+		d.Data[i*d.Stride+i] = s
+		if s != 0 {
+			dpinv.Data[i*dpinv.Stride+i] = 1 / s
+		}
+	}
+	tmp := zeros(m, n, max(1, n))
+	a := zeros(m, n, lda)
+	apinv := zeros(n, m, max(1, m))
+	if m > 0 && n > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, u, d, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, tmp, v, 0, a)
+		tmp = zeros(n, m, max(1, m))
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, v, dpinv, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, u, 0, apinv)
+	}
+
+	b := randomGeneral(maxmn, nrhs, ldb, rnd)
+	bm := blas64.General{Rows: m, Cols: nrhs, Stride: b.Stride, Data: b.Data}
+	want := zeros(n, nrhs, max(1, nrhs))
+	if m > 0 && n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, apinv, bm, 0, want)
+	}
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = dgelssMinWork(m, n, nrhs)
+	case mediumWork, optimumWork:
+		work := make([]float64, 1)
+		impl.Dgelss(m, n, nrhs, a.Data, a.Stride, b.Data, b.Stride, nil, -1, work, -1)
+		lwork = int(work[0])
+		if wl == mediumWork {
+			lwork = (lwork + dgelssMinWork(m, n, nrhs)) / 2
+		}
+	}
+	work := make([]float64, lwork)
+	s := make([]float64, mn)
+	aCopy := cloneGeneral(a)
+
+	name := fmt.Sprintf("m=%d,n=%d,nrhs=%d,rank=%d,lda=%d,ldb=%d,work=%v", m, n, nrhs, rank, lda, ldb, wl)
+
+	// The computed zero singular values are only zero to within rounding
+	// error so use a threshold well above machine precision.
+	gotRank, ok := impl.Dgelss(m, n, nrhs, a.Data, a.Stride, b.Data, b.Stride, s, 1e-10, work, lwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure", name)
+		return
+	}
+	if mn == 0 {
+		return
+	}
+
+	if gotRank != rank {
+		t.Errorf("%v: unexpected rank: got %d want %d", name, gotRank, rank)
+	}
+
+	sort.Sort(sort.Reverse(sort.Float64Slice(sv)))
+	for i := range s {
+		if math.Abs(s[i]-sv[i]) > tol*math.Max(1, sv[0]) {
+			t.Errorf("%v: unexpected singular values:\ngot: %v\nwant:%v", name, s, sv)
+			break
+		}
+	}
+
+	got := blas64.General{Rows: n, Cols: nrhs, Stride: b.Stride, Data: b.Data}
+	if nrhs > 0 && !equalApproxGeneral(got, want, 1e-10) {
+		t.Errorf("%v: unexpected solution", name)
+	}
+
+	if rank > 0 {
+		// Check the effect of the rcond threshold. With rcond just above
+		// the ratio of the smallest non-zero singular value to the largest,
+		// that singular value must be treated as zero.
+		copyGeneral(a, aCopy)
+		b = randomGeneral(maxmn, nrhs, ldb, rnd)
+		rcond := sv[rank-1] / sv[0] * (1 + 1e-8)
+		gotRank, ok = impl.Dgelss(m, n, nrhs, a.Data, a.Stride, b.Data, b.Stride, s, rcond, work, lwork)
+		if !ok {
+			t.Errorf("%v: unexpected failure with rcond=%v", name, rcond)
+			return
+		}
+		var wantRank int
+		for _, v := range sv {
+			if v > rcond*sv[0] {
+				wantRank++
+			}
+		}
+		if gotRank != wantRank {
+			t.Errorf("%v: unexpected rank with rcond=%v: got %d want %d", name, rcond, gotRank, wantRank)
+		}
+	}
+}
+
+func dgelssMinWork(m, n, nrhs int) int {
+	mn := min(m, n)
+	if mn == 0 {
+		return 1
+	}
+	return 3*mn + max(max(max(m, n), nrhs), 2*mn)
+}
@@ -0,0 +1,185 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgesdder interface {
+	Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool)
+}
+
+func DgesddTest(t *testing.T, impl Dgesdder, tol float64) {
+	for _, m := range []int{0, 1, 2, 3, 5, 10, 30, 60, 150} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 30, 60, 150} {
+			for _, mtype := range []int{1, 2, 3, 4, 5} {
+				dgesddTest(t, impl, m, n, mtype, tol)
+			}
+		}
+	}
+}
+
+// dgesddTest tests a Dgesdd implementation on an m×n matrix A generated
+// according to mtype in the same way as dgesvdTest. It first computes the full
+// SVD  A = U*Sigma*V^T  and checks that
+//  - U has orthonormal columns, and V^T has orthonormal rows,
+//  - U*Sigma*V^T multiply back to A,
+//  - the singular values are non-negative and sorted in decreasing order.
+// Then the partial SVD results are computed and checked whether they match the
+// full SVD result.
+func dgesddTest(t *testing.T, impl Dgesdder, m, n, mtype int, tol float64) {
+	rnd := rand.New(rand.NewSource(1))
+
+	lda := n + 3
+	ldu := m + 5
+	ldvt := n + 7
+
+	minmn := min(m, n)
+	maxmn := max(m, n)
+
+	a := make([]float64, m*lda)
+	for i := range a {
+		a[i] = rnd.NormFloat64()
+	}
+	var aNorm float64
+	switch mtype {
+	default:
+		panic("unknown test matrix type")
+	case 1:
+		// Zero matrix.
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				a[i*lda+j] = 0
+			}
+		}
+	case 2:
+		// Identity matrix.
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				a[i*lda+j] = 0
+			}
+			if i < n {
+				a[i*lda+i] = 1
+			}
+		}
+		aNorm = 1
+	case 3, 4, 5:
+		// Scaled random matrix with singular values spread linearly
+		// between 1/cond and 1.
+		s := make([]float64, minmn)
+		Dlatm1(s, 4, float64(max(1, minmn)), false, 1, rnd)
+		aNorm = 1
+		if mtype == 4 {
+			aNorm = dlamchS / dlamchP
+		}
+		if mtype == 5 {
+			aNorm = dlamchP / dlamchS
+		}
+		floats.Scale(aNorm, s)
+		Dlagge(m, n, max(0, m-1), max(0, n-1), s, a, lda, rnd, make([]float64, m+n))
+	}
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+
+	for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+		for _, jobz := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDStore, lapack.SVDNone} {
+			prefix := fmt.Sprintf("m=%v,n=%v,work=%v,mtype=%v,job=%v", m, n, wl, mtype, svdJobString(jobz))
+
+			copy(a, aCopy)
+			u := nanSlice(m * ldu)
+			vt := nanSlice(n * ldvt)
+			s := nanSlice(minmn)
+			iwork := make([]int, 4*minmn)
+
+			minwork := 1
+			if minmn > 0 {
+				if jobz == lapack.SVDNone {
+					minwork = 4*minmn + max(maxmn, 4*minmn)
+				} else {
+					minwork = minmn*minmn + 4*minmn + max(3*minmn*minmn+7*minmn+1, maxmn)
+				}
+			}
+			var lwork int
+			switch wl {
+			case minimumWork:
+				lwork = minwork
+			case mediumWork:
+				work := make([]float64, 1)
+				impl.Dgesdd(jobz, m, n, a, lda, s, u, ldu, vt, ldvt, work, -1, iwork)
+				lwork = (int(work[0]) + minwork) / 2
+			case optimumWork:
+				work := make([]float64, 1)
+				impl.Dgesdd(jobz, m, n, a, lda, s, u, ldu, vt, ldvt, work, -1, iwork)
+				lwork = int(work[0])
+			}
+			work := nanSlice(max(1, lwork))
+
+			ok := impl.Dgesdd(jobz, m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, iwork)
+			if !ok {
+				t.Errorf("Case %v: unexpected failure", prefix)
+				continue
+			}
+			if minmn == 0 {
+				continue
+			}
+
+			if !sort.IsSorted(sort.Reverse(sort.Float64Slice(s))) {
+				t.Errorf("Case %v: singular values are not decreasing", prefix)
+			}
+			if floats.Min(s) < 0 {
+				t.Errorf("Case %v: some singular values are negative", prefix)
+			}
+
+			// Compare the singular values with the ones used to
+			// construct A.
+			want := make([]float64, minmn)
+			switch mtype {
+			case 2:
+				for i := range want {
+					want[i] = 1
+				}
+			case 3, 4, 5:
+				Dlatm1(want, 4, float64(max(1, minmn)), false, 1, rnd)
+				floats.Scale(aNorm, want)
+			}
+			for i := range want {
+				if math.Abs(s[i]-want[i]) > tol*aNorm {
+					t.Errorf("Case %v: unexpected singular value s[%v]=%v, want %v", prefix, i, s[i], want[i])
+					break
+				}
+			}
+
+			if jobz == lapack.SVDNone {
+				continue
+			}
+
+			ncu := minmn
+			nrvt := minmn
+			if jobz == lapack.SVDAll {
+				ncu = m
+				nrvt = n
+			}
+			if !hasOrthonormalColumns(blas64.General{Rows: m, Cols: ncu, Data: u, Stride: ldu}) {
+				t.Errorf("Case %v: columns of U are not orthonormal", prefix)
+			}
+			if !hasOrthonormalRows(blas64.General{Rows: nrvt, Cols: n, Data: vt, Stride: ldvt}) {
+				t.Errorf("Case %v: rows of VT are not orthonormal", prefix)
+			}
+			if resid := svdFullResidual(m, n, aNorm, aCopy, lda, u, ldu, s, vt, ldvt); resid > tol {
+				t.Errorf("Case %v: original matrix not recovered, |A - U*D*VT|=%v", prefix, resid)
+			}
+		}
+	}
+}
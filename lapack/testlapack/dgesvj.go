@@ -0,0 +1,373 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgesvjer interface {
+	Dgesvj(jobU, jobV lapack.SVDJob, m, n int, a []float64, lda int, sva, v []float64, ldv int, work []float64, lwork int) (ok bool)
+}
+
+func DgesvjTest(t *testing.T, impl Dgesvjer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, mn := range []struct{ m, n int }{
+		{0, 0}, {1, 0}, {1, 1}, {2, 1}, {2, 2}, {3, 3}, {5, 2}, {5, 5},
+		{10, 3}, {10, 10}, {20, 8}, {30, 30},
+	} {
+		for mtype := 1; mtype <= 7; mtype++ {
+			dgesvjTest(t, impl, rnd, mn.m, mn.n, mtype)
+		}
+	}
+}
+
+func dgesvjTest(t *testing.T, impl Dgesvjer, rnd *rand.Rand, m, n, mtype int) {
+	const tol = 1e-13
+
+	lda := n + 3
+	ldv := n + 5
+
+	a, aNorm := svdRelativeTestMatrix(m, n, lda, mtype, rnd)
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+	want := extendedSingularValues(m, n, a, lda)
+
+	for _, jobU := range []lapack.SVDJob{lapack.SVDOverwrite, lapack.SVDNone} {
+		for _, jobV := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDNone} {
+			prefix := fmt.Sprintf("m=%v,n=%v,mtype=%v,jobU=%v,jobV=%v", m, n, mtype, svdJobString(jobU), svdJobString(jobV))
+
+			copy(a, aCopy)
+			sva := nanSlice(n)
+			v := nanSlice(n * ldv)
+			work := nanSlice(max(1, m))
+
+			ok := impl.Dgesvj(jobU, jobV, m, n, a, lda, sva, v, ldv, work, len(work))
+			if !ok {
+				t.Errorf("%v: unexpected failure", prefix)
+				continue
+			}
+			if n == 0 {
+				continue
+			}
+
+			if !sort.IsSorted(sort.Reverse(sort.Float64Slice(sva))) {
+				t.Errorf("%v: singular values are not decreasing", prefix)
+			}
+			if resid := svdRelativeError(sva, want); resid > tol {
+				t.Errorf("%v: unexpected relative error in singular values %v", prefix, resid)
+			}
+
+			if jobV == lapack.SVDAll && !isOrthogonal(blas64.General{Rows: n, Cols: n, Data: v, Stride: ldv}) {
+				t.Errorf("%v: V is not orthogonal", prefix)
+			}
+			if jobU == lapack.SVDNone || jobV == lapack.SVDNone || sva[n-1] == 0 {
+				continue
+			}
+			if !hasOrthonormalColumns(blas64.General{Rows: m, Cols: n, Data: a, Stride: lda}) {
+				t.Errorf("%v: columns of U are not orthonormal", prefix)
+			}
+			vt := transposeGeneral(blas64.General{Rows: n, Cols: n, Data: v, Stride: ldv})
+			if resid := svdFullResidual(m, n, aNorm, aCopy, lda, a, lda, sva, vt.Data, vt.Stride); resid > tol {
+				t.Errorf("%v: original matrix not recovered, |A - U*D*VT|=%v", prefix, resid)
+			}
+		}
+	}
+}
+
+// DgesvjNetlibTest compares the singular value decomposition computed by
+// Dgesvj with the one computed by the reference implementation of DGESVJ. The
+// test data is generated by lapack/internal/testdata/dgesvjtest, and the test
+// is skipped if it has not been generated.
+func DgesvjNetlibTest(t *testing.T, impl Dgesvjer) {
+	for _, test := range readSVDNetlibTests(t, "dgesvjdata.json.gz") {
+		m, n := test.M, test.N
+		prefix := fmt.Sprintf("m=%v,n=%v", m, n)
+
+		lda := n + 3
+		a := make([]float64, m*lda)
+		copyMatrix(m, n, a, lda, test.A)
+		ldv := n + 5
+		sva := nanSlice(n)
+		v := nanSlice(n * ldv)
+		work := nanSlice(max(1, m))
+
+		ok := impl.Dgesvj(lapack.SVDOverwrite, lapack.SVDAll, m, n, a, lda, sva, v, ldv, work, len(work))
+		if !ok {
+			t.Errorf("%v: unexpected failure", prefix)
+			continue
+		}
+		checkSVDNetlib(t, prefix, test, sva, a, lda, v, ldv)
+	}
+}
+
+// svdNetlibTest is a test case for the singular value decomposition
+// routines with the results of the reference implementation. The matrices
+// are stored in row-major order.
+type svdNetlibTest struct {
+	M, N int
+	A    []float64
+
+	SWant []float64
+	UWant []float64
+	VWant []float64
+}
+
+// readSVDNetlibTests returns the test cases stored in the gzip-compressed JSON
+// file name in testlapack/testdata. The test is skipped if the file does not
+// exist.
+func readSVDNetlibTests(t *testing.T, name string) []svdNetlibTest {
+	// Go runs tests from the source directory, so unfortunately we need to
+	// include the "../testlapack" part.
+	file, err := os.Open(filepath.FromSlash("../testlapack/testdata/" + name))
+	if os.IsNotExist(err) {
+		t.Skipf("reference data %s has not been generated", name)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	r, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var tests []svdNetlibTest
+	err = json.NewDecoder(r).Decode(&tests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tests
+}
+
+// checkSVDNetlib compares the singular values sva and the first n columns of
+// the singular vectors u and v with the reference values in test. The
+// singular vectors are compared up to sign, and only when the corresponding
+// singular value is well separated from the others.
+func checkSVDNetlib(t *testing.T, prefix string, test svdNetlibTest, sva, u []float64, ldu int, v []float64, ldv int) {
+	const (
+		tol    = 1e-13
+		vecTol = 1e-8
+		minGap = 1e-3
+	)
+
+	m, n := test.M, test.N
+	if resid := svdRelativeError(sva, test.SWant); resid > tol {
+		t.Errorf("%v: unexpected relative error in singular values %v", prefix, resid)
+	}
+	for j, s := range test.SWant {
+		if s == 0 {
+			continue
+		}
+		gap := math.Inf(1)
+		if j > 0 {
+			gap = math.Min(gap, (test.SWant[j-1]-s)/s)
+		}
+		if j < n-1 {
+			gap = math.Min(gap, (s-test.SWant[j+1])/s)
+		}
+		if gap < minGap {
+			continue
+		}
+		var du, dv float64
+		for i := 0; i < m; i++ {
+			du += u[i*ldu+j] * test.UWant[i*n+j]
+		}
+		for i := 0; i < n; i++ {
+			dv += v[i*ldv+j] * test.VWant[i*n+j]
+		}
+		if math.Abs(math.Abs(du)-1) > vecTol {
+			t.Errorf("%v: left singular vector %v differs from reference, |u^T*uwant|=%v", prefix, j, math.Abs(du))
+		}
+		if math.Abs(math.Abs(dv)-1) > vecTol {
+			t.Errorf("%v: right singular vector %v differs from reference, |v^T*vwant|=%v", prefix, j, math.Abs(dv))
+		}
+	}
+}
+
+// svdRelativeTestMatrix returns an m×n test matrix for the SVD routines that
+// compute the singular values to high relative accuracy, and its norm. The
+// matrix is generated according to mtype as follows
+//  1: zero matrix,
+//  2: identity matrix,
+//  3: random matrix with singular values between 1 and 10,
+//  4, 5: the matrix from mtype 3 scaled close to underflow and overflow,
+//  6: B*D where B is as in mtype 3 and D is a diagonal matrix with elements
+//     between 1e-15 and 1,
+//  7: D*B with B and D as in mtype 6.
+// The singular values of the matrices of types 6 and 7 are spread over many
+// orders of magnitude but are determined to high relative accuracy by the
+// elements of the matrix.
+func svdRelativeTestMatrix(m, n, lda, mtype int, rnd *rand.Rand) (a []float64, aNorm float64) {
+	a = make([]float64, m*lda)
+	for i := range a {
+		a[i] = rnd.NormFloat64()
+	}
+	minmn := min(m, n)
+	switch mtype {
+	default:
+		panic("unknown test matrix type")
+	case 1:
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				a[i*lda+j] = 0
+			}
+		}
+		return a, 0
+	case 2:
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				a[i*lda+j] = 0
+			}
+			if i < n {
+				a[i*lda+i] = 1
+			}
+		}
+		return a, 1
+	case 3, 4, 5, 6, 7:
+	}
+
+	s := make([]float64, minmn)
+	for i := range s {
+		s[i] = 1 + 9*rnd.Float64()
+	}
+	Dlagge(m, n, max(0, m-1), max(0, n-1), s, a, lda, rnd, make([]float64, m+n))
+	aNorm = 10
+	switch mtype {
+	case 4:
+		aNorm = dlamchS / dlamchP
+		for i := 0; i < m; i++ {
+			floats.Scale(aNorm/10, a[i*lda:i*lda+n])
+		}
+	case 5:
+		aNorm = dlamchP / dlamchS
+		for i := 0; i < m; i++ {
+			floats.Scale(aNorm/10, a[i*lda:i*lda+n])
+		}
+	case 6:
+		for j := 0; j < n; j++ {
+			d := math.Pow(10, -15*rnd.Float64())
+			for i := 0; i < m; i++ {
+				a[i*lda+j] *= d
+			}
+		}
+	case 7:
+		for i := 0; i < m; i++ {
+			floats.Scale(math.Pow(10, -15*rnd.Float64()), a[i*lda:i*lda+n])
+		}
+	}
+	return a, aNorm
+}
+
+// svdRelativeError returns the largest relative difference between the
+// singular values in s and want. Singular values that are both zero have no
+// error.
+func svdRelativeError(s, want []float64) float64 {
+	var resid float64
+	for i, w := range want {
+		if s[i] == w {
+			continue
+		}
+		resid = math.Max(resid, math.Abs(s[i]-w)/w)
+	}
+	return resid
+}
+
+// extendedSingularValues returns the singular values of the m×n matrix A in
+// decreasing order. The singular values are computed by the one-sided Jacobi
+// method in extended precision so that they are correctly rounded even if they
+// are spread over many orders of magnitude.
+func extendedSingularValues(m, n int, a []float64, lda int) []float64 {
+	const prec = 256
+
+	newFloat := func() *big.Float { return new(big.Float).SetPrec(prec) }
+	dot := func(x, y []*big.Float) *big.Float {
+		sum := newFloat()
+		tmp := newFloat()
+		for i := range x {
+			sum.Add(sum, tmp.Mul(x[i], y[i]))
+		}
+		return sum
+	}
+
+	cols := make([][]*big.Float, n)
+	for j := range cols {
+		cols[j] = make([]*big.Float, m)
+		for i := range cols[j] {
+			cols[j][i] = newFloat().SetFloat64(a[i*lda+j])
+		}
+	}
+
+	one := newFloat().SetInt64(1)
+	tol := newFloat().SetMantExp(one, -2*200)
+	for sweep := 0; sweep < 100; sweep++ {
+		var rotated bool
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				x, y := cols[p], cols[q]
+				alpha := dot(x, x)
+				beta := dot(y, y)
+				gamma := dot(x, y)
+				if gamma.Sign() == 0 {
+					continue
+				}
+				// Skip the rotation if |gamma|^2 <= tol * alpha * beta.
+				g2 := newFloat().Mul(gamma, gamma)
+				ab := newFloat().Mul(alpha, beta)
+				if g2.Cmp(ab.Mul(ab, tol)) <= 0 {
+					continue
+				}
+				rotated = true
+
+				// zeta = (beta - alpha) / (2*gamma),
+				// t = sign(zeta) / (|zeta| + sqrt(1 + zeta^2)),
+				// c = 1 / sqrt(1 + t^2), s = c*t.
+				zeta := newFloat().Sub(beta, alpha)
+				zeta.Quo(zeta, newFloat().Mul(gamma, newFloat().SetInt64(2)))
+				r := newFloat().Mul(zeta, zeta)
+				r.Sqrt(r.Add(r, one))
+				t := newFloat().Abs(zeta)
+				t.Quo(one, t.Add(t, r))
+				if zeta.Sign() < 0 {
+					t.Neg(t)
+				}
+				c := newFloat().Mul(t, t)
+				c.Quo(one, c.Sqrt(c.Add(c, one)))
+				s := newFloat().Mul(c, t)
+				for i := range x {
+					xi := newFloat().Set(x[i])
+					x[i].Sub(newFloat().Mul(c, xi), newFloat().Mul(s, y[i]))
+					y[i].Add(newFloat().Mul(s, xi), newFloat().Mul(c, y[i]))
+				}
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+
+	sv := make([]float64, n)
+	for j := range sv {
+		nrm := dot(cols[j], cols[j])
+		sv[j], _ = nrm.Sqrt(nrm).Float64()
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(sv)))
+	return sv
+}
@@ -0,0 +1,155 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgesvxer interface {
+	Dgesvx(fact lapack.FactJob, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed lapack.Equilibration, r, c []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.Equilibration, rcond, rpvgrw float64, ok bool)
+}
+
+func DgesvxTest(t *testing.T, impl Dgesvxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, fact := range []lapack.FactJob{lapack.Factorize, lapack.EquilibrateFactorize} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 30} {
+				for _, nrhs := range []int{0, 1, 2, 5} {
+					for _, extra := range []int{0, 3} {
+						for _, scaled := range []bool{false, true} {
+							if scaled && fact != lapack.EquilibrateFactorize {
+								// A badly scaled matrix may be singular to
+								// working precision without equilibration.
+								continue
+							}
+							testDgesvx(t, impl, rnd, fact, trans, n, nrhs, extra, scaled)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgesvx(t *testing.T, impl Dgesvxer, rnd *rand.Rand, fact lapack.FactJob, trans blas.Transpose, n, nrhs, extra int, scaled bool) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("fact=%c,trans=%v,n=%v,nrhs=%v,extra=%v,scaled=%v", fact, trans, n, nrhs, extra, scaled)
+
+	// Generate a random matrix A, optionally with badly scaled rows and
+	// columns.
+	a := randomGeneral(n, n, n+extra, rnd)
+	if scaled {
+		scaleRowsCols(a, rnd)
+	}
+	aCopy := cloneGeneral(a)
+
+	// Generate a random solution X and compute the right-hand side
+	// B = op(A)*X.
+	xWant := randomGeneral(n, nrhs, nrhs+extra, rnd)
+	b := randomGeneral(n, nrhs, nrhs+extra, rnd)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(trans, blas.NoTrans, 1, a, xWant, 0, b)
+	}
+	bCopy := cloneGeneral(b)
+
+	af := nanGeneral(n, n, n+extra)
+	ipiv := make([]int, n)
+	r := nanSlice(n)
+	c := nanSlice(n)
+	x := nanGeneral(n, nrhs, nrhs+extra)
+	ferr := nanSlice(nrhs)
+	berr := nanSlice(nrhs)
+	work := nanSlice(4 * n)
+	iwork := make([]int, n)
+
+	equed, rcond, rpvgrw, ok := impl.Dgesvx(fact, trans, n, nrhs, a.Data, a.Stride, af.Data, af.Stride, ipiv,
+		lapack.EquilibrateNone, r, c, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure, rcond=%v", name, rcond)
+		return
+	}
+	if fact == lapack.Factorize && equed != lapack.EquilibrateNone {
+		t.Errorf("%v: unexpected equilibration %c", name, equed)
+	}
+	if equed == lapack.EquilibrateNone && !equalApproxGeneral(a, aCopy, 0) {
+		t.Errorf("%v: unexpected modification of A", name)
+	}
+	if rcond <= 0 || 1+1e-14 < rcond {
+		t.Errorf("%v: invalid value of rcond, want in (0,1], got %v", name, rcond)
+	}
+	if rpvgrw <= 0 {
+		t.Errorf("%v: invalid value of rpvgrw, want > 0, got %v", name, rpvgrw)
+	}
+	if !generalOutsideAllNaN(x) {
+		t.Errorf("%v: out-of-range write to X", name)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	for j := 0; j < nrhs; j++ {
+		if berr[j] < 0 || tol < berr[j] {
+			t.Errorf("%v: column %v: unexpected backward error, want in [0,%v], got %v", name, j, tol, berr[j])
+		}
+		var diff, xmax float64
+		for i := 0; i < n; i++ {
+			diff = math.Max(diff, math.Abs(x.Data[i*x.Stride+j]-xWant.Data[i*xWant.Stride+j]))
+			xmax = math.Max(xmax, math.Abs(x.Data[i*x.Stride+j]))
+		}
+		if diff/xmax > ferr[j] {
+			t.Errorf("%v: column %v: forward error %v not bounded by ferr=%v", name, j, diff/xmax, ferr[j])
+		}
+	}
+
+	// Solve the system again using the factorization computed above.
+	b2 := cloneGeneral(bCopy)
+	x2 := nanGeneral(n, nrhs, nrhs+extra)
+	_, _, _, ok = impl.Dgesvx(lapack.Factored, trans, n, nrhs, a.Data, a.Stride, af.Data, af.Stride, ipiv,
+		equed, r, c, b2.Data, b2.Stride, x2.Data, x2.Stride, ferr, berr, work, iwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure with factored matrix", name)
+		return
+	}
+	if !equalApproxGeneral(x, x2, tol) {
+		t.Errorf("%v: solution using factored matrix differs", name)
+	}
+
+	// Check that a singular matrix is detected.
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+n/2] = 0
+	}
+	_, rcond, _, ok = impl.Dgesvx(fact, trans, n, nrhs, a.Data, a.Stride, af.Data, af.Stride, ipiv,
+		lapack.EquilibrateNone, r, c, bCopy.Data, bCopy.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+	if ok || rcond != 0 {
+		t.Errorf("%v: singular matrix not detected", name)
+	}
+}
+
+// scaleRowsCols scales the rows and columns of the n×n matrix a by random
+// powers of ten.
+func scaleRowsCols(a blas64.General, rnd *rand.Rand) {
+	for i := 0; i < a.Rows; i++ {
+		s := math.Pow(10, float64(rnd.Intn(11)-5))
+		for j := 0; j < a.Cols; j++ {
+			a.Data[i*a.Stride+j] *= s
+		}
+	}
+	for j := 0; j < a.Cols; j++ {
+		s := math.Pow(10, float64(rnd.Intn(11)-5))
+		for i := 0; i < a.Rows; i++ {
+			a.Data[i*a.Stride+j] *= s
+		}
+	}
+}
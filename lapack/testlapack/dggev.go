@@ -0,0 +1,206 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dggever interface {
+	Dggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int,
+		alphar, alphai, beta []float64, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool)
+}
+
+func DggevTest(t *testing.T, impl Dggever) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 20, 41} {
+		for _, ld := range []int{max(1, n), n + 3} {
+			for _, kind := range []string{"random", "singularB", "identityB"} {
+				for _, wl := range []worklen{minimumWork, optimumWork} {
+					dggevTest(t, impl, rnd, kind, n, ld, wl)
+				}
+			}
+		}
+	}
+}
+
+func dggevTest(t *testing.T, impl Dggever, rnd *rand.Rand, kind string, n, ld int, wl worklen) {
+	const tol = 1e-12
+
+	name := fmt.Sprintf("kind=%v,n=%v,ld=%v,work=%v", kind, n, ld, wl)
+
+	a := randomGeneral(n, n, ld, rnd)
+	var b blas64.General
+	switch kind {
+	case "random":
+		b = randomGeneral(n, n, ld, rnd)
+	case "singularB":
+		// Generate a singular B with a zero row, so that the pair
+		// (A,B) has an infinite eigenvalue.
+		b = randomGeneral(n, n, ld, rnd)
+		if n > 1 {
+			k := rnd.Intn(n)
+			for j := 0; j < n; j++ {
+				b.Data[k*b.Stride+j] = 0
+			}
+		}
+	case "identityB":
+		b = eye(n, ld)
+	}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = max(1, 8*n)
+	case optimumWork:
+		work := []float64{0}
+		impl.Dggev(lapack.LeftEVCompute, lapack.RightEVCompute, n, nil, max(1, n), nil, max(1, n),
+			nil, nil, nil, nil, max(1, n), nil, max(1, n), work, -1)
+		lwork = int(work[0])
+	}
+	work := make([]float64, lwork)
+
+	alphar := nanSlice(n)
+	alphai := nanSlice(n)
+	beta := nanSlice(n)
+	vl := nanGeneral(n, n, ld)
+	vr := nanGeneral(n, n, ld)
+	ok := impl.Dggev(lapack.LeftEVCompute, lapack.RightEVCompute, n, a.Data, a.Stride, b.Data, b.Stride,
+		alphar, alphai, beta, vl.Data, vl.Stride, vr.Data, vr.Stride, work, len(work))
+	if !ok {
+		t.Errorf("%v: unexpected failure", name)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	if floats.HasNaN(alphar) || floats.HasNaN(alphai) || floats.HasNaN(beta) {
+		t.Errorf("%v: eigenvalues not assigned completely", name)
+	}
+
+	// Check that complex eigenvalues are stored as consecutive complex
+	// conjugate pairs.
+	for j := 0; j < n; j++ {
+		if alphai[j] == 0 {
+			continue
+		}
+		if alphai[j] < 0 || j == n-1 || alphai[j+1] >= 0 {
+			t.Errorf("%v: eigenvalues at %v are not a complex conjugate pair", name, j)
+			break
+		}
+		ev1 := complex(alphar[j], alphai[j]) / complex(beta[j], 0)
+		ev2 := complex(alphar[j+1], alphai[j+1]) / complex(beta[j+1], 0)
+		if cmplx.Abs(ev1-cmplx.Conj(ev2)) > tol*cmplx.Abs(ev1) {
+			t.Errorf("%v: eigenvalues at %v are not a complex conjugate pair", name, j)
+		}
+		j++
+	}
+
+	if kind == "singularB" && n > 1 {
+		var found bool
+		for _, v := range beta {
+			if math.Abs(v) < 1e-12 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%v: infinite eigenvalue not found", name)
+		}
+	}
+
+	// Check the eigenvectors.
+	for j := 0; j < n; j++ {
+		iscomplex := alphai[j] != 0
+		alpha := complex(alphar[j], alphai[j])
+		for _, v := range []struct {
+			name string
+			mat  blas64.General
+			left bool
+		}{
+			{name: "left", mat: vl, left: true},
+			{name: "right", mat: vr, left: false},
+		} {
+			xRe := columnOf(v.mat, j)
+			var xIm []float64
+			if iscomplex {
+				xIm = columnOf(v.mat, j+1)
+			}
+			resid := genEigenvectorResidual(aCopy, bCopy, xRe, xIm, alpha, beta[j], v.left)
+			if resid > tol*float64(n) {
+				t.Errorf("%v: %v eigenvector %v not computed accurately, residual=%v", name, v.name, j, resid)
+			}
+
+			// Check the normalization of the eigenvector.
+			var xmax float64
+			for i := range xRe {
+				x := math.Abs(xRe[i])
+				if iscomplex {
+					x += math.Abs(xIm[i])
+				}
+				xmax = math.Max(xmax, x)
+			}
+			if math.Abs(xmax-1) > tol {
+				t.Errorf("%v: %v eigenvector %v not normalized", name, v.name, j)
+			}
+		}
+		if iscomplex {
+			j++
+		}
+	}
+
+	// Check that computing only the eigenvalues gives the same result.
+	copyGeneral(a, aCopy)
+	copyGeneral(b, bCopy)
+	alpharOnly := nanSlice(n)
+	alphaiOnly := nanSlice(n)
+	betaOnly := nanSlice(n)
+	ok = impl.Dggev(lapack.LeftEVNone, lapack.RightEVNone, n, a.Data, a.Stride, b.Data, b.Stride,
+		alpharOnly, alphaiOnly, betaOnly, nil, 1, nil, 1, work, len(work))
+	if !ok {
+		t.Errorf("%v: unexpected failure computing eigenvalues only", name)
+		return
+	}
+	if kind == "singularB" {
+		return
+	}
+	want := make([]complex128, n)
+	got := make([]complex128, n)
+	for j := range want {
+		want[j] = complex(alphar[j], alphai[j]) / complex(beta[j], 0)
+		got[j] = complex(alpharOnly[j], alphaiOnly[j]) / complex(betaOnly[j], 0)
+	}
+	sortComplex(want)
+	sortComplex(got)
+	for j := range want {
+		if cmplx.Abs(got[j]-want[j]) > 1e-8*math.Max(1, cmplx.Abs(want[j])) {
+			t.Errorf("%v: eigenvalues differ when computing eigenvalues only; got %v, want %v", name, got[j], want[j])
+			break
+		}
+	}
+}
+
+// sortComplex sorts v lexicographically by real and then imaginary part.
+func sortComplex(v []complex128) {
+	sort.Slice(v, func(i, j int) bool {
+		if real(v[i]) != real(v[j]) {
+			return real(v[i]) < real(v[j])
+		}
+		return imag(v[i]) < imag(v[j])
+	})
+}
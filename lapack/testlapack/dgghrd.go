@@ -0,0 +1,122 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgghrder interface {
+	Dgghrd(compq, compz lapack.OrthoComp, n, ilo, ihi int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int)
+}
+
+func DgghrdTest(t *testing.T, impl Dgghrder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, comp := range []lapack.OrthoComp{lapack.OrthoExplicit, lapack.OrthoPostmul} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 23} {
+			for _, ld := range []int{max(1, n), n + 7} {
+				for cas := 0; cas < 4; cas++ {
+					ilo := 0
+					ihi := n - 1
+					if cas > 0 && n > 0 {
+						ilo = rnd.Intn(n)
+						ihi = ilo + rnd.Intn(n-ilo)
+					}
+					dgghrdTest(t, impl, rnd, comp, n, ilo, ihi, ld)
+				}
+			}
+		}
+	}
+}
+
+func dgghrdTest(t *testing.T, impl Dgghrder, rnd *rand.Rand, comp lapack.OrthoComp, n, ilo, ihi, ld int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("comp=%v,n=%v,ilo=%v,ihi=%v,ld=%v", string(comp), n, ilo, ihi, ld)
+
+	// Generate a random general matrix A that is upper triangular in rows
+	// and columns outside of the block [ilo:ihi+1,ilo:ihi+1], and a random
+	// upper triangular matrix B.
+	a := randomGeneral(n, n, ld, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if j < ilo || ihi < i {
+				a.Data[i*a.Stride+j] = 0
+			}
+		}
+	}
+	b := randomGeneral(n, n, ld, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			b.Data[i*b.Stride+j] = 0
+		}
+	}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+
+	// Initialize Q and Z to random orthogonal matrices if they are to be
+	// postmultiplied.
+	var q1, z1 blas64.General
+	if comp == lapack.OrthoPostmul {
+		q1 = randomOrthogonal(n, rnd)
+		z1 = randomOrthogonal(n, rnd)
+	} else {
+		q1 = eye(n, n)
+		z1 = eye(n, n)
+	}
+	q := zeros(n, n, ld)
+	z := zeros(n, n, ld)
+	if comp == lapack.OrthoPostmul {
+		copyGeneral(q, q1)
+		copyGeneral(z, z1)
+	}
+
+	impl.Dgghrd(comp, comp, n, ilo, ihi, a.Data, a.Stride, b.Data, b.Stride, q.Data, q.Stride, z.Data, z.Stride)
+	if n == 0 {
+		return
+	}
+
+	if !isUpperHessenberg(a) {
+		t.Errorf("%v: H is not upper Hessenberg", name)
+	}
+	if !isUpperTriangular(b) {
+		t.Errorf("%v: T is not upper triangular", name)
+	}
+	if !isOrthogonal(q) {
+		t.Errorf("%v: Q is not orthogonal", name)
+	}
+	if !isOrthogonal(z) {
+		t.Errorf("%v: Z is not orthogonal", name)
+	}
+
+	// Check that
+	//  Q1*A*Z1^T = (Q1*Q)*H*(Z1*Z)^T,
+	//  Q1*B*Z1^T = (Q1*Q)*T*(Z1*Z)^T.
+	for _, m := range []struct {
+		name       string
+		got, input blas64.General
+	}{
+		{name: "A", got: a, input: aCopy},
+		{name: "B", got: b, input: bCopy},
+	} {
+		want := zeros(n, n, n)
+		got := zeros(n, n, n)
+		tmp := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q1, m.input, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z1, 0, want)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, m.got, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z, 0, got)
+		if !equalApproxGeneral(got, want, tol*float64(n)) {
+			t.Errorf("%v: unexpected reconstruction of %v", name, m.name)
+		}
+	}
+}
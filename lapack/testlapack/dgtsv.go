@@ -0,0 +1,83 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dgtsver interface {
+	Dgtsv(n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool)
+}
+
+func DgtsvTest(t *testing.T, impl Dgtsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 50} {
+		for _, nrhs := range []int{0, 1, 2, 3, 4, 10} {
+			for _, ldb := range []int{max(1, nrhs), nrhs + 3} {
+				testDgtsv(t, impl, rnd, n, nrhs, ldb)
+			}
+		}
+	}
+}
+
+func testDgtsv(t *testing.T, impl Dgtsver, rnd *rand.Rand, n, nrhs, ldb int) {
+	name := fmt.Sprintf("n=%v,nrhs=%v,ldb=%v", n, nrhs, ldb)
+
+	// Generate a random tridiagonal matrix A. The diagonal is not made
+	// dominant so that pivoting is exercised.
+	var dl, du []float64
+	if n > 1 {
+		dl = make([]float64, n-1)
+		du = make([]float64, n-1)
+		for i := range dl {
+			dl[i] = rnd.NormFloat64()
+			du[i] = rnd.NormFloat64()
+		}
+	}
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = rnd.NormFloat64()
+	}
+	a := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+i] = d[i]
+		if i > 0 {
+			a.Data[i*a.Stride+i-1] = dl[i-1]
+		}
+		if i < n-1 {
+			a.Data[i*a.Stride+i+1] = du[i]
+		}
+	}
+
+	// Generate a random solution X and compute the right-hand side
+	// B = A*X.
+	x := randomGeneral(n, nrhs, ldb, rnd)
+	b := randomGeneral(n, nrhs, ldb, rnd)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, x, 0, b)
+	}
+
+	ok := impl.Dgtsv(n, nrhs, dl, d, du, b.Data, ldb)
+	if !ok {
+		t.Errorf("%v: unexpected singular matrix", name)
+		return
+	}
+	for i := 0; i < n && nrhs > 0; i++ {
+		got := b.Data[i*ldb : i*ldb+nrhs]
+		want := x.Data[i*ldb : i*ldb+nrhs]
+		if !floats.EqualApprox(got, want, 1e-8) {
+			t.Errorf("%v: unexpected solution in row %v\ngot  %v\nwant %v", name, i, got, want)
+			return
+		}
+	}
+}
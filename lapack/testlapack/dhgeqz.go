@@ -0,0 +1,231 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dhgeqzer interface {
+	Dhgeqz(job lapack.SchurJob, compq, compz lapack.OrthoComp, n, ilo, ihi int, h []float64, ldh int, t []float64, ldt int,
+		alphar, alphai, beta, q []float64, ldq int, z []float64, ldz int, work []float64, lwork int) (unconverged int)
+}
+
+func DhgeqzTest(t *testing.T, impl Dhgeqzer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31} {
+		for _, ld := range []int{max(1, n), n + 5} {
+			for cas := 0; cas < 5; cas++ {
+				ilo := 0
+				ihi := n - 1
+				if cas > 2 && n > 0 {
+					ilo = rnd.Intn(n)
+					ihi = ilo + rnd.Intn(n-ilo)
+				}
+				// For cas == 2 make T singular.
+				singular := cas == 2
+				for _, comp := range []lapack.OrthoComp{lapack.OrthoExplicit, lapack.OrthoPostmul} {
+					dhgeqzTest(t, impl, rnd, comp, n, ilo, ihi, ld, singular)
+				}
+			}
+		}
+	}
+}
+
+func dhgeqzTest(t *testing.T, impl Dhgeqzer, rnd *rand.Rand, comp lapack.OrthoComp, n, ilo, ihi, ld int, singular bool) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("comp=%v,n=%v,ilo=%v,ihi=%v,ld=%v,singular=%v", string(comp), n, ilo, ihi, ld, singular)
+
+	// Generate a random upper Hessenberg matrix H that is upper triangular
+	// outside of the block [ilo:ihi+1,ilo:ihi+1], and a random upper
+	// triangular matrix T.
+	h := randomHessenberg(n, ld, rnd)
+	for i := 1; i < n; i++ {
+		if i-1 < ilo || ihi < i {
+			h.Data[i*h.Stride+i-1] = 0
+		}
+	}
+	tm := randomGeneral(n, n, ld, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			tm.Data[i*tm.Stride+j] = 0
+		}
+	}
+	if singular && ihi-ilo > 1 {
+		k := ilo + 1 + rnd.Intn(ihi-ilo-1)
+		tm.Data[k*tm.Stride+k] = 0
+	}
+	hCopy := cloneGeneral(h)
+	tCopy := cloneGeneral(tm)
+
+	var q1, z1 blas64.General
+	if comp == lapack.OrthoPostmul {
+		q1 = randomOrthogonal(n, rnd)
+		z1 = randomOrthogonal(n, rnd)
+	} else {
+		q1 = eye(n, n)
+		z1 = eye(n, n)
+	}
+	q := zeros(n, n, ld)
+	z := zeros(n, n, ld)
+	if comp == lapack.OrthoPostmul {
+		copyGeneral(q, q1)
+		copyGeneral(z, z1)
+	}
+
+	alphar := nanSlice(n)
+	alphai := nanSlice(n)
+	beta := nanSlice(n)
+
+	work := []float64{0}
+	impl.Dhgeqz(lapack.EigenvaluesAndSchur, comp, comp, n, ilo, ihi, h.Data, h.Stride, tm.Data, tm.Stride,
+		alphar, alphai, beta, q.Data, q.Stride, z.Data, z.Stride, work, -1)
+	work = nanSlice(int(work[0]))
+
+	unconverged := impl.Dhgeqz(lapack.EigenvaluesAndSchur, comp, comp, n, ilo, ihi, h.Data, h.Stride, tm.Data, tm.Stride,
+		alphar, alphai, beta, q.Data, q.Stride, z.Data, z.Stride, work, len(work))
+	if unconverged > 0 {
+		t.Errorf("%v: QZ iteration did not converge", name)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	if floats.HasNaN(alphar) || floats.HasNaN(alphai) || floats.HasNaN(beta) {
+		t.Errorf("%v: eigenvalues not assigned completely", name)
+	}
+
+	// Check that S is upper quasi-triangular with no consecutive non-zero
+	// subdiagonal elements, and that P is upper triangular with
+	// non-negative diagonal and with diagonal 2×2 blocks corresponding to
+	// the 2×2 blocks of S.
+	if !isUpperHessenberg(h) {
+		t.Errorf("%v: S is not upper Hessenberg", name)
+	}
+	for i := 0; i < n-2; i++ {
+		if h.Data[(i+1)*h.Stride+i] != 0 && h.Data[(i+2)*h.Stride+i+1] != 0 {
+			t.Errorf("%v: S is not upper quasi-triangular", name)
+			break
+		}
+	}
+	if !isUpperTriangular(tm) {
+		t.Errorf("%v: P is not upper triangular", name)
+	}
+	for i := 0; i < n; i++ {
+		if tm.Data[i*tm.Stride+i] < 0 {
+			t.Errorf("%v: P has negative diagonal element at %v", name, i)
+		}
+		if i < n-1 && h.Data[(i+1)*h.Stride+i] != 0 && tm.Data[i*tm.Stride+i+1] != 0 {
+			t.Errorf("%v: 2×2 block of P at %v is not diagonal", name, i)
+		}
+	}
+	if !isOrthogonal(q) {
+		t.Errorf("%v: Q is not orthogonal", name)
+	}
+	if !isOrthogonal(z) {
+		t.Errorf("%v: Z is not orthogonal", name)
+	}
+
+	// Check that
+	//  Q1*H*Z1^T = (Q1*Q)*S*(Z1*Z)^T,
+	//  Q1*T*Z1^T = (Q1*Q)*P*(Z1*Z)^T.
+	for _, m := range []struct {
+		name       string
+		got, input blas64.General
+	}{
+		{name: "H", got: h, input: hCopy},
+		{name: "T", got: tm, input: tCopy},
+	} {
+		want := zeros(n, n, n)
+		got := zeros(n, n, n)
+		tmp := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q1, m.input, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z1, 0, want)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, m.got, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, z, 0, got)
+		if !equalApproxGeneral(got, want, tol*float64(n)) {
+			t.Errorf("%v: unexpected reconstruction of %v", name, m.name)
+		}
+	}
+
+	// Check that the eigenvalues correspond to the diagonal blocks of
+	// (S,P).
+	for i := 0; i < n; {
+		if i == n-1 || h.Data[(i+1)*h.Stride+i] == 0 {
+			if alphai[i] != 0 {
+				t.Errorf("%v: unexpected complex eigenvalue at %v", name, i)
+			}
+			if alphar[i] != h.Data[i*h.Stride+i] || beta[i] != tm.Data[i*tm.Stride+i] {
+				t.Errorf("%v: eigenvalue at %v does not match diagonal of (S,P)", name, i)
+			}
+			i++
+			continue
+		}
+		if alphai[i] <= 0 || alphai[i+1] >= 0 {
+			t.Errorf("%v: eigenvalues at %v are not a complex conjugate pair", name, i)
+		}
+		if beta[i] <= 0 || beta[i+1] <= 0 {
+			t.Errorf("%v: non-positive beta for complex eigenvalue at %v", name, i)
+		}
+		lambda := complex(alphar[i], alphai[i]) / complex(beta[i], 0)
+		lambda2 := complex(alphar[i+1], alphai[i+1]) / complex(beta[i+1], 0)
+		if cmplx.Abs(lambda-cmplx.Conj(lambda2)) > tol*cmplx.Abs(lambda) {
+			t.Errorf("%v: eigenvalues at %v are not a complex conjugate pair", name, i)
+		}
+		// The eigenvalue λ = alpha/beta must satisfy
+		//  det(S_ii - λ*P_ii) = 0
+		// for the 2×2 diagonal block at i.
+		s11 := complex(h.Data[i*h.Stride+i], 0)
+		s12 := complex(h.Data[i*h.Stride+i+1], 0)
+		s21 := complex(h.Data[(i+1)*h.Stride+i], 0)
+		s22 := complex(h.Data[(i+1)*h.Stride+i+1], 0)
+		p11 := complex(tm.Data[i*tm.Stride+i], 0)
+		p22 := complex(tm.Data[(i+1)*tm.Stride+i+1], 0)
+		det := (s11-lambda*p11)*(s22-lambda*p22) - s12*s21
+		scale := (cmplx.Abs(s11)+cmplx.Abs(lambda*p11))*(cmplx.Abs(s22)+cmplx.Abs(lambda*p22)) + cmplx.Abs(s12*s21)
+		if cmplx.Abs(det) > tol*math.Max(1, scale) {
+			t.Errorf("%v: complex eigenvalue at %v does not match diagonal block of (S,P)", name, i)
+		}
+		i += 2
+	}
+
+	// Check that computing only the eigenvalues gives the same result.
+	copyGeneral(h, hCopy)
+	copyGeneral(tm, tCopy)
+	alpharOnly := nanSlice(n)
+	alphaiOnly := nanSlice(n)
+	betaOnly := nanSlice(n)
+	unconverged = impl.Dhgeqz(lapack.EigenvaluesOnly, lapack.OrthoNone, lapack.OrthoNone, n, ilo, ihi, h.Data, h.Stride, tm.Data, tm.Stride,
+		alpharOnly, alphaiOnly, betaOnly, nil, 1, nil, 1, work, len(work))
+	if unconverged > 0 {
+		t.Errorf("%v: QZ iteration did not converge when computing eigenvalues only", name)
+		return
+	}
+	for i := 0; i < n; i++ {
+		if math.Abs(betaOnly[i]-beta[i]) > 1e-10*math.Max(1, math.Abs(beta[i])) {
+			// The iterations may differ slightly, so only
+			// compare the eigenvalues when the results agree
+			// on beta.
+			continue
+		}
+		want := complex(alphar[i], alphai[i])
+		got := complex(alpharOnly[i], alphaiOnly[i])
+		if cmplx.Abs(got-want) > 1e-10*math.Max(1, cmplx.Abs(want)) {
+			t.Errorf("%v: eigenvalue %v differs when computing eigenvalues only; got %v, want %v", name, i, got, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+type Dlaed4er interface {
+	Dlaed4(n, i int, d, z, delta []float64, rho float64) (dlam float64, ok bool)
+}
+
+func Dlaed4Test(t *testing.T, impl Dlaed4er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 5, 10, 50} {
+		for _, close := range []bool{false, true} {
+			for cas := 0; cas < 10; cas++ {
+				dlaed4Test(t, impl, rnd, n, close)
+			}
+		}
+	}
+}
+
+func dlaed4Test(t *testing.T, impl Dlaed4er, rnd *rand.Rand, n int, close bool) {
+	const tol = 1e-13
+
+	// Generate the poles in strictly increasing order, optionally with
+	// some of them very close to each other.
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = rnd.NormFloat64()
+	}
+	sort.Float64s(d)
+	if close {
+		for i := 1; i < n; i += 2 {
+			d[i] = d[i-1] + 1e-10*(1+rnd.Float64())
+		}
+		sort.Float64s(d)
+	}
+	z := make([]float64, n)
+	var znorm float64
+	for i := range z {
+		z[i] = rnd.NormFloat64()
+		znorm += z[i] * z[i]
+	}
+	znorm = math.Sqrt(znorm)
+	for i := range z {
+		z[i] /= znorm
+	}
+	rho := 0.1 + rnd.Float64()
+
+	prefix := fmt.Sprintf("n=%v,close=%v", n, close)
+	delta := make([]float64, n)
+	for i := 0; i < n; i++ {
+		lambda, ok := impl.Dlaed4(n, i, d, z, delta, rho)
+		if !ok {
+			t.Errorf("%v,i=%v: iteration did not converge", prefix, i)
+			continue
+		}
+
+		// Check that the root interlaces with the poles.
+		if lambda < d[i] || (i < n-1 && d[i+1] < lambda) || (i == n-1 && d[i]+rho < lambda) {
+			t.Errorf("%v,i=%v: root %v not in the expected interval", prefix, i, lambda)
+		}
+
+		if n == 1 {
+			if delta[0] != 1 {
+				t.Errorf("%v: unexpected delta %v", prefix, delta[0])
+			}
+			if math.Abs(lambda-(d[0]+rho*z[0]*z[0])) > tol {
+				t.Errorf("%v: unexpected root %v", prefix, lambda)
+			}
+			continue
+		}
+
+		// Check that delta holds the differences between the poles and
+		// the root.
+		for j := 0; j < n; j++ {
+			if math.Abs(delta[j]-(d[j]-lambda)) > tol*math.Max(1, math.Abs(d[j])) {
+				t.Errorf("%v,i=%v: unexpected delta[%v], got %v want %v", prefix, i, j, delta[j], d[j]-lambda)
+			}
+		}
+
+		// Check that the root solves the secular equation using the
+		// differences in delta which are accurate also for close poles.
+		f := 1 / rho
+		var fabs float64
+		for j := 0; j < n; j++ {
+			term := z[j] * z[j] / delta[j]
+			f += term
+			fabs += math.Abs(term)
+		}
+		if math.Abs(f) > tol*(1/rho+fabs) {
+			t.Errorf("%v,i=%v: secular equation not satisfied, residual %v", prefix, i, f/(1/rho+fabs))
+		}
+	}
+}
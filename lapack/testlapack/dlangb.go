@@ -0,0 +1,49 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dlangber interface {
+	Dlange(norm lapack.MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+	Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64
+}
+
+func DlangbTest(t *testing.T, impl Dlangber) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.Frobenius} {
+		for _, m := range []int{0, 1, 2, 5, 10} {
+			for _, n := range []int{0, 1, 2, 5, 10} {
+				for _, kl := range []int{0, 1, 3, 11} {
+					for _, ku := range []int{0, 1, 3, 11} {
+						for _, extra := range []int{0, 2} {
+							ldab := kl + ku + 1 + extra
+							name := fmt.Sprintf("norm=%c,m=%v,n=%v,kl=%v,ku=%v,ldab=%v", norm, m, n, kl, ku, ldab)
+
+							ab := randomBand(m, n, kl, ku, ldab, rnd)
+							a := bandToGeneral(m, n, kl, ku, ab, ldab)
+
+							work := nanSlice(n)
+							want := impl.Dlange(norm, m, n, a.Data, a.Stride, work)
+							work = nanSlice(n)
+							got := impl.Dlangb(norm, m, n, kl, ku, ab, ldab, work)
+							if math.Abs(got-want) > 1e-14*want {
+								t.Errorf("%v: unexpected norm; got %v, want %v", name, got, want)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dlansber interface {
+	Dlansy(norm lapack.MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
+	Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64
+}
+
+func DlansbTest(t *testing.T, impl Dlansber) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.Frobenius} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 5, 10} {
+				for _, kd := range []int{0, 1, 3, 11} {
+					for _, extra := range []int{0, 2} {
+						ldab := kd + 1 + extra
+						name := fmt.Sprintf("norm=%c,uplo=%c,n=%v,kd=%v,ldab=%v", norm, uplo, n, kd, ldab)
+
+						// A symmetric band matrix in band storage is
+						// a triangular band matrix with the same data.
+						ab := randomTriBand(uplo, n, kd, ldab, rnd)
+						a := triBandToGeneral(uplo, blas.NonUnit, n, kd, ab, ldab)
+
+						work := nanSlice(n)
+						want := impl.Dlansy(norm, uplo, n, a.Data, a.Stride, work)
+						work = nanSlice(n)
+						got := impl.Dlansb(norm, uplo, n, kd, ab, ldab, work)
+						if math.Abs(got-want) > 1e-14*want {
+							t.Errorf("%v: unexpected norm; got %v, want %v", name, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
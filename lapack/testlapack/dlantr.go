@@ -27,6 +27,9 @@ func DlantrTest(t *testing.T, impl Dlantrer) {
 				for _, test := range []struct {
 					m, n, lda int
 				}{
+					{1, 1, 0},
+					{1, 4, 0},
+					{4, 1, 0},
 					{3, 3, 0},
 					{3, 5, 0},
 					{10, 5, 0},
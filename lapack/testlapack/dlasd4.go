@@ -0,0 +1,108 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+type Dlasd4er interface {
+	Dlasd4(n, i int, d, z, delta []float64, rho float64, work []float64) (sigma float64, ok bool)
+}
+
+func Dlasd4Test(t *testing.T, impl Dlasd4er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 5, 10, 50} {
+		for _, close := range []bool{false, true} {
+			for cas := 0; cas < 10; cas++ {
+				dlasd4Test(t, impl, rnd, n, close)
+			}
+		}
+	}
+}
+
+func dlasd4Test(t *testing.T, impl Dlasd4er, rnd *rand.Rand, n int, close bool) {
+	const tol = 1e-13
+
+	// Generate the non-negative poles in strictly increasing order,
+	// starting at zero as they do in the divide and conquer SVD.
+	d := make([]float64, n)
+	for i := 1; i < n; i++ {
+		d[i] = 1 + rnd.NormFloat64()*rnd.NormFloat64()
+		d[i] = math.Abs(d[i])
+	}
+	sort.Float64s(d)
+	if close {
+		for i := 2; i < n; i += 2 {
+			d[i] = d[i-1] + 1e-10*(1+rnd.Float64())
+		}
+		sort.Float64s(d)
+	}
+	z := make([]float64, n)
+	var znorm float64
+	for i := range z {
+		z[i] = rnd.NormFloat64()
+		znorm += z[i] * z[i]
+	}
+	znorm = math.Sqrt(znorm)
+	for i := range z {
+		z[i] /= znorm
+	}
+	rho := 0.1 + rnd.Float64()
+
+	prefix := fmt.Sprintf("n=%v,close=%v", n, close)
+	delta := make([]float64, n)
+	work := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sigma, ok := impl.Dlasd4(n, i, d, z, delta, rho, work)
+		if !ok {
+			t.Errorf("%v,i=%v: iteration did not converge", prefix, i)
+			continue
+		}
+
+		// Check that the root interlaces with the poles.
+		if sigma < d[i] || (i < n-1 && d[i+1] < sigma) {
+			t.Errorf("%v,i=%v: singular value %v not in the expected interval", prefix, i, sigma)
+		}
+
+		if n == 1 {
+			if delta[0] != 1 || work[0] != 1 {
+				t.Errorf("%v: unexpected delta %v or work %v", prefix, delta[0], work[0])
+			}
+			if math.Abs(sigma-math.Sqrt(d[0]*d[0]+rho*z[0]*z[0])) > tol {
+				t.Errorf("%v: unexpected singular value %v", prefix, sigma)
+			}
+			continue
+		}
+
+		// Check that delta and work hold the differences and sums of the
+		// poles and the singular value.
+		for j := 0; j < n; j++ {
+			if math.Abs(delta[j]-(d[j]-sigma)) > tol*math.Max(1, d[j]) {
+				t.Errorf("%v,i=%v: unexpected delta[%v], got %v want %v", prefix, i, j, delta[j], d[j]-sigma)
+			}
+			if math.Abs(work[j]-(d[j]+sigma)) > tol*math.Max(1, d[j]) {
+				t.Errorf("%v,i=%v: unexpected work[%v], got %v want %v", prefix, i, j, work[j], d[j]+sigma)
+			}
+		}
+
+		// Check that the singular value solves the secular equation.
+		f := 1 / rho
+		var fabs float64
+		for j := 0; j < n; j++ {
+			term := z[j] * z[j] / (delta[j] * work[j])
+			f += term
+			fabs += math.Abs(term)
+		}
+		if math.Abs(f) > tol*(1/rho+fabs) {
+			t.Errorf("%v,i=%v: secular equation not satisfied, residual %v", prefix, i, f/(1/rho+fabs))
+		}
+	}
+}
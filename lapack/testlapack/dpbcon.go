@@ -0,0 +1,68 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dpbconer interface {
+	Dpbtrfer
+	Dgetrier
+	Dlange(norm lapack.MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+	Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64
+	Dpbcon(uplo blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DpbconTest(t *testing.T, impl Dpbconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{1, 2, 3, 5, 10, 50} {
+			for _, kd := range []int{0, 1, 3, n / 2, n - 1} {
+				ldab := kd + 1 + rnd.Intn(3)
+				name := fmt.Sprintf("uplo=%c,n=%v,kd=%v,ldab=%v", uplo, n, kd, ldab)
+
+				ab, a := randomSPDBand(uplo, n, kd, ldab, rnd)
+
+				// Compute the exact reciprocal condition number
+				// in the 1-norm from the explicit inverse.
+				ipiv := make([]int, n)
+				impl.Dgetrf(n, n, a.Data, a.Stride, ipiv)
+				work := make([]float64, 1)
+				impl.Dgetri(n, a.Data, a.Stride, ipiv, work, -1)
+				work = make([]float64, int(work[0]))
+				impl.Dgetri(n, a.Data, a.Stride, ipiv, work, len(work))
+				work = make([]float64, n)
+				anorm := impl.Dlansb(lapack.MaxColumnSum, uplo, n, kd, ab, ldab, work)
+				ainvnm := impl.Dlange(lapack.MaxColumnSum, n, n, a.Data, a.Stride, work)
+				want := 1 / anorm / ainvnm
+
+				ok := impl.Dpbtrf(uplo, n, kd, ab, ldab)
+				if !ok {
+					t.Errorf("%v: bad test, matrix is not positive definite", name)
+					continue
+				}
+
+				work = make([]float64, 2*n)
+				iwork := make([]int, n)
+				got := impl.Dpbcon(uplo, n, kd, ab, ldab, anorm, work, iwork)
+
+				// The estimate of the norm of the inverse is a
+				// lower bound, so the estimated reciprocal condition
+				// number must not be smaller than the exact one. It
+				// should also be within a small factor of it.
+				if got < want*(1-1e-8) || got > 10*want {
+					t.Errorf("%v: unexpected rcond; got %v, want %v", name, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dpbtrfer interface {
+	Dpbtrf(uplo blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
+	Dpotrfer
+}
+
+func DpbtrfTest(t *testing.T, impl Dpbtrfer) {
+	// Test random symmetric banded matrices against the full version.
+	// Large semi-bandwidths are included so that the blocked code is
+	// exercised.
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 5, 10, 20, 100, 200} {
+		for _, kd := range []int{0, 1, 3, n / 2, n - 1, 70, 100} {
+			if kd < 0 || (n > 0 && kd > n-1) {
+				continue
+			}
+			for _, ldoff := range []int{0, 4} {
+				for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+					ldab := kd + 1 + ldoff
+					name := fmt.Sprintf("uplo=%c,n=%v,kd=%v,ldab=%v", uplo, n, kd, ldab)
+
+					ab, a := randomSPDBand(uplo, n, kd, ldab, rnd)
+
+					// Compute the Cholesky decomposition of the full matrix.
+					ok := impl.Dpotrf(uplo, n, a.Data, a.Stride)
+					if !ok {
+						panic("bad test: symmetric cholesky decomp failed")
+					}
+
+					// Compute the Cholesky decomposition of the banded matrix.
+					ok = impl.Dpbtrf(uplo, n, kd, ab, ldab)
+					if !ok {
+						t.Errorf("%v: band Cholesky factorization failed", name)
+						continue
+					}
+
+					// Compare the result to the full decomposition.
+					want := blas64.Symmetric{N: n, Stride: a.Stride, Data: a.Data, Uplo: uplo}
+					got := symBandToSym(uplo, ab, n, kd, ldab)
+					if !equalApproxSymmetric(got, want, 1e-12) {
+						t.Errorf("%v: Cholesky factor mismatch between band and full versions", name)
+					}
+				}
+			}
+		}
+	}
+}
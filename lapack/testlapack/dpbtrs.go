@@ -0,0 +1,67 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dpbtrser interface {
+	Dpbtrs(uplo blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int)
+	Dpbtrfer
+}
+
+func DpbtrsTest(t *testing.T, impl Dpbtrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 5, 10, 50} {
+			for _, kd := range []int{0, 1, 3, n / 2, n - 1} {
+				if kd < 0 {
+					continue
+				}
+				for _, nrhs := range []int{0, 1, 3} {
+					for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+						ldab := kd + 1 + rnd.Intn(3)
+						name := fmt.Sprintf("uplo=%c,n=%v,kd=%v,nrhs=%v,ldab=%v,ldb=%v", uplo, n, kd, nrhs, ldab, ldb)
+
+						ab, a := randomSPDBand(uplo, n, kd, ldab, rnd)
+
+						// Generate a random solution X and compute
+						// the right-hand side B = A*X.
+						x := randomGeneral(n, nrhs, ldb, rnd)
+						b := randomGeneral(n, nrhs, ldb, rnd)
+						if n > 0 && nrhs > 0 {
+							blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, x, 0, b)
+						}
+
+						ok := impl.Dpbtrf(uplo, n, kd, ab, ldab)
+						if !ok {
+							t.Errorf("%v: bad test, matrix is not positive definite", name)
+							continue
+						}
+
+						impl.Dpbtrs(uplo, n, kd, nrhs, ab, ldab, b.Data, ldb)
+
+						for i := 0; i < n && nrhs > 0; i++ {
+							got := b.Data[i*ldb : i*ldb+nrhs]
+							want := x.Data[i*ldb : i*ldb+nrhs]
+							if !floats.EqualApprox(got, want, 1e-10) {
+								t.Errorf("%v: unexpected solution in row %v\ngot  %v\nwant %v", name, i, got, want)
+								break
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dposvxer interface {
+	Dposvx(fact lapack.FactJob, uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, equed lapack.Equilibration, s []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.Equilibration, rcond float64, ok bool)
+}
+
+func DposvxTest(t *testing.T, impl Dposvxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, fact := range []lapack.FactJob{lapack.Factorize, lapack.EquilibrateFactorize} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 30} {
+				for _, nrhs := range []int{0, 1, 2, 5} {
+					for _, extra := range []int{0, 3} {
+						for _, scaled := range []bool{false, true} {
+							if scaled && fact != lapack.EquilibrateFactorize {
+								// A badly scaled matrix may be singular to
+								// working precision without equilibration.
+								continue
+							}
+							testDposvx(t, impl, rnd, fact, uplo, n, nrhs, extra, scaled)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDposvx(t *testing.T, impl Dposvxer, rnd *rand.Rand, fact lapack.FactJob, uplo blas.Uplo, n, nrhs, extra int, scaled bool) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("fact=%c,uplo=%c,n=%v,nrhs=%v,extra=%v,scaled=%v", fact, uplo, n, nrhs, extra, scaled)
+
+	// Generate a random symmetric positive definite matrix A, optionally
+	// with a badly scaled diagonal.
+	a := make([]float64, n*n)
+	if n > 0 {
+		g := randomGeneral(n, n, n, rnd)
+		blas64.Syrk(blas.NoTrans, 1, g, 0, blas64.Symmetric{N: n, Stride: n, Uplo: blas.Upper, Data: a})
+		for i := 0; i < n; i++ {
+			a[i*n+i] += 1
+			for j := i + 1; j < n; j++ {
+				a[j*n+i] = a[i*n+j]
+			}
+		}
+	}
+	if scaled {
+		for i := 0; i < n; i++ {
+			s := math.Pow(10, float64(rnd.Intn(11)-5))
+			for j := 0; j < n; j++ {
+				a[i*n+j] *= s
+				a[j*n+i] *= s
+			}
+		}
+	}
+	aFull := blas64.General{Rows: n, Cols: n, Stride: max(1, n), Data: a}
+	lda := max(1, n+extra)
+	aSym := blas64.Symmetric{N: n, Stride: lda, Uplo: uplo, Data: nanSlice(max(0, (n-1)*lda+n))}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j >= i) || (uplo == blas.Lower && j <= i) {
+				aSym.Data[i*lda+j] = a[i*n+j]
+			}
+		}
+	}
+	aCopy := make([]float64, len(aSym.Data))
+	copy(aCopy, aSym.Data)
+
+	// Generate a random solution X and compute the right-hand side
+	// B = A*X.
+	xWant := randomGeneral(n, nrhs, nrhs+extra, rnd)
+	b := randomGeneral(n, nrhs, nrhs+extra, rnd)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aFull, xWant, 0, b)
+	}
+	bCopy := cloneGeneral(b)
+
+	af := nanSlice(max(0, (n-1)*lda+n))
+	s := nanSlice(n)
+	x := nanGeneral(n, nrhs, nrhs+extra)
+	ferr := nanSlice(nrhs)
+	berr := nanSlice(nrhs)
+	work := nanSlice(3 * n)
+	iwork := make([]int, n)
+
+	equed, rcond, ok := impl.Dposvx(fact, uplo, n, nrhs, aSym.Data, lda, af, lda,
+		lapack.EquilibrateNone, s, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure, rcond=%v", name, rcond)
+		return
+	}
+	if fact == lapack.Factorize && equed != lapack.EquilibrateNone {
+		t.Errorf("%v: unexpected equilibration %c", name, equed)
+	}
+	if equed == lapack.EquilibrateNone {
+		for i, v := range aSym.Data {
+			if !sameFloat64(v, aCopy[i]) {
+				t.Errorf("%v: unexpected modification of A", name)
+				break
+			}
+		}
+	}
+	if rcond <= 0 || 1+1e-14 < rcond {
+		t.Errorf("%v: invalid value of rcond, want in (0,1], got %v", name, rcond)
+	}
+	if !generalOutsideAllNaN(x) {
+		t.Errorf("%v: out-of-range write to X", name)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	for j := 0; j < nrhs; j++ {
+		if berr[j] < 0 || tol < berr[j] {
+			t.Errorf("%v: column %v: unexpected backward error, want in [0,%v], got %v", name, j, tol, berr[j])
+		}
+		var diff, xmax float64
+		for i := 0; i < n; i++ {
+			diff = math.Max(diff, math.Abs(x.Data[i*x.Stride+j]-xWant.Data[i*xWant.Stride+j]))
+			xmax = math.Max(xmax, math.Abs(x.Data[i*x.Stride+j]))
+		}
+		if diff/xmax > ferr[j] {
+			t.Errorf("%v: column %v: forward error %v not bounded by ferr=%v", name, j, diff/xmax, ferr[j])
+		}
+	}
+
+	// Solve the system again using the factorization computed above.
+	b2 := cloneGeneral(bCopy)
+	x2 := nanGeneral(n, nrhs, nrhs+extra)
+	_, _, ok = impl.Dposvx(lapack.Factored, uplo, n, nrhs, aSym.Data, lda, af, lda,
+		equed, s, b2.Data, b2.Stride, x2.Data, x2.Stride, ferr, berr, work, iwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure with factored matrix", name)
+		return
+	}
+	if !equalApproxGeneral(x, x2, tol) {
+		t.Errorf("%v: solution using factored matrix differs", name)
+	}
+
+	// Check that an indefinite matrix is detected.
+	aSym.Data[(n/2)*lda+n/2] = -1
+	_, rcond, ok = impl.Dposvx(fact, uplo, n, nrhs, aSym.Data, lda, af, lda,
+		lapack.EquilibrateNone, s, bCopy.Data, bCopy.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+	if ok || rcond != 0 {
+		t.Errorf("%v: indefinite matrix not detected", name)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Dpstf2er interface {
+	Dpstf2(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+}
+
+func Dpstf2Test(t *testing.T, impl Dpstf2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 30, 64, 65} {
+			for _, lda := range []int{max(1, n), n + 5} {
+				for _, rank := range []int{n, n / 2, 1, 0} {
+					if rank > n {
+						continue
+					}
+					testPivotedCholesky(t, "Dpstf2", impl.Dpstf2, rnd, uplo, n, lda, rank)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dpstrfer interface {
+	Dpstrf(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+}
+
+func DpstrfTest(t *testing.T, impl Dpstrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 30, 64, 65, 100, 140} {
+			for _, lda := range []int{max(1, n), n + 5} {
+				for _, rank := range []int{n, n / 2, 1, 0} {
+					if rank > n {
+						continue
+					}
+					testPivotedCholesky(t, "Dpstrf", impl.Dpstrf, rnd, uplo, n, lda, rank)
+				}
+			}
+		}
+	}
+}
+
+// testPivotedCholesky checks a Cholesky factorization with complete pivoting
+// of a random n×n positive semidefinite matrix of the given rank computed by
+// dpstrf.
+func testPivotedCholesky(t *testing.T, name string, dpstrf func(blas.Uplo, int, []float64, int, []int, float64, []float64) (int, bool),
+	rnd *rand.Rand, uplo blas.Uplo, n, lda, rank int) {
+	const tol = 1e-13
+
+	prefix := fmt.Sprintf("%v: uplo=%c,n=%v,lda=%v,rank=%v", name, uplo, n, lda, rank)
+
+	// Generate a positive semidefinite matrix A = B * B^T of the given
+	// rank, where B is an n×rank random matrix.
+	a := randomPSD(n, lda, rank, rnd)
+	aCopy := cloneGeneral(a)
+	var anorm float64
+	for i := 0; i < n; i++ {
+		anorm = math.Max(anorm, a.Data[i*lda+i])
+	}
+
+	piv := make([]int, n)
+	for i := range piv {
+		piv[i] = -1
+	}
+	work := nanSlice(2 * n)
+
+	gotRank, ok := dpstrf(uplo, n, a.Data, a.Stride, piv, -1, work)
+	if ok != (rank == n) {
+		t.Errorf("%v: unexpected ok, got %v want %v", prefix, ok, rank == n)
+	}
+	if gotRank != rank {
+		t.Errorf("%v: unexpected rank, got %v want %v", prefix, gotRank, rank)
+		return
+	}
+
+	// Check that piv is a permutation.
+	seen := make([]bool, n)
+	for _, p := range piv {
+		if p < 0 || n <= p || seen[p] {
+			t.Errorf("%v: piv is not a permutation: %v", prefix, piv)
+			return
+		}
+		seen[p] = true
+	}
+
+	// Check that the diagonal of the factor is non-increasing.
+	for i := 1; i < rank; i++ {
+		if a.Data[i*lda+i] > a.Data[(i-1)*lda+i-1] {
+			t.Errorf("%v: diagonal of the factor is not non-increasing", prefix)
+			break
+		}
+	}
+
+	// Extract the factor into an n×rank matrix F so that
+	//  P^T * A * P = F * F^T.
+	f := zeros(n, rank, max(1, rank))
+	for i := 0; i < n; i++ {
+		for j := 0; j < min(i+1, rank); j++ {
+			if uplo == blas.Upper {
+				f.Data[i*f.Stride+j] = a.Data[j*lda+i]
+			} else {
+				f.Data[i*f.Stride+j] = a.Data[i*lda+j]
+			}
+		}
+	}
+	ff := zeros(n, n, max(1, n))
+	blas64.Gemm(blas.NoTrans, blas.Trans, 1, f, f, 0, ff)
+
+	var resid float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			want := aCopy.Data[piv[i]*lda+piv[j]]
+			resid = math.Max(resid, math.Abs(ff.Data[i*ff.Stride+j]-want))
+		}
+	}
+	if anorm != 0 {
+		resid /= anorm * float64(n)
+	}
+	if resid > tol {
+		t.Errorf("%v: unexpected residual |P^T*A*P - F*F^T| = %v", prefix, resid)
+	}
+}
+
+// randomPSD returns a random n×n symmetric positive semidefinite matrix of the
+// given rank.
+func randomPSD(n, stride, rank int, rnd *rand.Rand) blas64.General {
+	b := randomGeneral(n, rank, max(1, rank), rnd)
+	a := zeros(n, n, stride)
+	if rank > 0 {
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, b, b, 0, a)
+	}
+	return a
+}
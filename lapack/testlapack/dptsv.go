@@ -0,0 +1,101 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dptsver interface {
+	Dptsv(n, nrhs int, d, e []float64, b []float64, ldb int) (ok bool)
+}
+
+func DptsvTest(t *testing.T, impl Dptsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 50} {
+		for _, nrhs := range []int{0, 1, 2, 3, 4, 10} {
+			for _, ldb := range []int{max(1, nrhs), nrhs + 3} {
+				testDptsv(t, impl, rnd, n, nrhs, ldb)
+			}
+		}
+	}
+}
+
+func testDptsv(t *testing.T, impl Dptsver, rnd *rand.Rand, n, nrhs, ldb int) {
+	name := fmt.Sprintf("n=%v,nrhs=%v,ldb=%v", n, nrhs, ldb)
+
+	// Generate a random diagonally dominant symmetric tridiagonal matrix
+	// A with positive diagonal elements. Such a matrix is positive
+	// definite.
+	var e []float64
+	if n > 1 {
+		e = make([]float64, n-1)
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+	}
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = 1 + rnd.Float64()
+		if i > 0 {
+			d[i] += math.Abs(e[i-1])
+		}
+		if i < n-1 {
+			d[i] += math.Abs(e[i])
+		}
+	}
+	a := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+i] = d[i]
+		if i < n-1 {
+			a.Data[i*a.Stride+i+1] = e[i]
+			a.Data[(i+1)*a.Stride+i] = e[i]
+		}
+	}
+
+	// Generate a random solution X and compute the right-hand side
+	// B = A*X.
+	x := randomGeneral(n, nrhs, ldb, rnd)
+	b := randomGeneral(n, nrhs, ldb, rnd)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, x, 0, b)
+	}
+
+	ok := impl.Dptsv(n, nrhs, d, e, b.Data, ldb)
+	if !ok {
+		t.Errorf("%v: unexpected failure for positive definite matrix", name)
+		return
+	}
+	for i := 0; i < n && nrhs > 0; i++ {
+		got := b.Data[i*ldb : i*ldb+nrhs]
+		want := x.Data[i*ldb : i*ldb+nrhs]
+		if !floats.EqualApprox(got, want, 1e-12) {
+			t.Errorf("%v: unexpected solution in row %v\ngot  %v\nwant %v", name, i, got, want)
+			return
+		}
+	}
+
+	// Check that an indefinite matrix is detected.
+	if n > 0 {
+		for i := range d {
+			d[i] = -1
+		}
+		for i := range e {
+			e[i] = 0
+		}
+		ok = impl.Dptsv(n, nrhs, d, e, b.Data, ldb)
+		if ok {
+			t.Errorf("%v: indefinite matrix not detected", name)
+		}
+	}
+}
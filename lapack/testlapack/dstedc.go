@@ -0,0 +1,220 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dstedcer interface {
+	Dstedc(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (ok bool)
+}
+
+func DstedcTest(t *testing.T, impl Dstedcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, compz := range []lapack.EVComp{lapack.EVCompNone, lapack.EVTridiag, lapack.EVOrig} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 26, 50, 101} {
+			for _, ldz := range []int{n, n + 5} {
+				for kind := 0; kind < 6; kind++ {
+					for _, wl := range []worklen{minimumWork, optimumWork} {
+						dstedcTest(t, impl, rnd, compz, n, ldz, kind, wl)
+					}
+				}
+			}
+		}
+	}
+}
+
+func dstedcTest(t *testing.T, impl Dstedcer, rnd *rand.Rand, compz lapack.EVComp, n, ldz, kind int, wl worklen) {
+	const tol = 1e-13
+
+	ldz = max(1, ldz)
+	prefix := fmt.Sprintf("compz=%c,n=%v,ldz=%v,kind=%v,work=%v", compz, n, ldz, kind, wl)
+
+	d, e := symTridiagTestMatrix(kind, n, rnd)
+	dCopy := make([]float64, len(d))
+	copy(dCopy, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+
+	// For lapack.EVOrig, the tridiagonal matrix is considered to be the
+	// reduction Q^T * A * Q of a symmetric matrix A.
+	z := nanGeneral(n, n, ldz)
+	var q blas64.General
+	if compz == lapack.EVOrig {
+		q = randomOrthogonal(n, rnd)
+		copyGeneral(z, q)
+	}
+
+	var lwork, liwork int
+	switch wl {
+	case minimumWork:
+		lwork, liwork = 1, 1
+		if compz != lapack.EVCompNone && n > 1 {
+			if n <= 25 {
+				lwork = 2 * (n - 1)
+			} else {
+				lwork = 1 + 4*n + 2*n*n
+				if compz == lapack.EVOrig {
+					lwork += n * n
+				}
+				liwork = 3 + 5*n
+			}
+		}
+	case optimumWork:
+		work := []float64{0}
+		iwork := []int{0}
+		impl.Dstedc(compz, n, d, e, z.Data, z.Stride, work, -1, iwork, -1)
+		lwork, liwork = int(work[0]), iwork[0]
+	}
+	work := nanSlice(max(1, lwork))
+	iwork := make([]int, max(1, liwork))
+
+	ok := impl.Dstedc(compz, n, d, e, z.Data, z.Stride, work, lwork, iwork, liwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	if !sort.Float64sAreSorted(d) {
+		t.Errorf("%v: eigenvalues not sorted", prefix)
+	}
+
+	// Compare the eigenvalues with those computed by Dsterf.
+	want := make([]float64, n)
+	copy(want, dCopy)
+	impl.Dstedc(lapack.EVCompNone, n, want, append([]float64{}, eCopy...), nil, 1, []float64{0}, 1, []int{0}, 1)
+	if !floats.EqualApprox(d, want, tol*math.Max(1, floats.Norm(want, math.Inf(1)))) {
+		t.Errorf("%v: unexpected eigenvalues", prefix)
+	}
+
+	if compz == lapack.EVCompNone {
+		return
+	}
+
+	if !generalOutsideAllNaN(z) {
+		t.Errorf("%v: out-of-range write to Z", prefix)
+	}
+	if !isOrthogonal(z) {
+		t.Errorf("%v: Z is not orthogonal", prefix)
+	}
+
+	// Check that T*Z^ = Z^*Λ where Z^ = Q^T*Z holds the eigenvectors of the
+	// tridiagonal matrix.
+	zt := z
+	if compz == lapack.EVOrig {
+		zt = zeros(n, n, n)
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, q, z, 0, zt)
+	}
+	if resid := symTridiagResidual(n, dCopy, eCopy, d, zt); resid > tol {
+		t.Errorf("%v: unexpected residual |T*Z - Z*Λ| = %v", prefix, resid)
+	}
+}
+
+// symTridiagTestMatrix returns the diagonal and off-diagonal elements of an
+// n×n symmetric tridiagonal test matrix of the given kind:
+//  - a matrix with random elements if kind == 0,
+//  - the Wilkinson matrix W+ with pairs of close eigenvalues if kind == 1,
+//  - Wilkinson matrices of order 21 glued by small off-diagonal elements,
+//    which have clusters of very close eigenvalues, if kind == 2,
+//  - the identity matrix if kind == 3,
+//  - a matrix with constant diagonal and tiny random off-diagonal elements
+//    if kind == 4,
+//  - a graded matrix if kind == 5.
+func symTridiagTestMatrix(kind, n int, rnd *rand.Rand) (d, e []float64) {
+	d = make([]float64, n)
+	e = make([]float64, max(0, n-1))
+	switch kind {
+	default:
+		panic("unknown test matrix kind")
+	case 0:
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+	case 1:
+		for i := range d {
+			d[i] = math.Abs(float64(i) - float64(n-1)/2)
+		}
+		for i := range e {
+			e[i] = 1
+		}
+	case 2:
+		const m = 21
+		for i := range d {
+			d[i] = math.Abs(float64(i%m - m/2))
+		}
+		for i := range e {
+			e[i] = 1
+			if i%m == m-1 {
+				e[i] = 1e-9
+			}
+		}
+	case 3:
+		for i := range d {
+			d[i] = 1
+		}
+	case 4:
+		for i := range d {
+			d[i] = 1
+		}
+		for i := range e {
+			e[i] = 1e-12 * rnd.NormFloat64()
+		}
+	case 5:
+		for i := range d {
+			d[i] = math.Pow(2, -float64(i))
+		}
+		for i := range e {
+			e[i] = math.Pow(2, -float64(i)-0.5)
+		}
+	}
+	return d, e
+}
+
+// symTridiagResidual returns
+//  |T*Z - Z*Λ| / (n * max(1, |T|))
+// in the max-abs norm where T is the n×n symmetric tridiagonal matrix with
+// diagonal d and off-diagonal e, and the columns of Z are the eigenvectors
+// corresponding to the eigenvalues in w.
+func symTridiagResidual(n int, d, e, w []float64, z blas64.General) float64 {
+	if n == 0 {
+		return 0
+	}
+	m := z.Cols
+	tnorm := math.Max(1, floats.Norm(d, math.Inf(1)))
+	if n > 1 {
+		tnorm = math.Max(tnorm, floats.Norm(e, math.Inf(1)))
+	}
+	var resid float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			r := (d[i] - w[j]) * z.Data[i*z.Stride+j]
+			if i > 0 {
+				r += e[i-1] * z.Data[(i-1)*z.Stride+j]
+			}
+			if i < n-1 {
+				r += e[i] * z.Data[(i+1)*z.Stride+j]
+			}
+			resid = math.Max(resid, math.Abs(r))
+		}
+	}
+	return resid / tnorm / float64(n)
+}
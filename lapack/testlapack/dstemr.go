@@ -0,0 +1,165 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dstemrer interface {
+	Dstemr(jobz lapack.EVJob, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, tryrac bool, work []float64, lwork int, iwork []int, liwork int) (m int, rac, ok bool)
+	Dsterfer
+}
+
+func DstemrTest(t *testing.T, impl Dstemrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, jobz := range []lapack.EVJob{lapack.EVCompute, lapack.EVNone} {
+		for _, rng := range []lapack.EVRange{lapack.EVRangeAll, lapack.EVRangeValue, lapack.EVRangeIndex} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 26, 50, 101} {
+				for kind := 0; kind < 6; kind++ {
+					for _, tryrac := range []bool{false, true} {
+						for _, wl := range []worklen{minimumWork, optimumWork} {
+							dstemrTest(t, impl, rnd, jobz, rng, n, kind, tryrac, wl)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func dstemrTest(t *testing.T, impl Dstemrer, rnd *rand.Rand, jobz lapack.EVJob, rng lapack.EVRange, n, kind int, tryrac bool, wl worklen) {
+	const tol = 1e-13
+
+	wantz := jobz == lapack.EVCompute
+	prefix := fmt.Sprintf("jobz=%c,rng=%c,n=%v,kind=%v,tryrac=%v,work=%v", jobz, rng, n, kind, tryrac, wl)
+
+	d, e := symTridiagTestMatrix(kind, n, rnd)
+	dCopy := make([]float64, len(d))
+	copy(dCopy, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+	tnorm := math.Max(1, floats.Norm(d, math.Inf(1)))
+	if n > 1 {
+		tnorm = math.Max(tnorm, floats.Norm(e, math.Inf(1)))
+	}
+
+	// Compute the reference eigenvalues.
+	ev := make([]float64, n)
+	copy(ev, d)
+	impl.Dsterf(n, ev, append([]float64{}, e...))
+
+	// Choose the wanted range so that the bounds of the value range lie in
+	// gaps of the spectrum.
+	il, iu := 0, n-1
+	var vl, vu float64
+	if rng != lapack.EVRangeAll && n > 0 {
+		il = n / 4
+		for il > 0 && ev[il]-ev[il-1] < 1e-6*tnorm {
+			il--
+		}
+		iu = max(il, 3*n/4-1)
+		for iu < n-1 && ev[iu+1]-ev[iu] < 1e-6*tnorm {
+			iu++
+		}
+		vl = ev[0] - 1
+		if il > 0 {
+			vl = (ev[il-1] + ev[il]) / 2
+		}
+		vu = ev[n-1] + 1
+		if iu < n-1 {
+			vu = (ev[iu] + ev[iu+1]) / 2
+		}
+	}
+	if rng == lapack.EVRangeValue && n == 0 {
+		vl, vu = 0, 1
+	}
+	want := ev[il : iu+1]
+	nz := n
+	if rng == lapack.EVRangeIndex {
+		nz = iu - il + 1
+	}
+
+	var lwork, liwork int
+	switch wl {
+	case minimumWork:
+		lwork = max(1, 7*n)
+		if wantz {
+			lwork = max(1, 19*n)
+		}
+		liwork = max(1, 2*n)
+	case optimumWork:
+		work := []float64{0}
+		iwork := []int{0}
+		impl.Dstemr(jobz, rng, n, d, e, vl, vu, il, iu, nil, nil, max(1, nz), nil, tryrac, work, -1, iwork, -1)
+		lwork, liwork = int(work[0]), iwork[0]
+	}
+	work := nanSlice(lwork)
+	iwork := make([]int, liwork)
+
+	w := nanSlice(n)
+	z := nanGeneral(n, nz, nz+3)
+	isuppz := make([]int, 2*nz)
+
+	m, rac, ok := impl.Dstemr(jobz, rng, n, d, e, vl, vu, il, iu, w, z.Data, z.Stride, isuppz, tryrac, work, lwork, iwork, liwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if m != len(want) {
+		t.Errorf("%v: unexpected number of eigenvalues, got %v want %v", prefix, m, len(want))
+		return
+	}
+	if kind == 3 && n > 1 && rac != tryrac {
+		t.Errorf("%v: unexpected relative accuracy flag for the identity matrix, got %v", prefix, rac)
+	}
+	if n == 0 {
+		return
+	}
+
+	if !sort.Float64sAreSorted(w[:m]) {
+		t.Errorf("%v: eigenvalues not sorted", prefix)
+	}
+	if !floats.EqualApprox(w[:m], want, tol*tnorm) {
+		t.Errorf("%v: unexpected eigenvalues", prefix)
+	}
+
+	if !wantz {
+		return
+	}
+
+	if !generalOutsideAllNaN(z) {
+		t.Errorf("%v: out-of-range write to Z", prefix)
+	}
+	zm := blas64.General{Rows: n, Cols: m, Stride: z.Stride, Data: z.Data}
+	if !hasOrthonormalColumns(zm) {
+		t.Errorf("%v: eigenvectors are not orthonormal", prefix)
+	}
+	if resid := symTridiagResidual(n, dCopy, eCopy, w[:m], zm); resid > tol {
+		t.Errorf("%v: unexpected residual |T*Z - Z*Λ| = %v", prefix, resid)
+	}
+	for j := 0; j < m; j++ {
+		b1, b2 := isuppz[2*j], isuppz[2*j+1]
+		if b1 < 0 || b2 < b1 || n <= b2 {
+			t.Errorf("%v: invalid support [%v,%v] of eigenvector %v", prefix, b1, b2, j)
+			continue
+		}
+		for i := 0; i < n; i++ {
+			if (i < b1 || b2 < i) && z.Data[i*z.Stride+j] != 0 {
+				t.Errorf("%v: non-zero element outside the support of eigenvector %v", prefix, j)
+				break
+			}
+		}
+	}
+}
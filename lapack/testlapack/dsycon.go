@@ -0,0 +1,79 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsyconer interface {
+	Dsytrfer
+	Dgetrier
+	Dlange(norm lapack.MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+	Dlansy(norm lapack.MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
+	Dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DsyconTest(t *testing.T, impl Dsyconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{1, 2, 3, 5, 10, 30, 80} {
+			for _, lda := range []int{n, n + 3} {
+				for _, kind := range []string{"random", "kkt"} {
+					name := fmt.Sprintf("uplo=%c,n=%v,lda=%v,kind=%v", uplo, n, lda, kind)
+
+					a := randomSymmetricIndefinite(kind, n, lda, rnd)
+
+					// Compute the exact reciprocal condition number
+					// in the 1-norm from the explicit inverse.
+					aInv := make([]float64, len(a))
+					copy(aInv, a)
+					ipiv := make([]int, n)
+					impl.Dgetrf(n, n, aInv, lda, ipiv)
+					work := make([]float64, 1)
+					impl.Dgetri(n, aInv, lda, ipiv, work, -1)
+					work = make([]float64, int(work[0]))
+					impl.Dgetri(n, aInv, lda, ipiv, work, len(work))
+					work = make([]float64, n)
+					anorm := impl.Dlange(lapack.MaxColumnSum, n, n, a, lda, work)
+					ainvnm := impl.Dlange(lapack.MaxColumnSum, n, n, aInv, lda, work)
+					want := 1 / anorm / ainvnm
+
+					if got := impl.Dlansy(lapack.MaxColumnSum, uplo, n, a, lda, work); math.Abs(got-anorm) > 1e-14*anorm {
+						t.Errorf("%v: bad test, Dlansy and Dlange mismatch", name)
+						continue
+					}
+					impl.Dsytrf(uplo, n, a, lda, ipiv, work, -1)
+					work = make([]float64, int(work[0]))
+					ok := impl.Dsytrf(uplo, n, a, lda, ipiv, work, len(work))
+					if !ok {
+						t.Errorf("%v: bad test, matrix is singular", name)
+						continue
+					}
+
+					work = make([]float64, 2*n)
+					iwork := make([]int, n)
+					got := impl.Dsycon(uplo, n, a, lda, ipiv, anorm, work, iwork)
+
+					// The estimate of the norm of the inverse is a
+					// lower bound, so the estimated reciprocal
+					// condition number must not be smaller than the
+					// exact one. It should also be within a small
+					// factor of it.
+					if got < want*(1-1e-8) || got > 10*want {
+						t.Errorf("%v: unexpected rcond; got %v, want %v", name, got, want)
+					}
+				}
+			}
+		}
+	}
+}
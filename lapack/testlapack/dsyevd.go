@@ -0,0 +1,155 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsyevder interface {
+	Dsyevd(jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool)
+	Dsyever
+}
+
+func DsyevdTest(t *testing.T, impl Dsyevder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Lower, blas.Upper} {
+		for _, n := range []int{0, 1, 2, 5, 10, 26, 50, 100} {
+			for _, lda := range []int{n, n + 5} {
+				for _, clustered := range []bool{false, true} {
+					for _, wl := range []worklen{minimumWork, optimumWork} {
+						dsyevdTest(t, impl, rnd, uplo, n, lda, clustered, wl)
+					}
+				}
+			}
+		}
+	}
+}
+
+func dsyevdTest(t *testing.T, impl Dsyevder, rnd *rand.Rand, uplo blas.Uplo, n, lda int, clustered bool, wl worklen) {
+	const tol = 1e-13
+
+	lda = max(1, lda)
+	prefix := fmt.Sprintf("uplo=%c,n=%v,lda=%v,clustered=%v,work=%v", uplo, n, lda, clustered, wl)
+
+	// Generate a symmetric matrix, optionally with clusters of close
+	// eigenvalues.
+	var a blas64.General
+	if clustered && n > 0 {
+		ev := make([]float64, n)
+		for i := range ev {
+			ev[i] = float64(i/4) + 1e-10*rnd.Float64()
+		}
+		a = nanGeneral(n, n, lda)
+		Dlagsy(n, n-1, ev, a.Data, a.Stride, rnd, make([]float64, 2*n))
+	} else {
+		a = randomGeneral(n, n, lda, rnd)
+	}
+	orig := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && i >= j) {
+				orig.Data[i*n+j] = a.Data[i*a.Stride+j]
+				orig.Data[j*n+i] = a.Data[i*a.Stride+j]
+			}
+		}
+	}
+	aCopy := cloneGeneral(a)
+
+	for _, jobz := range []lapack.EVJob{lapack.EVCompute, lapack.EVNone} {
+		copyGeneral(a, aCopy)
+		w := nanSlice(n)
+
+		var lwork, liwork int
+		switch wl {
+		case minimumWork:
+			lwork, liwork = 1, 1
+			if n > 1 {
+				if jobz == lapack.EVCompute {
+					lwork = 1 + 6*n + 3*n*n
+					liwork = 3 + 5*n
+				} else {
+					lwork = 2*n + 1
+				}
+			}
+		case optimumWork:
+			work := []float64{0}
+			iwork := []int{0}
+			impl.Dsyevd(jobz, uplo, n, a.Data, a.Stride, w, work, -1, iwork, -1)
+			lwork, liwork = int(work[0]), iwork[0]
+		}
+		work := nanSlice(lwork)
+		iwork := make([]int, liwork)
+
+		ok := impl.Dsyevd(jobz, uplo, n, a.Data, a.Stride, w, work, lwork, iwork, liwork)
+		if !ok {
+			t.Errorf("%v,jobz=%c: unexpected failure", prefix, jobz)
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+		if !sort.Float64sAreSorted(w) {
+			t.Errorf("%v,jobz=%c: eigenvalues not sorted", prefix, jobz)
+		}
+
+		if jobz == lapack.EVNone {
+			// Compare the eigenvalues with those computed by Dsyev.
+			want := make([]float64, n)
+			work := make([]float64, 3*n)
+			ac := cloneGeneral(aCopy)
+			impl.Dsyev(lapack.EVNone, uplo, n, ac.Data, ac.Stride, want, work, len(work))
+			if !floats.EqualApprox(w, want, tol*math.Max(1, floats.Norm(want, math.Inf(1)))) {
+				t.Errorf("%v,jobz=%c: unexpected eigenvalues", prefix, jobz)
+			}
+			continue
+		}
+
+		if !generalOutsideAllNaN(a) {
+			t.Errorf("%v,jobz=%c: out-of-range write to A", prefix, jobz)
+		}
+		z := blas64.General{Rows: n, Cols: n, Stride: a.Stride, Data: a.Data}
+		if !isOrthogonal(z) {
+			t.Errorf("%v,jobz=%c: eigenvectors are not orthonormal", prefix, jobz)
+		}
+		if resid := symEigenResidual(orig, w, z); resid > tol {
+			t.Errorf("%v,jobz=%c: unexpected residual |A*Z - Z*Λ| = %v", prefix, jobz, resid)
+		}
+	}
+}
+
+// symEigenResidual returns
+//  |A*Z - Z*Λ| / (n * max(1, |A|))
+// in the max-abs norm where A is an n×n symmetric matrix stored in full, and
+// the columns of Z are the eigenvectors corresponding to the eigenvalues in w.
+func symEigenResidual(a blas64.General, w []float64, z blas64.General) float64 {
+	n := a.Rows
+	m := z.Cols
+	if n == 0 || m == 0 {
+		return 0
+	}
+	r := zeros(n, m, m)
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			r.Data[i*m+j] = z.Data[i*z.Stride+j] * w[j]
+		}
+	}
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, z, -1, r)
+	var anorm float64
+	for i := 0; i < n; i++ {
+		anorm = math.Max(anorm, floats.Norm(a.Data[i*a.Stride:i*a.Stride+n], math.Inf(1)))
+	}
+	return floats.Norm(r.Data, math.Inf(1)) / math.Max(1, anorm) / float64(n)
+}
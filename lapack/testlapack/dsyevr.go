@@ -0,0 +1,168 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsyevrer interface {
+	Dsyevr(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, w, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool)
+	Dsyever
+}
+
+func DsyevrTest(t *testing.T, impl Dsyevrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Lower, blas.Upper} {
+		for _, rng := range []lapack.EVRange{lapack.EVRangeAll, lapack.EVRangeValue, lapack.EVRangeIndex} {
+			for _, n := range []int{0, 1, 2, 5, 10, 26, 50, 100} {
+				for _, lda := range []int{n, n + 5} {
+					for _, clustered := range []bool{false, true} {
+						for _, wl := range []worklen{minimumWork, optimumWork} {
+							dsyevrTest(t, impl, rnd, uplo, rng, n, lda, clustered, wl)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func dsyevrTest(t *testing.T, impl Dsyevrer, rnd *rand.Rand, uplo blas.Uplo, rng lapack.EVRange, n, lda int, clustered bool, wl worklen) {
+	const tol = 1e-13
+
+	lda = max(1, lda)
+	prefix := fmt.Sprintf("uplo=%c,rng=%c,n=%v,lda=%v,clustered=%v,work=%v", uplo, rng, n, lda, clustered, wl)
+
+	// Generate a symmetric matrix, optionally with clusters of close
+	// eigenvalues.
+	var a blas64.General
+	if clustered && n > 0 {
+		ev := make([]float64, n)
+		for i := range ev {
+			ev[i] = float64(i/4) + 1e-10*rnd.Float64()
+		}
+		a = nanGeneral(n, n, lda)
+		Dlagsy(n, n-1, ev, a.Data, a.Stride, rnd, make([]float64, 2*n))
+	} else {
+		a = randomGeneral(n, n, lda, rnd)
+	}
+	orig := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && i >= j) {
+				orig.Data[i*n+j] = a.Data[i*a.Stride+j]
+				orig.Data[j*n+i] = a.Data[i*a.Stride+j]
+			}
+		}
+	}
+	aCopy := cloneGeneral(a)
+
+	// Compute the reference eigenvalues.
+	ev := make([]float64, n)
+	impl.Dsyev(lapack.EVNone, uplo, n, cloneGeneral(a).Data, a.Stride, ev, make([]float64, max(1, 3*n)), max(1, 3*n))
+	anorm := math.Max(1, floats.Norm(ev, math.Inf(1)))
+
+	// Choose the wanted range so that the bounds of the value range lie in
+	// gaps of the spectrum.
+	il, iu := 0, n-1
+	var vl, vu float64
+	if rng != lapack.EVRangeAll && n > 0 {
+		il = n / 4
+		for il > 0 && ev[il]-ev[il-1] < 1e-6*anorm {
+			il--
+		}
+		iu = max(il, 3*n/4-1)
+		for iu < n-1 && ev[iu+1]-ev[iu] < 1e-6*anorm {
+			iu++
+		}
+		vl = ev[0] - 1
+		if il > 0 {
+			vl = (ev[il-1] + ev[il]) / 2
+		}
+		vu = ev[n-1] + 1
+		if iu < n-1 {
+			vu = (ev[iu] + ev[iu+1]) / 2
+		}
+	}
+	if rng == lapack.EVRangeValue && n == 0 {
+		vl, vu = 0, 1
+	}
+	want := ev[il : iu+1]
+	nz := n
+	if rng == lapack.EVRangeIndex {
+		nz = iu - il + 1
+	}
+
+	for _, jobz := range []lapack.EVJob{lapack.EVCompute, lapack.EVNone} {
+		copyGeneral(a, aCopy)
+		wantz := jobz == lapack.EVCompute
+
+		var lwork, liwork int
+		switch wl {
+		case minimumWork:
+			lwork = max(1, 10*n)
+			if wantz {
+				lwork = max(1, 22*n)
+			}
+			liwork = max(1, 4*n)
+		case optimumWork:
+			work := []float64{0}
+			iwork := []int{0}
+			impl.Dsyevr(jobz, rng, uplo, n, a.Data, a.Stride, vl, vu, il, iu, nil, nil, max(1, nz), work, -1, iwork, -1)
+			lwork, liwork = int(work[0]), iwork[0]
+		}
+		work := nanSlice(lwork)
+		iwork := make([]int, liwork)
+
+		w := nanSlice(n)
+		z := nanGeneral(n, nz, nz+3)
+
+		m, ok := impl.Dsyevr(jobz, rng, uplo, n, a.Data, a.Stride, vl, vu, il, iu, w, z.Data, z.Stride, work, lwork, iwork, liwork)
+		if !ok {
+			t.Errorf("%v,jobz=%c: unexpected failure", prefix, jobz)
+			continue
+		}
+		if m != len(want) {
+			t.Errorf("%v,jobz=%c: unexpected number of eigenvalues, got %v want %v", prefix, jobz, m, len(want))
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+
+		if !sort.Float64sAreSorted(w[:m]) {
+			t.Errorf("%v,jobz=%c: eigenvalues not sorted", prefix, jobz)
+		}
+		if !floats.EqualApprox(w[:m], want, tol*anorm) {
+			t.Errorf("%v,jobz=%c: unexpected eigenvalues", prefix, jobz)
+		}
+
+		if !wantz {
+			continue
+		}
+
+		if !generalOutsideAllNaN(z) {
+			t.Errorf("%v,jobz=%c: out-of-range write to Z", prefix, jobz)
+		}
+		zm := blas64.General{Rows: n, Cols: m, Stride: z.Stride, Data: z.Data}
+		if !hasOrthonormalColumns(zm) {
+			t.Errorf("%v,jobz=%c: eigenvectors are not orthonormal", prefix, jobz)
+		}
+		if resid := symEigenResidual(orig, w[:m], zm); resid > tol {
+			t.Errorf("%v,jobz=%c: unexpected residual |A*Z - Z*Λ| = %v", prefix, jobz, resid)
+		}
+	}
+}
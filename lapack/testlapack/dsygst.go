@@ -0,0 +1,156 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsygster interface {
+	Dsygst(itype lapack.GenEVType, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int)
+
+	Dpotrfer
+}
+
+type Dsygs2er interface {
+	Dsygs2(itype lapack.GenEVType, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int)
+
+	Dpotrfer
+}
+
+func Dsygs2Test(t *testing.T, impl Dsygs2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, itype := range []lapack.GenEVType{lapack.AxLBx, lapack.ABxLx, lapack.BAxLx} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 5, 10, 20} {
+				for _, ld := range []int{max(1, n), n + 11} {
+					dsygstTest(t, dsygs2Wrapper{impl}, rnd, itype, uplo, n, ld, ld)
+				}
+			}
+		}
+	}
+}
+
+// dsygs2Wrapper adapts a Dsygs2er to the Dsygster interface so that the
+// unblocked and blocked routines can share a test.
+type dsygs2Wrapper struct {
+	Dsygs2er
+}
+
+func (w dsygs2Wrapper) Dsygst(itype lapack.GenEVType, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int) {
+	w.Dsygs2(itype, uplo, n, a, lda, b, ldb)
+}
+
+func DsygstTest(t *testing.T, impl Dsygster) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, itype := range []lapack.GenEVType{lapack.AxLBx, lapack.ABxLx, lapack.BAxLx} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 5, 10, 63, 65, 100, 129} {
+				for _, ld := range []int{max(1, n), n + 11} {
+					dsygstTest(t, impl, rnd, itype, uplo, n, ld, ld)
+				}
+			}
+		}
+	}
+}
+
+func dsygstTest(t *testing.T, impl Dsygster, rnd *rand.Rand, itype lapack.GenEVType, uplo blas.Uplo, n, lda, ldb int) {
+	const tol = 1e-12
+
+	name := fmt.Sprintf("itype=%v,uplo=%v,n=%v,lda=%v,ldb=%v", itype, string(uplo), n, lda, ldb)
+
+	// Generate a random symmetric matrix A.
+	a := randomGeneral(n, n, lda, rnd)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			a.Data[j*a.Stride+i] = a.Data[i*a.Stride+j]
+		}
+	}
+	aCopy := cloneGeneral(a)
+
+	// Generate a random symmetric positive definite matrix B and compute
+	// its Cholesky factorization.
+	d := make([]float64, n)
+	Dlatm1(d, 4, 100, false, 1, rnd)
+	b := zeros(n, n, ldb)
+	if n > 0 {
+		Dlagsy(n, 0, d, b.Data, b.Stride, rnd, make([]float64, 2*n))
+	}
+	if !impl.Dpotrf(uplo, n, b.Data, b.Stride) {
+		t.Fatalf("%v: unexpected Cholesky failure", name)
+	}
+	bCopy := cloneGeneral(b)
+
+	impl.Dsygst(itype, uplo, n, a.Data, a.Stride, b.Data, b.Stride)
+
+	if !equalApproxGeneral(b, bCopy, 0) {
+		t.Errorf("%v: unexpected modification of B", name)
+	}
+	if n == 0 {
+		return
+	}
+
+	// Extract the triangular factor of B and the transformed matrix as
+	// full matrices.
+	f := zeros(n, n, n)
+	c := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j >= i) || (uplo == blas.Lower && j <= i) {
+				f.Data[i*n+j] = b.Data[i*b.Stride+j]
+				c.Data[i*n+j] = a.Data[i*a.Stride+j]
+				c.Data[j*n+i] = a.Data[i*a.Stride+j]
+			}
+		}
+	}
+
+	// The transformed matrix C satisfies
+	//  U^T*C*U = A or L*C*L^T = A, if itype == lapack.AxLBx,
+	//  C = U*A*U^T or C = L^T*A*L, otherwise.
+	got := zeros(n, n, n)
+	want := zeros(n, n, n)
+	tmp := zeros(n, n, n)
+	trans := blas.NoTrans
+	if uplo == blas.Lower {
+		trans = blas.Trans
+	}
+	notTrans := blas.Trans
+	if trans == blas.Trans {
+		notTrans = blas.NoTrans
+	}
+	if itype == lapack.AxLBx {
+		// Compute F^T*C*F with F = U, or F*C*F^T with F = L.
+		blas64.Gemm(notTrans, blas.NoTrans, 1, f, c, 0, tmp)
+		blas64.Gemm(blas.NoTrans, trans, 1, tmp, f, 0, got)
+		copyGeneral(want, aCopy)
+	} else {
+		// Compute F*A*F^T with F = U, or F^T*A*F with F = L.
+		blas64.Gemm(trans, blas.NoTrans, 1, f, aCopy, 0, tmp)
+		blas64.Gemm(blas.NoTrans, notTrans, 1, tmp, f, 0, want)
+		copyGeneral(got, c)
+	}
+	if !equalApproxGeneral(got, want, tol*float64(n)) {
+		t.Errorf("%v: unexpected result", name)
+	}
+
+	// Check that the triangle not specified by uplo is not referenced.
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+				if a.Data[i*a.Stride+j] != aCopy.Data[i*aCopy.Stride+j] {
+					t.Errorf("%v: unexpected modification of the opposite triangle", name)
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dsytf2er interface {
+	Dsytf2(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) (ok bool)
+}
+
+func Dsytf2Test(t *testing.T, impl Dsytf2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 17, 40} {
+			for _, lda := range []int{max(1, n), n + 3} {
+				for _, kind := range []string{"random", "kkt"} {
+					name := fmt.Sprintf("uplo=%c,n=%v,lda=%v,kind=%v", uplo, n, lda, kind)
+					a := randomSymmetricIndefinite(kind, n, lda, rnd)
+					aCopy := make([]float64, len(a))
+					copy(aCopy, a)
+					ipiv := make([]int, n)
+					for i := range ipiv {
+						ipiv[i] = math.MinInt32
+					}
+
+					ok := impl.Dsytf2(uplo, n, a, lda, ipiv)
+					if !ok {
+						t.Errorf("%v: unexpected failure", name)
+						continue
+					}
+					checkBunchKaufman(t, name, uplo, n, a, lda, ipiv, aCopy)
+				}
+			}
+		}
+	}
+
+	// Check that an exactly singular D is detected.
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		n := 4
+		a := make([]float64, n*n)
+		ipiv := make([]int, n)
+		if impl.Dsytf2(uplo, n, a, n, ipiv) {
+			t.Errorf("uplo=%c: zero matrix factorized as nonsingular", uplo)
+		}
+	}
+}
+
+// randomSymmetricIndefinite returns a random n×n symmetric indefinite matrix
+// with stride lda. If kind is "kkt", the matrix has the saddle point structure
+//  [ H  B^T ]
+//  [ B   0  ]
+// that leads to 2×2 pivots in the Bunch-Kaufman factorization.
+func randomSymmetricIndefinite(kind string, n, lda int, rnd *rand.Rand) []float64 {
+	a := make([]float64, max(0, (n-1)*lda+n))
+	for i := range a {
+		a[i] = math.NaN()
+	}
+	m := n / 3
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			v := rnd.NormFloat64()
+			if kind == "kkt" && i >= n-m && j >= n-m {
+				v = 0
+			}
+			a[i*lda+j] = v
+			a[j*lda+i] = v
+		}
+	}
+	return a
+}
+
+// constructBunchKaufman returns the n×n symmetric matrix
+//  U*D*U^T  if uplo == blas.Upper, or
+//  L*D*L^T  if uplo == blas.Lower,
+// represented by the factorization computed by Dsytrf in a and ipiv.
+func constructBunchKaufman(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) blas64.General {
+	f := eye(n, n)
+	d := zeros(n, n, n)
+	swapCols := func(i, j int) {
+		for r := 0; r < n; r++ {
+			f.Data[r*n+i], f.Data[r*n+j] = f.Data[r*n+j], f.Data[r*n+i]
+		}
+	}
+	if uplo == blas.Upper {
+		for k := n - 1; k >= 0; {
+			s := 1
+			kp := ipiv[k]
+			if kp < 0 {
+				s = 2
+				kp = -kp - 1
+			}
+			kk := k - s + 1
+			// F = F * P[k].
+			if kp != kk {
+				swapCols(kk, kp)
+			}
+			// F = F * U[k].
+			for c := kk; c <= k; c++ {
+				for i := 0; i < n; i++ {
+					var sum float64
+					for r := 0; r < kk; r++ {
+						sum += f.Data[i*n+r] * a[r*lda+c]
+					}
+					f.Data[i*n+c] += sum
+				}
+			}
+			// Extract D[k].
+			d.Data[k*n+k] = a[k*lda+k]
+			if s == 2 {
+				d.Data[kk*n+kk] = a[kk*lda+kk]
+				d.Data[kk*n+k] = a[kk*lda+k]
+				d.Data[k*n+kk] = a[kk*lda+k]
+			}
+			k -= s
+		}
+	} else {
+		for k := 0; k < n; {
+			s := 1
+			kp := ipiv[k]
+			if kp < 0 {
+				s = 2
+				kp = -kp - 1
+			}
+			kk := k + s - 1
+			// F = F * P[k].
+			if kp != kk {
+				swapCols(kk, kp)
+			}
+			// F = F * L[k].
+			for c := k; c <= kk; c++ {
+				for i := 0; i < n; i++ {
+					var sum float64
+					for r := kk + 1; r < n; r++ {
+						sum += f.Data[i*n+r] * a[r*lda+c]
+					}
+					f.Data[i*n+c] += sum
+				}
+			}
+			// Extract D[k].
+			d.Data[k*n+k] = a[k*lda+k]
+			if s == 2 {
+				d.Data[kk*n+kk] = a[kk*lda+kk]
+				d.Data[kk*n+k] = a[kk*lda+k]
+				d.Data[k*n+kk] = a[kk*lda+k]
+			}
+			k += s
+		}
+	}
+	fd := zeros(n, n, n)
+	ans := zeros(n, n, n)
+	if n > 0 {
+		bi := blas64.Implementation()
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n, n, n, 1, f.Data, n, d.Data, n, 0, fd.Data, n)
+		bi.Dgemm(blas.NoTrans, blas.Trans, n, n, n, 1, fd.Data, n, f.Data, n, 0, ans.Data, n)
+	}
+	return ans
+}
+
+// checkBunchKaufman checks that the pivot indices in ipiv are valid and that
+// the factorization in a and ipiv reconstructs the symmetric matrix stored in
+// the uplo triangle of aCopy.
+func checkBunchKaufman(t *testing.T, name string, uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, aCopy []float64) {
+	t.Helper()
+
+	// Check the block structure of D encoded in ipiv.
+	for k := 0; k < n; {
+		kp := ipiv[k]
+		if kp >= 0 {
+			if kp >= n {
+				t.Errorf("%v: ipiv[%v] out of range", name, k)
+				return
+			}
+			k++
+			continue
+		}
+		if k == n-1 || ipiv[k+1] != kp || -kp-1 >= n {
+			t.Errorf("%v: invalid 2×2 pivot at %v", name, k)
+			return
+		}
+		k += 2
+	}
+
+	got := constructBunchKaufman(uplo, n, a, lda, ipiv)
+	var anorm, diff float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var want float64
+			if (uplo == blas.Upper) == (i <= j) {
+				want = aCopy[i*lda+j]
+			} else {
+				want = aCopy[j*lda+i]
+			}
+			anorm = math.Max(anorm, math.Abs(want))
+			diff = math.Max(diff, math.Abs(got.Data[i*got.Stride+j]-want))
+		}
+	}
+	const tol = 1e-12
+	if diff > tol*float64(n)*anorm {
+		t.Errorf("%v: factorization does not reconstruct A; |A - F*D*F^T| = %v", name, diff)
+	}
+}
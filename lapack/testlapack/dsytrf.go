@@ -0,0 +1,68 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Dsytrfer interface {
+	Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
+}
+
+func DsytrfTest(t *testing.T, impl Dsytrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 30, 63, 64, 65, 100, 150} {
+			for _, lda := range []int{max(1, n), n + 7} {
+				for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+					for _, kind := range []string{"random", "kkt"} {
+						testDsytrf(t, impl, rnd, uplo, n, lda, wl, kind)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDsytrf(t *testing.T, impl Dsytrfer, rnd *rand.Rand, uplo blas.Uplo, n, lda int, wl worklen, kind string) {
+	name := fmt.Sprintf("uplo=%c,n=%v,lda=%v,work=%v,kind=%v", uplo, n, lda, wl, kind)
+
+	a := randomSymmetricIndefinite(kind, n, lda, rnd)
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+	ipiv := make([]int, n)
+	for i := range ipiv {
+		ipiv[i] = math.MinInt32
+	}
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = 1
+	case mediumWork:
+		// Use a block size smaller than the optimal to exercise
+		// the blocked code for moderately sized matrices.
+		lwork = max(1, 8*n)
+	case optimumWork:
+		work := make([]float64, 1)
+		impl.Dsytrf(uplo, n, a, lda, ipiv, work, -1)
+		lwork = int(work[0])
+	}
+	work := make([]float64, max(1, lwork))
+
+	ok := impl.Dsytrf(uplo, n, a, lda, ipiv, work, lwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure", name)
+		return
+	}
+	checkBunchKaufman(t, name, uplo, n, a, lda, ipiv, aCopy)
+}
@@ -0,0 +1,70 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dsytrser interface {
+	Dsytrfer
+	Dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+}
+
+func DsytrsTest(t *testing.T, impl Dsytrser) {
+	rnd := rand.New(rand.NewSource(1))
+	bi := blas64.Implementation()
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 50, 100} {
+			for _, nrhs := range []int{0, 1, 2, 5} {
+				for _, lda := range []int{max(1, n), n + 3} {
+					for _, ldb := range []int{max(1, nrhs), nrhs + 4} {
+						for _, kind := range []string{"random", "kkt"} {
+							name := fmt.Sprintf("uplo=%c,n=%v,nrhs=%v,lda=%v,ldb=%v,kind=%v", uplo, n, nrhs, lda, ldb, kind)
+
+							a := randomSymmetricIndefinite(kind, n, lda, rnd)
+
+							// Generate a random solution X and compute
+							// the right-hand side B = A*X.
+							x := randomGeneral(n, nrhs, ldb, rnd)
+							b := randomGeneral(n, nrhs, ldb, rnd)
+							if n > 0 && nrhs > 0 {
+								bi.Dgemm(blas.NoTrans, blas.NoTrans, n, nrhs, n, 1, a, lda, x.Data, ldb, 0, b.Data, ldb)
+							}
+
+							ipiv := make([]int, n)
+							work := make([]float64, 1)
+							impl.Dsytrf(uplo, n, a, lda, ipiv, work, -1)
+							work = make([]float64, int(work[0]))
+							ok := impl.Dsytrf(uplo, n, a, lda, ipiv, work, len(work))
+							if !ok {
+								t.Errorf("%v: bad test, matrix is singular", name)
+								continue
+							}
+
+							impl.Dsytrs(uplo, n, nrhs, a, lda, ipiv, b.Data, ldb)
+
+							for i := 0; i < n && nrhs > 0; i++ {
+								got := b.Data[i*ldb : i*ldb+nrhs]
+								want := x.Data[i*ldb : i*ldb+nrhs]
+								if !floats.EqualApprox(got, want, 1e-8) {
+									t.Errorf("%v: unexpected solution in row %v\ngot  %v\nwant %v", name, i, got, want)
+									break
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dtbconer interface {
+	Dgetrier
+	Dlange(norm lapack.MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+	Dtbcon(norm lapack.MatrixNorm, uplo blas.Uplo, diag blas.Diag, n, kd int, ab []float64, ldab int, work []float64, iwork []int) float64
+}
+
+func DtbconTest(t *testing.T, impl Dtbconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxColumnSum, lapack.MaxRowSum} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, diag := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				for _, n := range []int{0, 1, 2, 3, 5, 10, 50} {
+					for _, kd := range []int{0, 1, 3, 11} {
+						ldab := kd + 1 + rnd.Intn(3)
+						name := fmt.Sprintf("norm=%c,uplo=%c,diag=%c,n=%v,kd=%v,ldab=%v", norm, uplo, diag, n, kd, ldab)
+
+						ab := randomTriBand(uplo, n, kd, ldab, rnd)
+
+						work := make([]float64, 2*n)
+						iwork := make([]int, n)
+						got := impl.Dtbcon(norm, uplo, diag, n, kd, ab, ldab, work, iwork)
+						if n == 0 {
+							if got != 1 {
+								t.Errorf("%v: unexpected rcond for empty matrix; got %v, want 1", name, got)
+							}
+							continue
+						}
+
+						// Compute the exact reciprocal condition
+						// number from the explicit inverse.
+						a := triBandToGeneral(uplo, diag, n, kd, ab, ldab)
+						anorm := impl.Dlange(norm, n, n, a.Data, a.Stride, make([]float64, n))
+						ipiv := make([]int, n)
+						impl.Dgetrf(n, n, a.Data, a.Stride, ipiv)
+						lwork := make([]float64, 1)
+						impl.Dgetri(n, a.Data, a.Stride, ipiv, lwork, -1)
+						lwork = make([]float64, int(lwork[0]))
+						impl.Dgetri(n, a.Data, a.Stride, ipiv, lwork, len(lwork))
+						ainvnm := impl.Dlange(norm, n, n, a.Data, a.Stride, make([]float64, n))
+						want := 1 / anorm / ainvnm
+
+						if got < want*(1-1e-8) || got > 10*want {
+							t.Errorf("%v: unexpected rcond; got %v, want %v", name, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
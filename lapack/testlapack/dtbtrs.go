@@ -0,0 +1,96 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dtbtrser interface {
+	Dtbtrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int) (ok bool)
+}
+
+func DtbtrsTest(t *testing.T, impl Dtbtrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, diag := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				for _, n := range []int{0, 1, 2, 5, 10} {
+					for _, kd := range []int{0, 1, 3, 11} {
+						for _, nrhs := range []int{1, 3} {
+							for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+								testDtbtrs(t, impl, rnd, uplo, trans, diag, n, kd, nrhs, kd+1+rnd.Intn(3), ldb)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtbtrs(t *testing.T, impl Dtbtrser, rnd *rand.Rand, uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, kd, nrhs, ldab, ldb int) {
+	name := fmt.Sprintf("uplo=%c,trans=%c,diag=%c,n=%v,kd=%v,nrhs=%v,ldab=%v,ldb=%v", uplo, trans, diag, n, kd, nrhs, ldab, ldb)
+
+	ab := randomTriBand(uplo, n, kd, ldab, rnd)
+	a := triBandToGeneral(uplo, diag, n, kd, ab, ldab)
+	if diag == blas.Unit {
+		// The diagonal must not be referenced.
+		d := 0
+		if uplo == blas.Lower {
+			d = kd
+		}
+		for i := 0; i < n; i++ {
+			ab[i*ldab+d] = 0
+		}
+	}
+	abCopy := make([]float64, len(ab))
+	copy(abCopy, ab)
+
+	// Generate a random solution X and compute the right-hand side
+	// B = op(A)*X.
+	x := randomGeneral(n, nrhs, ldb, rnd)
+	b := randomGeneral(n, nrhs, ldb, rnd)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(trans, blas.NoTrans, 1, a, x, 0, b)
+	}
+
+	ok := impl.Dtbtrs(uplo, trans, diag, n, kd, nrhs, ab, ldab, b.Data, ldb)
+	if !ok {
+		t.Errorf("%v: unexpected singular matrix", name)
+		return
+	}
+	if !floats.Same(ab, abCopy) {
+		t.Errorf("%v: unexpected modification of ab", name)
+	}
+	for i := 0; i < n && nrhs > 0; i++ {
+		got := b.Data[i*ldb : i*ldb+nrhs]
+		want := x.Data[i*ldb : i*ldb+nrhs]
+		if !floats.EqualApprox(got, want, 1e-12) {
+			t.Errorf("%v: unexpected solution in row %v\ngot  %v\nwant %v", name, i, got, want)
+			return
+		}
+	}
+
+	// Check that a zero on the diagonal is detected.
+	if n > 0 && diag == blas.NonUnit {
+		d := 0
+		if uplo == blas.Lower {
+			d = kd
+		}
+		ab[rnd.Intn(n)*ldab+d] = 0
+		ok = impl.Dtbtrs(uplo, trans, diag, n, kd, nrhs, ab, ldab, b.Data, ldb)
+		if ok {
+			t.Errorf("%v: singular matrix not detected", name)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dtgevcer interface {
+	Dtgevc(side lapack.EVSide, howmny lapack.EVHowMany, selected []bool, n int, s []float64, lds int, p []float64, ldp int, vl []float64, ldvl int, vr []float64, ldvr int, mm int, work []float64) (m int, ok bool)
+
+	Dhgeqzer
+}
+
+func DtgevcTest(t *testing.T, impl Dtgevcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 17, 30} {
+		for _, ld := range []int{max(1, n), n + 5} {
+			for cas := 0; cas < 3; cas++ {
+				for _, howmny := range []lapack.EVHowMany{lapack.EVAll, lapack.EVSelected} {
+					dtgevcTest(t, impl, rnd, howmny, n, ld)
+				}
+			}
+		}
+	}
+}
+
+func dtgevcTest(t *testing.T, impl Dtgevcer, rnd *rand.Rand, howmny lapack.EVHowMany, n, ld int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("howmny=%v,n=%v,ld=%v", string(howmny), n, ld)
+
+	// Generate a matrix pair (S,P) in generalized Schur form by reducing
+	// a random Hessenberg-triangular pair with Dhgeqz.
+	s := randomHessenberg(n, ld, rnd)
+	p := randomGeneral(n, n, ld, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			p.Data[i*p.Stride+j] = 0
+		}
+	}
+	alphar := make([]float64, n)
+	alphai := make([]float64, n)
+	beta := make([]float64, n)
+	work := make([]float64, max(1, 6*n))
+	unconverged := impl.Dhgeqz(lapack.EigenvaluesAndSchur, lapack.OrthoNone, lapack.OrthoNone, n, 0, n-1,
+		s.Data, s.Stride, p.Data, p.Stride, alphar, alphai, beta, nil, 1, nil, 1, work, len(work))
+	if unconverged > 0 {
+		t.Errorf("%v: QZ iteration did not converge", name)
+		return
+	}
+	sCopy := cloneGeneral(s)
+	pCopy := cloneGeneral(p)
+
+	// Select eigenvectors randomly.
+	var selected []bool
+	m := n
+	if howmny == lapack.EVSelected {
+		selected = make([]bool, n)
+		m = 0
+		for j := 0; j < n; j++ {
+			selected[j] = rnd.Float64() < 0.5
+		}
+		for j := 0; j < n; j++ {
+			if alphai[j] == 0 {
+				if selected[j] {
+					m++
+				}
+				continue
+			}
+			if selected[j] || selected[j+1] {
+				m += 2
+			}
+			j++
+		}
+	}
+
+	vl := nanGeneral(n, m, max(1, m)+ld-max(1, n))
+	vr := nanGeneral(n, m, max(1, m)+ld-max(1, n))
+	mGot, ok := impl.Dtgevc(lapack.EVBoth, howmny, selected, n, s.Data, s.Stride, p.Data, p.Stride,
+		vl.Data, vl.Stride, vr.Data, vr.Stride, m, work)
+	if !ok {
+		t.Errorf("%v: unexpected failure", name)
+		return
+	}
+	if mGot != m {
+		t.Errorf("%v: unexpected number of eigenvectors; got %v, want %v", name, mGot, m)
+		return
+	}
+	if !equalApproxGeneral(s, sCopy, 0) {
+		t.Errorf("%v: unexpected modification of S", name)
+	}
+	if !equalApproxGeneral(p, pCopy, 0) {
+		t.Errorf("%v: unexpected modification of P", name)
+	}
+
+	// Check that the computed eigenvectors satisfy
+	//  beta*S*x = alpha*P*x,
+	//  beta*y^H*S = alpha*y^H*P.
+	col := 0
+	for j := 0; j < n; j++ {
+		iscomplex := alphai[j] != 0
+		if howmny == lapack.EVSelected && !selected[j] && !(iscomplex && selected[j+1]) {
+			if iscomplex {
+				j++
+			}
+			continue
+		}
+		alpha := complex(alphar[j], alphai[j])
+		for _, v := range []struct {
+			name string
+			mat  blas64.General
+			left bool
+		}{
+			{name: "left", mat: vl, left: true},
+			{name: "right", mat: vr, left: false},
+		} {
+			xRe := columnOf(v.mat, col)
+			var xIm []float64
+			if iscomplex {
+				xIm = columnOf(v.mat, col+1)
+			}
+			resid := genEigenvectorResidual(sCopy, pCopy, xRe, xIm, alpha, beta[j], v.left)
+			if resid > tol*float64(n) {
+				t.Errorf("%v: %v eigenvector %v not computed accurately, residual=%v", name, v.name, j, resid)
+			}
+		}
+		if iscomplex {
+			col += 2
+			j++
+		} else {
+			col++
+		}
+	}
+}
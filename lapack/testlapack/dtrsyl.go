@@ -0,0 +1,101 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dtrsyler interface {
+	Dtrsyl(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool)
+	Dtrsyl3(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool)
+	Dlange(norm lapack.MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+}
+
+func DtrsylTest(t *testing.T, impl Dtrsyler) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, blocked := range []bool{false, true} {
+		for _, trana := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tranb := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, isgn := range []int{1, -1} {
+					for _, m := range []int{0, 1, 2, 3, 4, 5, 10, 41} {
+						for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 73} {
+							for _, extra := range []int{0, 3} {
+								for cas := 0; cas < 3; cas++ {
+									testDtrsyl(t, impl, blocked, trana, tranb, isgn, m, n, extra, rnd)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtrsyl(t *testing.T, impl Dtrsyler, blocked bool, trana, tranb blas.Transpose, isgn, m, n, extra int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	a := randomSchurCanonical(m, m+extra, rnd)
+	b := randomSchurCanonical(n, n+extra, rnd)
+	c := randomGeneral(m, n, n+extra, rnd)
+	cCopy := cloneGeneral(c)
+
+	name := "Dtrsyl"
+	var scale float64
+	var ok bool
+	if blocked {
+		name = "Dtrsyl3"
+		scale, ok = impl.Dtrsyl3(trana, tranb, isgn, m, n, a.Data, a.Stride, b.Data, b.Stride, c.Data, c.Stride)
+	} else {
+		scale, ok = impl.Dtrsyl(trana, tranb, isgn, m, n, a.Data, a.Stride, b.Data, b.Stride, c.Data, c.Stride)
+	}
+
+	prefix := fmt.Sprintf("Case %v trana=%v, tranb=%v, isgn=%v, m=%v, n=%v, extra=%v",
+		name, trana, tranb, isgn, m, n, extra)
+
+	if !generalOutsideAllNaN(c) {
+		t.Errorf("%v: out-of-range write to C", prefix)
+	}
+	if scale <= 0 || 1 < scale {
+		t.Errorf("%v: invalid value of scale, want in (0,1], got %v", prefix, scale)
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+	if !ok {
+		t.Logf("%v: Dtrsyl returned ok=false", prefix)
+	}
+
+	// Compute the residual
+	//  R = op(A)*X + isgn*X*op(B) - scale*C.
+	x := c
+	r := cloneGeneral(cCopy)
+	bi := blas64.Implementation()
+	bi.Dgemm(trana, blas.NoTrans, m, n, m, 1, a.Data, a.Stride, x.Data, x.Stride, -scale, r.Data, r.Stride)
+	bi.Dgemm(blas.NoTrans, tranb, m, n, n, float64(isgn), x.Data, x.Stride, b.Data, b.Stride, 1, r.Data, r.Stride)
+
+	anorm := impl.Dlange(lapack.MaxAbs, m, m, a.Data, a.Stride, nil)
+	bnorm := impl.Dlange(lapack.MaxAbs, n, n, b.Data, b.Stride, nil)
+	xnorm := impl.Dlange(lapack.MaxAbs, m, n, x.Data, x.Stride, nil)
+	cnorm := impl.Dlange(lapack.MaxAbs, m, n, cCopy.Data, cCopy.Stride, nil)
+	rnorm := impl.Dlange(lapack.MaxAbs, m, n, r.Data, r.Stride, nil)
+	den := float64(max(m, n)) * ((anorm+bnorm)*xnorm + scale*cnorm)
+	if den == 0 {
+		den = 1
+	}
+	resid := rnorm / den
+	if math.IsNaN(resid) || resid > tol {
+		t.Errorf("%v: residual |op(A)*X + isgn*X*op(B) - scale*C| too large, got %v, want <= %v", prefix, resid, tol)
+	}
+}
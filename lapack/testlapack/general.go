@@ -13,7 +13,9 @@ import (
 	"golang.org/x/exp/rand"
 
 	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
 	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/blas/cblas128"
 	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/gonum/lapack"
 )
@@ -1127,6 +1129,124 @@ func symBandToSym(ul blas.Uplo, band []float64, n, kb, ldab int) blas64.Symmetri
 	}
 }
 
+// randomBand returns a random m×n band matrix with kl sub-diagonals and ku
+// super-diagonals in band storage with the given leading dimension, as
+// described in the documentation of Dgbtrf. Elements of the storage that do
+// not correspond to elements of the band are filled with NaN values.
+func randomBand(m, n, kl, ku, ldab int, rnd *rand.Rand) []float64 {
+	rows := min(m, n+kl)
+	if rows <= 0 {
+		return nil
+	}
+	ab := nanSlice((rows-1)*ldab + kl + ku + 1)
+	for i := 0; i < rows; i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			ab[i*ldab+kl+j-i] = rnd.NormFloat64()
+		}
+	}
+	return ab
+}
+
+// bandToGeneral returns the m×n band matrix with kl sub-diagonals and ku
+// super-diagonals stored in ab as a general matrix.
+func bandToGeneral(m, n, kl, ku int, ab []float64, ldab int) blas64.General {
+	a := zeros(m, n, max(1, n))
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			a.Data[i*a.Stride+j] = ab[i*ldab+kl+j-i]
+		}
+	}
+	return a
+}
+
+// randomTriBand returns a random n×n triangular band matrix with kd super-
+// or sub-diagonals in band storage with the given leading dimension. The
+// diagonal elements are bounded away from zero so that the matrix is
+// reasonably well conditioned. Elements of the storage that do not correspond
+// to elements of the band are filled with NaN values.
+func randomTriBand(uplo blas.Uplo, n, kd, ldab int, rnd *rand.Rand) []float64 {
+	if n == 0 {
+		return nil
+	}
+	ab := nanSlice((n-1)*ldab + kd + 1)
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j <= min(n-1, i+kd); j++ {
+				ab[i*ldab+j-i] = rnd.NormFloat64()
+			}
+			ab[i*ldab] = math.Copysign(2+rnd.Float64(), ab[i*ldab])
+		} else {
+			for j := max(0, i-kd); j <= i; j++ {
+				ab[i*ldab+kd+j-i] = rnd.NormFloat64()
+			}
+			ab[i*ldab+kd] = math.Copysign(2+rnd.Float64(), ab[i*ldab+kd])
+		}
+	}
+	return ab
+}
+
+// randomSPDBand returns a random n×n symmetric positive definite band matrix
+// with kd super- or sub-diagonals in band storage with the given leading
+// dimension, together with the full matrix as a general matrix. The matrix
+// is strictly diagonally dominant with positive diagonal, so it is positive
+// definite and well conditioned. Elements of the storage that do not
+// correspond to elements of the band are filled with NaN values.
+func randomSPDBand(uplo blas.Uplo, n, kd, ldab int, rnd *rand.Rand) ([]float64, blas64.General) {
+	a := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		for j := i + 1; j <= min(n-1, i+kd); j++ {
+			aij := rnd.NormFloat64()
+			a.Data[i*a.Stride+j] = aij
+			a.Data[j*a.Stride+i] = aij
+		}
+	}
+	for i := 0; i < n; i++ {
+		var sum float64
+		for j := max(0, i-kd); j <= min(n-1, i+kd); j++ {
+			sum += math.Abs(a.Data[i*a.Stride+j])
+		}
+		a.Data[i*a.Stride+i] = sum + 1 + rnd.Float64()
+	}
+	if n == 0 {
+		return nil, a
+	}
+	ab := nanSlice((n-1)*ldab + kd + 1)
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j <= min(n-1, i+kd); j++ {
+				ab[i*ldab+j-i] = a.Data[i*a.Stride+j]
+			}
+		} else {
+			for j := max(0, i-kd); j <= i; j++ {
+				ab[i*ldab+kd+j-i] = a.Data[i*a.Stride+j]
+			}
+		}
+	}
+	return ab, a
+}
+
+// triBandToGeneral returns the n×n triangular band matrix with kd super- or
+// sub-diagonals stored in ab as a general matrix. If diag == blas.Unit, the
+// diagonal elements are set to one.
+func triBandToGeneral(uplo blas.Uplo, diag blas.Diag, n, kd int, ab []float64, ldab int) blas64.General {
+	a := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j <= min(n-1, i+kd); j++ {
+				a.Data[i*a.Stride+j] = ab[i*ldab+j-i]
+			}
+		} else {
+			for j := max(0, i-kd); j <= i; j++ {
+				a.Data[i*a.Stride+j] = ab[i*ldab+kd+j-i]
+			}
+		}
+		if diag == blas.Unit {
+			a.Data[i*a.Stride+i] = 1
+		}
+	}
+	return a
+}
+
 // eye returns an identity matrix of given order and stride.
 func eye(n, stride int) blas64.General {
 	ans := nanGeneral(n, n, stride)
@@ -1426,6 +1546,62 @@ func isLeftEigenvectorOf(a blas64.General, yRe, yIm []float64, lambda complex128
 	return true
 }
 
+// genEigenvectorResidual returns the scaled residual of the vector xRe+i*xIm,
+// where i is the imaginary unit, as a right (left == false) or left
+// (left == true) generalized eigenvector of the pair (A,B) corresponding to the
+// eigenvalue alpha/beta. For a right eigenvector x the residual is
+//  |beta*A*x - alpha*B*x|_∞ / ((|beta|*|A|_∞ + |alpha|*|B|_∞)*|x|_∞)
+// and for a left eigenvector y it is
+//  |beta*y^H*A - alpha*y^H*B|_∞ / ((|beta|*|A|_1 + |alpha|*|B|_1)*|y|_∞).
+// xIm may be nil if the vector is real.
+func genEigenvectorResidual(a, b blas64.General, xRe, xIm []float64, alpha complex128, beta float64, left bool) float64 {
+	n := a.Rows
+	if xIm == nil {
+		xIm = make([]float64, n)
+	}
+	trans := blas.NoTrans
+	if left {
+		// y^H*A = λ*y^H*B is equivalent to A^T*conj(y) = λ*B^T*conj(y).
+		trans = blas.Trans
+		xIm = append([]float64(nil), xIm...)
+		floats.Scale(-1, xIm)
+	}
+	axRe := make([]float64, n)
+	axIm := make([]float64, n)
+	bxRe := make([]float64, n)
+	bxIm := make([]float64, n)
+	blas64.Gemv(trans, 1, a, blas64.Vector{Data: xRe, Inc: 1}, 0, blas64.Vector{Data: axRe, Inc: 1})
+	blas64.Gemv(trans, 1, a, blas64.Vector{Data: xIm, Inc: 1}, 0, blas64.Vector{Data: axIm, Inc: 1})
+	blas64.Gemv(trans, 1, b, blas64.Vector{Data: xRe, Inc: 1}, 0, blas64.Vector{Data: bxRe, Inc: 1})
+	blas64.Gemv(trans, 1, b, blas64.Vector{Data: xIm, Inc: 1}, 0, blas64.Vector{Data: bxIm, Inc: 1})
+	var resid, xnorm float64
+	for i := 0; i < n; i++ {
+		r := complex(beta, 0)*complex(axRe[i], axIm[i]) - alpha*complex(bxRe[i], bxIm[i])
+		resid = math.Max(resid, cmplx.Abs(r))
+		xnorm = math.Max(xnorm, cmplx.Abs(complex(xRe[i], xIm[i])))
+	}
+	var anorm, bnorm float64
+	for i := 0; i < n; i++ {
+		var asum, bsum float64
+		for j := 0; j < n; j++ {
+			if left {
+				asum += math.Abs(a.Data[j*a.Stride+i])
+				bsum += math.Abs(b.Data[j*b.Stride+i])
+			} else {
+				asum += math.Abs(a.Data[i*a.Stride+j])
+				bsum += math.Abs(b.Data[i*b.Stride+j])
+			}
+		}
+		anorm = math.Max(anorm, asum)
+		bnorm = math.Max(bnorm, bsum)
+	}
+	denom := (math.Abs(beta)*anorm + cmplx.Abs(alpha)*bnorm) * xnorm
+	if denom == 0 {
+		return resid
+	}
+	return resid / denom
+}
+
 // rootsOfUnity returns the n complex numbers whose n-th power is equal to 1.
 func rootsOfUnity(n int) []complex128 {
 	w := make([]complex128, n)
@@ -1576,3 +1752,170 @@ func svdJobString(job lapack.SVDJob) string {
 	}
 	return "unknown SVD job"
 }
+
+// randomComplexGeneral allocates a new r×c complex general matrix with given
+// stride and fills it with random values that have real and imaginary parts
+// uniformly distributed in [-1, 1).
+func randomComplexGeneral(r, c, stride int, rnd *rand.Rand) cblas128.General {
+	ans := cblas128.General{
+		Rows:   r,
+		Cols:   c,
+		Stride: stride,
+		Data:   make([]complex128, max(1, (r-1)*stride+c)),
+	}
+	for i := range ans.Data {
+		ans.Data[i] = cmplx.NaN()
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			ans.Data[i*stride+j] = complex(2*rnd.Float64()-1, 2*rnd.Float64()-1)
+		}
+	}
+	return ans
+}
+
+// randomHPD allocates a new n×n random Hermitian positive definite matrix with
+// the given stride.
+func randomHPD(n, stride int, rnd *rand.Rand) cblas128.General {
+	x := randomComplexGeneral(n, n, max(1, n), rnd)
+	a := randomComplexGeneral(n, n, stride, rnd)
+	cblas128.Gemm(blas.NoTrans, blas.ConjTrans, 1, x, x, 0, a)
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+i] = complex(real(a.Data[i*a.Stride+i])+float64(n), 0)
+	}
+	return a
+}
+
+// cloneComplexGeneral allocates and returns an exact copy of the given
+// complex general matrix.
+func cloneComplexGeneral(a cblas128.General) cblas128.General {
+	c := a
+	c.Data = make([]complex128, len(a.Data))
+	copy(c.Data, a.Data)
+	return c
+}
+
+// zerosComplex returns an m×n complex matrix with given stride filled with
+// zeros.
+func zerosComplex(m, n, stride int) cblas128.General {
+	return cblas128.General{
+		Rows:   m,
+		Cols:   n,
+		Stride: stride,
+		Data:   make([]complex128, max(1, (m-1)*stride+n)),
+	}
+}
+
+// equalApproxComplexGeneral returns whether the complex general matrices a and
+// b are approximately equal within given tolerance.
+func equalApproxComplexGeneral(a, b cblas128.General, tol float64) bool {
+	if a.Rows != b.Rows || a.Cols != b.Cols {
+		panic("bad input")
+	}
+	for i := 0; i < a.Rows; i++ {
+		for j := 0; j < a.Cols; j++ {
+			diff := cmplx.Abs(a.Data[i*a.Stride+j] - b.Data[i*b.Stride+j])
+			if math.IsNaN(diff) || diff > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasOrthonormalColumnsComplex returns whether the columns of the complex
+// matrix Q are orthonormal.
+func hasOrthonormalColumnsComplex(q cblas128.General) bool {
+	m, n := q.Rows, q.Cols
+	if n > m {
+		return false
+	}
+	ldq := q.Stride
+	const tol = 1e-13
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			dot := cblas128.Dotc(m, cblas128.Vector{Data: q.Data[i:], Inc: ldq},
+				cblas128.Vector{Data: q.Data[j:], Inc: ldq})
+			if i == j {
+				dot -= 1
+			}
+			if cmplx.IsNaN(dot) || cmplx.Abs(dot) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasOrthonormalRowsComplex returns whether the rows of the complex matrix Q
+// are orthonormal.
+func hasOrthonormalRowsComplex(q cblas128.General) bool {
+	m, n := q.Rows, q.Cols
+	if m > n {
+		return false
+	}
+	ldq := q.Stride
+	const tol = 1e-13
+	for i := 0; i < m; i++ {
+		for j := i; j < m; j++ {
+			dot := cblas128.Dotc(n, cblas128.Vector{Data: q.Data[i*ldq:], Inc: 1},
+				cblas128.Vector{Data: q.Data[j*ldq:], Inc: 1})
+			if i == j {
+				dot -= 1
+			}
+			if cmplx.IsNaN(dot) || cmplx.Abs(dot) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// randomGeneral32 allocates a new r×c float32 general matrix with given stride
+// and fills it with random values uniformly distributed in [-1, 1). Elements
+// outside the matrix are set to NaN.
+func randomGeneral32(r, c, stride int, rnd *rand.Rand) blas32.General {
+	ans := blas32.General{
+		Rows:   r,
+		Cols:   c,
+		Stride: stride,
+		Data:   make([]float32, max(1, (r-1)*stride+c)),
+	}
+	for i := range ans.Data {
+		ans.Data[i] = float32(math.NaN())
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			ans.Data[i*stride+j] = float32(2*rnd.Float64() - 1)
+		}
+	}
+	return ans
+}
+
+// randomSPD32 allocates a new n×n random float32 symmetric positive definite
+// matrix with the given stride.
+func randomSPD32(n, stride int, rnd *rand.Rand) blas32.General {
+	x := randomGeneral32(n, n, max(1, n), rnd)
+	a := randomGeneral32(n, n, stride, rnd)
+	blas32.Gemm(blas.NoTrans, blas.Trans, 1, x, x, 0, a)
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+i] += float32(n)
+	}
+	return a
+}
+
+// general32To64 returns a float64 copy of the float32 general matrix a.
+func general32To64(a blas32.General) blas64.General {
+	b := blas64.General{
+		Rows:   a.Rows,
+		Cols:   a.Cols,
+		Stride: max(1, a.Cols),
+		Data:   make([]float64, max(1, a.Rows*a.Cols)),
+	}
+	for i := 0; i < a.Rows; i++ {
+		for j := 0; j < a.Cols; j++ {
+			b.Data[i*b.Stride+j] = float64(a.Data[i*a.Stride+j])
+		}
+	}
+	return b
+}
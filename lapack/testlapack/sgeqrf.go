@@ -0,0 +1,88 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Sgeqrfer interface {
+	Sgeqrf(m, n int, a []float32, lda int, tau, work []float32, lwork int)
+	Sorgqr(m, n, k int, a []float32, lda int, tau, work []float32, lwork int)
+}
+
+func SgeqrfTest(t *testing.T, impl Sgeqrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 3, 5, 10, 31} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 31} {
+			for _, lda := range []int{max(1, n), n + 4} {
+				sgeqrfTest(t, impl, rnd, m, n, lda)
+			}
+		}
+	}
+}
+
+func sgeqrfTest(t *testing.T, impl Sgeqrfer, rnd *rand.Rand, m, n, lda int) {
+	const tol = 1e-6
+
+	name := fmt.Sprintf("m=%v,n=%v,lda=%v", m, n, lda)
+
+	a := randomGeneral32(m, n, lda, rnd)
+	aCopy := general32To64(a)
+	k := min(m, n)
+	tau := make([]float32, k)
+
+	work := []float32{0}
+	impl.Sgeqrf(m, n, a.Data, a.Stride, tau, work, -1)
+	work = make([]float32, int(work[0]))
+	impl.Sgeqrf(m, n, a.Data, a.Stride, tau, work, len(work))
+	if k == 0 {
+		return
+	}
+
+	// Generate the full m×m matrix Q.
+	q := blas32.General{Rows: m, Cols: m, Stride: m, Data: make([]float32, m*m)}
+	for i := 0; i < m; i++ {
+		for j := 0; j < k; j++ {
+			q.Data[i*q.Stride+j] = a.Data[i*a.Stride+j]
+		}
+	}
+	work = []float32{0}
+	impl.Sorgqr(m, m, k, q.Data, q.Stride, tau, work, -1)
+	work = make([]float32, int(work[0]))
+	impl.Sorgqr(m, m, k, q.Data, q.Stride, tau, work, len(work))
+	q64 := general32To64(q)
+	if resid := distFromIdentity(m, qtq(q64), m); resid > tol*float64(m) {
+		t.Errorf("%v: Q is not orthogonal, |Q^T*Q-I|=%v", name, resid)
+	}
+
+	// Check that Q*R = A.
+	r := zeros(m, n, n)
+	for i := 0; i < k; i++ {
+		for j := i; j < n; j++ {
+			r.Data[i*r.Stride+j] = float64(a.Data[i*a.Stride+j])
+		}
+	}
+	got := zeros(m, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q64, r, 0, got)
+	if !equalApproxGeneral(got, aCopy, tol*float64(max(m, n))) {
+		t.Errorf("%v: Q*R != A", name)
+	}
+}
+
+// qtq returns the data of the n×n matrix Q^T*Q for the m×n matrix Q.
+func qtq(q blas64.General) []float64 {
+	n := q.Cols
+	c := zeros(n, n, n)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, q, q, 0, c)
+	return c.Data
+}
@@ -0,0 +1,64 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+type Sgetrser interface {
+	Sgetrs(trans blas.Transpose, n, nrhs int, a []float32, lda int, ipiv []int, b []float32, ldb int)
+
+	Sgetrfer
+}
+
+func SgetrsTest(t *testing.T, impl Sgetrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{0, 1, 2, 5, 10, 70} {
+			for _, nrhs := range []int{0, 1, 3} {
+				for _, ld := range []int{max(1, max(n, nrhs)), max(n, nrhs) + 3} {
+					sgetrsTest(t, impl, rnd, trans, n, nrhs, ld)
+				}
+			}
+		}
+	}
+}
+
+func sgetrsTest(t *testing.T, impl Sgetrser, rnd *rand.Rand, trans blas.Transpose, n, nrhs, ld int) {
+	name := fmt.Sprintf("trans=%v,n=%v,nrhs=%v,ld=%v", trans, n, nrhs, ld)
+
+	// Make A diagonally dominant so that it is well conditioned and the
+	// accuracy of the single precision solution can be tested reliably.
+	a := randomGeneral32(n, n, ld, rnd)
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+i] += float32(n)
+	}
+	aCopy := a
+	aCopy.Data = make([]float32, len(a.Data))
+	copy(aCopy.Data, a.Data)
+	x := randomGeneral32(n, nrhs, ld, rnd)
+	b := randomGeneral32(n, nrhs, ld, rnd)
+	if n > 0 && nrhs > 0 {
+		blas32.Gemm(trans, blas.NoTrans, 1, aCopy, x, 0, b)
+	}
+
+	ipiv := make([]int, n)
+	ok := impl.Sgetrf(n, n, a.Data, a.Stride, ipiv)
+	if !ok {
+		t.Errorf("%v: unexpected singular matrix", name)
+		return
+	}
+	impl.Sgetrs(trans, n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+	if !equalApproxGeneral(general32To64(b), general32To64(x), 1e-5) {
+		t.Errorf("%v: unexpected solution", name)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Sormqrer interface {
+	Sormqr(side blas.Side, trans blas.Transpose, m, n, k int, a []float32, lda int, tau, c []float32, ldc int, work []float32, lwork int)
+
+	Sgeqrfer
+}
+
+func SormqrTest(t *testing.T, impl Sormqrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []blas.Side{blas.Left, blas.Right} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, mn := range [][2]int{{1, 1}, {3, 2}, {2, 3}, {5, 5}, {10, 4}, {4, 10}, {25, 17}} {
+				m, n := mn[0], mn[1]
+				nq := m
+				if side == blas.Right {
+					nq = n
+				}
+				for _, k := range []int{0, 1, nq / 2, nq} {
+					sormqrTest(t, impl, rnd, side, trans, m, n, k)
+				}
+			}
+		}
+	}
+}
+
+func sormqrTest(t *testing.T, impl Sormqrer, rnd *rand.Rand, side blas.Side, trans blas.Transpose, m, n, k int) {
+	const tol = 1e-6
+
+	name := fmt.Sprintf("side=%v,trans=%v,m=%v,n=%v,k=%v", side, trans, m, n, k)
+
+	nq := m
+	if side == blas.Right {
+		nq = n
+	}
+	// Compute the QR factorization of a random nq×k matrix.
+	a := randomGeneral32(nq, k, max(1, k), rnd)
+	tau := make([]float32, k)
+	work := make([]float32, max(1, k))
+	impl.Sgeqrf(nq, k, a.Data, a.Stride, tau, work, len(work))
+
+	// Form Q explicitly.
+	q := blas32.General{Rows: nq, Cols: nq, Stride: nq, Data: make([]float32, nq*nq)}
+	for i := 0; i < nq; i++ {
+		for j := 0; j < k; j++ {
+			q.Data[i*q.Stride+j] = a.Data[i*a.Stride+j]
+		}
+	}
+	work = make([]float32, nq)
+	impl.Sorgqr(nq, nq, k, q.Data, q.Stride, tau, work, len(work))
+
+	c := randomGeneral32(m, n, n, rnd)
+	want := zeros(m, n, n)
+	if side == blas.Left {
+		blas64.Gemm(trans, blas.NoTrans, 1, general32To64(q), general32To64(c), 0, want)
+	} else {
+		blas64.Gemm(blas.NoTrans, trans, 1, general32To64(c), general32To64(q), 0, want)
+	}
+
+	work = []float32{0}
+	impl.Sormqr(side, trans, m, n, k, a.Data, a.Stride, tau, c.Data, c.Stride, work, -1)
+	work = make([]float32, int(work[0]))
+	impl.Sormqr(side, trans, m, n, k, a.Data, a.Stride, tau, c.Data, c.Stride, work, len(work))
+	if !equalApproxGeneral(general32To64(c), want, tol*float64(nq)) {
+		t.Errorf("%v: unexpected result", name)
+	}
+}
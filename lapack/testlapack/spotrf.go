@@ -0,0 +1,78 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Spotrfer interface {
+	Spotrf(ul blas.Uplo, n int, a []float32, lda int) (ok bool)
+}
+
+func SpotrfTest(t *testing.T, impl Spotrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 70, 150} {
+			for _, lda := range []int{max(1, n), n + 4} {
+				spotrfTest(t, impl, rnd, uplo, n, lda)
+			}
+		}
+	}
+}
+
+func spotrfTest(t *testing.T, impl Spotrfer, rnd *rand.Rand, uplo blas.Uplo, n, lda int) {
+	const tol = 1e-6
+
+	name := fmt.Sprintf("uplo=%v,n=%v,lda=%v", string(uplo), n, lda)
+
+	a := randomSPD32(n, lda, rnd)
+	aCopy := general32To64(a)
+
+	ok := impl.Spotrf(uplo, n, a.Data, a.Stride)
+	if !ok {
+		t.Errorf("%v: unexpected failure for positive definite matrix", name)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Extract the triangular factor in float64.
+	fact := general32To64(a)
+	tri := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j >= i) || (uplo == blas.Lower && j <= i) {
+				tri.Data[i*tri.Stride+j] = fact.Data[i*fact.Stride+j]
+			}
+		}
+	}
+	got := zeros(n, n, n)
+	if uplo == blas.Upper {
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, tri, tri, 0, got)
+	} else {
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tri, tri, 0, got)
+	}
+	if !equalApproxGeneral(got, aCopy, tol*float64(n*n)) {
+		t.Errorf("%v: unexpected reconstruction of A", name)
+	}
+
+	// Check that a matrix that is not positive definite is detected.
+	if n > 1 {
+		b := randomSPD32(n, lda, rnd)
+		k := rnd.Intn(n)
+		b.Data[k*b.Stride+k] = -1
+		if impl.Spotrf(uplo, n, b.Data, b.Stride) {
+			t.Errorf("%v: indefinite matrix not detected", name)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+type Spotrser interface {
+	Spotrs(uplo blas.Uplo, n, nrhs int, a []float32, lda int, b []float32, ldb int)
+
+	Spotrfer
+}
+
+func SpotrsTest(t *testing.T, impl Spotrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 5, 10, 70} {
+			for _, nrhs := range []int{0, 1, 4} {
+				for _, ld := range []int{max(1, max(n, nrhs)), max(n, nrhs) + 3} {
+					spotrsTest(t, impl, rnd, uplo, n, nrhs, ld)
+				}
+			}
+		}
+	}
+}
+
+func spotrsTest(t *testing.T, impl Spotrser, rnd *rand.Rand, uplo blas.Uplo, n, nrhs, ld int) {
+	const tol = 1e-5
+
+	name := fmt.Sprintf("uplo=%v,n=%v,nrhs=%v,ld=%v", string(uplo), n, nrhs, ld)
+
+	a := randomSPD32(n, ld, rnd)
+	x := randomGeneral32(n, nrhs, ld, rnd)
+	b := randomGeneral32(n, nrhs, ld, rnd)
+	if n > 0 && nrhs > 0 {
+		blas32.Gemm(blas.NoTrans, blas.NoTrans, 1, a, x, 0, b)
+	}
+
+	ok := impl.Spotrf(uplo, n, a.Data, a.Stride)
+	if !ok {
+		t.Errorf("%v: unexpected failure for positive definite matrix", name)
+		return
+	}
+	impl.Spotrs(uplo, n, nrhs, a.Data, a.Stride, b.Data, b.Stride)
+	if !equalApproxGeneral(general32To64(b), general32To64(x), tol) {
+		t.Errorf("%v: unexpected solution", name)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/cmplx"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Zgeconer interface {
+	Zgecon(norm lapack.MatrixNorm, n int, a []complex128, lda int, anorm float64, work []complex128, rwork []float64) float64
+
+	Zgetrser
+}
+
+func ZgeconTest(t *testing.T, impl Zgeconer) {
+	// The reciprocal condition numbers of a magic square. Multiplying
+	// A by a complex scalar of unit modulus does not change them.
+	a := []complex128{
+		8, 1, 6,
+		3, 5, 7,
+		4, 9, 2,
+	}
+	for i := range a {
+		a[i] *= complex(0.6, 0.8)
+	}
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxColumnSum, lapack.MaxRowSum} {
+		ipiv := make([]int, 3)
+		lu := make([]complex128, len(a))
+		copy(lu, a)
+		anorm := zgeconNorm(norm, 3, a, 3)
+		impl.Zgetrf(3, 3, lu, 3, ipiv)
+		got := impl.Zgecon(norm, 3, lu, 3, anorm, make([]complex128, 6), make([]float64, 6))
+		if !floats.EqualWithinAbsOrRel(got, 3.0/16, 1e-14, 1e-14) {
+			t.Errorf("norm=%c: unexpected rcond for magic square: got %v, want %v", norm, got, 3.0/16)
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 20, 50} {
+		for _, lda := range []int{max(1, n), n + 3} {
+			for _, norm := range []lapack.MatrixNorm{lapack.MaxColumnSum, lapack.MaxRowSum} {
+				zgeconTest(t, impl, rnd, norm, n, lda)
+			}
+		}
+	}
+}
+
+func zgeconTest(t *testing.T, impl Zgeconer, rnd *rand.Rand, norm lapack.MatrixNorm, n, lda int) {
+	name := fmt.Sprintf("norm=%c,n=%v,lda=%v", norm, n, lda)
+
+	a := randomComplexGeneral(n, n, lda, rnd)
+	// Make A increasingly ill-conditioned by grading its rows.
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a.Data[i*lda+j] *= complex(1/float64(1+i*i), 0)
+		}
+	}
+	anorm := zgeconNorm(norm, n, a.Data, lda)
+
+	// Compute the explicit inverse of A and its norm.
+	lu := cloneComplexGeneral(a)
+	ipiv := make([]int, n)
+	ok := impl.Zgetrf(n, n, lu.Data, lda, ipiv)
+	if !ok {
+		t.Fatalf("%v: unexpected singular matrix", name)
+	}
+	ainv := zerosComplex(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		ainv.Data[i*ainv.Stride+i] = 1
+	}
+	impl.Zgetrs(blas.NoTrans, n, n, lu.Data, lda, ipiv, ainv.Data, ainv.Stride)
+	want := 1.0
+	if n > 0 {
+		want = 1 / (anorm * zgeconNorm(norm, n, ainv.Data, ainv.Stride))
+	}
+
+	luCopy := cloneComplexGeneral(lu)
+	work := make([]complex128, 2*n)
+	rwork := make([]float64, 2*n)
+	got := impl.Zgecon(norm, n, lu.Data, lda, anorm, work, rwork)
+	if !equalApproxComplexGeneral(lu, luCopy, 0) {
+		t.Errorf("%v: unexpected modification of a", name)
+	}
+
+	// The estimate of the norm of the inverse is a lower bound, so the
+	// reciprocal condition number is overestimated, but usually by much
+	// less than a factor of 10.
+	if got < want*(1-1e-10) || want*10 < got {
+		t.Errorf("%v: unexpected rcond: got %v, want %v", name, got, want)
+	}
+}
+
+// zgeconNorm returns the 1-norm or ∞-norm of the n×n complex matrix A.
+func zgeconNorm(norm lapack.MatrixNorm, n int, a []complex128, lda int) float64 {
+	var value float64
+	for i := 0; i < n; i++ {
+		var sum float64
+		for j := 0; j < n; j++ {
+			if norm == lapack.MaxColumnSum {
+				sum += cmplx.Abs(a[j*lda+i])
+			} else {
+				sum += cmplx.Abs(a[i*lda+j])
+			}
+		}
+		if sum > value {
+			value = sum
+		}
+	}
+	return value
+}
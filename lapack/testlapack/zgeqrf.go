@@ -0,0 +1,97 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+type Zgeqrfer interface {
+	Zgeqrf(m, n int, a []complex128, lda int, tau, work []complex128, lwork int)
+	Zungqr(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int)
+}
+
+func ZgeqrfTest(t *testing.T, impl Zgeqrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 3, 5, 10, 31} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 31} {
+			for _, lda := range []int{max(1, n), n + 4} {
+				zgeqrfTest(t, impl, rnd, m, n, lda)
+			}
+		}
+	}
+}
+
+func zgeqrfTest(t *testing.T, impl Zgeqrfer, rnd *rand.Rand, m, n, lda int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("m=%v,n=%v,lda=%v", m, n, lda)
+
+	a := randomComplexGeneral(m, n, lda, rnd)
+	aCopy := cloneComplexGeneral(a)
+	k := min(m, n)
+	tau := make([]complex128, k)
+
+	work := []complex128{0}
+	impl.Zgeqrf(m, n, a.Data, a.Stride, tau, work, -1)
+	work = make([]complex128, int(real(work[0])))
+	impl.Zgeqrf(m, n, a.Data, a.Stride, tau, work, len(work))
+	if k == 0 {
+		return
+	}
+
+	// Generate the full m×m matrix Q.
+	q := zerosComplex(m, m, m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < k; j++ {
+			q.Data[i*q.Stride+j] = a.Data[i*a.Stride+j]
+		}
+	}
+	work = []complex128{0}
+	impl.Zungqr(m, m, k, q.Data, q.Stride, tau, work, -1)
+	work = make([]complex128, int(real(work[0])))
+	impl.Zungqr(m, m, k, q.Data, q.Stride, tau, work, len(work))
+	if !hasOrthonormalColumnsComplex(q) {
+		t.Errorf("%v: Q is not unitary", name)
+	}
+
+	// Check that Q*R = A.
+	r := zerosComplex(m, n, n)
+	for i := 0; i < k; i++ {
+		for j := i; j < n; j++ {
+			r.Data[i*r.Stride+j] = a.Data[i*a.Stride+j]
+		}
+	}
+	got := zerosComplex(m, n, n)
+	cblas128.Gemm(blas.NoTrans, blas.NoTrans, 1, q, r, 0, got)
+	if !equalApproxComplexGeneral(got, aCopy, tol*float64(max(m, n))) {
+		t.Errorf("%v: Q*R != A", name)
+	}
+
+	// Check that generating only the first k columns gives the same
+	// result.
+	qk := zerosComplex(m, k, k)
+	for i := 0; i < m; i++ {
+		for j := 0; j < k; j++ {
+			qk.Data[i*qk.Stride+j] = a.Data[i*a.Stride+j]
+		}
+	}
+	work = make([]complex128, k)
+	impl.Zungqr(m, k, k, qk.Data, qk.Stride, tau, work, len(work))
+	for i := 0; i < m; i++ {
+		for j := 0; j < k; j++ {
+			if qk.Data[i*qk.Stride+j] != q.Data[i*q.Stride+j] {
+				t.Errorf("%v: thin Q does not match full Q", name)
+				return
+			}
+		}
+	}
+}
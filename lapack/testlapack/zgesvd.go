@@ -0,0 +1,112 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Zgesvder interface {
+	Zgesvd(jobU, jobVT lapack.SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64) (ok bool)
+}
+
+func ZgesvdTest(t *testing.T, impl Zgesvder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 3, 5, 10, 33} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 33} {
+			for _, job := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDStore} {
+				for _, ld := range []int{0, 5} {
+					zgesvdTest(t, impl, rnd, job, m, n, ld)
+				}
+			}
+		}
+	}
+}
+
+func zgesvdTest(t *testing.T, impl Zgesvder, rnd *rand.Rand, job lapack.SVDJob, m, n, extra int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("job=%v,m=%v,n=%v,extra=%v", svdJobString(job), m, n, extra)
+
+	minmn := min(m, n)
+	ucols := minmn
+	vtrows := minmn
+	if job == lapack.SVDAll {
+		ucols = m
+		vtrows = n
+	}
+
+	a := randomComplexGeneral(m, n, max(1, n)+extra, rnd)
+	aCopy := cloneComplexGeneral(a)
+	s := make([]float64, minmn)
+	u := zerosComplex(m, ucols, max(1, ucols)+extra)
+	vt := zerosComplex(vtrows, n, max(1, n)+extra)
+	rwork := make([]float64, 5*minmn+2*minmn*minmn)
+
+	work := []complex128{0}
+	impl.Zgesvd(job, job, m, n, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, -1, rwork)
+	work = make([]complex128, int(real(work[0])))
+	ok := impl.Zgesvd(job, job, m, n, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, len(work), rwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure", name)
+		return
+	}
+	if minmn == 0 {
+		return
+	}
+
+	if !sort.IsSorted(sort.Reverse(sort.Float64Slice(s))) {
+		t.Errorf("%v: singular values not in decreasing order", name)
+	}
+	if s[minmn-1] < 0 {
+		t.Errorf("%v: negative singular value", name)
+	}
+	if !hasOrthonormalColumnsComplex(u) {
+		t.Errorf("%v: columns of U are not orthonormal", name)
+	}
+	if !hasOrthonormalRowsComplex(vt) {
+		t.Errorf("%v: rows of V^H are not orthonormal", name)
+	}
+
+	// Check that U * Sigma * V^H = A.
+	us := zerosComplex(m, minmn, minmn)
+	for i := 0; i < m; i++ {
+		for j := 0; j < minmn; j++ {
+			us.Data[i*us.Stride+j] = u.Data[i*u.Stride+j] * complex(s[j], 0)
+		}
+	}
+	vtk := vt
+	vtk.Rows = minmn
+	got := zerosComplex(m, n, n)
+	cblas128.Gemm(blas.NoTrans, blas.NoTrans, 1, us, vtk, 0, got)
+	if !equalApproxComplexGeneral(got, aCopy, tol*float64(max(m, n))) {
+		t.Errorf("%v: U*Sigma*V^H != A", name)
+	}
+
+	// Check that computing only the singular values gives the same result.
+	copy(a.Data, aCopy.Data)
+	sOnly := make([]float64, minmn)
+	work = []complex128{0}
+	impl.Zgesvd(lapack.SVDNone, lapack.SVDNone, m, n, a.Data, a.Stride, sOnly, nil, 1, nil, 1, work, -1, rwork)
+	work = make([]complex128, int(real(work[0])))
+	ok = impl.Zgesvd(lapack.SVDNone, lapack.SVDNone, m, n, a.Data, a.Stride, sOnly, nil, 1, nil, 1, work, len(work), rwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure computing singular values only", name)
+		return
+	}
+	if !floats.EqualApprox(s, sOnly, tol*math.Max(1, s[0])*float64(max(m, n))) {
+		t.Errorf("%v: singular values differ when computing values only", name)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+type Zgetrfer interface {
+	Zgetrf(m, n int, a []complex128, lda int, ipiv []int) bool
+}
+
+func ZgetrfTest(t *testing.T, impl Zgetrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{0, 0}, {0, 3}, {3, 0},
+		{1, 1}, {1, 5}, {5, 1},
+		{4, 4}, {10, 5}, {5, 10},
+		{100, 100}, {150, 80}, {80, 150},
+	} {
+		m, n := test.m, test.n
+		for _, lda := range []int{max(1, n), n + 3} {
+			zgetrfTest(t, impl, rnd, m, n, lda)
+		}
+	}
+}
+
+func zgetrfTest(t *testing.T, impl Zgetrfer, rnd *rand.Rand, m, n, lda int) {
+	const tol = 1e-12
+
+	name := fmt.Sprintf("m=%v,n=%v,lda=%v", m, n, lda)
+
+	a := randomComplexGeneral(m, n, lda, rnd)
+	aCopy := cloneComplexGeneral(a)
+	mn := min(m, n)
+	ipiv := make([]int, mn)
+	for i := range ipiv {
+		ipiv[i] = -1
+	}
+
+	ok := impl.Zgetrf(m, n, a.Data, a.Stride, ipiv)
+	if !ok {
+		t.Errorf("%v: unexpected singular matrix", name)
+		return
+	}
+	if mn == 0 {
+		return
+	}
+
+	// Extract L (m×mn) and U (mn×n).
+	l := zerosComplex(m, mn, mn)
+	u := zerosComplex(mn, n, n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < mn; j++ {
+			switch {
+			case i == j:
+				l.Data[i*l.Stride+j] = 1
+			case i > j:
+				l.Data[i*l.Stride+j] = a.Data[i*a.Stride+j]
+			}
+		}
+	}
+	for i := 0; i < mn; i++ {
+		for j := i; j < n; j++ {
+			u.Data[i*u.Stride+j] = a.Data[i*a.Stride+j]
+		}
+	}
+	got := zerosComplex(m, n, n)
+	cblas128.Gemm(blas.NoTrans, blas.NoTrans, 1, l, u, 0, got)
+
+	// Apply the row interchanges in reverse order to P*L*U.
+	for i := mn - 1; i >= 0; i-- {
+		if ipiv[i] < i || m <= ipiv[i] {
+			t.Errorf("%v: invalid pivot index %v at %v", name, ipiv[i], i)
+			return
+		}
+		cblas128.Swap(n, cblas128.Vector{Data: got.Data[i*got.Stride:], Inc: 1},
+			cblas128.Vector{Data: got.Data[ipiv[i]*got.Stride:], Inc: 1})
+	}
+	if !equalApproxComplexGeneral(got, aCopy, tol*float64(n)) {
+		t.Errorf("%v: P*L*U != A", name)
+	}
+}
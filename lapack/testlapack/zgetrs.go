@@ -0,0 +1,59 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+type Zgetrser interface {
+	Zgetrs(trans blas.Transpose, n, nrhs int, a []complex128, lda int, ipiv []int, b []complex128, ldb int)
+
+	Zgetrfer
+}
+
+func ZgetrsTest(t *testing.T, impl Zgetrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+		for _, n := range []int{0, 1, 2, 5, 10, 70} {
+			for _, nrhs := range []int{0, 1, 3} {
+				for _, ld := range []int{max(1, max(n, nrhs)), max(n, nrhs) + 3} {
+					zgetrsTest(t, impl, rnd, trans, n, nrhs, ld)
+				}
+			}
+		}
+	}
+}
+
+func zgetrsTest(t *testing.T, impl Zgetrser, rnd *rand.Rand, trans blas.Transpose, n, nrhs, ld int) {
+	const tol = 1e-11
+
+	name := fmt.Sprintf("trans=%v,n=%v,nrhs=%v,ld=%v", trans, n, nrhs, ld)
+
+	a := randomComplexGeneral(n, n, ld, rnd)
+	aCopy := cloneComplexGeneral(a)
+	x := randomComplexGeneral(n, nrhs, ld, rnd)
+	b := zerosComplex(n, nrhs, ld)
+	if n > 0 && nrhs > 0 {
+		cblas128.Gemm(trans, blas.NoTrans, 1, aCopy, x, 0, b)
+	}
+
+	ipiv := make([]int, n)
+	ok := impl.Zgetrf(n, n, a.Data, a.Stride, ipiv)
+	if !ok {
+		t.Errorf("%v: unexpected singular matrix", name)
+		return
+	}
+	impl.Zgetrs(trans, n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+	if !equalApproxComplexGeneral(b, x, tol*float64(n)) {
+		t.Errorf("%v: unexpected solution", name)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+type Zlatrser interface {
+	Zlatrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, normin bool, n int, a []complex128, lda int, x []complex128, cnorm []float64) (scale float64)
+}
+
+func ZlatrsTest(t *testing.T, impl Zlatrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 7, 10, 20, 50, 100} {
+				for _, lda := range []int{n, 2*n + 1} {
+					lda = max(1, lda)
+					imats := []int{7, 11, 12, 13, 14, 15, 16, 17, 18}
+					if n < 6 {
+						imats = append(imats, 19)
+					}
+					for _, imat := range imats {
+						testZlatrs(t, impl, imat, uplo, trans, n, lda, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testZlatrs(t *testing.T, impl Zlatrser, imat int, uplo blas.Uplo, trans blas.Transpose, n, lda int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	// Generate a real triangular test matrix and right hand side and
+	// rotate each element by a random phase. This keeps the magnitudes,
+	// and so the scaling difficulties, of the real test matrices.
+	ar := nanSlice(n * lda)
+	br := nanSlice(n)
+	work := make([]float64, 3*n)
+	realTrans := trans
+	if realTrans == blas.ConjTrans {
+		realTrans = blas.Trans
+	}
+	diag := dlattr(imat, uplo, realTrans, n, ar, lda, br, work, rnd)
+	if imat <= 10 {
+		// b has not been generated.
+		dlarnv(br, 3, rnd)
+	}
+	a := make([]complex128, len(ar))
+	for i, v := range ar {
+		a[i] = complex(v, 0) * cmplx.Rect(1, 2*math.Pi*rnd.Float64())
+	}
+	b := make([]complex128, n)
+	var bmax float64
+	for i, v := range br {
+		b[i] = complex(v, 0) * cmplx.Rect(1, 2*math.Pi*rnd.Float64())
+		bmax = math.Max(bmax, cmplx.Abs(b[i]))
+	}
+	if imat == 19 && bmax > 0 {
+		// The elements of A are close to the overflow threshold, so
+		// a small b gives a subnormal solution that can not satisfy
+		// the residual test. Scale b so that its largest element has
+		// modulus 2, the bound on the elements generated by dlattr.
+		for i := range b {
+			b[i] *= complex(2/bmax, 0)
+		}
+	}
+
+	cnorm := nanSlice(n)
+	x := make([]complex128, n)
+	zwork := make([]complex128, n)
+
+	// Call Zlatrs with normin=false.
+	copy(x, b)
+	scale := impl.Zlatrs(uplo, trans, diag, false, n, a, lda, x, cnorm)
+	prefix := fmt.Sprintf("Case imat=%v (n=%v,lda=%v,trans=%v,uplo=%v,diag=%v", imat, n, lda, trans, uplo, diag)
+	for i, v := range cnorm {
+		if math.IsNaN(v) {
+			t.Errorf("%v: cnorm[%v] not computed (scale=%v,normin=false)", prefix, i, scale)
+		}
+	}
+	resid, hasNaN := zlatrsResidual(uplo, trans, diag, n, a, lda, scale, cnorm, x, b, zwork)
+	if hasNaN {
+		t.Errorf("%v: unexpected NaN (scale=%v,normin=false)", prefix, scale)
+	} else if resid > tol {
+		t.Errorf("%v: residual %v too large (scale=%v,normin=false)", prefix, resid, scale)
+	}
+
+	// Call Zlatrs with normin=true because cnorm has been filled.
+	copy(x, b)
+	scale = impl.Zlatrs(uplo, trans, diag, true, n, a, lda, x, cnorm)
+	resid, hasNaN = zlatrsResidual(uplo, trans, diag, n, a, lda, scale, cnorm, x, b, zwork)
+	if hasNaN {
+		t.Errorf("%v: unexpected NaN (scale=%v,normin=true)", prefix, scale)
+	} else if resid > tol {
+		t.Errorf("%v: residual %v too large (scale=%v,normin=true)", prefix, resid, scale)
+	}
+}
+
+// zlatrsResidual returns norm(op(A)*x-scale*b) / (norm(op(A))*norm(x)*eps)
+// and whether NaN has been encountered in the process.
+func zlatrsResidual(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n int, a []complex128, lda int, scale float64, cnorm []float64, x, b, work []complex128) (resid float64, hasNaN bool) {
+	if n == 0 {
+		return 0, false
+	}
+
+	// Compute the norm of the triangular matrix A using the column norms
+	// already computed by Zlatrs.
+	var tnorm float64
+	if diag == blas.NonUnit {
+		for j := 0; j < n; j++ {
+			tnorm = math.Max(tnorm, cabs1(a[j*lda+j])+cnorm[j])
+		}
+	} else {
+		for j := 0; j < n; j++ {
+			tnorm = math.Max(tnorm, 1+cnorm[j])
+		}
+	}
+
+	eps := dlamchE
+	smlnum := dlamchS
+	bi := cblas128.Implementation()
+
+	// Compute norm(op(A)*x-scale*b) / (norm(op(A))*norm(x)*eps)
+	copy(work, x)
+	ix := bi.Izamax(n, work, 1)
+	xnorm := math.Max(1, cabs1(work[ix]))
+	xscal := 1 / xnorm / float64(n)
+	bi.Zdscal(n, xscal, work, 1)
+	bi.Ztrmv(uplo, trans, diag, n, a, lda, work, 1)
+	bi.Zaxpy(n, complex(-scale*xscal, 0), b, 1, work, 1)
+	for _, v := range work {
+		if cmplx.IsNaN(v) {
+			return 1 / eps, true
+		}
+	}
+	ix = bi.Izamax(n, work, 1)
+	resid = cabs1(work[ix])
+	ix = bi.Izamax(n, x, 1)
+	xnorm = cabs1(x[ix])
+	if resid*smlnum <= xnorm {
+		if xnorm > 0 {
+			resid /= xnorm
+		}
+	} else if resid > 0 {
+		resid = 1 / eps
+	}
+	if resid*smlnum <= tnorm {
+		if tnorm > 0 {
+			resid /= tnorm
+		}
+	} else if resid > 0 {
+		resid = 1 / eps
+	}
+	return resid, false
+}
+
+// cabs1 returns |real(z)|+|imag(z)|.
+func cabs1(z complex128) float64 {
+	return math.Abs(real(z)) + math.Abs(imag(z))
+}
@@ -0,0 +1,81 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+type Zpotrfer interface {
+	Zpotrf(ul blas.Uplo, n int, a []complex128, lda int) (ok bool)
+}
+
+func ZpotrfTest(t *testing.T, impl Zpotrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 70, 150} {
+			for _, lda := range []int{max(1, n), n + 4} {
+				zpotrfTest(t, impl, rnd, uplo, n, lda)
+			}
+		}
+	}
+}
+
+func zpotrfTest(t *testing.T, impl Zpotrfer, rnd *rand.Rand, uplo blas.Uplo, n, lda int) {
+	const tol = 1e-12
+
+	name := fmt.Sprintf("uplo=%v,n=%v,lda=%v", string(uplo), n, lda)
+
+	a := randomHPD(n, lda, rnd)
+	aCopy := cloneComplexGeneral(a)
+
+	ok := impl.Zpotrf(uplo, n, a.Data, a.Stride)
+	if !ok {
+		t.Errorf("%v: unexpected failure for positive definite matrix", name)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Extract the triangular factor and check that the imaginary part of
+	// its diagonal is zero.
+	tri := zerosComplex(n, n, n)
+	for i := 0; i < n; i++ {
+		if imag(a.Data[i*a.Stride+i]) != 0 {
+			t.Errorf("%v: non-real diagonal element at %v", name, i)
+		}
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j >= i) || (uplo == blas.Lower && j <= i) {
+				tri.Data[i*tri.Stride+j] = a.Data[i*a.Stride+j]
+			}
+		}
+	}
+	got := zerosComplex(n, n, n)
+	if uplo == blas.Upper {
+		cblas128.Gemm(blas.ConjTrans, blas.NoTrans, 1, tri, tri, 0, got)
+	} else {
+		cblas128.Gemm(blas.NoTrans, blas.ConjTrans, 1, tri, tri, 0, got)
+	}
+	if !equalApproxComplexGeneral(got, aCopy, tol*float64(n)) {
+		t.Errorf("%v: unexpected reconstruction of A", name)
+	}
+
+	// Check that a matrix that is not positive definite is detected.
+	if n > 1 {
+		b := randomHPD(n, lda, rnd)
+		k := rnd.Intn(n)
+		b.Data[k*b.Stride+k] = -1
+		if impl.Zpotrf(uplo, n, b.Data, b.Stride) {
+			t.Errorf("%v: indefinite matrix not detected", name)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+type Zpotrser interface {
+	Zpotrs(uplo blas.Uplo, n, nrhs int, a []complex128, lda int, b []complex128, ldb int)
+
+	Zpotrfer
+}
+
+func ZpotrsTest(t *testing.T, impl Zpotrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 5, 10, 70} {
+			for _, nrhs := range []int{0, 1, 4} {
+				for _, ld := range []int{max(1, max(n, nrhs)), max(n, nrhs) + 3} {
+					zpotrsTest(t, impl, rnd, uplo, n, nrhs, ld)
+				}
+			}
+		}
+	}
+}
+
+func zpotrsTest(t *testing.T, impl Zpotrser, rnd *rand.Rand, uplo blas.Uplo, n, nrhs, ld int) {
+	const tol = 1e-11
+
+	name := fmt.Sprintf("uplo=%v,n=%v,nrhs=%v,ld=%v", string(uplo), n, nrhs, ld)
+
+	a := randomHPD(n, ld, rnd)
+	x := randomComplexGeneral(n, nrhs, ld, rnd)
+	b := zerosComplex(n, nrhs, ld)
+	if n > 0 && nrhs > 0 {
+		cblas128.Gemm(blas.NoTrans, blas.NoTrans, 1, a, x, 0, b)
+	}
+
+	ok := impl.Zpotrf(uplo, n, a.Data, a.Stride)
+	if !ok {
+		t.Errorf("%v: unexpected failure for positive definite matrix", name)
+		return
+	}
+	impl.Zpotrs(uplo, n, nrhs, a.Data, a.Stride, b.Data, b.Stride)
+	if !equalApproxComplexGeneral(b, x, tol*float64(n)) {
+		t.Errorf("%v: unexpected solution", name)
+	}
+}
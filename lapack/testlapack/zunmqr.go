@@ -0,0 +1,81 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+type Zunmqrer interface {
+	Zunmqr(side blas.Side, trans blas.Transpose, m, n, k int, a []complex128, lda int, tau, c []complex128, ldc int, work []complex128, lwork int)
+
+	Zgeqrfer
+}
+
+func ZunmqrTest(t *testing.T, impl Zunmqrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []blas.Side{blas.Left, blas.Right} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.ConjTrans} {
+			for _, mn := range [][2]int{{1, 1}, {3, 2}, {2, 3}, {5, 5}, {10, 4}, {4, 10}, {25, 17}} {
+				m, n := mn[0], mn[1]
+				nq := m
+				if side == blas.Right {
+					nq = n
+				}
+				for _, k := range []int{0, 1, nq / 2, nq} {
+					zunmqrTest(t, impl, rnd, side, trans, m, n, k)
+				}
+			}
+		}
+	}
+}
+
+func zunmqrTest(t *testing.T, impl Zunmqrer, rnd *rand.Rand, side blas.Side, trans blas.Transpose, m, n, k int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("side=%v,trans=%v,m=%v,n=%v,k=%v", side, trans, m, n, k)
+
+	nq := m
+	if side == blas.Right {
+		nq = n
+	}
+	// Compute the QR factorization of a random nq×k matrix.
+	a := randomComplexGeneral(nq, k, max(1, k), rnd)
+	tau := make([]complex128, k)
+	work := make([]complex128, max(1, k))
+	impl.Zgeqrf(nq, k, a.Data, a.Stride, tau, work, len(work))
+
+	// Form Q explicitly.
+	q := zerosComplex(nq, nq, nq)
+	for i := 0; i < nq; i++ {
+		for j := 0; j < k; j++ {
+			q.Data[i*q.Stride+j] = a.Data[i*a.Stride+j]
+		}
+	}
+	work = make([]complex128, nq)
+	impl.Zungqr(nq, nq, k, q.Data, q.Stride, tau, work, len(work))
+
+	c := randomComplexGeneral(m, n, n, rnd)
+	want := zerosComplex(m, n, n)
+	if side == blas.Left {
+		cblas128.Gemm(trans, blas.NoTrans, 1, q, c, 0, want)
+	} else {
+		cblas128.Gemm(blas.NoTrans, trans, 1, c, q, 0, want)
+	}
+
+	work = []complex128{0}
+	impl.Zunmqr(side, trans, m, n, k, a.Data, a.Stride, tau, c.Data, c.Stride, work, -1)
+	work = make([]complex128, int(real(work[0])))
+	impl.Zunmqr(side, trans, m, n, k, a.Data, a.Stride, tau, c.Data, c.Stride, work, len(work))
+	if !equalApproxComplexGeneral(c, want, tol*float64(nq)) {
+		t.Errorf("%v: unexpected result", name)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// BiCGStab implements the BiConjugate Gradient Stabilized iterative method
+// with right preconditioning for solving systems of linear equations
+//  A * x = b,
+// where A is a nonsymmetric, nonsingular matrix.
+//
+// References:
+//  - Barrett, R. et al. (1994). Section 2.3.8 BiConjugate Gradient Stabilized (Bi-CGSTAB).
+//    In Templates for the Solution of Linear Systems: Building Blocks
+//    for Iterative Methods (2nd ed.) (pp. 24-25). Philadelphia, PA: SIAM.
+//    Retrieved from http://www.netlib.org/templates/templates.pdf
+type BiCGStab struct {
+	r, rt    mat.VecDense
+	p, v, t  mat.VecDense
+	phat, s  mat.VecDense
+	shat     mat.VecDense
+	rho      float64
+	rhoPrev  float64
+	alpha    float64
+	omega    float64
+	resume   int
+	notFirst bool
+}
+
+// Init initializes the data for a linear solve. See the Method interface for
+// more details.
+func (b *BiCGStab) Init(x, r *mat.VecDense) {
+	n := r.Len()
+	reuse(&b.r, n)
+	b.r.CopyVec(r)
+	reuse(&b.rt, n)
+	b.rt.CopyVec(r)
+	reuse(&b.p, n)
+	reuse(&b.v, n)
+	reuse(&b.t, n)
+	reuse(&b.phat, n)
+	reuse(&b.s, n)
+	reuse(&b.shat, n)
+
+	b.rhoPrev = 1
+	b.alpha = 1
+	b.omega = 1
+	b.notFirst = false
+	b.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface
+// for more details.
+//
+// BiCGStab will command the following operations:
+//  MulVec
+//  PreconSolve
+//  CheckResidualNorm
+//  MajorIteration
+func (b *BiCGStab) Iterate(ctx *Context) (Operation, error) {
+	switch b.resume {
+	case 1:
+		b.rho = mat.Dot(&b.rt, &b.r)
+		if b.rho == 0 {
+			return NoOperation, ErrBreakdown
+		}
+		if b.notFirst {
+			beta := (b.rho / b.rhoPrev) * (b.alpha / b.omega)
+			// p = r + beta*(p - omega*v)
+			b.p.AddScaledVec(&b.p, -b.omega, &b.v)
+			b.p.AddScaledVec(&b.r, beta, &b.p)
+		} else {
+			b.p.CopyVec(&b.r)
+			b.notFirst = true
+		}
+		// Solve M phat = p.
+		ctx.Src = &b.p
+		ctx.Dst = &b.phat
+		b.resume = 2
+		return PreconSolve, nil
+	case 2:
+		// Compute v = A phat.
+		ctx.Src = &b.phat
+		ctx.Dst = &b.v
+		b.resume = 3
+		return MulVec, nil
+	case 3:
+		rtv := mat.Dot(&b.rt, &b.v)
+		if rtv == 0 {
+			return NoOperation, ErrBreakdown
+		}
+		b.alpha = b.rho / rtv
+		b.s.AddScaledVec(&b.r, -b.alpha, &b.v)
+		ctx.ResidualNorm = mat.Norm(&b.s, 2)
+		b.resume = 4
+		return CheckResidualNorm, nil
+	case 4:
+		if ctx.Converged {
+			ctx.X.AddScaledVec(ctx.X, b.alpha, &b.phat)
+			b.r.CopyVec(&b.s)
+			b.resume = 1
+			return MajorIteration, nil
+		}
+		// Solve M shat = s.
+		ctx.Src = &b.s
+		ctx.Dst = &b.shat
+		b.resume = 5
+		return PreconSolve, nil
+	case 5:
+		// Compute t = A shat.
+		ctx.Src = &b.shat
+		ctx.Dst = &b.t
+		b.resume = 6
+		return MulVec, nil
+	case 6:
+		tt := mat.Dot(&b.t, &b.t)
+		if tt == 0 {
+			return NoOperation, ErrBreakdown
+		}
+		b.omega = mat.Dot(&b.t, &b.s) / tt
+		ctx.X.AddScaledVec(ctx.X, b.alpha, &b.phat)
+		ctx.X.AddScaledVec(ctx.X, b.omega, &b.shat)
+		b.r.AddScaledVec(&b.s, -b.omega, &b.t)
+		ctx.ResidualNorm = mat.Norm(&b.r, 2)
+		b.resume = 7
+		return CheckResidualNorm, nil
+	case 7:
+		if !ctx.Converged && b.omega == 0 {
+			return NoOperation, ErrBreakdown
+		}
+		b.rhoPrev = b.rho
+		b.resume = 1
+		return MajorIteration, nil
+	default:
+		panic("linsolve: BiCGStab.Init not called")
+	}
+}
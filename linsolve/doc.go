@@ -0,0 +1,12 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package linsolve provides iterative methods for solving linear systems.
+//
+// The methods in linsolve only access the matrix of the system through
+// matrix-vector products, so they are suitable for large sparse systems and
+// for matrix-free operators. A system is solved by calling Iterative with
+// a Method such as CG, MINRES, GMRES or BiCGStab, and the convergence
+// of the methods can be accelerated by providing a Preconditioner.
+package linsolve // import "gonum.org/v1/gonum/linsolve"
@@ -0,0 +1,215 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+const defaultRestart = 30
+
+// GMRES implements the Generalized Minimum Residual iterative method with
+// restarts and right preconditioning for solving systems of linear equations
+//  A * x = b,
+// where A is a nonsymmetric, nonsingular matrix. GMRES minimizes the
+// Euclidean norm of the residual over the Krylov subspace built in each
+// restart cycle.
+//
+// References:
+//  - Barrett, R. et al. (1994). Section 2.3.4 Generalized Minimal Residual
+//    (GMRES). In Templates for the Solution of Linear Systems: Building
+//    Blocks for Iterative Methods (2nd ed.) (pp. 17-19). Philadelphia, PA:
+//    SIAM. Retrieved from http://www.netlib.org/templates/templates.pdf
+//  - Saad, Y. (2003). Iterative methods for sparse linear systems (2nd ed.).
+//    Philadelphia, PA: SIAM. Section 9.3.2.
+type GMRES struct {
+	// Restart is the number of iterations between restarts, that is, the
+	// dimension of the Krylov subspace built in each cycle. If Restart is
+	// zero, min(n, 30) is used, where n is the dimension of the system.
+	Restart int
+
+	m int
+
+	// v holds the orthonormal basis of the Krylov subspace in its first
+	// j+1 columns.
+	v *mat.Dense
+	// h holds the upper Hessenberg matrix reduced to upper triangular form
+	// by Givens rotations.
+	h *mat.Dense
+	// cs and sn hold the parameters of the Givens rotations.
+	cs, sn []float64
+	// g holds the right-hand side of the least-squares problem.
+	g []float64
+	y []float64
+
+	r, vj, z, w mat.VecDense
+
+	j      int
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for
+// more details.
+func (g *GMRES) Init(x, r *mat.VecDense) {
+	n := r.Len()
+	if g.Restart < 0 {
+		panic("linsolve: negative GMRES restart")
+	}
+	g.m = g.Restart
+	if g.m == 0 {
+		g.m = min(n, defaultRestart)
+	}
+	g.m = min(g.m, n)
+
+	g.v = mat.NewDense(n, g.m+1, nil)
+	g.h = mat.NewDense(g.m+1, g.m, nil)
+	g.cs = make([]float64, g.m)
+	g.sn = make([]float64, g.m)
+	g.g = make([]float64, g.m+1)
+	g.y = make([]float64, g.m)
+
+	reuse(&g.r, n)
+	g.r.CopyVec(r)
+	reuse(&g.vj, n)
+	reuse(&g.z, n)
+	reuse(&g.w, n)
+
+	g.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface
+// for more details.
+//
+// GMRES will command the following operations:
+//  MulVec
+//  PreconSolve
+//  CheckResidualNorm
+//  MajorIteration
+func (g *GMRES) Iterate(ctx *Context) (Operation, error) {
+	switch g.resume {
+	case 1:
+		// Start a new cycle with v_0 = r / |r|.
+		beta := mat.Norm(&g.r, 2)
+		if beta == 0 {
+			return NoOperation, ErrBreakdown
+		}
+		g.v.Zero()
+		g.h.Zero()
+		for i := range g.g {
+			g.g[i] = 0
+		}
+		g.g[0] = beta
+		g.v.ColView(0).(*mat.VecDense).ScaleVec(1/beta, &g.r)
+		g.j = 0
+		fallthrough
+	case 2:
+		// Solve M z = v_j.
+		g.vj.CopyVec(g.v.ColView(g.j))
+		ctx.Src = &g.vj
+		ctx.Dst = &g.z
+		g.resume = 3
+		return PreconSolve, nil
+	case 3:
+		// Compute w = A z.
+		ctx.Src = &g.z
+		ctx.Dst = &g.w
+		g.resume = 4
+		return MulVec, nil
+	case 4:
+		j := g.j
+		// Orthogonalize w against the basis vectors with the modified
+		// Gram-Schmidt process.
+		for i := 0; i <= j; i++ {
+			vi := g.v.ColView(i)
+			hij := mat.Dot(vi, &g.w)
+			g.h.Set(i, j, hij)
+			g.w.AddScaledVec(&g.w, -hij, vi)
+		}
+		hnorm := mat.Norm(&g.w, 2)
+		g.h.Set(j+1, j, hnorm)
+		if hnorm != 0 {
+			g.v.ColView(j+1).(*mat.VecDense).ScaleVec(1/hnorm, &g.w)
+		}
+
+		// Apply the previous rotations to the new column of h.
+		for i := 0; i < j; i++ {
+			h0 := g.h.At(i, j)
+			h1 := g.h.At(i+1, j)
+			g.h.Set(i, j, g.cs[i]*h0+g.sn[i]*h1)
+			g.h.Set(i+1, j, -g.sn[i]*h0+g.cs[i]*h1)
+		}
+		// Compute and apply the rotation that eliminates h[j+1,j].
+		h0 := g.h.At(j, j)
+		h1 := g.h.At(j+1, j)
+		rho := math.Hypot(h0, h1)
+		if rho == 0 {
+			return NoOperation, ErrBreakdown
+		}
+		g.cs[j] = h0 / rho
+		g.sn[j] = h1 / rho
+		g.h.Set(j, j, rho)
+		g.h.Set(j+1, j, 0)
+		g.g[j+1] = -g.sn[j] * g.g[j]
+		g.g[j] *= g.cs[j]
+
+		ctx.ResidualNorm = math.Abs(g.g[j+1])
+		g.resume = 5
+		return CheckResidualNorm, nil
+	case 5:
+		if !ctx.Converged && g.j < g.m-1 {
+			g.j++
+			g.resume = 2
+			return MajorIteration, nil
+		}
+		// Update the solution by solving the upper triangular system
+		// H y = g and computing x += M^{-1} V y.
+		k := g.j + 1
+		copy(g.y, g.g[:k])
+		hraw := g.h.RawMatrix()
+		blas64.Trsv(blas.NoTrans, blas64.Triangular{
+			Uplo:   blas.Upper,
+			Diag:   blas.NonUnit,
+			N:      k,
+			Stride: hraw.Stride,
+			Data:   hraw.Data,
+		}, blas64.Vector{Inc: 1, Data: g.y[:k]})
+		g.vj.MulVec(g.v.Slice(0, g.v.RawMatrix().Rows, 0, k), mat.NewVecDense(k, g.y[:k]))
+		ctx.Src = &g.vj
+		ctx.Dst = &g.z
+		g.resume = 6
+		return PreconSolve, nil
+	case 6:
+		ctx.X.AddVec(ctx.X, &g.z)
+		if ctx.Converged {
+			g.resume = 8
+			return MajorIteration, nil
+		}
+		// Compute the residual of the updated solution,
+		// r = r - A z.
+		ctx.Src = &g.z
+		ctx.Dst = &g.w
+		g.resume = 7
+		return MulVec, nil
+	case 7:
+		g.r.SubVec(&g.r, &g.w)
+		g.resume = 1
+		return MajorIteration, nil
+	case 8:
+		panic("linsolve: GMRES.Iterate called after convergence")
+	default:
+		panic("linsolve: GMRES.Init not called")
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,343 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const defaultTolerance = 1e-8
+
+var (
+	// ErrIterationLimit is returned when the maximum number of iterations
+	// was reached before the method converged.
+	ErrIterationLimit = errors.New("linsolve: iteration limit reached")
+
+	// ErrBreakdown is returned when a method can not continue due to
+	// division by zero or a similar failure of the underlying recurrences.
+	ErrBreakdown = errors.New("linsolve: method breakdown")
+
+	// ErrNotPositiveDefinite is returned when a method that requires a
+	// positive definite matrix or preconditioner detects that it is not.
+	ErrNotPositiveDefinite = errors.New("linsolve: matrix not positive definite")
+)
+
+// MulVecToer represents a square matrix A by means of a matrix-vector
+// multiplication.
+type MulVecToer interface {
+	// MulVecTo computes A*x or A^T*x and stores the result into dst.
+	MulVecTo(dst *mat.VecDense, trans bool, x mat.Vector)
+}
+
+// Operation specifies the type of operation that a Method requests from the
+// caller of Iterate.
+type Operation uint
+
+// Supported Operations.
+const (
+	// NoOperation specifies that no action is requested.
+	NoOperation Operation = 0
+
+	// InitIteration is sent to Recorder to indicate the initial state
+	// of the solve. Method must not return it.
+	InitIteration Operation = 1 << (iota - 1)
+
+	// PostIteration is sent to Recorder to indicate the final state
+	// of the solve. Method must not return it.
+	PostIteration
+
+	// MulVec specifies that the caller must compute the product A*Src and
+	// store the result into Dst.
+	MulVec
+
+	// PreconSolve specifies that the caller must solve M*Dst = Src, where M
+	// is the preconditioner.
+	PreconSolve
+
+	// CheckResidualNorm specifies that the caller must check whether the
+	// value of ResidualNorm satisfies the convergence criterion and set the
+	// Converged field accordingly.
+	CheckResidualNorm
+
+	// MajorIteration indicates that the Method has completed an iteration.
+	// If Converged is true, the caller must stop the iterations and X
+	// must contain the solution.
+	MajorIteration
+)
+
+func (op Operation) String() string {
+	switch op {
+	case NoOperation:
+		return "NoOperation"
+	case InitIteration:
+		return "InitIteration"
+	case PostIteration:
+		return "PostIteration"
+	case MulVec:
+		return "MulVec"
+	case PreconSolve:
+		return "PreconSolve"
+	case CheckResidualNorm:
+		return "CheckResidualNorm"
+	case MajorIteration:
+		return "MajorIteration"
+	}
+	return fmt.Sprintf("Operation(%d)", op)
+}
+
+// Context mediates the communication between a Method and the caller of its
+// Iterate method.
+type Context struct {
+	// X is the current approximate solution. It is updated in place
+	// by the Method.
+	X *mat.VecDense
+
+	// ResidualNorm is an estimate of the norm of the residual of the
+	// current approximate solution. It is set by the Method before
+	// requesting the CheckResidualNorm operation.
+	ResidualNorm float64
+
+	// Converged indicates whether the current approximate solution
+	// satisfies the convergence criterion. It is set by the caller as
+	// the result of the CheckResidualNorm operation.
+	Converged bool
+
+	// Src and Dst are the source and destination vectors of the MulVec
+	// and PreconSolve operations.
+	Src, Dst *mat.VecDense
+}
+
+// Method is an iterative method that produces a sequence of vectors converging
+// to the solution of the system of linear equations
+//  A * x = b.
+// A Method communicates with its caller by means of reverse communication,
+// requesting matrix-vector products, preconditioner solves and convergence
+// checks through the returned Operation.
+type Method interface {
+	// Init initializes the method for solving an n×n linear system with
+	// the initial estimate of the solution x and its residual r = b - A*x.
+	// The Method must not retain x or r. During subsequent calls to
+	// Iterate the Method updates the approximate solution held in ctx.X.
+	Init(x, r *mat.VecDense)
+
+	// Iterate performs a step of the method and returns the next
+	// Operation that the caller must perform. The Method communicates with
+	// the caller by means of ctx.
+	Iterate(ctx *Context) (Operation, error)
+}
+
+// Preconditioner represents a preconditioning matrix M that approximates
+// the system matrix A and for which systems M * x = b are cheap to solve.
+type Preconditioner interface {
+	// SolveVecTo solves M * x = b and stores the result into dst.
+	SolveVecTo(dst *mat.VecDense, b mat.Vector) error
+}
+
+// A Recorder can record the progress of the solve, for example to print
+// the progress to StdOut or to a log file. A Recorder must not modify
+// any data.
+type Recorder interface {
+	Init() error
+	Record(ctx *Context, op Operation, stats *Stats) error
+}
+
+// Settings holds the settings for solving a linear system.
+type Settings struct {
+	// InitX is the initial estimate of the solution. If InitX is nil,
+	// the zero vector is used.
+	InitX mat.Vector
+
+	// Tolerance is the relative tolerance for the residual norm. The
+	// method is considered to have converged when
+	//  |r| <= Tolerance * |b|,
+	// where r is the residual of the approximate solution. If Tolerance
+	// is zero, a default value of 1e-8 is used. Tolerance must be less
+	// than one.
+	Tolerance float64
+
+	// MaxIterations is the maximum number of iterations that will be
+	// performed. If it is zero, a default value of 4*n is used where n
+	// is the dimension of the system.
+	MaxIterations int
+
+	// Preconditioner is the preconditioner used by the method. If it is
+	// nil, the identity matrix is used.
+	Preconditioner Preconditioner
+
+	// Recorder records the progress of the solve.
+	Recorder Recorder
+}
+
+// Stats contains the statistics of the solve.
+type Stats struct {
+	Iterations   int           // Total number of iterations.
+	MulVec       int           // Number of matrix-vector products.
+	PreconSolve  int           // Number of preconditioner solves.
+	ResidualNorm float64       // Last residual norm estimate.
+	Runtime      time.Duration // Total runtime of the solve.
+}
+
+// Result holds the result of a linear solve.
+type Result struct {
+	// X is the approximate solution.
+	X *mat.VecDense
+
+	Stats
+}
+
+// Iterative finds an approximate solution of the system of n linear equations
+//  A * x = b,
+// where A is an n×n matrix represented by the matrix-vector multiplication a
+// and b is a given vector of length n. The system is solved by the iterative
+// method m. If m is nil, GMRES is used.
+//
+// If settings is nil, default settings are used.
+//
+// Iterative returns the result of the solve together with any error. If the
+// iteration limit is reached before convergence, the result holds the
+// current approximate solution and ErrIterationLimit is returned.
+func Iterative(a MulVecToer, b mat.Vector, m Method, settings *Settings) (*Result, error) {
+	start := time.Now()
+
+	n := b.Len()
+	var s Settings
+	if settings != nil {
+		s = *settings
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = defaultTolerance
+	}
+	if s.Tolerance < 0 || 1 <= s.Tolerance {
+		panic("linsolve: invalid tolerance")
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 4 * n
+	}
+	if s.MaxIterations < 0 {
+		panic("linsolve: negative iteration limit")
+	}
+	if m == nil {
+		m = &GMRES{}
+	}
+
+	x := mat.NewVecDense(n, nil)
+	if s.InitX != nil {
+		if s.InitX.Len() != n {
+			panic("linsolve: mismatched length of initial estimate")
+		}
+		x.CopyVec(s.InitX)
+	}
+
+	// Compute the initial residual r = b - A*x.
+	var stats Stats
+	r := mat.NewVecDense(n, nil)
+	if s.InitX != nil {
+		a.MulVecTo(r, false, x)
+		stats.MulVec++
+		r.SubVec(b, r)
+	} else {
+		r.CopyVec(b)
+	}
+
+	bnorm := mat.Norm(b, 2)
+	if bnorm == 0 {
+		// The solution of a homogeneous system is zero.
+		bnorm = 1
+		x.Zero()
+		r.Zero()
+	}
+
+	ctx := &Context{
+		X:            x,
+		ResidualNorm: mat.Norm(r, 2),
+	}
+	ctx.Converged = ctx.ResidualNorm <= s.Tolerance*bnorm
+	stats.ResidualNorm = ctx.ResidualNorm
+
+	if s.Recorder != nil {
+		err := s.Recorder.Init()
+		if err != nil {
+			return nil, err
+		}
+		err = s.Recorder.Record(ctx, InitIteration, &stats)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if !ctx.Converged {
+		m.Init(x, r)
+		err = iterate(a, m, &s, ctx, bnorm, &stats)
+	}
+
+	stats.Runtime = time.Since(start)
+	if s.Recorder != nil {
+		recErr := s.Recorder.Record(ctx, PostIteration, &stats)
+		if err == nil {
+			err = recErr
+		}
+	}
+	return &Result{X: x, Stats: stats}, err
+}
+
+// iterate runs the main loop of the method m, carrying out the requested
+// operations until convergence, failure or the iteration limit is reached.
+func iterate(a MulVecToer, m Method, s *Settings, ctx *Context, bnorm float64, stats *Stats) error {
+	for {
+		op, err := m.Iterate(ctx)
+		if err != nil {
+			return err
+		}
+		switch op {
+		default:
+			panic(fmt.Sprintf("linsolve: invalid operation %v", op))
+		case NoOperation:
+		case MulVec:
+			a.MulVecTo(ctx.Dst, false, ctx.Src)
+			stats.MulVec++
+		case PreconSolve:
+			if s.Preconditioner == nil {
+				ctx.Dst.CopyVec(ctx.Src)
+				break
+			}
+			err := s.Preconditioner.SolveVecTo(ctx.Dst, ctx.Src)
+			if err != nil {
+				return err
+			}
+			stats.PreconSolve++
+		case CheckResidualNorm:
+			stats.ResidualNorm = ctx.ResidualNorm
+			ctx.Converged = ctx.ResidualNorm <= s.Tolerance*bnorm
+		case MajorIteration:
+			stats.Iterations++
+			if s.Recorder != nil {
+				err := s.Recorder.Record(ctx, MajorIteration, stats)
+				if err != nil {
+					return err
+				}
+			}
+			if ctx.Converged {
+				return nil
+			}
+			if stats.Iterations >= s.MaxIterations {
+				return ErrIterationLimit
+			}
+		}
+	}
+}
+
+// reuse resizes v to be a zeroed vector of length n.
+func reuse(v *mat.VecDense, n int) {
+	if v.IsZero() || v.Len() != n {
+		*v = *mat.NewVecDense(n, nil)
+		return
+	}
+	v.Zero()
+}
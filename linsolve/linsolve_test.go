@@ -0,0 +1,418 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// laplace2D returns the n²×n² matrix of the five-point finite difference
+// discretization of the negative Laplacian on an n×n grid.
+func laplace2D(n int) *mat.CSR {
+	coo := mat.NewCOO(n*n, n*n, nil, nil, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			k := i*n + j
+			coo.Append(k, k, 4)
+			if i > 0 {
+				coo.Append(k, k-n, -1)
+			}
+			if i < n-1 {
+				coo.Append(k, k+n, -1)
+			}
+			if j > 0 {
+				coo.Append(k, k-1, -1)
+			}
+			if j < n-1 {
+				coo.Append(k, k+1, -1)
+			}
+		}
+	}
+	return coo.ToCSR()
+}
+
+// convDiff2D returns the n²×n² matrix of the upwind finite difference
+// discretization of a convection-diffusion operator on an n×n grid. The
+// matrix is nonsymmetric and diagonally dominant.
+func convDiff2D(n int, beta float64) *mat.CSR {
+	coo := mat.NewCOO(n*n, n*n, nil, nil, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			k := i*n + j
+			coo.Append(k, k, 4+2*beta)
+			if i > 0 {
+				coo.Append(k, k-n, -1-beta)
+			}
+			if i < n-1 {
+				coo.Append(k, k+n, -1)
+			}
+			if j > 0 {
+				coo.Append(k, k-1, -1-beta)
+			}
+			if j < n-1 {
+				coo.Append(k, k+1, -1)
+			}
+		}
+	}
+	return coo.ToCSR()
+}
+
+// shiftedLaplace2D returns laplace2D(n) - sigma*I which is symmetric
+// indefinite for sigma between the extreme eigenvalues of laplace2D(n).
+func shiftedLaplace2D(n int, sigma float64) *mat.CSR {
+	coo := mat.NewCOO(n*n, n*n, nil, nil, nil)
+	laplace2D(n).DoNonZero(func(i, j int, v float64) {
+		coo.Append(i, j, v)
+	})
+	for i := 0; i < n*n; i++ {
+		coo.Append(i, i, -sigma)
+	}
+	return coo.ToCSR()
+}
+
+type symCSR struct {
+	*mat.CSR
+}
+
+func (m symCSR) Symmetric() int {
+	r, _ := m.Dims()
+	return r
+}
+
+type symCOO struct {
+	*mat.COO
+}
+
+func (m symCOO) Symmetric() int {
+	r, _ := m.Dims()
+	return r
+}
+
+type testProblem struct {
+	name string
+	a    *mat.CSR
+	spd  bool
+	sym  bool
+}
+
+func testProblems() []testProblem {
+	return []testProblem{
+		{name: "laplace", a: laplace2D(8), spd: true, sym: true},
+		{name: "shifted laplace", a: shiftedLaplace2D(6, 1.5), sym: true},
+		{name: "convection-diffusion", a: convDiff2D(7, 0.8)},
+	}
+}
+
+func methods(p testProblem) []Method {
+	var ms []Method
+	if p.spd {
+		ms = append(ms, &CG{})
+	}
+	if p.sym {
+		ms = append(ms, &MINRES{})
+	}
+	if p.spd || !p.sym {
+		// Restarted GMRES may stagnate for indefinite systems.
+		ms = append(ms, &GMRES{Restart: 5})
+	}
+	return append(ms, &GMRES{}, &BiCGStab{})
+}
+
+func methodName(m Method) string {
+	if g, ok := m.(*GMRES); ok {
+		return fmt.Sprintf("GMRES(%d)", g.Restart)
+	}
+	return fmt.Sprintf("%T", m)
+}
+
+func preconditioners(t *testing.T, p testProblem) map[string]Preconditioner {
+	precs := map[string]Preconditioner{"none": nil}
+	j, err := NewJacobi(p.a)
+	if err != nil {
+		t.Fatalf("%s: unexpected error from NewJacobi: %v", p.name, err)
+	}
+	precs["Jacobi"] = j
+	if !p.sym || p.spd {
+		// SSOR and IC0 are positive definite only for positive definite
+		// matrices. The ILU(0) preconditioner of an indefinite matrix is
+		// not symmetric and so is unsuitable for MINRES.
+		ssor, err := NewSSOR(p.a, 1.2)
+		if err != nil {
+			t.Fatalf("%s: unexpected error from NewSSOR: %v", p.name, err)
+		}
+		precs["SSOR"] = ssor
+		ilu, err := NewILU0(p.a)
+		if err != nil {
+			t.Fatalf("%s: unexpected error from NewILU0: %v", p.name, err)
+		}
+		precs["ILU0"] = ilu
+	}
+	if p.spd {
+		ic, err := NewIC0(symCSR{p.a})
+		if err != nil {
+			t.Fatalf("%s: unexpected error from NewIC0: %v", p.name, err)
+		}
+		precs["IC0"] = ic
+	}
+	return precs
+}
+
+func TestIterative(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const tol = 1e-10
+	for _, p := range testProblems() {
+		n, _ := p.a.Dims()
+		want := mat.NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			want.SetVec(i, rnd.NormFloat64())
+		}
+		var b mat.VecDense
+		b.MulVec(p.a, want)
+
+		for pname, prec := range preconditioners(t, p) {
+			for _, m := range methods(p) {
+				for _, initX := range []bool{false, true} {
+					name := fmt.Sprintf("%s: %s with %s preconditioner, initX=%t", p.name, methodName(m), pname, initX)
+					settings := &Settings{
+						Tolerance:      tol,
+						MaxIterations:  10 * n,
+						Preconditioner: prec,
+					}
+					if initX {
+						x0 := mat.NewVecDense(n, nil)
+						for i := 0; i < n; i++ {
+							x0.SetVec(i, rnd.NormFloat64())
+						}
+						settings.InitX = x0
+					}
+					res, err := Iterative(p.a, &b, m, settings)
+					if err != nil {
+						t.Errorf("%s: unexpected error: %v", name, err)
+						continue
+					}
+
+					var r mat.VecDense
+					r.MulVec(p.a, res.X)
+					r.SubVec(&b, &r)
+					rnorm := mat.Norm(&r, 2)
+					bnorm := mat.Norm(&b, 2)
+					// The residual norm estimates of MINRES and GMRES may
+					// differ slightly from the true residual norm.
+					if rnorm > 10*tol*bnorm {
+						t.Errorf("%s: residual too large: |r|/|b|=%v", name, rnorm/bnorm)
+					}
+					if prec == nil && res.PreconSolve != 0 {
+						t.Errorf("%s: unexpected preconditioner solves: %d", name, res.PreconSolve)
+					}
+					if res.Iterations > settings.MaxIterations {
+						t.Errorf("%s: iteration limit exceeded: %d", name, res.Iterations)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestIterativeZeroRHS(t *testing.T) {
+	a := laplace2D(4)
+	n, _ := a.Dims()
+	b := mat.NewVecDense(n, nil)
+	x0 := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		x0.SetVec(i, float64(i+1))
+	}
+	res, err := Iterative(a, b, &CG{}, &Settings{InitX: x0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Iterations != 0 {
+		t.Errorf("unexpected number of iterations: got %d want 0", res.Iterations)
+	}
+	if !floats.Equal(res.X.RawVector().Data, make([]float64, n)) {
+		t.Errorf("unexpected solution for zero right-hand side: %v", res.X.RawVector().Data)
+	}
+}
+
+func TestIterativeIterationLimit(t *testing.T) {
+	a := laplace2D(10)
+	n, _ := a.Dims()
+	b := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		b.SetVec(i, 1)
+	}
+	const limit = 3
+	res, err := Iterative(a, b, &CG{}, &Settings{MaxIterations: limit})
+	if err != ErrIterationLimit {
+		t.Errorf("unexpected error: got %v want %v", err, ErrIterationLimit)
+	}
+	if res == nil || res.Iterations != limit {
+		t.Errorf("unexpected result: %+v", res)
+	}
+}
+
+func TestIC0NotPositiveDefinite(t *testing.T) {
+	_, err := NewIC0(symCSR{shiftedLaplace2D(4, 6)})
+	if err != ErrNotPositiveDefinite {
+		t.Errorf("unexpected error: got %v want %v", err, ErrNotPositiveDefinite)
+	}
+}
+
+func TestPreconditionerExact(t *testing.T) {
+	// For a tridiagonal matrix, IC(0) and ILU(0) are exact factorizations.
+	const n = 10
+	coo := mat.NewCOO(n, n, nil, nil, nil)
+	for i := 0; i < n; i++ {
+		coo.Append(i, i, 3)
+		if i > 0 {
+			coo.Append(i, i-1, -1)
+			coo.Append(i-1, i, -1)
+		}
+	}
+	a := coo.ToCSR()
+	b := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		b.SetVec(i, float64(i))
+	}
+	var want mat.VecDense
+	err := want.SolveVec(mat.DenseCopyOf(a), b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ic, err := NewIC0(symCSR{a})
+	if err != nil {
+		t.Fatalf("unexpected error from NewIC0: %v", err)
+	}
+	ilu, err := NewILU0(a)
+	if err != nil {
+		t.Fatalf("unexpected error from NewILU0: %v", err)
+	}
+	for _, p := range []Preconditioner{ic, ilu} {
+		var got mat.VecDense
+		got.CloneVec(b)
+		err = p.SolveVecTo(&got, b)
+		if err != nil {
+			t.Errorf("%T: unexpected error: %v", p, err)
+		}
+		if !mat.EqualApprox(&got, &want, 1e-12) {
+			t.Errorf("%T: unexpected solution:\ngot: %v\nwant:%v", p, got.RawVector().Data, want.RawVector().Data)
+		}
+	}
+}
+
+func TestPreconditionerDuplicates(t *testing.T) {
+	// Preconditioners constructed from a COO with duplicate entries must
+	// match those constructed from the equivalent compressed matrix.
+	const n = 5
+	a := laplace2D(n)
+	coo := mat.NewCOO(n*n, n*n, nil, nil, nil)
+	// Split each entry into two parts, appending the rows in reverse order
+	// so that the column indices within a row are not sorted.
+	for i := n*n - 1; i >= 0; i-- {
+		a.DoRowNonZero(i, func(i, j int, v float64) {
+			coo.Append(i, j, 0.25*v)
+		})
+		a.DoRowNonZero(i, func(i, j int, v float64) {
+			coo.Append(i, j, 0.75*v)
+		})
+	}
+	if coo.NNZ() != 2*a.NNZ() {
+		t.Fatalf("unexpected number of stored entries: got %d want %d", coo.NNZ(), 2*a.NNZ())
+	}
+
+	build := func(a mat.Matrix, sym mat.Symmetric) []Preconditioner {
+		j, err := NewJacobi(a)
+		if err != nil {
+			t.Fatalf("unexpected error from NewJacobi: %v", err)
+		}
+		ssor, err := NewSSOR(a, 1.2)
+		if err != nil {
+			t.Fatalf("unexpected error from NewSSOR: %v", err)
+		}
+		ic, err := NewIC0(sym)
+		if err != nil {
+			t.Fatalf("unexpected error from NewIC0: %v", err)
+		}
+		ilu, err := NewILU0(a)
+		if err != nil {
+			t.Fatalf("unexpected error from NewILU0: %v", err)
+		}
+		return []Preconditioner{j, ssor, ic, ilu}
+	}
+	want := build(a, symCSR{a})
+	got := build(coo, symCOO{coo})
+
+	b := mat.NewVecDense(n*n, nil)
+	for i := 0; i < n*n; i++ {
+		b.SetVec(i, float64(i%7)-3)
+	}
+	for k := range want {
+		var x, y mat.VecDense
+		x.CloneVec(b)
+		y.CloneVec(b)
+		if err := want[k].SolveVecTo(&x, b); err != nil {
+			t.Fatalf("%T: unexpected error: %v", want[k], err)
+		}
+		if err := got[k].SolveVecTo(&y, b); err != nil {
+			t.Fatalf("%T: unexpected error: %v", got[k], err)
+		}
+		if !mat.EqualApprox(&y, &x, 1e-14) {
+			t.Errorf("%T: unexpected solution with duplicate entries:\ngot: %v\nwant:%v",
+				got[k], y.RawVector().Data, x.RawVector().Data)
+		}
+	}
+}
+
+type recorder struct {
+	init bool
+	ops  []Operation
+}
+
+func (r *recorder) Init() error {
+	r.init = true
+	return nil
+}
+
+func (r *recorder) Record(_ *Context, op Operation, _ *Stats) error {
+	r.ops = append(r.ops, op)
+	return nil
+}
+
+func TestRecorder(t *testing.T) {
+	a := laplace2D(5)
+	n, _ := a.Dims()
+	b := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		b.SetVec(i, 1)
+	}
+	rec := &recorder{}
+	res, err := Iterative(a, b, &CG{}, &Settings{Recorder: rec})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !rec.init {
+		t.Errorf("Recorder not initialized")
+	}
+	if len(rec.ops) != res.Iterations+2 {
+		t.Fatalf("unexpected number of records: got %d want %d", len(rec.ops), res.Iterations+2)
+	}
+	if rec.ops[0] != InitIteration {
+		t.Errorf("unexpected first operation: got %v want %v", rec.ops[0], InitIteration)
+	}
+	if rec.ops[len(rec.ops)-1] != PostIteration {
+		t.Errorf("unexpected last operation: got %v want %v", rec.ops[len(rec.ops)-1], PostIteration)
+	}
+	for _, op := range rec.ops[1 : len(rec.ops)-1] {
+		if op != MajorIteration {
+			t.Errorf("unexpected operation: got %v want %v", op, MajorIteration)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// MINRES implements the Minimum Residual iterative method with
+// preconditioning for solving systems of linear equations
+//  A * x = b,
+// where A is a symmetric, possibly indefinite, matrix. The preconditioner
+// must be symmetric positive definite.
+//
+// The residual norm reported by MINRES is the estimate of |r|_{M^{-1}}, the
+// norm of the residual weighted by the inverse of the preconditioner M. When
+// no preconditioner is used, it is the Euclidean norm of the residual.
+//
+// References:
+//  - Paige, C. C., & Saunders, M. A. (1975). Solution of sparse indefinite
+//    systems of linear equations. SIAM Journal on Numerical Analysis,
+//    12(4), 617-629. https://doi.org/10.1137/0712047
+//  - Choi, S.-C. T. (2006). Iterative methods for singular linear equations
+//    and least-squares problems (Doctoral dissertation). Stanford University.
+type MINRES struct {
+	r1, r2, y, v mat.VecDense
+	w, w1, w2    mat.VecDense
+	beta, oldb   float64
+	dbar, epsln  float64
+	phibar       float64
+	cs, sn       float64
+	alfa         float64
+	iter, resume int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for
+// more details.
+func (m *MINRES) Init(x, r *mat.VecDense) {
+	n := r.Len()
+	reuse(&m.r1, n)
+	m.r1.CopyVec(r)
+	reuse(&m.r2, n)
+	m.r2.CopyVec(r)
+	reuse(&m.y, n)
+	reuse(&m.v, n)
+	reuse(&m.w, n)
+	reuse(&m.w1, n)
+	reuse(&m.w2, n)
+
+	m.oldb = 0
+	m.dbar = 0
+	m.epsln = 0
+	m.cs = -1
+	m.sn = 0
+	m.iter = 0
+	m.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface
+// for more details.
+//
+// MINRES will command the following operations:
+//  MulVec
+//  PreconSolve
+//  CheckResidualNorm
+//  MajorIteration
+func (m *MINRES) Iterate(ctx *Context) (Operation, error) {
+	switch m.resume {
+	case 1:
+		// Solve M y = r1.
+		ctx.Src = &m.r1
+		ctx.Dst = &m.y
+		m.resume = 2
+		return PreconSolve, nil
+	case 2:
+		beta1 := mat.Dot(&m.r1, &m.y)
+		if beta1 <= 0 {
+			if beta1 == 0 {
+				return NoOperation, ErrBreakdown
+			}
+			return NoOperation, ErrNotPositiveDefinite
+		}
+		m.beta = math.Sqrt(beta1)
+		m.phibar = m.beta
+		m.resume = 3
+		fallthrough
+	case 3:
+		// Compute the next Lanczos vector v and y = A v.
+		m.v.ScaleVec(1/m.beta, &m.y)
+		ctx.Src = &m.v
+		ctx.Dst = &m.y
+		m.resume = 4
+		return MulVec, nil
+	case 4:
+		if m.iter > 0 {
+			m.y.AddScaledVec(&m.y, -m.beta/m.oldb, &m.r1)
+		}
+		m.alfa = mat.Dot(&m.v, &m.y)
+		m.y.AddScaledVec(&m.y, -m.alfa/m.beta, &m.r2)
+		m.r1.CopyVec(&m.r2)
+		m.r2.CopyVec(&m.y)
+		// Solve M y = r2.
+		ctx.Src = &m.r2
+		ctx.Dst = &m.y
+		m.resume = 5
+		return PreconSolve, nil
+	case 5:
+		m.oldb = m.beta
+		beta := mat.Dot(&m.r2, &m.y)
+		if beta < 0 {
+			return NoOperation, ErrNotPositiveDefinite
+		}
+		m.beta = math.Sqrt(beta)
+
+		// Apply the previous rotation to obtain the new column of the
+		// tridiagonal matrix.
+		oldeps := m.epsln
+		delta := m.cs*m.dbar + m.sn*m.alfa
+		gbar := m.sn*m.dbar - m.cs*m.alfa
+		m.epsln = m.sn * m.beta
+		m.dbar = -m.cs * m.beta
+
+		// Compute the next plane rotation.
+		gamma := math.Hypot(gbar, m.beta)
+		if gamma == 0 {
+			return NoOperation, ErrBreakdown
+		}
+		m.cs = gbar / gamma
+		m.sn = m.beta / gamma
+		phi := m.cs * m.phibar
+		m.phibar *= m.sn
+
+		// Update the solution.
+		m.w1.CopyVec(&m.w2)
+		m.w2.CopyVec(&m.w)
+		m.w.AddScaledVec(&m.v, -oldeps, &m.w1)
+		m.w.AddScaledVec(&m.w, -delta, &m.w2)
+		m.w.ScaleVec(1/gamma, &m.w)
+		ctx.X.AddScaledVec(ctx.X, phi, &m.w)
+
+		m.iter++
+		ctx.ResidualNorm = math.Abs(m.phibar)
+		m.resume = 6
+		return CheckResidualNorm, nil
+	case 6:
+		if !ctx.Converged && m.beta == 0 {
+			// The Krylov subspace is exhausted.
+			return NoOperation, ErrBreakdown
+		}
+		m.resume = 3
+		return MajorIteration, nil
+	default:
+		panic("linsolve: MINRES.Init not called")
+	}
+}
@@ -0,0 +1,434 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	_ Preconditioner = (*Jacobi)(nil)
+	_ Preconditioner = (*SSOR)(nil)
+	_ Preconditioner = (*IC0)(nil)
+	_ Preconditioner = (*ILU0)(nil)
+)
+
+// ErrZeroPivot is returned when a preconditioner can not be constructed
+// because a zero pivot is encountered.
+var ErrZeroPivot = errors.New("linsolve: zero pivot")
+
+// csr is a square sparse matrix in compressed sparse row format with sorted
+// column indices.
+type csr struct {
+	n      int
+	indptr []int
+	ind    []int
+	data   []float64
+	// diag holds the index into ind and data of the diagonal
+	// element of each row, or -1 if it is not stored.
+	diag []int
+}
+
+// newCSR returns the non-zero elements of the square matrix a in compressed
+// sparse row format. If a implements mat.NonZeroDoer or mat.RowNonZeroDoer,
+// it is used to find the non-zero elements. Duplicate entries, as may be
+// stored by a mat.COO, are summed.
+func newCSR(a mat.Matrix) *csr {
+	n, c := a.Dims()
+	if n != c {
+		panic(mat.ErrSquare)
+	}
+	m := &csr{
+		n:      n,
+		indptr: make([]int, n+1),
+		diag:   make([]int, n),
+	}
+	switch a := a.(type) {
+	case mat.NonZeroDoer:
+		// Bucket the elements by row using two passes over the non-zero
+		// elements so that the cost is linear in their number.
+		a.DoNonZero(func(i, _ int, _ float64) {
+			m.indptr[i+1]++
+		})
+		for i := 0; i < n; i++ {
+			m.indptr[i+1] += m.indptr[i]
+		}
+		m.ind = make([]int, m.indptr[n])
+		m.data = make([]float64, m.indptr[n])
+		next := make([]int, n)
+		copy(next, m.indptr)
+		a.DoNonZero(func(i, j int, v float64) {
+			k := next[i]
+			m.ind[k] = j
+			m.data[k] = v
+			next[i]++
+		})
+	case mat.RowNonZeroDoer:
+		for i := 0; i < n; i++ {
+			a.DoRowNonZero(i, func(_, j int, v float64) {
+				m.ind = append(m.ind, j)
+				m.data = append(m.data, v)
+			})
+			m.indptr[i+1] = len(m.ind)
+		}
+	default:
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if v := a.At(i, j); v != 0 {
+					m.ind = append(m.ind, j)
+					m.data = append(m.data, v)
+				}
+			}
+			m.indptr[i+1] = len(m.ind)
+		}
+	}
+	m.compress()
+	return m
+}
+
+// compress sorts the column indices of each row of m, sums the values of
+// duplicate column indices and records the position of the diagonal elements.
+func (m *csr) compress() {
+	var w int
+	for i := 0; i < m.n; i++ {
+		start, end := m.indptr[i], m.indptr[i+1]
+		sortRow(m.ind[start:end], m.data[start:end])
+		m.indptr[i] = w
+		m.diag[i] = -1
+		for k := start; k < end; k++ {
+			if w > m.indptr[i] && m.ind[w-1] == m.ind[k] {
+				m.data[w-1] += m.data[k]
+				continue
+			}
+			if m.ind[k] == i {
+				m.diag[i] = w
+			}
+			m.ind[w] = m.ind[k]
+			m.data[w] = m.data[k]
+			w++
+		}
+	}
+	m.indptr[m.n] = w
+	m.ind = m.ind[:w]
+	m.data = m.data[:w]
+}
+
+// sortRow sorts the column indices of a row along with their values using
+// insertion sort. Rows are expected to be short and usually already sorted.
+func sortRow(ind []int, data []float64) {
+	for i := 1; i < len(ind); i++ {
+		for j := i; j > 0 && ind[j-1] > ind[j]; j-- {
+			ind[j-1], ind[j] = ind[j], ind[j-1]
+			data[j-1], data[j] = data[j], data[j-1]
+		}
+	}
+}
+
+// vecData returns the elements of b as a slice.
+func vecData(b mat.Vector) []float64 {
+	n := b.Len()
+	if rv, ok := b.(mat.RawVectorer); ok {
+		raw := rv.RawVector()
+		if raw.Inc == 1 {
+			return raw.Data[:n]
+		}
+	}
+	data := make([]float64, n)
+	for i := range data {
+		data[i] = b.AtVec(i)
+	}
+	return data
+}
+
+// Jacobi is the Jacobi or diagonal preconditioner
+//  M = diag(A).
+type Jacobi struct {
+	inv []float64
+}
+
+// NewJacobi returns a new Jacobi preconditioner for the square matrix a.
+// NewJacobi returns ErrZeroPivot if a diagonal element of a is zero.
+func NewJacobi(a mat.Matrix) (*Jacobi, error) {
+	n, c := a.Dims()
+	if n != c {
+		panic(mat.ErrSquare)
+	}
+	inv := make([]float64, n)
+	if nz, ok := a.(mat.NonZeroDoer); ok {
+		// Sum the diagonal in a single pass since At may be
+		// expensive for sparse matrices.
+		nz.DoNonZero(func(i, j int, v float64) {
+			if i == j {
+				inv[i] += v
+			}
+		})
+	} else {
+		for i := range inv {
+			inv[i] = a.At(i, i)
+		}
+	}
+	for i, d := range inv {
+		if d == 0 {
+			return nil, ErrZeroPivot
+		}
+		inv[i] = 1 / d
+	}
+	return &Jacobi{inv: inv}, nil
+}
+
+// SolveVecTo solves M * x = b and stores the result into dst.
+func (p *Jacobi) SolveVecTo(dst *mat.VecDense, b mat.Vector) error {
+	if b.Len() != len(p.inv) {
+		panic(mat.ErrShape)
+	}
+	dst.MulElemVec(mat.NewVecDense(len(p.inv), p.inv), b)
+	return nil
+}
+
+// SSOR is the symmetric successive over-relaxation preconditioner
+//  M = ω/(2-ω) * (D/ω + L) * (D/ω)^{-1} * (D/ω + U),
+// where D, L and U are the diagonal, strictly lower and strictly upper
+// triangular parts of A, and 0 < ω < 2 is the relaxation parameter. For
+// a symmetric positive definite matrix A, M is symmetric positive definite.
+type SSOR struct {
+	a     *csr
+	omega float64
+	work  []float64
+}
+
+// NewSSOR returns a new SSOR preconditioner for the square matrix a with the
+// relaxation parameter omega. NewSSOR will panic if omega is not in the
+// interval (0, 2). NewSSOR returns ErrZeroPivot if a diagonal element of a
+// is zero.
+func NewSSOR(a mat.Matrix, omega float64) (*SSOR, error) {
+	if omega <= 0 || 2 <= omega {
+		panic("linsolve: SSOR relaxation parameter out of range")
+	}
+	m := newCSR(a)
+	for _, k := range m.diag {
+		if k < 0 || m.data[k] == 0 {
+			return nil, ErrZeroPivot
+		}
+	}
+	return &SSOR{a: m, omega: omega, work: make([]float64, m.n)}, nil
+}
+
+// SolveVecTo solves M * x = b and stores the result into dst.
+func (p *SSOR) SolveVecTo(dst *mat.VecDense, b mat.Vector) error {
+	a := p.a
+	if b.Len() != a.n {
+		panic(mat.ErrShape)
+	}
+	bs := vecData(b)
+	y := p.work
+	w := p.omega
+
+	// Solve (D/ω + L) y = b.
+	for i := 0; i < a.n; i++ {
+		sum := bs[i]
+		for k := a.indptr[i]; k < a.diag[i]; k++ {
+			sum -= a.data[k] * y[a.ind[k]]
+		}
+		y[i] = sum * w / a.data[a.diag[i]]
+	}
+	// Compute y = (2-ω)/ω * (D/ω) y.
+	for i := 0; i < a.n; i++ {
+		y[i] *= (2 - w) / (w * w) * a.data[a.diag[i]]
+	}
+	// Solve (D/ω + U) x = y.
+	for i := a.n - 1; i >= 0; i-- {
+		sum := y[i]
+		for k := a.diag[i] + 1; k < a.indptr[i+1]; k++ {
+			sum -= a.data[k] * y[a.ind[k]]
+		}
+		y[i] = sum * w / a.data[a.diag[i]]
+	}
+	dst.CopyVec(mat.NewVecDense(a.n, y))
+	return nil
+}
+
+// IC0 is the zero fill-in incomplete Cholesky preconditioner
+//  M = L * L^T,
+// where L is a lower triangular matrix with the same sparsity pattern as
+// the lower triangle of a symmetric positive definite matrix A.
+type IC0 struct {
+	l    *csr
+	work []float64
+}
+
+// NewIC0 returns a new incomplete Cholesky preconditioner for the symmetric
+// matrix a. Only the lower triangle of a is referenced. NewIC0 returns
+// ErrNotPositiveDefinite if a non-positive pivot is encountered during the
+// factorization, which may happen even if a is positive definite.
+func NewIC0(a mat.Symmetric) (*IC0, error) {
+	full := newCSR(a)
+	n := full.n
+	// Extract the lower triangle.
+	l := &csr{
+		n:      n,
+		indptr: make([]int, n+1),
+		diag:   make([]int, n),
+	}
+	for i := 0; i < n; i++ {
+		for k := full.indptr[i]; k < full.indptr[i+1]; k++ {
+			if j := full.ind[k]; j <= i {
+				l.ind = append(l.ind, j)
+				l.data = append(l.data, full.data[k])
+			}
+		}
+		l.indptr[i+1] = len(l.ind)
+		l.diag[i] = -1
+		if l.indptr[i+1] > l.indptr[i] && l.ind[l.indptr[i+1]-1] == i {
+			l.diag[i] = l.indptr[i+1] - 1
+		} else {
+			return nil, ErrNotPositiveDefinite
+		}
+	}
+
+	// Compute the factorization row by row. For each element l_ij in the
+	// pattern, l_ij = (a_ij - Σ_{k<j} l_ik*l_jk) / l_jj.
+	for i := 0; i < n; i++ {
+		for p := l.indptr[i]; p <= l.diag[i]; p++ {
+			j := l.ind[p]
+			// Sparse dot product of the rows i and j of L over
+			// the columns less than j.
+			sum := l.data[p]
+			pi, pj := l.indptr[i], l.indptr[j]
+			for pi < p && pj < l.diag[j] {
+				switch ci, cj := l.ind[pi], l.ind[pj]; {
+				case ci == cj:
+					sum -= l.data[pi] * l.data[pj]
+					pi++
+					pj++
+				case ci < cj:
+					pi++
+				default:
+					pj++
+				}
+			}
+			if j == i {
+				if sum <= 0 {
+					return nil, ErrNotPositiveDefinite
+				}
+				l.data[p] = math.Sqrt(sum)
+			} else {
+				l.data[p] = sum / l.data[l.diag[j]]
+			}
+		}
+	}
+	return &IC0{l: l, work: make([]float64, n)}, nil
+}
+
+// SolveVecTo solves M * x = b and stores the result into dst.
+func (p *IC0) SolveVecTo(dst *mat.VecDense, b mat.Vector) error {
+	l := p.l
+	if b.Len() != l.n {
+		panic(mat.ErrShape)
+	}
+	y := p.work
+	copy(y, vecData(b))
+	// Solve L y = b.
+	for i := 0; i < l.n; i++ {
+		sum := y[i]
+		for k := l.indptr[i]; k < l.diag[i]; k++ {
+			sum -= l.data[k] * y[l.ind[k]]
+		}
+		y[i] = sum / l.data[l.diag[i]]
+	}
+	// Solve L^T x = y by columns of L^T.
+	for i := l.n - 1; i >= 0; i-- {
+		y[i] /= l.data[l.diag[i]]
+		for k := l.indptr[i]; k < l.diag[i]; k++ {
+			y[l.ind[k]] -= l.data[k] * y[i]
+		}
+	}
+	dst.CopyVec(mat.NewVecDense(l.n, y))
+	return nil
+}
+
+// ILU0 is the zero fill-in incomplete LU preconditioner
+//  M = L * U,
+// where L is a unit lower triangular matrix and U is an upper triangular
+// matrix that have together the same sparsity pattern as A.
+type ILU0 struct {
+	lu   *csr
+	work []float64
+}
+
+// NewILU0 returns a new incomplete LU preconditioner for the square matrix a.
+// NewILU0 returns ErrZeroPivot if a zero pivot is encountered during the
+// factorization.
+func NewILU0(a mat.Matrix) (*ILU0, error) {
+	lu := newCSR(a)
+	n := lu.n
+	for _, k := range lu.diag {
+		if k < 0 {
+			return nil, ErrZeroPivot
+		}
+	}
+	// pos maps the column indices of the current row to
+	// their positions in the storage.
+	pos := make([]int, n)
+	for i := range pos {
+		pos[i] = -1
+	}
+	for i := 0; i < n; i++ {
+		for k := lu.indptr[i]; k < lu.indptr[i+1]; k++ {
+			pos[lu.ind[k]] = k
+		}
+		for k := lu.indptr[i]; k < lu.diag[i]; k++ {
+			j := lu.ind[k]
+			ujj := lu.data[lu.diag[j]]
+			if ujj == 0 {
+				return nil, ErrZeroPivot
+			}
+			lu.data[k] /= ujj
+			lij := lu.data[k]
+			for kk := lu.diag[j] + 1; kk < lu.indptr[j+1]; kk++ {
+				if p := pos[lu.ind[kk]]; p >= 0 {
+					lu.data[p] -= lij * lu.data[kk]
+				}
+			}
+		}
+		for k := lu.indptr[i]; k < lu.indptr[i+1]; k++ {
+			pos[lu.ind[k]] = -1
+		}
+		if lu.data[lu.diag[i]] == 0 {
+			return nil, ErrZeroPivot
+		}
+	}
+	return &ILU0{lu: lu, work: make([]float64, n)}, nil
+}
+
+// SolveVecTo solves M * x = b and stores the result into dst.
+func (p *ILU0) SolveVecTo(dst *mat.VecDense, b mat.Vector) error {
+	lu := p.lu
+	if b.Len() != lu.n {
+		panic(mat.ErrShape)
+	}
+	y := p.work
+	copy(y, vecData(b))
+	// Solve L y = b.
+	for i := 0; i < lu.n; i++ {
+		sum := y[i]
+		for k := lu.indptr[i]; k < lu.diag[i]; k++ {
+			sum -= lu.data[k] * y[lu.ind[k]]
+		}
+		y[i] = sum
+	}
+	// Solve U x = y.
+	for i := lu.n - 1; i >= 0; i-- {
+		sum := y[i]
+		for k := lu.diag[i] + 1; k < lu.indptr[i+1]; k++ {
+			sum -= lu.data[k] * y[lu.ind[k]]
+		}
+		y[i] = sum / lu.data[lu.diag[i]]
+	}
+	dst.CopyVec(mat.NewVecDense(lu.n, y))
+	return nil
+}
@@ -166,6 +166,27 @@ func (b *BandDense) Dims() (r, c int) {
 	return b.mat.Rows, b.mat.Cols
 }
 
+// IsZero returns whether the receiver is zero-sized. Zero-sized matrices can be the
+// receiver for size-restricted operations. BandDense matrices can be zeroed using Reset.
+func (b *BandDense) IsZero() bool {
+	// It must be the case that b.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return b.mat.Stride == 0
+}
+
+// Reset zeros the dimensions of the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (b *BandDense) Reset() {
+	b.mat.Rows = 0
+	b.mat.Cols = 0
+	b.mat.KL = 0
+	b.mat.KU = 0
+	b.mat.Stride = 0
+	b.mat.Data = b.mat.Data[:0]
+}
+
 // Bandwidth returns the upper and lower bandwidths of the matrix.
 func (b *BandDense) Bandwidth() (kl, ku int) {
 	return b.mat.KL, b.mat.KU
@@ -0,0 +1,297 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badBandCholesky = "mat: invalid band Cholesky factorization"
+
+var (
+	_ Matrix    = (*BandCholesky)(nil)
+	_ Symmetric = (*BandCholesky)(nil)
+	_ Banded    = (*BandCholesky)(nil)
+	_ SymBanded = (*BandCholesky)(nil)
+)
+
+// BandCholesky is a symmetric positive definite band matrix represented by
+// its Cholesky decomposition
+//  A = U^T * U
+// where U is an upper triangular band matrix with the same bandwidth as A.
+// The factorization is computed and stored in band form, so factorizing and
+// solving with an n×n matrix with bandwidth k cost O(n*k^2) and O(n*k)
+// operations per right-hand side, respectively.
+//
+// Note that this matrix representation is useful for certain operations, in
+// particular finding solutions to linear equations. It is very inefficient
+// at other operations, in particular At is slow.
+//
+// BandCholesky methods may only be called on a value that has been
+// successfully initialized by a call to Factorize that has returned true.
+// Calls to methods of an unsuccessful BandCholesky factorization will panic.
+type BandCholesky struct {
+	// The chol pointer must never be retained as a pointer outside the
+	// BandCholesky struct, either by returning chol outside the struct or
+	// by setting it to a pointer coming from outside. The same prohibition
+	// applies to the data slice within chol.
+	chol *TriBandDense
+	cond float64
+}
+
+// Factorize calculates the Cholesky decomposition of the symmetric band
+// matrix A and returns whether the matrix is positive definite. If Factorize
+// returns false, the factorization must not be used.
+func (ch *BandCholesky) Factorize(a SymBanded) (ok bool) {
+	n, k := a.SymBand()
+	if ch.chol == nil {
+		ch.chol = NewTriBandDense(n, k, Upper, nil)
+	} else {
+		ch.chol = NewTriBandDense(n, k, Upper, use(ch.chol.mat.Data, n*(k+1)))
+	}
+	copySymBandIntoTriBand(ch.chol, a)
+
+	sym := ch.chol.asSymBandBlas()
+	work := getFloats(n, false)
+	norm := lapack64.Lansb(CondNorm, sym, work)
+	putFloats(work)
+	_, ok = lapack64.Pbtrf(sym)
+	if !ok {
+		ch.Reset()
+		return false
+	}
+	work = getFloats(2*n, false)
+	iwork := getInts(n, false)
+	v := lapack64.Pbcon(ch.chol.mat, norm, work, iwork)
+	putFloats(work)
+	putInts(iwork)
+	ch.cond = 1 / v
+	return true
+}
+
+// copySymBandIntoTriBand copies the upper triangle of the symmetric band
+// matrix a into the band of the upper triangular band matrix dst. The
+// bandwidth of dst must be equal to that of a.
+func copySymBandIntoTriBand(dst *TriBandDense, a SymBanded) {
+	n, k := a.SymBand()
+	if rs, ok := a.(RawSymBander); ok {
+		src := rs.RawSymBand()
+		if src.Uplo == blas.Upper {
+			for i := 0; i < n; i++ {
+				m := min(k+1, n-i)
+				copy(dst.mat.Data[i*dst.mat.Stride:i*dst.mat.Stride+m], src.Data[i*src.Stride:i*src.Stride+m])
+			}
+			return
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j <= min(n-1, i+k); j++ {
+			dst.mat.Data[i*dst.mat.Stride+j-i] = a.At(i, j)
+		}
+	}
+}
+
+// asSymBandBlas returns the receiver as a blas64.SymmetricBand. The receiver
+// must be upper triangular.
+func (t *TriBandDense) asSymBandBlas() blas64.SymmetricBand {
+	return blas64.SymmetricBand{
+		N:      t.mat.N,
+		K:      t.mat.K,
+		Stride: t.mat.Stride,
+		Data:   t.mat.Data,
+		Uplo:   t.mat.Uplo,
+	}
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of
+// a dimensionally restricted operation.
+func (ch *BandCholesky) Reset() {
+	if ch.chol != nil {
+		ch.chol.Reset()
+	}
+	ch.cond = math.Inf(1)
+}
+
+func (ch *BandCholesky) valid() bool {
+	return ch.chol != nil && !ch.chol.IsZero()
+}
+
+// Dims returns the dimensions of the matrix.
+func (ch *BandCholesky) Dims() (r, c int) {
+	if !ch.valid() {
+		panic(badBandCholesky)
+	}
+	r, c = ch.chol.Dims()
+	return r, c
+}
+
+// At returns the element at row i, column j.
+func (ch *BandCholesky) At(i, j int) float64 {
+	if !ch.valid() {
+		panic(badBandCholesky)
+	}
+	n, k, _ := ch.chol.TriBand()
+	if uint(i) >= uint(n) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(n) {
+		panic(ErrColAccess)
+	}
+	if i > j {
+		i, j = j, i
+	}
+	if j-i > k {
+		return 0
+	}
+	var aij float64
+	for l := max(0, j-k); l <= i; l++ {
+		aij += ch.chol.at(l, i) * ch.chol.at(l, j)
+	}
+	return aij
+}
+
+// T returns the receiver, the transpose of a symmetric matrix.
+func (ch *BandCholesky) T() Matrix {
+	return ch
+}
+
+// TBand returns the receiver, the transpose of a symmetric band matrix.
+func (ch *BandCholesky) TBand() Banded {
+	return ch
+}
+
+// Symmetric implements the Symmetric interface and returns the number of rows
+// in the matrix (this is also the number of columns).
+func (ch *BandCholesky) Symmetric() int {
+	n, _ := ch.chol.Triangle()
+	return n
+}
+
+// Bandwidth returns the lower and upper bandwidth values for the matrix.
+// The total bandwidth of the matrix is kl+ku+1.
+func (ch *BandCholesky) Bandwidth() (kl, ku int) {
+	_, k, _ := ch.chol.TriBand()
+	return k, k
+}
+
+// SymBand returns the number of rows/columns in the matrix, and the size of
+// the bandwidth.
+func (ch *BandCholesky) SymBand() (n, k int) {
+	n, k, _ = ch.chol.TriBand()
+	return n, k
+}
+
+// Cond returns the condition number of the factorized matrix.
+func (ch *BandCholesky) Cond() float64 {
+	if !ch.valid() {
+		panic(badBandCholesky)
+	}
+	return ch.cond
+}
+
+// Det returns the determinant of the matrix that has been factorized.
+func (ch *BandCholesky) Det() float64 {
+	if !ch.valid() {
+		panic(badBandCholesky)
+	}
+	return math.Exp(ch.LogDet())
+}
+
+// LogDet returns the log of the determinant of the matrix that has been factorized.
+func (ch *BandCholesky) LogDet() float64 {
+	if !ch.valid() {
+		panic(badBandCholesky)
+	}
+	var det float64
+	for i := 0; i < ch.chol.mat.N; i++ {
+		det += 2 * math.Log(ch.chol.mat.Data[i*ch.chol.mat.Stride])
+	}
+	return det
+}
+
+// UTo extracts the n×n upper triangular band matrix U from a BandCholesky
+// decomposition into dst and returns the result. If dst is nil a new
+// TriBandDense is allocated.
+//  A = U^T * U.
+func (ch *BandCholesky) UTo(dst *TriBandDense) *TriBandDense {
+	if !ch.valid() {
+		panic(badBandCholesky)
+	}
+	n, k, _ := ch.chol.TriBand()
+	if dst == nil {
+		dst = NewTriBandDense(n, k, Upper, nil)
+	} else if dst.IsZero() {
+		*dst = *NewTriBandDense(n, k, Upper, use(dst.mat.Data, n*(k+1)))
+	} else if dn, dk, kind := dst.TriBand(); dn != n || dk != k || kind != Upper {
+		panic(ErrShape)
+	}
+	copy(dst.mat.Data, ch.chol.mat.Data)
+	return dst
+}
+
+// SolveTo finds the matrix X that solves A * X = B where A is represented
+// by the band Cholesky decomposition. The result is stored in-place into dst.
+func (ch *BandCholesky) SolveTo(dst *Dense, b Matrix) error {
+	if !ch.valid() {
+		panic(badBandCholesky)
+	}
+	n := ch.chol.mat.N
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+
+	dst.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	var restore func()
+	if dst == bU {
+		dst, restore = dst.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		dst.checkOverlap(rm.RawMatrix())
+	}
+
+	dst.Copy(b)
+	lapack64.Pbtrs(ch.chol.mat, dst.mat)
+	if ch.cond > ConditionTolerance {
+		return Condition(ch.cond)
+	}
+	return nil
+}
+
+// SolveVecTo finds the vector x that solves A * x = b where A is represented
+// by the band Cholesky decomposition. The result is stored in-place into
+// dst.
+func (ch *BandCholesky) SolveVecTo(dst *VecDense, b Vector) error {
+	if !ch.valid() {
+		panic(badBandCholesky)
+	}
+	n := ch.chol.mat.N
+	if br, bc := b.Dims(); br != n || bc != 1 {
+		panic(ErrShape)
+	}
+	switch rv := b.(type) {
+	default:
+		dst.reuseAs(n)
+		return ch.SolveTo(dst.asDense(), b)
+	case RawVectorer:
+		if dst != b {
+			dst.checkOverlap(rv.RawVector())
+		}
+		dst.reuseAs(n)
+		if dst != b {
+			dst.CopyVec(b)
+		}
+		lapack64.Pbtrs(ch.chol.mat, dst.asGeneral())
+		if ch.cond > ConditionTolerance {
+			return Condition(ch.cond)
+		}
+		return nil
+	}
+}
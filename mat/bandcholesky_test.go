@@ -0,0 +1,128 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// randSPDBand returns a random n×n symmetric positive definite band matrix
+// with k super-diagonals.
+func randSPDBand(n, k int, rnd *rand.Rand) *SymBandDense {
+	a := NewSymBandDense(n, k, nil)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j <= min(n-1, i+k); j++ {
+			a.SetSymBand(i, j, rnd.NormFloat64())
+		}
+	}
+	for i := 0; i < n; i++ {
+		var sum float64
+		for j := max(0, i-k); j <= min(n-1, i+k); j++ {
+			if j != i {
+				sum += math.Abs(a.At(i, j))
+			}
+		}
+		a.SetSymBand(i, i, sum+rnd.Float64())
+	}
+	return a
+}
+
+func TestBandCholesky(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 50, 150} {
+		for _, k := range []int{0, 1, 3, 70} {
+			k := min(k, n-1)
+			a := randSPDBand(n, k, rnd)
+
+			var ch BandCholesky
+			ok := ch.Factorize(a)
+			if !ok {
+				t.Errorf("n=%d,k=%d: unexpected failure for positive definite matrix", n, k)
+				continue
+			}
+			if !EqualApprox(&ch, a, 1e-12) {
+				t.Errorf("n=%d,k=%d: reconstructed matrix does not equal original", n, k)
+			}
+
+			u := ch.UTo(nil)
+			var got Dense
+			got.Mul(u.T(), u)
+			if !EqualApprox(&got, a, 1e-12) {
+				t.Errorf("n=%d,k=%d: U^T*U does not equal original matrix", n, k)
+			}
+
+			var chol Cholesky
+			chol.Factorize(a)
+			if !floats.EqualWithinAbsOrRel(ch.LogDet(), chol.LogDet(), 1e-10, 1e-10) {
+				t.Errorf("n=%d,k=%d: unexpected log determinant: got %v, want %v", n, k, ch.LogDet(), chol.LogDet())
+			}
+
+			// Compute the exact condition number. The 1-norm and the ∞-norm
+			// of a symmetric matrix are equal.
+			var inv Dense
+			inv.Inverse(a)
+			wantCond := Norm(a, 1) * Norm(&inv, 1)
+			cond := ch.Cond()
+			if cond > wantCond*(1+1e-10) || cond < wantCond/10 {
+				t.Errorf("n=%d,k=%d: unexpected condition number: got %v, want %v", n, k, cond, wantCond)
+			}
+
+			for _, bc := range []int{1, 3} {
+				want := randNormDense(n, bc, rnd)
+				var b Dense
+				b.Mul(a, want)
+				var x Dense
+				err := ch.SolveTo(&x, &b)
+				if err != nil {
+					t.Errorf("n=%d,k=%d,bc=%d: unexpected error: %v", n, k, bc, err)
+					continue
+				}
+				if !EqualApprox(&x, want, 1e-12) {
+					t.Errorf("n=%d,k=%d,bc=%d: unexpected solution", n, k, bc)
+				}
+
+				// Test in-place solve.
+				err = ch.SolveTo(&b, &b)
+				if err != nil {
+					t.Errorf("n=%d,k=%d,bc=%d: unexpected error for in-place solve: %v", n, k, bc, err)
+					continue
+				}
+				if !Equal(&b, &x) {
+					t.Errorf("n=%d,k=%d,bc=%d: mismatch between in-place and out-of-place solve", n, k, bc)
+				}
+			}
+
+			want := randNormVec(n, rnd)
+			var b VecDense
+			b.MulVec(a, want)
+			var x VecDense
+			err := ch.SolveVecTo(&x, &b)
+			if err != nil {
+				t.Errorf("n=%d,k=%d: unexpected error for vector solve: %v", n, k, err)
+				continue
+			}
+			if !EqualApprox(&x, want, 1e-12) {
+				t.Errorf("n=%d,k=%d: unexpected vector solution", n, k)
+			}
+		}
+	}
+}
+
+func TestBandCholeskyNotPD(t *testing.T) {
+	a := NewSymBandDense(3, 1, []float64{
+		1, 2,
+		1, 0,
+		1, 0,
+	})
+	var ch BandCholesky
+	if ch.Factorize(a) {
+		t.Errorf("unexpected success for indefinite matrix")
+	}
+}
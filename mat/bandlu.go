@@ -0,0 +1,243 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badBandLU = "mat: invalid band LU factorization"
+
+// BandLU is a type for creating and using the LU factorization of a square
+// band matrix. The factorization has the form
+//  A = P * L * U
+// where P is a permutation matrix, L is unit lower triangular with at most kl
+// non-zero elements below the diagonal in each column, and U is upper
+// triangular with kl+ku super-diagonals. The factorization is computed and
+// stored in band form, so factorizing and solving with an n×n matrix with
+// bandwidths kl and ku cost O(n*kl*(kl+ku)) and O(n*(2*kl+ku)) operations per
+// right-hand side, respectively.
+type BandLU struct {
+	// lu holds the factorization in the band storage used by
+	// lapack64.Gbtrf. The stride accommodates kl additional
+	// super-diagonals for the fill-in of U caused by pivoting.
+	lu    blas64.Band
+	pivot []int
+	ok    bool
+	cond  float64
+}
+
+// Factorize computes the LU factorization of the square band matrix a and
+// stores the result. The factorization will complete regardless of the
+// singularity of a.
+func (lu *BandLU) Factorize(a Banded) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	n := r
+	kl, ku := a.Bandwidth()
+	kl = min(kl, n-1)
+	ku = min(ku, n-1)
+	stride := 2*kl + ku + 1
+	lu.lu = blas64.Band{
+		Rows:   n,
+		Cols:   n,
+		KL:     kl,
+		KU:     ku,
+		Stride: stride,
+		Data:   use(lu.lu.Data, n*stride),
+	}
+	zero(lu.lu.Data)
+	copyBandInto(lu.lu, a)
+	lu.pivot = useInt(lu.pivot, n)
+
+	ab := lu.lu
+	work := getFloats(n, false)
+	anorm := lapack64.Langb(CondNorm, ab, work)
+	putFloats(work)
+	lu.ok = lapack64.Gbtrf(ab, lu.pivot)
+	if !lu.ok {
+		lu.cond = math.Inf(1)
+		return
+	}
+	work = getFloats(2*n, false)
+	iwork := getInts(n, false)
+	v := lapack64.Gbcon(CondNorm, ab, lu.pivot, anorm, work, iwork)
+	putFloats(work)
+	putInts(iwork)
+	lu.cond = 1 / v
+}
+
+// copyBandInto copies the elements of the band matrix a into the band of dst.
+// The bandwidths of dst must not be smaller than those of a.
+func copyBandInto(dst blas64.Band, a Banded) {
+	r, c := a.Dims()
+	kl, ku := a.Bandwidth()
+	aU, trans := untranspose(a)
+	if rb, ok := aU.(RawBander); ok && !trans {
+		src := rb.RawBand()
+		for i := 0; i < min(r, c+kl); i++ {
+			jl := max(0, i-kl)
+			ju := min(c-1, i+ku)
+			copy(dst.Data[i*dst.Stride+dst.KL+jl-i:i*dst.Stride+dst.KL+ju-i+1],
+				src.Data[i*src.Stride+src.KL+jl-i:i*src.Stride+src.KL+ju-i+1])
+		}
+		return
+	}
+	for i := 0; i < min(r, c+kl); i++ {
+		for j := max(0, i-kl); j <= min(c-1, i+ku); j++ {
+			dst.Data[i*dst.Stride+dst.KL+j-i] = a.At(i, j)
+		}
+	}
+}
+
+// isValid returns whether the receiver contains a factorization.
+func (lu *BandLU) isValid() bool {
+	return len(lu.pivot) != 0
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (lu *BandLU) Reset() {
+	lu.pivot = lu.pivot[:0]
+	lu.ok = false
+	lu.cond = math.Inf(1)
+}
+
+// Cond returns the condition number for the factorized matrix.
+// Cond will panic if the receiver does not contain a factorization.
+func (lu *BandLU) Cond() float64 {
+	if !lu.isValid() {
+		panic(badBandLU)
+	}
+	return lu.cond
+}
+
+// Det returns the determinant of the matrix that has been factorized. In many
+// expressions, using LogDet will be more numerically stable.
+// Det will panic if the receiver does not contain a factorization.
+func (lu *BandLU) Det() float64 {
+	det, sign := lu.LogDet()
+	return math.Exp(det) * sign
+}
+
+// LogDet returns the log of the determinant and the sign of the determinant
+// for the matrix that has been factorized. Numerical stability in product and
+// division expressions is generally improved by working in log space.
+// LogDet will panic if the receiver does not contain a factorization.
+func (lu *BandLU) LogDet() (det float64, sign float64) {
+	if !lu.isValid() {
+		panic(badBandLU)
+	}
+	ab := lu.lu
+	sign = 1
+	for i, p := range lu.pivot {
+		v := ab.Data[i*ab.Stride+ab.KL]
+		if v < 0 {
+			sign *= -1
+		}
+		if p != i {
+			sign *= -1
+		}
+		det += math.Log(math.Abs(v))
+	}
+	return det, sign
+}
+
+// SolveTo solves a system of linear equations using the LU factorization of a
+// band matrix. It computes
+//  A * X = B if trans == false
+//  A^T * X = B if trans == true
+// In both cases, A is represented in LU factorized form, and the matrix X is
+// stored into dst.
+//
+// If A is singular or near-singular a Condition error is returned. See
+// the documentation for Condition for more information.
+// SolveTo will panic if the receiver does not contain a factorization.
+func (lu *BandLU) SolveTo(dst *Dense, trans bool, b Matrix) error {
+	if !lu.isValid() {
+		panic(badBandLU)
+	}
+	n := len(lu.pivot)
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	if !lu.ok {
+		return Condition(math.Inf(1))
+	}
+
+	dst.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	var restore func()
+	if dst == bU {
+		dst, restore = dst.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		dst.checkOverlap(rm.RawMatrix())
+	}
+
+	dst.Copy(b)
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	lapack64.Gbtrs(t, lu.lu, lu.pivot, dst.mat)
+	if lu.cond > ConditionTolerance {
+		return Condition(lu.cond)
+	}
+	return nil
+}
+
+// SolveVecTo solves a system of linear equations using the LU factorization
+// of a band matrix. It computes
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// In both cases, A is represented in LU factorized form, and the vector x is
+// stored into dst.
+//
+// If A is singular or near-singular a Condition error is returned. See
+// the documentation for Condition for more information.
+// SolveVecTo will panic if the receiver does not contain a factorization.
+func (lu *BandLU) SolveVecTo(dst *VecDense, trans bool, b Vector) error {
+	if !lu.isValid() {
+		panic(badBandLU)
+	}
+	n := len(lu.pivot)
+	if br, bc := b.Dims(); br != n || bc != 1 {
+		panic(ErrShape)
+	}
+	switch rv := b.(type) {
+	default:
+		dst.reuseAs(n)
+		return lu.SolveTo(dst.asDense(), trans, b)
+	case RawVectorer:
+		if dst != b {
+			dst.checkOverlap(rv.RawVector())
+		}
+		if !lu.ok {
+			return Condition(math.Inf(1))
+		}
+
+		dst.reuseAs(n)
+		if dst != b {
+			dst.CopyVec(b)
+		}
+		t := blas.NoTrans
+		if trans {
+			t = blas.Trans
+		}
+		lapack64.Gbtrs(t, lu.lu, lu.pivot, dst.asGeneral())
+		if lu.cond > ConditionTolerance {
+			return Condition(lu.cond)
+		}
+		return nil
+	}
+}
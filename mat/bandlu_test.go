@@ -0,0 +1,139 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// randBandDense returns a random n×n band matrix with kl sub-diagonals and
+// ku super-diagonals.
+func randBandDense(n, kl, ku int, rnd *rand.Rand) *BandDense {
+	b := NewBandDense(n, n, kl, ku, nil)
+	for i := 0; i < n; i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			b.SetBand(i, j, rnd.NormFloat64())
+		}
+	}
+	return b
+}
+
+func TestBandLU(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 50} {
+		for _, kl := range []int{0, 1, 2, 5} {
+			for _, ku := range []int{0, 1, 3} {
+				kl := min(kl, n-1)
+				ku := min(ku, n-1)
+				a := randBandDense(n, kl, ku, rnd)
+				// Improve the conditioning of the matrix while keeping
+				// pivoting likely.
+				for i := 0; i < n; i++ {
+					a.SetBand(i, i, a.At(i, i)+math.Copysign(1, a.At(i, i)))
+				}
+
+				var lu BandLU
+				lu.Factorize(a)
+
+				var dlu LU
+				dlu.Factorize(a)
+				wantDet, wantSign := dlu.LogDet()
+				gotDet, gotSign := lu.LogDet()
+				if gotSign != wantSign || !floats.EqualWithinAbsOrRel(gotDet, wantDet, 1e-10, 1e-10) {
+					t.Errorf("n=%d,kl=%d,ku=%d: unexpected log determinant: got (%v,%v), want (%v,%v)",
+						n, kl, ku, gotDet, gotSign, wantDet, wantSign)
+				}
+
+				// The condition estimate must not exceed the true
+				// condition number and should be within a modest
+				// factor of it.
+				// Compute the exact condition number in the ∞-norm.
+				var inv Dense
+				inv.Inverse(a)
+				want := Norm(a, math.Inf(1)) * Norm(&inv, math.Inf(1))
+				cond := lu.Cond()
+				if cond > want*(1+1e-10) || cond < want/10 {
+					t.Errorf("n=%d,kl=%d,ku=%d: unexpected condition number: got %v, want %v", n, kl, ku, cond, want)
+				}
+
+				for _, trans := range []bool{false, true} {
+					for _, bc := range []int{1, 3} {
+						want := randNormDense(n, bc, rnd)
+						var b Dense
+						if trans {
+							b.Mul(a.T(), want)
+						} else {
+							b.Mul(a, want)
+						}
+						var x Dense
+						err := lu.SolveTo(&x, trans, &b)
+						if err != nil {
+							t.Errorf("n=%d,kl=%d,ku=%d,trans=%t,bc=%d: unexpected error: %v", n, kl, ku, trans, bc, err)
+							continue
+						}
+						tol := 1e-14 * lu.Cond()
+						if !EqualApprox(&x, want, tol) {
+							t.Errorf("n=%d,kl=%d,ku=%d,trans=%t,bc=%d: unexpected solution", n, kl, ku, trans, bc)
+						}
+
+						// Test in-place solve.
+						err = lu.SolveTo(&b, trans, &b)
+						if err != nil {
+							t.Errorf("n=%d,kl=%d,ku=%d,trans=%t,bc=%d: unexpected error for in-place solve: %v", n, kl, ku, trans, bc, err)
+							continue
+						}
+						if !Equal(&b, &x) {
+							t.Errorf("n=%d,kl=%d,ku=%d,trans=%t,bc=%d: mismatch between in-place and out-of-place solve", n, kl, ku, trans, bc)
+						}
+					}
+
+					want := randNormVec(n, rnd)
+					var b VecDense
+					if trans {
+						b.MulVec(a.T(), want)
+					} else {
+						b.MulVec(a, want)
+					}
+					var x VecDense
+					err := lu.SolveVecTo(&x, trans, &b)
+					if err != nil {
+						t.Errorf("n=%d,kl=%d,ku=%d,trans=%t: unexpected error for vector solve: %v", n, kl, ku, trans, err)
+						continue
+					}
+					tol := 1e-14 * lu.Cond()
+					if !EqualApprox(&x, want, tol) {
+						t.Errorf("n=%d,kl=%d,ku=%d,trans=%t: unexpected vector solution", n, kl, ku, trans)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBandLUSingular(t *testing.T) {
+	a := NewBandDense(3, 3, 1, 1, []float64{
+		0, 1, 2,
+		2, 4, 0,
+		3, 6, 0,
+	})
+	var lu BandLU
+	lu.Factorize(a)
+	if det := lu.Det(); det != 0 {
+		t.Errorf("unexpected determinant of singular matrix: got %v, want 0", det)
+	}
+	if cond := lu.Cond(); !math.IsInf(cond, 1) {
+		t.Errorf("unexpected condition number of singular matrix: got %v, want +Inf", cond)
+	}
+	var x Dense
+	err := lu.SolveTo(&x, false, NewDense(3, 1, []float64{1, 2, 3}))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("unexpected error for singular matrix: got %v, want Condition", err)
+	}
+}
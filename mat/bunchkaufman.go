@@ -0,0 +1,338 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badBunchKaufman = "mat: invalid Bunch-Kaufman factorization"
+
+// BunchKaufman is a type for creating and using the Bunch-Kaufman
+// factorization of a symmetric, possibly indefinite, matrix. The
+// factorization has the form
+//  A = U * D * U^T
+// where U is a product of permutation and unit upper triangular matrices,
+// and D is symmetric and block diagonal with 1×1 and 2×2 diagonal blocks.
+//
+// Unlike the Cholesky decomposition, the Bunch-Kaufman factorization exists
+// for every symmetric matrix, and it preserves symmetry in contrast to the LU
+// decomposition, so it is suitable for solving indefinite symmetric systems
+// such as the saddle point systems arising in constrained optimization.
+type BunchKaufman struct {
+	fact *SymDense
+	ipiv []int
+	ok   bool
+	cond float64
+}
+
+// Factorize computes the Bunch-Kaufman factorization of the symmetric matrix
+// a. The factorization always exists even if a is singular.
+func (bk *BunchKaufman) Factorize(a Symmetric) {
+	n := a.Symmetric()
+	if bk.fact == nil {
+		bk.fact = NewSymDense(n, nil)
+	} else {
+		bk.fact = NewSymDense(n, use(bk.fact.mat.Data, n*n))
+	}
+	bk.fact.CopySym(a)
+	bk.ipiv = useInt(bk.ipiv, n)
+
+	sym := bk.fact.mat
+	work := getFloats(n, false)
+	anorm := lapack64.Lansy(CondNorm, sym, work)
+	putFloats(work)
+
+	work = []float64{0}
+	lapack64.Sytrf(sym, bk.ipiv, work, -1)
+	work = getFloats(int(work[0]), false)
+	bk.ok = lapack64.Sytrf(sym, bk.ipiv, work, len(work))
+	putFloats(work)
+
+	if !bk.ok {
+		bk.cond = math.Inf(1)
+		return
+	}
+	// The 1-norm and the ∞-norm of a symmetric matrix are equal.
+	work = getFloats(2*n, false)
+	iwork := getInts(n, false)
+	v := lapack64.Sycon(sym, bk.ipiv, anorm, work, iwork)
+	putFloats(work)
+	putInts(iwork)
+	bk.cond = 1 / v
+}
+
+// isValid returns whether the receiver contains a factorization.
+func (bk *BunchKaufman) isValid() bool {
+	return bk.fact != nil && !bk.fact.IsZero()
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (bk *BunchKaufman) Reset() {
+	if bk.fact != nil {
+		bk.fact.Reset()
+	}
+	bk.ipiv = bk.ipiv[:0]
+	bk.ok = false
+	bk.cond = math.Inf(1)
+}
+
+// Symmetric returns the number of rows and columns in the factorized matrix.
+// Symmetric will panic if the receiver does not contain a factorization.
+func (bk *BunchKaufman) Symmetric() int {
+	if !bk.isValid() {
+		panic(badBunchKaufman)
+	}
+	return bk.fact.mat.N
+}
+
+// Cond returns the condition number for the factorized matrix.
+// Cond will panic if the receiver does not contain a factorization.
+func (bk *BunchKaufman) Cond() float64 {
+	if !bk.isValid() {
+		panic(badBunchKaufman)
+	}
+	return bk.cond
+}
+
+// Det returns the determinant of the matrix that has been factorized. In many
+// expressions, using LogDet will be more numerically stable.
+// Det will panic if the receiver does not contain a factorization.
+func (bk *BunchKaufman) Det() float64 {
+	det, sign := bk.LogDet()
+	return math.Exp(det) * sign
+}
+
+// LogDet returns the log of the determinant and the sign of the determinant
+// for the matrix that has been factorized. Numerical stability in product and
+// division expressions is generally improved by working in log space.
+// LogDet will panic if the receiver does not contain a factorization.
+func (bk *BunchKaufman) LogDet() (det float64, sign float64) {
+	if !bk.isValid() {
+		panic(badBunchKaufman)
+	}
+	// The determinant of A is the determinant of D because U is a product
+	// of unit triangular and permutation matrices that appear in pairs.
+	sign = 1
+	a := bk.fact.mat
+	for k := 0; k < a.N; {
+		var v float64
+		if bk.ipiv[k] >= 0 {
+			v = a.Data[k*a.Stride+k]
+			k++
+		} else {
+			// 2×2 diagonal block in rows and columns k and k+1.
+			d11 := a.Data[k*a.Stride+k]
+			d12 := a.Data[k*a.Stride+k+1]
+			d22 := a.Data[(k+1)*a.Stride+k+1]
+			v = d11*d22 - d12*d12
+			k += 2
+		}
+		if v < 0 {
+			sign *= -1
+		}
+		det += math.Log(math.Abs(v))
+	}
+	return det, sign
+}
+
+// Inertia returns the inertia of the factorized matrix, that is the number of
+// positive, negative and zero eigenvalues. By Sylvester's law of inertia, the
+// inertia of A is equal to the inertia of the block diagonal matrix D, and it
+// is computed from the diagonal blocks of D. Only eigenvalues of D that are
+// exactly zero are counted as zero.
+// Inertia will panic if the receiver does not contain a factorization.
+func (bk *BunchKaufman) Inertia() (pos, neg, zero int) {
+	if !bk.isValid() {
+		panic(badBunchKaufman)
+	}
+	count := func(v float64) {
+		switch {
+		case v > 0:
+			pos++
+		case v < 0:
+			neg++
+		default:
+			zero++
+		}
+	}
+	a := bk.fact.mat
+	for k := 0; k < a.N; {
+		if bk.ipiv[k] >= 0 {
+			count(a.Data[k*a.Stride+k])
+			k++
+			continue
+		}
+		// The signs of the eigenvalues of the 2×2 diagonal block in rows
+		// and columns k and k+1 are determined by its determinant and
+		// trace.
+		d11 := a.Data[k*a.Stride+k]
+		d12 := a.Data[k*a.Stride+k+1]
+		d22 := a.Data[(k+1)*a.Stride+k+1]
+		det := d11*d22 - d12*d12
+		tr := d11 + d22
+		switch {
+		case det < 0:
+			pos++
+			neg++
+		case det > 0:
+			count(tr)
+			count(tr)
+		default:
+			zero++
+			count(tr)
+		}
+		k += 2
+	}
+	return pos, neg, zero
+}
+
+// SolveTo finds the matrix X that solves A * X = B where A is represented
+// by its Bunch-Kaufman factorization. The result is stored in-place into dst.
+//
+// If A is singular or near-singular a Condition error is returned. See
+// the documentation for Condition for more information.
+// SolveTo will panic if the receiver does not contain a factorization.
+func (bk *BunchKaufman) SolveTo(dst *Dense, b Matrix) error {
+	if !bk.isValid() {
+		panic(badBunchKaufman)
+	}
+	n := bk.fact.mat.N
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	if !bk.ok {
+		return Condition(math.Inf(1))
+	}
+
+	dst.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	var restore func()
+	if dst == bU {
+		dst, restore = dst.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		dst.checkOverlap(rm.RawMatrix())
+	}
+
+	dst.Copy(b)
+	lapack64.Sytrs(bk.fact.mat, bk.ipiv, dst.mat)
+	if bk.cond > ConditionTolerance {
+		return Condition(bk.cond)
+	}
+	return nil
+}
+
+// SolveVecTo finds the vector x that solves A * x = b where A is represented
+// by its Bunch-Kaufman factorization. The result is stored in-place into dst.
+//
+// If A is singular or near-singular a Condition error is returned. See
+// the documentation for Condition for more information.
+// SolveVecTo will panic if the receiver does not contain a factorization.
+func (bk *BunchKaufman) SolveVecTo(dst *VecDense, b Vector) error {
+	if !bk.isValid() {
+		panic(badBunchKaufman)
+	}
+	n := bk.fact.mat.N
+	if br, bc := b.Dims(); br != n || bc != 1 {
+		panic(ErrShape)
+	}
+	if rv, ok := b.(RawVectorer); ok && dst != b {
+		dst.checkOverlap(rv.RawVector())
+	}
+	if !bk.ok {
+		return Condition(math.Inf(1))
+	}
+
+	dst.reuseAs(n)
+	if dst != b {
+		dst.CopyVec(b)
+	}
+	lapack64.Sytrs(bk.fact.mat, bk.ipiv, dst.asGeneral())
+	if bk.cond > ConditionTolerance {
+		return Condition(bk.cond)
+	}
+	return nil
+}
+
+// UTo extracts the n×n unit upper triangular matrix U and the block diagonal
+// matrix D from the Bunch-Kaufman factorization
+//  A = U * D * U^T
+// into u and d and returns them. U includes the symmetric pivoting, so it is
+// in general not triangular and it is returned as a Dense. If u or d is nil,
+// new matrices are allocated.
+// UTo will panic if the receiver does not contain a factorization.
+func (bk *BunchKaufman) UTo(u *Dense, d *SymDense) (*Dense, *SymDense) {
+	if !bk.isValid() {
+		panic(badBunchKaufman)
+	}
+	n := bk.fact.mat.N
+	if u == nil {
+		u = NewDense(n, n, nil)
+	} else {
+		u.reuseAsZeroed(n, n)
+	}
+	if d == nil {
+		d = NewSymDense(n, nil)
+	} else {
+		d.reuseAs(n)
+		d.Zero()
+	}
+	for i := 0; i < n; i++ {
+		u.set(i, i, 1)
+	}
+
+	// U = P[n-1]*U[n-1]* ... *P[k]*U[k]* ...
+	a := bk.fact.mat
+	for k := n - 1; k >= 0; {
+		s := 1
+		kp := bk.ipiv[k]
+		if kp < 0 {
+			s = 2
+			kp = -kp - 1
+		}
+		kk := k - s + 1
+		if kp != kk {
+			blas64.Swap(u.ColView(kk).(*VecDense).mat, u.ColView(kp).(*VecDense).mat)
+		}
+		if kk > 0 {
+			// Columns kk:k+1 of U are updated as
+			//  U[:,kk:k+1] += U[:,0:kk] * V
+			// where V is held in A[0:kk,kk:k+1].
+			v := blas64.General{
+				Rows:   kk,
+				Cols:   s,
+				Stride: a.Stride,
+				Data:   a.Data[kk:],
+			}
+			ul := blas64.General{
+				Rows:   n,
+				Cols:   kk,
+				Stride: u.mat.Stride,
+				Data:   u.mat.Data,
+			}
+			ur := blas64.General{
+				Rows:   n,
+				Cols:   s,
+				Stride: u.mat.Stride,
+				Data:   u.mat.Data[kk:],
+			}
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, ul, v, 1, ur)
+		}
+		d.SetSym(k, k, a.Data[k*a.Stride+k])
+		if s == 2 {
+			d.SetSym(kk, kk, a.Data[kk*a.Stride+kk])
+			d.SetSym(kk, k, a.Data[kk*a.Stride+k])
+		}
+		k -= s
+	}
+	return u, d
+}
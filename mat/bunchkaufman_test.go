@@ -0,0 +1,185 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// randSymIndefinite returns a random n×n symmetric matrix. If kkt is true,
+// the trailing n/3×n/3 block is zero so that the matrix has the structure of
+// a saddle point system.
+func randSymIndefinite(n int, kkt bool, rnd *rand.Rand) *SymDense {
+	a := NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			a.SetSym(i, j, rnd.NormFloat64())
+		}
+	}
+	if kkt {
+		for i := n - n/3; i < n; i++ {
+			for j := i; j < n; j++ {
+				a.SetSym(i, j, 0)
+			}
+		}
+	}
+	return a
+}
+
+func TestBunchKaufman(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 31, 100} {
+		for _, kkt := range []bool{false, true} {
+			a := randSymIndefinite(n, kkt, rnd)
+
+			var bk BunchKaufman
+			bk.Factorize(a)
+
+			u, d := bk.UTo(nil, nil)
+			var got Dense
+			got.Product(u, d, u.T())
+			if !EqualApprox(&got, a, 1e-12) {
+				t.Errorf("n=%d,kkt=%t: U*D*U^T does not equal original matrix", n, kkt)
+			}
+
+			var lu LU
+			lu.Factorize(a)
+			wantDet, wantSign := lu.LogDet()
+			gotDet, gotSign := bk.LogDet()
+			if gotSign != wantSign || !floats.EqualWithinAbsOrRel(gotDet, wantDet, 1e-10, 1e-10) {
+				t.Errorf("n=%d,kkt=%t: unexpected log determinant: got (%v,%v), want (%v,%v)",
+					n, kkt, gotDet, gotSign, wantDet, wantSign)
+			}
+
+			var ed EigenSym
+			ok := ed.Factorize(a, false)
+			if !ok {
+				t.Fatalf("n=%d,kkt=%t: eigendecomposition failed", n, kkt)
+			}
+			var wantPos, wantNeg int
+			for _, v := range ed.Values(nil) {
+				if v > 0 {
+					wantPos++
+				} else {
+					wantNeg++
+				}
+			}
+			pos, neg, zero := bk.Inertia()
+			if pos != wantPos || neg != wantNeg || zero != 0 {
+				t.Errorf("n=%d,kkt=%t: unexpected inertia: got (%d,%d,%d), want (%d,%d,0)",
+					n, kkt, pos, neg, zero, wantPos, wantNeg)
+			}
+
+			// The condition estimate must not exceed the true condition
+			// number and should be within a modest factor of it.
+			want := Cond(a, 1)
+			cond := bk.Cond()
+			if cond > want*(1+1e-10) || cond < want/10 {
+				t.Errorf("n=%d,kkt=%t: unexpected condition number: got %v, want %v", n, kkt, cond, want)
+			}
+		}
+	}
+}
+
+func TestBunchKaufmanSolveTo(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 31, 100} {
+		for _, bc := range []int{1, 3, 10} {
+			for _, kkt := range []bool{false, true} {
+				a := randSymIndefinite(n, kkt, rnd)
+				want := randNormDense(n, bc, rnd)
+				var b Dense
+				b.Mul(a, want)
+
+				var bk BunchKaufman
+				bk.Factorize(a)
+				var x Dense
+				err := bk.SolveTo(&x, &b)
+				if err != nil {
+					t.Errorf("n=%d,bc=%d,kkt=%t: unexpected error: %v", n, bc, kkt, err)
+					continue
+				}
+				tol := 1e-14 * bk.Cond()
+				if !EqualApprox(&x, want, tol) {
+					t.Errorf("n=%d,bc=%d,kkt=%t: unexpected solution", n, bc, kkt)
+				}
+
+				// Test in-place solve.
+				err = bk.SolveTo(&b, &b)
+				if err != nil {
+					t.Errorf("n=%d,bc=%d,kkt=%t: unexpected error for in-place solve: %v", n, bc, kkt, err)
+					continue
+				}
+				if !Equal(&b, &x) {
+					t.Errorf("n=%d,bc=%d,kkt=%t: mismatch between in-place and out-of-place solve", n, bc, kkt)
+				}
+			}
+		}
+	}
+}
+
+func TestBunchKaufmanSolveVecTo(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 31, 100} {
+		a := randSymIndefinite(n, true, rnd)
+		want := randNormVec(n, rnd)
+		var b VecDense
+		b.MulVec(a, want)
+
+		var bk BunchKaufman
+		bk.Factorize(a)
+		var x VecDense
+		err := bk.SolveVecTo(&x, &b)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		tol := 1e-14 * bk.Cond()
+		if !EqualApprox(&x, want, tol) {
+			t.Errorf("n=%d: unexpected solution", n)
+		}
+
+		// Test in-place solve.
+		err = bk.SolveVecTo(&b, &b)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error for in-place solve: %v", n, err)
+			continue
+		}
+		if !Equal(&b, &x) {
+			t.Errorf("n=%d: mismatch between in-place and out-of-place solve", n)
+		}
+	}
+}
+
+func TestBunchKaufmanSingular(t *testing.T) {
+	// The matrix has inertia (1,1,1).
+	a := NewSymDense(3, []float64{
+		1, 0, 0,
+		0, 0, 0,
+		0, 0, -1,
+	})
+	var bk BunchKaufman
+	bk.Factorize(a)
+	if det := bk.Det(); det != 0 {
+		t.Errorf("unexpected determinant of singular matrix: got %v, want 0", det)
+	}
+	if cond := bk.Cond(); !math.IsInf(cond, 1) {
+		t.Errorf("unexpected condition number of singular matrix: got %v, want +Inf", cond)
+	}
+	pos, neg, zero := bk.Inertia()
+	if pos != 1 || neg != 1 || zero != 1 {
+		t.Errorf("unexpected inertia: got (%d,%d,%d), want (1,1,1)", pos, neg, zero)
+	}
+	var x Dense
+	err := bk.SolveTo(&x, NewDense(3, 1, []float64{1, 2, 3}))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("unexpected error for singular matrix: got %v, want Condition", err)
+	}
+}
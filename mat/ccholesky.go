@@ -0,0 +1,176 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/lapack/lapack128"
+)
+
+const badCCholesky = "mat: invalid complex Cholesky factorization"
+
+// CCholesky is a Hermitian positive definite matrix represented by its
+// Cholesky decomposition.
+//
+// The decomposition can be constructed using the Factorize method. The
+// factorization itself can be extracted using the UTo or LTo methods.
+//
+// CCholesky methods may only be called on a value that has been successfully
+// initialized by a call to Factorize that has returned true. Calls to methods
+// of an unsuccessful CCholesky factorization will panic.
+type CCholesky struct {
+	// The upper triangle of chol holds the factor U, the strictly lower
+	// triangle is zero.
+	chol *CDense
+}
+
+// Factorize calculates the Cholesky decomposition of the Hermitian matrix A
+// and returns whether the matrix is positive definite. Only the upper triangle
+// of a is referenced. If Factorize returns false, the factorization must not
+// be used.
+//
+// Factorize panics if a is not square.
+func (c *CCholesky) Factorize(a CMatrix) (ok bool) {
+	r, n := a.Dims()
+	if r != n {
+		panic(ErrSquare)
+	}
+	if c.chol == nil {
+		c.chol = NewCDense(n, n, nil)
+	} else {
+		c.chol.Reset()
+		c.chol.reuseAsZeroed(n, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			c.chol.set(i, j, a.At(i, j))
+		}
+	}
+	_, ok = lapack128.Potrf(c.hermitian())
+	if !ok {
+		c.Reset()
+	}
+	return ok
+}
+
+// hermitian returns the factor storage as a cblas128.Hermitian.
+func (c *CCholesky) hermitian() cblas128.Hermitian {
+	return cblas128.Hermitian{
+		N:      c.chol.mat.Rows,
+		Stride: c.chol.mat.Stride,
+		Data:   c.chol.mat.Data,
+		Uplo:   blas.Upper,
+	}
+}
+
+// triangular returns the factor storage as a cblas128.Triangular.
+func (c *CCholesky) triangular() cblas128.Triangular {
+	return cblas128.Triangular{
+		N:      c.chol.mat.Rows,
+		Stride: c.chol.mat.Stride,
+		Data:   c.chol.mat.Data,
+		Uplo:   blas.Upper,
+		Diag:   blas.NonUnit,
+	}
+}
+
+// valid returns whether the receiver contains a factorization.
+func (c *CCholesky) valid() bool {
+	return c.chol != nil && !c.chol.IsZero()
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (c *CCholesky) Reset() {
+	if c.chol != nil {
+		c.chol.Reset()
+	}
+}
+
+// Det returns the determinant of the matrix that has been factorized. The
+// determinant of a Hermitian positive definite matrix is real and positive.
+func (c *CCholesky) Det() float64 {
+	if !c.valid() {
+		panic(badCCholesky)
+	}
+	return math.Exp(c.LogDet())
+}
+
+// LogDet returns the log of the determinant of the matrix that has been factorized.
+func (c *CCholesky) LogDet() float64 {
+	if !c.valid() {
+		panic(badCCholesky)
+	}
+	var det float64
+	for i := 0; i < c.chol.mat.Rows; i++ {
+		det += 2 * math.Log(real(c.chol.mat.Data[i*c.chol.mat.Stride+i]))
+	}
+	return det
+}
+
+// SolveTo finds the matrix X that solves A * X = B where A is represented
+// by the Cholesky decomposition. The result is stored in-place into dst.
+func (c *CCholesky) SolveTo(dst *CDense, b CMatrix) error {
+	if !c.valid() {
+		panic(badCCholesky)
+	}
+	n := c.chol.mat.Rows
+	bm, bn := b.Dims()
+	if n != bm {
+		panic(ErrShape)
+	}
+
+	dst.reuseAs(bm, bn)
+	bU, _ := unconjugate(b)
+	var restore func()
+	if dst == bU {
+		dst, restore = dst.isolatedWorkspace(bU)
+		defer restore()
+	}
+	if b != dst {
+		dst.Copy(b)
+	}
+	lapack128.Potrs(c.triangular(), dst.mat)
+	return nil
+}
+
+// UTo extracts the n×n upper triangular matrix U from a Cholesky
+// decomposition into dst and returns the result. If dst is nil a new
+// CDense is allocated.
+//  A = U^H * U.
+func (c *CCholesky) UTo(dst *CDense) *CDense {
+	if !c.valid() {
+		panic(badCCholesky)
+	}
+	n := c.chol.mat.Rows
+	if dst == nil {
+		dst = NewCDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(c.chol)
+	return dst
+}
+
+// LTo extracts the n×n lower triangular matrix L from a Cholesky
+// decomposition into dst and returns the result. If dst is nil a new
+// CDense is allocated.
+//  A = L * L^H.
+func (c *CCholesky) LTo(dst *CDense) *CDense {
+	if !c.valid() {
+		panic(badCCholesky)
+	}
+	n := c.chol.mat.Rows
+	if dst == nil {
+		dst = NewCDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(c.chol.H())
+	return dst
+}
@@ -0,0 +1,64 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestCCholesky(t *testing.T) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		// Construct a Hermitian positive definite matrix.
+		x := randCDense(n, n, rnd)
+		a := cmul(x.H(), x)
+		for i := 0; i < n; i++ {
+			a.set(i, i, a.At(i, i)+complex(float64(n), 0))
+		}
+
+		var chol CCholesky
+		if !chol.Factorize(a) {
+			t.Errorf("n=%d: unexpected factorization failure", n)
+			continue
+		}
+		u := chol.UTo(nil)
+		l := chol.LTo(nil)
+		if !CEqualApprox(cmul(u.H(), u), a, tol) {
+			t.Errorf("n=%d: U^H*U != A", n)
+		}
+		if !CEqualApprox(cmul(l, l.H()), a, tol) {
+			t.Errorf("n=%d: L*L^H != A", n)
+		}
+
+		var lu CLU
+		lu.Factorize(a)
+		want := real(lu.Det())
+		if got := chol.Det(); math.Abs(got-want) > tol*math.Abs(want) {
+			t.Errorf("n=%d: unexpected determinant: got %v, want %v", n, got, want)
+		}
+
+		b := randCDense(n, 3, rnd)
+		var sol CDense
+		err := chol.SolveTo(&sol, b)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		if !CEqualApprox(cmul(a, &sol), b, tol) {
+			t.Errorf("n=%d: A*X != B", n)
+		}
+	}
+
+	// Check that a non-positive-definite matrix is reported.
+	var chol CCholesky
+	a := NewCDense(2, 2, []complex128{1, 2i, -2i, 1})
+	if chol.Factorize(a) {
+		t.Errorf("expected failure for indefinite matrix")
+	}
+}
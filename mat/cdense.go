@@ -4,9 +4,13 @@
 
 package mat
 
-import "gonum.org/v1/gonum/blas/cblas128"
+import (
+	"math/cmplx"
 
-// Dense is a dense matrix representation with complex data.
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// CDense is a dense matrix representation with complex data.
 type CDense struct {
 	mat cblas128.General
 
@@ -18,6 +22,9 @@ func (m *CDense) Dims() (r, c int) {
 	return m.mat.Rows, m.mat.Cols
 }
 
+// Caps returns the number of rows and columns in the backing matrix.
+func (m *CDense) Caps() (r, c int) { return m.capRows, m.capCols }
+
 // H performs an implicit conjugate transpose by returning the receiver inside a
 // Conjugate.
 func (m *CDense) H() CMatrix {
@@ -112,6 +119,20 @@ func (m *CDense) reuseAsZeroed(r, c int) {
 	m.Zero()
 }
 
+// isolatedWorkspace returns a new complex dense matrix w with the size of a and
+// returns a callback to defer which performs cleanup at the return of the call.
+// This should be used when a method receiver is the same pointer as an input argument.
+func (m *CDense) isolatedWorkspace(a CMatrix) (w *CDense, restore func()) {
+	r, c := a.Dims()
+	if r == 0 || c == 0 {
+		panic(ErrZeroLength)
+	}
+	w = NewCDense(r, c, nil)
+	return w, func() {
+		m.Copy(w)
+	}
+}
+
 // Reset zeros the dimensions of the matrix so that it can be reused as the
 // receiver of a dimensionally restricted operation.
 //
@@ -143,10 +164,7 @@ func (m *CDense) Zero() {
 // Copy makes a copy of elements of a into the receiver. It is similar to the
 // built-in copy; it copies as much as the overlap between the two matrices and
 // returns the number of rows and columns it copied. If a aliases the receiver
-// and is a transposed Dense or VecDense, with a non-unitary increment, Copy will
-// panic.
-//
-// See the Copier interface for more information.
+// and is a conjugate transposed CDense, Copy will panic.
 func (m *CDense) Copy(a CMatrix) (r, c int) {
 	r, c = a.Dims()
 	if a == m {
@@ -157,12 +175,183 @@ func (m *CDense) Copy(a CMatrix) (r, c int) {
 	if r == 0 || c == 0 {
 		return 0, 0
 	}
-	// TODO(btracey): Check for overlap when complex version exists.
-	// TODO(btracey): Add fast-paths.
-	for i := 0; i < r; i++ {
-		for j := 0; j < c; j++ {
-			m.set(i, j, a.At(i, j))
+	aU, trans := unconjugate(a)
+	switch aU := aU.(type) {
+	case RawCMatrixer:
+		amat := aU.RawCMatrix()
+		if trans {
+			if amat.Stride != 1 {
+				m.checkOverlap(amat)
+			}
+			for i := 0; i < r; i++ {
+				row := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c]
+				for j := range row {
+					row[j] = cmplx.Conj(amat.Data[j*amat.Stride+i])
+				}
+			}
+		} else {
+			switch o := offsetComplex(m.mat.Data, amat.Data); {
+			case o < 0:
+				for i := r - 1; i >= 0; i-- {
+					copy(m.mat.Data[i*m.mat.Stride:i*m.mat.Stride+c], amat.Data[i*amat.Stride:i*amat.Stride+c])
+				}
+			case o > 0:
+				for i := 0; i < r; i++ {
+					copy(m.mat.Data[i*m.mat.Stride:i*m.mat.Stride+c], amat.Data[i*amat.Stride:i*amat.Stride+c])
+				}
+			default:
+				// Nothing to do.
+			}
+		}
+	default:
+		m.checkOverlapMatrix(aU)
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				m.set(i, j, a.At(i, j))
+			}
 		}
 	}
 	return r, c
 }
+
+// CDenseCopyOf returns a newly allocated copy of the elements of a.
+func CDenseCopyOf(a CMatrix) *CDense {
+	d := &CDense{}
+	d.Clone(a)
+	return d
+}
+
+// SetRawCMatrix sets the underlying cblas128.General used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in b.
+func (m *CDense) SetRawCMatrix(b cblas128.General) {
+	m.capRows, m.capCols = b.Rows, b.Cols
+	m.mat = b
+}
+
+// RawCMatrix returns the underlying cblas128.General used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned cblas128.General.
+func (m *CDense) RawCMatrix() cblas128.General { return m.mat }
+
+// Clone makes a copy of a into the receiver, overwriting the previous value of
+// the receiver. The clone operation does not make any restriction on shape and
+// will not cause shadowing.
+func (m *CDense) Clone(a CMatrix) {
+	r, c := a.Dims()
+	mat := cblas128.General{
+		Rows:   r,
+		Cols:   c,
+		Stride: c,
+		Data:   make([]complex128, r*c),
+	}
+	w := CDense{
+		mat:     mat,
+		capRows: r,
+		capCols: c,
+	}
+	aU, trans := unconjugate(a)
+	if rm, ok := aU.(RawCMatrixer); ok && !trans {
+		amat := rm.RawCMatrix()
+		for i := 0; i < r; i++ {
+			copy(mat.Data[i*c:(i+1)*c], amat.Data[i*amat.Stride:i*amat.Stride+c])
+		}
+	} else {
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				w.set(i, j, a.At(i, j))
+			}
+		}
+	}
+	*m = w
+}
+
+// Slice returns a new CMatrix that shares backing data with the receiver.
+// The returned matrix starts at {i,j} of the receiver and extends k-i rows
+// and l-j columns. The final row in the resulting matrix is k-1 and the
+// final column is l-1.
+// Slice panics with ErrIndexOutOfRange if the slice is outside the capacity
+// of the receiver.
+func (m *CDense) Slice(i, k, j, l int) CMatrix {
+	mr, mc := m.Caps()
+	if i < 0 || mr <= i || j < 0 || mc <= j || k < i || mr < k || l < j || mc < l {
+		if i == k || j == l {
+			panic(ErrZeroLength)
+		}
+		panic(ErrIndexOutOfRange)
+	}
+	t := *m
+	t.mat.Data = t.mat.Data[i*t.mat.Stride+j : (k-1)*t.mat.Stride+l]
+	t.mat.Rows = k - i
+	t.mat.Cols = l - j
+	t.capRows -= i
+	t.capCols -= j
+	return &t
+}
+
+// ColView returns a CVector reflecting the column j, backed by the matrix data.
+func (m *CDense) ColView(j int) CVector {
+	var v CVecDense
+	v.ColViewOf(m, j)
+	return &v
+}
+
+// RowView returns row i of the matrix data represented as a column vector,
+// backed by the matrix data.
+func (m *CDense) RowView(i int) CVector {
+	var v CVecDense
+	v.RowViewOf(m, i)
+	return &v
+}
+
+// SetCol sets the values in the specified column of the matrix to the values
+// in src. len(src) must equal the number of rows in the receiver.
+func (m *CDense) SetCol(j int, src []complex128) {
+	if j >= m.mat.Cols || j < 0 {
+		panic(ErrColAccess)
+	}
+	if len(src) != m.mat.Rows {
+		panic(ErrColLength)
+	}
+	for i, v := range src {
+		m.mat.Data[i*m.mat.Stride+j] = v
+	}
+}
+
+// SetRow sets the values in the specified rows of the matrix to the values
+// in src. len(src) must equal the number of columns in the receiver.
+func (m *CDense) SetRow(i int, src []complex128) {
+	if i >= m.mat.Rows || i < 0 {
+		panic(ErrRowAccess)
+	}
+	if len(src) != m.mat.Cols {
+		panic(ErrRowLength)
+	}
+	copy(m.rawRowView(i), src)
+}
+
+// RawRowView returns a slice backed by the same array as backing the
+// receiver.
+func (m *CDense) RawRowView(i int) []complex128 {
+	if i >= m.mat.Rows || i < 0 {
+		panic(ErrRowAccess)
+	}
+	return m.rawRowView(i)
+}
+
+func (m *CDense) rawRowView(i int) []complex128 {
+	return m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+m.mat.Cols]
+}
+
+// Trace returns the trace of the matrix. The matrix must be square or Trace
+// will panic.
+func (m *CDense) Trace() complex128 {
+	if m.mat.Rows != m.mat.Cols {
+		panic(ErrSquare)
+	}
+	var v complex128
+	for i := 0; i < m.mat.Rows; i++ {
+		v += m.mat.Data[i*m.mat.Stride+i]
+	}
+	return v
+}
@@ -0,0 +1,283 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/lapack/lapack128"
+)
+
+// Add adds a and b element-wise, placing the result in the receiver. Add
+// will panic if the two matrices do not have the same shape.
+func (m *CDense) Add(a, b CMatrix) {
+	m.elementWise(a, b, func(x, y complex128) complex128 { return x + y })
+}
+
+// Sub subtracts the matrix b from a, placing the result in the receiver. Sub
+// will panic if the two matrices do not have the same shape.
+func (m *CDense) Sub(a, b CMatrix) {
+	m.elementWise(a, b, func(x, y complex128) complex128 { return x - y })
+}
+
+// MulElem performs element-wise multiplication of a and b, placing the result
+// in the receiver. MulElem will panic if the two matrices do not have the same
+// shape.
+func (m *CDense) MulElem(a, b CMatrix) {
+	m.elementWise(a, b, func(x, y complex128) complex128 { return x * y })
+}
+
+// DivElem performs element-wise division of a by b, placing the result
+// in the receiver. DivElem will panic if the two matrices do not have the same
+// shape.
+func (m *CDense) DivElem(a, b CMatrix) {
+	m.elementWise(a, b, func(x, y complex128) complex128 { return x / y })
+}
+
+// elementWise applies the binary operation fn element-wise to a and b,
+// placing the result in the receiver.
+func (m *CDense) elementWise(a, b CMatrix, fn func(x, y complex128) complex128) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+
+	aU, aTrans := unconjugate(a)
+	bU, bTrans := unconjugate(b)
+	m.reuseAs(ar, ac)
+
+	if arm, ok := a.(RawCMatrixer); ok {
+		if brm, ok := b.(RawCMatrixer); ok {
+			amat, bmat := arm.RawCMatrix(), brm.RawCMatrix()
+			if m != aU {
+				m.checkOverlap(amat)
+			}
+			if m != bU {
+				m.checkOverlap(bmat)
+			}
+			for ja, jb, jm := 0, 0, 0; ja < ar*amat.Stride; ja, jb, jm = ja+amat.Stride, jb+bmat.Stride, jm+m.mat.Stride {
+				for i, v := range amat.Data[ja : ja+ac] {
+					m.mat.Data[i+jm] = fn(v, bmat.Data[i+jb])
+				}
+			}
+			return
+		}
+	}
+
+	var restore func()
+	if aTrans && m == aU {
+		m, restore = m.isolatedWorkspace(aU)
+		defer restore()
+	} else if bTrans && m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	} else {
+		m.checkOverlapMatrix(aU)
+		m.checkOverlapMatrix(bU)
+	}
+
+	for r := 0; r < ar; r++ {
+		for c := 0; c < ac; c++ {
+			m.set(r, c, fn(a.At(r, c), b.At(r, c)))
+		}
+	}
+}
+
+// Scale multiplies the elements of a by f, placing the result in the receiver.
+func (m *CDense) Scale(f complex128, a CMatrix) {
+	m.Apply(func(_, _ int, v complex128) complex128 { return f * v }, a)
+}
+
+// Conj places the element-wise conjugate of a in the receiver.
+func (m *CDense) Conj(a CMatrix) {
+	m.Apply(func(_, _ int, v complex128) complex128 { return cmplx.Conj(v) }, a)
+}
+
+// Apply applies the function fn to each of the elements of a, placing the
+// resulting matrix in the receiver. The function fn takes a row/column
+// index and element value and returns some function of that tuple.
+func (m *CDense) Apply(fn func(i, j int, v complex128) complex128, a CMatrix) {
+	ar, ac := a.Dims()
+
+	m.reuseAs(ar, ac)
+
+	aU, aTrans := unconjugate(a)
+	if rm, ok := aU.(RawCMatrixer); ok && !aTrans {
+		amat := rm.RawCMatrix()
+		if m != aU {
+			m.checkOverlap(amat)
+		}
+		for j, ja, jm := 0, 0, 0; ja < ar*amat.Stride; j, ja, jm = j+1, ja+amat.Stride, jm+m.mat.Stride {
+			for i, v := range amat.Data[ja : ja+ac] {
+				m.mat.Data[i+jm] = fn(j, i, v)
+			}
+		}
+		return
+	}
+
+	if m == aU {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	} else {
+		m.checkOverlapMatrix(aU)
+	}
+	for r := 0; r < ar; r++ {
+		for c := 0; c < ac; c++ {
+			m.set(r, c, fn(r, c, a.At(r, c)))
+		}
+	}
+}
+
+// Mul takes the matrix product of a and b, placing the result in the receiver.
+// If the number of columns in a does not equal the number of rows in b, Mul will panic.
+func (m *CDense) Mul(a, b CMatrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+
+	if ac != br {
+		panic(ErrShape)
+	}
+
+	aU, aTrans := unconjugate(a)
+	bU, bTrans := unconjugate(b)
+	m.reuseAs(ar, bc)
+	var restore func()
+	if m == aU {
+		m, restore = m.isolatedWorkspace(aU)
+		defer restore()
+	} else if m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	}
+	aT := blas.NoTrans
+	if aTrans {
+		aT = blas.ConjTrans
+	}
+	bT := blas.NoTrans
+	if bTrans {
+		bT = blas.ConjTrans
+	}
+
+	if aUrm, ok := aU.(RawCMatrixer); ok {
+		if bUrm, ok := bU.(RawCMatrixer); ok {
+			amat := aUrm.RawCMatrix()
+			bmat := bUrm.RawCMatrix()
+			if restore == nil {
+				m.checkOverlap(amat)
+				m.checkOverlap(bmat)
+			}
+			cblas128.Gemm(aT, bT, 1, amat, bmat, 0, m.mat)
+			return
+		}
+	}
+
+	if restore == nil {
+		m.checkOverlapMatrix(aU)
+		m.checkOverlapMatrix(bU)
+	}
+	row := make([]complex128, ac)
+	for r := 0; r < ar; r++ {
+		for i := range row {
+			row[i] = a.At(r, i)
+		}
+		for c := 0; c < bc; c++ {
+			var v complex128
+			for i, e := range row {
+				v += e * b.At(i, c)
+			}
+			m.mat.Data[r*m.mat.Stride+c] = v
+		}
+	}
+}
+
+// Inverse computes the inverse of the matrix a, storing the result into the
+// receiver. If a is ill-conditioned, a Condition error will be returned.
+// Note that matrix inversion is numerically unstable, and should generally
+// be avoided where possible, for example by using the Solve routines.
+func (m *CDense) Inverse(a CMatrix) error {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	m.reuseAs(r, c)
+
+	lu := NewCDense(r, r, nil)
+	lu.Copy(a)
+	norm := CNorm(lu, math.Inf(1))
+	ipiv := getInts(r, false)
+	defer putInts(ipiv)
+	ok := lapack128.Getrf(lu.mat, ipiv)
+	if !ok {
+		return Condition(math.Inf(1))
+	}
+	for i := 0; i < r; i++ {
+		row := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+r]
+		zeroC(row)
+		row[i] = 1
+	}
+	lapack128.Getrs(blas.NoTrans, lu.mat, m.mat, ipiv)
+
+	work := make([]complex128, 2*r)
+	rwork := getFloats(2*r, false)
+	defer putFloats(rwork)
+	rcond := lapack128.Gecon(CondNorm, lu.mat, norm, work, rwork)
+	if rcond == 0 {
+		return Condition(math.Inf(1))
+	}
+	cond := 1 / rcond
+	if cond > ConditionTolerance {
+		return Condition(cond)
+	}
+	return nil
+}
+
+// Solve solves the linear least squares problem
+//  minimize over x |b - A*x|_2
+// where A is an m×n complex matrix A, b is a given m element vector and x is
+// n element solution vector. Solve assumes that A has full rank, that is
+//  rank(A) = min(m,n)
+//
+// If m >= n, Solve finds the unique least squares solution of an overdetermined
+// system.
+//
+// If m < n, there is an infinite number of solutions that satisfy b-A*x=0. In
+// this case Solve finds the unique solution of an underdetermined system that
+// minimizes |x|_2.
+//
+// Several right-hand side vectors b and solution vectors x can be handled in a
+// single call. Vectors b are stored in the columns of the m×k matrix B. Vectors
+// x are stored in the columns of the n×k matrix X.
+//
+// If A is exactly singular, a Condition error is returned.
+func (m *CDense) Solve(a, b CMatrix) error {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br {
+		panic(ErrShape)
+	}
+	m.reuseAs(ac, bc)
+
+	switch {
+	case ar == ac:
+		var lu CLU
+		lu.Factorize(a)
+		return lu.SolveTo(m, false, b)
+	case ar > ac:
+		var qr CQR
+		qr.Factorize(a)
+		return qr.SolveTo(m, false, b)
+	default:
+		// The minimum norm solution of A * X = B is found from the
+		// QR factorization of A^H.
+		var qr CQR
+		qr.Factorize(a.H())
+		return qr.SolveTo(m, true, b)
+	}
+}
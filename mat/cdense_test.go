@@ -4,7 +4,13 @@
 
 package mat
 
-import "testing"
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
 
 func TestCDenseNewAtSet(t *testing.T) {
 	for cas, test := range []struct {
@@ -46,3 +52,326 @@ func TestCDenseNewAtSet(t *testing.T) {
 		}
 	}
 }
+
+// randCDense returns an r×c complex matrix with elements drawn from the
+// standard complex normal distribution.
+func randCDense(r, c int, rnd *rand.Rand) *CDense {
+	m := NewCDense(r, c, nil)
+	for i := range m.mat.Data {
+		m.mat.Data[i] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+	}
+	return m
+}
+
+// ceye returns an n×n complex identity matrix.
+func ceye(n int) *CDense {
+	m := NewCDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		m.mat.Data[i*n+i] = 1
+	}
+	return m
+}
+
+// cmul returns the product a*b computed without BLAS.
+func cmul(a, b CMatrix) *CDense {
+	ar, ac := a.Dims()
+	_, bc := b.Dims()
+	m := NewCDense(ar, bc, nil)
+	for i := 0; i < ar; i++ {
+		for j := 0; j < bc; j++ {
+			var v complex128
+			for k := 0; k < ac; k++ {
+				v += a.At(i, k) * b.At(k, j)
+			}
+			m.set(i, j, v)
+		}
+	}
+	return m
+}
+
+func TestCDenseElementWise(t *testing.T) {
+	const tol = 1e-14
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name string
+		fn   func(m *CDense, a, b CMatrix)
+		op   func(x, y complex128) complex128
+	}{
+		{name: "Add", fn: (*CDense).Add, op: func(x, y complex128) complex128 { return x + y }},
+		{name: "Sub", fn: (*CDense).Sub, op: func(x, y complex128) complex128 { return x - y }},
+		{name: "MulElem", fn: (*CDense).MulElem, op: func(x, y complex128) complex128 { return x * y }},
+		{name: "DivElem", fn: (*CDense).DivElem, op: func(x, y complex128) complex128 { return x / y }},
+	} {
+		for _, dims := range [][2]int{{1, 1}, {3, 3}, {4, 7}} {
+			r, c := dims[0], dims[1]
+			a := randCDense(r, c, rnd)
+			b := randCDense(r, c, rnd)
+			bh := randCDense(c, r, rnd)
+
+			want := NewCDense(r, c, nil)
+			wantH := NewCDense(r, c, nil)
+			for i := 0; i < r; i++ {
+				for j := 0; j < c; j++ {
+					want.set(i, j, test.op(a.At(i, j), b.At(i, j)))
+					wantH.set(i, j, test.op(a.At(i, j), cmplx.Conj(bh.At(j, i))))
+				}
+			}
+
+			var got CDense
+			test.fn(&got, a, b)
+			if !CEqualApprox(&got, want, tol) {
+				t.Errorf("%s %d×%d: unexpected result", test.name, r, c)
+			}
+			test.fn(&got, a, bh.H())
+			if !CEqualApprox(&got, wantH, tol) {
+				t.Errorf("%s %d×%d: unexpected result with conjugate transpose", test.name, r, c)
+			}
+
+			// Check that the receiver may alias an operand.
+			ac := CDenseCopyOf(a)
+			test.fn(ac, ac, b)
+			if !CEqualApprox(ac, want, tol) {
+				t.Errorf("%s %d×%d: unexpected result with aliased receiver", test.name, r, c)
+			}
+		}
+	}
+}
+
+func TestCDenseScaleConj(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := randCDense(3, 4, rnd)
+	f := complex(2, -1)
+
+	var s, cj CDense
+	s.Scale(f, a)
+	cj.Conj(a)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 4; j++ {
+			if s.At(i, j) != f*a.At(i, j) {
+				t.Errorf("unexpected Scale value at %d,%d", i, j)
+			}
+			if cj.At(i, j) != cmplx.Conj(a.At(i, j)) {
+				t.Errorf("unexpected Conj value at %d,%d", i, j)
+			}
+		}
+	}
+
+	// Conj of the conjugate transpose is the transpose.
+	var tr CDense
+	tr.Conj(a.H())
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			if tr.At(i, j) != a.At(j, i) {
+				t.Errorf("unexpected transpose value at %d,%d", i, j)
+			}
+		}
+	}
+}
+
+func TestCDenseMul(t *testing.T) {
+	const tol = 1e-12
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		ar, ac, bc int
+	}{
+		{1, 1, 1},
+		{3, 3, 3},
+		{2, 5, 4},
+		{7, 3, 6},
+	} {
+		a := randCDense(test.ar, test.ac, rnd)
+		b := randCDense(test.ac, test.bc, rnd)
+		var got CDense
+		got.Mul(a, b)
+		if !CEqualApprox(&got, cmul(a, b), tol) {
+			t.Errorf("%d×%d*%d×%d: unexpected product", test.ar, test.ac, test.ac, test.bc)
+		}
+
+		ah := CDenseCopyOf(a.H())
+		bh := CDenseCopyOf(b.H())
+		got.Reset()
+		got.Mul(ah.H(), bh.H())
+		if !CEqualApprox(&got, cmul(a, b), tol) {
+			t.Errorf("%d×%d*%d×%d: unexpected product of conjugate transposes", test.ar, test.ac, test.ac, test.bc)
+		}
+	}
+
+	// Check that the receiver may alias an operand.
+	a := randCDense(4, 4, rnd)
+	b := randCDense(4, 4, rnd)
+	want := cmul(a, b)
+	a.Mul(a, b)
+	if !CEqualApprox(a, want, tol) {
+		t.Errorf("unexpected product with aliased receiver")
+	}
+}
+
+func TestCDenseSliceView(t *testing.T) {
+	m := NewCDense(3, 4, nil)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 4; j++ {
+			m.set(i, j, complex(float64(i), float64(j)))
+		}
+	}
+	s := m.Slice(1, 3, 1, 4).(*CDense)
+	if r, c := s.Dims(); r != 2 || c != 3 {
+		t.Fatalf("unexpected slice dimensions: got %d×%d, want 2×3", r, c)
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			if s.At(i, j) != m.At(i+1, j+1) {
+				t.Errorf("unexpected slice value at %d,%d", i, j)
+			}
+		}
+	}
+	s.Set(0, 0, 100)
+	if m.At(1, 1) != 100 {
+		t.Errorf("slice does not share data with the original matrix")
+	}
+
+	row := m.RowView(2)
+	if row.Len() != 4 {
+		t.Errorf("unexpected row length: got %d, want 4", row.Len())
+	}
+	for j := 0; j < 4; j++ {
+		if row.AtVec(j) != m.At(2, j) {
+			t.Errorf("unexpected row view value at %d", j)
+		}
+	}
+	col := m.ColView(1)
+	if col.Len() != 3 {
+		t.Errorf("unexpected column length: got %d, want 3", col.Len())
+	}
+	for i := 0; i < 3; i++ {
+		if col.AtVec(i) != m.At(i, 1) {
+			t.Errorf("unexpected column view value at %d", i)
+		}
+	}
+	col.(*CVecDense).SetVec(0, 7i)
+	if m.At(0, 1) != 7i {
+		t.Errorf("column view does not share data with the original matrix")
+	}
+
+	m.SetRow(0, []complex128{1, 2, 3, 4})
+	m.SetCol(3, []complex128{5, 6, 7})
+	if m.At(0, 2) != 3 || m.At(0, 3) != 5 || m.At(2, 3) != 7 {
+		t.Errorf("unexpected values after SetRow and SetCol")
+	}
+	if tr := m.Slice(0, 3, 0, 3).(*CDense).Trace(); tr != m.At(0, 0)+m.At(1, 1)+m.At(2, 2) {
+		t.Errorf("unexpected trace: got %v", tr)
+	}
+}
+
+func TestCNorm(t *testing.T) {
+	const tol = 1e-14
+	a := NewCDense(2, 3, []complex128{
+		3 + 4i, 0, 2,
+		-1i, 2, 1 - 1i,
+	})
+	for _, test := range []struct {
+		norm float64
+		want float64
+	}{
+		{norm: 1, want: 6},
+		{norm: 2, want: 6},
+		{norm: math.Inf(1), want: 7},
+	} {
+		got := CNorm(a, test.norm)
+		if math.Abs(got-test.want) > tol {
+			t.Errorf("unexpected %v norm: got %v, want %v", test.norm, got, test.want)
+		}
+		gotH := CNorm(a.H(), test.norm)
+		switch test.norm {
+		case 1:
+			test.want = 7
+		case math.Inf(1):
+			test.want = 6
+		}
+		if math.Abs(gotH-test.want) > tol {
+			t.Errorf("unexpected %v norm of conjugate transpose: got %v, want %v", test.norm, gotH, test.want)
+		}
+	}
+}
+
+func TestCDenseInverseSolve(t *testing.T) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10} {
+		a := randCDense(n, n, rnd)
+		var inv CDense
+		err := inv.Inverse(a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		if !CEqualApprox(cmul(a, &inv), ceye(n), tol) {
+			t.Errorf("n=%d: A*A^-1 != I", n)
+		}
+	}
+
+	var inv CDense
+	err := inv.Inverse(NewCDense(2, 2, []complex128{1, 1i, 1i, -1}))
+	if err == nil {
+		t.Errorf("expected error for singular matrix")
+	}
+
+	// An ill-conditioned matrix is inverted, but a Condition error
+	// reports its estimated condition number.
+	var ill CDense
+	err = ill.Inverse(NewCDense(3, 3, []complex128{
+		1 + 1i, 2, 0,
+		0, 1e-20i, 0,
+		3, 0, 1,
+	}))
+	if cond, ok := err.(Condition); !ok || float64(cond) < ConditionTolerance {
+		t.Errorf("unexpected error for ill-conditioned matrix: got %v, want Condition > %v", err, ConditionTolerance)
+	}
+	if got, want := ill.At(1, 1), complex(0, -1e20); cmplx.Abs(got-want) > 1e-10*cmplx.Abs(want) {
+		t.Errorf("unexpected element of inverse of ill-conditioned matrix: got %v, want %v", got, want)
+	}
+
+	for _, test := range []struct {
+		m, n, bc int
+	}{
+		{3, 3, 2},
+		{8, 3, 2},
+		{3, 8, 2},
+	} {
+		a := randCDense(test.m, test.n, rnd)
+		b := randCDense(test.m, test.bc, rnd)
+		var x CDense
+		err := x.Solve(a, b)
+		if err != nil {
+			t.Errorf("%d×%d: unexpected error: %v", test.m, test.n, err)
+			continue
+		}
+		if r, c := x.Dims(); r != test.n || c != test.bc {
+			t.Errorf("%d×%d: unexpected solution dimensions", test.m, test.n)
+			continue
+		}
+		// The residual of a least squares solution is orthogonal to
+		// the range of A, and for an underdetermined system it is zero.
+		var res, ahr CDense
+		res.Sub(cmul(a, &x), b)
+		ahr.Mul(a.H(), &res)
+		if CNorm(&ahr, 2) > tol {
+			t.Errorf("%d×%d: residual not orthogonal to range of A", test.m, test.n)
+		}
+		if test.m < test.n {
+			if CNorm(&res, 2) > tol {
+				t.Errorf("%d×%d: non-zero residual for underdetermined system", test.m, test.n)
+			}
+			// The minimum norm solution lies in the range of A^H.
+			var z CDense
+			if err := z.Solve(a.H(), &x); err != nil {
+				t.Errorf("%d×%d: unexpected error: %v", test.m, test.n, err)
+				continue
+			}
+			var ahz CDense
+			ahz.Sub(cmul(a.H(), &z), &x)
+			if CNorm(&ahz, 2) > tol {
+				t.Errorf("%d×%d: solution is not of minimum norm", test.m, test.n)
+			}
+		}
+	}
+}
@@ -494,6 +494,81 @@ func (c *Cholesky) ExtendVecSym(a *Cholesky, v Vector) (ok bool) {
 	return true
 }
 
+// DeleteRowCol computes the Cholesky decomposition of the original matrix A,
+// whose Cholesky decomposition is in orig, with row and column k removed,
+// storing the result into the receiver. That is, if A is n×n and
+//  [A11  a   A13]
+//  [a'   b   c' ]
+//  [A13' c   A33]
+// with b at row and column k, the updated matrix is
+//  [A11  A13]
+//  [A13' A33].
+// Removing a row and column of a positive definite matrix leaves it positive
+// definite, so DeleteRowCol always succeeds.
+//
+// DeleteRowCol updates the factorization in O(n²) time using Givens rotations.
+// It will panic if k is out of range, if orig is 1×1 or if orig does not
+// contain a valid decomposition.
+func (c *Cholesky) DeleteRowCol(orig *Cholesky, k int) {
+	if !orig.valid() {
+		panic(badCholesky)
+	}
+	n := orig.Symmetric()
+	if k < 0 || n <= k {
+		panic(ErrIndexOutOfRange)
+	}
+	if n == 1 {
+		panic(ErrZeroLength)
+	}
+
+	// Removing column k from U gives the factor of the updated matrix up
+	// to the trailing block. If
+	//  U = [U11 u   U13]
+	//      [0   d   w' ]
+	//      [0   0   U33]
+	// then the trailing block S of the updated factor must satisfy
+	//  S'*S = U33'*U33 + w*w',
+	// which is a rank-one update of U33 computed with Givens rotations as
+	// in SymRankOne.
+	umat := orig.chol.mat
+	newU := NewTriDense(n-1, Upper, nil)
+	nmat := newU.mat
+	for i := 0; i < k; i++ {
+		copy(nmat.Data[i*nmat.Stride+i:i*nmat.Stride+k], umat.Data[i*umat.Stride+i:i*umat.Stride+k])
+		copy(nmat.Data[i*nmat.Stride+k:i*nmat.Stride+n-1], umat.Data[i*umat.Stride+k+1:i*umat.Stride+n])
+	}
+	for i := k + 1; i < n; i++ {
+		copy(nmat.Data[(i-1)*nmat.Stride+i-1:(i-1)*nmat.Stride+n-1], umat.Data[i*umat.Stride+i:i*umat.Stride+n])
+	}
+	m := n - 1 - k
+	work := getFloats(m, false)
+	defer putFloats(work)
+	copy(work, umat.Data[k*umat.Stride+k+1:k*umat.Stride+n])
+
+	stride := nmat.Stride
+	for i := k; i < n-1; i++ {
+		// Compute parameters of the Givens matrix that zeroes
+		// the corresponding element of w.
+		cs, sn, r, _ := blas64.Rotg(nmat.Data[i*stride+i], work[i-k])
+		if r < 0 {
+			// Multiply by -1 to have positive diagonal
+			// elements.
+			r *= -1
+			cs *= -1
+			sn *= -1
+		}
+		nmat.Data[i*stride+i] = r
+		if i < n-2 {
+			blas64.Rot(
+				blas64.Vector{N: n - i - 2, Data: nmat.Data[i*stride+i+1 : i*stride+n-1], Inc: 1},
+				blas64.Vector{N: n - i - 2, Data: work[i-k+1 : m], Inc: 1},
+				cs, sn)
+		}
+	}
+	c.chol = newU
+	c.updateCond(-1)
+}
+
 // SymRankOne performs a rank-1 update of the original matrix A and refactorizes
 // its Cholesky factorization, storing the result into the receiver. That is, if
 // in the original Cholesky factorization
@@ -0,0 +1,203 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/lapack/lapack32"
+)
+
+const badCholesky32 = "mat: invalid single precision Cholesky factorization"
+
+// Cholesky32 is a single precision symmetric positive definite matrix
+// represented by its Cholesky decomposition.
+//
+// The decomposition can be constructed using the Factorize method. The
+// factorization itself can be extracted using the UTo or LTo methods.
+//
+// Cholesky32 methods may only be called on a value that has been successfully
+// initialized by a call to Factorize that has returned true. Calls to methods
+// of an unsuccessful Cholesky32 factorization will panic.
+type Cholesky32 struct {
+	// The upper triangle of chol holds the factor U, the strictly lower
+	// triangle is zero.
+	chol *Dense32
+}
+
+// Factorize calculates the Cholesky decomposition of the matrix A and returns
+// whether the matrix is positive definite. If Factorize returns false, the
+// factorization must not be used.
+func (c *Cholesky32) Factorize(a Symmetric32) (ok bool) {
+	n := a.Symmetric()
+	if c.chol == nil {
+		c.chol = NewDense32(n, n, nil)
+	} else {
+		c.chol.Reset()
+		c.chol.reuseAsZeroed(n, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			c.chol.set(i, j, a.At(i, j))
+		}
+	}
+	_, ok = lapack32.Potrf(c.symmetric())
+	if !ok {
+		c.Reset()
+	}
+	return ok
+}
+
+// symmetric returns the factor storage as a blas32.Symmetric.
+func (c *Cholesky32) symmetric() blas32.Symmetric {
+	return blas32.Symmetric{
+		N:      c.chol.mat.Rows,
+		Stride: c.chol.mat.Stride,
+		Data:   c.chol.mat.Data,
+		Uplo:   blas.Upper,
+	}
+}
+
+// triangular returns the factor storage as a blas32.Triangular.
+func (c *Cholesky32) triangular() blas32.Triangular {
+	return blas32.Triangular{
+		N:      c.chol.mat.Rows,
+		Stride: c.chol.mat.Stride,
+		Data:   c.chol.mat.Data,
+		Uplo:   blas.Upper,
+		Diag:   blas.NonUnit,
+	}
+}
+
+// valid returns whether the receiver contains a factorization.
+func (c *Cholesky32) valid() bool {
+	return c.chol != nil && !c.chol.IsZero()
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (c *Cholesky32) Reset() {
+	if c.chol != nil {
+		c.chol.Reset()
+	}
+}
+
+// Det returns the determinant of the matrix that has been factorized.
+func (c *Cholesky32) Det() float64 {
+	if !c.valid() {
+		panic(badCholesky32)
+	}
+	return math.Exp(c.LogDet())
+}
+
+// LogDet returns the log of the determinant of the matrix that has been
+// factorized. The determinant is accumulated in double precision.
+func (c *Cholesky32) LogDet() float64 {
+	if !c.valid() {
+		panic(badCholesky32)
+	}
+	var det float64
+	for i := 0; i < c.chol.mat.Rows; i++ {
+		det += 2 * math.Log(float64(c.chol.mat.Data[i*c.chol.mat.Stride+i]))
+	}
+	return det
+}
+
+// SolveTo finds the matrix X that solves A * X = B where A is represented
+// by the Cholesky decomposition. The result is stored in-place into dst.
+func (c *Cholesky32) SolveTo(dst *Dense32, b Matrix32) error {
+	if !c.valid() {
+		panic(badCholesky32)
+	}
+	n := c.chol.mat.Rows
+	bm, bn := b.Dims()
+	if n != bm {
+		panic(ErrShape)
+	}
+
+	dst.reuseAs(bm, bn)
+	bU, _ := untranspose32(b)
+	var restore func()
+	if dst == bU {
+		dst, restore = dst.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer32); ok {
+		dst.checkOverlap(rm.RawMatrix())
+	}
+	if b != dst {
+		dst.Copy(b)
+	}
+	lapack32.Potrs(c.triangular(), dst.mat)
+	return nil
+}
+
+// SolveVecTo finds the vector x that solves A * x = b where A is represented
+// by the Cholesky decomposition. The result is stored in-place into dst.
+func (c *Cholesky32) SolveVecTo(dst *VecDense32, b Vector32) error {
+	if !c.valid() {
+		panic(badCholesky32)
+	}
+	n := c.chol.mat.Rows
+	if br, bc := b.Dims(); br != n || bc != 1 {
+		panic(ErrShape)
+	}
+	dst.reuseAs(n)
+	if bv, ok := b.(*VecDense32); ok && dst != bv {
+		dst.checkOverlap(bv.mat)
+	}
+	return c.SolveTo(dst.asDense(), b)
+}
+
+// UTo extracts the n×n upper triangular matrix U from a Cholesky
+// decomposition into dst and returns the result. If dst is nil a new
+// Dense32 is allocated.
+//  A = U^T * U.
+func (c *Cholesky32) UTo(dst *Dense32) *Dense32 {
+	if !c.valid() {
+		panic(badCholesky32)
+	}
+	n := c.chol.mat.Rows
+	if dst == nil {
+		dst = NewDense32(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(c.chol)
+	return dst
+}
+
+// LTo extracts the n×n lower triangular matrix L from a Cholesky
+// decomposition into dst and returns the result. If dst is nil a new
+// Dense32 is allocated.
+//  A = L * L^T.
+func (c *Cholesky32) LTo(dst *Dense32) *Dense32 {
+	if !c.valid() {
+		panic(badCholesky32)
+	}
+	n := c.chol.mat.Rows
+	if dst == nil {
+		dst = NewDense32(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(c.chol.T())
+	return dst
+}
+
+// ToSym reconstructs the original positive definite matrix from its
+// Cholesky decomposition, storing the result into dst. If dst is nil
+// a new SymDense32 is allocated.
+func (c *Cholesky32) ToSym(dst *SymDense32) *SymDense32 {
+	if !c.valid() {
+		panic(badCholesky32)
+	}
+	if dst == nil {
+		dst = &SymDense32{}
+	}
+	dst.SymOuterK(1, c.chol.T())
+	return dst
+}
@@ -0,0 +1,89 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestCholesky32(t *testing.T) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		// Construct a symmetric positive definite matrix.
+		x := randDense32(n, n, rnd)
+		var a SymDense32
+		a.SymOuterK(1, x.T())
+		for i := 0; i < n; i++ {
+			a.SetSym(i, i, a.At(i, i)+float32(n))
+		}
+		a64 := a.To64(nil)
+
+		var chol Cholesky32
+		if !chol.Factorize(&a) {
+			t.Errorf("n=%d: unexpected factorization failure", n)
+			continue
+		}
+		u := chol.UTo(nil).To64(nil)
+		l := chol.LTo(nil).To64(nil)
+		var got Dense
+		got.Mul(u.T(), u)
+		if !EqualApprox(&got, a64, tol*float64(n)) {
+			t.Errorf("n=%d: U^T*U != A", n)
+		}
+		got.Mul(l, l.T())
+		if !EqualApprox(&got, a64, tol*float64(n)) {
+			t.Errorf("n=%d: L*L^T != A", n)
+		}
+		if !EqualApprox(chol.ToSym(nil).To64(nil), a64, tol*float64(n)) {
+			t.Errorf("n=%d: unexpected reconstruction of A", n)
+		}
+
+		var chol64 Cholesky
+		if !chol64.Factorize(a64) {
+			t.Fatalf("n=%d: unexpected float64 factorization failure", n)
+		}
+		want := chol64.LogDet()
+		if got := chol.LogDet(); math.Abs(got-want) > tol*math.Max(1, math.Abs(want)) {
+			t.Errorf("n=%d: unexpected log determinant: got %v, want %v", n, got, want)
+		}
+
+		b := randDense32(n, 3, rnd)
+		var sol Dense32
+		err := chol.SolveTo(&sol, b)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		var solWant Dense
+		err = chol64.SolveTo(&solWant, b.To64(nil))
+		if err != nil {
+			t.Fatalf("n=%d: unexpected error from float64 solve: %v", n, err)
+		}
+		if !EqualApprox(sol.To64(nil), &solWant, tol) {
+			t.Errorf("n=%d: unexpected solution", n)
+		}
+
+		var solVec VecDense32
+		err = chol.SolveVecTo(&solVec, b.ColView(2))
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		if !EqualApprox(solVec.To64(nil), solWant.ColView(2), tol) {
+			t.Errorf("n=%d: unexpected vector solution", n)
+		}
+	}
+
+	// Check that a non-positive-definite matrix is reported.
+	var chol Cholesky32
+	a := NewSymDense32(2, []float32{1, 2, 2, 1})
+	if chol.Factorize(a) {
+		t.Errorf("expected failure for indefinite matrix")
+	}
+}
@@ -536,6 +536,62 @@ func TestCholeskyExtendVecSym(t *testing.T) {
 	}
 }
 
+func TestCholeskyDeleteRowCol(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{2, 3, 5, 10, 25} {
+		data := make([]float64, n*n)
+		for i := range data {
+			data[i] = rnd.NormFloat64()
+		}
+		var a SymDense
+		a.SymOuterK(1, NewDense(n, n, data))
+
+		var chol Cholesky
+		ok := chol.Factorize(&a)
+		if !ok {
+			panic("mat: bad test, matrix not positive definite")
+		}
+		for _, k := range []int{0, n / 2, n - 1} {
+			set := make([]int, 0, n-1)
+			for i := 0; i < n; i++ {
+				if i != k {
+					set = append(set, i)
+				}
+			}
+			var want SymDense
+			want.SubsetSym(&a, set)
+			var cholFull Cholesky
+			ok := cholFull.Factorize(&want)
+			if !ok {
+				panic("mat: bad test, subset is not positive definite")
+			}
+
+			var cholNew Cholesky
+			cholNew.DeleteRowCol(&chol, k)
+			got := cholNew.ToSym(nil)
+			if !EqualApprox(got, &want, 1e-10) {
+				t.Errorf("n=%d, k=%d: mismatch", n, k)
+			}
+			// The condition number with the updated rule is an
+			// overestimate, so only compare the factors.
+			if !EqualApprox(cholNew.chol, cholFull.chol, 1e-10) {
+				t.Errorf("n=%d, k=%d: updated Cholesky does not match full", n, k)
+			}
+			if cholNew.Cond() < 0.5*cholFull.Cond() {
+				t.Errorf("n=%d, k=%d: condition number underestimated: got %v, want at least %v", n, k, cholNew.Cond(), cholFull.Cond())
+			}
+
+			// Test in-place.
+			var cholInPlace Cholesky
+			cholInPlace.Clone(&chol)
+			cholInPlace.DeleteRowCol(&cholInPlace, k)
+			if !equalChol(&cholInPlace, &cholNew) {
+				t.Errorf("n=%d, k=%d: Cholesky different in-place vs. new", n, k)
+			}
+		}
+	}
+}
+
 func TestCholeskyScale(t *testing.T) {
 	for cas, test := range []struct {
 		a *SymDense
@@ -0,0 +1,223 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack/lapack128"
+)
+
+const badCLU = "mat: invalid complex LU factorization"
+
+// CLU is a type for creating and using the LU factorization of a complex matrix.
+type CLU struct {
+	lu    *CDense
+	pivot []int
+}
+
+// Factorize computes the LU factorization of the square complex matrix a and
+// stores the result. The LU decomposition will complete regardless of the
+// singularity of a.
+//
+// The LU factorization is computed with pivoting, and so really the decomposition
+// is a PLU decomposition where P is a permutation matrix. The individual matrix
+// factors can be extracted from the factorization using the Pivot method and
+// the CLU LTo and UTo methods.
+func (lu *CLU) Factorize(a CMatrix) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	if lu.lu == nil {
+		lu.lu = NewCDense(r, r, nil)
+	} else {
+		lu.lu.Reset()
+		lu.lu.reuseAs(r, r)
+	}
+	lu.lu.Copy(a)
+	if cap(lu.pivot) < r {
+		lu.pivot = make([]int, r)
+	}
+	lu.pivot = lu.pivot[:r]
+	lapack128.Getrf(lu.lu.mat, lu.pivot)
+}
+
+// isValid returns whether the receiver contains a factorization.
+func (lu *CLU) isValid() bool {
+	return lu.lu != nil && !lu.lu.IsZero()
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (lu *CLU) Reset() {
+	if lu.lu != nil {
+		lu.lu.Reset()
+	}
+	lu.pivot = lu.pivot[:0]
+}
+
+// Det returns the determinant of the matrix that has been factorized. In many
+// expressions, using LogDet will be more numerically stable.
+// Det will panic if the receiver does not contain a factorization.
+func (lu *CLU) Det() complex128 {
+	det, phase := lu.LogDet()
+	return complex(math.Exp(det), 0) * phase
+}
+
+// LogDet returns the log of the absolute value of the determinant and the phase
+// of the determinant for the matrix that has been factorized, such that
+//  det(A) = exp(det) * phase
+// where phase has unit modulus, or is zero if the matrix is exactly singular.
+// Numerical stability in product and division expressions is generally
+// improved by working in log space.
+// LogDet will panic if the receiver does not contain a factorization.
+func (lu *CLU) LogDet() (det float64, phase complex128) {
+	if !lu.isValid() {
+		panic(badCLU)
+	}
+
+	_, n := lu.lu.Dims()
+	phase = 1
+	for i := 0; i < n; i++ {
+		v := lu.lu.at(i, i)
+		abs := cmplx.Abs(v)
+		if abs == 0 {
+			return math.Inf(-1), 0
+		}
+		phase *= v / complex(abs, 0)
+		if lu.pivot[i] != i {
+			phase = -phase
+		}
+		det += math.Log(abs)
+	}
+	return det, phase
+}
+
+// Pivot returns pivot indices that enable the construction of the permutation
+// matrix P (see Dense.Permutation). If swaps == nil, then new memory will be
+// allocated, otherwise the length of the input must be equal to the size of the
+// factorized matrix.
+// Pivot will panic if the receiver does not contain a factorization.
+func (lu *CLU) Pivot(swaps []int) []int {
+	if !lu.isValid() {
+		panic(badCLU)
+	}
+
+	_, n := lu.lu.Dims()
+	if swaps == nil {
+		swaps = make([]int, n)
+	}
+	if len(swaps) != n {
+		panic(badSliceLength)
+	}
+	// Perform the inverse of the row swaps in order to find the final
+	// row swap position.
+	for i := range swaps {
+		swaps[i] = i
+	}
+	for i := n - 1; i >= 0; i-- {
+		v := lu.pivot[i]
+		swaps[i], swaps[v] = swaps[v], swaps[i]
+	}
+	return swaps
+}
+
+// LTo extracts the unit lower triangular matrix from an LU factorization.
+// If dst is nil, a new matrix is allocated. The resulting L matrix is returned.
+// LTo will panic if the receiver does not contain a factorization.
+func (lu *CLU) LTo(dst *CDense) *CDense {
+	if !lu.isValid() {
+		panic(badCLU)
+	}
+
+	_, n := lu.lu.Dims()
+	if dst == nil {
+		dst = NewCDense(n, n, nil)
+	} else {
+		dst.reuseAsZeroed(n, n)
+	}
+	// Extract the lower triangular elements.
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			dst.mat.Data[i*dst.mat.Stride+j] = lu.lu.mat.Data[i*lu.lu.mat.Stride+j]
+		}
+	}
+	// Set ones on the diagonal.
+	for i := 0; i < n; i++ {
+		dst.mat.Data[i*dst.mat.Stride+i] = 1
+	}
+	return dst
+}
+
+// UTo extracts the upper triangular matrix from an LU factorization.
+// If dst is nil, a new matrix is allocated. The resulting U matrix is returned.
+// UTo will panic if the receiver does not contain a factorization.
+func (lu *CLU) UTo(dst *CDense) *CDense {
+	if !lu.isValid() {
+		panic(badCLU)
+	}
+
+	_, n := lu.lu.Dims()
+	if dst == nil {
+		dst = NewCDense(n, n, nil)
+	} else {
+		dst.reuseAsZeroed(n, n)
+	}
+	// Extract the upper triangular elements.
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			dst.mat.Data[i*dst.mat.Stride+j] = lu.lu.mat.Data[i*lu.lu.mat.Stride+j]
+		}
+	}
+	return dst
+}
+
+// SolveTo solves a system of linear equations using the LU decomposition of a
+// complex matrix. It computes
+//  A * X = B if trans == false
+//  A^H * X = B if trans == true
+// In both cases, A is represented in LU factorized form, and the matrix X is
+// stored into dst.
+//
+// If A is exactly singular a Condition error is returned. No estimate of the
+// condition number is made for complex matrices.
+// SolveTo will panic if the receiver does not contain a factorization.
+func (lu *CLU) SolveTo(dst *CDense, trans bool, b CMatrix) error {
+	if !lu.isValid() {
+		panic(badCLU)
+	}
+
+	_, n := lu.lu.Dims()
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	for i := 0; i < n; i++ {
+		if lu.lu.at(i, i) == 0 {
+			return Condition(math.Inf(1))
+		}
+	}
+
+	dst.reuseAs(n, bc)
+	bU, _ := unconjugate(b)
+	var restore func()
+	if dst == bU {
+		dst, restore = dst.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawCMatrixer); ok {
+		dst.checkOverlap(rm.RawCMatrix())
+	}
+
+	dst.Copy(b)
+	t := blas.NoTrans
+	if trans {
+		t = blas.ConjTrans
+	}
+	lapack128.Getrs(t, lu.lu.mat, dst.mat, lu.pivot)
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestCLU(t *testing.T) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		a := randCDense(n, n, rnd)
+		var lu CLU
+		lu.Factorize(a)
+
+		l := lu.LTo(nil)
+		u := lu.UTo(nil)
+		for i := 0; i < n; i++ {
+			if l.At(i, i) != 1 {
+				t.Errorf("n=%d: L does not have unit diagonal", n)
+			}
+			for j := i + 1; j < n; j++ {
+				if l.At(i, j) != 0 || u.At(j, i) != 0 {
+					t.Errorf("n=%d: factors are not triangular", n)
+				}
+			}
+		}
+		var p Dense
+		p.Permutation(n, lu.Pivot(nil))
+		cp := NewCDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				cp.set(i, j, complex(p.At(i, j), 0))
+			}
+		}
+		if !CEqualApprox(cmul(cmul(cp, l), u), a, tol) {
+			t.Errorf("n=%d: P*L*U != A", n)
+		}
+
+		// Check the determinant against the product of the diagonal of U
+		// and the sign of the permutation.
+		want := complex(Det(&p), 0)
+		for i := 0; i < n; i++ {
+			want *= u.At(i, i)
+		}
+		if got := lu.Det(); cmplx.Abs(got-want) > tol*cmplx.Abs(want) {
+			t.Errorf("n=%d: unexpected determinant: got %v, want %v", n, got, want)
+		}
+
+		for _, trans := range []bool{false, true} {
+			b := randCDense(n, 3, rnd)
+			var x CDense
+			err := lu.SolveTo(&x, trans, b)
+			if err != nil {
+				t.Errorf("n=%d: unexpected error: %v", n, err)
+				continue
+			}
+			var ax *CDense
+			if trans {
+				ax = cmul(a.H(), &x)
+			} else {
+				ax = cmul(a, &x)
+			}
+			if !CEqualApprox(ax, b, tol) {
+				t.Errorf("n=%d, trans=%t: unexpected solution", n, trans)
+			}
+		}
+	}
+
+	var lu CLU
+	lu.Factorize(NewCDense(2, 2, []complex128{1, 1i, 1i, -1}))
+	if lu.Det() != 0 {
+		t.Errorf("unexpected non-zero determinant for singular matrix")
+	}
+	var x CDense
+	if err := lu.SolveTo(&x, false, NewCDense(2, 1, nil)); err == nil {
+		t.Errorf("expected error solving singular system")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"math"
 	"math/cmplx"
 
+	"gonum.org/v1/gonum/blas/cblas128"
 	"gonum.org/v1/gonum/floats"
 )
 
@@ -77,6 +78,84 @@ type Unconjugator interface {
 	Unconjugate() CMatrix
 }
 
+// A RawCMatrixSetter can set the underlying cblas128.General used by the receiver. There is no restriction
+// on the shape of the receiver. Changes to the receiver's elements will be reflected in the cblas128.General.Data.
+type RawCMatrixSetter interface {
+	SetRawCMatrix(a cblas128.General)
+}
+
+// A RawCMatrixer can return a cblas128.General representation of the receiver. Changes to the cblas128.General.Data
+// slice will be reflected in the original matrix, changes to the Rows, Cols and Stride fields will not.
+type RawCMatrixer interface {
+	RawCMatrix() cblas128.General
+}
+
+// CNorm returns the specified (induced) norm of the complex matrix a. See
+// https://en.wikipedia.org/wiki/Matrix_norm for the definition of an induced norm.
+//
+// Valid norms are:
+//    1 - The maximum absolute column sum
+//    2 - Frobenius norm, the square root of the sum of the squared moduli of the elements.
+//  Inf - The maximum absolute row sum.
+// CNorm will panic with ErrNormOrder if an illegal norm order is specified and
+// with matrix.ErrShape if the matrix has zero size.
+func CNorm(a CMatrix, norm float64) float64 {
+	r, c := a.Dims()
+	if r == 0 || c == 0 {
+		panic(ErrShape)
+	}
+	switch norm {
+	default:
+		panic(ErrNormOrder)
+	case 1:
+		var max float64
+		for j := 0; j < c; j++ {
+			var sum float64
+			for i := 0; i < r; i++ {
+				sum += cmplx.Abs(a.At(i, j))
+			}
+			if sum > max {
+				max = sum
+			}
+		}
+		return max
+	case 2:
+		// Scale the sum to avoid unnecessary overflow and underflow.
+		scale := 0.0
+		sumSq := 1.0
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				v := a.At(i, j)
+				for _, x := range [2]float64{real(v), imag(v)} {
+					if x == 0 {
+						continue
+					}
+					absx := math.Abs(x)
+					if scale < absx {
+						sumSq = 1 + sumSq*(scale/absx)*(scale/absx)
+						scale = absx
+					} else {
+						sumSq += (absx / scale) * (absx / scale)
+					}
+				}
+			}
+		}
+		return scale * math.Sqrt(sumSq)
+	case math.Inf(1):
+		var max float64
+		for i := 0; i < r; i++ {
+			var sum float64
+			for j := 0; j < c; j++ {
+				sum += cmplx.Abs(a.At(i, j))
+			}
+			if sum > max {
+				max = sum
+			}
+		}
+		return max
+	}
+}
+
 // useC returns a complex128 slice with l elements, using c if it
 // has the necessary capacity, otherwise creating a new slice.
 func useC(c []complex128, l int) []complex128 {
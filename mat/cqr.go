@@ -0,0 +1,174 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/lapack/lapack128"
+)
+
+const badCQR = "mat: invalid complex QR factorization"
+
+// CQR is a type for creating and using the QR factorization of a complex matrix.
+type CQR struct {
+	qr  *CDense
+	tau []complex128
+}
+
+// Factorize computes the QR factorization of an m×n complex matrix a where
+// m >= n. The QR factorization always exists even if A is singular.
+//
+// The QR decomposition is a factorization of the matrix A such that A = Q * R.
+// The matrix Q is a unitary m×m matrix, and R is an m×n upper triangular matrix.
+// Q and R can be extracted using the QTo and RTo methods.
+func (qr *CQR) Factorize(a CMatrix) {
+	m, n := a.Dims()
+	if m < n {
+		panic(ErrShape)
+	}
+	k := min(m, n)
+	if qr.qr == nil {
+		qr.qr = &CDense{}
+	}
+	qr.qr.Clone(a)
+	work := []complex128{0}
+	qr.tau = make([]complex128, k)
+	lapack128.Geqrf(qr.qr.mat, qr.tau, work, -1)
+
+	work = make([]complex128, int(real(work[0])))
+	lapack128.Geqrf(qr.qr.mat, qr.tau, work, len(work))
+}
+
+// isValid returns whether the receiver contains a factorization.
+func (qr *CQR) isValid() bool {
+	return qr.qr != nil && !qr.qr.IsZero()
+}
+
+// RTo extracts the m×n upper trapezoidal matrix from a QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting dst matrix is returned.
+// RTo will panic if the receiver does not contain a factorization.
+func (qr *CQR) RTo(dst *CDense) *CDense {
+	if !qr.isValid() {
+		panic(badCQR)
+	}
+
+	r, c := qr.qr.Dims()
+	if dst == nil {
+		dst = NewCDense(r, c, nil)
+	} else {
+		dst.reuseAsZeroed(r, c)
+	}
+
+	// Extract the upper triangular elements.
+	for i := 0; i < c; i++ {
+		for j := i; j < c; j++ {
+			dst.mat.Data[i*dst.mat.Stride+j] = qr.qr.mat.Data[i*qr.qr.mat.Stride+j]
+		}
+	}
+	return dst
+}
+
+// QTo extracts the m×m unitary matrix Q from a QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting Q matrix is returned.
+// QTo will panic if the receiver does not contain a factorization.
+func (qr *CQR) QTo(dst *CDense) *CDense {
+	if !qr.isValid() {
+		panic(badCQR)
+	}
+
+	r, _ := qr.qr.Dims()
+	if dst == nil {
+		dst = NewCDense(r, r, nil)
+	} else {
+		dst.reuseAsZeroed(r, r)
+	}
+
+	// Set Q = I.
+	for i := 0; i < r*r; i += r + 1 {
+		dst.mat.Data[i] = 1
+	}
+
+	// Construct Q from the elementary reflectors.
+	work := []complex128{0}
+	lapack128.Unmqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, dst.mat, work, -1)
+	work = make([]complex128, int(real(work[0])))
+	lapack128.Unmqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, dst.mat, work, len(work))
+
+	return dst
+}
+
+// SolveTo finds a minimum-norm solution to a system of linear equations defined
+// by the matrices A and b, where A is an m×n complex matrix represented in its
+// QR factorized form. If A is exactly singular a Condition error is returned.
+//
+// The minimization problem solved depends on the input parameters.
+//  If trans == false, find X such that ||A*X - B||_2 is minimized.
+//  If trans == true, find the minimum norm solution of A^H * X = B.
+// The solution matrix, X, is stored in place into dst.
+// SolveTo will panic if the receiver does not contain a factorization.
+func (qr *CQR) SolveTo(dst *CDense, trans bool, b CMatrix) error {
+	if !qr.isValid() {
+		panic(badCQR)
+	}
+
+	r, c := qr.qr.Dims()
+	br, bc := b.Dims()
+
+	// The QR solve algorithm stores the result in-place into the right hand side.
+	// The storage for the answer must be large enough to hold both b and x.
+	// However, this method's receiver must be the size of x. Copy b, and then
+	// copy the result into dst at the end.
+	if trans {
+		if c != br {
+			panic(ErrShape)
+		}
+		dst.reuseAs(r, bc)
+	} else {
+		if r != br {
+			panic(ErrShape)
+		}
+		dst.reuseAs(c, bc)
+	}
+	// Do not need to worry about overlap between dst and b because x has its
+	// own independent storage.
+	w := NewCDense(max(r, c), bc, nil)
+	w.Copy(b)
+	t := cblas128.Triangular{
+		N:      c,
+		Stride: qr.qr.mat.Stride,
+		Data:   qr.qr.mat.Data,
+		Uplo:   blas.Upper,
+		Diag:   blas.NonUnit,
+	}
+	if trans {
+		ok := lapack128.Trtrs(blas.ConjTrans, t, w.mat)
+		if !ok {
+			return Condition(math.Inf(1))
+		}
+		for i := c; i < r; i++ {
+			zeroC(w.mat.Data[i*w.mat.Stride : i*w.mat.Stride+bc])
+		}
+		work := []complex128{0}
+		lapack128.Unmqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, w.mat, work, -1)
+		work = make([]complex128, int(real(work[0])))
+		lapack128.Unmqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, w.mat, work, len(work))
+	} else {
+		work := []complex128{0}
+		lapack128.Unmqr(blas.Left, blas.ConjTrans, qr.qr.mat, qr.tau, w.mat, work, -1)
+		work = make([]complex128, int(real(work[0])))
+		lapack128.Unmqr(blas.Left, blas.ConjTrans, qr.qr.mat, qr.tau, w.mat, work, len(work))
+
+		ok := lapack128.Trtrs(blas.NoTrans, t, w.mat)
+		if !ok {
+			return Condition(math.Inf(1))
+		}
+	}
+	// X was set above to be the correct size for the result.
+	dst.Copy(w)
+	return nil
+}
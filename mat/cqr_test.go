@@ -0,0 +1,72 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestCQR(t *testing.T) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{3, 3},
+		{5, 3},
+		{10, 10},
+		{30, 7},
+	} {
+		m, n := test.m, test.n
+		a := randCDense(m, n, rnd)
+		var qr CQR
+		qr.Factorize(a)
+
+		q := qr.QTo(nil)
+		r := qr.RTo(nil)
+		if !CEqualApprox(cmul(q.H(), q), ceye(m), tol) {
+			t.Errorf("%d×%d: Q is not unitary", m, n)
+		}
+		for i := 0; i < m; i++ {
+			for j := 0; j < min(i, n); j++ {
+				if r.At(i, j) != 0 {
+					t.Errorf("%d×%d: R is not upper triangular", m, n)
+				}
+			}
+		}
+		if !CEqualApprox(cmul(q, r), a, tol) {
+			t.Errorf("%d×%d: Q*R != A", m, n)
+		}
+
+		// Least squares solution.
+		b := randCDense(m, 2, rnd)
+		var x CDense
+		err := qr.SolveTo(&x, false, b)
+		if err != nil {
+			t.Errorf("%d×%d: unexpected error: %v", m, n, err)
+			continue
+		}
+		var res CDense
+		res.Sub(cmul(a, &x), b)
+		if CNorm(cmul(a.H(), &res), 2) > tol {
+			t.Errorf("%d×%d: residual not orthogonal to range of A", m, n)
+		}
+
+		// Minimum norm solution of A^H * X = B.
+		b = randCDense(n, 2, rnd)
+		x.Reset()
+		err = qr.SolveTo(&x, true, b)
+		if err != nil {
+			t.Errorf("%d×%d: unexpected error: %v", m, n, err)
+			continue
+		}
+		if !CEqualApprox(cmul(a.H(), &x), b, tol) {
+			t.Errorf("%d×%d: A^H*X != B", m, n)
+		}
+	}
+}
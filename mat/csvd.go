@@ -0,0 +1,224 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/lapack128"
+)
+
+// CSVD is a type for creating and using the Singular Value Decomposition (SVD)
+// of a complex matrix.
+type CSVD struct {
+	kind SVDKind
+
+	s  []float64
+	u  cblas128.General
+	vt cblas128.General
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (svd *CSVD) succFact() bool {
+	return len(svd.s) != 0
+}
+
+// Factorize computes the singular value decomposition (SVD) of the complex
+// input matrix A. The singular values of A are computed in all cases, while
+// the singular vectors are optionally computed depending on the input kind.
+//
+// The full singular value decomposition (kind == SVDFull) is a factorization
+// of an m×n matrix A of the form
+//  A = U * Σ * V^H
+// where Σ is an m×n real diagonal matrix, U is an m×m unitary matrix, and V is
+// an n×n unitary matrix. The diagonal elements of Σ are the singular values of
+// A. The first min(m,n) columns of U and V are, respectively, the left and
+// right singular vectors of A.
+//
+// The thin SVD (kind == SVDThin) finds
+//  A = U~ * Σ * V~^H
+// where U~ is of size m×min(m,n), Σ is a diagonal matrix of size min(m,n)×min(m,n)
+// and V~ is of size n×min(m,n).
+//
+// Factorize returns whether the decomposition succeeded. If the decomposition
+// failed, routines that require a successful factorization will panic.
+func (svd *CSVD) Factorize(a CMatrix, kind SVDKind) (ok bool) {
+	// kill previous factorization
+	svd.s = svd.s[:0]
+	svd.kind = kind
+
+	m, n := a.Dims()
+	k := min(m, n)
+	var jobU, jobVT lapack.SVDJob
+	lrwork := 5 * k
+	switch {
+	case kind&SVDFullU != 0:
+		jobU = lapack.SVDAll
+		svd.u = cblas128.General{
+			Rows:   m,
+			Cols:   m,
+			Stride: m,
+			Data:   useC(svd.u.Data, m*m),
+		}
+		lrwork += k * k
+	case kind&SVDThinU != 0:
+		jobU = lapack.SVDStore
+		svd.u = cblas128.General{
+			Rows:   m,
+			Cols:   k,
+			Stride: k,
+			Data:   useC(svd.u.Data, m*k),
+		}
+		lrwork += k * k
+	default:
+		jobU = lapack.SVDNone
+	}
+	switch {
+	case kind&SVDFullV != 0:
+		svd.vt = cblas128.General{
+			Rows:   n,
+			Cols:   n,
+			Stride: n,
+			Data:   useC(svd.vt.Data, n*n),
+		}
+		jobVT = lapack.SVDAll
+		lrwork += k * k
+	case kind&SVDThinV != 0:
+		svd.vt = cblas128.General{
+			Rows:   k,
+			Cols:   n,
+			Stride: n,
+			Data:   useC(svd.vt.Data, k*n),
+		}
+		jobVT = lapack.SVDStore
+		lrwork += k * k
+	default:
+		jobVT = lapack.SVDNone
+	}
+
+	// A is destroyed on call, so copy the matrix.
+	aCopy := CDenseCopyOf(a)
+	svd.kind = kind
+	svd.s = use(svd.s, k)
+
+	work := []complex128{0}
+	lapack128.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, -1, nil)
+	work = make([]complex128, int(real(work[0])))
+	rwork := getFloats(lrwork, false)
+	ok = lapack128.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work), rwork)
+	putFloats(rwork)
+	if !ok {
+		svd.kind = 0
+	}
+	return ok
+}
+
+// Kind returns the SVDKind of the decomposition. If no decomposition has been
+// computed, Kind returns -1.
+func (svd *CSVD) Kind() SVDKind {
+	if !svd.succFact() {
+		return -1
+	}
+	return svd.kind
+}
+
+// Cond returns the 2-norm condition number for the factorized matrix. Cond will
+// panic if the receiver does not contain a successful factorization.
+func (svd *CSVD) Cond() float64 {
+	if !svd.succFact() {
+		panic(badFact)
+	}
+	return svd.s[0] / svd.s[len(svd.s)-1]
+}
+
+// Values returns the singular values of the factorized matrix in descending order.
+//
+// If the input slice is non-nil, the values will be stored in-place into
+// the slice. In this case, the slice must have length min(m,n), and Values will
+// panic with ErrSliceLengthMismatch otherwise. If the input slice is nil, a new
+// slice of the appropriate length will be allocated and returned.
+//
+// Values will panic if the receiver does not contain a successful factorization.
+func (svd *CSVD) Values(s []float64) []float64 {
+	if !svd.succFact() {
+		panic(badFact)
+	}
+	if s == nil {
+		s = make([]float64, len(svd.s))
+	}
+	if len(s) != len(svd.s) {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(s, svd.s)
+	return s
+}
+
+// UTo extracts the matrix U from the singular value decomposition. The first
+// min(m,n) columns are the left singular vectors and correspond to the singular
+// values as returned from CSVD.Values.
+//
+// If dst is not nil, U is stored in-place into dst, and dst must have size
+// m×m if the full U was computed, size m×min(m,n) if the thin U was computed,
+// and UTo panics otherwise. If dst is nil, a new matrix of the appropriate size
+// is allocated and returned.
+func (svd *CSVD) UTo(dst *CDense) *CDense {
+	if !svd.succFact() {
+		panic(badFact)
+	}
+	kind := svd.kind
+	if kind&SVDThinU == 0 && kind&SVDFullU == 0 {
+		panic("svd: u not computed during factorization")
+	}
+	r := svd.u.Rows
+	c := svd.u.Cols
+	if dst == nil {
+		dst = NewCDense(r, c, nil)
+	} else {
+		dst.reuseAs(r, c)
+	}
+
+	tmp := &CDense{
+		mat:     svd.u,
+		capRows: r,
+		capCols: c,
+	}
+	dst.Copy(tmp)
+
+	return dst
+}
+
+// VTo extracts the matrix V from the singular value decomposition. The first
+// min(m,n) columns are the right singular vectors and correspond to the singular
+// values as returned from CSVD.Values.
+//
+// If dst is not nil, V is stored in-place into dst, and dst must have size
+// n×n if the full V was computed, size n×min(m,n) if the thin V was computed,
+// and VTo panics otherwise. If dst is nil, a new matrix of the appropriate size
+// is allocated and returned.
+func (svd *CSVD) VTo(dst *CDense) *CDense {
+	if !svd.succFact() {
+		panic(badFact)
+	}
+	kind := svd.kind
+	if kind&SVDThinV == 0 && kind&SVDFullV == 0 {
+		panic("svd: v not computed during factorization")
+	}
+	r := svd.vt.Rows
+	c := svd.vt.Cols
+	if dst == nil {
+		dst = NewCDense(c, r, nil)
+	} else {
+		dst.reuseAs(c, r)
+	}
+
+	tmp := &CDense{
+		mat:     svd.vt,
+		capRows: r,
+		capCols: c,
+	}
+	dst.Copy(tmp.H())
+
+	return dst
+}
@@ -0,0 +1,82 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestCSVD(t *testing.T) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{3, 3},
+		{5, 3},
+		{3, 5},
+		{10, 10},
+		{20, 7},
+		{7, 20},
+	} {
+		m, n := test.m, test.n
+		k := min(m, n)
+		a := randCDense(m, n, rnd)
+		for _, kind := range []SVDKind{SVDThin, SVDFull} {
+			var svd CSVD
+			if !svd.Factorize(a, kind) {
+				t.Errorf("%d×%d: unexpected factorization failure", m, n)
+				continue
+			}
+			s := svd.Values(nil)
+			if !sort.IsSorted(sort.Reverse(sort.Float64Slice(s))) {
+				t.Errorf("%d×%d: singular values not in descending order", m, n)
+			}
+			u := svd.UTo(nil)
+			v := svd.VTo(nil)
+			ur, uc := u.Dims()
+			vr, vc := v.Dims()
+			if kind == SVDFull {
+				if ur != m || uc != m || vr != n || vc != n {
+					t.Errorf("%d×%d: unexpected dimensions of full U and V", m, n)
+					continue
+				}
+			} else if ur != m || uc != k || vr != n || vc != k {
+				t.Errorf("%d×%d: unexpected dimensions of thin U and V", m, n)
+				continue
+			}
+			if !CEqualApprox(cmul(u.H(), u), ceye(uc), tol) {
+				t.Errorf("%d×%d: U does not have orthonormal columns", m, n)
+			}
+			if !CEqualApprox(cmul(v.H(), v), ceye(vc), tol) {
+				t.Errorf("%d×%d: V does not have orthonormal columns", m, n)
+			}
+			sigma := NewCDense(uc, vc, nil)
+			for i, sv := range s {
+				sigma.set(i, i, complex(sv, 0))
+			}
+			if !CEqualApprox(cmul(cmul(u, sigma), v.H()), a, tol) {
+				t.Errorf("%d×%d: U*Σ*V^H != A", m, n)
+			}
+		}
+
+		// Check that the singular values agree when not computing vectors.
+		var full, none CSVD
+		full.Factorize(a, SVDFull)
+		if !none.Factorize(a, SVDNone) {
+			t.Errorf("%d×%d: unexpected factorization failure without vectors", m, n)
+			continue
+		}
+		if !floats.EqualApprox(none.Values(nil), full.Values(nil), tol) {
+			t.Errorf("%d×%d: singular values differ when not computing vectors", m, n)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import "gonum.org/v1/gonum/blas/cblas128"
+
+var (
+	_ CVector = (*CVecDense)(nil)
+)
+
+// CVector is a complex vector.
+type CVector interface {
+	CMatrix
+	AtVec(int) complex128
+	Len() int
+}
+
+// CVecDense represents a complex column vector.
+type CVecDense struct {
+	mat cblas128.Vector
+	n   int
+	// A BLAS vector can have a negative increment, but allowing this
+	// in the mat type complicates a lot of code, and doesn't gain anything.
+	// CVecDense must have positive increment in this package.
+}
+
+// NewCVecDense creates a new CVecDense of length n. If data == nil,
+// a new slice is allocated for the backing slice. If len(data) == n, data is
+// used as the backing slice, and changes to the elements of the returned CVecDense
+// will be reflected in data. If neither of these is true, NewCVecDense will panic.
+// NewCVecDense will panic if n is zero.
+func NewCVecDense(n int, data []complex128) *CVecDense {
+	if n <= 0 {
+		if n == 0 {
+			panic(ErrZeroLength)
+		}
+		panic("mat: negative dimension")
+	}
+	if len(data) != n && data != nil {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]complex128, n)
+	}
+	return &CVecDense{
+		mat: cblas128.Vector{
+			Inc:  1,
+			Data: data,
+		},
+		n: n,
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix. Columns is always 1
+// for a non-Reset vector.
+func (v *CVecDense) Dims() (r, c int) {
+	if v.IsZero() {
+		return 0, 0
+	}
+	return v.n, 1
+}
+
+// Len returns the length of the vector.
+func (v *CVecDense) Len() int {
+	return v.n
+}
+
+// H performs an implicit conjugate transpose by returning the receiver inside a
+// Conjugate.
+func (v *CVecDense) H() CMatrix {
+	return Conjugate{v}
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized vectors can be the
+// receiver for size-restricted operations. CVecDenses can be zeroed using Reset.
+func (v *CVecDense) IsZero() bool {
+	// It must be the case that v.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return v.mat.Inc == 0
+}
+
+// Reset zeros the length of the vector so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (v *CVecDense) Reset() {
+	// No change of Inc or n to 0 may be
+	// made unless both are set to 0.
+	v.mat.Inc = 0
+	v.n = 0
+	v.mat.Data = v.mat.Data[:0]
+}
+
+// Zero sets all of the vector elements to zero.
+func (v *CVecDense) Zero() {
+	for i := 0; i < v.n; i++ {
+		v.mat.Data[v.mat.Inc*i] = 0
+	}
+}
+
+// RawCVector returns the underlying cblas128.Vector used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned cblas128.Vector.
+func (v *CVecDense) RawCVector() cblas128.Vector {
+	return v.mat
+}
+
+// ColViewOf reflects the column j of the RawCMatrixer m, into the receiver
+// backed by the same underlying data. The length of the receiver must either be
+// zero or match the number of rows in m.
+func (v *CVecDense) ColViewOf(m RawCMatrixer, j int) {
+	rm := m.RawCMatrix()
+
+	if j >= rm.Cols || j < 0 {
+		panic(ErrColAccess)
+	}
+	if !v.IsZero() && v.n != rm.Rows {
+		panic(ErrShape)
+	}
+
+	v.mat.Inc = rm.Stride
+	v.mat.Data = rm.Data[j : (rm.Rows-1)*rm.Stride+j+1]
+	v.n = rm.Rows
+}
+
+// RowViewOf reflects the row i of the RawCMatrixer m, into the receiver
+// backed by the same underlying data. The length of the receiver must either be
+// zero or match the number of columns in m.
+func (v *CVecDense) RowViewOf(m RawCMatrixer, i int) {
+	rm := m.RawCMatrix()
+
+	if i >= rm.Rows || i < 0 {
+		panic(ErrRowAccess)
+	}
+	if !v.IsZero() && v.n != rm.Cols {
+		panic(ErrShape)
+	}
+
+	v.mat.Inc = 1
+	v.mat.Data = rm.Data[i*rm.Stride : i*rm.Stride+rm.Cols]
+	v.n = rm.Cols
+}
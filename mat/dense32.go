@@ -0,0 +1,367 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import "gonum.org/v1/gonum/blas/blas32"
+
+var (
+	dense32 *Dense32
+
+	_ Matrix32      = dense32
+	_ RawMatrixer32 = dense32
+)
+
+// Dense32 is a dense matrix representation with single precision data.
+//
+// Dense32 halves the memory footprint and bandwidth of Dense at the cost of
+// precision, which makes it suitable for memory-bound workloads that tolerate
+// single precision rounding. Values can be converted to and from the float64
+// types using CloneFrom64 and To64.
+type Dense32 struct {
+	mat blas32.General
+
+	capRows, capCols int
+}
+
+// NewDense32 creates a new single precision Dense matrix with r rows and c
+// columns. If data == nil, a new slice is allocated for the backing slice.
+// If len(data) == r*c, data is used as the backing slice, and changes to the
+// elements of the returned Dense32 will be reflected in data.
+// If neither of these is true, NewDense32 will panic.
+// NewDense32 will panic if either r or c is zero.
+//
+// The data must be arranged in row-major order, i.e. the (i*c + j)-th
+// element in the data slice is the {i, j}-th element in the matrix.
+func NewDense32(r, c int, data []float32) *Dense32 {
+	if r <= 0 || c <= 0 {
+		if r == 0 || c == 0 {
+			panic(ErrZeroLength)
+		}
+		panic("mat: negative dimension")
+	}
+	if data != nil && r*c != len(data) {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]float32, r*c)
+	}
+	return &Dense32{
+		mat: blas32.General{
+			Rows:   r,
+			Cols:   c,
+			Stride: c,
+			Data:   data,
+		},
+		capRows: r,
+		capCols: c,
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *Dense32) Dims() (r, c int) {
+	return m.mat.Rows, m.mat.Cols
+}
+
+// Caps returns the number of rows and columns in the backing matrix.
+func (m *Dense32) Caps() (r, c int) { return m.capRows, m.capCols }
+
+// T performs an implicit transpose by returning the receiver inside a
+// Transpose32.
+func (m *Dense32) T() Matrix32 {
+	return Transpose32{m}
+}
+
+// reuseAs resizes an empty matrix to a r×c matrix,
+// or checks that a non-empty matrix is r×c.
+//
+// reuseAs must be kept in sync with reuseAsZeroed.
+func (m *Dense32) reuseAs(r, c int) {
+	if m.mat.Rows > m.capRows || m.mat.Cols > m.capCols {
+		// Panic as a string, not a mat.Error.
+		panic("mat: caps not correctly set")
+	}
+	if r == 0 || c == 0 {
+		panic(ErrZeroLength)
+	}
+	if m.IsZero() {
+		m.mat = blas32.General{
+			Rows:   r,
+			Cols:   c,
+			Stride: c,
+			Data:   use32(m.mat.Data, r*c),
+		}
+		m.capRows = r
+		m.capCols = c
+		return
+	}
+	if r != m.mat.Rows || c != m.mat.Cols {
+		panic(ErrShape)
+	}
+}
+
+func (m *Dense32) reuseAsZeroed(r, c int) {
+	// This must be kept in-sync with reuseAs.
+	if m.mat.Rows > m.capRows || m.mat.Cols > m.capCols {
+		// Panic as a string, not a mat.Error.
+		panic("mat: caps not correctly set")
+	}
+	if r == 0 || c == 0 {
+		panic(ErrZeroLength)
+	}
+	if m.IsZero() {
+		m.mat = blas32.General{
+			Rows:   r,
+			Cols:   c,
+			Stride: c,
+			Data:   useZeroed32(m.mat.Data, r*c),
+		}
+		m.capRows = r
+		m.capCols = c
+		return
+	}
+	if r != m.mat.Rows || c != m.mat.Cols {
+		panic(ErrShape)
+	}
+	m.Zero()
+}
+
+// isolatedWorkspace returns a new dense matrix w with the size of a and
+// returns a callback to defer which performs cleanup at the return of the call.
+// This should be used when a method receiver is the same pointer as an input argument.
+func (m *Dense32) isolatedWorkspace(a Matrix32) (w *Dense32, restore func()) {
+	r, c := a.Dims()
+	if r == 0 || c == 0 {
+		panic(ErrZeroLength)
+	}
+	w = NewDense32(r, c, nil)
+	return w, func() {
+		m.Copy(w)
+	}
+}
+
+// Reset zeros the dimensions of the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (m *Dense32) Reset() {
+	// Row, Cols and Stride must be zeroed in unison.
+	m.mat.Rows, m.mat.Cols, m.mat.Stride = 0, 0, 0
+	m.capRows, m.capCols = 0, 0
+	m.mat.Data = m.mat.Data[:0]
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized matrices can be the
+// receiver for size-restricted operations. Dense32 matrices can be zeroed using Reset.
+func (m *Dense32) IsZero() bool {
+	// It must be the case that m.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return m.mat.Stride == 0
+}
+
+// Zero sets all of the matrix elements to zero.
+func (m *Dense32) Zero() {
+	r := m.mat.Rows
+	c := m.mat.Cols
+	for i := 0; i < r; i++ {
+		zero32(m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c])
+	}
+}
+
+// Copy makes a copy of elements of a into the receiver. It is similar to the
+// built-in copy; it copies as much as the overlap between the two matrices and
+// returns the number of rows and columns it copied.
+func (m *Dense32) Copy(a Matrix32) (r, c int) {
+	r, c = a.Dims()
+	if a == m {
+		return r, c
+	}
+	r = min(r, m.mat.Rows)
+	c = min(c, m.mat.Cols)
+	if r == 0 || c == 0 {
+		return 0, 0
+	}
+	aU, trans := untranspose32(a)
+	if rm, ok := aU.(RawMatrixer32); ok && aU != m {
+		amat := rm.RawMatrix()
+		if trans {
+			for i := 0; i < r; i++ {
+				row := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c]
+				for j := range row {
+					row[j] = amat.Data[j*amat.Stride+i]
+				}
+			}
+		} else {
+			for i := 0; i < r; i++ {
+				copy(m.mat.Data[i*m.mat.Stride:i*m.mat.Stride+c], amat.Data[i*amat.Stride:i*amat.Stride+c])
+			}
+		}
+		return r, c
+	}
+	if aU == m {
+		w := NewDense32(r, c, nil)
+		w.Copy(a)
+		a = w
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			m.set(i, j, a.At(i, j))
+		}
+	}
+	return r, c
+}
+
+// Dense32CopyOf returns a newly allocated copy of the elements of a.
+func Dense32CopyOf(a Matrix32) *Dense32 {
+	d := &Dense32{}
+	d.Clone(a)
+	return d
+}
+
+// Clone makes a copy of a into the receiver, overwriting the previous value of
+// the receiver. The clone operation does not make any restriction on shape and
+// will not cause shadowing.
+func (m *Dense32) Clone(a Matrix32) {
+	r, c := a.Dims()
+	w := NewDense32(r, c, nil)
+	w.Copy(a)
+	*m = *w
+}
+
+// CloneFrom64 makes a copy of the float64 matrix a into the receiver, rounding
+// each element to the nearest float32 value and overwriting the previous value
+// of the receiver.
+func (m *Dense32) CloneFrom64(a Matrix) {
+	r, c := a.Dims()
+	w := NewDense32(r, c, nil)
+	if rm, ok := a.(RawMatrixer); ok {
+		amat := rm.RawMatrix()
+		for i := 0; i < r; i++ {
+			row := w.mat.Data[i*c : (i+1)*c]
+			for j, v := range amat.Data[i*amat.Stride : i*amat.Stride+c] {
+				row[j] = float32(v)
+			}
+		}
+	} else {
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				w.set(i, j, float32(a.At(i, j)))
+			}
+		}
+	}
+	*m = *w
+}
+
+// To64 copies the elements of the receiver into dst as float64 values and
+// returns the result. If dst is nil, a new Dense is allocated, otherwise dst
+// must be zero-sized or have the same shape as the receiver.
+func (m *Dense32) To64(dst *Dense) *Dense {
+	r, c := m.Dims()
+	if dst == nil {
+		dst = NewDense(r, c, nil)
+	} else {
+		dst.reuseAs(r, c)
+	}
+	for i := 0; i < r; i++ {
+		row := dst.mat.Data[i*dst.mat.Stride : i*dst.mat.Stride+c]
+		for j, v := range m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c] {
+			row[j] = float64(v)
+		}
+	}
+	return dst
+}
+
+// SetRawMatrix sets the underlying blas32.General used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in b.
+func (m *Dense32) SetRawMatrix(b blas32.General) {
+	m.capRows, m.capCols = b.Rows, b.Cols
+	m.mat = b
+}
+
+// RawMatrix returns the underlying blas32.General used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned blas32.General.
+func (m *Dense32) RawMatrix() blas32.General { return m.mat }
+
+// Slice returns a new Matrix32 that shares backing data with the receiver.
+// The returned matrix starts at {i,j} of the receiver and extends k-i rows
+// and l-j columns. The final row in the resulting matrix is k-1 and the
+// final column is l-1.
+// Slice panics with ErrIndexOutOfRange if the slice is outside the capacity
+// of the receiver.
+func (m *Dense32) Slice(i, k, j, l int) Matrix32 {
+	mr, mc := m.Caps()
+	if i < 0 || mr <= i || j < 0 || mc <= j || k < i || mr < k || l < j || mc < l {
+		if i == k || j == l {
+			panic(ErrZeroLength)
+		}
+		panic(ErrIndexOutOfRange)
+	}
+	t := *m
+	t.mat.Data = t.mat.Data[i*t.mat.Stride+j : (k-1)*t.mat.Stride+l]
+	t.mat.Rows = k - i
+	t.mat.Cols = l - j
+	t.capRows -= i
+	t.capCols -= j
+	return &t
+}
+
+// ColView returns a Vector32 reflecting the column j, backed by the matrix data.
+//
+// See ColViewer for more information.
+func (m *Dense32) ColView(j int) Vector32 {
+	var v VecDense32
+	v.ColViewOf(m, j)
+	return &v
+}
+
+// RowView returns row i of the matrix data represented as a column vector,
+// backed by the matrix data.
+//
+// See RowViewer for more information.
+func (m *Dense32) RowView(i int) Vector32 {
+	var v VecDense32
+	v.RowViewOf(m, i)
+	return &v
+}
+
+// SetCol sets the values in the specified column of the matrix to the values
+// in src. len(src) must equal the number of rows in the receiver.
+func (m *Dense32) SetCol(j int, src []float32) {
+	if j >= m.mat.Cols || j < 0 {
+		panic(ErrColAccess)
+	}
+	if len(src) != m.mat.Rows {
+		panic(ErrColLength)
+	}
+	for i, v := range src {
+		m.mat.Data[i*m.mat.Stride+j] = v
+	}
+}
+
+// SetRow sets the values in the specified rows of the matrix to the values
+// in src. len(src) must equal the number of columns in the receiver.
+func (m *Dense32) SetRow(i int, src []float32) {
+	if i >= m.mat.Rows || i < 0 {
+		panic(ErrRowAccess)
+	}
+	if len(src) != m.mat.Cols {
+		panic(ErrRowLength)
+	}
+	copy(m.rawRowView(i), src)
+}
+
+// RawRowView returns a slice backed by the same array as backing the
+// receiver.
+func (m *Dense32) RawRowView(i int) []float32 {
+	if i >= m.mat.Rows || i < 0 {
+		panic(ErrRowAccess)
+	}
+	return m.rawRowView(i)
+}
+
+func (m *Dense32) rawRowView(i int) []float32 {
+	return m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+m.mat.Cols]
+}
@@ -0,0 +1,264 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/lapack/lapack32"
+)
+
+// Add adds a and b element-wise, placing the result in the receiver. Add
+// will panic if the two matrices do not have the same shape.
+func (m *Dense32) Add(a, b Matrix32) {
+	m.elementWise(a, b, func(x, y float32) float32 { return x + y })
+}
+
+// Sub subtracts the matrix b from a, placing the result in the receiver. Sub
+// will panic if the two matrices do not have the same shape.
+func (m *Dense32) Sub(a, b Matrix32) {
+	m.elementWise(a, b, func(x, y float32) float32 { return x - y })
+}
+
+// MulElem performs element-wise multiplication of a and b, placing the result
+// in the receiver. MulElem will panic if the two matrices do not have the same
+// shape.
+func (m *Dense32) MulElem(a, b Matrix32) {
+	m.elementWise(a, b, func(x, y float32) float32 { return x * y })
+}
+
+// DivElem performs element-wise division of a by b, placing the result
+// in the receiver. DivElem will panic if the two matrices do not have the same
+// shape.
+func (m *Dense32) DivElem(a, b Matrix32) {
+	m.elementWise(a, b, func(x, y float32) float32 { return x / y })
+}
+
+// elementWise applies the binary operation fn element-wise to a and b,
+// placing the result in the receiver.
+func (m *Dense32) elementWise(a, b Matrix32, fn func(x, y float32) float32) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+
+	aU, aTrans := untranspose32(a)
+	bU, bTrans := untranspose32(b)
+	m.reuseAs(ar, ac)
+
+	if arm, ok := a.(RawMatrixer32); ok {
+		if brm, ok := b.(RawMatrixer32); ok {
+			amat, bmat := arm.RawMatrix(), brm.RawMatrix()
+			if m != aU {
+				m.checkOverlap(amat)
+			}
+			if m != bU {
+				m.checkOverlap(bmat)
+			}
+			for ja, jb, jm := 0, 0, 0; ja < ar*amat.Stride; ja, jb, jm = ja+amat.Stride, jb+bmat.Stride, jm+m.mat.Stride {
+				for i, v := range amat.Data[ja : ja+ac] {
+					m.mat.Data[i+jm] = fn(v, bmat.Data[i+jb])
+				}
+			}
+			return
+		}
+	}
+
+	var restore func()
+	if aTrans && m == aU {
+		m, restore = m.isolatedWorkspace(aU)
+		defer restore()
+	} else if bTrans && m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	} else {
+		m.checkOverlapMatrix(aU)
+		m.checkOverlapMatrix(bU)
+	}
+
+	for r := 0; r < ar; r++ {
+		for c := 0; c < ac; c++ {
+			m.set(r, c, fn(a.At(r, c), b.At(r, c)))
+		}
+	}
+}
+
+// Scale multiplies the elements of a by f, placing the result in the receiver.
+func (m *Dense32) Scale(f float32, a Matrix32) {
+	m.Apply(func(_, _ int, v float32) float32 { return f * v }, a)
+}
+
+// Apply applies the function fn to each of the elements of a, placing the
+// resulting matrix in the receiver. The function fn takes a row/column
+// index and element value and returns some function of that tuple.
+func (m *Dense32) Apply(fn func(i, j int, v float32) float32, a Matrix32) {
+	ar, ac := a.Dims()
+
+	m.reuseAs(ar, ac)
+
+	aU, aTrans := untranspose32(a)
+	if rm, ok := aU.(RawMatrixer32); ok && !aTrans {
+		amat := rm.RawMatrix()
+		if m != aU {
+			m.checkOverlap(amat)
+		}
+		for j, ja, jm := 0, 0, 0; ja < ar*amat.Stride; j, ja, jm = j+1, ja+amat.Stride, jm+m.mat.Stride {
+			for i, v := range amat.Data[ja : ja+ac] {
+				m.mat.Data[i+jm] = fn(j, i, v)
+			}
+		}
+		return
+	}
+
+	if m == aU {
+		var restore func()
+		m, restore = m.isolatedWorkspace(a)
+		defer restore()
+	} else {
+		m.checkOverlapMatrix(aU)
+	}
+	for r := 0; r < ar; r++ {
+		for c := 0; c < ac; c++ {
+			m.set(r, c, fn(r, c, a.At(r, c)))
+		}
+	}
+}
+
+// Mul takes the matrix product of a and b, placing the result in the receiver.
+// If the number of columns in a does not equal the number of rows in b, Mul will panic.
+func (m *Dense32) Mul(a, b Matrix32) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+
+	if ac != br {
+		panic(ErrShape)
+	}
+
+	aU, aTrans := untranspose32(a)
+	bU, bTrans := untranspose32(b)
+	m.reuseAs(ar, bc)
+	var restore func()
+	if m == aU {
+		m, restore = m.isolatedWorkspace(aU)
+		defer restore()
+	} else if m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	}
+	aT := blas.NoTrans
+	if aTrans {
+		aT = blas.Trans
+	}
+	bT := blas.NoTrans
+	if bTrans {
+		bT = blas.Trans
+	}
+
+	if aUrm, ok := aU.(RawMatrixer32); ok {
+		if bUrm, ok := bU.(RawMatrixer32); ok {
+			amat := aUrm.RawMatrix()
+			bmat := bUrm.RawMatrix()
+			if restore == nil {
+				m.checkOverlap(amat)
+				m.checkOverlap(bmat)
+			}
+			blas32.Gemm(aT, bT, 1, amat, bmat, 0, m.mat)
+			return
+		}
+	}
+
+	if restore == nil {
+		m.checkOverlapMatrix(aU)
+		m.checkOverlapMatrix(bU)
+	}
+	row := make([]float32, ac)
+	for r := 0; r < ar; r++ {
+		for i := range row {
+			row[i] = a.At(r, i)
+		}
+		for c := 0; c < bc; c++ {
+			var v float32
+			for i, e := range row {
+				v += e * b.At(i, c)
+			}
+			m.mat.Data[r*m.mat.Stride+c] = v
+		}
+	}
+}
+
+// Inverse computes the inverse of the matrix a, storing the result into the
+// receiver. If a is exactly singular, a Condition error will be returned.
+// Note that matrix inversion is numerically unstable, and should generally
+// be avoided where possible, for example by using the Solve routines.
+func (m *Dense32) Inverse(a Matrix32) error {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	m.reuseAs(r, c)
+
+	lu := NewDense32(r, r, nil)
+	lu.Copy(a)
+	ipiv := getInts(r, false)
+	defer putInts(ipiv)
+	ok := lapack32.Getrf(lu.mat, ipiv)
+	if !ok {
+		return Condition(math.Inf(1))
+	}
+	for i := 0; i < r; i++ {
+		row := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+r]
+		zero32(row)
+		row[i] = 1
+	}
+	lapack32.Getrs(blas.NoTrans, lu.mat, m.mat, ipiv)
+	return nil
+}
+
+// Solve solves the linear least squares problem
+//  minimize over x |b - A*x|_2
+// where A is an m×n matrix A, b is a given m element vector and x is n element
+// solution vector. Solve assumes that A has full rank, that is
+//  rank(A) = min(m,n)
+//
+// If m >= n, Solve finds the unique least squares solution of an overdetermined
+// system.
+//
+// If m < n, there is an infinite number of solutions that satisfy b-A*x=0. In
+// this case Solve finds the unique solution of an underdetermined system that
+// minimizes |x|_2.
+//
+// Several right-hand side vectors b and solution vectors x can be handled in a
+// single call. Vectors b are stored in the columns of the m×k matrix B. Vectors
+// x are stored in the columns of the n×k matrix X.
+//
+// If A is exactly singular, a Condition error is returned.
+func (m *Dense32) Solve(a, b Matrix32) error {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br {
+		panic(ErrShape)
+	}
+	m.reuseAs(ac, bc)
+
+	switch {
+	case ar == ac:
+		var lu LU32
+		lu.Factorize(a)
+		return lu.SolveTo(m, false, b)
+	case ar > ac:
+		var qr QR32
+		qr.Factorize(a)
+		return qr.SolveTo(m, false, b)
+	default:
+		// The minimum norm solution of A * X = B is found from the
+		// QR factorization of A^T.
+		var qr QR32
+		qr.Factorize(a.T())
+		return qr.SolveTo(m, true, b)
+	}
+}
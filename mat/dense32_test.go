@@ -0,0 +1,223 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// randDense32 returns an r×c matrix with elements uniformly distributed
+// in [-1, 1).
+func randDense32(r, c int, rnd *rand.Rand) *Dense32 {
+	d := NewDense32(r, c, nil)
+	for i := range d.mat.Data {
+		d.mat.Data[i] = float32(2*rnd.Float64() - 1)
+	}
+	return d
+}
+
+func TestDense32Conversion(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := NewDense(4, 3, nil)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			a.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	var a32 Dense32
+	a32.CloneFrom64(a)
+	if r, c := a32.Dims(); r != 4 || c != 3 {
+		t.Fatalf("unexpected dimensions: got %d×%d, want 4×3", r, c)
+	}
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			if a32.At(i, j) != float32(a.At(i, j)) {
+				t.Errorf("unexpected value at (%d,%d): got %v, want %v", i, j, a32.At(i, j), float32(a.At(i, j)))
+			}
+		}
+	}
+	back := a32.To64(nil)
+	if !EqualApprox(back, a, 1e-6) {
+		t.Errorf("round trip through float32 lost too much precision")
+	}
+
+	// Conversion through a transpose and into a sub-matrix view.
+	a32.CloneFrom64(a.T())
+	if !EqualApprox(a32.To64(nil), a.T(), 1e-6) {
+		t.Errorf("unexpected result converting transposed matrix")
+	}
+	dst := NewDense(5, 5, nil)
+	a32.To64(dst.Slice(1, 4, 0, 4).(*Dense))
+	if !Equal(dst.Slice(1, 4, 0, 4), a32.To64(nil)) {
+		t.Errorf("unexpected result converting into a view")
+	}
+
+	v := NewVecDense(5, []float64{1, -2, 3.5, 1e-3, 7})
+	var v32 VecDense32
+	v32.CloneFromVec64(v)
+	if !EqualApprox(v32.To64(nil), v, 1e-6) {
+		t.Errorf("vector round trip through float32 lost too much precision")
+	}
+
+	s := NewSymDense(3, []float64{
+		1, 2, 3,
+		0, 4, 5,
+		0, 0, 6,
+	})
+	var s32 SymDense32
+	s32.CloneFromSym64(s)
+	if !Equal(s32.To64(nil), s) {
+		t.Errorf("unexpected symmetric round trip")
+	}
+}
+
+func TestDense32Arithmetic(t *testing.T) {
+	const tol = 1e-5
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, k, n int
+	}{
+		{1, 1, 1},
+		{3, 4, 2},
+		{10, 10, 10},
+		{17, 5, 23},
+	} {
+		m, k, n := test.m, test.k, test.n
+		a := randDense32(m, k, rnd)
+		b := randDense32(k, n, rnd)
+		c := randDense32(m, k, rnd)
+		a64 := a.To64(nil)
+		b64 := b.To64(nil)
+		c64 := c.To64(nil)
+
+		var got Dense32
+		var want Dense
+		got.Add(a, c)
+		want.Add(a64, c64)
+		if !EqualApprox(got.To64(nil), &want, tol) {
+			t.Errorf("%d×%d: unexpected Add result", m, k)
+		}
+		got.Sub(a, c)
+		want.Sub(a64, c64)
+		if !EqualApprox(got.To64(nil), &want, tol) {
+			t.Errorf("%d×%d: unexpected Sub result", m, k)
+		}
+		got.MulElem(a, c)
+		want.MulElem(a64, c64)
+		if !EqualApprox(got.To64(nil), &want, tol) {
+			t.Errorf("%d×%d: unexpected MulElem result", m, k)
+		}
+		got.Scale(-3, a)
+		want.Scale(-3, a64)
+		if !EqualApprox(got.To64(nil), &want, tol) {
+			t.Errorf("%d×%d: unexpected Scale result", m, k)
+		}
+
+		got.Reset()
+		want.Reset()
+		got.Mul(a, b)
+		want.Mul(a64, b64)
+		if !EqualApprox(got.To64(nil), &want, tol*float64(k)) {
+			t.Errorf("%d×%d×%d: unexpected Mul result", m, k, n)
+		}
+		got.Reset()
+		want.Reset()
+		got.Mul(a.T(), c)
+		want.Mul(a64.T(), c64)
+		if !EqualApprox(got.To64(nil), &want, tol*float64(m)) {
+			t.Errorf("%d×%d×%d: unexpected Mul result with transpose", m, k, n)
+		}
+		got.Reset()
+		want.Reset()
+		got.Mul(a, Transpose32{c})
+		want.Mul(a64, c64.T())
+		got.Mul(&got, &got)
+		want.Mul(&want, &want)
+		if !EqualApprox(got.To64(nil), &want, tol*float64(m*k)) {
+			t.Errorf("%d×%d×%d: unexpected Mul result with aliased receiver", m, k, n)
+		}
+	}
+}
+
+func TestDense32Solve(t *testing.T) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{5, 5},
+		{20, 20},
+		{10, 4},
+		{4, 10},
+	} {
+		m, n := test.m, test.n
+		a := randDense32(m, n, rnd)
+		for i := 0; i < min(m, n); i++ {
+			// Make the problem well conditioned.
+			a.set(i, i, a.at(i, i)+float32(min(m, n)))
+		}
+		b := randDense32(m, 3, rnd)
+		var x Dense32
+		err := x.Solve(a, b)
+		if err != nil {
+			t.Errorf("%d×%d: unexpected error: %v", m, n, err)
+			continue
+		}
+		var want Dense
+		err = want.Solve(a.To64(nil), b.To64(nil))
+		if err != nil {
+			t.Fatalf("%d×%d: unexpected error from float64 solve: %v", m, n, err)
+		}
+		if !EqualApprox(x.To64(nil), &want, tol) {
+			t.Errorf("%d×%d: unexpected solution", m, n)
+		}
+
+		var xv VecDense32
+		bv := NewVecDense32(m, nil)
+		for i := 0; i < m; i++ {
+			bv.SetVec(i, b.At(i, 0))
+		}
+		err = xv.SolveVec(a, bv)
+		if err != nil {
+			t.Errorf("%d×%d: unexpected error: %v", m, n, err)
+			continue
+		}
+		if !EqualApprox(xv.To64(nil), want.ColView(0), tol) {
+			t.Errorf("%d×%d: unexpected vector solution", m, n)
+		}
+
+		if m != n {
+			continue
+		}
+		var inv Dense32
+		err = inv.Inverse(a)
+		if err != nil {
+			t.Errorf("%d×%d: unexpected error: %v", m, n, err)
+			continue
+		}
+		var eye Dense32
+		eye.Mul(a, &inv)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				w := float32(0)
+				if i == j {
+					w = 1
+				}
+				if abs32(eye.At(i, j)-w) > tol {
+					t.Errorf("%d×%d: A*inv(A) != I", m, n)
+					i, j = n, n
+				}
+			}
+		}
+	}
+
+	var x Dense32
+	if err := x.Solve(NewDense32(2, 2, []float32{1, 2, 2, 4}), NewDense32(2, 1, nil)); err == nil {
+		t.Errorf("expected error solving singular system")
+	}
+}
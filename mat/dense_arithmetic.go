@@ -266,6 +266,39 @@ func (m *Dense) Inverse(a Matrix) error {
 	return nil
 }
 
+// Pinv computes the Moore-Penrose pseudo-inverse of the matrix a, placing the
+// result in the receiver. If a is m×n, the receiver is n×m. The pseudo-inverse
+// is computed from the singular value decomposition of a, with singular values
+// less than or equal to rcond times the largest singular value treated as zero.
+// If rcond is negative, machine precision is used instead.
+//
+// Pinv returns the effective rank of a. If the singular value decomposition
+// fails to converge, ErrFailedSVD is returned.
+func (m *Dense) Pinv(a Matrix, rcond float64) (rank int, err error) {
+	r, c := a.Dims()
+	if r == 0 || c == 0 {
+		panic(ErrZeroLength)
+	}
+	m.reuseAs(c, r)
+
+	tmpA := getWorkspace(r, c, false)
+	defer putWorkspace(tmpA)
+	tmpA.Copy(a)
+	// The pseudo-inverse is the minimum-norm solution of A * X = I.
+	x := getWorkspace(max(r, c), r, true)
+	defer putWorkspace(x)
+	for i := 0; i < r; i++ {
+		x.mat.Data[i*x.mat.Stride+i] = 1
+	}
+
+	rank, ok := gelss(tmpA, x, rcond)
+	if !ok {
+		return 0, ErrFailedSVD
+	}
+	m.Copy(x)
+	return rank, nil
+}
+
 // Mul takes the matrix product of a and b, placing the result in the receiver.
 // If the number of columns in a does not equal the number of rows in b, Mul will panic.
 func (m *Dense) Mul(a, b Matrix) {
@@ -296,6 +329,22 @@ func (m *Dense) Mul(a, b Matrix) {
 		bT = blas.Trans
 	}
 
+	// Sparse operands are handled by iterating over their non-zero elements.
+	if as, ok := asSparse(aU); ok {
+		if restore == nil {
+			m.checkOverlapMatrix(bU)
+		}
+		m.mulSparseLeft(as, aTrans, b)
+		return
+	}
+	if bs, ok := asSparse(bU); ok {
+		if restore == nil {
+			m.checkOverlapMatrix(aU)
+		}
+		m.mulSparseRight(a, bs, bTrans)
+		return
+	}
+
 	// Some of the cases do not have a transpose option, so create
 	// temporary memory.
 	// C = A^T * B = (B^T * A)^T
@@ -758,6 +807,33 @@ func (m *Dense) Apply(fn func(i, j int, v float64) float64, a Matrix) {
 	}
 }
 
+// Kronecker calculates the Kronecker product of a and b, placing the result in
+// the receiver. If a is m×n and b is p×q, the result is the mp×nq matrix
+//  [ a_00*b     ... a_0(n-1)*b     ]
+//  [ ...                           ]
+//  [ a_(m-1)0*b ... a_(m-1)(n-1)*b ].
+// To represent a Kronecker product without forming it, see Kronecker and
+// SymKronecker.
+func (m *Dense) Kronecker(a, b Matrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+
+	aU, _ := untranspose(a)
+	bU, _ := untranspose(b)
+	if m == aU || m == bU {
+		panic(regionIdentity)
+	}
+	m.reuseAs(ar*br, ac*bc)
+	m.checkOverlapMatrix(aU)
+	m.checkOverlapMatrix(bU)
+
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			m.Slice(i*br, (i+1)*br, j*bc, (j+1)*bc).(*Dense).Scale(a.At(i, j), b)
+		}
+	}
+}
+
 // RankOne performs a rank-one update to the matrix a and stores the result
 // in the receiver. If a is zero, see Outer.
 //  m = a + alpha * x * y'
@@ -1959,6 +1959,77 @@ func TestDenseInverse(t *testing.T) {
 	}
 }
 
+func TestDensePinv(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, rank int
+	}{
+		{1, 1, 1},
+		{3, 3, 3},
+		{3, 3, 2},
+		{6, 3, 3},
+		{6, 3, 1},
+		{3, 6, 2},
+		{10, 7, 5},
+	} {
+		a := randRankDeficient(test.m, test.n, test.rank, rnd)
+		var pinv Dense
+		rank, err := pinv.Pinv(a, 1e-10)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if rank != test.rank {
+			t.Errorf("unexpected rank for %d×%d matrix: got %d want %d", test.m, test.n, rank, test.rank)
+		}
+		if r, c := pinv.Dims(); r != test.n || c != test.m {
+			t.Errorf("unexpected dimensions: got %d×%d want %d×%d", r, c, test.n, test.m)
+			continue
+		}
+
+		// Check the Moore-Penrose conditions.
+		var aap, apa, got Dense
+		aap.Mul(a, &pinv)
+		apa.Mul(&pinv, a)
+		got.Mul(&aap, a)
+		if !EqualApprox(&got, a, 1e-10) {
+			t.Errorf("A * A^+ * A != A for %d×%d matrix of rank %d", test.m, test.n, test.rank)
+		}
+		got.Reset()
+		got.Mul(&apa, &pinv)
+		if !EqualApprox(&got, &pinv, 1e-10) {
+			t.Errorf("A^+ * A * A^+ != A^+ for %d×%d matrix of rank %d", test.m, test.n, test.rank)
+		}
+		if !EqualApprox(&aap, aap.T(), 1e-10) {
+			t.Errorf("A * A^+ not symmetric for %d×%d matrix of rank %d", test.m, test.n, test.rank)
+		}
+		if !EqualApprox(&apa, apa.T(), 1e-10) {
+			t.Errorf("A^+ * A not symmetric for %d×%d matrix of rank %d", test.m, test.n, test.rank)
+		}
+
+		if test.m == test.n && test.rank == test.n {
+			var inv Dense
+			err := inv.Inverse(a)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !EqualApprox(&pinv, &inv, 1e-10) {
+				t.Errorf("pseudo-inverse does not match inverse for full rank matrix")
+			}
+		}
+	}
+
+	// The pseudo-inverse of a zero matrix is its transpose.
+	var pinv Dense
+	rank, err := pinv.Pinv(NewDense(2, 3, nil), -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rank != 0 || !Equal(&pinv, NewDense(3, 2, nil)) {
+		t.Errorf("unexpected pseudo-inverse of zero matrix: rank=%d\n%v", rank, Formatted(&pinv))
+	}
+}
+
 var (
 	wd *Dense
 )
@@ -5,15 +5,47 @@
 package mat
 
 import (
+	"gonum.org/v1/gonum/blas/blas64"
 	"gonum.org/v1/gonum/lapack"
 	"gonum.org/v1/gonum/lapack/lapack64"
 )
 
 const (
-	badFact   = "mat: use without successful factorization"
-	badNoVect = "mat: eigenvectors not computed"
+	badFact       = "mat: use without successful factorization"
+	badNoVect     = "mat: eigenvectors not computed"
+	badValueRange = "mat: lower bound of value range not less than upper bound"
 )
 
+// EigenSymAlgorithm specifies the algorithm used to compute the eigenvalue
+// decomposition of a symmetric matrix. All algorithms first reduce the matrix
+// to tridiagonal form and differ in how the eigenpairs of the tridiagonal
+// matrix are computed.
+type EigenSymAlgorithm int
+
+const (
+	// EigenSymAuto chooses the algorithm based on the size of the matrix
+	// and whether the eigenvectors are computed.
+	EigenSymAuto EigenSymAlgorithm = iota
+	// EigenSymQR specifies the implicit QL or QR iteration as implemented
+	// by lapack64.Syev.
+	EigenSymQR
+	// EigenSymDivideConquer specifies the divide and conquer method as
+	// implemented by lapack64.Syevd. It is usually considerably faster
+	// than EigenSymQR for large matrices when the eigenvectors are
+	// computed, at the cost of additional workspace.
+	EigenSymDivideConquer
+	// EigenSymRRR specifies the algorithm of Multiple Relatively Robust
+	// Representations as implemented by lapack64.Syevr. It is usually the
+	// fastest algorithm and the only one that can compute a subset of the
+	// eigenpairs at a reduced cost.
+	EigenSymRRR
+)
+
+// eigenSymDivideConquerMin is the smallest order of a matrix for which
+// EigenSymAuto uses the divide and conquer method when the eigenvectors are
+// computed.
+const eigenSymDivideConquerMin = 26
+
 // EigenSym is a type for creating and manipulating the Eigen decomposition of
 // symmetric matrices.
 type EigenSym struct {
@@ -34,6 +66,114 @@ type EigenSym struct {
 // Factorize returns whether the decomposition succeeded. If the decomposition
 // failed, methods that require a successful factorization will panic.
 func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
+	return e.FactorizeAlg(a, vectors, EigenSymAuto)
+}
+
+// FactorizeAlg computes the eigenvalue decomposition of the symmetric matrix a
+// using the specified algorithm. See the Factorize method for the description
+// of the decomposition.
+//
+// FactorizeAlg returns whether the decomposition succeeded. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *EigenSym) FactorizeAlg(a Symmetric, vectors bool, alg EigenSymAlgorithm) (ok bool) {
+	n := a.Symmetric()
+	if alg == EigenSymAuto {
+		alg = EigenSymQR
+		if vectors && n >= eigenSymDivideConquerMin {
+			alg = EigenSymDivideConquer
+		}
+	}
+	if alg == EigenSymRRR {
+		return e.factorizeRange(a, vectors, lapack.EVRangeAll, 0, 0, 0, 0)
+	}
+
+	// kill previous decomposition
+	e.vectorsComputed = false
+	e.values = e.values[:]
+
+	sd := NewSymDense(n, nil)
+	sd.CopySym(a)
+
+	jobz := lapack.EVNone
+	if vectors {
+		jobz = lapack.EVCompute
+	}
+	w := make([]float64, n)
+	work := []float64{0}
+	switch alg {
+	default:
+		panic("mat: unknown symmetric eigensolver")
+	case EigenSymQR:
+		lapack64.Syev(jobz, sd.mat, w, work, -1)
+
+		work = getFloats(int(work[0]), false)
+		ok = lapack64.Syev(jobz, sd.mat, w, work, len(work))
+		putFloats(work)
+	case EigenSymDivideConquer:
+		iwork := []int{0}
+		lapack64.Syevd(jobz, sd.mat, w, work, -1, iwork, -1)
+
+		work = getFloats(int(work[0]), false)
+		iwork = getInts(iwork[0], false)
+		ok = lapack64.Syevd(jobz, sd.mat, w, work, len(work), iwork, len(iwork))
+		putFloats(work)
+		putInts(iwork)
+	}
+	if !ok {
+		e.vectorsComputed = false
+		e.values = nil
+		e.vectors = nil
+		return false
+	}
+	e.vectorsComputed = vectors
+	e.values = w
+	e.vectors = NewDense(n, n, sd.mat.Data)
+	return true
+}
+
+// FactorizeIndices computes the eigenvalues of the symmetric matrix a with
+// indices lo through hi-1 in the ascending order of all eigenvalues and,
+// optionally, the corresponding eigenvectors. The indices must satisfy
+// 0 <= lo < hi <= n, and FactorizeIndices will panic otherwise.
+//
+// The eigenpairs are computed by the algorithm of Multiple Relatively Robust
+// Representations, and computing k eigenpairs of an n×n matrix is
+// considerably faster than computing all of them when k is small compared to
+// n.
+//
+// FactorizeIndices returns whether the decomposition succeeded. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *EigenSym) FactorizeIndices(a Symmetric, vectors bool, lo, hi int) (ok bool) {
+	n := a.Symmetric()
+	if lo < 0 || hi <= lo || n < hi {
+		panic(ErrIndexOutOfRange)
+	}
+	return e.factorizeRange(a, vectors, lapack.EVRangeIndex, 0, 0, lo, hi-1)
+}
+
+// FactorizeValues computes the eigenvalues of the symmetric matrix a in the
+// half-open interval (vl, vu] and, optionally, the corresponding eigenvectors.
+// vl must be less than vu, and FactorizeValues will panic otherwise.
+//
+// The eigenpairs are computed by the algorithm of Multiple Relatively Robust
+// Representations. If no eigenvalue lies in the interval, the factorization
+// succeeds and Values returns an empty slice.
+//
+// FactorizeValues returns whether the decomposition succeeded. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *EigenSym) FactorizeValues(a Symmetric, vectors bool, vl, vu float64) (ok bool) {
+	if vl >= vu {
+		panic(badValueRange)
+	}
+	return e.factorizeRange(a, vectors, lapack.EVRangeValue, vl, vu, 0, 0)
+}
+
+// factorizeRange computes the eigenpairs of a selected by rng, vl, vu, il and
+// iu as described in the documentation of lapack64.Syevr.
+func (e *EigenSym) factorizeRange(a Symmetric, vectors bool, rng lapack.EVRange, vl, vu float64, il, iu int) (ok bool) {
 	// kill previous decomposition
 	e.vectorsComputed = false
 	e.values = e.values[:]
@@ -43,16 +183,25 @@ func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
 	sd.CopySym(a)
 
 	jobz := lapack.EVNone
+	nz := n
+	if rng == lapack.EVRangeIndex {
+		nz = iu - il + 1
+	}
+	z := blas64.General{Rows: n, Cols: nz, Stride: nz}
 	if vectors {
 		jobz = lapack.EVCompute
+		z.Data = make([]float64, n*nz)
 	}
 	w := make([]float64, n)
 	work := []float64{0}
-	lapack64.Syev(jobz, sd.mat, w, work, -1)
+	iwork := []int{0}
+	lapack64.Syevr(jobz, rng, sd.mat, vl, vu, il, iu, w, z, work, -1, iwork, -1)
 
 	work = getFloats(int(work[0]), false)
-	ok = lapack64.Syev(jobz, sd.mat, w, work, len(work))
+	iwork = getInts(iwork[0], false)
+	m, ok := lapack64.Syevr(jobz, rng, sd.mat, vl, vu, il, iu, w, z, work, len(work), iwork, len(iwork))
 	putFloats(work)
+	putInts(iwork)
 	if !ok {
 		e.vectorsComputed = false
 		e.values = nil
@@ -60,19 +209,32 @@ func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
 		return false
 	}
 	e.vectorsComputed = vectors
-	e.values = w
-	e.vectors = NewDense(n, n, sd.mat.Data)
+	e.values = w[:m]
+	e.vectors = nil
+	if vectors && m > 0 {
+		e.vectors = &Dense{
+			mat: blas64.General{
+				Rows:   n,
+				Cols:   m,
+				Stride: nz,
+				Data:   z.Data,
+			},
+			capRows: n,
+			capCols: m,
+		}
+	}
 	return true
 }
 
 // succFact returns whether the receiver contains a successful factorization.
 func (e *EigenSym) succFact() bool {
-	return len(e.values) != 0
+	return e.values != nil
 }
 
 // Values extracts the eigenvalues of the factorized matrix. If dst is
 // non-nil, the values are stored in-place into dst. In this case
-// dst must have length n, otherwise Values will panic. If dst is
+// dst must have length equal to the number of computed eigenvalues,
+// otherwise Values will panic. If dst is
 // nil, then a new slice will be allocated of the proper length and filled
 // with the eigenvalues.
 //
@@ -96,8 +258,9 @@ func (e *EigenSym) Values(dst []float64) []float64 {
 // or if the factorization was not successful.
 //
 // If dst is not nil, the eigenvectors are stored in-place into dst, and dst
-// must have size n×n and panics otherwise. If dst is nil, a new matrix
-// is allocated and returned.
+// must have size n×k, where k is the number of computed eigenvalues, and
+// panics otherwise. If dst is nil, a new matrix is allocated and returned.
+// VectorsTo panics if no eigenvalues were computed.
 func (e *EigenSym) VectorsTo(dst *Dense) *Dense {
 	if !e.succFact() {
 		panic(badFact)
@@ -105,6 +268,9 @@ func (e *EigenSym) VectorsTo(dst *Dense) *Dense {
 	if !e.vectorsComputed {
 		panic(badNoVect)
 	}
+	if len(e.values) == 0 {
+		panic(ErrZeroLength)
+	}
 	r, c := e.vectors.Dims()
 	if dst == nil {
 		dst = NewDense(r, c, nil)
@@ -222,14 +388,14 @@ func (e *Eigen) Factorize(a Matrix, kind EigenKind) (ok bool) {
 	var cvl, cvr CDense
 	if left {
 		cvl = *NewCDense(r, r, nil)
-		e.complexEigenTo(&cvl, &vl)
+		complexEigenTo(&cvl, &vl, e.values)
 		e.lVectors = &cvl
 	} else {
 		e.lVectors = nil
 	}
 	if right {
 		cvr = *NewCDense(c, c, nil)
-		e.complexEigenTo(&cvr, &vr)
+		complexEigenTo(&cvr, &vr, e.values)
 		e.rVectors = &cvr
 	} else {
 		e.rVectors = nil
@@ -248,7 +414,8 @@ func (e *Eigen) Kind() EigenKind {
 
 // Values extracts the eigenvalues of the factorized matrix. If dst is
 // non-nil, the values are stored in-place into dst. In this case
-// dst must have length n, otherwise Values will panic. If dst is
+// dst must have length equal to the number of computed eigenvalues,
+// otherwise Values will panic. If dst is
 // nil, then a new slice will be allocated of the proper length and
 // filed with the eigenvalues.
 //
@@ -271,7 +438,7 @@ func (e *Eigen) Values(dst []complex128) []complex128 {
 // and stores them into the complex matrix dst.
 //
 // The columns of the returned n×n dense matrix contain the eigenvectors of the
-// decomposition in the same order as the eigenvalues.
+// decomposition in the same order as the eigenvalues in values.
 // If the j-th eigenvalue is real, then
 //  dst[:,j] = d[:,j],
 // and if it is not real, then the elements of the j-th and (j+1)-th columns of d
@@ -279,7 +446,7 @@ func (e *Eigen) Values(dst []complex128) []complex128 {
 //  dst[:,j]   = d[:,j] + i*d[:,j+1],
 //  dst[:,j+1] = d[:,j] - i*d[:,j+1],
 // where i is the imaginary unit.
-func (e *Eigen) complexEigenTo(dst *CDense, d *Dense) {
+func complexEigenTo(dst *CDense, d *Dense, values []complex128) {
 	r, c := d.Dims()
 	cr, cc := dst.Dims()
 	if r != cr {
@@ -289,7 +456,7 @@ func (e *Eigen) complexEigenTo(dst *CDense, d *Dense) {
 		panic("size mismatch")
 	}
 	for j := 0; j < c; j++ {
-		if imag(e.values[j]) == 0 {
+		if imag(values[j]) == 0 {
 			for i := 0; i < r; i++ {
 				dst.set(i, j, complex(d.at(i, j), 0))
 			}
@@ -5,6 +5,7 @@
 package mat
 
 import (
+	"math"
 	"sort"
 	"testing"
 
@@ -200,3 +201,120 @@ func TestSymEigen(t *testing.T) {
 		}
 	}
 }
+
+func TestSymEigenAlg(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 3, 10, 40} {
+		a := NewSymDense(n, nil)
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				a.SetSym(i, j, rnd.NormFloat64())
+			}
+		}
+		var want EigenSym
+		if !want.FactorizeAlg(a, false, EigenSymQR) {
+			t.Fatalf("n=%d: bad factorization", n)
+		}
+		for _, alg := range []EigenSymAlgorithm{EigenSymAuto, EigenSymQR, EigenSymDivideConquer, EigenSymRRR} {
+			for _, vectors := range []bool{false, true} {
+				var es EigenSym
+				if !es.FactorizeAlg(a, vectors, alg) {
+					t.Errorf("n=%d,alg=%d,vectors=%t: bad factorization", n, alg, vectors)
+					continue
+				}
+				if !floats.EqualApprox(es.Values(nil), want.values, 1e-12) {
+					t.Errorf("n=%d,alg=%d,vectors=%t: eigenvalue mismatch", n, alg, vectors)
+				}
+				if !vectors {
+					continue
+				}
+				if resid := symEigenResidual(a, es.Values(nil), es.VectorsTo(nil)); resid > 1e-12 {
+					t.Errorf("n=%d,alg=%d: unexpected residual %v", n, alg, resid)
+				}
+			}
+		}
+	}
+}
+
+func TestSymEigenRange(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 3, 10, 40} {
+		a := NewSymDense(n, nil)
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				a.SetSym(i, j, rnd.NormFloat64())
+			}
+		}
+		var all EigenSym
+		if !all.FactorizeAlg(a, false, EigenSymQR) {
+			t.Fatalf("n=%d: bad factorization", n)
+		}
+		ev := all.Values(nil)
+
+		lo, hi := n/4, max(n/4+1, 3*n/4)
+		for _, vectors := range []bool{false, true} {
+			var es EigenSym
+			if !es.FactorizeIndices(a, vectors, lo, hi) {
+				t.Errorf("n=%d,vectors=%t: bad factorization by indices", n, vectors)
+				continue
+			}
+			got := es.Values(nil)
+			if !floats.EqualApprox(got, ev[lo:hi], 1e-12) {
+				t.Errorf("n=%d,vectors=%t: eigenvalue mismatch by indices", n, vectors)
+			}
+			if vectors {
+				if resid := symEigenResidual(a, got, es.VectorsTo(nil)); resid > 1e-12 {
+					t.Errorf("n=%d: unexpected residual by indices %v", n, resid)
+				}
+			}
+
+			// Choose the bounds of the value range between eigenvalues
+			// so that the same eigenvalues are computed.
+			vl := ev[0] - 1
+			if lo > 0 {
+				vl = (ev[lo-1] + ev[lo]) / 2
+			}
+			vu := ev[n-1] + 1
+			if hi < n {
+				vu = (ev[hi-1] + ev[hi]) / 2
+			}
+			if !es.FactorizeValues(a, vectors, vl, vu) {
+				t.Errorf("n=%d,vectors=%t: bad factorization by values", n, vectors)
+				continue
+			}
+			got = es.Values(nil)
+			if !floats.EqualApprox(got, ev[lo:hi], 1e-12) {
+				t.Errorf("n=%d,vectors=%t: eigenvalue mismatch by values", n, vectors)
+			}
+			if vectors {
+				if resid := symEigenResidual(a, got, es.VectorsTo(nil)); resid > 1e-12 {
+					t.Errorf("n=%d: unexpected residual by values %v", n, resid)
+				}
+			}
+
+			// An interval without eigenvalues is not an error.
+			if !es.FactorizeValues(a, vectors, ev[n-1]+1, ev[n-1]+2) {
+				t.Errorf("n=%d,vectors=%t: bad factorization by empty value range", n, vectors)
+				continue
+			}
+			if len(es.Values(nil)) != 0 {
+				t.Errorf("n=%d,vectors=%t: unexpected eigenvalues in empty value range", n, vectors)
+			}
+		}
+	}
+}
+
+// symEigenResidual returns the largest column norm of A*Z - Z*Λ scaled by
+// the norm of A, where Λ is the diagonal matrix with the eigenvalues w and the
+// columns of Z are the corresponding eigenvectors. It returns infinity if the
+// columns of Z are not orthonormal.
+func symEigenResidual(a Symmetric, w []float64, z *Dense) float64 {
+	if !isOrthonormalColumns(z, 1e-12) {
+		return math.Inf(1)
+	}
+	var az, zl Dense
+	az.Mul(a, z)
+	zl.Mul(z, NewDiagDense(len(w), w))
+	az.Sub(&az, &zl)
+	return Norm(&az, 1) / math.Max(1, Norm(a, 1))
+}
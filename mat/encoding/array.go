@@ -0,0 +1,137 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encoding
+
+import (
+	"errors"
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	// ErrNotSymmetric is returned when data that is not symmetric is
+	// read into a *mat.SymDense.
+	ErrNotSymmetric = errors.New("encoding: matrix is not symmetric")
+
+	// ErrComplex is returned when data with a non-zero imaginary part
+	// is read into a real destination.
+	ErrComplex = errors.New("encoding: complex data for real destination")
+
+	// ErrShape is returned when the shape of the data read does not
+	// match the destination.
+	ErrShape = errors.New("encoding: dimension mismatch")
+)
+
+// array is the format-independent representation of a decoded matrix.
+type array struct {
+	rows, cols int
+
+	// vector indicates that the data was stored as a one-dimensional
+	// array of length rows with cols == 1.
+	vector bool
+
+	// symmetric indicates that the source declared the data to be
+	// symmetric.
+	symmetric bool
+
+	// re and im hold the real and imaginary parts of the data in
+	// row-major order. im is nil for real data.
+	re, im []float64
+}
+
+func newArray(r, c int, cmplx bool) *array {
+	a := &array{rows: r, cols: c, re: make([]float64, r*c)}
+	if cmplx {
+		a.im = make([]float64, r*c)
+	}
+	return a
+}
+
+// isReal returns whether all the imaginary parts of a are zero.
+func (a *array) isReal() bool {
+	for _, v := range a.im {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isSymmetric returns whether a is square and exactly symmetric.
+func (a *array) isSymmetric() bool {
+	if a.rows != a.cols {
+		return false
+	}
+	if a.symmetric {
+		return true
+	}
+	n := a.rows
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if a.re[i*n+j] != a.re[j*n+i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// assign stores a into dst which must be an empty *mat.Dense,
+// *mat.SymDense, *mat.VecDense or *mat.CDense.
+func (a *array) assign(dst interface{}) error {
+	if a.rows == 0 || a.cols == 0 {
+		return mat.ErrZeroLength
+	}
+	switch dst := dst.(type) {
+	case *mat.Dense:
+		if !dst.IsZero() {
+			panic(errNonEmpty)
+		}
+		if !a.isReal() {
+			return ErrComplex
+		}
+		*dst = *mat.NewDense(a.rows, a.cols, a.re)
+	case *mat.SymDense:
+		if !dst.IsZero() {
+			panic(errNonEmpty)
+		}
+		if !a.isReal() {
+			return ErrComplex
+		}
+		if !a.isSymmetric() {
+			return ErrNotSymmetric
+		}
+		*dst = *mat.NewSymDense(a.rows, a.re)
+	case *mat.VecDense:
+		if !dst.IsZero() {
+			panic(errNonEmpty)
+		}
+		if !a.isReal() {
+			return ErrComplex
+		}
+		if a.rows != 1 && a.cols != 1 {
+			return ErrShape
+		}
+		*dst = *mat.NewVecDense(len(a.re), a.re)
+	case *mat.CDense:
+		if !dst.IsZero() {
+			panic(errNonEmpty)
+		}
+		data := make([]complex128, len(a.re))
+		for i, v := range a.re {
+			data[i] = complex(v, 0)
+		}
+		for i, v := range a.im {
+			data[i] += complex(0, v)
+		}
+		*dst = *mat.NewCDense(a.rows, a.cols, data)
+	default:
+		return fmt.Errorf("encoding: unsupported destination type %T", dst)
+	}
+	return nil
+}
+
+const errNonEmpty = "encoding: destination is not empty"
@@ -0,0 +1,16 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package encoding provides reading and writing of mat matrices in common
+// interchange formats.
+//
+// The Matrix Market exchange format, as used by the SuiteSparse and NIST
+// Matrix Market collections, is supported in both its coordinate and array
+// forms, and the NumPy .npy and .npz formats are supported for numeric
+// arrays with one or two dimensions.
+//
+// Values read from a file are stored into a *mat.Dense, *mat.SymDense,
+// *mat.VecDense or *mat.CDense destination. The destination must be empty,
+// as reported by its IsZero method, and is sized to fit the data read.
+package encoding // import "gonum.org/v1/gonum/mat/encoding"
@@ -0,0 +1,51 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encoding_test
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/mat/encoding"
+)
+
+func ExampleReadMatrixMarket() {
+	const data = `%%MatrixMarket matrix coordinate real symmetric
+% A 3×3 symmetric matrix with its lower triangle stored.
+3 3 4
+1 1 2
+2 1 -1
+2 2 2
+3 3 1
+`
+	var a mat.SymDense
+	err := encoding.ReadMatrixMarket(strings.NewReader(data), &a)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("a = %v\n\n", mat.Formatted(&a, mat.Prefix("    ")))
+
+	err = encoding.WriteMatrixMarket(os.Stdout, &a, encoding.Array)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Output:
+	// a = ⎡ 2  -1   0⎤
+	//     ⎢-1   2   0⎥
+	//     ⎣ 0   0   1⎦
+	//
+	// %%MatrixMarket matrix array real symmetric
+	// 3 3
+	// 2
+	// -1
+	// 0
+	// 2
+	// 0
+	// 1
+}
@@ -0,0 +1,383 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encoding
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// MatrixMarketFormat specifies the layout of a Matrix Market file.
+type MatrixMarketFormat int
+
+const (
+	// Array is the dense Matrix Market layout, holding every element
+	// in column-major order.
+	Array MatrixMarketFormat = iota
+	// Coordinate is the sparse Matrix Market layout, holding the
+	// non-zero elements as row, column and value triplets.
+	Coordinate
+)
+
+func (f MatrixMarketFormat) String() string {
+	switch f {
+	case Array:
+		return "array"
+	case Coordinate:
+		return "coordinate"
+	default:
+		return fmt.Sprintf("MatrixMarketFormat(%d)", int(f))
+	}
+}
+
+const mmBanner = "%%MatrixMarket"
+
+// mmHeader is the parsed banner of a Matrix Market file.
+type mmHeader struct {
+	format   MatrixMarketFormat
+	field    string
+	symmetry string
+}
+
+// ReadMatrixMarket reads a matrix in the Matrix Market exchange format from r
+// and stores it in dst, which must be an empty *mat.Dense, *mat.SymDense,
+// *mat.VecDense or *mat.CDense. ReadMatrixMarket panics if dst is not empty.
+//
+// Both the array and the coordinate formats are supported with real, integer,
+// complex and pattern fields, and general, symmetric, skew-symmetric and
+// Hermitian symmetries. Elements of a pattern matrix that are present are
+// read as one. Duplicate coordinate entries are summed. Complex data can only
+// be stored in a real destination if all the imaginary parts are zero, and a
+// *mat.VecDense destination requires a matrix with a single row or column.
+func ReadMatrixMarket(r io.Reader, dst interface{}) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return err
+		}
+		return io.ErrUnexpectedEOF
+	}
+	h, err := parseMMBanner(sc.Text())
+	if err != nil {
+		return err
+	}
+
+	line, err := nextMMLine(sc)
+	if err != nil {
+		return err
+	}
+	size := strings.Fields(line)
+	want := 2
+	if h.format == Coordinate {
+		want = 3
+	}
+	if len(size) != want {
+		return fmt.Errorf("encoding: invalid Matrix Market size line %q", line)
+	}
+	dims := make([]int, want)
+	for i, f := range size {
+		dims[i], err = strconv.Atoi(f)
+		if err != nil || dims[i] < 0 {
+			return fmt.Errorf("encoding: invalid Matrix Market size line %q", line)
+		}
+	}
+	rows, cols := dims[0], dims[1]
+	if h.symmetry != "general" && rows != cols {
+		return fmt.Errorf("encoding: non-square %s Matrix Market matrix", h.symmetry)
+	}
+
+	a := newArray(rows, cols, h.field == "complex")
+	a.symmetric = h.symmetry == "symmetric" || (h.symmetry == "hermitian" && h.field != "complex")
+
+	nvals := 1
+	switch h.field {
+	case "complex":
+		nvals = 2
+	case "pattern":
+		nvals = 0
+	}
+
+	if h.format == Array {
+		err = readMMArray(sc, h, a, nvals)
+	} else {
+		err = readMMCoordinate(sc, h, a, nvals, dims[2])
+	}
+	if err != nil {
+		return err
+	}
+	return a.assign(dst)
+}
+
+// parseMMBanner parses the first line of a Matrix Market file.
+func parseMMBanner(line string) (mmHeader, error) {
+	var h mmHeader
+	f := strings.Fields(line)
+	if len(f) != 5 || f[0] != mmBanner {
+		return h, fmt.Errorf("encoding: invalid Matrix Market banner %q", line)
+	}
+	for i := 1; i < len(f); i++ {
+		f[i] = strings.ToLower(f[i])
+	}
+	if f[1] != "matrix" {
+		return h, fmt.Errorf("encoding: unsupported Matrix Market object %q", f[1])
+	}
+	switch f[2] {
+	case "array":
+		h.format = Array
+	case "coordinate":
+		h.format = Coordinate
+	default:
+		return h, fmt.Errorf("encoding: unsupported Matrix Market format %q", f[2])
+	}
+	switch f[3] {
+	case "real", "double", "integer", "complex":
+	case "pattern":
+		if h.format == Array {
+			return h, fmt.Errorf("encoding: invalid pattern field for Matrix Market array")
+		}
+	default:
+		return h, fmt.Errorf("encoding: unsupported Matrix Market field %q", f[3])
+	}
+	h.field = f[3]
+	switch f[4] {
+	case "general", "symmetric", "skew-symmetric", "hermitian":
+	default:
+		return h, fmt.Errorf("encoding: unsupported Matrix Market symmetry %q", f[4])
+	}
+	h.symmetry = f[4]
+	return h, nil
+}
+
+// nextMMLine returns the next line of sc that is neither blank nor a comment.
+func nextMMLine(sc *bufio.Scanner) (string, error) {
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '%' {
+			continue
+		}
+		return line, nil
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	return "", io.ErrUnexpectedEOF
+}
+
+// readMMValue parses the nvals fields of f as the real and imaginary parts
+// of a value. A pattern entry, with no fields, has the value one.
+func readMMValue(f []string, nvals int) (re, im float64, err error) {
+	if len(f) != nvals {
+		return 0, 0, fmt.Errorf("encoding: invalid Matrix Market entry %q", strings.Join(f, " "))
+	}
+	switch nvals {
+	case 0:
+		return 1, 0, nil
+	case 2:
+		im, err = strconv.ParseFloat(f[1], 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	re, err = strconv.ParseFloat(f[0], 64)
+	return re, im, err
+}
+
+// set stores the value at (i, j) of a and its reflection implied by the
+// symmetry of the file.
+func (a *array) set(i, j int, re, im float64, symmetry string, add bool) {
+	if add {
+		re += a.re[i*a.cols+j]
+		if a.im != nil {
+			im += a.im[i*a.cols+j]
+		}
+	}
+	a.re[i*a.cols+j] = re
+	if a.im != nil {
+		a.im[i*a.cols+j] = im
+	}
+	if i == j {
+		return
+	}
+	switch symmetry {
+	case "symmetric":
+		a.re[j*a.cols+i] = re
+		if a.im != nil {
+			a.im[j*a.cols+i] = im
+		}
+	case "skew-symmetric":
+		a.re[j*a.cols+i] = -re
+		if a.im != nil {
+			a.im[j*a.cols+i] = -im
+		}
+	case "hermitian":
+		a.re[j*a.cols+i] = re
+		if a.im != nil {
+			a.im[j*a.cols+i] = -im
+		}
+	}
+}
+
+func readMMArray(sc *bufio.Scanner, h mmHeader, a *array, nvals int) error {
+	// Array data is stored in column-major order. For matrices
+	// with symmetry only the lower triangle is stored, and for
+	// skew-symmetric matrices the zero diagonal is omitted.
+	for j := 0; j < a.cols; j++ {
+		i0 := 0
+		switch h.symmetry {
+		case "symmetric", "hermitian":
+			i0 = j
+		case "skew-symmetric":
+			i0 = j + 1
+		}
+		for i := i0; i < a.rows; i++ {
+			line, err := nextMMLine(sc)
+			if err != nil {
+				return err
+			}
+			re, im, err := readMMValue(strings.Fields(line), nvals)
+			if err != nil {
+				return err
+			}
+			a.set(i, j, re, im, h.symmetry, false)
+		}
+	}
+	return nil
+}
+
+func readMMCoordinate(sc *bufio.Scanner, h mmHeader, a *array, nvals, nnz int) error {
+	for k := 0; k < nnz; k++ {
+		line, err := nextMMLine(sc)
+		if err != nil {
+			return err
+		}
+		f := strings.Fields(line)
+		if len(f) < 2 {
+			return fmt.Errorf("encoding: invalid Matrix Market entry %q", line)
+		}
+		i, err := strconv.Atoi(f[0])
+		if err != nil {
+			return err
+		}
+		j, err := strconv.Atoi(f[1])
+		if err != nil {
+			return err
+		}
+		// Matrix Market indices are one-based.
+		i--
+		j--
+		if i < 0 || a.rows <= i || j < 0 || a.cols <= j {
+			return fmt.Errorf("encoding: Matrix Market entry %q out of range", line)
+		}
+		re, im, err := readMMValue(f[2:], nvals)
+		if err != nil {
+			return err
+		}
+		a.set(i, j, re, im, h.symmetry, true)
+	}
+	return nil
+}
+
+// WriteMatrixMarket writes m to w in the Matrix Market exchange format using
+// the given layout. If m is a mat.Symmetric, only its lower triangle is
+// written and the matrix is marked as symmetric, otherwise it is written as
+// a general matrix. Values are written with the minimal number of digits
+// needed to represent them exactly.
+func WriteMatrixMarket(w io.Writer, m mat.Matrix, format MatrixMarketFormat) error {
+	r, c := m.Dims()
+	symmetry := "general"
+	if _, ok := m.(mat.Symmetric); ok {
+		symmetry = "symmetric"
+	}
+	return writeMM(w, format, "real", symmetry, r, c, func(i, j int) []float64 {
+		v := m.At(i, j)
+		if v == 0 {
+			return nil
+		}
+		return []float64{v}
+	})
+}
+
+// WriteMatrixMarketComplex writes m to w as a general complex matrix in the
+// Matrix Market exchange format using the given layout.
+func WriteMatrixMarketComplex(w io.Writer, m mat.CMatrix, format MatrixMarketFormat) error {
+	r, c := m.Dims()
+	return writeMM(w, format, "complex", "general", r, c, func(i, j int) []float64 {
+		v := m.At(i, j)
+		if v == 0 {
+			return nil
+		}
+		return []float64{real(v), imag(v)}
+	})
+}
+
+// writeMM writes an r×c matrix with the elements returned by at. at returns
+// nil for zero elements.
+func writeMM(w io.Writer, format MatrixMarketFormat, field, symmetry string, r, c int, at func(i, j int) []float64) error {
+	if format != Array && format != Coordinate {
+		return fmt.Errorf("encoding: invalid Matrix Market format %v", format)
+	}
+	lower := symmetry != "general"
+	nvals := 1
+	if field == "complex" {
+		nvals = 2
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s matrix %v %s %s\n", mmBanner, format, field, symmetry)
+
+	// Elements are written in column-major order.
+	if format == Array {
+		fmt.Fprintf(bw, "%d %d\n", r, c)
+	} else {
+		var nnz int
+		for j := 0; j < c; j++ {
+			for i := 0; i < r; i++ {
+				if (!lower || i >= j) && at(i, j) != nil {
+					nnz++
+				}
+			}
+		}
+		fmt.Fprintf(bw, "%d %d %d\n", r, c, nnz)
+	}
+	var buf []byte
+	for j := 0; j < c; j++ {
+		i0 := 0
+		if lower {
+			i0 = j
+		}
+		for i := i0; i < r; i++ {
+			v := at(i, j)
+			if v == nil {
+				if format == Coordinate {
+					continue
+				}
+				v = make([]float64, nvals)
+			}
+			buf = buf[:0]
+			if format == Coordinate {
+				buf = strconv.AppendInt(buf, int64(i+1), 10)
+				buf = append(buf, ' ')
+				buf = strconv.AppendInt(buf, int64(j+1), 10)
+				buf = append(buf, ' ')
+			}
+			for k, x := range v {
+				if k != 0 {
+					buf = append(buf, ' ')
+				}
+				buf = strconv.AppendFloat(buf, x, 'g', -1, 64)
+			}
+			buf = append(buf, '\n')
+			bw.Write(buf)
+		}
+	}
+	return bw.Flush()
+}
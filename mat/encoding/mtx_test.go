@@ -0,0 +1,352 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encoding
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+var readMatrixMarketTests = []struct {
+	name string
+	data string
+	dst  func() interface{}
+	want interface{}
+	err  error
+}{
+	{
+		name: "coordinate real general",
+		data: `%%MatrixMarket matrix coordinate real general
+% A comment.
+%
+3 2 3
+
+1 1 1.5
+3 2 -2
+2 1 1e3
+`,
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(3, 2, []float64{1.5, 0, 1e3, 0, 0, -2}),
+	},
+	{
+		name: "coordinate duplicate entries",
+		data: `%%MatrixMarket matrix coordinate real general
+2 2 3
+1 1 1
+1 1 2
+2 2 4
+`,
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(2, 2, []float64{3, 0, 0, 4}),
+	},
+	{
+		name: "array real general",
+		data: `%%MatrixMarket matrix array real general
+2 3
+1
+4
+2
+5
+3
+6
+`,
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+	},
+	{
+		name: "upper case banner",
+		data: `%%MatrixMarket MATRIX Array Integer General
+1 2
+7
+-8
+`,
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(1, 2, []float64{7, -8}),
+	},
+	{
+		name: "coordinate symmetric",
+		data: `%%MatrixMarket matrix coordinate real symmetric
+3 3 4
+1 1 4
+2 1 1
+3 2 2
+3 3 5
+`,
+		dst:  func() interface{} { return &mat.SymDense{} },
+		want: mat.NewSymDense(3, []float64{4, 1, 0, 1, 0, 2, 0, 2, 5}),
+	},
+	{
+		name: "array symmetric into Dense",
+		data: `%%MatrixMarket matrix array real symmetric
+2 2
+1
+2
+3
+`,
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(2, 2, []float64{1, 2, 2, 3}),
+	},
+	{
+		name: "array skew-symmetric",
+		data: `%%MatrixMarket matrix array real skew-symmetric
+3 3
+1
+2
+3
+`,
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(3, 3, []float64{0, -1, -2, 1, 0, -3, 2, 3, 0}),
+	},
+	{
+		name: "coordinate pattern",
+		data: `%%MatrixMarket matrix coordinate pattern general
+2 3 2
+1 3
+2 1
+`,
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(2, 3, []float64{0, 0, 1, 1, 0, 0}),
+	},
+	{
+		name: "general symmetric data into SymDense",
+		data: `%%MatrixMarket matrix array real general
+2 2
+1
+2
+2
+3
+`,
+		dst:  func() interface{} { return &mat.SymDense{} },
+		want: mat.NewSymDense(2, []float64{1, 2, 2, 3}),
+	},
+	{
+		name: "column into VecDense",
+		data: `%%MatrixMarket matrix array real general
+3 1
+1
+2
+3
+`,
+		dst:  func() interface{} { return &mat.VecDense{} },
+		want: mat.NewVecDense(3, []float64{1, 2, 3}),
+	},
+	{
+		name: "row into VecDense",
+		data: `%%MatrixMarket matrix coordinate real general
+1 3 1
+1 2 5
+`,
+		dst:  func() interface{} { return &mat.VecDense{} },
+		want: mat.NewVecDense(3, []float64{0, 5, 0}),
+	},
+	{
+		name: "coordinate complex hermitian",
+		data: `%%MatrixMarket matrix coordinate complex hermitian
+2 2 3
+1 1 1 0
+2 1 2 3
+2 2 4 0
+`,
+		dst:  func() interface{} { return &mat.CDense{} },
+		want: mat.NewCDense(2, 2, []complex128{1, 2 - 3i, 2 + 3i, 4}),
+	},
+	{
+		name: "array complex general",
+		data: `%%MatrixMarket matrix array complex general
+1 2
+1 -1
+0 2
+`,
+		dst:  func() interface{} { return &mat.CDense{} },
+		want: mat.NewCDense(1, 2, []complex128{1 - 1i, 2i}),
+	},
+	{
+		name: "real into CDense",
+		data: `%%MatrixMarket matrix array real general
+1 2
+1
+2
+`,
+		dst:  func() interface{} { return &mat.CDense{} },
+		want: mat.NewCDense(1, 2, []complex128{1, 2}),
+	},
+	{
+		name: "complex with zero imaginary part into Dense",
+		data: `%%MatrixMarket matrix coordinate complex general
+1 1 1
+1 1 3 0
+`,
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(1, 1, []float64{3}),
+	},
+	{
+		name: "complex into Dense",
+		data: `%%MatrixMarket matrix coordinate complex general
+1 1 1
+1 1 3 1
+`,
+		dst: func() interface{} { return &mat.Dense{} },
+		err: ErrComplex,
+	},
+	{
+		name: "non-symmetric into SymDense",
+		data: `%%MatrixMarket matrix array real general
+2 2
+1
+2
+3
+4
+`,
+		dst: func() interface{} { return &mat.SymDense{} },
+		err: ErrNotSymmetric,
+	},
+	{
+		name: "matrix into VecDense",
+		data: `%%MatrixMarket matrix array real general
+2 2
+1
+2
+3
+4
+`,
+		dst: func() interface{} { return &mat.VecDense{} },
+		err: ErrShape,
+	},
+	{
+		name: "empty",
+		data: `%%MatrixMarket matrix coordinate real general
+0 0 0
+`,
+		dst: func() interface{} { return &mat.Dense{} },
+		err: mat.ErrZeroLength,
+	},
+	{
+		name: "truncated",
+		data: `%%MatrixMarket matrix coordinate real general
+2 2 2
+1 1 1
+`,
+		dst: func() interface{} { return &mat.Dense{} },
+		err: io.ErrUnexpectedEOF,
+	},
+}
+
+func TestReadMatrixMarket(t *testing.T) {
+	for _, test := range readMatrixMarketTests {
+		dst := test.dst()
+		err := ReadMatrixMarket(strings.NewReader(test.data), dst)
+		if err != test.err {
+			t.Errorf("%s: unexpected error: got:%v want:%v", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !equal(dst, test.want) {
+			t.Errorf("%s: unexpected result:\ngot: %v\nwant:%v", test.name, dst, test.want)
+		}
+	}
+}
+
+func TestReadMatrixMarketInvalid(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"%%MatrixMarket matrix coordinate real\n1 1 0\n",
+		"%%MatrixMarket vector coordinate real general\n1 1 0\n",
+		"%%MatrixMarket matrix sparse real general\n1 1 0\n",
+		"%%MatrixMarket matrix array pattern general\n1 1\n",
+		"%%MatrixMarket matrix array real symmetric\n2 3\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1 x\n",
+	} {
+		var m mat.Dense
+		err := ReadMatrixMarket(strings.NewReader(data), &m)
+		if err == nil {
+			t.Errorf("expected error for input %q", data)
+		}
+	}
+}
+
+func TestMatrixMarketRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		m   interface{}
+		dst func() interface{}
+	}{
+		{
+			m:   mat.NewDense(3, 2, []float64{1, 0, -2.5, 1e-300, 0, 1.0 / 3}),
+			dst: func() interface{} { return &mat.Dense{} },
+		},
+		{
+			m:   mat.NewSymDense(3, []float64{1, 2, 0, 2, 4, 5, 0, 5, 6}),
+			dst: func() interface{} { return &mat.SymDense{} },
+		},
+		{
+			m:   mat.NewVecDense(4, []float64{0, 1, 0, 2}),
+			dst: func() interface{} { return &mat.VecDense{} },
+		},
+		{
+			m:   mat.NewCDense(2, 2, []complex128{1 + 2i, 0, -3i, 4}),
+			dst: func() interface{} { return &mat.CDense{} },
+		},
+	} {
+		for _, format := range []MatrixMarketFormat{Array, Coordinate} {
+			var buf bytes.Buffer
+			var err error
+			switch m := test.m.(type) {
+			case mat.Matrix:
+				err = WriteMatrixMarket(&buf, m, format)
+			case mat.CMatrix:
+				err = WriteMatrixMarketComplex(&buf, m, format)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error writing %T in %v format: %v", test.m, format, err)
+			}
+			dst := test.dst()
+			err = ReadMatrixMarket(&buf, dst)
+			if err != nil {
+				t.Errorf("unexpected error reading %T in %v format: %v", test.m, format, err)
+				continue
+			}
+			if !equal(dst, test.m) {
+				t.Errorf("round trip of %T in %v format failed:\ngot: %v\nwant:%v", test.m, format, dst, test.m)
+			}
+		}
+	}
+}
+
+func TestWriteMatrixMarket(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteMatrixMarket(&buf, mat.NewSymDense(2, []float64{1, 0.5, 0.5, 0}), Coordinate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `%%MatrixMarket matrix coordinate real symmetric
+2 2 2
+1 1 1
+2 1 0.5
+`
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected output:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// equal returns whether a and b hold the same real or complex matrix.
+func equal(a, b interface{}) bool {
+	switch a := a.(type) {
+	case mat.Matrix:
+		b, ok := b.(mat.Matrix)
+		return ok && mat.Equal(a, b)
+	case mat.CMatrix:
+		b, ok := b.(mat.CMatrix)
+		return ok && mat.CEqual(a, b)
+	}
+	return false
+}
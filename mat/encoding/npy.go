@@ -0,0 +1,539 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encoding
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// npyMagic is the prefix of every .npy file.
+const npyMagic = "\x93NUMPY"
+
+// npyAlign is the alignment of the start of the array data in a .npy file.
+const npyAlign = 64
+
+// maxLen is the largest number of elements that can be read.
+const maxLen = int(^uint(0) >> 1)
+
+// npyHeader is the parsed header of a .npy file.
+type npyHeader struct {
+	descr   string
+	fortran bool
+	shape   []int
+}
+
+// dtype describes the in-file representation of an element.
+type dtype struct {
+	order binary.ByteOrder
+	kind  byte // One of 'b', 'i', 'u', 'f' or 'c'.
+	size  int  // Size of an element in bytes.
+}
+
+// ReadNPY reads a one or two dimensional array in the NumPy .npy format from r
+// and stores it in dst, which must be an empty *mat.Dense, *mat.SymDense,
+// *mat.VecDense or *mat.CDense. ReadNPY panics if dst is not empty.
+//
+// Boolean, integer, floating point and complex element types of either byte
+// order are supported, and arrays may be stored in either C or Fortran order.
+// A one-dimensional array of length n is read as an n×1 matrix. Complex data
+// can only be stored in a real destination if all the imaginary parts are
+// zero, and a *mat.VecDense destination requires an array with a single row
+// or column.
+//
+// ReadNPY does not limit the size of the array read, and so it should not be
+// used on untrusted data.
+func ReadNPY(r io.Reader, dst interface{}) error {
+	a, err := readNPY(r)
+	if err != nil {
+		return err
+	}
+	return a.assign(dst)
+}
+
+func readNPY(r io.Reader) (*array, error) {
+	var pre [len(npyMagic) + 2]byte
+	_, err := io.ReadFull(r, pre[:])
+	if err != nil {
+		return nil, err
+	}
+	if string(pre[:len(npyMagic)]) != npyMagic {
+		return nil, errors.New("encoding: not a .npy file")
+	}
+	var hlen int
+	switch major := pre[len(npyMagic)]; major {
+	case 1:
+		var b [2]byte
+		_, err = io.ReadFull(r, b[:])
+		hlen = int(binary.LittleEndian.Uint16(b[:]))
+	case 2, 3:
+		var b [4]byte
+		_, err = io.ReadFull(r, b[:])
+		hlen = int(binary.LittleEndian.Uint32(b[:]))
+	default:
+		return nil, fmt.Errorf("encoding: unsupported .npy version %d", major)
+	}
+	if err != nil {
+		return nil, err
+	}
+	hbuf := make([]byte, hlen)
+	_, err = io.ReadFull(r, hbuf)
+	if err != nil {
+		return nil, err
+	}
+	h, err := parseNPYHeader(string(hbuf))
+	if err != nil {
+		return nil, err
+	}
+	dt, err := parseDescr(h.descr)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows, cols int
+	vector := false
+	switch len(h.shape) {
+	case 0:
+		rows, cols = 1, 1
+	case 1:
+		rows, cols = h.shape[0], 1
+		vector = true
+	case 2:
+		rows, cols = h.shape[0], h.shape[1]
+	default:
+		return nil, fmt.Errorf("encoding: unsupported %d-dimensional array", len(h.shape))
+	}
+	if rows < 0 || cols < 0 {
+		return nil, fmt.Errorf("encoding: invalid array shape %v", h.shape)
+	}
+	if rows != 0 && (cols > maxLen/rows || rows*cols > maxLen/dt.size) {
+		return nil, errors.New("encoding: array too big")
+	}
+
+	a := newArray(rows, cols, dt.kind == 'c')
+	a.vector = vector
+	data := make([]byte, rows*cols*dt.size)
+	_, err = io.ReadFull(r, data)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	for k := 0; k < rows*cols; k++ {
+		// Find the row-major index of the k-th stored element.
+		idx := k
+		if h.fortran && !vector {
+			idx = (k%rows)*cols + k/rows
+		}
+		re, im := dt.decode(data[k*dt.size : (k+1)*dt.size])
+		a.re[idx] = re
+		if a.im != nil {
+			a.im[idx] = im
+		}
+	}
+	return a, nil
+}
+
+// parseNPYHeader parses the Python dictionary literal held in the header of
+// a .npy file.
+func parseNPYHeader(s string) (npyHeader, error) {
+	var h npyHeader
+	p := &pyParser{s: strings.TrimSpace(s)}
+	if !p.consume('{') {
+		return h, p.errorf("expected '{'")
+	}
+	var seen int
+	for {
+		if p.consume('}') {
+			break
+		}
+		key, err := p.str()
+		if err != nil {
+			return h, err
+		}
+		if !p.consume(':') {
+			return h, p.errorf("expected ':'")
+		}
+		switch key {
+		case "descr":
+			h.descr, err = p.str()
+		case "fortran_order":
+			h.fortran, err = p.boolean()
+		case "shape":
+			h.shape, err = p.tuple()
+		default:
+			return h, fmt.Errorf("encoding: unexpected .npy header key %q", key)
+		}
+		if err != nil {
+			return h, err
+		}
+		seen++
+		if !p.consume(',') {
+			if !p.consume('}') {
+				return h, p.errorf("expected ',' or '}'")
+			}
+			break
+		}
+	}
+	if seen != 3 {
+		return h, errors.New("encoding: incomplete .npy header")
+	}
+	return h, nil
+}
+
+// pyParser is a parser for the subset of Python literals used in .npy headers.
+type pyParser struct {
+	s   string
+	pos int
+}
+
+func (p *pyParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("encoding: invalid .npy header at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *pyParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// consume skips white space and advances past c if it is next, returning
+// whether it was found.
+func (p *pyParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *pyParser) str() (string, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) || (p.s[p.pos] != '\'' && p.s[p.pos] != '"') {
+		return "", p.errorf("expected string")
+	}
+	q := p.s[p.pos]
+	end := strings.IndexByte(p.s[p.pos+1:], q)
+	if end < 0 {
+		return "", p.errorf("unterminated string")
+	}
+	v := p.s[p.pos+1 : p.pos+1+end]
+	p.pos += end + 2
+	return v, nil
+}
+
+func (p *pyParser) boolean() (bool, error) {
+	p.skipSpace()
+	switch {
+	case strings.HasPrefix(p.s[p.pos:], "True"):
+		p.pos += len("True")
+		return true, nil
+	case strings.HasPrefix(p.s[p.pos:], "False"):
+		p.pos += len("False")
+		return false, nil
+	}
+	return false, p.errorf("expected boolean")
+}
+
+func (p *pyParser) tuple() ([]int, error) {
+	if !p.consume('(') {
+		return nil, p.errorf("expected '('")
+	}
+	var t []int
+	for {
+		if p.consume(')') {
+			return t, nil
+		}
+		p.skipSpace()
+		start := p.pos
+		for p.pos < len(p.s) && '0' <= p.s[p.pos] && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+		v, err := strconv.Atoi(p.s[start:p.pos])
+		if err != nil {
+			return nil, p.errorf("expected integer")
+		}
+		// Python 2 may write long integers with an L suffix.
+		if p.pos < len(p.s) && p.s[p.pos] == 'L' {
+			p.pos++
+		}
+		t = append(t, v)
+		if !p.consume(',') {
+			if !p.consume(')') {
+				return nil, p.errorf("expected ',' or ')'")
+			}
+			return t, nil
+		}
+	}
+}
+
+// parseDescr parses a NumPy array-protocol type string.
+func parseDescr(s string) (dtype, error) {
+	var dt dtype
+	if len(s) < 3 {
+		return dt, fmt.Errorf("encoding: unsupported .npy type %q", s)
+	}
+	switch s[0] {
+	case '<', '|':
+		dt.order = binary.LittleEndian
+	case '>':
+		dt.order = binary.BigEndian
+	case '=':
+		// NumPy always records the byte order of multi-byte types
+		// explicitly, so native order is treated as little-endian.
+		dt.order = binary.LittleEndian
+	default:
+		return dt, fmt.Errorf("encoding: unsupported .npy type %q", s)
+	}
+	dt.kind = s[1]
+	var err error
+	dt.size, err = strconv.Atoi(s[2:])
+	if err != nil {
+		return dt, fmt.Errorf("encoding: unsupported .npy type %q", s)
+	}
+	ok := false
+	switch dt.kind {
+	case 'b':
+		ok = dt.size == 1
+	case 'i', 'u':
+		ok = dt.size == 1 || dt.size == 2 || dt.size == 4 || dt.size == 8
+	case 'f':
+		ok = dt.size == 4 || dt.size == 8
+	case 'c':
+		ok = dt.size == 8 || dt.size == 16
+	}
+	if !ok {
+		return dt, fmt.Errorf("encoding: unsupported .npy type %q", s)
+	}
+	return dt, nil
+}
+
+// decode returns the value held in b.
+func (dt dtype) decode(b []byte) (re, im float64) {
+	switch dt.kind {
+	case 'b':
+		if b[0] != 0 {
+			return 1, 0
+		}
+		return 0, 0
+	case 'i':
+		switch dt.size {
+		case 1:
+			return float64(int8(b[0])), 0
+		case 2:
+			return float64(int16(dt.order.Uint16(b))), 0
+		case 4:
+			return float64(int32(dt.order.Uint32(b))), 0
+		default:
+			return float64(int64(dt.order.Uint64(b))), 0
+		}
+	case 'u':
+		switch dt.size {
+		case 1:
+			return float64(b[0]), 0
+		case 2:
+			return float64(dt.order.Uint16(b)), 0
+		case 4:
+			return float64(dt.order.Uint32(b)), 0
+		default:
+			return float64(dt.order.Uint64(b)), 0
+		}
+	case 'f':
+		if dt.size == 4 {
+			return float64(math.Float32frombits(dt.order.Uint32(b))), 0
+		}
+		return math.Float64frombits(dt.order.Uint64(b)), 0
+	default:
+		half := dt.size / 2
+		re, _ = dtype{order: dt.order, kind: 'f', size: half}.decode(b[:half])
+		im, _ = dtype{order: dt.order, kind: 'f', size: half}.decode(b[half:])
+		return re, im
+	}
+}
+
+// WriteNPY writes m to w in the NumPy .npy format as a C-ordered array of
+// little-endian float64 values. A mat.Vector with a single column is written
+// as a one-dimensional array, and any other matrix as a two-dimensional array.
+func WriteNPY(w io.Writer, m mat.Matrix) error {
+	r, c := m.Dims()
+	shape := []int{r, c}
+	if _, ok := m.(mat.Vector); ok && c == 1 {
+		shape = shape[:1]
+	}
+	return writeNPY(w, "<f8", shape, r, c, func(buf []byte, i, j int) {
+		binary.LittleEndian.PutUint64(buf, math.Float64bits(m.At(i, j)))
+	})
+}
+
+// WriteNPYComplex writes m to w in the NumPy .npy format as a C-ordered
+// two-dimensional array of little-endian complex128 values.
+func WriteNPYComplex(w io.Writer, m mat.CMatrix) error {
+	r, c := m.Dims()
+	return writeNPY(w, "<c16", []int{r, c}, r, c, func(buf []byte, i, j int) {
+		v := m.At(i, j)
+		binary.LittleEndian.PutUint64(buf, math.Float64bits(real(v)))
+		binary.LittleEndian.PutUint64(buf[8:], math.Float64bits(imag(v)))
+	})
+}
+
+// writeNPY writes an r×c array with the given type and shape, with the
+// elements encoded into buf by put.
+func writeNPY(w io.Writer, descr string, shape []int, r, c int, put func(buf []byte, i, j int)) error {
+	dt, err := parseDescr(descr)
+	if err != nil {
+		panic(err)
+	}
+
+	dims := make([]string, len(shape))
+	for i, d := range shape {
+		dims[i] = strconv.Itoa(d)
+	}
+	tuple := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		// A Python tuple with a single element has a trailing comma.
+		tuple += ","
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", descr, tuple)
+
+	// Pad the header with spaces and a terminating newline so that the
+	// data are aligned. As in NumPy, at least one space is added.
+	major := byte(1)
+	pre := len(npyMagic) + 2 + 2
+	if pre+sb.Len()+1 > math.MaxUint16 {
+		major = 2
+		pre += 2
+	}
+	pad := npyAlign - (pre+sb.Len()+1)%npyAlign
+	header := sb.String() + strings.Repeat(" ", pad) + "\n"
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(npyMagic)
+	bw.Write([]byte{major, 0})
+	if major == 1 {
+		var b [2]byte
+		binary.LittleEndian.PutUint16(b[:], uint16(len(header)))
+		bw.Write(b[:])
+	} else {
+		var b [4]byte
+		binary.LittleEndian.PutUint32(b[:], uint32(len(header)))
+		bw.Write(b[:])
+	}
+	bw.WriteString(header)
+	buf := make([]byte, dt.size)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			put(buf, i, j)
+			bw.Write(buf)
+		}
+	}
+	return bw.Flush()
+}
+
+// NPZReader reads arrays from a NumPy .npz archive as written by
+// numpy.savez and numpy.savez_compressed.
+type NPZReader struct {
+	zr *zip.Reader
+}
+
+// NewNPZReader returns a new NPZReader reading from r, which is assumed to
+// have the given size in bytes.
+func NewNPZReader(r io.ReaderAt, size int64) (*NPZReader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return &NPZReader{zr: zr}, nil
+}
+
+// Names returns the names of the arrays held in the archive in the order
+// they are stored.
+func (r *NPZReader) Names() []string {
+	var names []string
+	for _, f := range r.zr.File {
+		names = append(names, strings.TrimSuffix(f.Name, ".npy"))
+	}
+	return names
+}
+
+// Read reads the named array from the archive and stores it in dst.
+// See ReadNPY for the destinations that are supported.
+func (r *NPZReader) Read(name string, dst interface{}) error {
+	for _, f := range r.zr.File {
+		if f.Name != name+".npy" && f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = ReadNPY(rc, dst)
+		cerr := rc.Close()
+		if err != nil {
+			return err
+		}
+		return cerr
+	}
+	return fmt.Errorf("encoding: array %q not found", name)
+}
+
+// NPZWriter writes arrays to a NumPy .npz archive.
+type NPZWriter struct {
+	// Compress specifies whether arrays are deflate compressed
+	// as by numpy.savez_compressed.
+	Compress bool
+
+	zw *zip.Writer
+}
+
+// NewNPZWriter returns a new NPZWriter writing to w.
+func NewNPZWriter(w io.Writer) *NPZWriter {
+	return &NPZWriter{zw: zip.NewWriter(w)}
+}
+
+// Write adds m to the archive as an array with the given name.
+// See WriteNPY for the representation of the array.
+func (w *NPZWriter) Write(name string, m mat.Matrix) error {
+	f, err := w.create(name)
+	if err != nil {
+		return err
+	}
+	return WriteNPY(f, m)
+}
+
+// WriteComplex adds m to the archive as an array with the given name.
+// See WriteNPYComplex for the representation of the array.
+func (w *NPZWriter) WriteComplex(name string, m mat.CMatrix) error {
+	f, err := w.create(name)
+	if err != nil {
+		return err
+	}
+	return WriteNPYComplex(f, m)
+}
+
+func (w *NPZWriter) create(name string) (io.Writer, error) {
+	method := zip.Store
+	if w.Compress {
+		method = zip.Deflate
+	}
+	return w.zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: method})
+}
+
+// Close finishes writing the archive. It does not close the underlying
+// writer.
+func (w *NPZWriter) Close() error {
+	return w.zw.Close()
+}
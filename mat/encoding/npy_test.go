@@ -0,0 +1,425 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package encoding
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// npyFile returns a .npy file with the given version, header dictionary
+// and data, padded as NumPy does.
+func npyFile(major byte, dict string, data []byte) []byte {
+	pre := len(npyMagic) + 2 + 2
+	if major > 1 {
+		pre += 2
+	}
+	pad := npyAlign - (pre+len(dict)+1)%npyAlign
+	header := dict + strings.Repeat(" ", pad) + "\n"
+
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.Write([]byte{major, 0})
+	if major == 1 {
+		binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	} else {
+		binary.Write(&buf, binary.LittleEndian, uint32(len(header)))
+	}
+	buf.WriteString(header)
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+// encode returns the binary encoding of the values in v with the given
+// byte order.
+func encode(order binary.ByteOrder, v interface{}) []byte {
+	var buf bytes.Buffer
+	err := binary.Write(&buf, order, v)
+	if err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+var readNPYTests = []struct {
+	name string
+	data []byte
+	dst  func() interface{}
+	want interface{}
+	err  bool
+}{
+	{
+		name: "little-endian float64 C order",
+		data: npyFile(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }",
+			encode(binary.LittleEndian, []float64{1, 2, 3, 4, 5, 6})),
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+	},
+	{
+		name: "big-endian float64 Fortran order",
+		data: npyFile(1, "{'descr': '>f8', 'fortran_order': True, 'shape': (2, 3), }",
+			encode(binary.BigEndian, []float64{1, 4, 2, 5, 3, 6})),
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+	},
+	{
+		name: "big-endian float32",
+		data: npyFile(1, "{'descr': '>f4', 'fortran_order': False, 'shape': (1, 2), }",
+			encode(binary.BigEndian, []float32{0.5, -1})),
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(1, 2, []float64{0.5, -1}),
+	},
+	{
+		name: "version 2 header with reordered keys",
+		data: npyFile(2, `{"shape": (2,), "fortran_order": False, "descr": "<f8"}`,
+			encode(binary.LittleEndian, []float64{math.Inf(1), -3})),
+		dst:  func() interface{} { return &mat.VecDense{} },
+		want: mat.NewVecDense(2, []float64{math.Inf(1), -3}),
+	},
+	{
+		name: "int32 vector",
+		data: npyFile(1, "{'descr': '<i4', 'fortran_order': False, 'shape': (3,), }",
+			encode(binary.LittleEndian, []int32{-1, 0, 7})),
+		dst:  func() interface{} { return &mat.VecDense{} },
+		want: mat.NewVecDense(3, []float64{-1, 0, 7}),
+	},
+	{
+		name: "int64 vector into Dense",
+		data: npyFile(1, "{'descr': '>i8', 'fortran_order': False, 'shape': (2,), }",
+			encode(binary.BigEndian, []int64{-5, 1 << 40})),
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(2, 1, []float64{-5, 1 << 40}),
+	},
+	{
+		name: "uint8 and Python 2 shape",
+		data: npyFile(1, "{'descr': '|u1', 'fortran_order': False, 'shape': (1L, 3L), }",
+			[]byte{0, 128, 255}),
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(1, 3, []float64{0, 128, 255}),
+	},
+	{
+		name: "uint16 Fortran order",
+		data: npyFile(1, "{'descr': '<u2', 'fortran_order': True, 'shape': (2, 2), }",
+			encode(binary.LittleEndian, []uint16{1, 2, 3, 65535})),
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(2, 2, []float64{1, 3, 2, 65535}),
+	},
+	{
+		name: "bool",
+		data: npyFile(1, "{'descr': '|b1', 'fortran_order': False, 'shape': (2, 2), }",
+			[]byte{1, 0, 0, 1}),
+		dst:  func() interface{} { return &mat.SymDense{} },
+		want: mat.NewSymDense(2, []float64{1, 0, 0, 1}),
+	},
+	{
+		name: "scalar",
+		data: npyFile(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (), }",
+			encode(binary.LittleEndian, []float64{42})),
+		dst:  func() interface{} { return &mat.Dense{} },
+		want: mat.NewDense(1, 1, []float64{42}),
+	},
+	{
+		name: "complex64",
+		data: npyFile(1, "{'descr': '<c8', 'fortran_order': False, 'shape': (1, 2), }",
+			encode(binary.LittleEndian, []float32{1, 2, 3, -4})),
+		dst:  func() interface{} { return &mat.CDense{} },
+		want: mat.NewCDense(1, 2, []complex128{1 + 2i, 3 - 4i}),
+	},
+	{
+		name: "big-endian complex128 Fortran order",
+		data: npyFile(1, "{'descr': '>c16', 'fortran_order': True, 'shape': (2, 2), }",
+			encode(binary.BigEndian, []float64{1, 1, 2, 2, 3, 3, 4, 4})),
+		dst:  func() interface{} { return &mat.CDense{} },
+		want: mat.NewCDense(2, 2, []complex128{1 + 1i, 3 + 3i, 2 + 2i, 4 + 4i}),
+	},
+	{
+		name: "complex into Dense",
+		data: npyFile(1, "{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }",
+			encode(binary.LittleEndian, []float64{1, 1})),
+		dst: func() interface{} { return &mat.Dense{} },
+		err: true,
+	},
+	{
+		name: "three dimensions",
+		data: npyFile(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (1, 1, 1), }",
+			encode(binary.LittleEndian, []float64{1})),
+		dst: func() interface{} { return &mat.Dense{} },
+		err: true,
+	},
+	{
+		name: "structured type",
+		data: npyFile(1, "{'descr': [('a', '<f8')], 'fortran_order': False, 'shape': (1,), }",
+			encode(binary.LittleEndian, []float64{1})),
+		dst: func() interface{} { return &mat.Dense{} },
+		err: true,
+	},
+	{
+		name: "unsupported type",
+		data: npyFile(1, "{'descr': '<f2', 'fortran_order': False, 'shape': (1,), }",
+			[]byte{0, 0}),
+		dst: func() interface{} { return &mat.Dense{} },
+		err: true,
+	},
+	{
+		name: "truncated data",
+		data: npyFile(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (2,), }",
+			encode(binary.LittleEndian, []float64{1})),
+		dst: func() interface{} { return &mat.Dense{} },
+		err: true,
+	},
+	{
+		name: "bad magic",
+		data: []byte("\x93NUMPZ\x01\x00\x00\x00"),
+		dst:  func() interface{} { return &mat.Dense{} },
+		err:  true,
+	},
+	{
+		name: "unsupported destination",
+		data: npyFile(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (1,), }",
+			encode(binary.LittleEndian, []float64{1})),
+		dst: func() interface{} { return &mat.TriDense{} },
+		err: true,
+	},
+}
+
+func TestReadNPY(t *testing.T) {
+	for _, test := range readNPYTests {
+		dst := test.dst()
+		err := ReadNPY(bytes.NewReader(test.data), dst)
+		if (err != nil) != test.err {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !equal(dst, test.want) {
+			t.Errorf("%s: unexpected result:\ngot: %v\nwant:%v", test.name, dst, test.want)
+		}
+	}
+}
+
+func TestParseNPYHeader(t *testing.T) {
+	for _, test := range []struct {
+		header string
+		want   npyHeader
+		err    bool
+	}{
+		{
+			header: "{'descr': '<f8', 'fortran_order': False, 'shape': (3, 4), }   \n",
+			want:   npyHeader{descr: "<f8", shape: []int{3, 4}},
+		},
+		{
+			header: "{'shape':(5,),'descr':'>i2','fortran_order':True}",
+			want:   npyHeader{descr: ">i2", fortran: true, shape: []int{5}},
+		},
+		{header: "{'descr': '<f8', 'shape': (3,), }", err: true},
+		{header: "{'descr': '<f8', 'fortran_order': 0, 'shape': (3,), }", err: true},
+		{header: "{'descr': '<f8', 'fortran_order': False, 'shape': (3, x), }", err: true},
+		{header: "{'descr': '<f8', 'fortran_order': False, 'shape': (3,), 'extra': 1}", err: true},
+		{header: "'descr': '<f8'", err: true},
+	} {
+		got, err := parseNPYHeader(test.header)
+		if (err != nil) != test.err {
+			t.Errorf("unexpected error for %q: %v", test.header, err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected header for %q: got:%+v want:%+v", test.header, got, test.want)
+		}
+	}
+}
+
+func TestWriteNPY(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteNPY(&buf, mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// This is the output of numpy.save for the same array.
+	want := npyFile(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }",
+		encode(binary.LittleEndian, []float64{1, 2, 3, 4, 5, 6}))
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("unexpected output:\ngot: %q\nwant:%q", buf.Bytes(), want)
+	}
+
+	buf.Reset()
+	err = WriteNPY(&buf, mat.NewVecDense(2, []float64{1, 2}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "'shape': (2,), }") {
+		t.Errorf("unexpected header for vector: %q", buf.Bytes())
+	}
+	if (buf.Len()-2*8)%npyAlign != 0 {
+		t.Errorf("data not aligned")
+	}
+}
+
+func TestNPYRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		m   interface{}
+		dst func() interface{}
+	}{
+		{
+			m:   mat.NewDense(3, 2, []float64{1, 0, -2.5, math.SmallestNonzeroFloat64, math.NaN(), 1.0 / 3}),
+			dst: func() interface{} { return &mat.Dense{} },
+		},
+		{
+			m:   mat.NewDense(3, 2, []float64{1, 0, -2.5, 1e-300, 0, 1.0 / 3}).T(),
+			dst: func() interface{} { return &mat.Dense{} },
+		},
+		{
+			m:   mat.NewSymDense(2, []float64{1, 2, 2, 3}),
+			dst: func() interface{} { return &mat.SymDense{} },
+		},
+		{
+			m:   mat.NewVecDense(3, []float64{1, 2, 3}),
+			dst: func() interface{} { return &mat.VecDense{} },
+		},
+		{
+			m:   mat.NewCDense(2, 2, []complex128{1 + 2i, 0, -3i, 4}),
+			dst: func() interface{} { return &mat.CDense{} },
+		},
+	} {
+		var buf bytes.Buffer
+		var err error
+		switch m := test.m.(type) {
+		case mat.Matrix:
+			err = WriteNPY(&buf, m)
+		case mat.CMatrix:
+			err = WriteNPYComplex(&buf, m)
+		}
+		if err != nil {
+			t.Fatalf("unexpected error writing %T: %v", test.m, err)
+		}
+		dst := test.dst()
+		err = ReadNPY(&buf, dst)
+		if err != nil {
+			t.Errorf("unexpected error reading %T: %v", test.m, err)
+			continue
+		}
+		if !equalNaN(dst, test.m) {
+			t.Errorf("round trip of %T failed:\ngot: %v\nwant:%v", test.m, dst, test.m)
+		}
+	}
+}
+
+func TestNPZ(t *testing.T) {
+	a := mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	v := mat.NewVecDense(2, []float64{-1, 1})
+	c := mat.NewCDense(1, 2, []complex128{1i, 2})
+
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		w := NewNPZWriter(&buf)
+		w.Compress = compress
+		if err := w.Write("a", a); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := w.Write("v", v); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := w.WriteComplex("c", c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data := buf.Bytes()
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		wantMethod := zip.Store
+		if compress {
+			wantMethod = zip.Deflate
+		}
+		for _, f := range zr.File {
+			if f.Method != wantMethod {
+				t.Errorf("unexpected compression method for %s: got:%d want:%d", f.Name, f.Method, wantMethod)
+			}
+		}
+
+		r, err := NewNPZReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, want := r.Names(), []string{"a", "v", "c"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected names: got:%v want:%v", got, want)
+		}
+		var (
+			gotA mat.Dense
+			gotV mat.VecDense
+			gotC mat.CDense
+		)
+		for _, test := range []struct {
+			name string
+			dst  interface{}
+			want interface{}
+		}{
+			{name: "a", dst: &gotA, want: a},
+			{name: "v", dst: &gotV, want: v},
+			{name: "c", dst: &gotC, want: c},
+		} {
+			err = r.Read(test.name, test.dst)
+			if err != nil {
+				t.Errorf("unexpected error reading %s: %v", test.name, err)
+				continue
+			}
+			if !equal(test.dst, test.want) {
+				t.Errorf("unexpected result for %s:\ngot: %v\nwant:%v", test.name, test.dst, test.want)
+			}
+		}
+		var missing mat.Dense
+		if err := r.Read("missing", &missing); err == nil {
+			t.Error("expected error for missing array")
+		}
+	}
+}
+
+func TestReadNPYNonEmpty(t *testing.T) {
+	data := npyFile(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (1,), }",
+		encode(binary.LittleEndian, []float64{1}))
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for non-empty destination")
+		}
+	}()
+	_ = ReadNPY(bytes.NewReader(data), mat.NewDense(1, 1, nil))
+}
+
+// equalNaN is like equal but treats NaN elements as equal.
+func equalNaN(a, b interface{}) bool {
+	am, ok := a.(mat.Matrix)
+	if !ok {
+		return equal(a, b)
+	}
+	bm, ok := b.(mat.Matrix)
+	if !ok {
+		return false
+	}
+	r, c := am.Dims()
+	if br, bc := bm.Dims(); r != br || c != bc {
+		return false
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			x, y := am.At(i, j), bm.At(i, j)
+			if x != y && !(math.IsNaN(x) && math.IsNaN(y)) {
+				return false
+			}
+		}
+	}
+	return true
+}
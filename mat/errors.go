@@ -134,6 +134,10 @@ var (
 	ErrSliceLengthMismatch = Error{"matrix: input slice length mismatch"}
 	ErrNotPSD              = Error{"matrix: input not positive symmetric definite"}
 	ErrFailedEigen         = Error{"matrix: eigendecomposition not successful"}
+	ErrFailedSVD           = Error{"matrix: singular value decomposition not successful"}
+	ErrNegativeEigenvalue  = Error{"matrix: input has negative real eigenvalue"}
+	ErrNotStable           = Error{"matrix: input has eigenvalue with non-negative real part"}
+	ErrZeroRealPart        = Error{"matrix: input has eigenvalue with zero real part"}
 )
 
 // ErrorStack represents matrix handling errors that have been recovered by Maybe wrappers.
@@ -0,0 +1,368 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// GeneralizedEigenSym is a type for creating and using the eigenvalue
+// decomposition of a symmetric-definite matrix pencil (A, B) where A is
+// symmetric and B is symmetric positive definite.
+type GeneralizedEigenSym struct {
+	vectorsComputed bool
+
+	values  []float64
+	vectors *Dense
+}
+
+// Factorize computes the eigenvalue decomposition of the symmetric-definite
+// generalized eigenproblem
+//  A * x = λ * B * x
+// where A is symmetric and B is symmetric positive definite. The problem is
+// reduced to a standard symmetric eigenproblem using the Cholesky
+// factorization of B. Factorize computes the eigenvalues in ascending order.
+// If the vectors input argument is false, the eigenvectors are not computed.
+//
+// Factorize panics if a and b do not have the same size.
+//
+// Factorize returns whether the decomposition succeeded. The decomposition
+// fails if b is not positive definite or if the eigenvalue iteration does not
+// converge. If the decomposition failed, methods that require a successful
+// factorization will panic.
+func (e *GeneralizedEigenSym) Factorize(a, b Symmetric, vectors bool) (ok bool) {
+	// kill previous decomposition
+	e.vectorsComputed = false
+	e.values = nil
+	e.vectors = nil
+
+	n := a.Symmetric()
+	if b.Symmetric() != n {
+		panic(ErrShape)
+	}
+
+	var chol Cholesky
+	ok = chol.Factorize(b)
+	if !ok {
+		return false
+	}
+
+	sd := NewSymDense(n, nil)
+	sd.CopySym(a)
+	lapack64.Sygst(lapack.AxLBx, sd.mat, chol.chol.mat)
+
+	jobz := lapack.EVNone
+	if vectors {
+		jobz = lapack.EVCompute
+	}
+	w := make([]float64, n)
+	work := []float64{0}
+	lapack64.Syev(jobz, sd.mat, w, work, -1)
+
+	work = getFloats(int(work[0]), false)
+	ok = lapack64.Syev(jobz, sd.mat, w, work, len(work))
+	putFloats(work)
+	if !ok {
+		return false
+	}
+	e.values = w
+	if vectors {
+		// Back-transform the eigenvectors of the reduced problem
+		// to the eigenvectors of the original problem by solving
+		//  U * x = y.
+		v := NewDense(n, n, sd.mat.Data)
+		blas64.Trsm(blas.Left, blas.NoTrans, 1, chol.chol.mat, v.mat)
+		e.vectors = v
+	}
+	e.vectorsComputed = vectors
+	return true
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (e *GeneralizedEigenSym) succFact() bool {
+	return len(e.values) != 0
+}
+
+// Values extracts the eigenvalues of the factorized pencil. If dst is
+// non-nil, the values are stored in-place into dst. In this case
+// dst must have length n, otherwise Values will panic. If dst is
+// nil, then a new slice will be allocated of the proper length and filled
+// with the eigenvalues.
+//
+// Values panics if the decomposition was not successful.
+func (e *GeneralizedEigenSym) Values(dst []float64) []float64 {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]float64, len(e.values))
+	}
+	if len(dst) != len(e.values) {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(dst, e.values)
+	return dst
+}
+
+// VectorsTo returns the eigenvectors of the decomposition. VectorsTo
+// will panic if the eigenvectors were not computed during the factorization,
+// or if the factorization was not successful.
+//
+// The eigenvectors are normalized so that
+//  X^T * B * X = I.
+//
+// If dst is not nil, the eigenvectors are stored in-place into dst, and dst
+// must have size n×n and panics otherwise. If dst is nil, a new matrix
+// is allocated and returned.
+func (e *GeneralizedEigenSym) VectorsTo(dst *Dense) *Dense {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if !e.vectorsComputed {
+		panic(badNoVect)
+	}
+	r, c := e.vectors.Dims()
+	if dst == nil {
+		dst = NewDense(r, c, nil)
+	} else {
+		dst.reuseAs(r, c)
+	}
+	dst.Copy(e.vectors)
+	return dst
+}
+
+// GeneralizedEigen is a type for creating and using the eigenvalue
+// decomposition of a general square matrix pencil (A, B).
+type GeneralizedEigen struct {
+	n int // The size of the factorized pencil.
+
+	kind EigenKind
+
+	alpha    []complex128
+	beta     []float64
+	rVectors *CDense
+	lVectors *CDense
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (e *GeneralizedEigen) succFact() bool {
+	return e.n != 0
+}
+
+// Factorize computes the generalized eigenvalues of the square matrix pencil
+// (A, B), and optionally the generalized eigenvectors, using the QZ algorithm.
+//
+// A generalized eigenvalue is a scalar λ such that A - λ*B is singular. It is
+// represented as a ratio α/β where β may be zero, in which case the
+// eigenvalue is infinite.
+//
+// A right eigenvalue/eigenvector combination is defined by
+//  A * x_r = λ * B * x_r
+// and a left eigenvalue/eigenvector combination is defined by
+//  x_l^H * A = λ * x_l^H * B.
+//
+// kind specifies which of the eigenvectors, if any, to compute. See the
+// EigenKind documentation for more information.
+// Factorize panics if the input matrices are not square or do not have the
+// same size.
+//
+// Factorize returns whether the decomposition succeeded. If the decomposition
+// failed, methods that require a successful factorization will panic.
+func (e *GeneralizedEigen) Factorize(a, b Matrix, kind EigenKind) (ok bool) {
+	// kill previous factorization.
+	e.n = 0
+	e.kind = 0
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrShape)
+	}
+	br, bc := b.Dims()
+	if br != r || bc != c {
+		panic(ErrShape)
+	}
+	// Copy a and b because they are modified during the Lapack call.
+	var ad, bd Dense
+	ad.Clone(a)
+	bd.Clone(b)
+
+	left := kind&EigenLeft != 0
+	right := kind&EigenRight != 0
+
+	var vl, vr Dense
+	jobvl := lapack.LeftEVNone
+	jobvr := lapack.RightEVNone
+	if left {
+		vl = *NewDense(r, r, nil)
+		jobvl = lapack.LeftEVCompute
+	}
+	if right {
+		vr = *NewDense(c, c, nil)
+		jobvr = lapack.RightEVCompute
+	}
+
+	alphar := getFloats(c, false)
+	defer putFloats(alphar)
+	alphai := getFloats(c, false)
+	defer putFloats(alphai)
+	beta := make([]float64, c)
+
+	work := []float64{0}
+	lapack64.Ggev(jobvl, jobvr, ad.mat, bd.mat, alphar, alphai, beta, vl.mat, vr.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	ok = lapack64.Ggev(jobvl, jobvr, ad.mat, bd.mat, alphar, alphai, beta, vl.mat, vr.mat, work, len(work))
+	putFloats(work)
+
+	if !ok {
+		e.alpha = nil
+		e.beta = nil
+		return false
+	}
+	e.n = r
+	e.kind = kind
+
+	// Construct complex alpha from float64 data.
+	alpha := make([]complex128, r)
+	for i, v := range alphar {
+		alpha[i] = complex(v, alphai[i])
+	}
+	e.alpha = alpha
+	e.beta = beta
+
+	// Construct complex eigenvectors from float64 data.
+	if left {
+		cvl := NewCDense(r, r, nil)
+		complexEigenTo(cvl, &vl, alpha)
+		e.lVectors = cvl
+	} else {
+		e.lVectors = nil
+	}
+	if right {
+		cvr := NewCDense(c, c, nil)
+		complexEigenTo(cvr, &vr, alpha)
+		e.rVectors = cvr
+	} else {
+		e.rVectors = nil
+	}
+	return true
+}
+
+// Kind returns the EigenKind of the decomposition. If no decomposition has been
+// computed, Kind returns -1.
+func (e *GeneralizedEigen) Kind() EigenKind {
+	if !e.succFact() {
+		return -1
+	}
+	return e.kind
+}
+
+// Values extracts the generalized eigenvalues α/β of the factorized pencil.
+// If dst is non-nil, the values are stored in-place into dst. In this case
+// dst must have length n, otherwise Values will panic. If dst is nil, then a
+// new slice will be allocated of the proper length and filled with the
+// eigenvalues.
+//
+// Eigenvalues with β equal to zero are returned as complex infinity, unless
+// α is also zero in which case the pencil is singular and the eigenvalue is
+// returned as complex NaN. AlphaBeta may be used to obtain the eigenvalues
+// without forming the ratio.
+//
+// Values panics if the decomposition was not successful.
+func (e *GeneralizedEigen) Values(dst []complex128) []complex128 {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]complex128, e.n)
+	}
+	if len(dst) != e.n {
+		panic(ErrSliceLengthMismatch)
+	}
+	for i, a := range e.alpha {
+		b := e.beta[i]
+		switch {
+		case b != 0:
+			dst[i] = a / complex(b, 0)
+		case a == 0:
+			dst[i] = cmplx.NaN()
+		default:
+			dst[i] = cmplx.Inf()
+		}
+	}
+	return dst
+}
+
+// AlphaBeta extracts the numerators α and denominators β of the generalized
+// eigenvalues λ = α/β of the factorized pencil. If alpha and beta are non-nil,
+// the values are stored in-place and both must have length n, otherwise
+// AlphaBeta will panic. If either is nil, a new slice is allocated of the
+// proper length.
+//
+// AlphaBeta panics if the decomposition was not successful.
+func (e *GeneralizedEigen) AlphaBeta(alpha []complex128, beta []float64) ([]complex128, []float64) {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if alpha == nil {
+		alpha = make([]complex128, e.n)
+	}
+	if beta == nil {
+		beta = make([]float64, e.n)
+	}
+	if len(alpha) != e.n || len(beta) != e.n {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(alpha, e.alpha)
+	copy(beta, e.beta)
+	return alpha, beta
+}
+
+// VectorsTo returns the right eigenvectors of the decomposition. VectorsTo
+// will panic if the right eigenvectors were not computed during the factorization,
+// or if the factorization was not successful.
+//
+// The computed eigenvectors are normalized so that the component of largest
+// modulus has |real part| + |imag. part| equal to 1.
+func (e *GeneralizedEigen) VectorsTo(dst *CDense) *CDense {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if e.kind&EigenRight == 0 {
+		panic(badNoVect)
+	}
+	if dst == nil {
+		dst = NewCDense(e.n, e.n, nil)
+	} else {
+		dst.reuseAs(e.n, e.n)
+	}
+	dst.Copy(e.rVectors)
+	return dst
+}
+
+// LeftVectorsTo returns the left eigenvectors of the decomposition. LeftVectorsTo
+// will panic if the left eigenvectors were not computed during the factorization,
+// or if the factorization was not successful.
+//
+// The computed eigenvectors are normalized so that the component of largest
+// modulus has |real part| + |imag. part| equal to 1.
+func (e *GeneralizedEigen) LeftVectorsTo(dst *CDense) *CDense {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if e.kind&EigenLeft == 0 {
+		panic(badNoVect)
+	}
+	if dst == nil {
+		dst = NewCDense(e.n, e.n, nil)
+	} else {
+		dst.reuseAs(e.n, e.n)
+	}
+	dst.Copy(e.lVectors)
+	return dst
+}
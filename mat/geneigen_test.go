@@ -0,0 +1,149 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestGeneralizedEigenSym(t *testing.T) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		a := NewSymDense(n, nil)
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				a.SetSym(i, j, rnd.NormFloat64())
+			}
+		}
+		b := randSPD(n, rnd)
+
+		var ges GeneralizedEigenSym
+		ok := ges.Factorize(a, b, true)
+		if !ok {
+			t.Errorf("n=%d: unexpected factorization failure", n)
+			continue
+		}
+		values := ges.Values(nil)
+		if !sort.Float64sAreSorted(values) {
+			t.Errorf("n=%d: eigenvalues not ascending", n)
+		}
+		x := ges.VectorsTo(nil)
+
+		// Check that A*X = B*X*Λ.
+		var ax, bx Dense
+		ax.Mul(a, x)
+		bx.Mul(b, x)
+		bx.Mul(&bx, NewDiagDense(n, values))
+		if !EqualApprox(&ax, &bx, tol) {
+			t.Errorf("n=%d: A*X != B*X*Λ", n)
+		}
+
+		// Check that X^T*B*X = I.
+		var xbx Dense
+		xbx.Product(x.T(), b, x)
+		if !EqualApprox(&xbx, eye(n), tol) {
+			t.Errorf("n=%d: X^T*B*X != I", n)
+		}
+
+		// Check that the eigenvalues agree when not computing vectors.
+		var gesNoVec GeneralizedEigenSym
+		ok = gesNoVec.Factorize(a, b, false)
+		if !ok {
+			t.Errorf("n=%d: unexpected factorization failure without vectors", n)
+			continue
+		}
+		if !floats.EqualApprox(gesNoVec.Values(nil), values, tol) {
+			t.Errorf("n=%d: eigenvalues differ when not computing vectors", n)
+		}
+	}
+
+	// Check that a non-positive-definite B is reported.
+	var ges GeneralizedEigenSym
+	a := NewSymDense(2, []float64{1, 0, 0, 1})
+	b := NewSymDense(2, []float64{1, 0, 0, -1})
+	if ges.Factorize(a, b, true) {
+		t.Errorf("expected failure for indefinite B")
+	}
+}
+
+func TestGeneralizedEigen(t *testing.T) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		a := randNormDense(n, n, rnd)
+		b := randNormDense(n, n, rnd)
+
+		var ge GeneralizedEigen
+		ok := ge.Factorize(a, b, EigenBoth)
+		if !ok {
+			t.Errorf("n=%d: unexpected factorization failure", n)
+			continue
+		}
+		if ge.Kind() != EigenBoth {
+			t.Errorf("n=%d: unexpected kind", n)
+		}
+		alpha, beta := ge.AlphaBeta(nil, nil)
+		values := ge.Values(nil)
+		vr := ge.VectorsTo(nil)
+		vl := ge.LeftVectorsTo(nil)
+
+		ca := NewCDense(n, n, nil)
+		cb := NewCDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				ca.set(i, j, complex(a.At(i, j), 0))
+				cb.set(i, j, complex(b.At(i, j), 0))
+			}
+		}
+		for k := 0; k < n; k++ {
+			if beta[k] != 0 && cmplx.Abs(values[k]-alpha[k]/complex(beta[k], 0)) > tol*cmplx.Abs(values[k]) {
+				t.Errorf("n=%d: eigenvalue %d does not match alpha/beta", n, k)
+			}
+			// Check that β*A*x = α*B*x and β*y^H*A = α*y^H*B.
+			var rmax, lmax float64
+			for i := 0; i < n; i++ {
+				var r, l complex128
+				for j := 0; j < n; j++ {
+					r += (complex(beta[k], 0)*ca.At(i, j) - alpha[k]*cb.At(i, j)) * vr.At(j, k)
+					l += cmplx.Conj(vl.At(j, k)) * (complex(beta[k], 0)*ca.At(j, i) - alpha[k]*cb.At(j, i))
+				}
+				rmax = math.Max(rmax, cmplx.Abs(r))
+				lmax = math.Max(lmax, cmplx.Abs(l))
+			}
+			scale := math.Abs(beta[k])*Norm(a, 1) + cmplx.Abs(alpha[k])*Norm(b, 1)
+			if rmax > tol*scale {
+				t.Errorf("n=%d: right eigenvector %d mismatch", n, k)
+			}
+			if lmax > tol*scale {
+				t.Errorf("n=%d: left eigenvector %d mismatch", n, k)
+			}
+		}
+	}
+
+	// Check that an infinite eigenvalue is reported for a singular B.
+	var ge GeneralizedEigen
+	a := NewDense(2, 2, []float64{1, 2, 3, 4})
+	b := NewDense(2, 2, []float64{1, 0, 0, 0})
+	if !ge.Factorize(a, b, EigenNone) {
+		t.Fatalf("unexpected factorization failure for singular B")
+	}
+	var inf int
+	for _, v := range ge.Values(nil) {
+		if cmplx.IsInf(v) {
+			inf++
+		}
+	}
+	if inf != 1 {
+		t.Errorf("unexpected number of infinite eigenvalues: got %d, want 1", inf)
+	}
+}
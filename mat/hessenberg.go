@@ -0,0 +1,103 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badHess = "mat: invalid Hessenberg factorization"
+
+// Hessenberg is a type for creating and using the Hessenberg decomposition of
+// a square matrix.
+//
+// The Hessenberg decomposition of an n×n matrix A is
+//  A = Q * H * Q^T
+// where Q is an n×n orthogonal matrix and H is an n×n upper Hessenberg matrix,
+// that is, H[i,j] == 0 for i > j+1.
+type Hessenberg struct {
+	h   *Dense
+	tau []float64
+}
+
+// Factorize computes the Hessenberg decomposition of the square matrix a.
+// Factorize panics if a is not square.
+func (h *Hessenberg) Factorize(a Matrix) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	if h.h == nil {
+		h.h = &Dense{}
+	}
+	h.h.Clone(a)
+	h.tau = make([]float64, max(0, r-1))
+
+	work := []float64{0}
+	lapack64.Gehrd(0, r-1, h.h.mat, h.tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Gehrd(0, r-1, h.h.mat, h.tau, work, len(work))
+	putFloats(work)
+}
+
+// isValid returns whether the receiver contains a factorization.
+func (h *Hessenberg) isValid() bool {
+	return h.h != nil && !h.h.IsZero()
+}
+
+// HTo extracts the n×n upper Hessenberg matrix H from a Hessenberg
+// decomposition. If dst is nil, a new matrix is allocated. The resulting
+// matrix H is returned.
+//
+// HTo will panic if the receiver does not contain a factorization.
+func (h *Hessenberg) HTo(dst *Dense) *Dense {
+	if !h.isValid() {
+		panic(badHess)
+	}
+
+	n, _ := h.h.Dims()
+	if dst == nil {
+		dst = NewDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+
+	// Copy the upper Hessenberg part and zero the elementary reflectors
+	// stored below the first subdiagonal.
+	for i := 0; i < n; i++ {
+		row := dst.mat.Data[i*dst.mat.Stride : i*dst.mat.Stride+n]
+		k := max(0, i-1)
+		zero(row[:k])
+		copy(row[k:], h.h.mat.Data[i*h.h.mat.Stride+k:i*h.h.mat.Stride+n])
+	}
+	return dst
+}
+
+// QTo extracts the n×n orthogonal matrix Q from a Hessenberg decomposition.
+// If dst is nil, a new matrix is allocated. The resulting matrix Q is returned.
+//
+// QTo will panic if the receiver does not contain a factorization.
+func (h *Hessenberg) QTo(dst *Dense) *Dense {
+	if !h.isValid() {
+		panic(badHess)
+	}
+
+	n, _ := h.h.Dims()
+	if dst == nil {
+		dst = NewDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(h.h)
+
+	// Construct Q from the elementary reflectors.
+	work := []float64{0}
+	lapack64.Orghr(0, n-1, dst.mat, h.tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Orghr(0, n-1, dst.mat, h.tau, work, len(work))
+	putFloats(work)
+
+	return dst
+}
@@ -0,0 +1,63 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestHessenberg(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 31} {
+		a := randNormDense(n, n, rnd)
+
+		var hess Hessenberg
+		hess.Factorize(a)
+		h := hess.HTo(nil)
+		q := hess.QTo(nil)
+
+		for i := 0; i < n; i++ {
+			for j := 0; j < i-1; j++ {
+				if h.At(i, j) != 0 {
+					t.Errorf("n=%d: H not upper Hessenberg at (%d,%d): %v", n, i, j, h.At(i, j))
+				}
+			}
+		}
+
+		var qtq Dense
+		qtq.Mul(q.T(), q)
+		if !EqualApprox(&qtq, eye(n), 1e-13) {
+			t.Errorf("n=%d: Q not orthogonal", n)
+		}
+
+		var qh, got Dense
+		qh.Mul(q, h)
+		got.Mul(&qh, q.T())
+		if !EqualApprox(&got, a, 1e-12) {
+			t.Errorf("n=%d: Q*H*Q^T does not equal A", n)
+		}
+
+		// Check that HTo and QTo overwrite a non-empty destination.
+		dst := randNormDense(n, n, rnd)
+		hess.HTo(dst)
+		if !Equal(dst, h) {
+			t.Errorf("n=%d: unexpected H with non-empty destination", n)
+		}
+		hess.QTo(dst)
+		if !Equal(dst, q) {
+			t.Errorf("n=%d: unexpected Q with non-empty destination", n)
+		}
+	}
+
+	var hess Hessenberg
+	if panicked, _ := panics(func() { hess.Factorize(NewDense(2, 3, nil)) }); !panicked {
+		t.Errorf("expected panic for non-square matrix")
+	}
+	if panicked, _ := panics(func() { hess.HTo(nil) }); !panicked {
+		t.Errorf("expected panic for HTo without factorization")
+	}
+}
@@ -68,6 +68,44 @@ func (m *CDense) set(i, j int, v complex128) {
 	m.mat.Data[i*m.mat.Stride+j] = v
 }
 
+// At returns the element at row i.
+// It panics if i is out of bounds or if j is not zero.
+func (v *CVecDense) At(i, j int) complex128 {
+	if uint(i) >= uint(v.n) {
+		panic(ErrRowAccess)
+	}
+	if j != 0 {
+		panic(ErrColAccess)
+	}
+	return v.at(i)
+}
+
+// AtVec returns the element at row i.
+// It panics if i is out of bounds.
+func (v *CVecDense) AtVec(i int) complex128 {
+	if uint(i) >= uint(v.n) {
+		panic(ErrRowAccess)
+	}
+	return v.at(i)
+}
+
+func (v *CVecDense) at(i int) complex128 {
+	return v.mat.Data[i*v.mat.Inc]
+}
+
+// SetVec sets the element at row i to the value val.
+// It panics if i is out of bounds.
+func (v *CVecDense) SetVec(i int, val complex128) {
+	if uint(i) >= uint(v.n) {
+		panic(ErrVectorAccess)
+	}
+	v.setVec(i, val)
+}
+
+func (v *CVecDense) setVec(i int, val complex128) {
+	v.mat.Data[i*v.mat.Inc] = val
+}
+
 // At returns the element at row i.
 // It panics if i is out of bounds or if j is not zero.
 func (v *VecDense) At(i, j int) float64 {
@@ -357,3 +395,107 @@ func (d *DiagDense) SetDiag(i int, v float64) {
 func (d *DiagDense) setDiag(i int, v float64) {
 	d.mat.Data[i*d.mat.Inc] = v
 }
+
+// At returns the element at row i, column j.
+func (m *Dense32) At(i, j int) float32 {
+	if uint(i) >= uint(m.mat.Rows) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.mat.Cols) {
+		panic(ErrColAccess)
+	}
+	return m.at(i, j)
+}
+
+func (m *Dense32) at(i, j int) float32 {
+	return m.mat.Data[i*m.mat.Stride+j]
+}
+
+// Set sets the element at row i, column j to the value v.
+func (m *Dense32) Set(i, j int, v float32) {
+	if uint(i) >= uint(m.mat.Rows) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.mat.Cols) {
+		panic(ErrColAccess)
+	}
+	m.set(i, j, v)
+}
+
+func (m *Dense32) set(i, j int, v float32) {
+	m.mat.Data[i*m.mat.Stride+j] = v
+}
+
+// At returns the element at row i.
+// It panics if i is out of bounds or if j is not zero.
+func (v *VecDense32) At(i, j int) float32 {
+	if uint(i) >= uint(v.n) {
+		panic(ErrRowAccess)
+	}
+	if j != 0 {
+		panic(ErrColAccess)
+	}
+	return v.at(i)
+}
+
+// AtVec returns the element at row i.
+// It panics if i is out of bounds.
+func (v *VecDense32) AtVec(i int) float32 {
+	if uint(i) >= uint(v.n) {
+		panic(ErrRowAccess)
+	}
+	return v.at(i)
+}
+
+func (v *VecDense32) at(i int) float32 {
+	return v.mat.Data[i*v.mat.Inc]
+}
+
+// SetVec sets the element at row i to the value val.
+// It panics if i is out of bounds.
+func (v *VecDense32) SetVec(i int, val float32) {
+	if uint(i) >= uint(v.n) {
+		panic(ErrVectorAccess)
+	}
+	v.setVec(i, val)
+}
+
+func (v *VecDense32) setVec(i int, val float32) {
+	v.mat.Data[i*v.mat.Inc] = val
+}
+
+// At returns the element at row i and column j.
+func (s *SymDense32) At(i, j int) float32 {
+	if uint(i) >= uint(s.mat.N) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(s.mat.N) {
+		panic(ErrColAccess)
+	}
+	return s.at(i, j)
+}
+
+func (s *SymDense32) at(i, j int) float32 {
+	if i > j {
+		i, j = j, i
+	}
+	return s.mat.Data[i*s.mat.Stride+j]
+}
+
+// SetSym sets the elements at (i,j) and (j,i) to the value v.
+func (s *SymDense32) SetSym(i, j int, v float32) {
+	if uint(i) >= uint(s.mat.N) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(s.mat.N) {
+		panic(ErrColAccess)
+	}
+	s.set(i, j, v)
+}
+
+func (s *SymDense32) set(i, j int, v float32) {
+	if i > j {
+		i, j = j, i
+	}
+	s.mat.Data[i*s.mat.Stride+j] = v
+}
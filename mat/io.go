@@ -11,6 +11,9 @@ import (
 	"fmt"
 	"io"
 	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
 )
 
 // version is the current on-disk codec version.
@@ -35,7 +38,7 @@ var (
 // Type encoding scheme:
 //
 // Type 		Form 	Packing 	Uplo 		Unit 		Rows 	Columns kU 	kL
-// uint8 		[GST] 	uint8 [BPF] 	uint8 [AUL] 	bool 		int64 	int64 	int64 	int64
+// uint8 		[GSTC] 	uint8 [BPF] 	uint8 [AUL] 	bool 		int64 	int64 	int64 	int64
 // General 		'G' 	'F' 		'A' 		false 		r 	c 	0 	0
 // Band 		'G' 	'B' 		'A' 		false 		r 	c 	kU 	kL
 // Symmetric 		'S' 	'F' 		ul 		false 		n 	n 	0 	0
@@ -44,8 +47,9 @@ var (
 // Triangular 		'T' 	'F' 		ul 		Diag==Unit 	n 	n 	0 	0
 // TriangularBand 	'T' 	'B' 		ul 		Diag==Unit 	n 	n 	k 	k
 // TriangularPacked 	'T' 	'P' 		ul	 	Diag==Unit 	n 	n 	0 	0
+// Complex 		'C' 	'F' 		'A' 		false 		r 	c 	0 	0
 //
-// G - general, S - symmetric, T - triangular
+// G - general, S - symmetric, T - triangular, C - complex general
 // F - full, B - band, P - packed
 // A - all, U - upper, L - lower
 
@@ -425,11 +429,1181 @@ func (v *VecDense) UnmarshalBinaryFrom(r io.Reader) (int, error) {
 	return n, nil
 }
 
+// MarshalBinary encodes the receiver into a binary form and returns the result.
+// Only the upper triangle of the matrix is encoded.
+//
+// SymDense is little-endian encoded as follows:
+//   0 -  3  Version = 1          (uint32)
+//   4       'S'                  (byte)
+//   5       'P'                  (byte)
+//   6       'U'                  (byte)
+//   7       0                    (byte)
+//   8 - 15  n                    (int64)
+//  16 - 23  n                    (int64)
+//  24 - 31  0                    (int64)
+//  32 - 39  0                    (int64)
+//  40 - ..  upper triangle elements (float64)
+//           [0,0] [0,1] ... [0,n-1]
+//           [1,1] ... [1,n-1]
+//           ...
+//           [n-1,n-1]
+func (s SymDense) MarshalBinary() ([]byte, error) {
+	return marshalElems(s.header(), packedLen(s.mat.N), s.visit)
+}
+
+// MarshalBinaryTo encodes the receiver into a binary form and writes it into w.
+// MarshalBinaryTo returns the number of bytes written into w and an error, if any.
+//
+// See MarshalBinary for the on-disk layout.
+func (s SymDense) MarshalBinaryTo(w io.Writer) (int, error) {
+	return marshalElemsTo(w, s.header(), s.visit)
+}
+
+func (s *SymDense) header() storage {
+	return storage{
+		Form: 'S', Packing: 'P', Uplo: 'U',
+		Rows: int64(s.mat.N), Cols: int64(s.mat.N),
+		Version: version,
+	}
+}
+
+// visit calls fn with each element of the upper triangle of s in row-major
+// order.
+func (s *SymDense) visit(fn func(v *float64)) {
+	for i := 0; i < s.mat.N; i++ {
+		for j := i; j < s.mat.N; j++ {
+			fn(&s.mat.Data[i*s.mat.Stride+j])
+		}
+	}
+}
+
+// UnmarshalBinary decodes the binary form into the receiver.
+// It panics if the receiver is a non-zero SymDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+//
+// Limited checks on the validity of the binary input are performed:
+//  - an error is returned if the number of rows and columns differ or are
+//  negative,
+//  - an error is returned if the resulting SymDense matrix is too
+//  big for the current architecture (e.g. a 16GB matrix written by a
+//  64b application and read back from a 32b application.)
+// UnmarshalBinary does not limit the size of the unmarshaled matrix, and so
+// it should not be used on untrusted data.
+func (s *SymDense) UnmarshalBinary(data []byte) error {
+	if !s.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	header, err := unmarshalHeader(data)
+	if err != nil {
+		return err
+	}
+	n, err := header.triangular('S', 'P')
+	if err != nil {
+		return err
+	}
+	if header.Uplo != 'U' {
+		return errWrongType
+	}
+	if len(data) != headerSize+int(packedLen(n))*sizeFloat64 {
+		return errBadBuffer
+	}
+
+	s.reuseAs(n)
+	unmarshalElems(data[headerSize:], s.visit)
+	return nil
+}
+
+// UnmarshalBinaryFrom decodes the binary form into the receiver and returns
+// the number of bytes read and an error if any.
+// It panics if the receiver is a non-zero SymDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+// See UnmarshalBinary for the list of sanity checks performed on the input.
+func (s *SymDense) UnmarshalBinaryFrom(r io.Reader) (int, error) {
+	if !s.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	var header storage
+	n, err := header.unmarshalBinaryFrom(r)
+	if err != nil {
+		return n, err
+	}
+	size, err := header.triangular('S', 'P')
+	if err != nil {
+		return n, err
+	}
+	if header.Uplo != 'U' {
+		return n, errWrongType
+	}
+
+	s.reuseAs(size)
+	nn, err := unmarshalElemsFrom(r, s.visit)
+	return n + nn, err
+}
+
+// MarshalBinary encodes the receiver into a binary form and returns the result.
+// Only the elements of the triangle of the matrix are encoded.
+//
+// TriDense is little-endian encoded as follows:
+//   0 -  3  Version = 1          (uint32)
+//   4       'T'                  (byte)
+//   5       'P'                  (byte)
+//   6       'U' or 'L'           (byte)
+//   7       0                    (byte)
+//   8 - 15  n                    (int64)
+//  16 - 23  n                    (int64)
+//  24 - 31  0                    (int64)
+//  32 - 39  0                    (int64)
+//  40 - ..  triangle elements    (float64)
+//           for an upper triangular matrix:
+//           [0,0] [0,1] ... [0,n-1]
+//           [1,1] ... [1,n-1]
+//           ...
+//           [n-1,n-1]
+//           and for a lower triangular matrix:
+//           [0,0]
+//           [1,0] [1,1]
+//           ...
+//           [n-1,0] ... [n-1,n-1]
+func (t TriDense) MarshalBinary() ([]byte, error) {
+	return marshalElems(t.header(), packedLen(t.mat.N), t.visit)
+}
+
+// MarshalBinaryTo encodes the receiver into a binary form and writes it into w.
+// MarshalBinaryTo returns the number of bytes written into w and an error, if any.
+//
+// See MarshalBinary for the on-disk layout.
+func (t TriDense) MarshalBinaryTo(w io.Writer) (int, error) {
+	return marshalElemsTo(w, t.header(), t.visit)
+}
+
+func (t *TriDense) header() storage {
+	return storage{
+		Form: 'T', Packing: 'P', Uplo: uploByte(t.mat.Uplo),
+		Rows: int64(t.mat.N), Cols: int64(t.mat.N),
+		Version: version,
+	}
+}
+
+// visit calls fn with each element of the triangle of t in row-major order.
+func (t *TriDense) visit(fn func(v *float64)) {
+	n := t.mat.N
+	for i := 0; i < n; i++ {
+		jl, ju := i, n-1
+		if !t.isUpper() {
+			jl, ju = 0, i
+		}
+		for j := jl; j <= ju; j++ {
+			fn(&t.mat.Data[i*t.mat.Stride+j])
+		}
+	}
+}
+
+// UnmarshalBinary decodes the binary form into the receiver.
+// It panics if the receiver is a non-zero TriDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+//
+// Limited checks on the validity of the binary input are performed:
+//  - an error is returned if the number of rows and columns differ or are
+//  negative,
+//  - an error is returned if the resulting TriDense matrix is too
+//  big for the current architecture (e.g. a 16GB matrix written by a
+//  64b application and read back from a 32b application.)
+// UnmarshalBinary does not limit the size of the unmarshaled matrix, and so
+// it should not be used on untrusted data.
+func (t *TriDense) UnmarshalBinary(data []byte) error {
+	if !t.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	header, err := unmarshalHeader(data)
+	if err != nil {
+		return err
+	}
+	n, err := header.triangular('T', 'P')
+	if err != nil {
+		return err
+	}
+	kind, err := header.triKind()
+	if err != nil {
+		return err
+	}
+	if len(data) != headerSize+int(packedLen(n))*sizeFloat64 {
+		return errBadBuffer
+	}
+
+	t.reuseAs(n, kind)
+	unmarshalElems(data[headerSize:], t.visit)
+	return nil
+}
+
+// UnmarshalBinaryFrom decodes the binary form into the receiver and returns
+// the number of bytes read and an error if any.
+// It panics if the receiver is a non-zero TriDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+// See UnmarshalBinary for the list of sanity checks performed on the input.
+func (t *TriDense) UnmarshalBinaryFrom(r io.Reader) (int, error) {
+	if !t.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	var header storage
+	n, err := header.unmarshalBinaryFrom(r)
+	if err != nil {
+		return n, err
+	}
+	size, err := header.triangular('T', 'P')
+	if err != nil {
+		return n, err
+	}
+	kind, err := header.triKind()
+	if err != nil {
+		return n, err
+	}
+
+	t.reuseAs(size, kind)
+	nn, err := unmarshalElemsFrom(r, t.visit)
+	return n + nn, err
+}
+
+// MarshalBinary encodes the receiver into a binary form and returns the result.
+// Only the elements within the band of the matrix are encoded.
+//
+// BandDense is little-endian encoded as follows:
+//   0 -  3  Version = 1          (uint32)
+//   4       'G'                  (byte)
+//   5       'B'                  (byte)
+//   6       'A'                  (byte)
+//   7       0                    (byte)
+//   8 - 15  number of rows       (int64)
+//  16 - 23  number of columns    (int64)
+//  24 - 31  ku                   (int64)
+//  32 - 39  kl                   (int64)
+//  40 - ..  band elements        (float64)
+//           row i holds the elements [i,j] for max(0,i-kl) <= j <= min(ncols-1,i+ku)
+//           for the rows 0 <= i < min(nrows,ncols+kl)
+func (b BandDense) MarshalBinary() ([]byte, error) {
+	return marshalElems(b.header(), visitLen(b.visit), b.visit)
+}
+
+// MarshalBinaryTo encodes the receiver into a binary form and writes it into w.
+// MarshalBinaryTo returns the number of bytes written into w and an error, if any.
+//
+// See MarshalBinary for the on-disk layout.
+func (b BandDense) MarshalBinaryTo(w io.Writer) (int, error) {
+	return marshalElemsTo(w, b.header(), b.visit)
+}
+
+func (b *BandDense) header() storage {
+	return storage{
+		Form: 'G', Packing: 'B', Uplo: 'A',
+		Rows: int64(b.mat.Rows), Cols: int64(b.mat.Cols),
+		KU: int64(b.mat.KU), KL: int64(b.mat.KL),
+		Version: version,
+	}
+}
+
+// visit calls fn with each element within the band of b in row-major order.
+func (b *BandDense) visit(fn func(v *float64)) {
+	r, c, kl, ku := b.mat.Rows, b.mat.Cols, b.mat.KL, b.mat.KU
+	for i := 0; i < min(r, c+kl); i++ {
+		for j := max(0, i-kl); j <= min(c-1, i+ku); j++ {
+			fn(&b.mat.Data[i*b.mat.Stride+kl+j-i])
+		}
+	}
+}
+
+// UnmarshalBinary decodes the binary form into the receiver.
+// It panics if the receiver is a non-zero BandDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+//
+// Limited checks on the validity of the binary input are performed:
+//  - an error is returned if the number of rows or columns is negative or
+//  the bandwidths are out of range,
+//  - an error is returned if the resulting BandDense matrix is too
+//  big for the current architecture (e.g. a 16GB matrix written by a
+//  64b application and read back from a 32b application.)
+// UnmarshalBinary does not limit the size of the unmarshaled matrix, and so
+// it should not be used on untrusted data.
+func (b *BandDense) UnmarshalBinary(data []byte) error {
+	if !b.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	header, err := unmarshalHeader(data)
+	if err != nil {
+		return err
+	}
+	if header.Form != 'G' || header.Packing != 'B' || header.Uplo != 'A' || header.Unit {
+		return errWrongType
+	}
+	err = header.band()
+	if err != nil {
+		return err
+	}
+	if len(data) != headerSize+int(header.bandLen())*sizeFloat64 {
+		return errBadBuffer
+	}
+
+	b.reuseAsBand(header)
+	unmarshalElems(data[headerSize:], b.visit)
+	return nil
+}
+
+// UnmarshalBinaryFrom decodes the binary form into the receiver and returns
+// the number of bytes read and an error if any.
+// It panics if the receiver is a non-zero BandDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+// See UnmarshalBinary for the list of sanity checks performed on the input.
+func (b *BandDense) UnmarshalBinaryFrom(r io.Reader) (int, error) {
+	if !b.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	var header storage
+	n, err := header.unmarshalBinaryFrom(r)
+	if err != nil {
+		return n, err
+	}
+	if header.Form != 'G' || header.Packing != 'B' || header.Uplo != 'A' || header.Unit {
+		return n, errWrongType
+	}
+	err = header.band()
+	if err != nil {
+		return n, err
+	}
+
+	b.reuseAsBand(header)
+	nn, err := unmarshalElemsFrom(r, b.visit)
+	return n + nn, err
+}
+
+// reuseAsBand resizes a zero receiver to the band matrix described by the
+// validated header h.
+func (b *BandDense) reuseAsBand(h storage) {
+	r, c := int(h.Rows), int(h.Cols)
+	kl, ku := int(h.KL), int(h.KU)
+	bc := kl + ku + 1
+	b.mat = blas64.Band{
+		Rows:   r,
+		Cols:   c,
+		KL:     kl,
+		KU:     ku,
+		Stride: bc,
+		Data:   use(b.mat.Data, min(r, c+kl)*bc),
+	}
+}
+
+// MarshalBinary encodes the receiver into a binary form and returns the result.
+// Only the elements within the upper band of the matrix are encoded.
+//
+// SymBandDense is little-endian encoded as follows:
+//   0 -  3  Version = 1          (uint32)
+//   4       'S'                  (byte)
+//   5       'B'                  (byte)
+//   6       'U'                  (byte)
+//   7       0                    (byte)
+//   8 - 15  n                    (int64)
+//  16 - 23  n                    (int64)
+//  24 - 31  k                    (int64)
+//  32 - 39  k                    (int64)
+//  40 - ..  band elements        (float64)
+//           row i holds the elements [i,j] for i <= j <= min(n-1,i+k)
+func (s SymBandDense) MarshalBinary() ([]byte, error) {
+	return marshalElems(s.header(), visitLen(s.visit), s.visit)
+}
+
+// MarshalBinaryTo encodes the receiver into a binary form and writes it into w.
+// MarshalBinaryTo returns the number of bytes written into w and an error, if any.
+//
+// See MarshalBinary for the on-disk layout.
+func (s SymBandDense) MarshalBinaryTo(w io.Writer) (int, error) {
+	return marshalElemsTo(w, s.header(), s.visit)
+}
+
+func (s *SymBandDense) header() storage {
+	return storage{
+		Form: 'S', Packing: 'B', Uplo: 'U',
+		Rows: int64(s.mat.N), Cols: int64(s.mat.N),
+		KU: int64(s.mat.K), KL: int64(s.mat.K),
+		Version: version,
+	}
+}
+
+// visit calls fn with each element within the upper band of s in row-major
+// order.
+func (s *SymBandDense) visit(fn func(v *float64)) {
+	n, k := s.mat.N, s.mat.K
+	for i := 0; i < n; i++ {
+		for j := i; j <= min(n-1, i+k); j++ {
+			fn(&s.mat.Data[i*s.mat.Stride+j-i])
+		}
+	}
+}
+
+// UnmarshalBinary decodes the binary form into the receiver.
+// It panics if the receiver is a non-zero SymBandDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+//
+// Limited checks on the validity of the binary input are performed:
+//  - an error is returned if the number of rows and columns differ or are
+//  negative, or the bandwidth is out of range,
+//  - an error is returned if the resulting SymBandDense matrix is too
+//  big for the current architecture (e.g. a 16GB matrix written by a
+//  64b application and read back from a 32b application.)
+// UnmarshalBinary does not limit the size of the unmarshaled matrix, and so
+// it should not be used on untrusted data.
+func (s *SymBandDense) UnmarshalBinary(data []byte) error {
+	if !s.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	header, err := unmarshalHeader(data)
+	if err != nil {
+		return err
+	}
+	err = header.symBand('S')
+	if err != nil {
+		return err
+	}
+	if header.Uplo != 'U' {
+		return errWrongType
+	}
+	if len(data) != headerSize+int(header.symBandLen())*sizeFloat64 {
+		return errBadBuffer
+	}
+
+	s.reuseAsSymBand(header)
+	unmarshalElems(data[headerSize:], s.visit)
+	return nil
+}
+
+// UnmarshalBinaryFrom decodes the binary form into the receiver and returns
+// the number of bytes read and an error if any.
+// It panics if the receiver is a non-zero SymBandDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+// See UnmarshalBinary for the list of sanity checks performed on the input.
+func (s *SymBandDense) UnmarshalBinaryFrom(r io.Reader) (int, error) {
+	if !s.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	var header storage
+	n, err := header.unmarshalBinaryFrom(r)
+	if err != nil {
+		return n, err
+	}
+	err = header.symBand('S')
+	if err != nil {
+		return n, err
+	}
+	if header.Uplo != 'U' {
+		return n, errWrongType
+	}
+
+	s.reuseAsSymBand(header)
+	nn, err := unmarshalElemsFrom(r, s.visit)
+	return n + nn, err
+}
+
+// reuseAsSymBand resizes a zero receiver to the symmetric band matrix
+// described by the validated header h.
+func (s *SymBandDense) reuseAsSymBand(h storage) {
+	n, k := int(h.Rows), int(h.KU)
+	s.mat = blas64.SymmetricBand{
+		N:      n,
+		K:      k,
+		Stride: k + 1,
+		Uplo:   blas.Upper,
+		Data:   use(s.mat.Data, n*(k+1)),
+	}
+}
+
+// MarshalBinary encodes the receiver into a binary form and returns the result.
+// Only the elements within the band of the matrix are encoded.
+//
+// TriBandDense is little-endian encoded as follows:
+//   0 -  3  Version = 1          (uint32)
+//   4       'T'                  (byte)
+//   5       'B'                  (byte)
+//   6       'U' or 'L'           (byte)
+//   7       0                    (byte)
+//   8 - 15  n                    (int64)
+//  16 - 23  n                    (int64)
+//  24 - 31  k                    (int64)
+//  32 - 39  k                    (int64)
+//  40 - ..  band elements        (float64)
+//           for an upper triangular matrix, row i holds the elements [i,j]
+//           for i <= j <= min(n-1,i+k),
+//           for a lower triangular matrix, row i holds the elements [i,j]
+//           for max(0,i-k) <= j <= i
+func (t TriBandDense) MarshalBinary() ([]byte, error) {
+	return marshalElems(t.header(), visitLen(t.visit), t.visit)
+}
+
+// MarshalBinaryTo encodes the receiver into a binary form and writes it into w.
+// MarshalBinaryTo returns the number of bytes written into w and an error, if any.
+//
+// See MarshalBinary for the on-disk layout.
+func (t TriBandDense) MarshalBinaryTo(w io.Writer) (int, error) {
+	return marshalElemsTo(w, t.header(), t.visit)
+}
+
+func (t *TriBandDense) header() storage {
+	return storage{
+		Form: 'T', Packing: 'B', Uplo: uploByte(t.mat.Uplo),
+		Rows: int64(t.mat.N), Cols: int64(t.mat.N),
+		KU: int64(t.mat.K), KL: int64(t.mat.K),
+		Version: version,
+	}
+}
+
+// visit calls fn with each element within the band of t in row-major order.
+func (t *TriBandDense) visit(fn func(v *float64)) {
+	n, k := t.mat.N, t.mat.K
+	for i := 0; i < n; i++ {
+		if t.isUpper() {
+			for j := i; j <= min(n-1, i+k); j++ {
+				fn(&t.mat.Data[i*t.mat.Stride+j-i])
+			}
+		} else {
+			for j := max(0, i-k); j <= i; j++ {
+				fn(&t.mat.Data[i*t.mat.Stride+k+j-i])
+			}
+		}
+	}
+}
+
+// UnmarshalBinary decodes the binary form into the receiver.
+// It panics if the receiver is a non-zero TriBandDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+//
+// Limited checks on the validity of the binary input are performed:
+//  - an error is returned if the number of rows and columns differ or are
+//  negative, or the bandwidth is out of range,
+//  - an error is returned if the resulting TriBandDense matrix is too
+//  big for the current architecture (e.g. a 16GB matrix written by a
+//  64b application and read back from a 32b application.)
+// UnmarshalBinary does not limit the size of the unmarshaled matrix, and so
+// it should not be used on untrusted data.
+func (t *TriBandDense) UnmarshalBinary(data []byte) error {
+	if !t.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	header, err := unmarshalHeader(data)
+	if err != nil {
+		return err
+	}
+	err = header.symBand('T')
+	if err != nil {
+		return err
+	}
+	kind, err := header.triKind()
+	if err != nil {
+		return err
+	}
+	if len(data) != headerSize+int(header.symBandLen())*sizeFloat64 {
+		return errBadBuffer
+	}
+
+	t.reuseAsTriBand(header, kind)
+	unmarshalElems(data[headerSize:], t.visit)
+	return nil
+}
+
+// UnmarshalBinaryFrom decodes the binary form into the receiver and returns
+// the number of bytes read and an error if any.
+// It panics if the receiver is a non-zero TriBandDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+// See UnmarshalBinary for the list of sanity checks performed on the input.
+func (t *TriBandDense) UnmarshalBinaryFrom(r io.Reader) (int, error) {
+	if !t.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	var header storage
+	n, err := header.unmarshalBinaryFrom(r)
+	if err != nil {
+		return n, err
+	}
+	err = header.symBand('T')
+	if err != nil {
+		return n, err
+	}
+	kind, err := header.triKind()
+	if err != nil {
+		return n, err
+	}
+
+	t.reuseAsTriBand(header, kind)
+	nn, err := unmarshalElemsFrom(r, t.visit)
+	return n + nn, err
+}
+
+// reuseAsTriBand resizes a zero receiver to the triangular band matrix
+// described by the validated header h.
+func (t *TriBandDense) reuseAsTriBand(h storage, kind TriKind) {
+	n, k := int(h.Rows), int(h.KU)
+	uplo := blas.Lower
+	if kind == Upper {
+		uplo = blas.Upper
+	}
+	t.mat = blas64.TriangularBand{
+		Uplo:   uplo,
+		Diag:   blas.NonUnit,
+		N:      n,
+		K:      k,
+		Stride: k + 1,
+		Data:   use(t.mat.Data, n*(k+1)),
+	}
+}
+
+// MarshalBinary encodes the receiver into a binary form and returns the result.
+//
+// DiagDense is encoded in the same form as a SymBandDense with zero
+// bandwidth, so it is little-endian encoded as follows:
+//   0 -  3  Version = 1          (uint32)
+//   4       'S'                  (byte)
+//   5       'B'                  (byte)
+//   6       'U'                  (byte)
+//   7       0                    (byte)
+//   8 - 15  n                    (int64)
+//  16 - 23  n                    (int64)
+//  24 - 31  0                    (int64)
+//  32 - 39  0                    (int64)
+//  40 - ..  diagonal elements    (float64)
+//           [0,0] [1,1] ... [n-1,n-1]
+func (d DiagDense) MarshalBinary() ([]byte, error) {
+	return marshalElems(d.header(), int64(d.mat.N), d.visit)
+}
+
+// MarshalBinaryTo encodes the receiver into a binary form and writes it into w.
+// MarshalBinaryTo returns the number of bytes written into w and an error, if any.
+//
+// See MarshalBinary for the on-disk layout.
+func (d DiagDense) MarshalBinaryTo(w io.Writer) (int, error) {
+	return marshalElemsTo(w, d.header(), d.visit)
+}
+
+func (d *DiagDense) header() storage {
+	return storage{
+		Form: 'S', Packing: 'B', Uplo: 'U',
+		Rows: int64(d.mat.N), Cols: int64(d.mat.N),
+		Version: version,
+	}
+}
+
+// visit calls fn with each diagonal element of d.
+func (d *DiagDense) visit(fn func(v *float64)) {
+	for i := 0; i < d.mat.N; i++ {
+		fn(&d.mat.Data[i*d.mat.Inc])
+	}
+}
+
+// UnmarshalBinary decodes the binary form into the receiver.
+// It panics if the receiver is a non-zero DiagDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+//
+// Limited checks on the validity of the binary input are performed:
+//  - an error is returned if the number of rows and columns differ or are
+//  negative, or the encoded matrix is not diagonal,
+//  - an error is returned if the resulting DiagDense matrix is too
+//  big for the current architecture (e.g. a 16GB matrix written by a
+//  64b application and read back from a 32b application.)
+// UnmarshalBinary does not limit the size of the unmarshaled matrix, and so
+// it should not be used on untrusted data.
+func (d *DiagDense) UnmarshalBinary(data []byte) error {
+	if !d.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	header, err := unmarshalHeader(data)
+	if err != nil {
+		return err
+	}
+	err = header.symBand('S')
+	if err != nil {
+		return err
+	}
+	if header.Uplo != 'U' || header.KU != 0 {
+		return errWrongType
+	}
+	n := int(header.Rows)
+	if len(data) != headerSize+n*sizeFloat64 {
+		return errBadBuffer
+	}
+
+	d.reuseAs(n)
+	unmarshalElems(data[headerSize:], d.visit)
+	return nil
+}
+
+// UnmarshalBinaryFrom decodes the binary form into the receiver and returns
+// the number of bytes read and an error if any.
+// It panics if the receiver is a non-zero DiagDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+// See UnmarshalBinary for the list of sanity checks performed on the input.
+func (d *DiagDense) UnmarshalBinaryFrom(r io.Reader) (int, error) {
+	if !d.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	var header storage
+	n, err := header.unmarshalBinaryFrom(r)
+	if err != nil {
+		return n, err
+	}
+	err = header.symBand('S')
+	if err != nil {
+		return n, err
+	}
+	if header.Uplo != 'U' || header.KU != 0 {
+		return n, errWrongType
+	}
+
+	d.reuseAs(int(header.Rows))
+	nn, err := unmarshalElemsFrom(r, d.visit)
+	return n + nn, err
+}
+
+// MarshalBinary encodes the receiver into a binary form and returns the result.
+//
+// CDense is little-endian encoded as follows:
+//   0 -  3  Version = 1          (uint32)
+//   4       'C'                  (byte)
+//   5       'F'                  (byte)
+//   6       'A'                  (byte)
+//   7       0                    (byte)
+//   8 - 15  number of rows       (int64)
+//  16 - 23  number of columns    (int64)
+//  24 - 31  0                    (int64)
+//  32 - 39  0                    (int64)
+//  40 - ..  matrix data elements (pairs of float64 holding the real and
+//           imaginary parts)
+//           [0,0] [0,1] ... [0,ncols-1]
+//           [1,0] [1,1] ... [1,ncols-1]
+//           ...
+//           [nrows-1,0] ... [nrows-1,ncols-1]
+func (m CDense) MarshalBinary() ([]byte, error) {
+	return marshalElems(m.header(), 2*int64(m.mat.Rows)*int64(m.mat.Cols), m.visit)
+}
+
+// MarshalBinaryTo encodes the receiver into a binary form and writes it into w.
+// MarshalBinaryTo returns the number of bytes written into w and an error, if any.
+//
+// See MarshalBinary for the on-disk layout.
+func (m CDense) MarshalBinaryTo(w io.Writer) (int, error) {
+	return marshalElemsTo(w, m.header(), m.visit)
+}
+
+func (m *CDense) header() storage {
+	return storage{
+		Form: 'C', Packing: 'F', Uplo: 'A',
+		Rows: int64(m.mat.Rows), Cols: int64(m.mat.Cols),
+		Version: version,
+	}
+}
+
+// visit calls fn with the real and imaginary parts of each element of m in
+// row-major order.
+func (m *CDense) visit(fn func(v *float64)) {
+	for i := 0; i < m.mat.Rows; i++ {
+		for j := 0; j < m.mat.Cols; j++ {
+			v := &m.mat.Data[i*m.mat.Stride+j]
+			re, im := real(*v), imag(*v)
+			fn(&re)
+			fn(&im)
+			// Only store when decoding so that marshaling does not
+			// write to the receiver.
+			if math.Float64bits(re) != math.Float64bits(real(*v)) || math.Float64bits(im) != math.Float64bits(imag(*v)) {
+				*v = complex(re, im)
+			}
+		}
+	}
+}
+
+// UnmarshalBinary decodes the binary form into the receiver.
+// It panics if the receiver is a non-zero CDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+//
+// Limited checks on the validity of the binary input are performed:
+//  - an error is returned if the number of rows or columns is negative,
+//  - an error is returned if the resulting CDense matrix is too
+//  big for the current architecture (e.g. a 16GB matrix written by a
+//  64b application and read back from a 32b application.)
+// UnmarshalBinary does not limit the size of the unmarshaled matrix, and so
+// it should not be used on untrusted data.
+func (m *CDense) UnmarshalBinary(data []byte) error {
+	if !m.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	header, err := unmarshalHeader(data)
+	if err != nil {
+		return err
+	}
+	r, c, err := header.general('C')
+	if err != nil {
+		return err
+	}
+	if len(data) != headerSize+2*r*c*sizeFloat64 {
+		return errBadBuffer
+	}
+
+	m.reuseAs(r, c)
+	unmarshalElems(data[headerSize:], m.visit)
+	return nil
+}
+
+// UnmarshalBinaryFrom decodes the binary form into the receiver and returns
+// the number of bytes read and an error if any.
+// It panics if the receiver is a non-zero CDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+// See UnmarshalBinary for the list of sanity checks performed on the input.
+func (m *CDense) UnmarshalBinaryFrom(r io.Reader) (int, error) {
+	if !m.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	var header storage
+	n, err := header.unmarshalBinaryFrom(r)
+	if err != nil {
+		return n, err
+	}
+	rows, cols, err := header.general('C')
+	if err != nil {
+		return n, err
+	}
+
+	m.reuseAs(rows, cols)
+	nn, err := unmarshalElemsFrom(r, m.visit)
+	return n + nn, err
+}
+
+// MarshalBinary encodes the Cholesky factor U of the receiver into a binary
+// form and returns the result. The encoding is that of an upper triangular
+// TriDense holding U. See TriDense.MarshalBinary for the on-disk layout.
+//
+// MarshalBinary will panic if the receiver does not contain a factorization.
+func (c *Cholesky) MarshalBinary() ([]byte, error) {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	return c.chol.MarshalBinary()
+}
+
+// MarshalBinaryTo encodes the Cholesky factor U of the receiver into a binary
+// form and writes it into w. MarshalBinaryTo returns the number of bytes
+// written into w and an error, if any.
+//
+// See MarshalBinary for the on-disk layout.
+func (c *Cholesky) MarshalBinaryTo(w io.Writer) (int, error) {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	return c.chol.MarshalBinaryTo(w)
+}
+
+// UnmarshalBinary decodes the binary form of a Cholesky factor into the
+// receiver, replacing any factorization it holds. As with SetFromU, the
+// condition number of the factorized matrix is estimated from the decoded
+// factor and may differ from that of the marshaled receiver.
+//
+// See MarshalBinary for the on-disk layout and TriDense.UnmarshalBinary for
+// the list of sanity checks performed on the input. An error is returned if
+// the encoded matrix is not upper triangular.
+func (c *Cholesky) UnmarshalBinary(data []byte) error {
+	var u TriDense
+	err := u.UnmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+	return c.setFromDecoded(&u)
+}
+
+// UnmarshalBinaryFrom decodes the binary form of a Cholesky factor into the
+// receiver, replacing any factorization it holds, and returns the number of
+// bytes read and an error if any.
+//
+// See UnmarshalBinary for details.
+func (c *Cholesky) UnmarshalBinaryFrom(r io.Reader) (int, error) {
+	var u TriDense
+	n, err := u.UnmarshalBinaryFrom(r)
+	if err != nil {
+		return n, err
+	}
+	return n, c.setFromDecoded(&u)
+}
+
+func (c *Cholesky) setFromDecoded(u *TriDense) error {
+	if _, kind := u.Triangle(); kind != Upper {
+		return errWrongType
+	}
+	c.SetFromU(u)
+	return nil
+}
+
+// packedLen returns the number of elements in the triangle of an n×n
+// matrix.
+func packedLen(n int) int64 {
+	return int64(n) * int64(n+1) / 2
+}
+
+// visitLen returns the number of elements visited by visit.
+func visitLen(visit func(fn func(v *float64))) int64 {
+	var n int64
+	visit(func(*float64) { n++ })
+	return n
+}
+
+// uploByte returns the storage encoding of uplo.
+func uploByte(uplo blas.Uplo) byte {
+	if uplo == blas.Upper {
+		return 'U'
+	}
+	return 'L'
+}
+
+// marshalElems returns the binary encoding of a matrix with the given header
+// and size elements, which are visited in order by visit.
+func marshalElems(header storage, size int64, visit func(fn func(v *float64))) ([]byte, error) {
+	bufLen := int64(headerSize) + size*int64(sizeFloat64)
+	if bufLen <= 0 {
+		// bufLen is too big and has wrapped around.
+		return nil, errTooBig
+	}
+
+	buf := make([]byte, bufLen)
+	n, err := header.marshalBinaryTo(bytes.NewBuffer(buf[:0]))
+	if err != nil {
+		return buf[:n], err
+	}
+
+	p := headerSize
+	visit(func(v *float64) {
+		binary.LittleEndian.PutUint64(buf[p:p+sizeFloat64], math.Float64bits(*v))
+		p += sizeFloat64
+	})
+	return buf, nil
+}
+
+// marshalElemsTo writes the binary encoding of a matrix with the given
+// header and the elements visited in order by visit into w.
+func marshalElemsTo(w io.Writer, header storage, visit func(fn func(v *float64))) (int, error) {
+	n, err := header.marshalBinaryTo(w)
+	if err != nil {
+		return n, err
+	}
+
+	var b [8]byte
+	visit(func(v *float64) {
+		if err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(*v))
+		var nn int
+		nn, err = w.Write(b[:])
+		n += nn
+	})
+	return n, err
+}
+
+// unmarshalHeader decodes the header at the start of data.
+func unmarshalHeader(data []byte) (storage, error) {
+	if len(data) < headerSize {
+		return storage{}, errTooSmall
+	}
+	var header storage
+	err := header.unmarshalBinary(data[:headerSize])
+	return header, err
+}
+
+// unmarshalElems decodes the elements in data into the elements visited in
+// order by visit. data must hold exactly the visited elements.
+func unmarshalElems(data []byte, visit func(fn func(v *float64))) {
+	p := 0
+	visit(func(v *float64) {
+		*v = math.Float64frombits(binary.LittleEndian.Uint64(data[p : p+sizeFloat64]))
+		p += sizeFloat64
+	})
+}
+
+// unmarshalElemsFrom reads elements from r into the elements visited in
+// order by visit.
+func unmarshalElemsFrom(r io.Reader, visit func(fn func(v *float64))) (int, error) {
+	var (
+		n   int
+		err error
+		b   [8]byte
+	)
+	visit(func(v *float64) {
+		if err != nil {
+			return
+		}
+		var nn int
+		nn, err = readFull(r, b[:])
+		n += nn
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return
+		}
+		*v = math.Float64frombits(binary.LittleEndian.Uint64(b[:]))
+	})
+	return n, err
+}
+
+// general checks that s describes a full general matrix of the given form
+// and returns its dimensions.
+func (s storage) general(form byte) (r, c int, err error) {
+	if s.Form != form || s.Packing != 'F' || s.Uplo != 'A' || s.Unit || s.KU != 0 || s.KL != 0 {
+		return 0, 0, errWrongType
+	}
+	if s.Rows < 0 || s.Cols < 0 {
+		return 0, 0, errBadSize
+	}
+	size := s.Rows * s.Cols
+	if size == 0 {
+		return 0, 0, ErrZeroLength
+	}
+	if int(size) < 0 || size > maxLen/2 {
+		return 0, 0, errTooBig
+	}
+	return int(s.Rows), int(s.Cols), nil
+}
+
+// triangular checks that s describes a symmetric or triangular matrix of the
+// given form and packing and returns its order.
+func (s storage) triangular(form, packing byte) (n int, err error) {
+	if s.Form != form || s.Packing != packing || s.Unit || s.KU != 0 || s.KL != 0 {
+		return 0, errWrongType
+	}
+	if s.Rows != s.Cols {
+		return 0, ErrShape
+	}
+	if s.Rows < 0 {
+		return 0, errBadSize
+	}
+	if s.Rows == 0 {
+		return 0, ErrZeroLength
+	}
+	if s.Rows > maxLen/s.Rows {
+		return 0, errTooBig
+	}
+	return int(s.Rows), nil
+}
+
+// triKind returns the orientation of the triangular matrix described by s.
+func (s storage) triKind() (TriKind, error) {
+	switch s.Uplo {
+	case 'U':
+		return Upper, nil
+	case 'L':
+		return Lower, nil
+	default:
+		return false, errWrongType
+	}
+}
+
+// band checks that s describes a valid general band matrix.
+func (s storage) band() error {
+	if s.Rows < 0 || s.Cols < 0 || s.KL < 0 || s.KU < 0 {
+		return errBadSize
+	}
+	if s.Rows == 0 || s.Cols == 0 {
+		return ErrZeroLength
+	}
+	if s.KL >= s.Rows || s.KU >= s.Cols {
+		return errBadSize
+	}
+	bc := s.KL + s.KU + 1
+	if s.Rows > maxLen/bc {
+		return errTooBig
+	}
+	return nil
+}
+
+// bandLen returns the number of elements within the band of the general
+// band matrix described by the validated header s.
+func (s storage) bandLen() int64 {
+	// Count the elements along each diagonal. Diagonal d of the upper
+	// band has min(r, c-d) elements and diagonal d of the lower band has
+	// min(r-d, c) elements.
+	r, c := s.Rows, s.Cols
+	// The main diagonal is counted in both bands.
+	return diagsLen(r, c, s.KU) + diagsLen(c, r, s.KL) - int64(min(int(r), int(c)))
+}
+
+// diagsLen returns the sum of min(p, q-d) for 0 <= d <= k, where k < q.
+func diagsLen(p, q, k int64) int64 {
+	// The diagonals d <= m, with m = min(k, q-p), have p elements.
+	m := k
+	if q-p < m {
+		m = q - p
+	}
+	if m < -1 {
+		m = -1
+	}
+	n := (m + 1) * p
+	// The remaining diagonals have q-m-1 down to q-k elements.
+	cnt := k - m
+	return n + cnt*(q-k) + cnt*(cnt-1)/2
+}
+
+// symBandLen returns the number of elements within the upper or lower band
+// of the symmetric or triangular band matrix described by the validated
+// header s.
+func (s storage) symBandLen() int64 {
+	n, k := s.Rows, s.KU
+	return n*(k+1) - k*(k+1)/2
+}
+
+// symBand checks that s describes a valid symmetric or triangular band
+// matrix of the given form.
+func (s storage) symBand(form byte) error {
+	if s.Form != form || s.Packing != 'B' || s.Unit {
+		return errWrongType
+	}
+	if s.Rows != s.Cols || s.KU != s.KL {
+		return ErrShape
+	}
+	if s.Rows < 0 || s.KU < 0 {
+		return errBadSize
+	}
+	if s.Rows == 0 {
+		return ErrZeroLength
+	}
+	if s.KU >= s.Rows {
+		return errBadSize
+	}
+	if s.Rows > maxLen/(s.KU+1) {
+		return errTooBig
+	}
+	return nil
+}
+
 // storage is the internal representation of the storage format of a
 // serialised matrix.
 type storage struct {
 	Version uint32 // Keep this first.
-	Form    byte   // [GST]
+	Form    byte   // [GSTC]
 	Packing byte   // [BPF]
 	Uplo    byte   // [AUL]
 	Unit    bool
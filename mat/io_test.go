@@ -20,6 +20,23 @@ var (
 	_ encoding.BinaryUnmarshaler = (*Dense)(nil)
 	_ encoding.BinaryMarshaler   = (*VecDense)(nil)
 	_ encoding.BinaryUnmarshaler = (*VecDense)(nil)
+
+	_ encoding.BinaryMarshaler   = (*SymDense)(nil)
+	_ encoding.BinaryUnmarshaler = (*SymDense)(nil)
+	_ encoding.BinaryMarshaler   = (*TriDense)(nil)
+	_ encoding.BinaryUnmarshaler = (*TriDense)(nil)
+	_ encoding.BinaryMarshaler   = (*BandDense)(nil)
+	_ encoding.BinaryUnmarshaler = (*BandDense)(nil)
+	_ encoding.BinaryMarshaler   = (*SymBandDense)(nil)
+	_ encoding.BinaryUnmarshaler = (*SymBandDense)(nil)
+	_ encoding.BinaryMarshaler   = (*TriBandDense)(nil)
+	_ encoding.BinaryUnmarshaler = (*TriBandDense)(nil)
+	_ encoding.BinaryMarshaler   = (*DiagDense)(nil)
+	_ encoding.BinaryUnmarshaler = (*DiagDense)(nil)
+	_ encoding.BinaryMarshaler   = (*CDense)(nil)
+	_ encoding.BinaryUnmarshaler = (*CDense)(nil)
+	_ encoding.BinaryMarshaler   = (*Cholesky)(nil)
+	_ encoding.BinaryUnmarshaler = (*Cholesky)(nil)
 )
 
 var denseData = []struct {
@@ -567,6 +584,396 @@ func TestVecDenseIORoundTrip(t *testing.T) {
 	}
 }
 
+// binaryMatrix is a matrix type that can be marshaled.
+type binaryMatrix interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	MarshalBinaryTo(io.Writer) (int, error)
+	UnmarshalBinaryFrom(io.Reader) (int, error)
+}
+
+var structuredData = []struct {
+	name string
+	want binaryMatrix
+	new  func() binaryMatrix
+	// elems is the number of float64 values encoded.
+	elems int
+	eq    func(got, want binaryMatrix) bool
+}{
+	{
+		name:  "SymDense",
+		want:  NewSymDense(3, []float64{1, 2, 3, 2, 4, 5, 3, 5, 6}),
+		new:   func() binaryMatrix { return &SymDense{} },
+		elems: 6,
+	},
+	{
+		name:  "TriDense upper",
+		want:  NewTriDense(3, Upper, []float64{1, 2, 3, 0, 4, 5, 0, 0, 6}),
+		new:   func() binaryMatrix { return &TriDense{} },
+		elems: 6,
+	},
+	{
+		name:  "TriDense lower",
+		want:  NewTriDense(3, Lower, []float64{1, 0, 0, 2, 3, 0, 4, 5, 6}),
+		new:   func() binaryMatrix { return &TriDense{} },
+		elems: 6,
+	},
+	{
+		name: "BandDense tall",
+		want: NewBandDense(5, 3, 2, 1, []float64{
+			-1, -1, 1, 2,
+			-1, 3, 4, 5,
+			6, 7, 8, -1,
+			9, 10, -1, -1,
+			11, -1, -1, -1,
+		}),
+		new:   func() binaryMatrix { return &BandDense{} },
+		elems: 11,
+	},
+	{
+		name: "BandDense wide",
+		want: NewBandDense(2, 5, 0, 2, []float64{
+			1, 2, 3,
+			4, 5, 6,
+		}),
+		new:   func() binaryMatrix { return &BandDense{} },
+		elems: 6,
+	},
+	{
+		name: "SymBandDense",
+		want: NewSymBandDense(4, 1, []float64{
+			1, 2,
+			3, 4,
+			5, 6,
+			7, -1,
+		}),
+		new:   func() binaryMatrix { return &SymBandDense{} },
+		elems: 7,
+	},
+	{
+		name: "TriBandDense upper",
+		want: NewTriBandDense(4, 2, Upper, []float64{
+			1, 2, 3,
+			4, 5, 6,
+			7, 8, -1,
+			9, -1, -1,
+		}),
+		new:   func() binaryMatrix { return &TriBandDense{} },
+		elems: 9,
+	},
+	{
+		name: "TriBandDense lower",
+		want: NewTriBandDense(4, 2, Lower, []float64{
+			-1, -1, 1,
+			-1, 2, 3,
+			4, 5, 6,
+			7, 8, 9,
+		}),
+		new:   func() binaryMatrix { return &TriBandDense{} },
+		elems: 9,
+	},
+	{
+		name:  "DiagDense",
+		want:  NewDiagDense(3, []float64{1, math.NaN(), math.Inf(-1)}),
+		new:   func() binaryMatrix { return &DiagDense{} },
+		elems: 3,
+	},
+	{
+		name:  "CDense",
+		want:  NewCDense(2, 3, []complex128{1, 2i, 3 + 4i, -5, complex(math.Inf(1), 0), complex(6, math.NaN())}),
+		new:   func() binaryMatrix { return &CDense{} },
+		elems: 12,
+		eq: func(got, want binaryMatrix) bool {
+			g := got.(*CDense)
+			w := want.(*CDense)
+			r, c := w.Dims()
+			if gr, gc := g.Dims(); gr != r || gc != c {
+				return false
+			}
+			for i := 0; i < r; i++ {
+				for j := 0; j < c; j++ {
+					gv, wv := g.At(i, j), w.At(i, j)
+					if !sameFloat(real(gv), real(wv)) || !sameFloat(imag(gv), imag(wv)) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+	},
+}
+
+func TestStructuredIORoundTrip(t *testing.T) {
+	for _, test := range structuredData {
+		eq := test.eq
+		if eq == nil {
+			eq = func(got, want binaryMatrix) bool {
+				return sameMatrix(got.(Matrix), want.(Matrix))
+			}
+		}
+
+		buf, err := test.want.MarshalBinary()
+		if err != nil {
+			t.Errorf("%s: error encoding: %v", test.name, err)
+			continue
+		}
+		if len(buf) != headerSize+test.elems*sizeFloat64 {
+			t.Errorf("%s: unexpected encoded length: got:%d want:%d", test.name, len(buf), headerSize+test.elems*sizeFloat64)
+		}
+
+		got := test.new()
+		err = got.UnmarshalBinary(buf)
+		if err != nil {
+			t.Errorf("%s: error decoding: %v", test.name, err)
+			continue
+		}
+		if !eq(got, test.want) {
+			t.Errorf("%s: r/w test failed\n got=%v\nwant=%v", test.name, got, test.want)
+		}
+
+		wbuf := new(bytes.Buffer)
+		n, err := test.want.MarshalBinaryTo(wbuf)
+		if err != nil {
+			t.Errorf("%s: error encoding: %v", test.name, err)
+		}
+		if n != len(buf) {
+			t.Errorf("%s: unexpected number of bytes written: got:%d want:%d", test.name, n, len(buf))
+		}
+		if !bytes.Equal(buf, wbuf.Bytes()) {
+			t.Errorf("%s: encoding via MarshalBinary and MarshalBinaryTo differ:\nwith-stream: %q\n  no-stream: %q",
+				test.name, wbuf.Bytes(), buf,
+			)
+		}
+
+		wgot := test.new()
+		n, err = wgot.UnmarshalBinaryFrom(wbuf)
+		if err != nil {
+			t.Errorf("%s: error decoding: %v", test.name, err)
+		}
+		if n != len(buf) {
+			t.Errorf("%s: unexpected number of bytes read: got:%d want:%d", test.name, n, len(buf))
+		}
+		if !eq(wgot, test.want) {
+			t.Errorf("%s: r/w test failed\n got=%v\nwant=%v", test.name, wgot, test.want)
+		}
+
+		// Truncated input must be rejected.
+		err = test.new().UnmarshalBinary(buf[:len(buf)-1])
+		if err != errBadBuffer {
+			t.Errorf("%s: unexpected error for truncated buffer: got:%v want:%v", test.name, err, errBadBuffer)
+		}
+		_, err = test.new().UnmarshalBinaryFrom(bytes.NewReader(buf[:len(buf)-1]))
+		if err != io.ErrUnexpectedEOF {
+			t.Errorf("%s: unexpected error for truncated stream: got:%v want:%v", test.name, err, io.ErrUnexpectedEOF)
+		}
+
+		// Decoding into a non-zero receiver must panic.
+		if panicked, _ := panics(func() { _ = got.UnmarshalBinary(buf) }); !panicked {
+			t.Errorf("%s: expected panic for unmarshal into non-zero receiver", test.name)
+		}
+	}
+}
+
+// sameMatrix returns whether a and b have the same shape and elements,
+// treating NaN elements as equal.
+func sameMatrix(a, b Matrix) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			if !sameFloat(a.At(i, j), b.At(i, j)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sameFloat returns whether a and b are equal or are both NaN.
+func sameFloat(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+func TestStructuredIOWrongType(t *testing.T) {
+	sym, err := NewSymDense(2, []float64{1, 2, 2, 3}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	band, err := NewBandDense(2, 2, 1, 0, []float64{0, 1, 2, 3}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	symBand, err := NewSymBandDense(2, 1, []float64{1, 2, 3, 0}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lower, err := NewTriDense(2, Lower, []float64{1, 0, 2, 3}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dense, err := NewDense(2, 2, []float64{1, 2, 3, 4}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, test := range []struct {
+		name string
+		dst  binaryMatrix
+		data []byte
+	}{
+		{name: "Dense into SymDense", dst: &SymDense{}, data: dense},
+		{name: "SymDense into TriDense", dst: &TriDense{}, data: sym},
+		{name: "SymDense into Dense", dst: &Dense{}, data: sym},
+		{name: "BandDense into SymBandDense", dst: &SymBandDense{}, data: band},
+		{name: "SymBandDense into BandDense", dst: &BandDense{}, data: symBand},
+		{name: "SymBandDense into TriBandDense", dst: &TriBandDense{}, data: symBand},
+		{name: "SymBandDense into DiagDense", dst: &DiagDense{}, data: symBand},
+		{name: "Dense into CDense", dst: &CDense{}, data: dense},
+		{name: "lower TriDense into Cholesky", dst: &Cholesky{}, data: lower},
+	} {
+		err := test.dst.UnmarshalBinary(test.data)
+		if err != errWrongType {
+			t.Errorf("%s: unexpected error: got:%v want:%v", test.name, err, errWrongType)
+		}
+	}
+}
+
+func TestBandIOLen(t *testing.T) {
+	for r := 1; r <= 6; r++ {
+		for c := 1; c <= 6; c++ {
+			for kl := 0; kl < r; kl++ {
+				for ku := 0; ku < c; ku++ {
+					b := NewBandDense(r, c, kl, ku, nil)
+					got := b.header().bandLen()
+					want := visitLen(b.visit)
+					if got != want {
+						t.Errorf("unexpected length for r=%d c=%d kl=%d ku=%d: got:%d want:%d", r, c, kl, ku, got, want)
+					}
+				}
+			}
+		}
+	}
+	for n := 1; n <= 6; n++ {
+		for k := 0; k < n; k++ {
+			s := NewSymBandDense(n, k, nil)
+			got := s.header().symBandLen()
+			want := visitLen(s.visit)
+			if got != want {
+				t.Errorf("unexpected symmetric length for n=%d k=%d: got:%d want:%d", n, k, got, want)
+			}
+			for _, kind := range []TriKind{Upper, Lower} {
+				tb := NewTriBandDense(n, k, kind, nil)
+				got := tb.header().symBandLen()
+				want := visitLen(tb.visit)
+				if got != want {
+					t.Errorf("unexpected triangular length for n=%d k=%d kind=%v: got:%d want:%d", n, k, kind, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestBandIOShortData(t *testing.T) {
+	// The headers describe matrices far too large to allocate, so the
+	// short payload must be rejected before the receiver is resized.
+	const huge = 1 << 40
+	for _, test := range []struct {
+		name   string
+		header storage
+		dst    binaryMatrix
+	}{
+		{
+			name:   "BandDense",
+			header: storage{Form: 'G', Packing: 'B', Uplo: 'A', Rows: huge, Cols: huge, KU: 1, KL: 1},
+			dst:    &BandDense{},
+		},
+		{
+			name:   "SymBandDense",
+			header: storage{Form: 'S', Packing: 'B', Uplo: 'U', Rows: huge, Cols: huge, KU: 1, KL: 1},
+			dst:    &SymBandDense{},
+		},
+		{
+			name:   "TriBandDense",
+			header: storage{Form: 'T', Packing: 'B', Uplo: 'L', Rows: huge, Cols: huge, KU: 1, KL: 1},
+			dst:    &TriBandDense{},
+		},
+	} {
+		test.header.Version = version
+		var buf bytes.Buffer
+		_, err := test.header.marshalBinaryTo(&buf)
+		if err != nil {
+			t.Fatalf("%s: unexpected error encoding header: %v", test.name, err)
+		}
+		buf.Write(make([]byte, 4*sizeFloat64))
+		err = test.dst.UnmarshalBinary(buf.Bytes())
+		if err != errBadBuffer {
+			t.Errorf("%s: unexpected error: got:%v want:%v", test.name, err, errBadBuffer)
+		}
+		if !test.dst.(interface{ IsZero() bool }).IsZero() {
+			t.Errorf("%s: receiver resized for short data", test.name)
+		}
+	}
+}
+
+func TestCholeskyIORoundTrip(t *testing.T) {
+	a := NewSymDense(3, []float64{
+		4, 1, 1,
+		1, 2, 3,
+		1, 3, 6,
+	})
+	var want Cholesky
+	if ok := want.Factorize(a); !ok {
+		t.Fatal("unexpected Cholesky factorization failure: not positive definite")
+	}
+
+	buf, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error encoding: %v", err)
+	}
+	wbuf := new(bytes.Buffer)
+	_, err = want.MarshalBinaryTo(wbuf)
+	if err != nil {
+		t.Fatalf("unexpected error encoding: %v", err)
+	}
+	if !bytes.Equal(buf, wbuf.Bytes()) {
+		t.Errorf("encoding via MarshalBinary and MarshalBinaryTo differ")
+	}
+
+	var got, wgot Cholesky
+	err = got.UnmarshalBinary(buf)
+	if err != nil {
+		t.Fatalf("unexpected error decoding: %v", err)
+	}
+	_, err = wgot.UnmarshalBinaryFrom(wbuf)
+	if err != nil {
+		t.Fatalf("unexpected error decoding: %v", err)
+	}
+
+	var wantSym SymDense
+	want.ToSym(&wantSym)
+	// The condition number is re-estimated from the factor on decoding.
+	var u TriDense
+	want.UTo(&u)
+	var fromU Cholesky
+	fromU.SetFromU(&u)
+	for _, c := range []*Cholesky{&got, &wgot} {
+		var sym SymDense
+		c.ToSym(&sym)
+		if !EqualApprox(&sym, &wantSym, 1e-14) {
+			t.Errorf("unexpected decoded factorization:\ngot: %v\nwant:%v", Formatted(&sym), Formatted(&wantSym))
+		}
+		if math.Abs(c.Det()-want.Det()) > 1e-12 {
+			t.Errorf("unexpected determinant: got:%v want:%v", c.Det(), want.Det())
+		}
+		if c.Cond() != fromU.Cond() {
+			t.Errorf("unexpected condition number: got:%v want:%v", c.Cond(), fromU.Cond())
+		}
+	}
+}
+
 func BenchmarkMarshalDense10(b *testing.B)    { marshalBinaryBenchDense(b, 10) }
 func BenchmarkMarshalDense100(b *testing.B)   { marshalBinaryBenchDense(b, 100) }
 func BenchmarkMarshalDense1000(b *testing.B)  { marshalBinaryBenchDense(b, 1000) }
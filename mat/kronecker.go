@@ -0,0 +1,511 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badKronEigen = "mat: invalid Kronecker eigendecomposition"
+
+var (
+	kron *Kronecker
+	_    Matrix     = kron
+	_    MulVecToer = kron
+
+	symKron *SymKronecker
+	_       Matrix     = symKron
+	_       Symmetric  = symKron
+	_       MulVecToer = symKron
+)
+
+// Kronecker represents the Kronecker product of two matrices
+//  A ⊗ B
+// without forming the product explicitly. If A is m×n and B is p×q, the
+// product is an mp×nq matrix with the block structure
+//  [ a_00*B     a_01*B     ... a_0(n-1)*B     ]
+//  [ a_10*B     a_11*B     ... a_1(n-1)*B     ]
+//  [ ...                                      ]
+//  [ a_(m-1)0*B a_(m-1)1*B ... a_(m-1)(n-1)*B ].
+//
+// The factors are retained by the Kronecker and must not be modified while
+// the Kronecker is in use.
+type Kronecker struct {
+	a, b Matrix
+}
+
+// NewKronecker returns the Kronecker product of a and b.
+func NewKronecker(a, b Matrix) *Kronecker {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar == 0 || ac == 0 || br == 0 || bc == 0 {
+		panic(ErrZeroLength)
+	}
+	return &Kronecker{a: a, b: b}
+}
+
+// Factors returns the factors A and B of the Kronecker product A ⊗ B.
+func (k *Kronecker) Factors() (a, b Matrix) {
+	return k.a, k.b
+}
+
+// Dims returns the dimensions of the Kronecker product.
+func (k *Kronecker) Dims() (r, c int) {
+	return kronDims(k.a, k.b)
+}
+
+// At returns the element at row i, column j of the Kronecker product.
+func (k *Kronecker) At(i, j int) float64 {
+	return kronAt(k.a, k.b, i, j)
+}
+
+// T performs an implicit transpose by returning the receiver inside a
+// Transpose.
+func (k *Kronecker) T() Matrix {
+	return Transpose{k}
+}
+
+// MulVecTo computes (A ⊗ B)*x or (A ⊗ B)^T*x storing the result into dst.
+// The product is computed using the identity
+//  (A ⊗ B) vec(X) = vec(A * X * B^T),
+// where vec stacks the rows of a matrix, without forming A ⊗ B.
+func (k *Kronecker) MulVecTo(dst *VecDense, trans bool, x Vector) {
+	kronMulVec(dst, k.a, k.b, trans, x)
+}
+
+// SymKronecker represents the Kronecker product of two symmetric matrices
+//  A ⊗ B,
+// which is itself symmetric, without forming the product explicitly.
+//
+// The factors are retained by the SymKronecker and must not be modified while
+// the SymKronecker is in use.
+type SymKronecker struct {
+	a, b Symmetric
+}
+
+// NewSymKronecker returns the Kronecker product of the symmetric matrices
+// a and b.
+func NewSymKronecker(a, b Symmetric) *SymKronecker {
+	if a.Symmetric() == 0 || b.Symmetric() == 0 {
+		panic(ErrZeroLength)
+	}
+	return &SymKronecker{a: a, b: b}
+}
+
+// Factors returns the factors A and B of the Kronecker product A ⊗ B.
+func (k *SymKronecker) Factors() (a, b Symmetric) {
+	return k.a, k.b
+}
+
+// Dims returns the dimensions of the Kronecker product.
+func (k *SymKronecker) Dims() (r, c int) {
+	return kronDims(k.a, k.b)
+}
+
+// Symmetric implements the Symmetric interface and returns the number of rows
+// in the matrix (this is also the number of columns).
+func (k *SymKronecker) Symmetric() int {
+	return k.a.Symmetric() * k.b.Symmetric()
+}
+
+// At returns the element at row i, column j of the Kronecker product.
+func (k *SymKronecker) At(i, j int) float64 {
+	return kronAt(k.a, k.b, i, j)
+}
+
+// T returns the receiver, the transpose of a symmetric matrix.
+func (k *SymKronecker) T() Matrix {
+	return k
+}
+
+// MulVecTo computes (A ⊗ B)*x storing the result into dst. Since A ⊗ B is
+// symmetric, trans is ignored.
+func (k *SymKronecker) MulVecTo(dst *VecDense, _ bool, x Vector) {
+	kronMulVec(dst, k.a, k.b, false, x)
+}
+
+func kronDims(a, b Matrix) (r, c int) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	return ar * br, ac * bc
+}
+
+func kronAt(a, b Matrix, i, j int) float64 {
+	r, c := kronDims(a, b)
+	if uint(i) >= uint(r) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(c) {
+		panic(ErrColAccess)
+	}
+	br, bc := b.Dims()
+	return a.At(i/br, j/bc) * b.At(i%br, j%bc)
+}
+
+// kronMulVec computes (A ⊗ B)*x or (A ⊗ B)^T*x storing the result into dst.
+func kronMulVec(dst *VecDense, a, b Matrix, trans bool, x Vector) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	// (A ⊗ B)^T = A^T ⊗ B^T.
+	if trans {
+		a = a.T()
+		b = b.T()
+		ar, ac = ac, ar
+		br, bc = bc, br
+	}
+	if x.Len() != ac*bc {
+		panic(ErrShape)
+	}
+
+	xm := getWorkspace(ac, bc, false)
+	defer putWorkspace(xm)
+	vecToDense(xm, x)
+
+	tmp := getWorkspace(ar, bc, false)
+	defer putWorkspace(tmp)
+	tmp.Mul(a, xm)
+	ym := getWorkspace(ar, br, false)
+	defer putWorkspace(ym)
+	ym.Mul(tmp, b.T())
+
+	denseToVec(dst, ym)
+}
+
+// vecToDense copies the elements of x into the rows of the r×c matrix dst.
+func vecToDense(dst *Dense, x Vector) {
+	r, c := dst.Dims()
+	for i := 0; i < r; i++ {
+		row := dst.mat.Data[i*dst.mat.Stride : i*dst.mat.Stride+c]
+		for j := range row {
+			row[j] = x.AtVec(i*c + j)
+		}
+	}
+}
+
+// denseToVec stores the rows of m consecutively into dst.
+func denseToVec(dst *VecDense, m *Dense) {
+	r, c := m.Dims()
+	dst.reuseAs(r * c)
+	for i := 0; i < r; i++ {
+		for j, v := range m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c] {
+			dst.setVec(i*c+j, v)
+		}
+	}
+}
+
+// KroneckerCholesky is a symmetric positive definite Kronecker product
+//  A ⊗ B
+// represented by the Cholesky decompositions of its factors. If A = U_A^T*U_A
+// and B = U_B^T*U_B, then
+//  A ⊗ B = (U_A ⊗ U_B)^T * (U_A ⊗ U_B).
+//
+// KroneckerCholesky methods may only be called on a value that has been
+// successfully initialized by a call to Factorize that has returned true.
+type KroneckerCholesky struct {
+	a, b Cholesky
+}
+
+// Factorize calculates the Cholesky decompositions of the factors of the
+// Kronecker product k and returns whether both factors are positive definite.
+// If Factorize returns false, the factorization must not be used.
+func (c *KroneckerCholesky) Factorize(k *SymKronecker) (ok bool) {
+	if !c.a.Factorize(k.a) {
+		c.Reset()
+		return false
+	}
+	if !c.b.Factorize(k.b) {
+		c.Reset()
+		return false
+	}
+	return true
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of
+// a dimensionally restricted operation.
+func (c *KroneckerCholesky) Reset() {
+	c.a.Reset()
+	c.b.Reset()
+}
+
+func (c *KroneckerCholesky) valid() bool {
+	return c.a.valid() && c.b.valid()
+}
+
+// Factors returns the Cholesky decompositions of the factors A and B of the
+// Kronecker product A ⊗ B. The returned values must not be modified.
+func (c *KroneckerCholesky) Factors() (a, b *Cholesky) {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	return &c.a, &c.b
+}
+
+// Symmetric returns the number of rows in the factorized matrix (this is also
+// the number of columns).
+func (c *KroneckerCholesky) Symmetric() int {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	return c.a.Symmetric() * c.b.Symmetric()
+}
+
+// Cond returns the condition number of the factorized matrix. The condition
+// number of a Kronecker product is the product of the condition numbers of
+// its factors.
+func (c *KroneckerCholesky) Cond() float64 {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	return c.a.cond * c.b.cond
+}
+
+// Det returns the determinant of the matrix that has been factorized.
+func (c *KroneckerCholesky) Det() float64 {
+	return math.Exp(c.LogDet())
+}
+
+// LogDet returns the log of the determinant of the matrix that has been
+// factorized. For an m×m matrix A and an n×n matrix B
+//  log(det(A ⊗ B)) = n*log(det(A)) + m*log(det(B)).
+func (c *KroneckerCholesky) LogDet() float64 {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	m := c.a.Symmetric()
+	n := c.b.Symmetric()
+	return float64(n)*c.a.LogDet() + float64(m)*c.b.LogDet()
+}
+
+// SolveVecTo finds the vector x that solves (A ⊗ B) * x = b where A ⊗ B is
+// represented by the Cholesky decompositions of its factors. The result is
+// stored in-place into dst.
+func (c *KroneckerCholesky) SolveVecTo(dst *VecDense, b Vector) error {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	m := c.a.Symmetric()
+	n := c.b.Symmetric()
+	if br, bc := b.Dims(); br != m*n || bc != 1 {
+		panic(ErrShape)
+	}
+
+	// Solve A * X * B = Y where b = vec(Y).
+	y := getWorkspace(m, n, false)
+	defer putWorkspace(y)
+	vecToDense(y, b)
+	lapack64.Potrs(c.a.chol.mat, y.mat)
+	blas64.Trsm(blas.Right, blas.NoTrans, 1, c.b.chol.mat, y.mat)
+	blas64.Trsm(blas.Right, blas.Trans, 1, c.b.chol.mat, y.mat)
+	denseToVec(dst, y)
+
+	if cond := c.Cond(); cond > ConditionTolerance {
+		return Condition(cond)
+	}
+	return nil
+}
+
+// SolveTo finds the matrix X that solves (A ⊗ B) * X = B where A ⊗ B is
+// represented by the Cholesky decompositions of its factors. The result is
+// stored in-place into dst.
+func (c *KroneckerCholesky) SolveTo(dst *Dense, b Matrix) error {
+	if !c.valid() {
+		panic(badCholesky)
+	}
+	n := c.Symmetric()
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+
+	dst.reuseAs(br, bc)
+	if b != dst {
+		dst.Copy(b)
+	}
+	var err error
+	col := getWorkspaceVec(n, false)
+	defer putWorkspaceVec(col)
+	for j := 0; j < bc; j++ {
+		col.CopyVec(dst.ColView(j))
+		err = c.SolveVecTo(col, col)
+		dst.SetCol(j, col.mat.Data)
+	}
+	return err
+}
+
+// KroneckerEigenSym is the eigendecomposition of a symmetric Kronecker product
+//  A ⊗ B
+// represented by the eigendecompositions of its factors. If A = P*D*P^T and
+// B = Q*E*Q^T, then
+//  A ⊗ B = (P ⊗ Q) * (D ⊗ E) * (P ⊗ Q)^T.
+type KroneckerEigenSym struct {
+	a, b EigenSym
+}
+
+// Factorize computes the eigenvalue decompositions of the factors of the
+// symmetric Kronecker product k. If the vectors input argument is false, the
+// eigenvectors are not computed.
+//
+// Factorize returns whether the decomposition succeeded. If the decomposition
+// failed, methods that require a successful factorization will panic.
+func (e *KroneckerEigenSym) Factorize(k *SymKronecker, vectors bool) (ok bool) {
+	if !e.a.Factorize(k.a, vectors) {
+		e.b = EigenSym{}
+		return false
+	}
+	if !e.b.Factorize(k.b, vectors) {
+		e.a = EigenSym{}
+		return false
+	}
+	return true
+}
+
+func (e *KroneckerEigenSym) succFact() bool {
+	return e.a.succFact() && e.b.succFact()
+}
+
+// Factors returns the eigendecompositions of the factors A and B of the
+// Kronecker product A ⊗ B. The returned values must not be modified.
+func (e *KroneckerEigenSym) Factors() (a, b *EigenSym) {
+	if !e.succFact() {
+		panic(badKronEigen)
+	}
+	return &e.a, &e.b
+}
+
+// Values extracts the eigenvalues of the factorized matrix. The eigenvalue
+// at index i*n+j is the product of the i-th eigenvalue of A and the j-th
+// eigenvalue of B, where B is n×n, so the values are not in general sorted.
+//
+// If dst is non-nil, the values are stored in-place into dst. In this case
+// dst must have length m*n, otherwise Values will panic. If dst is nil, then
+// a new slice will be allocated of the proper length and filled with the
+// eigenvalues.
+//
+// Values panics if the eigendecomposition was not successful.
+func (e *KroneckerEigenSym) Values(dst []float64) []float64 {
+	if !e.succFact() {
+		panic(badKronEigen)
+	}
+	m := len(e.a.values)
+	n := len(e.b.values)
+	if dst == nil {
+		dst = make([]float64, m*n)
+	}
+	if len(dst) != m*n {
+		panic(ErrSliceLengthMismatch)
+	}
+	for i, va := range e.a.values {
+		for j, vb := range e.b.values {
+			dst[i*n+j] = va * vb
+		}
+	}
+	return dst
+}
+
+// VectorsTo returns the eigenvectors of the decomposition, the Kronecker
+// product of the eigenvectors of the factors, with the column order matching
+// the order of the values returned by Values. VectorsTo will panic if the
+// eigenvectors were not computed during the factorization, or if the
+// factorization was not successful.
+//
+// If dst is not nil, the eigenvectors are stored in-place into dst, and dst
+// must have size mn×mn and panics otherwise. If dst is nil, a new matrix
+// is allocated and returned.
+func (e *KroneckerEigenSym) VectorsTo(dst *Dense) *Dense {
+	if !e.succFact() {
+		panic(badKronEigen)
+	}
+	if !e.a.vectorsComputed || !e.b.vectorsComputed {
+		panic(badNoVect)
+	}
+	if dst == nil {
+		dst = &Dense{}
+	}
+	dst.Kronecker(e.a.vectors, e.b.vectors)
+	return dst
+}
+
+// LogDetShifted returns the log of the absolute value and the sign of the
+// determinant of the matrix
+//  A ⊗ B + sigma*I.
+func (e *KroneckerEigenSym) LogDetShifted(sigma float64) (det float64, sign float64) {
+	if !e.succFact() {
+		panic(badKronEigen)
+	}
+	sign = 1
+	for _, va := range e.a.values {
+		for _, vb := range e.b.values {
+			d := va*vb + sigma
+			if d < 0 {
+				sign *= -1
+			}
+			det += math.Log(math.Abs(d))
+		}
+	}
+	return det, sign
+}
+
+// SolveShiftedVecTo finds the vector x that solves
+//  (A ⊗ B + sigma*I) * x = b
+// where A ⊗ B is represented by the eigendecompositions of its factors. The
+// result is stored in-place into dst. SolveShiftedVecTo will panic if the
+// eigenvectors were not computed during the factorization.
+//
+// If the shifted matrix is exactly singular, SolveShiftedVecTo returns
+// ErrSingular and the contents of dst are undefined. If it is near singular,
+// a Condition error is returned.
+func (e *KroneckerEigenSym) SolveShiftedVecTo(dst *VecDense, sigma float64, b Vector) error {
+	if !e.succFact() {
+		panic(badKronEigen)
+	}
+	if !e.a.vectorsComputed || !e.b.vectorsComputed {
+		panic(badNoVect)
+	}
+	m := len(e.a.values)
+	n := len(e.b.values)
+	if br, bc := b.Dims(); br != m*n || bc != 1 {
+		panic(ErrShape)
+	}
+
+	y := getWorkspace(m, n, false)
+	defer putWorkspace(y)
+	vecToDense(y, b)
+	tmp := getWorkspace(m, n, false)
+	defer putWorkspace(tmp)
+
+	// Transform into the eigenbasis, Z = P^T * Y * Q.
+	tmp.Mul(e.a.vectors.T(), y)
+	y.Mul(tmp, e.b.vectors)
+
+	// Scale by the inverse of the shifted eigenvalues.
+	dmin := math.Inf(1)
+	dmax := 0.0
+	for i, va := range e.a.values {
+		row := y.mat.Data[i*y.mat.Stride : i*y.mat.Stride+n]
+		for j, vb := range e.b.values {
+			d := va*vb + sigma
+			if d == 0 {
+				return ErrSingular
+			}
+			dmin = math.Min(dmin, math.Abs(d))
+			dmax = math.Max(dmax, math.Abs(d))
+			row[j] /= d
+		}
+	}
+
+	// Transform back, X = P * Z * Q^T.
+	tmp.Mul(e.a.vectors, y)
+	y.Mul(tmp, e.b.vectors.T())
+	denseToVec(dst, y)
+
+	if cond := dmax / dmin; cond > ConditionTolerance {
+		return Condition(cond)
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// randNormDense returns a random r×c matrix with normally distributed elements.
+func randNormDense(r, c int, rnd *rand.Rand) *Dense {
+	m := NewDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			m.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	return m
+}
+
+// randNormVec returns a random vector of length n with normally distributed
+// elements.
+func randNormVec(n int, rnd *rand.Rand) *VecDense {
+	v := NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		v.SetVec(i, rnd.NormFloat64())
+	}
+	return v
+}
+
+// randSPD returns a random n×n symmetric positive definite matrix.
+func randSPD(n int, rnd *rand.Rand) *SymDense {
+	a := randNormDense(n, n, rnd)
+	var s SymDense
+	s.SymOuterK(1, a)
+	for i := 0; i < n; i++ {
+		s.SetSym(i, i, s.At(i, i)+float64(n))
+	}
+	return &s
+}
+
+func TestDenseKronecker(t *testing.T) {
+	for _, test := range []struct {
+		a, b *Dense
+		want *Dense
+	}{
+		{
+			a:    NewDense(1, 1, []float64{2}),
+			b:    NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+			want: NewDense(2, 3, []float64{2, 4, 6, 8, 10, 12}),
+		},
+		{
+			a: NewDense(2, 2, []float64{1, 2, 3, 4}),
+			b: NewDense(2, 2, []float64{0, 5, 6, 7}),
+			want: NewDense(4, 4, []float64{
+				0, 5, 0, 10,
+				6, 7, 12, 14,
+				0, 15, 0, 20,
+				18, 21, 24, 28,
+			}),
+		},
+		{
+			a: NewDense(1, 2, []float64{1, -1}),
+			b: NewDense(2, 1, []float64{2, 3}),
+			want: NewDense(2, 2, []float64{
+				2, -2,
+				3, -3,
+			}),
+		},
+	} {
+		var got Dense
+		got.Kronecker(test.a, test.b)
+		if !Equal(&got, test.want) {
+			t.Errorf("unexpected Kronecker product:\ngot:\n%v\nwant:\n%v", Formatted(&got), Formatted(test.want))
+		}
+
+		// Check transposed arguments against (A^T ⊗ B^T) = (A ⊗ B)^T.
+		got.Reset()
+		got.Kronecker(test.a.T(), test.b.T())
+		if !Equal(&got, test.want.T()) {
+			t.Errorf("unexpected Kronecker product of transposes:\ngot:\n%v\nwant:\n%v", Formatted(&got), Formatted(test.want.T()))
+		}
+
+		// Check the lazy representation.
+		k := NewKronecker(test.a, test.b)
+		if !Equal(k, test.want) {
+			t.Errorf("unexpected lazy Kronecker product:\ngot:\n%v\nwant:\n%v", Formatted(k), Formatted(test.want))
+		}
+	}
+
+	a := NewDense(2, 2, []float64{1, 2, 3, 4})
+	if panicked, _ := panics(func() { a.Kronecker(a, a) }); !panicked {
+		t.Errorf("expected panic for aliased receiver")
+	}
+}
+
+func TestKroneckerMulVec(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		ar, ac, br, bc int
+	}{
+		{1, 1, 1, 1},
+		{2, 3, 4, 5},
+		{5, 4, 3, 2},
+		{3, 3, 4, 4},
+	} {
+		a := randNormDense(test.ar, test.ac, rnd)
+		b := randNormDense(test.br, test.bc, rnd)
+		var want Dense
+		want.Kronecker(a, b)
+		k := NewKronecker(a, b)
+
+		for _, trans := range []bool{false, true} {
+			n := test.ac * test.bc
+			var wm Matrix = &want
+			var km Matrix = k
+			if trans {
+				n = test.ar * test.br
+				wm = want.T()
+				km = k.T()
+			}
+			x := randNormVec(n, rnd)
+			var wantv, got VecDense
+			wantv.MulVec(wm, x)
+			got.MulVec(km, x)
+			if !EqualApprox(&got, &wantv, 1e-12) {
+				t.Errorf("unexpected MulVec result for trans=%t:\ngot: %v\nwant:%v", trans, got.RawVector().Data, wantv.RawVector().Data)
+			}
+			got.Reset()
+			k.MulVecTo(&got, trans, &basicVector{x.RawVector().Data})
+			if !EqualApprox(&got, &wantv, 1e-12) {
+				t.Errorf("unexpected MulVecTo result for trans=%t", trans)
+			}
+		}
+	}
+}
+
+func TestKroneckerCholesky(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{1, 4},
+		{3, 1},
+		{3, 4},
+		{5, 5},
+	} {
+		a := randSPD(test.m, rnd)
+		b := randSPD(test.n, rnd)
+		k := NewSymKronecker(a, b)
+		var dense Dense
+		dense.Kronecker(a, b)
+		sym := NewSymDense(test.m*test.n, dense.RawMatrix().Data)
+		if !Equal(k, sym) {
+			t.Errorf("unexpected SymKronecker value")
+		}
+
+		var kc KroneckerCholesky
+		if !kc.Factorize(k) {
+			t.Errorf("unexpected Factorize failure for %d×%d ⊗ %d×%d", test.m, test.m, test.n, test.n)
+			continue
+		}
+		var chol Cholesky
+		if !chol.Factorize(sym) {
+			t.Fatalf("unexpected Cholesky failure")
+		}
+
+		if got, want := kc.LogDet(), chol.LogDet(); !floats.EqualWithinAbsOrRel(got, want, 1e-10, 1e-10) {
+			t.Errorf("unexpected LogDet: got %v want %v", got, want)
+		}
+		if got, want := kc.Det(), chol.Det(); !floats.EqualWithinAbsOrRel(got, want, 1e-10, 1e-10) {
+			t.Errorf("unexpected Det: got %v want %v", got, want)
+		}
+
+		x := randNormVec(test.m*test.n, rnd)
+		var want, got VecDense
+		err := chol.SolveVecTo(&want, x)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = kc.SolveVecTo(&got, x)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !EqualApprox(&got, &want, 1e-10) {
+			t.Errorf("unexpected SolveVecTo result:\ngot: %v\nwant:%v", got.RawVector().Data, want.RawVector().Data)
+		}
+
+		bm := randNormDense(test.m*test.n, 3, rnd)
+		var wantm, gotm Dense
+		err = chol.SolveTo(&wantm, bm)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = kc.SolveTo(&gotm, bm)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !EqualApprox(&gotm, &wantm, 1e-10) {
+			t.Errorf("unexpected SolveTo result")
+		}
+	}
+
+	// A Kronecker product with an indefinite factor is not positive definite.
+	a := randSPD(3, rnd)
+	b := NewSymDense(2, []float64{1, 2, 2, 1})
+	var kc KroneckerCholesky
+	if kc.Factorize(NewSymKronecker(a, b)) {
+		t.Errorf("unexpected Factorize success for indefinite factor")
+	}
+}
+
+func TestKroneckerEigenSym(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{2, 3},
+		{4, 2},
+		{4, 4},
+	} {
+		a := randSPD(test.m, rnd)
+		b := randSPD(test.n, rnd)
+		k := NewSymKronecker(a, b)
+
+		var ke KroneckerEigenSym
+		if !ke.Factorize(k, true) {
+			t.Fatalf("unexpected Factorize failure")
+		}
+		vals := ke.Values(nil)
+		vecs := ke.VectorsTo(nil)
+
+		// Check A ⊗ B * V = V * D.
+		var av, vd Dense
+		av.Mul(k, vecs)
+		vd.Mul(vecs, NewDiagDense(len(vals), vals))
+		if !EqualApprox(&av, &vd, 1e-10) {
+			t.Errorf("unexpected eigendecomposition")
+		}
+
+		const sigma = 0.5
+		shifted := NewSymDense(test.m*test.n, nil)
+		shifted.CopySym(k)
+		for i := 0; i < test.m*test.n; i++ {
+			shifted.SetSym(i, i, shifted.At(i, i)+sigma)
+		}
+		var chol Cholesky
+		if !chol.Factorize(shifted) {
+			t.Fatalf("unexpected Cholesky failure")
+		}
+		det, sign := ke.LogDetShifted(sigma)
+		if sign != 1 || math.Abs(det-chol.LogDet()) > 1e-10*math.Max(1, math.Abs(det)) {
+			t.Errorf("unexpected LogDetShifted: got %v (sign %v) want %v", det, sign, chol.LogDet())
+		}
+
+		x := randNormVec(test.m*test.n, rnd)
+		var want, got VecDense
+		err := chol.SolveVecTo(&want, x)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = ke.SolveShiftedVecTo(&got, sigma, x)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !EqualApprox(&got, &want, 1e-10) {
+			t.Errorf("unexpected SolveShiftedVecTo result:\ngot: %v\nwant:%v", got.RawVector().Data, want.RawVector().Data)
+		}
+	}
+
+	a := NewSymDense(2, []float64{2, 0, 0, 3})
+	var ke KroneckerEigenSym
+	if !ke.Factorize(NewSymKronecker(a, a), false) {
+		t.Fatalf("unexpected Factorize failure")
+	}
+	if panicked, _ := panics(func() { ke.VectorsTo(nil) }); !panicked {
+		t.Errorf("expected panic for VectorsTo without vectors")
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack/lapack32"
+)
+
+const badLU32 = "mat: invalid single precision LU factorization"
+
+// LU32 is a type for creating and using the LU factorization of a single
+// precision matrix.
+type LU32 struct {
+	lu    *Dense32
+	pivot []int
+}
+
+// Factorize computes the LU factorization of the square matrix a and stores
+// the result. The LU decomposition will complete regardless of the singularity
+// of a.
+//
+// The LU factorization is computed with pivoting, and so really the decomposition
+// is a PLU decomposition where P is a permutation matrix. The individual matrix
+// factors can be extracted from the factorization using the Pivot method and
+// the LU32 LTo and UTo methods.
+func (lu *LU32) Factorize(a Matrix32) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	if lu.lu == nil {
+		lu.lu = NewDense32(r, r, nil)
+	} else {
+		lu.lu.Reset()
+		lu.lu.reuseAs(r, r)
+	}
+	lu.lu.Copy(a)
+	if cap(lu.pivot) < r {
+		lu.pivot = make([]int, r)
+	}
+	lu.pivot = lu.pivot[:r]
+	lapack32.Getrf(lu.lu.mat, lu.pivot)
+}
+
+// isValid returns whether the receiver contains a factorization.
+func (lu *LU32) isValid() bool {
+	return lu.lu != nil && !lu.lu.IsZero()
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (lu *LU32) Reset() {
+	if lu.lu != nil {
+		lu.lu.Reset()
+	}
+	lu.pivot = lu.pivot[:0]
+}
+
+// Det returns the determinant of the matrix that has been factorized. In many
+// expressions, using LogDet will be more numerically stable.
+// Det will panic if the receiver does not contain a factorization.
+func (lu *LU32) Det() float64 {
+	det, sign := lu.LogDet()
+	return math.Exp(det) * sign
+}
+
+// LogDet returns the log of the determinant and the sign of the determinant
+// for the matrix that has been factorized. Numerical stability in product and
+// division expressions is generally improved by working in log space.
+// The determinant is accumulated in double precision.
+// LogDet will panic if the receiver does not contain a factorization.
+func (lu *LU32) LogDet() (det float64, sign float64) {
+	if !lu.isValid() {
+		panic(badLU32)
+	}
+
+	_, n := lu.lu.Dims()
+	logDiag := make([]float64, n)
+	sign = 1.0
+	for i := 0; i < n; i++ {
+		v := float64(lu.lu.at(i, i))
+		if v < 0 {
+			sign *= -1
+		}
+		if lu.pivot[i] != i {
+			sign *= -1
+		}
+		logDiag[i] = math.Log(math.Abs(v))
+	}
+	for _, v := range logDiag {
+		det += v
+	}
+	return det, sign
+}
+
+// Pivot returns pivot indices that enable the construction of the permutation
+// matrix P (see Dense.Permutation). If swaps == nil, then new memory will be
+// allocated, otherwise the length of the input must be equal to the size of the
+// factorized matrix.
+// Pivot will panic if the receiver does not contain a factorization.
+func (lu *LU32) Pivot(swaps []int) []int {
+	if !lu.isValid() {
+		panic(badLU32)
+	}
+
+	_, n := lu.lu.Dims()
+	if swaps == nil {
+		swaps = make([]int, n)
+	}
+	if len(swaps) != n {
+		panic(badSliceLength)
+	}
+	// Perform the inverse of the row swaps in order to find the final
+	// row swap position.
+	for i := range swaps {
+		swaps[i] = i
+	}
+	for i := n - 1; i >= 0; i-- {
+		v := lu.pivot[i]
+		swaps[i], swaps[v] = swaps[v], swaps[i]
+	}
+	return swaps
+}
+
+// LTo extracts the unit lower triangular matrix from an LU factorization.
+// If dst is nil, a new matrix is allocated. The resulting L matrix is returned.
+// LTo will panic if the receiver does not contain a factorization.
+func (lu *LU32) LTo(dst *Dense32) *Dense32 {
+	if !lu.isValid() {
+		panic(badLU32)
+	}
+
+	_, n := lu.lu.Dims()
+	if dst == nil {
+		dst = NewDense32(n, n, nil)
+	} else {
+		dst.reuseAsZeroed(n, n)
+	}
+	// Extract the lower triangular elements.
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			dst.mat.Data[i*dst.mat.Stride+j] = lu.lu.mat.Data[i*lu.lu.mat.Stride+j]
+		}
+	}
+	// Set ones on the diagonal.
+	for i := 0; i < n; i++ {
+		dst.mat.Data[i*dst.mat.Stride+i] = 1
+	}
+	return dst
+}
+
+// UTo extracts the upper triangular matrix from an LU factorization.
+// If dst is nil, a new matrix is allocated. The resulting U matrix is returned.
+// UTo will panic if the receiver does not contain a factorization.
+func (lu *LU32) UTo(dst *Dense32) *Dense32 {
+	if !lu.isValid() {
+		panic(badLU32)
+	}
+
+	_, n := lu.lu.Dims()
+	if dst == nil {
+		dst = NewDense32(n, n, nil)
+	} else {
+		dst.reuseAsZeroed(n, n)
+	}
+	// Extract the upper triangular elements.
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			dst.mat.Data[i*dst.mat.Stride+j] = lu.lu.mat.Data[i*lu.lu.mat.Stride+j]
+		}
+	}
+	return dst
+}
+
+// SolveTo solves a system of linear equations using the LU decomposition of a
+// matrix. It computes
+//  A * X = B if trans == false
+//  A^T * X = B if trans == true
+// In both cases, A is represented in LU factorized form, and the matrix X is
+// stored into dst.
+//
+// If A is exactly singular a Condition error is returned. No estimate of the
+// condition number is made for single precision matrices.
+// SolveTo will panic if the receiver does not contain a factorization.
+func (lu *LU32) SolveTo(dst *Dense32, trans bool, b Matrix32) error {
+	if !lu.isValid() {
+		panic(badLU32)
+	}
+
+	_, n := lu.lu.Dims()
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	for i := 0; i < n; i++ {
+		if lu.lu.at(i, i) == 0 {
+			return Condition(math.Inf(1))
+		}
+	}
+
+	dst.reuseAs(n, bc)
+	bU, _ := untranspose32(b)
+	var restore func()
+	if dst == bU {
+		dst, restore = dst.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer32); ok {
+		dst.checkOverlap(rm.RawMatrix())
+	}
+
+	dst.Copy(b)
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	lapack32.Getrs(t, lu.lu.mat, dst.mat, lu.pivot)
+	return nil
+}
+
+// SolveVecTo solves a system of linear equations using the LU decomposition
+// of a matrix. It computes
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// In both cases, A is represented in LU factorized form, and the vector x is
+// stored into dst.
+//
+// If A is exactly singular a Condition error is returned.
+// SolveVecTo will panic if the receiver does not contain a factorization.
+func (lu *LU32) SolveVecTo(dst *VecDense32, trans bool, b Vector32) error {
+	if !lu.isValid() {
+		panic(badLU32)
+	}
+
+	_, n := lu.lu.Dims()
+	if br, bc := b.Dims(); br != n || bc != 1 {
+		panic(ErrShape)
+	}
+	dst.reuseAs(n)
+	if bv, ok := b.(*VecDense32); ok && dst != bv {
+		dst.checkOverlap(bv.mat)
+	}
+	return lu.SolveTo(dst.asDense(), trans, b)
+}
@@ -0,0 +1,88 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestLU32(t *testing.T) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		a := randDense32(n, n, rnd)
+		var lu LU32
+		lu.Factorize(a)
+
+		l := lu.LTo(nil)
+		u := lu.UTo(nil)
+		for i := 0; i < n; i++ {
+			if l.At(i, i) != 1 {
+				t.Errorf("n=%d: L does not have unit diagonal", n)
+			}
+			for j := i + 1; j < n; j++ {
+				if l.At(i, j) != 0 || u.At(j, i) != 0 {
+					t.Errorf("n=%d: factors are not triangular", n)
+				}
+			}
+		}
+		var p, plu Dense
+		p.Permutation(n, lu.Pivot(nil))
+		plu.Mul(&p, l.To64(nil))
+		plu.Mul(&plu, u.To64(nil))
+		a64 := a.To64(nil)
+		if !EqualApprox(&plu, a64, tol) {
+			t.Errorf("n=%d: P*L*U != A", n)
+		}
+
+		var lu64 LU
+		lu64.Factorize(a64)
+		want := lu64.Det()
+		if got := lu.Det(); math.Abs(got-want) > tol*math.Abs(want) {
+			t.Errorf("n=%d: unexpected determinant: got %v, want %v", n, got, want)
+		}
+
+		for _, trans := range []bool{false, true} {
+			b := randDense32(n, 3, rnd)
+			var x Dense32
+			err := lu.SolveTo(&x, trans, b)
+			if err != nil {
+				t.Errorf("n=%d: unexpected error: %v", n, err)
+				continue
+			}
+			var want Dense
+			err = lu64.SolveTo(&want, trans, b.To64(nil))
+			if err != nil {
+				t.Fatalf("n=%d: unexpected error from float64 solve: %v", n, err)
+			}
+			if !EqualApprox(x.To64(nil), &want, tol*lu64.Cond()) {
+				t.Errorf("n=%d, trans=%t: unexpected solution", n, trans)
+			}
+
+			var xv VecDense32
+			err = lu.SolveVecTo(&xv, trans, b.ColView(1))
+			if err != nil {
+				t.Errorf("n=%d: unexpected error: %v", n, err)
+				continue
+			}
+			if !EqualApprox(xv.To64(nil), want.ColView(1), tol*lu64.Cond()) {
+				t.Errorf("n=%d, trans=%t: unexpected vector solution", n, trans)
+			}
+		}
+	}
+
+	var lu LU32
+	lu.Factorize(NewDense32(2, 2, []float32{1, 2, 2, 4}))
+	if lu.Det() != 0 {
+		t.Errorf("unexpected non-zero determinant for singular matrix")
+	}
+	var x Dense32
+	if err := lu.SolveTo(&x, false, NewDense32(2, 1, nil)); err == nil {
+		t.Errorf("expected error solving singular system")
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Sqrt calculates the principal square root of the matrix a, placing the
+// result in the receiver. The principal square root is the unique square
+// root X of A
+//  X * X = A
+// whose eigenvalues all have positive real part. Sqrt will panic with
+// ErrShape if a is not square.
+//
+// Sqrt returns ErrNegativeEigenvalue if a has a negative real eigenvalue, in
+// which case no real principal square root exists. If a is singular, the
+// square root may not exist or may be too ill-conditioned to compute, in which
+// case a Condition error is returned. ErrFailedEigen is returned if the Schur
+// decomposition of a fails.
+func (m *Dense) Sqrt(a Matrix) error {
+	// The implementation used here is the real Schur method from
+	// Higham, N. J. Computing real square roots of a real matrix.
+	// Linear Algebra and its Applications 88-89, 405-430 (1987).
+	// https://doi.org/10.1016/0024-3795(87)90118-2
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrShape)
+	}
+	m.reuseAs(r, r)
+
+	var schur Schur
+	ok := schur.Factorize(a, true)
+	if !ok {
+		return ErrFailedEigen
+	}
+	t := schur.TTo(nil)
+	err := sqrtQuasiTri(t, t)
+	if err != nil {
+		return err
+	}
+	z := schur.ZTo(nil)
+	m.Product(z, t, z.T())
+	return nil
+}
+
+// Log calculates the principal logarithm of the matrix a, placing the result
+// in the receiver. The principal logarithm is the unique logarithm X of A
+//  exp(X) = A
+// whose eigenvalues all have imaginary part in the interval (-π, π). Log
+// will panic with ErrShape if a is not square.
+//
+// Log returns ErrNegativeEigenvalue if a has a negative real eigenvalue, in
+// which case no real principal logarithm exists, and ErrSingular if a is
+// singular. ErrFailedEigen is returned if the Schur decomposition of a fails.
+func (m *Dense) Log(a Matrix) error {
+	// The implementation used here is the inverse scaling and squaring
+	// method from Functions of Matrices: Theory and Computation
+	// Chapter 11, Section 11.5. https://doi.org/10.1137/1.9780898717778.ch11
+	// applied to the real Schur form of a. The Padé approximant is
+	// evaluated in its partial fraction form following
+	// Higham, N. J. Evaluating Padé approximants of the matrix logarithm.
+	// SIAM J. Matrix Anal. Appl. 22(4), 1126-1135 (2001).
+	// https://doi.org/10.1137/S0895479800368688
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrShape)
+	}
+	m.reuseAs(r, r)
+
+	var schur Schur
+	ok := schur.Factorize(a, true)
+	if !ok {
+		return ErrFailedEigen
+	}
+	for _, v := range schur.Values(nil) {
+		if imag(v) == 0 {
+			switch {
+			case real(v) < 0:
+				return ErrNegativeEigenvalue
+			case real(v) == 0:
+				return ErrSingular
+			}
+		}
+	}
+	t := schur.TTo(nil)
+
+	// Take square roots of T until it is close enough to the identity
+	// for the Padé approximant to be accurate.
+	const (
+		// theta is the bound on |T - I|_1 at which the [8/8] Padé
+		// approximant of log(I + X) is accurate to double precision.
+		theta = 0.25
+
+		maxRoots = 64
+	)
+	x := NewDense(r, r, nil)
+	var s int
+	for ; s < maxRoots; s++ {
+		x.Copy(t)
+		for i := 0; i < r; i++ {
+			x.set(i, i, x.at(i, i)-1)
+		}
+		if Norm(x, 1) <= theta {
+			break
+		}
+		err := sqrtQuasiTri(t, t)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Evaluate the [8/8] Padé approximant
+	//  log(I + X) ≈ sum_j w_j * X * (I + x_j * X)^-1
+	// where x_j and w_j are the nodes and weights of the 8 point
+	// Gauss-Legendre quadrature on [0, 1].
+	nodes := [...]float64{
+		0.01985507175123188415821957,
+		0.1016667612931866302042231,
+		0.2372337950418355070911305,
+		0.4082826787521750975302619,
+		0.5917173212478249024697381,
+		0.7627662049581644929088695,
+		0.8983332387068133697957769,
+		0.9801449282487681158417804,
+	}
+	weights := [...]float64{
+		0.05061426814518812957626568,
+		0.1111905172266872352721780,
+		0.1568533229389436436689812,
+		0.1813418916891809914825752,
+		0.1813418916891809914825752,
+		0.1568533229389436436689812,
+		0.1111905172266872352721780,
+		0.05061426814518812957626568,
+	}
+	d := NewDense(r, r, nil)
+	y := NewDense(r, r, nil)
+	m.Zero()
+	for j, node := range nodes {
+		d.Scale(node, x)
+		for i := 0; i < r; i++ {
+			d.set(i, i, d.at(i, i)+1)
+		}
+		// X and (I + x_j * X) commute, so the product may be
+		// formed by solving with X as the right-hand side.
+		err := y.Solve(d, x)
+		if err != nil {
+			return err
+		}
+		y.Scale(weights[j], y)
+		m.Add(m, y)
+	}
+	y.Scale(math.Ldexp(1, s), m)
+
+	z := schur.ZTo(nil)
+	m.Product(z, y, z.T())
+	return nil
+}
+
+// Sign calculates the matrix sign function of the matrix a, placing the
+// result in the receiver. The matrix sign function is the square root of the
+// identity
+//  S * S = I
+// whose eigenvalues are the signs of the real parts of the corresponding
+// eigenvalues of A. Sign will panic with ErrShape if a is not square.
+//
+// Sign returns ErrZeroRealPart if a has an eigenvalue with zero real part, in
+// which case the sign function is not defined. If the iteration used to
+// compute the sign function does not converge, a Condition error is
+// returned. ErrFailedEigen is returned if the Schur decomposition of a fails.
+func (m *Dense) Sign(a Matrix) error {
+	// The implementation used here is the scaled Newton iteration from
+	// Functions of Matrices: Theory and Computation
+	// Chapter 5, Algorithm 5.14. https://doi.org/10.1137/1.9780898717778.ch5
+	// with determinantal scaling.
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrShape)
+	}
+
+	var schur Schur
+	ok := schur.Factorize(a, false)
+	if !ok {
+		return ErrFailedEigen
+	}
+	for _, v := range schur.Values(nil) {
+		if real(v) == 0 {
+			return ErrZeroRealPart
+		}
+	}
+	x := DenseCopyOf(a)
+	m.reuseAs(r, r)
+
+	const (
+		// scaleTol is the relative change in the iterates below which
+		// scaling no longer accelerates convergence.
+		scaleTol = 1e-2
+
+		maxIter = 100
+	)
+	tol := float64(r) * machEps
+	eye := NewDiagDense(r, nil)
+	for i := 0; i < r; i++ {
+		eye.SetDiag(i, 1)
+	}
+	var lu LU
+	xinv := NewDense(r, r, nil)
+	next := NewDense(r, r, nil)
+	scale := true
+	for k := 0; k < maxIter; k++ {
+		lu.Factorize(x)
+		err := lu.SolveTo(xinv, false, eye)
+		if err != nil {
+			if cond, ok := err.(Condition); ok && math.IsInf(float64(cond), 1) {
+				return err
+			}
+		}
+		xinvNorm := Norm(xinv, 1)
+
+		// Form X_{k+1} = (mu*X_k + (mu*X_k)^-1) / 2.
+		mu := 1.0
+		if scale {
+			logdet, _ := lu.LogDet()
+			mu = math.Exp(-logdet / float64(r))
+		}
+		next.Scale(mu/2, x)
+		xinv.Scale(1/(2*mu), xinv)
+		next.Add(next, xinv)
+
+		x.Sub(next, x)
+		diff := Norm(x, 1)
+		nextNorm := Norm(next, 1)
+		x, next = next, x
+		if diff <= tol*nextNorm || (!scale && diff <= math.Sqrt(tol*nextNorm/xinvNorm)) {
+			m.Copy(x)
+			return nil
+		}
+		if diff <= scaleTol*nextNorm {
+			scale = false
+		}
+	}
+	return Condition(math.Inf(1))
+}
+
+// sqrtQuasiTri computes the principal square root of the upper
+// quasi-triangular matrix t in real Schur canonical form and stores the
+// result into dst. dst and t may be the same matrix.
+func sqrtQuasiTri(dst, t *Dense) error {
+	n, _ := t.Dims()
+
+	blocks := schurBlocks(t)
+	nb := len(blocks) - 1
+
+	u := NewDense(n, n, nil)
+
+	// Compute the square roots of the diagonal blocks.
+	for k := 0; k < nb; k++ {
+		i := blocks[k]
+		if blocks[k+1]-i == 1 {
+			v := t.at(i, i)
+			if v < 0 {
+				return ErrNegativeEigenvalue
+			}
+			u.set(i, i, math.Sqrt(v))
+			continue
+		}
+		// The 2×2 block has complex conjugate eigenvalues θ ± iμ.
+		// If (α + iβ)^2 = θ + iμ, the square root of the block B is
+		//  α*I + (B - θ*I)/(2*α).
+		t11, t12 := t.at(i, i), t.at(i, i+1)
+		t21, t22 := t.at(i+1, i), t.at(i+1, i+1)
+		theta := (t11 + t22) / 2
+		p := (t11 - t22) / 2
+		mu := math.Sqrt(math.Abs(p*p + t12*t21))
+		alpha := real(cmplx.Sqrt(complex(theta, mu)))
+		u.set(i, i, alpha+(t11-theta)/(2*alpha))
+		u.set(i, i+1, t12/(2*alpha))
+		u.set(i+1, i, t21/(2*alpha))
+		u.set(i+1, i+1, alpha+(t22-theta)/(2*alpha))
+	}
+
+	// Compute the off-diagonal blocks a column of blocks at a time by
+	// solving the Sylvester equations
+	//  U_ii * U_ij + U_ij * U_jj = T_ij - sum_{k=i+1}^{j-1} U_ik * U_kj.
+	var sys, rhs, x Dense
+	for bj := 1; bj < nb; bj++ {
+		j0, j1 := blocks[bj], blocks[bj+1]
+		nj := j1 - j0
+		for bi := bj - 1; bi >= 0; bi-- {
+			i0, i1 := blocks[bi], blocks[bi+1]
+			ni := i1 - i0
+			// Form the right-hand side.
+			sz := ni * nj
+			rhs.Reset()
+			rhs.reuseAs(sz, 1)
+			for r := 0; r < ni; r++ {
+				for c := 0; c < nj; c++ {
+					v := t.at(i0+r, j0+c)
+					for k := i1; k < j0; k++ {
+						v -= u.at(i0+r, k) * u.at(k, j0+c)
+					}
+					rhs.set(r*nj+c, 0, v)
+				}
+			}
+			// Form the Kronecker sum system
+			//  (U_ii ⊗ I + I ⊗ U_jj^T) * vec(U_ij) = vec(rhs)
+			// with vec taken row-wise.
+			sys.Reset()
+			sys.reuseAsZeroed(sz, sz)
+			for r := 0; r < ni; r++ {
+				for c := 0; c < nj; c++ {
+					row := r*nj + c
+					for k := 0; k < ni; k++ {
+						sys.set(row, k*nj+c, sys.at(row, k*nj+c)+u.at(i0+r, i0+k))
+					}
+					for k := 0; k < nj; k++ {
+						sys.set(row, r*nj+k, sys.at(row, r*nj+k)+u.at(j0+k, j0+c))
+					}
+				}
+			}
+			x.Reset()
+			err := x.Solve(&sys, &rhs)
+			if err != nil {
+				return err
+			}
+			for r := 0; r < ni; r++ {
+				for c := 0; c < nj; c++ {
+					u.set(i0+r, j0+c, x.at(r*nj+c, 0))
+				}
+			}
+		}
+	}
+	dst.Copy(u)
+	return nil
+}
+
+// schurBlocks returns the starting rows of the diagonal blocks of the upper
+// quasi-triangular matrix t in real Schur canonical form, followed by the
+// order of t.
+func schurBlocks(t *Dense) []int {
+	n, _ := t.Dims()
+	var blocks []int
+	for i := 0; i < n; {
+		blocks = append(blocks, i)
+		if i < n-1 && t.at(i+1, i) != 0 {
+			i += 2
+		} else {
+			i++
+		}
+	}
+	return append(blocks, n)
+}
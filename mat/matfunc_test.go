@@ -0,0 +1,356 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestDenseSqrt(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		a   *Dense
+		err error
+	}{
+		{a: NewDense(1, 1, []float64{4})},
+		{a: NewDense(2, 2, []float64{4, 1, 0, 9})},
+		// Rotation with complex eigenvalues ±i.
+		{a: NewDense(2, 2, []float64{0, -1, 1, 0})},
+		{a: NewDense(3, 3, []float64{
+			1, -2, 0.5,
+			3, 1, 2,
+			0, 0.1, 4,
+		})},
+		{a: NewDense(1, 1, []float64{-1}), err: ErrNegativeEigenvalue},
+		{a: NewDense(2, 2, []float64{-2, 1, 0, 3}), err: ErrNegativeEigenvalue},
+	} {
+		var s Dense
+		err := s.Sqrt(test.a)
+		if err != test.err {
+			t.Errorf("unexpected error for\n%v\ngot:%v want:%v", Formatted(test.a), err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		var got Dense
+		got.Mul(&s, &s)
+		if !EqualApprox(&got, test.a, 1e-12) {
+			t.Errorf("unexpected result for\n%v\nS*S =\n%v", Formatted(test.a), Formatted(&got))
+		}
+	}
+
+	for _, n := range []int{1, 2, 3, 5, 10, 20} {
+		for k := 0; k < 5; k++ {
+			a := randNormDense(n, n, rnd)
+			for i := 0; i < n; i++ {
+				a.set(i, i, a.at(i, i)+float64(2*n))
+			}
+			var s Dense
+			err := s.Sqrt(a)
+			if err != nil {
+				t.Errorf("n=%d: unexpected error: %v", n, err)
+				continue
+			}
+			var got Dense
+			got.Mul(&s, &s)
+			if !EqualApprox(&got, a, 1e-10*float64(n)) {
+				t.Errorf("n=%d: S*S != A", n)
+			}
+		}
+	}
+}
+
+func TestDenseLog(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		a, want *Dense
+		err     error
+	}{
+		{
+			a:    NewDense(1, 1, []float64{math.E}),
+			want: NewDense(1, 1, []float64{1}),
+		},
+		{
+			a:    NewDense(2, 2, []float64{1, 1, 0, 1}),
+			want: NewDense(2, 2, []float64{0, 1, 0, 0}),
+		},
+		{
+			// exp of the rotation generator by π/2.
+			a:    NewDense(2, 2, []float64{0, -1, 1, 0}),
+			want: NewDense(2, 2, []float64{0, -math.Pi / 2, math.Pi / 2, 0}),
+		},
+		{a: NewDense(1, 1, []float64{-1}), err: ErrNegativeEigenvalue},
+		{a: NewDense(2, 2, []float64{1, 2, 2, 4}), err: ErrSingular},
+	} {
+		var l Dense
+		err := l.Log(test.a)
+		if err != test.err {
+			t.Errorf("unexpected error for\n%v\ngot:%v want:%v", Formatted(test.a), err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !EqualApprox(&l, test.want, 1e-12) {
+			t.Errorf("unexpected result for\n%v\ngot:\n%v\nwant:\n%v", Formatted(test.a), Formatted(&l), Formatted(test.want))
+		}
+	}
+
+	for _, n := range []int{1, 2, 3, 5, 10, 20} {
+		for k := 0; k < 5; k++ {
+			// Log(Exp(B)) = B when the eigenvalues of B have
+			// imaginary part in (-π, π).
+			b := randNormDense(n, n, rnd)
+			b.Scale(1/math.Sqrt(float64(n)), b)
+			var a, l Dense
+			a.Exp(b)
+			err := l.Log(&a)
+			if err != nil {
+				t.Errorf("n=%d: unexpected error: %v", n, err)
+				continue
+			}
+			if !EqualApprox(&l, b, 1e-9) {
+				t.Errorf("n=%d: Log(Exp(B)) != B", n)
+			}
+
+			// Exp(Log(A)) = A.
+			a.Clone(randNormDense(n, n, rnd))
+			for i := 0; i < n; i++ {
+				a.set(i, i, a.at(i, i)+float64(2*n))
+			}
+			err = l.Log(&a)
+			if err != nil {
+				t.Errorf("n=%d: unexpected error: %v", n, err)
+				continue
+			}
+			var got Dense
+			got.Exp(&l)
+			if !EqualApprox(&got, &a, 1e-10*float64(n)) {
+				t.Errorf("n=%d: Exp(Log(A)) != A", n)
+			}
+		}
+	}
+}
+
+func TestDenseSign(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		a, want *Dense
+		err     error
+	}{
+		{
+			a:    NewDense(1, 1, []float64{-3}),
+			want: NewDense(1, 1, []float64{-1}),
+		},
+		{
+			a:    NewDense(2, 2, []float64{2, 1, 0, -1}),
+			want: NewDense(2, 2, []float64{1, 2.0 / 3, 0, -1}),
+		},
+		{
+			// Complex eigenvalues 1±i.
+			a:    NewDense(2, 2, []float64{1, -1, 1, 1}),
+			want: NewDense(2, 2, []float64{1, 0, 0, 1}),
+		},
+		// Rotation with eigenvalues ±i.
+		{a: NewDense(2, 2, []float64{0, -1, 1, 0}), err: ErrZeroRealPart},
+		{a: NewDense(2, 2, []float64{1, 2, 2, 4}), err: ErrZeroRealPart},
+	} {
+		var s Dense
+		err := s.Sign(test.a)
+		if err != test.err {
+			t.Errorf("unexpected error for\n%v\ngot:%v want:%v", Formatted(test.a), err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !EqualApprox(&s, test.want, 1e-12) {
+			t.Errorf("unexpected result for\n%v\ngot:\n%v\nwant:\n%v", Formatted(test.a), Formatted(&s), Formatted(test.want))
+		}
+	}
+
+	for _, n := range []int{1, 2, 3, 5, 10, 20} {
+		for k := 0; k < 5; k++ {
+			// A = V * D * V^-1 with D diagonal has sign
+			// V * sign(D) * V^-1.
+			v := randNormDense(n, n, rnd)
+			for i := 0; i < n; i++ {
+				v.set(i, i, v.at(i, i)+float64(2*n))
+			}
+			var vinv Dense
+			err := vinv.Inverse(v)
+			if err != nil {
+				t.Fatalf("n=%d: unexpected error inverting V: %v", n, err)
+			}
+			d := NewDiagDense(n, nil)
+			sd := NewDiagDense(n, nil)
+			for i := 0; i < n; i++ {
+				x := 0.1 + 10*rnd.Float64()
+				if rnd.Intn(2) == 0 {
+					x = -x
+				}
+				d.SetDiag(i, x)
+				sd.SetDiag(i, math.Copysign(1, x))
+			}
+			var a, want Dense
+			a.Product(v, d, &vinv)
+			want.Product(v, sd, &vinv)
+
+			var s Dense
+			err = s.Sign(&a)
+			if err != nil {
+				t.Errorf("n=%d: unexpected error: %v", n, err)
+				continue
+			}
+			if !EqualApprox(&s, &want, 1e-10) {
+				t.Errorf("n=%d: unexpected sign:\ngot:\n%v\nwant:\n%v", n, Formatted(&s), Formatted(&want))
+			}
+			eye := NewDiagDense(n, nil)
+			for i := 0; i < n; i++ {
+				eye.SetDiag(i, 1)
+			}
+			var got Dense
+			got.Mul(&s, &s)
+			if !EqualApprox(&got, eye, 1e-10) {
+				t.Errorf("n=%d: S*S != I", n)
+			}
+			var as, sa Dense
+			as.Mul(&a, &s)
+			sa.Mul(&s, &a)
+			if !EqualApprox(&as, &sa, 1e-10*float64(n)) {
+				t.Errorf("n=%d: A*S != S*A", n)
+			}
+		}
+	}
+}
+
+func TestSymDenseMatrixFunctions(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10} {
+		a := randSPD(n, rnd)
+
+		var s SymDense
+		err := s.SqrtPSD(a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected SqrtPSD error: %v", n, err)
+			continue
+		}
+		var got Dense
+		got.Mul(&s, &s)
+		if !EqualApprox(&got, a, 1e-10) {
+			t.Errorf("n=%d: SqrtPSD(A)^2 != A", n)
+		}
+		var ds Dense
+		err = ds.Sqrt(a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected Sqrt error: %v", n, err)
+			continue
+		}
+		if !EqualApprox(&s, &ds, 1e-10) {
+			t.Errorf("n=%d: SqrtPSD does not match Dense.Sqrt", n)
+		}
+
+		var l SymDense
+		err = l.LogPSD(a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected LogPSD error: %v", n, err)
+			continue
+		}
+		got.Exp(&l)
+		if !EqualApprox(&got, a, 1e-10) {
+			t.Errorf("n=%d: Exp(LogPSD(A)) != A", n)
+		}
+		var dl Dense
+		err = dl.Log(a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected Log error: %v", n, err)
+			continue
+		}
+		if !EqualApprox(&l, &dl, 1e-10) {
+			t.Errorf("n=%d: LogPSD does not match Dense.Log", n)
+		}
+
+		var f SymDense
+		err = f.FuncSym(math.Exp, &l)
+		if err != nil {
+			t.Errorf("n=%d: unexpected FuncSym error: %v", n, err)
+			continue
+		}
+		if !EqualApprox(&f, a, 1e-10) {
+			t.Errorf("n=%d: FuncSym(exp, LogPSD(A)) != A", n)
+		}
+		var p SymDense
+		err = p.PowPSD(a, 0.3)
+		if err != nil {
+			t.Errorf("n=%d: unexpected PowPSD error: %v", n, err)
+			continue
+		}
+		err = f.FuncSym(func(v float64) float64 { return math.Pow(v, 0.3) }, a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected FuncSym error: %v", n, err)
+			continue
+		}
+		if !EqualApprox(&f, &p, 1e-10) {
+			t.Errorf("n=%d: FuncSym(pow) does not match PowPSD", n)
+		}
+	}
+
+	for _, n := range []int{2, 3, 5, 10, 20} {
+		// A = X * X^T with X of rank n/2 is positive semi-definite
+		// but its zero eigenvalues may be computed as small negative
+		// values.
+		x := randNormDense(n, n/2, rnd)
+		var a SymDense
+		a.SymOuterK(1, x)
+
+		var s SymDense
+		err := s.SqrtPSD(&a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected SqrtPSD error for rank deficient matrix: %v", n, err)
+			continue
+		}
+		var got Dense
+		got.Mul(&s, &s)
+		if !EqualApprox(&got, &a, 1e-10*float64(n)) {
+			t.Errorf("n=%d: S*S != A for rank deficient matrix", n)
+		}
+
+		var p SymDense
+		err = p.PowPSD(&a, 0.5)
+		if err != nil {
+			t.Errorf("n=%d: unexpected PowPSD error for rank deficient matrix: %v", n, err)
+			continue
+		}
+		if !EqualApprox(&p, &s, 1e-10) {
+			t.Errorf("n=%d: PowPSD(0.5) does not match SqrtPSD for rank deficient matrix", n)
+		}
+		p.Reset()
+		if err := p.PowPSD(&a, -0.5); err != ErrNotPSD {
+			t.Errorf("n=%d: unexpected PowPSD error for negative power of singular matrix: got:%v want:%v", n, err, ErrNotPSD)
+		}
+	}
+
+	neg := NewSymDense(2, []float64{1, 0, 0, -1})
+	var s SymDense
+	if err := s.SqrtPSD(neg); err != ErrNotPSD {
+		t.Errorf("unexpected SqrtPSD error for indefinite matrix: got:%v want:%v", err, ErrNotPSD)
+	}
+	s.Reset()
+	if err := s.LogPSD(NewSymDense(2, []float64{1, 0, 0, 0})); err != ErrNotPSD {
+		t.Errorf("unexpected LogPSD error for singular matrix: got:%v want:%v", err, ErrNotPSD)
+	}
+	s.Reset()
+	if err := s.SqrtPSD(NewSymDense(2, []float64{1, 0, 0, 0})); err != nil {
+		t.Errorf("unexpected SqrtPSD error for singular PSD matrix: %v", err)
+	}
+}
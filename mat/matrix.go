@@ -207,6 +207,13 @@ type ColNonZeroDoer interface {
 	DoColNonZero(j int, fn func(i, j int, v float64))
 }
 
+// A MulVecToer can compute a Matrix-Vector product, or its transpose, storing
+// the result into dst.
+type MulVecToer interface {
+	Matrix
+	MulVecTo(dst *VecDense, trans bool, x Vector)
+}
+
 // untranspose untransposes a matrix if applicable. If a is an Untransposer, then
 // untranspose returns the underlying matrix and true. If it is not, then it returns
 // the input matrix and false.
@@ -0,0 +1,190 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import "gonum.org/v1/gonum/blas/blas32"
+
+// Matrix32 is the basic matrix interface type for single precision matrices.
+type Matrix32 interface {
+	// Dims returns the dimensions of a Matrix32.
+	Dims() (r, c int)
+
+	// At returns the value of a matrix element at row i, column j.
+	// It will panic if i or j are out of bounds for the matrix.
+	At(i, j int) float32
+
+	// T returns the transpose of the Matrix32. Whether T returns a copy of the
+	// underlying data is implementation dependent.
+	// This method may be implemented using the Transpose32 type, which
+	// provides an implicit matrix transpose.
+	T() Matrix32
+}
+
+// Vector32 is a single precision vector.
+type Vector32 interface {
+	Matrix32
+	AtVec(int) float32
+	Len() int
+}
+
+// Symmetric32 represents a single precision symmetric matrix (where the
+// element at {i, j} equals the element at {j, i}). Symmetric32 matrices are
+// always square.
+type Symmetric32 interface {
+	Matrix32
+	// Symmetric returns the number of rows/columns in the matrix.
+	Symmetric() int
+}
+
+// RawMatrixer32 is a type that can return a blas32.General representation of
+// itself.
+type RawMatrixer32 interface {
+	RawMatrix() blas32.General
+}
+
+var (
+	_ Matrix32       = Transpose32{}
+	_ Untransposer32 = Transpose32{}
+)
+
+// Transpose32 is a type for performing an implicit matrix transpose of a
+// Matrix32. It implements the Matrix32 interface, returning values from the
+// transpose of the matrix within.
+type Transpose32 struct {
+	Matrix32 Matrix32
+}
+
+// At returns the value of the element at row i and column j of the transposed
+// matrix, that is, row j and column i of the Matrix32 field.
+func (t Transpose32) At(i, j int) float32 {
+	return t.Matrix32.At(j, i)
+}
+
+// Dims returns the dimensions of the transposed matrix. The number of rows returned
+// is the number of columns in the Matrix32 field, and the number of columns is
+// the number of rows in the Matrix32 field.
+func (t Transpose32) Dims() (r, c int) {
+	c, r = t.Matrix32.Dims()
+	return r, c
+}
+
+// T performs an implicit transpose by returning the Matrix32 field.
+func (t Transpose32) T() Matrix32 {
+	return t.Matrix32
+}
+
+// Untranspose returns the Matrix32 field.
+func (t Transpose32) Untranspose() Matrix32 {
+	return t.Matrix32
+}
+
+// Untransposer32 is a type that can undo an implicit transpose of a Matrix32.
+type Untransposer32 interface {
+	// Untranspose returns the underlying Matrix32 stored for the implicit
+	// transpose.
+	Untranspose() Matrix32
+}
+
+// untranspose32 untransposes a matrix if applicable. If a is an Untransposer32,
+// then untranspose32 returns the underlying matrix and true. If it is not, then
+// it returns the input matrix and false.
+func untranspose32(a Matrix32) (Matrix32, bool) {
+	if ut, ok := a.(Untransposer32); ok {
+		return ut.Untranspose(), true
+	}
+	return a, false
+}
+
+// use32 returns a float32 slice with l elements, using f if it
+// has the necessary capacity, otherwise creating a new slice.
+func use32(f []float32, l int) []float32 {
+	if l <= cap(f) {
+		return f[:l]
+	}
+	return make([]float32, l)
+}
+
+// useZeroed32 returns a float32 slice with l elements, using f if it
+// has the necessary capacity, otherwise creating a new slice. The
+// elements of the returned slice are guaranteed to be zero.
+func useZeroed32(f []float32, l int) []float32 {
+	if l <= cap(f) {
+		f = f[:l]
+		zero32(f)
+		return f
+	}
+	return make([]float32, l)
+}
+
+// zero32 zeros the given slice's elements.
+func zero32(f []float32) {
+	for i := range f {
+		f[i] = 0
+	}
+}
+
+// Equal32 returns whether the single precision matrices a and b have the same
+// size and are element-wise equal.
+func Equal32(a, b Matrix32) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			if a.At(i, j) != b.At(i, j) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// EqualApprox32 returns whether the single precision matrices a and b have the
+// same size and contain all equal elements with tolerance for element-wise
+// equality specified by epsilon. Matrices with non-equal shapes are not equal.
+func EqualApprox32(a, b Matrix32, epsilon float32) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			if !equalWithinAbsOrRel32(a.At(i, j), b.At(i, j), epsilon, epsilon) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// equalWithinAbsOrRel32 returns true if a and b are equal to within the
+// absolute or relative tolerances.
+func equalWithinAbsOrRel32(a, b, absTol, relTol float32) bool {
+	if a == b {
+		return true
+	}
+	delta := abs32(a - b)
+	if delta <= absTol {
+		return true
+	}
+	return delta/max32(abs32(a), abs32(b)) <= relTol
+}
+
+func abs32(a float32) float32 {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
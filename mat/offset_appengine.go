@@ -10,6 +10,10 @@ import "reflect"
 
 var sizeOfFloat64 = int(reflect.TypeOf(float64(0)).Size())
 
+var sizeOfComplex128 = int(reflect.TypeOf(complex128(0)).Size())
+
+var sizeOfFloat32 = int(reflect.TypeOf(float32(0)).Size())
+
 // offset returns the number of float64 values b[0] is after a[0].
 func offset(a, b []float64) int {
 	va0 := reflect.ValueOf(a).Index(0)
@@ -22,3 +26,29 @@ func offset(a, b []float64) int {
 	// move. See https://golang.org/issue/12445.
 	return int(vb0.UnsafeAddr()-va0.UnsafeAddr()) / sizeOfFloat64
 }
+
+// offsetComplex returns the number of complex128 values b[0] is after a[0].
+func offsetComplex(a, b []complex128) int {
+	va0 := reflect.ValueOf(a).Index(0)
+	vb0 := reflect.ValueOf(b).Index(0)
+	if va0.Addr() == vb0.Addr() {
+		return 0
+	}
+	// This expression must be atomic with respect to GC moves.
+	// At this stage this is true, because the GC does not
+	// move. See https://golang.org/issue/12445.
+	return int(vb0.UnsafeAddr()-va0.UnsafeAddr()) / sizeOfComplex128
+}
+
+// offset32 returns the number of float32 values b[0] is after a[0].
+func offset32(a, b []float32) int {
+	va0 := reflect.ValueOf(a).Index(0)
+	vb0 := reflect.ValueOf(b).Index(0)
+	if va0.Addr() == vb0.Addr() {
+		return 0
+	}
+	// This expression must be atomic with respect to GC moves.
+	// At this stage this is true, because the GC does not
+	// move. See https://golang.org/issue/12445.
+	return int(vb0.UnsafeAddr()-va0.UnsafeAddr()) / sizeOfFloat32
+}
@@ -0,0 +1,177 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badPivotedCholesky = "mat: invalid pivoted Cholesky factorization"
+
+// PivotedCholesky is a type for creating and using the Cholesky factorization
+// with complete pivoting of a symmetric positive semidefinite matrix. The
+// pivoting makes the factorization rank revealing, so that a low-rank factor
+// of a semidefinite matrix can be computed.
+type PivotedCholesky struct {
+	chol *TriDense
+	piv  []int
+	rank int
+}
+
+// Factorize computes the Cholesky factorization with complete pivoting of the
+// n×n symmetric positive semidefinite matrix a. The factorization has the form
+//  P^T * A * P = U^T * U
+// where P is an n×n permutation matrix and U is an n×n upper triangular matrix
+// whose last n-rank rows are zero. The pivoting makes the diagonal elements of
+// U non-increasing.
+//
+// The factorization terminates when the largest remaining pivot is less than or
+// equal to tol, and the number of completed steps is the computed rank of A. If
+// tol is negative, n*eps*max(A[k,k]) is used instead. The first step is taken
+// whenever the largest diagonal element of A is positive.
+//
+// Factorize returns whether the computed rank is n, that is, whether A is
+// positive definite to the given tolerance. Unlike Cholesky.Factorize, the
+// factorization is valid also when Factorize returns false. Factorize does not
+// check that A is positive semidefinite.
+func (c *PivotedCholesky) Factorize(a Symmetric, tol float64) (ok bool) {
+	n := a.Symmetric()
+	if c.chol == nil {
+		c.chol = NewTriDense(n, Upper, nil)
+	} else {
+		c.chol = NewTriDense(n, Upper, use(c.chol.mat.Data, n*n))
+	}
+	copySymIntoTriangle(c.chol, a)
+	c.piv = useInt(c.piv, n)
+
+	sym := c.chol.asSymBlas()
+	work := getFloats(2*n, false)
+	_, c.rank, ok = lapack64.Pstrf(sym, c.piv, tol, work)
+	putFloats(work)
+
+	// The trailing rows of the triangle are not referenced by the
+	// factorization and contain undefined values on return from Pstrf.
+	for i := c.rank; i < n; i++ {
+		zero(c.chol.mat.Data[i*c.chol.mat.Stride+i : i*c.chol.mat.Stride+n])
+	}
+	return ok
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (c *PivotedCholesky) Reset() {
+	if c.chol != nil {
+		c.chol.Reset()
+	}
+	c.piv = c.piv[:0]
+	c.rank = 0
+}
+
+// isValid returns whether the receiver contains a factorization.
+func (c *PivotedCholesky) isValid() bool {
+	return c.chol != nil && !c.chol.IsZero()
+}
+
+// Symmetric returns the number of rows in the factorized matrix (this is also
+// the number of columns).
+func (c *PivotedCholesky) Symmetric() int {
+	if !c.isValid() {
+		panic(badPivotedCholesky)
+	}
+	return c.chol.mat.N
+}
+
+// Rank returns the computed rank of the factorized matrix.
+// Rank will panic if the receiver does not contain a factorization.
+func (c *PivotedCholesky) Rank() int {
+	if !c.isValid() {
+		panic(badPivotedCholesky)
+	}
+	return c.rank
+}
+
+// Pivot returns the pivot indices of the factorization. The element at row i
+// and column j of P^T * A * P is the element at row piv[i] and column piv[j]
+// of A. The transpose of the permutation matrix P can be constructed with
+// Dense.Permutation. If piv == nil, then new memory will be allocated,
+// otherwise the length of the input must be equal to the size of the
+// factorized matrix.
+// Pivot will panic if the receiver does not contain a factorization.
+func (c *PivotedCholesky) Pivot(piv []int) []int {
+	if !c.isValid() {
+		panic(badPivotedCholesky)
+	}
+	n := len(c.piv)
+	if piv == nil {
+		piv = make([]int, n)
+	}
+	if len(piv) != n {
+		panic(badSliceLength)
+	}
+	copy(piv, c.piv)
+	return piv
+}
+
+// UTo extracts the n×n upper triangular matrix U from a pivoted Cholesky
+// decomposition into dst and returns the result. If dst is nil a new
+// TriDense is allocated.
+//  P^T * A * P = U^T * U.
+// UTo will panic if the receiver does not contain a factorization.
+func (c *PivotedCholesky) UTo(dst *TriDense) *TriDense {
+	if !c.isValid() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	if dst == nil {
+		dst = NewTriDense(n, Upper, make([]float64, n*n))
+	} else {
+		dst.reuseAs(n, Upper)
+	}
+	dst.Copy(c.chol)
+	return dst
+}
+
+// RawU returns the Triangular matrix used to store the pivoted Cholesky
+// decomposition of the original matrix A. The last n-rank rows of the matrix
+// are zero. The returned matrix should not be modified. If it is modified, the
+// decomposition is invalid and should not be used.
+func (c *PivotedCholesky) RawU() Triangular {
+	return c.chol
+}
+
+// FactorTo extracts the n×rank low-rank factor F of the factorized matrix into
+// dst and returns the result. F satisfies
+//  A ≈ F * F^T,
+// where the approximation is exact if A is positive semidefinite of the
+// computed rank, and the row piv[i] of F is the column i of the leading rank
+// rows of U. If dst is nil a new Dense is allocated. FactorTo will panic if
+// the receiver does not contain a factorization or if the computed rank is
+// zero.
+func (c *PivotedCholesky) FactorTo(dst *Dense) *Dense {
+	if !c.isValid() {
+		panic(badPivotedCholesky)
+	}
+	if c.rank == 0 {
+		panic(ErrZeroLength)
+	}
+	n := c.chol.mat.N
+	if dst == nil {
+		dst = NewDense(n, c.rank, nil)
+	} else {
+		dst.reuseAs(n, c.rank)
+	}
+	u := c.chol.mat
+	for i, p := range c.piv {
+		row := dst.rawRowView(p)
+		for j := range row {
+			if j <= i {
+				row[j] = u.Data[j*u.Stride+i]
+			} else {
+				row[j] = 0
+			}
+		}
+	}
+	return dst
+}
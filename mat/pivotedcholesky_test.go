@@ -0,0 +1,115 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestPivotedCholesky(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, rank int
+	}{
+		{1, 1},
+		{5, 5},
+		{5, 3},
+		{10, 1},
+		{10, 7},
+		{80, 80},
+		{80, 40},
+		{150, 100},
+	} {
+		n, rank := test.n, test.rank
+
+		// Construct a positive semidefinite matrix A = B * B^T of the
+		// given rank.
+		b := NewDense(n, rank, nil)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = rnd.NormFloat64()
+		}
+		a := NewSymDense(n, nil)
+		a.SymOuterK(1, b)
+
+		var chol PivotedCholesky
+		ok := chol.Factorize(a, -1)
+		if ok != (rank == n) {
+			t.Errorf("unexpected ok for n = %v, rank = %v: got %v", n, rank, ok)
+		}
+		if got := chol.Rank(); got != rank {
+			t.Errorf("unexpected rank for n = %v: got %v, want %v", n, got, rank)
+			continue
+		}
+
+		u := chol.UTo(nil)
+		if !Equal(u, chol.RawU()) {
+			t.Errorf("UTo does not match RawU: n = %v, rank = %v", n, rank)
+		}
+		for i := 1; i < n; i++ {
+			if u.At(i, i) > u.At(i-1, i-1) {
+				t.Errorf("diagonal of U is not non-increasing: n = %v, rank = %v", n, rank)
+				break
+			}
+		}
+		for i := rank; i < n; i++ {
+			for j := i; j < n; j++ {
+				if u.At(i, j) != 0 {
+					t.Errorf("trailing rows of U are not zero: n = %v, rank = %v", n, rank)
+				}
+			}
+		}
+
+		var p, pap, utu Dense
+		p.Permutation(n, chol.Pivot(nil))
+		pap.Product(&p, a, p.T())
+		utu.Mul(u.T(), u)
+		if !EqualApprox(&utu, &pap, 1e-12*float64(n)) {
+			t.Errorf("U^T*U does not equal P^T*A*P: n = %v, rank = %v", n, rank)
+		}
+
+		f := chol.FactorTo(nil)
+		if r, c := f.Dims(); r != n || c != rank {
+			t.Errorf("unexpected shape of factor: got %v×%v, want %v×%v", r, c, n, rank)
+			continue
+		}
+		var fft Dense
+		fft.Mul(f, f.T())
+		if !EqualApprox(&fft, a, 1e-12*float64(n)) {
+			t.Errorf("F*F^T does not equal A: n = %v, rank = %v", n, rank)
+		}
+	}
+}
+
+func TestPivotedCholeskyTol(t *testing.T) {
+	// A matrix with eigenvalues spread over many orders of magnitude is
+	// positive definite, but its computed rank depends on the tolerance.
+	a := NewSymDense(4, []float64{
+		1, 0, 0, 0,
+		0, 1e-4, 0, 0,
+		0, 0, 1e-8, 0,
+		0, 0, 0, 1e-12,
+	})
+	for _, test := range []struct {
+		tol  float64
+		rank int
+	}{
+		{-1, 4},
+		{1e-13, 4},
+		{1e-10, 3},
+		{1e-6, 2},
+		{1e-2, 1},
+	} {
+		var chol PivotedCholesky
+		ok := chol.Factorize(a, test.tol)
+		if ok != (test.rank == 4) {
+			t.Errorf("unexpected ok for tol = %v: got %v", test.tol, ok)
+		}
+		if got := chol.Rank(); got != test.rank {
+			t.Errorf("unexpected rank for tol = %v: got %v, want %v", test.tol, got, test.rank)
+		}
+	}
+}
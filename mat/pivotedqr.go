@@ -0,0 +1,300 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badPivotedQR = "mat: invalid pivoted QR factorization"
+
+// PivotedQR is a type for creating and using the QR factorization with column
+// pivoting of a matrix. The column pivoting makes the factorization rank
+// revealing, so that the numerical rank of a matrix can be estimated and
+// rank-deficient least squares problems can be solved.
+type PivotedQR struct {
+	qr   *Dense
+	tau  []float64
+	piv  []int
+	cond float64
+}
+
+func (qr *PivotedQR) updateCond(norm lapack.MatrixNorm) {
+	// See the comment in QR.updateCond. The condition number is
+	// estimated from the leading min(m,n)×min(m,n) block of R.
+	m, n := qr.qr.Dims()
+	k := min(m, n)
+	qr.cond = qr.condR(k, norm)
+}
+
+// condR returns the estimated condition number of the leading k×k block
+// of R in the given norm.
+func (qr *PivotedQR) condR(k int, norm lapack.MatrixNorm) float64 {
+	work := getFloats(3*k, false)
+	iwork := getInts(k, false)
+	r := qr.qr.asTriDense(k, blas.NonUnit, blas.Upper)
+	v := lapack64.Trcon(norm, r.mat, work, iwork)
+	putFloats(work)
+	putInts(iwork)
+	return 1 / v
+}
+
+// Factorize computes the QR factorization with column pivoting of the m×n
+// matrix a. The factorization always exists even if a is rank deficient.
+//
+// The pivoted QR decomposition is a factorization of the matrix A such that
+//  A * P = Q * R
+// where P is an n×n permutation matrix, Q is an m×m orthonormal matrix and R
+// is an m×n upper trapezoidal matrix. The columns of A are permuted so that the
+// magnitudes of the diagonal elements of R are non-increasing. P, Q and R can
+// be extracted using the Pivot, QTo and RTo methods.
+func (qr *PivotedQR) Factorize(a Matrix) {
+	qr.factorize(a, CondNorm)
+}
+
+func (qr *PivotedQR) factorize(a Matrix, norm lapack.MatrixNorm) {
+	m, n := a.Dims()
+	k := min(m, n)
+	if qr.qr == nil {
+		qr.qr = &Dense{}
+	}
+	qr.qr.Clone(a)
+	qr.tau = make([]float64, k)
+	qr.piv = make([]int, n)
+	// All columns are free.
+	for i := range qr.piv {
+		qr.piv[i] = -1
+	}
+	work := []float64{0}
+	lapack64.Geqp3(qr.qr.mat, qr.piv, qr.tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Geqp3(qr.qr.mat, qr.piv, qr.tau, work, len(work))
+	putFloats(work)
+	qr.updateCond(norm)
+}
+
+// isValid returns whether the receiver contains a factorization.
+func (qr *PivotedQR) isValid() bool {
+	return qr.qr != nil && !qr.qr.IsZero()
+}
+
+// reflectors returns the storage of the elementary reflectors defining Q
+// as an m×min(m,n) general matrix.
+func (qr *PivotedQR) reflectors() blas64.General {
+	a := qr.qr.mat
+	a.Cols = len(qr.tau)
+	return a
+}
+
+// Cond returns the condition number for the factorized matrix.
+// Cond will panic if the receiver does not contain a factorization.
+func (qr *PivotedQR) Cond() float64 {
+	if !qr.isValid() {
+		panic(badPivotedQR)
+	}
+	return qr.cond
+}
+
+// Rank returns the numerical rank of the factorized matrix, the number of
+// leading diagonal elements of R whose magnitude is greater than rcond times
+// the magnitude of the first diagonal element. If rcond is negative, machine
+// precision is used instead. Rank will panic if the receiver does not contain
+// a factorization.
+func (qr *PivotedQR) Rank(rcond float64) int {
+	if !qr.isValid() {
+		panic(badPivotedQR)
+	}
+	if rcond < 0 {
+		rcond = 1.0 / (1 << 53)
+	}
+	thr := rcond * math.Abs(qr.qr.mat.Data[0])
+	var rank int
+	for i := range qr.tau {
+		if math.Abs(qr.qr.mat.Data[i*qr.qr.mat.Stride+i]) <= thr {
+			break
+		}
+		rank++
+	}
+	return rank
+}
+
+// Pivot returns the column pivot indices of the factorization. The jth column
+// of A * P is the piv[j]th column of A. The transpose of the permutation
+// matrix P can be constructed with Dense.Permutation. If piv == nil, then new
+// memory will be allocated, otherwise the length of the input must be equal
+// to the number of columns of the factorized matrix.
+// Pivot will panic if the receiver does not contain a factorization.
+func (qr *PivotedQR) Pivot(piv []int) []int {
+	if !qr.isValid() {
+		panic(badPivotedQR)
+	}
+	n := len(qr.piv)
+	if piv == nil {
+		piv = make([]int, n)
+	}
+	if len(piv) != n {
+		panic(badSliceLength)
+	}
+	copy(piv, qr.piv)
+	return piv
+}
+
+// RTo extracts the m×n upper trapezoidal matrix from a pivoted QR
+// decomposition. If dst is nil, a new matrix is allocated. The resulting
+// dst matrix is returned.
+// RTo will panic if the receiver does not contain a factorization.
+func (qr *PivotedQR) RTo(dst *Dense) *Dense {
+	if !qr.isValid() {
+		panic(badPivotedQR)
+	}
+
+	r, c := qr.qr.Dims()
+	if dst == nil {
+		dst = NewDense(r, c, nil)
+	} else {
+		dst.reuseAsZeroed(r, c)
+	}
+	for i := 0; i < len(qr.tau); i++ {
+		copy(dst.mat.Data[i*dst.mat.Stride+i:i*dst.mat.Stride+c], qr.qr.mat.Data[i*qr.qr.mat.Stride+i:i*qr.qr.mat.Stride+c])
+	}
+	return dst
+}
+
+// QTo extracts the m×m orthonormal matrix Q from a pivoted QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting Q matrix is returned.
+// QTo will panic if the receiver does not contain a factorization.
+func (qr *PivotedQR) QTo(dst *Dense) *Dense {
+	if !qr.isValid() {
+		panic(badPivotedQR)
+	}
+
+	r, _ := qr.qr.Dims()
+	if dst == nil {
+		dst = NewDense(r, r, nil)
+	} else {
+		dst.reuseAsZeroed(r, r)
+	}
+
+	// Set Q = I.
+	for i := 0; i < r*r; i += r + 1 {
+		dst.mat.Data[i] = 1
+	}
+
+	// Construct Q from the elementary reflectors.
+	a := qr.reflectors()
+	work := []float64{0}
+	lapack64.Ormqr(blas.Left, blas.NoTrans, a, qr.tau, dst.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, blas.NoTrans, a, qr.tau, dst.mat, work, len(work))
+	putFloats(work)
+
+	return dst
+}
+
+// SolveTo finds a basic solution to the least squares problem
+//  minimize over X |A * X - B|_F
+// where A is an m×n matrix represented in its pivoted QR factorized form and
+// is assumed to have the given rank. Typically rank is obtained from a call to
+// the Rank method. The solution is computed from the leading rank×rank block
+// R11 of R, and it has at most rank non-zero rows. If rank == n the basic
+// solution is the unique least squares solution.
+//
+// If R11 is singular or near-singular a Condition error is returned.
+// See the documentation for Condition for more information.
+// The solution matrix, X, is stored in place into dst.
+// SolveTo will panic if the receiver does not contain a factorization or if
+// rank is negative or greater than min(m,n).
+func (qr *PivotedQR) SolveTo(dst *Dense, rank int, b Matrix) error {
+	if !qr.isValid() {
+		panic(badPivotedQR)
+	}
+
+	r, c := qr.qr.Dims()
+	br, bc := b.Dims()
+	if r != br {
+		panic(ErrShape)
+	}
+	if rank < 0 || len(qr.tau) < rank {
+		panic("mat: rank out of range")
+	}
+	dst.reuseAs(c, bc)
+	if rank == 0 {
+		dst.Zero()
+		return nil
+	}
+
+	// Compute Q^T * B in independent storage so that overlap between dst
+	// and b need not be considered.
+	w := getWorkspace(r, bc, false)
+	w.Copy(b)
+	a := qr.reflectors()
+	work := []float64{0}
+	lapack64.Ormqr(blas.Left, blas.Trans, a, qr.tau, w.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, blas.Trans, a, qr.tau, w.mat, work, len(work))
+	putFloats(work)
+
+	// Solve R11 * Y = (Q^T * B)[:rank].
+	t := qr.qr.asTriDense(rank, blas.NonUnit, blas.Upper).mat
+	y := blas64.General{
+		Rows:   rank,
+		Cols:   bc,
+		Stride: w.mat.Stride,
+		Data:   w.mat.Data,
+	}
+	ok := lapack64.Trtrs(blas.NoTrans, t, y)
+	if !ok {
+		putWorkspace(w)
+		return Condition(math.Inf(1))
+	}
+
+	// Undo the column permutation, X[piv[i], :] = Y[i, :].
+	dst.Zero()
+	for i := 0; i < rank; i++ {
+		copy(dst.rawRowView(qr.piv[i]), w.rawRowView(i))
+	}
+	putWorkspace(w)
+
+	cond := qr.cond
+	if rank < len(qr.tau) {
+		cond = qr.condR(rank, CondNorm)
+	}
+	if cond > ConditionTolerance {
+		return Condition(cond)
+	}
+	return nil
+}
+
+// SolveVecTo finds a basic solution to the least squares problem
+//  minimize over x |A * x - b|_2.
+// See PivotedQR.SolveTo for the full documentation.
+// SolveVecTo will panic if the receiver does not contain a factorization.
+func (qr *PivotedQR) SolveVecTo(dst *VecDense, rank int, b Vector) error {
+	if !qr.isValid() {
+		panic(badPivotedQR)
+	}
+
+	_, c := qr.qr.Dims()
+	if _, bc := b.Dims(); bc != 1 {
+		panic(ErrShape)
+	}
+
+	bm := Matrix(b)
+	if rv, ok := b.(RawVectorer); ok {
+		bmat := rv.RawVector()
+		if dst != b {
+			dst.checkOverlap(bmat)
+		}
+		b := VecDense{mat: bmat}
+		bm = b.asDense()
+	}
+	dst.reuseAs(c)
+	return qr.SolveTo(dst.asDense(), rank, bm)
+}
@@ -0,0 +1,132 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestPivotedQR(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, rank int
+	}{
+		{5, 5, 5},
+		{10, 5, 5},
+		{5, 10, 5},
+		{1, 1, 1},
+		{6, 6, 3},
+		{10, 6, 4},
+		{6, 10, 2},
+	} {
+		m, n := test.m, test.n
+		a := randRankDeficient(m, n, test.rank, rnd)
+
+		var qr PivotedQR
+		qr.Factorize(a)
+		q := qr.QTo(nil)
+		if !isOrthonormal(q, 1e-10) {
+			t.Errorf("Q is not orthonormal: m = %v, n = %v", m, n)
+		}
+		r := qr.RTo(nil)
+		for i := 0; i < m; i++ {
+			for j := 0; j < min(i, n); j++ {
+				if r.At(i, j) != 0 {
+					t.Errorf("R is not upper trapezoidal: m = %v, n = %v", m, n)
+				}
+			}
+		}
+		for i := 1; i < min(m, n); i++ {
+			if math.Abs(r.At(i, i)) > math.Abs(r.At(i-1, i-1))*(1+1e-14) {
+				t.Errorf("diagonal of R is not non-increasing in magnitude: m = %v, n = %v", m, n)
+			}
+		}
+
+		var p, ap, qrm Dense
+		p.Permutation(n, qr.Pivot(nil))
+		ap.Mul(a, p.T())
+		qrm.Mul(q, r)
+		if !EqualApprox(&qrm, &ap, 1e-12) {
+			t.Errorf("Q*R does not equal A*P: m = %v, n = %v", m, n)
+		}
+
+		if rank := qr.Rank(1e-10); rank != test.rank {
+			t.Errorf("unexpected rank: m = %v, n = %v, got %v, want %v", m, n, rank, test.rank)
+		}
+	}
+}
+
+func TestPivotedQRSolveTo(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, rank, bc int
+	}{
+		{5, 5, 5, 1},
+		{10, 5, 5, 3},
+		{8, 8, 5, 2},
+		{10, 6, 3, 1},
+		{6, 10, 4, 2},
+		{6, 10, 6, 3},
+	} {
+		m, n, bc := test.m, test.n, test.bc
+		a := randRankDeficient(m, n, test.rank, rnd)
+		b := randNormDense(m, bc, rnd)
+
+		var qr PivotedQR
+		qr.Factorize(a)
+		rank := qr.Rank(1e-10)
+		var x Dense
+		err := qr.SolveTo(&x, rank, b)
+		if err != nil {
+			t.Errorf("unexpected error: m = %v, n = %v: %v", m, n, err)
+			continue
+		}
+
+		// The basic solution has at most rank non-zero rows.
+		var nonzero int
+		for i := 0; i < n; i++ {
+			if Norm(x.RowView(i), 2) != 0 {
+				nonzero++
+			}
+		}
+		if nonzero > rank {
+			t.Errorf("basic solution has too many non-zero rows: m = %v, n = %v, got %v, want at most %v", m, n, nonzero, rank)
+		}
+
+		// The solution satisfies the normal equations A^T * (A*X - B) = 0.
+		var res, grad Dense
+		res.Mul(a, &x)
+		res.Sub(&res, b)
+		grad.Mul(a.T(), &res)
+		if Norm(&grad, 1) > 1e-10*Norm(a, 1)*Norm(b, 1) {
+			t.Errorf("solution does not satisfy the normal equations: m = %v, n = %v", m, n)
+		}
+
+		if m >= n && rank == n {
+			var want Dense
+			err = want.Solve(a, b)
+			if err != nil {
+				t.Errorf("unexpected Solve error: m = %v, n = %v: %v", m, n, err)
+			}
+			if !EqualApprox(&x, &want, 1e-12) {
+				t.Errorf("full rank solution mismatch: m = %v, n = %v", m, n)
+			}
+		}
+
+		if bc == 1 {
+			var xv VecDense
+			err = qr.SolveVecTo(&xv, rank, b.ColView(0))
+			if err != nil {
+				t.Errorf("unexpected SolveVecTo error: m = %v, n = %v: %v", m, n, err)
+			}
+			if !EqualApprox(&xv, x.ColView(0), 1e-14) {
+				t.Errorf("SolveVecTo mismatch: m = %v, n = %v", m, n)
+			}
+		}
+	}
+}
@@ -20,6 +20,12 @@ type QR struct {
 	qr   *Dense
 	tau  []float64
 	cond float64
+
+	// q holds the explicit m×m orthogonal factor after the factorization
+	// has been modified by one of the updating methods. In that case the
+	// upper triangle of qr holds R, its strictly lower triangle is zero
+	// and tau is not used.
+	q *Dense
 }
 
 func (qr *QR) updateCond(norm lapack.MatrixNorm) {
@@ -59,6 +65,9 @@ func (qr *QR) factorize(a Matrix, norm lapack.MatrixNorm) {
 	if qr.qr == nil {
 		qr.qr = &Dense{}
 	}
+	if qr.q != nil {
+		qr.q.Reset()
+	}
 	qr.qr.Clone(a)
 	work := []float64{0}
 	qr.tau = make([]float64, k)
@@ -75,6 +84,33 @@ func (qr *QR) isValid() bool {
 	return qr.qr != nil && !qr.qr.IsZero()
 }
 
+// isExplicit returns whether the receiver holds the factor Q explicitly.
+func (qr *QR) isExplicit() bool {
+	return qr.q != nil && !qr.q.IsZero()
+}
+
+// mulQTo computes Q * w if trans is false and Q^T * w otherwise, storing the
+// result in place into w.
+func (qr *QR) mulQTo(trans bool, w *Dense) {
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	if qr.isExplicit() {
+		r, c := w.Dims()
+		tmp := getWorkspace(r, c, false)
+		blas64.Gemm(t, blas.NoTrans, 1, qr.q.mat, w.mat, 0, tmp.mat)
+		w.Copy(tmp)
+		putWorkspace(tmp)
+		return
+	}
+	work := []float64{0}
+	lapack64.Ormqr(blas.Left, t, qr.qr.mat, qr.tau, w.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, t, qr.qr.mat, qr.tau, w.mat, work, len(work))
+	putFloats(work)
+}
+
 // Cond returns the condition number for the factorized matrix.
 // Cond will panic if the receiver does not contain a factorization.
 func (qr *QR) Cond() float64 {
@@ -137,6 +173,10 @@ func (qr *QR) QTo(dst *Dense) *Dense {
 	} else {
 		dst.reuseAsZeroed(r, r)
 	}
+	if qr.isExplicit() {
+		dst.Copy(qr.q)
+		return dst
+	}
 
 	// Set Q = I.
 	for i := 0; i < r*r; i += r + 1 {
@@ -144,11 +184,7 @@ func (qr *QR) QTo(dst *Dense) *Dense {
 	}
 
 	// Construct Q from the elementary reflectors.
-	work := []float64{0}
-	lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, dst.mat, work, -1)
-	work = getFloats(int(work[0]), false)
-	lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, dst.mat, work, len(work))
-	putFloats(work)
+	qr.mulQTo(false, dst)
 
 	return dst
 }
@@ -199,17 +235,9 @@ func (qr *QR) SolveTo(dst *Dense, trans bool, b Matrix) error {
 		for i := c; i < r; i++ {
 			zero(w.mat.Data[i*w.mat.Stride : i*w.mat.Stride+bc])
 		}
-		work := []float64{0}
-		lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, w.mat, work, -1)
-		work = getFloats(int(work[0]), false)
-		lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, w.mat, work, len(work))
-		putFloats(work)
+		qr.mulQTo(false, w)
 	} else {
-		work := []float64{0}
-		lapack64.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, w.mat, work, -1)
-		work = getFloats(int(work[0]), false)
-		lapack64.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, w.mat, work, len(work))
-		putFloats(work)
+		qr.mulQTo(true, w)
 
 		ok := lapack64.Trtrs(blas.NoTrans, t, w.mat)
 		if !ok {
@@ -258,3 +286,298 @@ func (qr *QR) SolveVecTo(dst *VecDense, trans bool, b Vector) error {
 	return qr.SolveTo(dst.asDense(), trans, bm)
 
 }
+
+// explicitFactors returns newly allocated copies of the explicit factors Q
+// and R of the factorization held by the receiver.
+func (qr *QR) explicitFactors() (q, r *Dense) {
+	return qr.QTo(nil), qr.RTo(nil)
+}
+
+// setExplicit stores the explicit factors q and r into the receiver and
+// updates the condition number.
+func (qr *QR) setExplicit(q, r *Dense) {
+	qr.q = q
+	qr.qr = r
+	qr.tau = qr.tau[:0]
+	qr.updateCond(CondNorm)
+}
+
+// rotRows applies the plane rotation defined by c and s to rows i and k of
+// a, starting at column j.
+func rotRows(a *Dense, i, k, j int, c, s float64) {
+	n := a.mat.Cols - j
+	if n <= 0 {
+		return
+	}
+	blas64.Rot(
+		blas64.Vector{N: n, Data: a.mat.Data[i*a.mat.Stride+j:], Inc: 1},
+		blas64.Vector{N: n, Data: a.mat.Data[k*a.mat.Stride+j:], Inc: 1},
+		c, s)
+}
+
+// rotCols applies the plane rotation defined by c and s to columns i and k
+// of a.
+func rotCols(a *Dense, i, k int, c, s float64) {
+	blas64.Rot(
+		blas64.Vector{N: a.mat.Rows, Data: a.mat.Data[i:], Inc: a.mat.Stride},
+		blas64.Vector{N: a.mat.Rows, Data: a.mat.Data[k:], Inc: a.mat.Stride},
+		c, s)
+}
+
+// RankOne updates a QR factorization as if a rank-one update had been applied
+// to the original matrix A, storing the result into the receiver. That is, if
+// in the original QR decomposition Q * R = A, in the updated decomposition
+//  Q * R = A + alpha * x * y^T.
+// The length of x must equal the number of rows of A and the length of y
+// must equal the number of columns of A.
+//
+// RankOne updates the factorization in O(m²) time using Givens rotations when
+// orig holds the factor Q explicitly, as it does after any of the updating
+// methods. Directly after Factorize, Q must first be formed from the
+// Householder reflectors, which takes O(m²n) time once. The updated factor Q
+// is held explicitly, so subsequent updates of the receiver take O(m²) time.
+// RankOne will panic if orig does not contain a factorization.
+func (qr *QR) RankOne(orig *QR, alpha float64, x, y Vector) {
+	if !orig.isValid() {
+		panic(badQR)
+	}
+	m, n := orig.qr.Dims()
+	if r, c := x.Dims(); r != m || c != 1 {
+		panic(ErrShape)
+	}
+	if r, c := y.Dims(); r != n || c != 1 {
+		panic(ErrShape)
+	}
+
+	// The algorithm is described in section 6.5.1 of
+	//  G. H. Golub, C. F. Van Loan: Matrix Computations. 4th edition.
+	//  Johns Hopkins University Press (2013).
+	q, r := orig.explicitFactors()
+
+	// Compute w = alpha * Q^T * x so that A + alpha*x*y^T = Q * (R + w*y^T).
+	var w VecDense
+	w.MulVec(q.T(), x)
+	w.ScaleVec(alpha, &w)
+	wd := w.RawVector().Data
+
+	// Reduce w to a multiple of e_0 from the bottom up, which transforms R
+	// into upper Hessenberg form.
+	for k := m - 1; k > 0; k-- {
+		c, s, rr, _ := blas64.Rotg(wd[k-1], wd[k])
+		wd[k-1] = rr
+		wd[k] = 0
+		rotRows(r, k-1, k, k-1, c, s)
+		rotCols(q, k-1, k, c, s)
+	}
+
+	// Add the rank-one term, which only modifies the first row.
+	for j := 0; j < n; j++ {
+		r.mat.Data[j] += wd[0] * y.AtVec(j)
+	}
+
+	// Restore the upper Hessenberg matrix to triangular form.
+	for k := 0; k < min(m-1, n); k++ {
+		c, s, rr, _ := blas64.Rotg(r.at(k, k), r.at(k+1, k))
+		r.set(k, k, rr)
+		r.set(k+1, k, 0)
+		rotRows(r, k, k+1, k+1, c, s)
+		rotCols(q, k, k+1, c, s)
+	}
+	qr.setExplicit(q, r)
+}
+
+// InsertCol updates a QR factorization as if the column x had been inserted
+// into the original matrix A before column j, storing the result into the
+// receiver. If A is m×n, the updated matrix is m×(n+1) and j must be in
+// [0, n]. The length of x must equal m and m must be greater than n.
+//
+// InsertCol updates the factorization in O(m²) time using Givens rotations
+// when orig holds the factor Q explicitly. Otherwise, directly after Factorize,
+// Q is first formed in O(m²n) time. The updated factor Q is held explicitly.
+// InsertCol will panic if orig does not contain a factorization.
+func (qr *QR) InsertCol(orig *QR, j int, x Vector) {
+	if !orig.isValid() {
+		panic(badQR)
+	}
+	m, n := orig.qr.Dims()
+	if j < 0 || n < j {
+		panic(ErrColAccess)
+	}
+	if r, c := x.Dims(); r != m || c != 1 {
+		panic(ErrShape)
+	}
+	if m < n+1 {
+		panic(ErrShape)
+	}
+
+	q, rOrig := orig.explicitFactors()
+
+	// Form R' = [R[:, :j], Q^T*x, R[:, j:]].
+	r := NewDense(m, n+1, nil)
+	if j > 0 {
+		r.Slice(0, m, 0, j).(*Dense).Copy(rOrig.Slice(0, m, 0, j))
+	}
+	if j < n {
+		r.Slice(0, m, j+1, n+1).(*Dense).Copy(rOrig.Slice(0, m, j, n))
+	}
+	var w VecDense
+	w.MulVec(q.T(), x)
+	r.SetCol(j, w.RawVector().Data)
+
+	// Zero the new column below the diagonal from the bottom up. Since the
+	// columns to the right of j have been shifted, no fill-in is created
+	// below the diagonal.
+	for k := m - 1; k > j; k-- {
+		c, s, rr, _ := blas64.Rotg(r.at(k-1, j), r.at(k, j))
+		r.set(k-1, j, rr)
+		r.set(k, j, 0)
+		rotRows(r, k-1, k, j+1, c, s)
+		rotCols(q, k-1, k, c, s)
+	}
+	qr.setExplicit(q, r)
+}
+
+// DeleteCol updates a QR factorization as if column j had been removed from
+// the original matrix A, storing the result into the receiver. If A is m×n,
+// the updated matrix is m×(n-1) and n must be greater than one.
+//
+// DeleteCol updates the factorization in O(m*n) time using Givens rotations
+// when orig holds the factor Q explicitly. Otherwise, directly after Factorize,
+// Q is first formed in O(m²n) time. The updated factor Q is held explicitly.
+// DeleteCol will panic if orig does not contain a factorization.
+func (qr *QR) DeleteCol(orig *QR, j int) {
+	if !orig.isValid() {
+		panic(badQR)
+	}
+	m, n := orig.qr.Dims()
+	if j < 0 || n <= j {
+		panic(ErrColAccess)
+	}
+	if n == 1 {
+		panic(ErrShape)
+	}
+
+	q, rOrig := orig.explicitFactors()
+
+	// Removing column j leaves the columns to its right in upper Hessenberg
+	// form.
+	r := NewDense(m, n-1, nil)
+	if j > 0 {
+		r.Slice(0, m, 0, j).(*Dense).Copy(rOrig.Slice(0, m, 0, j))
+	}
+	if j < n-1 {
+		r.Slice(0, m, j, n-1).(*Dense).Copy(rOrig.Slice(0, m, j+1, n))
+	}
+
+	for k := j; k < n-1; k++ {
+		c, s, rr, _ := blas64.Rotg(r.at(k, k), r.at(k+1, k))
+		r.set(k, k, rr)
+		r.set(k+1, k, 0)
+		rotRows(r, k, k+1, k+1, c, s)
+		rotCols(q, k, k+1, c, s)
+	}
+	qr.setExplicit(q, r)
+}
+
+// InsertRow updates a QR factorization as if the row x had been inserted into
+// the original matrix A before row i, storing the result into the receiver.
+// If A is m×n, the updated matrix is (m+1)×n and i must be in [0, m]. The
+// length of x must equal n.
+//
+// InsertRow updates the factorization in O(m²) time using Givens rotations
+// when orig holds the factor Q explicitly. Otherwise, directly after Factorize,
+// Q is first formed in O(m²n) time. The updated factor Q is held explicitly.
+// InsertRow will panic if orig does not contain a factorization.
+func (qr *QR) InsertRow(orig *QR, i int, x Vector) {
+	if !orig.isValid() {
+		panic(badQR)
+	}
+	m, n := orig.qr.Dims()
+	if i < 0 || m < i {
+		panic(ErrRowAccess)
+	}
+	if r, c := x.Dims(); r != n || c != 1 {
+		panic(ErrShape)
+	}
+
+	qOrig, rOrig := orig.explicitFactors()
+
+	// With the new row moved to the top, the updated matrix is
+	//  [x^T] = [1 0] [x^T]
+	//  [ A ]   [0 Q] [ R ],
+	// where the right factor H is upper Hessenberg. Undoing the row move
+	// permutes the rows of the left factor.
+	q := NewDense(m+1, m+1, nil)
+	q.set(i, 0, 1)
+	for k := 0; k < m; k++ {
+		dk := k
+		if k >= i {
+			dk++
+		}
+		copy(q.mat.Data[dk*q.mat.Stride+1:dk*q.mat.Stride+m+1], qOrig.rawRowView(k))
+	}
+	r := NewDense(m+1, n, nil)
+	for j := 0; j < n; j++ {
+		r.set(0, j, x.AtVec(j))
+	}
+	r.Slice(1, m+1, 0, n).(*Dense).Copy(rOrig)
+
+	for k := 0; k < min(m, n); k++ {
+		c, s, rr, _ := blas64.Rotg(r.at(k, k), r.at(k+1, k))
+		r.set(k, k, rr)
+		r.set(k+1, k, 0)
+		rotRows(r, k, k+1, k+1, c, s)
+		rotCols(q, k, k+1, c, s)
+	}
+	qr.setExplicit(q, r)
+}
+
+// DeleteRow updates a QR factorization as if row i had been removed from the
+// original matrix A, storing the result into the receiver. If A is m×n, the
+// updated matrix is (m-1)×n and m must be greater than n.
+//
+// DeleteRow updates the factorization in O(m²) time using Givens rotations
+// when orig holds the factor Q explicitly. Otherwise, directly after Factorize,
+// Q is first formed in O(m²n) time. The updated factor Q is held explicitly.
+// DeleteRow will panic if orig does not contain a factorization.
+func (qr *QR) DeleteRow(orig *QR, i int) {
+	if !orig.isValid() {
+		panic(badQR)
+	}
+	m, n := orig.qr.Dims()
+	if i < 0 || m <= i {
+		panic(ErrRowAccess)
+	}
+	if m-1 < n || m == 1 {
+		panic(ErrShape)
+	}
+
+	qOrig, rOrig := orig.explicitFactors()
+
+	// Rotate the i-th row of Q into a multiple of e_0^T. Since Q is
+	// orthogonal, the first column of Q then becomes ±e_i and the rotations
+	// leave R in upper Hessenberg form.
+	qi := make([]float64, m)
+	copy(qi, qOrig.rawRowView(i))
+	for k := m - 1; k > 0; k-- {
+		c, s, rr, _ := blas64.Rotg(qi[k-1], qi[k])
+		qi[k-1] = rr
+		qi[k] = 0
+		rotRows(rOrig, k-1, k, k-1, c, s)
+		rotCols(qOrig, k-1, k, c, s)
+	}
+
+	// Removing row i and column 0 of Q, and row 0 of R gives the updated
+	// factors.
+	q := NewDense(m-1, m-1, nil)
+	for k := 0; k < m-1; k++ {
+		sk := k
+		if k >= i {
+			sk++
+		}
+		copy(q.rawRowView(k), qOrig.mat.Data[sk*qOrig.mat.Stride+1:sk*qOrig.mat.Stride+m])
+	}
+	r := NewDense(m-1, n, nil)
+	r.Copy(rOrig.Slice(1, m, 0, n))
+	qr.setExplicit(q, r)
+}
@@ -0,0 +1,195 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/lapack/lapack32"
+)
+
+const badQR32 = "mat: invalid single precision QR factorization"
+
+// QR32 is a type for creating and using the QR factorization of a single precision matrix.
+type QR32 struct {
+	qr  *Dense32
+	tau []float32
+}
+
+// Factorize computes the QR factorization of an m×n matrix a where
+// m >= n. The QR factorization always exists even if A is singular.
+//
+// The QR decomposition is a factorization of the matrix A such that A = Q * R.
+// The matrix Q is a orthogonal m×m matrix, and R is an m×n upper triangular matrix.
+// Q and R can be extracted using the QTo and RTo methods.
+func (qr *QR32) Factorize(a Matrix32) {
+	m, n := a.Dims()
+	if m < n {
+		panic(ErrShape)
+	}
+	k := min(m, n)
+	if qr.qr == nil {
+		qr.qr = &Dense32{}
+	}
+	qr.qr.Clone(a)
+	work := []float32{0}
+	qr.tau = make([]float32, k)
+	lapack32.Geqrf(qr.qr.mat, qr.tau, work, -1)
+
+	work = make([]float32, int(work[0]))
+	lapack32.Geqrf(qr.qr.mat, qr.tau, work, len(work))
+}
+
+// isValid returns whether the receiver contains a factorization.
+func (qr *QR32) isValid() bool {
+	return qr.qr != nil && !qr.qr.IsZero()
+}
+
+// RTo extracts the m×n upper trapezoidal matrix from a QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting dst matrix is returned.
+// RTo will panic if the receiver does not contain a factorization.
+func (qr *QR32) RTo(dst *Dense32) *Dense32 {
+	if !qr.isValid() {
+		panic(badQR32)
+	}
+
+	r, c := qr.qr.Dims()
+	if dst == nil {
+		dst = NewDense32(r, c, nil)
+	} else {
+		dst.reuseAsZeroed(r, c)
+	}
+
+	// Extract the upper triangular elements.
+	for i := 0; i < c; i++ {
+		for j := i; j < c; j++ {
+			dst.mat.Data[i*dst.mat.Stride+j] = qr.qr.mat.Data[i*qr.qr.mat.Stride+j]
+		}
+	}
+	return dst
+}
+
+// QTo extracts the m×m orthogonal matrix Q from a QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting Q matrix is returned.
+// QTo will panic if the receiver does not contain a factorization.
+func (qr *QR32) QTo(dst *Dense32) *Dense32 {
+	if !qr.isValid() {
+		panic(badQR32)
+	}
+
+	r, _ := qr.qr.Dims()
+	if dst == nil {
+		dst = NewDense32(r, r, nil)
+	} else {
+		dst.reuseAsZeroed(r, r)
+	}
+
+	// Set Q = I.
+	for i := 0; i < r*r; i += r + 1 {
+		dst.mat.Data[i] = 1
+	}
+
+	// Construct Q from the elementary reflectors.
+	work := []float32{0}
+	lapack32.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, dst.mat, work, -1)
+	work = make([]float32, int(work[0]))
+	lapack32.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, dst.mat, work, len(work))
+
+	return dst
+}
+
+// SolveTo finds a minimum-norm solution to a system of linear equations defined
+// by the matrices A and b, where A is an m×n matrix represented in its
+// QR factorized form. If A is exactly singular a Condition error is returned.
+//
+// The minimization problem solved depends on the input parameters.
+//  If trans == false, find X such that ||A*X - B||_2 is minimized.
+//  If trans == true, find the minimum norm solution of A^T * X = B.
+// The solution matrix, X, is stored in place into dst.
+// SolveTo will panic if the receiver does not contain a factorization.
+func (qr *QR32) SolveTo(dst *Dense32, trans bool, b Matrix32) error {
+	if !qr.isValid() {
+		panic(badQR32)
+	}
+
+	r, c := qr.qr.Dims()
+	br, bc := b.Dims()
+
+	// The QR solve algorithm stores the result in-place into the right hand side.
+	// The storage for the answer must be large enough to hold both b and x.
+	// However, this method's receiver must be the size of x. Copy b, and then
+	// copy the result into dst at the end.
+	if trans {
+		if c != br {
+			panic(ErrShape)
+		}
+		dst.reuseAs(r, bc)
+	} else {
+		if r != br {
+			panic(ErrShape)
+		}
+		dst.reuseAs(c, bc)
+	}
+	// Do not need to worry about overlap between dst and b because x has its
+	// own independent storage.
+	w := NewDense32(max(r, c), bc, nil)
+	w.Copy(b)
+	t := blas32.Triangular{
+		N:      c,
+		Stride: qr.qr.mat.Stride,
+		Data:   qr.qr.mat.Data,
+		Uplo:   blas.Upper,
+		Diag:   blas.NonUnit,
+	}
+	if trans {
+		ok := lapack32.Trtrs(blas.Trans, t, w.mat)
+		if !ok {
+			return Condition(math.Inf(1))
+		}
+		for i := c; i < r; i++ {
+			zero32(w.mat.Data[i*w.mat.Stride : i*w.mat.Stride+bc])
+		}
+		work := []float32{0}
+		lapack32.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, w.mat, work, -1)
+		work = make([]float32, int(work[0]))
+		lapack32.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, w.mat, work, len(work))
+	} else {
+		work := []float32{0}
+		lapack32.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, w.mat, work, -1)
+		work = make([]float32, int(work[0]))
+		lapack32.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, w.mat, work, len(work))
+
+		ok := lapack32.Trtrs(blas.NoTrans, t, w.mat)
+		if !ok {
+			return Condition(math.Inf(1))
+		}
+	}
+	// X was set above to be the correct size for the result.
+	dst.Copy(w)
+	return nil
+}
+
+// SolveVecTo finds a minimum-norm solution to a system of linear equations
+//  Ax = b.
+// See QR32.SolveTo for the full documentation.
+// SolveVecTo will panic if the receiver does not contain a factorization.
+func (qr *QR32) SolveVecTo(dst *VecDense32, trans bool, b Vector32) error {
+	if !qr.isValid() {
+		panic(badQR32)
+	}
+
+	r, c := qr.qr.Dims()
+	if _, bc := b.Dims(); bc != 1 {
+		panic(ErrShape)
+	}
+	if trans {
+		dst.reuseAs(r)
+	} else {
+		dst.reuseAs(c)
+	}
+	return qr.SolveTo(dst.asDense(), trans, b)
+}
@@ -0,0 +1,89 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestQR32(t *testing.T) {
+	const tol = 1e-4
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{3, 3},
+		{5, 3},
+		{10, 10},
+		{30, 7},
+	} {
+		m, n := test.m, test.n
+		a := randDense32(m, n, rnd)
+		for i := 0; i < n; i++ {
+			// Make the problem well conditioned.
+			a.set(i, i, a.at(i, i)+float32(n))
+		}
+		a64 := a.To64(nil)
+		var qr QR32
+		qr.Factorize(a)
+
+		q := qr.QTo(nil).To64(nil)
+		r := qr.RTo(nil).To64(nil)
+		var got Dense
+		got.Mul(q.T(), q)
+		if !EqualApprox(&got, eye(m), tol) {
+			t.Errorf("%d×%d: Q is not orthogonal", m, n)
+		}
+		for i := 0; i < m; i++ {
+			for j := 0; j < min(i, n); j++ {
+				if r.At(i, j) != 0 {
+					t.Errorf("%d×%d: R is not upper triangular", m, n)
+				}
+			}
+		}
+		got.Reset()
+		got.Mul(q, r)
+		if !EqualApprox(&got, a64, tol*float64(n)) {
+			t.Errorf("%d×%d: Q*R != A", m, n)
+		}
+
+		var qr64 QR
+		qr64.Factorize(a64)
+		for _, trans := range []bool{false, true} {
+			br := m
+			if trans {
+				br = n
+			}
+			b := randDense32(br, 2, rnd)
+			var x Dense32
+			err := qr.SolveTo(&x, trans, b)
+			if err != nil {
+				t.Errorf("%d×%d: unexpected error: %v", m, n, err)
+				continue
+			}
+			var want Dense
+			err = qr64.SolveTo(&want, trans, b.To64(nil))
+			if err != nil {
+				t.Fatalf("%d×%d: unexpected error from float64 solve: %v", m, n, err)
+			}
+			if !EqualApprox(x.To64(nil), &want, tol) {
+				t.Errorf("%d×%d, trans=%t: unexpected solution", m, n, trans)
+			}
+
+			var xv VecDense32
+			err = qr.SolveVecTo(&xv, trans, b.ColView(0))
+			if err != nil {
+				t.Errorf("%d×%d: unexpected error: %v", m, n, err)
+				continue
+			}
+			if !EqualApprox(xv.To64(nil), want.ColView(0), tol) {
+				t.Errorf("%d×%d, trans=%t: unexpected vector solution", m, n, trans)
+			}
+		}
+	}
+}
@@ -202,3 +202,190 @@ func TestQRSolveCondTo(t *testing.T) {
 		}
 	}
 }
+
+func TestQRUpdate(t *testing.T) {
+	const tol = 1e-12
+	rnd := rand.New(rand.NewSource(1))
+	randMat := func(m, n int) *Dense {
+		a := NewDense(m, n, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+		return a
+	}
+	randVec := func(n int) *VecDense {
+		v := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			v.SetVec(i, rnd.NormFloat64())
+		}
+		return v
+	}
+	// checkQR checks that qr is a valid QR factorization of want.
+	checkQR := func(name string, qr *QR, want *Dense) {
+		m, n := want.Dims()
+		q := qr.QTo(nil)
+		if !isOrthonormal(q, tol) {
+			t.Errorf("%s: %d×%d: Q is not orthonormal", name, m, n)
+		}
+		r := qr.RTo(nil)
+		if rr, rc := r.Dims(); rr != m || rc != n {
+			t.Errorf("%s: unexpected R dimensions: got %d×%d, want %d×%d", name, rr, rc, m, n)
+			return
+		}
+		for i := 0; i < m; i++ {
+			for j := 0; j < min(i, n); j++ {
+				if r.At(i, j) != 0 {
+					t.Errorf("%s: %d×%d: R is not upper triangular", name, m, n)
+					i = m
+					break
+				}
+			}
+		}
+		var got Dense
+		got.Mul(q, r)
+		if !EqualApprox(&got, want, tol) {
+			t.Errorf("%s: %d×%d: Q*R does not equal the updated matrix", name, m, n)
+		}
+
+		// The solution of a least squares problem must agree with a
+		// factorization computed from scratch.
+		b := randMat(m, 2)
+		var x, xWant Dense
+		err := qr.SolveTo(&x, false, b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			return
+		}
+		var qrWant QR
+		qrWant.Factorize(want)
+		err = qrWant.SolveTo(&xWant, false, b)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			return
+		}
+		if !EqualApprox(&x, &xWant, 1e-10) {
+			t.Errorf("%s: %d×%d: unexpected solution after update", name, m, n)
+		}
+		if math.Abs(qr.Cond()-qrWant.Cond()) > 1e-8*qrWant.Cond() {
+			t.Errorf("%s: %d×%d: unexpected condition number: got %v, want %v", name, m, n, qr.Cond(), qrWant.Cond())
+		}
+	}
+
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{3, 2},
+		{5, 5},
+		{10, 4},
+		{20, 19},
+	} {
+		m, n := test.m, test.n
+		a := randMat(m, n)
+		var orig QR
+		orig.Factorize(a)
+
+		// Rank-one update applied to both the compact and the explicit
+		// form of the factorization.
+		x := randVec(m)
+		y := randVec(n)
+		var want Dense
+		want.RankOne(a, 0.5, x, y)
+		var qr QR
+		qr.RankOne(&orig, 0.5, x, y)
+		checkQR("RankOne", &qr, &want)
+		x = randVec(m)
+		y = randVec(n)
+		want.RankOne(&want, -2, x, y)
+		qr.RankOne(&qr, -2, x, y)
+		checkQR("RankOne twice", &qr, &want)
+
+		// Column insertion and deletion.
+		if m > n {
+			for _, j := range []int{0, n / 2, n} {
+				c := randVec(m)
+				want := NewDense(m, n+1, nil)
+				for i := 0; i < m; i++ {
+					for k := 0; k < n+1; k++ {
+						switch {
+						case k < j:
+							want.Set(i, k, a.At(i, k))
+						case k == j:
+							want.Set(i, k, c.AtVec(i))
+						default:
+							want.Set(i, k, a.At(i, k-1))
+						}
+					}
+				}
+				var qr QR
+				qr.InsertCol(&orig, j, c)
+				checkQR("InsertCol", &qr, want)
+
+				qr.DeleteCol(&qr, j)
+				checkQR("InsertCol then DeleteCol", &qr, a)
+			}
+		}
+		if n > 1 {
+			for _, j := range []int{0, n / 2, n - 1} {
+				want := NewDense(m, n-1, nil)
+				for i := 0; i < m; i++ {
+					for k := 0; k < n-1; k++ {
+						if k < j {
+							want.Set(i, k, a.At(i, k))
+						} else {
+							want.Set(i, k, a.At(i, k+1))
+						}
+					}
+				}
+				var qr QR
+				qr.DeleteCol(&orig, j)
+				checkQR("DeleteCol", &qr, want)
+			}
+		}
+
+		// Row insertion and deletion.
+		for _, i := range []int{0, m / 2, m} {
+			r := randVec(n)
+			want := NewDense(m+1, n, nil)
+			for k := 0; k < m+1; k++ {
+				for j := 0; j < n; j++ {
+					switch {
+					case k < i:
+						want.Set(k, j, a.At(k, j))
+					case k == i:
+						want.Set(k, j, r.AtVec(j))
+					default:
+						want.Set(k, j, a.At(k-1, j))
+					}
+				}
+			}
+			var qr QR
+			qr.InsertRow(&orig, i, r)
+			checkQR("InsertRow", &qr, want)
+
+			qr.DeleteRow(&qr, i)
+			checkQR("InsertRow then DeleteRow", &qr, a)
+		}
+		if m > n {
+			for _, i := range []int{0, m / 2, m - 1} {
+				want := NewDense(m-1, n, nil)
+				for k := 0; k < m-1; k++ {
+					for j := 0; j < n; j++ {
+						if k < i {
+							want.Set(k, j, a.At(k, j))
+						} else {
+							want.Set(k, j, a.At(k+1, j))
+						}
+					}
+				}
+				var qr QR
+				qr.DeleteRow(&orig, i)
+				checkQR("DeleteRow", &qr, want)
+			}
+		}
+
+		// Refactorizing must discard the explicit form.
+		qr.Factorize(a)
+		checkQR("Factorize after update", &qr, a)
+	}
+}
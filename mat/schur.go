@@ -0,0 +1,304 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badNoSchurVect = "mat: Schur vectors not computed"
+
+// Schur is a type for creating and using the real Schur decomposition of a
+// square matrix.
+//
+// The real Schur decomposition of an n×n matrix A is
+//  A = Z * T * Z^T
+// where Z is an n×n orthogonal matrix of Schur vectors and T is an n×n upper
+// quasi-triangular matrix in Schur canonical form. T is block upper triangular
+// with 1×1 and 2×2 diagonal blocks. The 1×1 blocks hold the real eigenvalues
+// of A and each 2×2 block has equal diagonal elements and off-diagonal
+// elements of opposite sign, and holds a pair of complex conjugate
+// eigenvalues of A.
+//
+// The diagonal blocks of T can be reordered so that a selected set of
+// eigenvalues appears in the leading k×k block of T. The first k columns of Z
+// then form an orthonormal basis for the invariant subspace of A
+// corresponding to the selected eigenvalues.
+type Schur struct {
+	t *Dense
+	z *Dense
+
+	values []complex128
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (s *Schur) succFact() bool {
+	return s.t != nil && !s.t.IsZero()
+}
+
+// Factorize computes the real Schur decomposition of the square matrix a.
+// If vectors is true, the Schur vectors are also computed. Factorize panics
+// if a is not square.
+//
+// Factorize returns whether the decomposition succeeded. If the decomposition
+// failed, methods that require a successful factorization will panic.
+func (s *Schur) Factorize(a Matrix, vectors bool) (ok bool) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	n := r
+
+	if s.t == nil {
+		s.t = &Dense{}
+	}
+	s.t.Clone(a)
+
+	// Reduce A to upper Hessenberg form.
+	tau := getFloats(max(0, n-1), false)
+	defer putFloats(tau)
+	work := []float64{0}
+	lapack64.Gehrd(0, n-1, s.t.mat, tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Gehrd(0, n-1, s.t.mat, tau, work, len(work))
+	putFloats(work)
+
+	compz := lapack.SchurNone
+	var z blas64.General
+	if vectors {
+		if s.z == nil {
+			s.z = &Dense{}
+		}
+		s.z.Clone(s.t)
+		work = []float64{0}
+		lapack64.Orghr(0, n-1, s.z.mat, tau, work, -1)
+		work = getFloats(int(work[0]), false)
+		lapack64.Orghr(0, n-1, s.z.mat, tau, work, len(work))
+		putFloats(work)
+		compz = lapack.SchurOrig
+		z = s.z.mat
+	} else if s.z != nil {
+		s.z.Reset()
+	}
+
+	// Compute the Schur form of the Hessenberg matrix.
+	wr := getFloats(n, false)
+	defer putFloats(wr)
+	wi := getFloats(n, false)
+	defer putFloats(wi)
+	work = []float64{0}
+	lapack64.Hseqr(lapack.EigenvaluesAndSchur, compz, 0, n-1, s.t.mat, wr, wi, z, work, -1)
+	work = getFloats(int(work[0]), false)
+	unconverged := lapack64.Hseqr(lapack.EigenvaluesAndSchur, compz, 0, n-1, s.t.mat, wr, wi, z, work, len(work))
+	putFloats(work)
+	if unconverged != 0 {
+		s.t.Reset()
+		if s.z != nil {
+			s.z.Reset()
+		}
+		s.values = nil
+		return false
+	}
+
+	s.values = make([]complex128, n)
+	for i, v := range wr {
+		s.values[i] = complex(v, wi[i])
+	}
+	return true
+}
+
+// hasVectors returns whether the Schur vectors were computed.
+func (s *Schur) hasVectors() bool {
+	return s.z != nil && !s.z.IsZero()
+}
+
+// TTo extracts the n×n upper quasi-triangular matrix T in Schur canonical
+// form from a Schur decomposition. If dst is nil, a new matrix is allocated.
+// The resulting matrix T is returned.
+//
+// TTo will panic if the receiver does not contain a successful factorization.
+func (s *Schur) TTo(dst *Dense) *Dense {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	n, _ := s.t.Dims()
+	if dst == nil {
+		dst = NewDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(s.t)
+	return dst
+}
+
+// ZTo extracts the n×n orthogonal matrix Z of Schur vectors from a Schur
+// decomposition. If dst is nil, a new matrix is allocated. The resulting
+// matrix Z is returned.
+//
+// ZTo will panic if the receiver does not contain a successful factorization
+// or if the Schur vectors were not computed.
+func (s *Schur) ZTo(dst *Dense) *Dense {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	if !s.hasVectors() {
+		panic(badNoSchurVect)
+	}
+	n, _ := s.z.Dims()
+	if dst == nil {
+		dst = NewDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(s.z)
+	return dst
+}
+
+// Values extracts the eigenvalues of the factorized matrix in the order in
+// which they appear on the diagonal of T. Complex conjugate pairs appear
+// consecutively with the eigenvalue having positive imaginary part first.
+// If dst is non-nil, the values are stored in-place into dst. In this case
+// dst must have length n, otherwise Values will panic. If dst is nil, then a
+// new slice will be allocated of the proper length and filled with the
+// eigenvalues.
+//
+// Values panics if the Schur decomposition was not successful.
+func (s *Schur) Values(dst []complex128) []complex128 {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]complex128, len(s.values))
+	}
+	if len(dst) != len(s.values) {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(dst, s.values)
+	return dst
+}
+
+// Exchange reorders the Schur decomposition so that the diagonal block of T
+// starting at row ifst is moved to row ilst by a sequence of orthogonal
+// similarity transformations. If the Schur vectors were computed, they are
+// updated accordingly.
+//
+// If ifst points to the second row of a 2×2 block, the whole block is moved.
+// Exchange returns the row at which the moved block starts in its final
+// position, which may differ from ilst by one when 2×2 blocks are involved.
+//
+// If ok is false, two adjacent blocks were too close to swap because the
+// problem is very ill-conditioned. In this case T may have been partially
+// reordered but the decomposition remains valid.
+//
+// Exchange panics if the receiver does not contain a successful factorization,
+// or if ifst or ilst are out of range.
+func (s *Schur) Exchange(ifst, ilst int) (ilstOut int, ok bool) {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	n, _ := s.t.Dims()
+	if ifst < 0 || n <= ifst || ilst < 0 || n <= ilst {
+		panic(ErrIndexOutOfRange)
+	}
+	work := getFloats(n, false)
+	_, ilstOut, ok = s.trexc(ifst, ilst, work)
+	putFloats(work)
+	s.updateValues()
+	return ilstOut, ok
+}
+
+// Reorder reorders the Schur decomposition so that the eigenvalues for which
+// selected returns true are moved to the leading k×k diagonal block of T,
+// preserving their relative order. If either eigenvalue of a complex
+// conjugate pair is selected, both are moved. If the Schur vectors were
+// computed, they are updated accordingly and their first k columns form an
+// orthonormal basis for the invariant subspace corresponding to the selected
+// eigenvalues.
+//
+// Reorder returns k, the dimension of the invariant subspace. If ok is false,
+// two adjacent blocks were too close to swap because the problem is very
+// ill-conditioned. In this case T may have been partially reordered but the
+// decomposition remains valid, and k holds the number of eigenvalues that were
+// successfully moved to the leading block.
+//
+// Reorder panics if the receiver does not contain a successful factorization.
+func (s *Schur) Reorder(selected func(complex128) bool) (k int, ok bool) {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	n, _ := s.t.Dims()
+
+	// Evaluate the selection before any reordering since the computed
+	// eigenvalues may change slightly under the swaps.
+	sel := make([]bool, n)
+	for i, v := range s.values {
+		sel[i] = selected(v)
+	}
+
+	work := getFloats(n, false)
+	defer putFloats(work)
+	defer s.updateValues()
+	t := s.t.mat
+	for i := 0; i < n; {
+		size := 1
+		if i < n-1 && t.Data[(i+1)*t.Stride+i] != 0 {
+			size = 2
+		}
+		if sel[i] || (size == 2 && sel[i+1]) {
+			// Blocks past i are not affected by moving the block at
+			// row i towards the top of T.
+			if i != k {
+				_, _, ok = s.trexc(i, k, work)
+				if !ok {
+					return k, false
+				}
+			}
+			k += size
+		}
+		i += size
+	}
+	return k, true
+}
+
+// trexc calls Trexc on the receiver, updating the Schur vectors if they were
+// computed.
+func (s *Schur) trexc(ifst, ilst int, work []float64) (ifstOut, ilstOut int, ok bool) {
+	compq := lapack.UpdateSchurNone
+	var q blas64.General
+	if s.hasVectors() {
+		compq = lapack.UpdateSchur
+		q = s.z.mat
+	}
+	return lapack64.Trexc(compq, s.t.mat, q, ifst, ilst, work)
+}
+
+// updateValues recomputes the eigenvalues from the diagonal blocks of T in
+// Schur canonical form.
+func (s *Schur) updateValues() {
+	t := s.t.mat
+	n := t.Rows
+	for i := 0; i < n; {
+		a := t.Data[i*t.Stride+i]
+		if i == n-1 || t.Data[(i+1)*t.Stride+i] == 0 {
+			s.values[i] = complex(a, 0)
+			i++
+			continue
+		}
+		// A 2×2 block in standard form
+		//  [a b]
+		//  [c a]
+		// with b*c < 0 has eigenvalues a ± i*sqrt(|b|)*sqrt(|c|).
+		b := t.Data[i*t.Stride+i+1]
+		c := t.Data[(i+1)*t.Stride+i]
+		im := math.Sqrt(math.Abs(b)) * math.Sqrt(math.Abs(c))
+		s.values[i] = complex(a, im)
+		s.values[i+1] = complex(a, -im)
+		i += 2
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// isSchurCanonical returns whether t is upper quasi-triangular with 1×1 and
+// standardized 2×2 diagonal blocks.
+func isSchurCanonical(t *Dense) bool {
+	n, _ := t.Dims()
+	for i := 0; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			if t.At(i, j) != 0 {
+				return false
+			}
+		}
+	}
+	for i := 0; i < n-1; {
+		if t.At(i+1, i) == 0 {
+			i++
+			continue
+		}
+		if i+2 < n && t.At(i+2, i+1) != 0 {
+			// Consecutive non-zero subdiagonal elements.
+			return false
+		}
+		if t.At(i, i) != t.At(i+1, i+1) || t.At(i, i+1)*t.At(i+1, i) >= 0 {
+			return false
+		}
+		i += 2
+	}
+	return true
+}
+
+func sortedValues(v []complex128) []complex128 {
+	v = append([]complex128(nil), v...)
+	sort.Slice(v, func(i, j int) bool {
+		if real(v[i]) != real(v[j]) {
+			return real(v[i]) < real(v[j])
+		}
+		return imag(v[i]) < imag(v[j])
+	})
+	return v
+}
+
+func equalValuesApprox(a, b []complex128, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = sortedValues(a)
+	b = sortedValues(b)
+	for i := range a {
+		if cmplx.Abs(a[i]-b[i]) > tol*math.Max(1, cmplx.Abs(a[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSchur(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 31} {
+		a := randNormDense(n, n, rnd)
+		name := fmt.Sprintf("n=%d", n)
+
+		var schur Schur
+		if !schur.Factorize(a, true) {
+			t.Errorf("%s: unexpected Factorize failure", name)
+			continue
+		}
+		tm := schur.TTo(nil)
+		z := schur.ZTo(nil)
+
+		if !isSchurCanonical(tm) {
+			t.Errorf("%s: T not in Schur canonical form", name)
+		}
+		var ztz Dense
+		ztz.Mul(z.T(), z)
+		if !EqualApprox(&ztz, eye(n), 1e-13) {
+			t.Errorf("%s: Z not orthogonal", name)
+		}
+		var zt, got Dense
+		zt.Mul(z, tm)
+		got.Mul(&zt, z.T())
+		if !EqualApprox(&got, a, 1e-12) {
+			t.Errorf("%s: Z*T*Z^T does not equal A", name)
+		}
+
+		var eig Eigen
+		if !eig.Factorize(a, EigenNone) {
+			t.Fatalf("%s: unexpected Eigen failure", name)
+		}
+		values := schur.Values(nil)
+		if !equalValuesApprox(values, eig.Values(nil), 1e-10) {
+			t.Errorf("%s: unexpected eigenvalues:\ngot: %v\nwant:%v", name, values, eig.Values(nil))
+		}
+
+		var noVec Schur
+		if !noVec.Factorize(a, false) {
+			t.Errorf("%s: unexpected Factorize failure without vectors", name)
+			continue
+		}
+		if !EqualApprox(noVec.TTo(nil), tm, 1e-14) {
+			t.Errorf("%s: T depends on whether vectors are computed", name)
+		}
+		if panicked, _ := panics(func() { noVec.ZTo(nil) }); !panicked {
+			t.Errorf("%s: expected panic for ZTo without vectors", name)
+		}
+	}
+
+	var schur Schur
+	if panicked, _ := panics(func() { schur.Factorize(NewDense(3, 2, nil), true) }); !panicked {
+		t.Errorf("expected panic for non-square matrix")
+	}
+	if panicked, _ := panics(func() { schur.TTo(nil) }); !panicked {
+		t.Errorf("expected panic for TTo without factorization")
+	}
+}
+
+func TestSchurReorder(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 31} {
+		for _, test := range []struct {
+			name     string
+			selected func(complex128) bool
+		}{
+			{name: "stable", selected: func(v complex128) bool { return real(v) < 0 }},
+			{name: "unstable", selected: func(v complex128) bool { return real(v) >= 0 }},
+			{name: "complex", selected: func(v complex128) bool { return imag(v) > 0 }},
+			{name: "none", selected: func(v complex128) bool { return false }},
+			{name: "all", selected: func(v complex128) bool { return true }},
+		} {
+			name := fmt.Sprintf("n=%d,%s", n, test.name)
+			a := randNormDense(n, n, rnd)
+
+			var schur Schur
+			if !schur.Factorize(a, true) {
+				t.Errorf("%s: unexpected Factorize failure", name)
+				continue
+			}
+			before := schur.Values(nil)
+			var want int
+			for i := 0; i < n; i++ {
+				v := before[i]
+				if test.selected(v) || (imag(v) != 0 && test.selected(cmplx.Conj(v))) {
+					want++
+				}
+			}
+
+			k, ok := schur.Reorder(test.selected)
+			if !ok {
+				t.Errorf("%s: unexpected Reorder failure", name)
+				continue
+			}
+			if k != want {
+				t.Errorf("%s: unexpected subspace dimension: got %d want %d", name, k, want)
+			}
+
+			tm := schur.TTo(nil)
+			z := schur.ZTo(nil)
+			if !isSchurCanonical(tm) {
+				t.Errorf("%s: T not in Schur canonical form after Reorder", name)
+			}
+			var zt, got Dense
+			zt.Mul(z, tm)
+			got.Mul(&zt, z.T())
+			if !EqualApprox(&got, a, 1e-11) {
+				t.Errorf("%s: Z*T*Z^T does not equal A after Reorder", name)
+			}
+
+			after := schur.Values(nil)
+			if !equalValuesApprox(after, before, 1e-10) {
+				t.Errorf("%s: eigenvalues changed by Reorder", name)
+			}
+			for i, v := range after {
+				sel := test.selected(v) || (imag(v) != 0 && test.selected(cmplx.Conj(v)))
+				if sel != (i < k) {
+					t.Errorf("%s: eigenvalue %v at position %d with k=%d", name, v, i, k)
+				}
+			}
+
+			// Check that the leading k columns of Z span an invariant
+			// subspace, A * Z_k = Z_k * T_kk.
+			if k == 0 {
+				continue
+			}
+			zk := z.Slice(0, n, 0, k)
+			tk := tm.Slice(0, k, 0, k)
+			var az, zkt Dense
+			az.Mul(a, zk)
+			zkt.Mul(zk, tk)
+			if !EqualApprox(&az, &zkt, 1e-11) {
+				t.Errorf("%s: leading Schur vectors do not span an invariant subspace", name)
+			}
+		}
+	}
+}
+
+func TestSchurExchange(t *testing.T) {
+	// A block upper triangular matrix with known eigenvalues.
+	a := NewDense(4, 4, []float64{
+		1, 2, 3, 4,
+		0, 2, -1, 5,
+		0, 1, 2, 6,
+		0, 0, 0, 3,
+	})
+	var schur Schur
+	if !schur.Factorize(a, true) {
+		t.Fatalf("unexpected Factorize failure")
+	}
+	vals := schur.Values(nil)
+	last := vals[len(vals)-1]
+	ilst, ok := schur.Exchange(3, 0)
+	if !ok {
+		t.Fatalf("unexpected Exchange failure")
+	}
+	if ilst != 0 {
+		t.Errorf("unexpected final position: got %d want 0", ilst)
+	}
+	got := schur.Values(nil)
+	if cmplx.Abs(got[0]-last) > 1e-12 {
+		t.Errorf("unexpected leading eigenvalue after Exchange: got %v want %v", got[0], last)
+	}
+	tm := schur.TTo(nil)
+	z := schur.ZTo(nil)
+	var zt, res Dense
+	zt.Mul(z, tm)
+	res.Mul(&zt, z.T())
+	if !EqualApprox(&res, a, 1e-12) {
+		t.Errorf("Z*T*Z^T does not equal A after Exchange")
+	}
+	if panicked, _ := panics(func() { schur.Exchange(4, 0) }); !panicked {
+		t.Errorf("expected panic for out of range index")
+	}
+}
@@ -5,7 +5,9 @@
 package mat
 
 import (
+	"gonum.org/v1/gonum/blas/blas32"
 	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/blas/cblas128"
 )
 
 const (
@@ -66,6 +68,86 @@ func checkOverlap(a, b blas64.General) bool {
 	return false
 }
 
+// checkOverlapComplex is the complex128 equivalent of checkOverlap.
+func checkOverlapComplex(a, b cblas128.General) bool {
+	if cap(a.Data) == 0 || cap(b.Data) == 0 {
+		return false
+	}
+
+	off := offsetComplex(a.Data[:1], b.Data[:1])
+
+	if off == 0 {
+		// At least one element overlaps.
+		if a.Cols == b.Cols && a.Rows == b.Rows && a.Stride == b.Stride {
+			panic(regionIdentity)
+		}
+		panic(regionOverlap)
+	}
+
+	if off > 0 && len(a.Data) <= off {
+		// We know a is completely before b.
+		return false
+	}
+	if off < 0 && len(b.Data) <= -off {
+		// We know a is completely after b.
+		return false
+	}
+
+	if a.Stride != b.Stride {
+		// Too hard, so assume the worst.
+		panic(mismatchedStrides)
+	}
+
+	if off < 0 {
+		off = -off
+		a.Cols, b.Cols = b.Cols, a.Cols
+	}
+	if rectanglesOverlap(off, a.Cols, b.Cols, a.Stride) {
+		panic(regionOverlap)
+	}
+	return false
+}
+
+// checkOverlap32 is the float32 equivalent of checkOverlap.
+func checkOverlap32(a, b blas32.General) bool {
+	if cap(a.Data) == 0 || cap(b.Data) == 0 {
+		return false
+	}
+
+	off := offset32(a.Data[:1], b.Data[:1])
+
+	if off == 0 {
+		// At least one element overlaps.
+		if a.Cols == b.Cols && a.Rows == b.Rows && a.Stride == b.Stride {
+			panic(regionIdentity)
+		}
+		panic(regionOverlap)
+	}
+
+	if off > 0 && len(a.Data) <= off {
+		// We know a is completely before b.
+		return false
+	}
+	if off < 0 && len(b.Data) <= -off {
+		// We know a is completely after b.
+		return false
+	}
+
+	if a.Stride != b.Stride {
+		// Too hard, so assume the worst.
+		panic(mismatchedStrides)
+	}
+
+	if off < 0 {
+		off = -off
+		a.Cols, b.Cols = b.Cols, a.Cols
+	}
+	if rectanglesOverlap(off, a.Cols, b.Cols, a.Stride) {
+		panic(regionOverlap)
+	}
+	return false
+}
+
 func (m *Dense) checkOverlap(a blas64.General) bool {
 	return checkOverlap(m.RawMatrix(), a)
 }
@@ -88,6 +170,44 @@ func (m *Dense) checkOverlapMatrix(a Matrix) bool {
 	return m.checkOverlap(amat)
 }
 
+func (m *CDense) checkOverlap(a cblas128.General) bool {
+	return checkOverlapComplex(m.RawCMatrix(), a)
+}
+
+func (m *CDense) checkOverlapMatrix(a CMatrix) bool {
+	if m == a {
+		return false
+	}
+	var amat cblas128.General
+	switch a := a.(type) {
+	default:
+		return false
+	case RawCMatrixer:
+		amat = a.RawCMatrix()
+	}
+	return m.checkOverlap(amat)
+}
+
+func (m *Dense32) checkOverlap(a blas32.General) bool {
+	return checkOverlap32(m.RawMatrix(), a)
+}
+
+func (m *Dense32) checkOverlapMatrix(a Matrix32) bool {
+	if m == a {
+		return false
+	}
+	var amat blas32.General
+	switch a := a.(type) {
+	default:
+		return false
+	case RawMatrixer32:
+		amat = a.RawMatrix()
+	case *SymDense32:
+		amat = generalFromSymmetric32(a.RawSymmetric())
+	}
+	return m.checkOverlap(amat)
+}
+
 func (s *SymDense) checkOverlap(a blas64.General) bool {
 	return checkOverlap(generalFromSymmetric(s.RawSymmetric()), a)
 }
@@ -121,6 +241,21 @@ func generalFromSymmetric(a blas64.Symmetric) blas64.General {
 	}
 }
 
+func (s *SymDense32) checkOverlap(a blas32.General) bool {
+	return checkOverlap32(generalFromSymmetric32(s.RawSymmetric()), a)
+}
+
+// generalFromSymmetric32 returns a blas32.General with the backing
+// data and dimensions of a.
+func generalFromSymmetric32(a blas32.Symmetric) blas32.General {
+	return blas32.General{
+		Rows:   a.N,
+		Cols:   a.N,
+		Stride: a.Stride,
+		Data:   a.Data,
+	}
+}
+
 func (t *TriDense) checkOverlap(a blas64.General) bool {
 	return checkOverlap(generalFromTriangular(t.RawTriangular()), a)
 }
@@ -190,6 +325,42 @@ func (v *VecDense) checkOverlap(a blas64.Vector) bool {
 	return false
 }
 
+func (v *VecDense32) checkOverlap(a blas32.Vector) bool {
+	mat := v.mat
+	if cap(mat.Data) == 0 || cap(a.Data) == 0 {
+		return false
+	}
+
+	off := offset32(mat.Data[:1], a.Data[:1])
+
+	if off == 0 {
+		// At least one element overlaps.
+		if mat.Inc == a.Inc && len(mat.Data) == len(a.Data) {
+			panic(regionIdentity)
+		}
+		panic(regionOverlap)
+	}
+
+	if off > 0 && len(mat.Data) <= off {
+		// We know v is completely before a.
+		return false
+	}
+	if off < 0 && len(a.Data) <= -off {
+		// We know v is completely after a.
+		return false
+	}
+
+	if mat.Inc != a.Inc {
+		// Too hard, so assume the worst.
+		panic(mismatchedStrides)
+	}
+
+	if mat.Inc == 1 || off&mat.Inc == 0 {
+		panic(regionOverlap)
+	}
+	return false
+}
+
 // rectanglesOverlap returns whether the strided rectangles a and b overlap
 // when b is offset by off elements after a but has at least one element before
 // the end of a. off must be positive. a and b have aCols and bCols respectively.
@@ -72,6 +72,8 @@ func (m *Dense) Solve(a, b Matrix) error {
 			return Condition(cond)
 		}
 		return nil
+	case RawTriBander:
+		return solveTriBand(m, rma.RawTriBand(), aTrans, b)
 	}
 
 	switch {
@@ -138,3 +140,68 @@ func (v *VecDense) SolveVec(a Matrix, b Vector) error {
 	m := v.asDense()
 	return m.Solve(a, b)
 }
+
+// SolveMinNorm finds the minimum-norm solution to the least squares problem
+//  minimize ||A*X - B||_2
+// where A may be rank deficient, using the singular value decomposition of A.
+// Each column of B is treated as a separate right-hand side. The solution
+// matrix, X, is stored in-place into the receiver.
+//
+// Singular values of A that are less than or equal to rcond times the largest
+// singular value are treated as zero when determining the effective rank of A.
+// If rcond is negative, machine precision is used instead.
+//
+// SolveMinNorm returns the effective rank of A. If the singular value
+// decomposition fails to converge, ErrFailedSVD is returned.
+func (m *Dense) SolveMinNorm(a, b Matrix, rcond float64) (rank int, err error) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br {
+		panic(ErrShape)
+	}
+	m.reuseAs(ac, bc)
+
+	// Copy a and b into workspaces since they are overwritten by Gelss and
+	// they may share data with the receiver.
+	tmpA := getWorkspace(ar, ac, false)
+	defer putWorkspace(tmpA)
+	tmpA.Copy(a)
+	x := getWorkspace(max(ar, ac), bc, false)
+	defer putWorkspace(x)
+	x.Copy(b)
+
+	rank, ok := gelss(tmpA, x, rcond)
+	if !ok {
+		return 0, ErrFailedSVD
+	}
+	m.Copy(x)
+	return rank, nil
+}
+
+// SolveMinNormVec finds the minimum-norm solution to the least squares problem
+//  minimize ||A*x - b||_2
+// where A may be rank deficient. The solution is stored in-place into the
+// receiver. See the documentation for Dense.SolveMinNorm for more information.
+func (v *VecDense) SolveMinNormVec(a Matrix, b Vector, rcond float64) (rank int, err error) {
+	if _, bc := b.Dims(); bc != 1 {
+		panic(ErrShape)
+	}
+	_, c := a.Dims()
+	v.reuseAs(c)
+	return v.asDense().SolveMinNorm(a, b, rcond)
+}
+
+// gelss solves the least squares problem defined by a and the first m rows
+// of b using Gelss, overwriting a and b. b must have max(m,n) rows, where a
+// is m×n. On return, the first n rows of b hold the solution. gelss returns
+// the effective rank of a and whether the computation succeeded.
+func gelss(a, b *Dense, rcond float64) (rank int, ok bool) {
+	r, c := a.Dims()
+	s := getFloats(min(r, c), false)
+	defer putFloats(s)
+	work := []float64{0}
+	lapack64.Gelss(a.mat, b.mat, s, rcond, work, -1)
+	work = getFloats(int(work[0]), false)
+	defer putFloats(work)
+	return lapack64.Gelss(a.mat, b.mat, s, rcond, work, len(work))
+}
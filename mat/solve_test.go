@@ -295,3 +295,101 @@ func TestSolveVec(t *testing.T) {
 	}
 	testTwoInput(t, "SolveVec", &VecDense{}, method, denseComparison, legalTypesMatrixVector, legalSizeSolve, 1e-12)
 }
+
+// randRankDeficient returns a random m×n matrix of rank r.
+func randRankDeficient(m, n, r int, rnd *rand.Rand) *Dense {
+	var a Dense
+	a.Mul(randNormDense(m, r, rnd), randNormDense(r, n, rnd))
+	return &a
+}
+
+// pinvFromSVD returns the pseudo-inverse of a computed from its singular
+// value decomposition, treating singular values less than tol as zero.
+func pinvFromSVD(a Matrix, tol float64) *Dense {
+	var svd SVD
+	if !svd.Factorize(a, SVDThin) {
+		panic("svd failed")
+	}
+	s, u, v := extractSVD(&svd)
+	for i, sv := range s {
+		if sv > tol*s[0] {
+			s[i] = 1 / sv
+		} else {
+			s[i] = 0
+		}
+	}
+	var pinv Dense
+	pinv.Product(v, NewDiagDense(len(s), s), u.T())
+	return &pinv
+}
+
+func TestSolveMinNorm(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, rank, bc int
+	}{
+		{1, 1, 1, 1},
+		{5, 5, 5, 2},
+		{5, 5, 3, 2},
+		{8, 4, 4, 3},
+		{8, 4, 2, 3},
+		{4, 8, 4, 1},
+		{4, 8, 1, 5},
+		{10, 10, 7, 10},
+	} {
+		a := randRankDeficient(test.m, test.n, test.rank, rnd)
+		aCopy := DenseCopyOf(a)
+		b := randNormDense(test.m, test.bc, rnd)
+		bCopy := DenseCopyOf(b)
+
+		var want Dense
+		want.Mul(pinvFromSVD(a, 1e-10), b)
+
+		var x Dense
+		rank, err := x.SolveMinNorm(a, b, 1e-10)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if rank != test.rank {
+			t.Errorf("unexpected rank for %d×%d matrix: got %d want %d", test.m, test.n, rank, test.rank)
+		}
+		if !EqualApprox(&x, &want, 1e-10) {
+			t.Errorf("unexpected solution for %d×%d matrix of rank %d:\ngot:\n%v\nwant:\n%v",
+				test.m, test.n, test.rank, Formatted(&x), Formatted(&want))
+		}
+		if !Equal(a, aCopy) || !Equal(b, bCopy) {
+			t.Errorf("input modified")
+		}
+
+		// Check the vector form for the first column.
+		var xv VecDense
+		rank, err = xv.SolveMinNormVec(a, b.ColView(0), 1e-10)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if rank != test.rank {
+			t.Errorf("unexpected rank for vector solve: got %d want %d", rank, test.rank)
+		}
+		if !EqualApprox(&xv, want.ColView(0), 1e-10) {
+			t.Errorf("unexpected solution for vector solve")
+		}
+	}
+
+	// Check that a solve into the right-hand side works for square systems.
+	a := randRankDeficient(6, 6, 4, rnd)
+	b := randNormDense(6, 2, rnd)
+	var want Dense
+	_, err := want.SolveMinNorm(a, b, 1e-10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = b.SolveMinNorm(a, b, 1e-10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !EqualApprox(b, &want, 1e-14) {
+		t.Errorf("unexpected solution for aliased receiver")
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/lapack32"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// SolveOptions specifies how the expert solvers SolveExpert and
+// SolveSymExpert compute the solution of a system of linear equations.
+// A nil *SolveOptions is equivalent to the zero value.
+type SolveOptions struct {
+	// NoEquilibrate disables the row and column scaling of A that is
+	// otherwise applied when it improves the condition of the system.
+	NoEquilibrate bool
+
+	// Mixed specifies that A is factorized in single precision and that
+	// the solution is then refined using residuals computed in double
+	// precision. For well-conditioned systems this gives a solution that
+	// is as accurate as one computed from a double precision factorization
+	// in less time. If refinement fails to reach a backward error close to
+	// double precision, A is factorized again in double precision.
+	Mixed bool
+}
+
+// SolveBounds holds the error estimates computed by the expert solvers
+// SolveExpert and SolveSymExpert.
+type SolveBounds struct {
+	// Cond is the estimated condition number in the 1-norm of A after
+	// equilibration.
+	Cond float64
+
+	// Forward holds for each column of the solution X an estimated bound
+	// on its relative forward error
+	//  max_i |X[i,j] - Xtrue[i,j]| / max_i |X[i,j]|.
+	// The estimate is almost always a slight overestimate of the true
+	// error.
+	Forward []float64
+
+	// Backward holds for each column of the solution X its componentwise
+	// relative backward error, that is the smallest relative change in
+	// any element of A or B that makes the column an exact solution.
+	Backward []float64
+
+	// PivotGrowth is the reciprocal pivot growth factor of the LU
+	// factorization computed by SolveExpert. A value much less than one
+	// indicates that the factorization may be unstable and that the
+	// solution and the error bounds may be unreliable. PivotGrowth is
+	// zero for SolveSymExpert.
+	PivotGrowth float64
+
+	// Equilibrated reports whether A was scaled to improve its condition.
+	Equilibrated bool
+
+	// Mixed reports whether the solution was obtained by refining the
+	// result of a single precision factorization.
+	Mixed bool
+}
+
+// SolveExpert solves the system of linear equations
+//  A * X = B
+// where A is a square matrix, using the LU factorization of A with partial
+// pivoting. Unless disabled in opts, A is equilibrated before it is
+// factorized. The computed solution is improved by iterative refinement and
+// stored into the receiver. The returned SolveBounds holds the condition
+// estimate of A and error bounds for each column of the solution.
+//
+// If A is exactly singular, the solution is not computed and a Condition
+// error with an infinite condition number is returned. If A is
+// near-singular, the solution and the error bounds are computed and a
+// Condition error is returned. See the documentation for Condition for more
+// information.
+func (m *Dense) SolveExpert(a, b Matrix, opts *SolveOptions) (SolveBounds, error) {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	if opts == nil {
+		opts = &SolveOptions{}
+	}
+
+	// Work on copies of A and B so that m may alias either of them.
+	aw := getWorkspace(n, n, false)
+	defer putWorkspace(aw)
+	bw := getWorkspace(n, bc, false)
+	defer putWorkspace(bw)
+	x := getWorkspace(n, bc, false)
+	defer putWorkspace(x)
+	af := getWorkspace(n, n, false)
+	defer putWorkspace(af)
+
+	ipiv := getInts(n, false)
+	defer putInts(ipiv)
+	r := getFloats(n, false)
+	defer putFloats(r)
+	cs := getFloats(n, false)
+	defer putFloats(cs)
+	work := getFloats(4*n, false)
+	defer putFloats(work)
+	iwork := getInts(n, false)
+	defer putInts(iwork)
+
+	bounds := SolveBounds{
+		Forward:  make([]float64, bc),
+		Backward: make([]float64, bc),
+	}
+
+	var (
+		equed  lapack.Equilibration
+		rcond  float64
+		rpvgrw float64
+		ok     bool
+	)
+	if opts.Mixed {
+		aw.Copy(a)
+		bw.Copy(b)
+		equed = lapack.EquilibrateNone
+		if !opts.NoEquilibrate {
+			rowcnd, colcnd, amax, okequ := lapack64.Geequ(aw.mat, r, cs)
+			if okequ {
+				equed = lapack64.Laqge(aw.mat, r, cs, rowcnd, colcnd, amax)
+			}
+		}
+		// Factorize A in single precision and promote the factors, which
+		// are an exact LU factorization of a matrix close to A.
+		var a32 Dense32
+		a32.CloneFrom64(aw)
+		if lapack32.Getrf(a32.mat, ipiv) {
+			a32.To64(af)
+			equed, rcond, rpvgrw, ok = lapack64.Gesvx(lapack.Factored, blas.NoTrans, aw.mat, af.mat, ipiv, equed, r, cs, bw.mat, x.mat, bounds.Forward, bounds.Backward, work, iwork)
+			bounds.Mixed = refined(bounds.Backward, n)
+		}
+	}
+	if !bounds.Mixed {
+		aw.Copy(a)
+		bw.Copy(b)
+		fact := lapack.EquilibrateFactorize
+		if opts.NoEquilibrate {
+			fact = lapack.Factorize
+		}
+		equed, rcond, rpvgrw, ok = lapack64.Gesvx(fact, blas.NoTrans, aw.mat, af.mat, ipiv, lapack.EquilibrateNone, r, cs, bw.mat, x.mat, bounds.Forward, bounds.Backward, work, iwork)
+	}
+	bounds.Equilibrated = equed != lapack.EquilibrateNone
+	bounds.PivotGrowth = rpvgrw
+	if rcond == 0 && !ok {
+		bounds.Cond = math.Inf(1)
+		return bounds, Condition(math.Inf(1))
+	}
+	bounds.Cond = 1 / rcond
+
+	m.reuseAs(n, bc)
+	m.Copy(x)
+	if bounds.Cond > ConditionTolerance {
+		return bounds, Condition(bounds.Cond)
+	}
+	return bounds, nil
+}
+
+// SolveSymExpert solves the system of linear equations
+//  A * X = B
+// where A is a symmetric positive definite matrix, using the Cholesky
+// factorization of A. Unless disabled in opts, A is equilibrated before it is
+// factorized. The computed solution is improved by iterative refinement and
+// stored into the receiver. The returned SolveBounds holds the condition
+// estimate of A and error bounds for each column of the solution.
+//
+// If A is not positive definite, the solution is not computed and ErrNotPSD
+// is returned. If A is near-singular, the solution and the error bounds are
+// computed and a Condition error is returned. See the documentation for
+// Condition for more information.
+func (m *Dense) SolveSymExpert(a Symmetric, b Matrix, opts *SolveOptions) (SolveBounds, error) {
+	n := a.Symmetric()
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	if opts == nil {
+		opts = &SolveOptions{}
+	}
+
+	// Work on copies of A and B so that m may alias either of them.
+	aw := getWorkspaceSym(n, false)
+	defer putWorkspaceSym(aw)
+	bw := getWorkspace(n, bc, false)
+	defer putWorkspace(bw)
+	x := getWorkspace(n, bc, false)
+	defer putWorkspace(x)
+	af := getWorkspace(n, n, false)
+	defer putWorkspace(af)
+	t := blas64.Triangular{
+		Uplo:   blas.Upper,
+		Diag:   blas.NonUnit,
+		N:      n,
+		Stride: af.mat.Stride,
+		Data:   af.mat.Data,
+	}
+
+	s := getFloats(n, false)
+	defer putFloats(s)
+	work := getFloats(3*n, false)
+	defer putFloats(work)
+	iwork := getInts(n, false)
+	defer putInts(iwork)
+
+	bounds := SolveBounds{
+		Forward:  make([]float64, bc),
+		Backward: make([]float64, bc),
+	}
+
+	var (
+		equed lapack.Equilibration
+		rcond float64
+		ok    bool
+	)
+	if opts.Mixed {
+		aw.CopySym(a)
+		bw.Copy(b)
+		equed = lapack.EquilibrateNone
+		if !opts.NoEquilibrate {
+			scond, amax, okequ := lapack64.Poequ(aw.mat, s)
+			if okequ {
+				equed = lapack64.Laqsy(aw.mat, s, scond, amax)
+			}
+		}
+		// Factorize A in single precision and promote the factor, which is
+		// an exact Cholesky factorization of a matrix close to A.
+		var a32 Dense32
+		a32.CloneFrom64(aw)
+		a32s := blas32.Symmetric{
+			Uplo:   blas.Upper,
+			N:      n,
+			Stride: a32.mat.Stride,
+			Data:   a32.mat.Data,
+		}
+		if _, ok32 := lapack32.Potrf(a32s); ok32 {
+			a32.To64(af)
+			equed, rcond, ok = lapack64.Posvx(lapack.Factored, aw.mat, t, equed, s, bw.mat, x.mat, bounds.Forward, bounds.Backward, work, iwork)
+			bounds.Mixed = refined(bounds.Backward, n)
+		}
+	}
+	if !bounds.Mixed {
+		aw.CopySym(a)
+		bw.Copy(b)
+		fact := lapack.EquilibrateFactorize
+		if opts.NoEquilibrate {
+			fact = lapack.Factorize
+		}
+		equed, rcond, ok = lapack64.Posvx(fact, aw.mat, t, lapack.EquilibrateNone, s, bw.mat, x.mat, bounds.Forward, bounds.Backward, work, iwork)
+	}
+	bounds.Equilibrated = equed != lapack.EquilibrateNone
+	if rcond == 0 && !ok {
+		bounds.Cond = math.Inf(1)
+		return bounds, ErrNotPSD
+	}
+	bounds.Cond = 1 / rcond
+
+	m.reuseAs(n, bc)
+	m.Copy(x)
+	if bounds.Cond > ConditionTolerance {
+		return bounds, Condition(bounds.Cond)
+	}
+	return bounds, nil
+}
+
+// refined returns whether the componentwise backward errors in berr of a
+// solution refined from a single precision factorization of an n×n matrix
+// are close enough to double precision to accept the solution.
+func refined(berr []float64, n int) bool {
+	tol := math.Sqrt(float64(n)) * machEps
+	for _, v := range berr {
+		if !(v <= tol) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,207 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// hilbert returns the n×n Hilbert matrix which is notoriously ill-conditioned.
+func hilbert(n int) *SymDense {
+	h := NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			h.SetSym(i, j, 1/float64(i+j+1))
+		}
+	}
+	return h
+}
+
+// checkSolveBounds checks that the forward error of each column of x with
+// respect to want is within the bounds and that the backward errors are small.
+func checkSolveBounds(t *testing.T, name string, x, want *Dense, bounds SolveBounds) {
+	const tol = 1e-13
+	_, c := x.Dims()
+	if len(bounds.Forward) != c || len(bounds.Backward) != c {
+		t.Errorf("%s: unexpected length of error bounds", name)
+		return
+	}
+	for j := 0; j < c; j++ {
+		var diff, xmax float64
+		xj := x.ColView(j)
+		for i := 0; i < xj.Len(); i++ {
+			diff = math.Max(diff, math.Abs(xj.AtVec(i)-want.At(i, j)))
+			xmax = math.Max(xmax, math.Abs(xj.AtVec(i)))
+		}
+		if diff/xmax > bounds.Forward[j] {
+			t.Errorf("%s: column %d: forward error %v not bounded by %v", name, j, diff/xmax, bounds.Forward[j])
+		}
+		if bounds.Backward[j] > tol {
+			t.Errorf("%s: column %d: backward error %v too large", name, j, bounds.Backward[j])
+		}
+	}
+}
+
+func TestDenseSolveExpert(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 50} {
+		for _, bc := range []int{1, 3} {
+			for _, scaled := range []bool{false, true} {
+				for _, opts := range []*SolveOptions{nil, {NoEquilibrate: true}, {Mixed: true}} {
+					if scaled && opts != nil && opts.NoEquilibrate {
+						continue
+					}
+					name := fmt.Sprintf("n=%d,bc=%d,scaled=%t,opts=%+v", n, bc, scaled, opts)
+					a := randNormDense(n, n, rnd)
+					if scaled {
+						for i := 0; i < n; i++ {
+							s := math.Pow(10, float64(rnd.Intn(9)-4))
+							row := a.RawRowView(i)
+							for j := range row {
+								row[j] *= s
+							}
+						}
+					}
+					want := randNormDense(n, bc, rnd)
+					var b Dense
+					b.Mul(a, want)
+					aCopy := DenseCopyOf(a)
+					bCopy := DenseCopyOf(&b)
+
+					var x Dense
+					bounds, err := x.SolveExpert(a, &b, opts)
+					if err != nil {
+						t.Errorf("%s: unexpected error: %v", name, err)
+						continue
+					}
+					if !Equal(a, aCopy) || !Equal(&b, bCopy) {
+						t.Errorf("%s: input modified", name)
+					}
+					if opts != nil && opts.Mixed && !bounds.Mixed {
+						t.Errorf("%s: mixed precision solution not used", name)
+					}
+					if opts != nil && opts.NoEquilibrate && bounds.Equilibrated {
+						t.Errorf("%s: unexpected equilibration", name)
+					}
+					if bounds.Cond < 0.5 || math.IsInf(bounds.Cond, 1) {
+						t.Errorf("%s: unexpected condition number %v", name, bounds.Cond)
+					}
+					if bounds.PivotGrowth <= 0 {
+						t.Errorf("%s: unexpected pivot growth %v", name, bounds.PivotGrowth)
+					}
+					checkSolveBounds(t, name, &x, want, bounds)
+
+					// Check that the receiver may alias B.
+					bounds, err = b.SolveExpert(a, &b, opts)
+					if err != nil {
+						t.Errorf("%s: unexpected error with aliased receiver: %v", name, err)
+						continue
+					}
+					if !EqualApprox(&b, &x, 1e-14*Norm(&x, math.Inf(1))) {
+						t.Errorf("%s: unexpected solution with aliased receiver", name)
+					}
+				}
+			}
+		}
+	}
+
+	// A badly conditioned matrix falls back to a double precision
+	// factorization.
+	h := DenseCopyOf(hilbert(8))
+	var x Dense
+	bounds, err := x.SolveExpert(h, NewDense(8, 1, []float64{1, 1, 1, 1, 1, 1, 1, 1}), &SolveOptions{Mixed: true})
+	if err != nil {
+		t.Errorf("unexpected error for Hilbert matrix: %v", err)
+	}
+	if bounds.Mixed {
+		t.Errorf("unexpected mixed precision solution for Hilbert matrix")
+	}
+
+	// A singular matrix is detected.
+	for _, opts := range []*SolveOptions{nil, {Mixed: true}} {
+		a := NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 0, 0, 0})
+		_, err = x.SolveExpert(a, NewDense(3, 1, []float64{1, 1, 1}), opts)
+		if c, ok := err.(Condition); !ok || !math.IsInf(float64(c), 1) {
+			t.Errorf("opts=%+v: unexpected error for singular matrix: %v", opts, err)
+		}
+	}
+}
+
+func TestDenseSolveSymExpert(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 50} {
+		for _, bc := range []int{1, 3} {
+			for _, scaled := range []bool{false, true} {
+				for _, opts := range []*SolveOptions{nil, {NoEquilibrate: true}, {Mixed: true}} {
+					if scaled && opts != nil && opts.NoEquilibrate {
+						continue
+					}
+					name := fmt.Sprintf("n=%d,bc=%d,scaled=%t,opts=%+v", n, bc, scaled, opts)
+					a := randSPD(n, rnd)
+					if scaled {
+						for i := 0; i < n; i++ {
+							s := math.Pow(10, float64(rnd.Intn(9)-4))
+							for j := 0; j < n; j++ {
+								a.SetSym(i, j, s*a.At(i, j))
+							}
+							a.SetSym(i, i, s*a.At(i, i))
+						}
+					}
+					want := randNormDense(n, bc, rnd)
+					var b Dense
+					b.Mul(a, want)
+					aCopy := NewSymDense(n, nil)
+					aCopy.CopySym(a)
+
+					var x Dense
+					bounds, err := x.SolveSymExpert(a, &b, opts)
+					if err != nil {
+						t.Errorf("%s: unexpected error: %v", name, err)
+						continue
+					}
+					if !Equal(a, aCopy) {
+						t.Errorf("%s: input modified", name)
+					}
+					if opts != nil && opts.Mixed && !bounds.Mixed {
+						t.Errorf("%s: mixed precision solution not used", name)
+					}
+					if opts != nil && opts.NoEquilibrate && bounds.Equilibrated {
+						t.Errorf("%s: unexpected equilibration", name)
+					}
+					if bounds.Cond < 0.5 || math.IsInf(bounds.Cond, 1) {
+						t.Errorf("%s: unexpected condition number %v", name, bounds.Cond)
+					}
+					checkSolveBounds(t, name, &x, want, bounds)
+				}
+			}
+		}
+	}
+
+	// A badly conditioned matrix falls back to a double precision
+	// factorization.
+	var x Dense
+	bounds, err := x.SolveSymExpert(hilbert(8), NewDense(8, 1, []float64{1, 1, 1, 1, 1, 1, 1, 1}), &SolveOptions{Mixed: true})
+	if err != nil {
+		t.Errorf("unexpected error for Hilbert matrix: %v", err)
+	}
+	if bounds.Mixed {
+		t.Errorf("unexpected mixed precision solution for Hilbert matrix")
+	}
+
+	// An indefinite matrix is detected.
+	for _, opts := range []*SolveOptions{nil, {Mixed: true}} {
+		a := NewSymDense(2, []float64{1, 2, 2, 1})
+		_, err = x.SolveSymExpert(a, NewDense(2, 1, []float64{1, 1}), opts)
+		if err != ErrNotPSD {
+			t.Errorf("opts=%+v: unexpected error for indefinite matrix: got %v, want %v", opts, err, ErrNotPSD)
+		}
+	}
+}
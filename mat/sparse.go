@@ -0,0 +1,641 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/internal/asm/f64"
+)
+
+var (
+	cooMatrix *COO
+	_         Matrix         = cooMatrix
+	_         NonZeroDoer    = cooMatrix
+	_         RowNonZeroDoer = cooMatrix
+	_         ColNonZeroDoer = cooMatrix
+	_         MulVecToer     = cooMatrix
+
+	csrMatrix *CSR
+	_         Matrix         = csrMatrix
+	_         NonZeroDoer    = csrMatrix
+	_         RowNonZeroDoer = csrMatrix
+	_         ColNonZeroDoer = csrMatrix
+	_         MulVecToer     = csrMatrix
+
+	cscMatrix *CSC
+	_         Matrix         = cscMatrix
+	_         NonZeroDoer    = cscMatrix
+	_         RowNonZeroDoer = cscMatrix
+	_         ColNonZeroDoer = cscMatrix
+	_         MulVecToer     = cscMatrix
+)
+
+const (
+	badSparseIndex  = "mat: sparse index out of range"
+	badSparseIndptr = "mat: invalid sparse index pointer"
+	badSparseOrder  = "mat: sparse indices not strictly increasing"
+)
+
+// COO is a sparse matrix stored in coordinate (triplet) format. Each stored
+// element is held as a row index, a column index and a value. Duplicate
+// entries are permitted and the value of the matrix at a position is the
+// sum of all entries stored at that position.
+//
+// COO is intended for the incremental construction of sparse matrices.
+// Matrices that are used repeatedly in arithmetic should be converted to
+// CSR or CSC format using the ToCSR and ToCSC methods.
+type COO struct {
+	r, c int
+
+	rows []int
+	cols []int
+	data []float64
+}
+
+// NewCOO returns a new r×c sparse matrix in coordinate format holding the
+// entries described by rows, cols and data, so that entry k is the value
+// data[k] at row rows[k] and column cols[k]. The slices are used as the
+// backing storage of the returned COO. If all three slices are nil, an empty
+// matrix is returned.
+//
+// NewCOO will panic if r or c is not positive, if the lengths of rows, cols
+// and data differ, or if any index is out of range.
+func NewCOO(r, c int, rows, cols []int, data []float64) *COO {
+	if r <= 0 || c <= 0 {
+		if r == 0 || c == 0 {
+			panic(ErrZeroLength)
+		}
+		panic("mat: negative dimension")
+	}
+	if len(rows) != len(data) || len(cols) != len(data) {
+		panic(ErrShape)
+	}
+	for k := range data {
+		if uint(rows[k]) >= uint(r) || uint(cols[k]) >= uint(c) {
+			panic(badSparseIndex)
+		}
+	}
+	return &COO{r: r, c: c, rows: rows, cols: cols, data: data}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *COO) Dims() (r, c int) {
+	return m.r, m.c
+}
+
+// At returns the element at row i, column j. At is O(n) in the number
+// of stored entries.
+func (m *COO) At(i, j int) float64 {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	var v float64
+	for k, row := range m.rows {
+		if row == i && m.cols[k] == j {
+			v += m.data[k]
+		}
+	}
+	return v
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (m *COO) T() Matrix {
+	return Transpose{m}
+}
+
+// NNZ returns the number of stored entries in the matrix, including
+// duplicate entries and explicitly stored zeros.
+func (m *COO) NNZ() int {
+	return len(m.data)
+}
+
+// Append adds the entry v at row i, column j to the matrix. If an entry
+// already exists at that position, the value of the matrix at (i, j) becomes
+// the sum of the entries.
+func (m *COO) Append(i, j int, v float64) {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	m.rows = append(m.rows, i)
+	m.cols = append(m.cols, j)
+	m.data = append(m.data, v)
+}
+
+// DoNonZero calls the function fn for each of the non-zero entries of m.
+// The function fn takes a row/column index and the entry value.
+// Duplicate entries are passed to fn individually.
+func (m *COO) DoNonZero(fn func(i, j int, v float64)) {
+	for k, v := range m.data {
+		if v != 0 {
+			fn(m.rows[k], m.cols[k], v)
+		}
+	}
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero entries of row i
+// of m. The function fn takes a row/column index and the entry value.
+// Duplicate entries are passed to fn individually.
+func (m *COO) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	for k, v := range m.data {
+		if m.rows[k] == i && v != 0 {
+			fn(i, m.cols[k], v)
+		}
+	}
+}
+
+// DoColNonZero calls the function fn for each of the non-zero entries of
+// column j of m. The function fn takes a row/column index and the entry value.
+// Duplicate entries are passed to fn individually.
+func (m *COO) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	for k, v := range m.data {
+		if m.cols[k] == j && v != 0 {
+			fn(m.rows[k], j, v)
+		}
+	}
+}
+
+// MulVecTo computes A⋅x or Aᵀ⋅x storing the result into dst.
+func (m *COO) MulVecTo(dst *VecDense, trans bool, x Vector) {
+	xv, restore := sparseMulVecSetup(dst, m, trans, x)
+	defer restore()
+	y := dst.mat
+	for k, v := range m.data {
+		i, j := m.rows[k], m.cols[k]
+		if trans {
+			i, j = j, i
+		}
+		y.Data[i*y.Inc] += v * xv.Data[j*xv.Inc]
+	}
+}
+
+// ToCSR returns a CSR representation of the receiver. Duplicate entries
+// are summed.
+func (m *COO) ToCSR() *CSR {
+	indptr, ind, data := compress(m.r, m.rows, m.cols, m.data)
+	return &CSR{r: m.r, c: m.c, indptr: indptr, ind: ind, data: data}
+}
+
+// ToCSC returns a CSC representation of the receiver. Duplicate entries
+// are summed.
+func (m *COO) ToCSC() *CSC {
+	indptr, ind, data := compress(m.c, m.cols, m.rows, m.data)
+	return &CSC{r: m.r, c: m.c, indptr: indptr, ind: ind, data: data}
+}
+
+// compress converts the coordinate representation given by major, minor and
+// data into a compressed representation along the major index, which takes n
+// values. The minor indices within each major index are sorted and duplicate
+// entries are summed.
+func compress(n int, major, minor []int, data []float64) (indptr, ind []int, vals []float64) {
+	indptr = make([]int, n+1)
+	for _, i := range major {
+		indptr[i+1]++
+	}
+	for i := 0; i < n; i++ {
+		indptr[i+1] += indptr[i]
+	}
+	next := make([]int, n)
+	copy(next, indptr)
+	ind = make([]int, len(data))
+	vals = make([]float64, len(data))
+	for k, i := range major {
+		ind[next[i]] = minor[k]
+		vals[next[i]] = data[k]
+		next[i]++
+	}
+
+	// Sort each major slice by minor index and sum duplicates,
+	// compacting the storage in place.
+	var nnz int
+	for i := 0; i < n; i++ {
+		start, end := indptr[i], indptr[i+1]
+		sort.Sort(sparseEntries{ind: ind[start:end], data: vals[start:end]})
+		indptr[i] = nnz
+		for k := start; k < end; k++ {
+			if nnz > indptr[i] && ind[nnz-1] == ind[k] {
+				vals[nnz-1] += vals[k]
+				continue
+			}
+			ind[nnz] = ind[k]
+			vals[nnz] = vals[k]
+			nnz++
+		}
+	}
+	indptr[n] = nnz
+	return indptr, ind[:nnz:nnz], vals[:nnz:nnz]
+}
+
+// sparseEntries sorts index and value pairs by index.
+type sparseEntries struct {
+	ind  []int
+	data []float64
+}
+
+func (s sparseEntries) Len() int           { return len(s.ind) }
+func (s sparseEntries) Less(i, j int) bool { return s.ind[i] < s.ind[j] }
+func (s sparseEntries) Swap(i, j int) {
+	s.ind[i], s.ind[j] = s.ind[j], s.ind[i]
+	s.data[i], s.data[j] = s.data[j], s.data[i]
+}
+
+// CSR is a sparse matrix stored in compressed sparse row format.
+//
+// The column indices of the stored elements of row i are held in
+// ind[indptr[i]:indptr[i+1]] and the corresponding values in
+// data[indptr[i]:indptr[i+1]].
+type CSR struct {
+	r, c int
+
+	indptr []int
+	ind    []int
+	data   []float64
+}
+
+// NewCSR returns a new r×c sparse matrix in compressed sparse row format.
+// The column indices of the elements of row i are ind[indptr[i]:indptr[i+1]]
+// and their values are data[indptr[i]:indptr[i+1]]. The slices are used as
+// the backing storage of the returned CSR.
+//
+// NewCSR will panic if r or c is not positive, if indptr does not have
+// length r+1, if indptr is not non-decreasing from zero to len(ind), if ind
+// and data have different lengths, or if the column indices of a row are not
+// strictly increasing and within [0, c).
+func NewCSR(r, c int, indptr, ind []int, data []float64) *CSR {
+	if r <= 0 || c <= 0 {
+		if r == 0 || c == 0 {
+			panic(ErrZeroLength)
+		}
+		panic("mat: negative dimension")
+	}
+	checkCompressed(r, c, indptr, ind, data)
+	return &CSR{r: r, c: c, indptr: indptr, ind: ind, data: data}
+}
+
+// checkCompressed panics if indptr, ind and data do not form a valid
+// compressed representation with n major and m minor indices.
+func checkCompressed(n, m int, indptr, ind []int, data []float64) {
+	if len(indptr) != n+1 || len(ind) != len(data) {
+		panic(ErrShape)
+	}
+	if indptr[0] != 0 || indptr[n] != len(ind) {
+		panic(badSparseIndptr)
+	}
+	// indptr must be non-decreasing, which together with the checks
+	// above ensures that all of its elements are valid indices into ind.
+	for i := 0; i < n; i++ {
+		if indptr[i] > indptr[i+1] {
+			panic(badSparseIndptr)
+		}
+	}
+	for i := 0; i < n; i++ {
+		prev := -1
+		for _, j := range ind[indptr[i]:indptr[i+1]] {
+			if j < 0 || j >= m {
+				panic(badSparseIndex)
+			}
+			if j <= prev {
+				panic(badSparseOrder)
+			}
+			prev = j
+		}
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *CSR) Dims() (r, c int) {
+	return m.r, m.c
+}
+
+// At returns the element at row i, column j.
+func (m *CSR) At(i, j int) float64 {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	return compressedAt(m.indptr, m.ind, m.data, i, j)
+}
+
+// compressedAt returns the element at major index i and minor index j of
+// the compressed representation given by indptr, ind and data.
+func compressedAt(indptr, ind []int, data []float64, i, j int) float64 {
+	start, end := indptr[i], indptr[i+1]
+	k := start + sort.SearchInts(ind[start:end], j)
+	if k < end && ind[k] == j {
+		return data[k]
+	}
+	return 0
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (m *CSR) T() Matrix {
+	return Transpose{m}
+}
+
+// NNZ returns the number of stored elements in the matrix, including
+// explicitly stored zeros.
+func (m *CSR) NNZ() int {
+	return len(m.data)
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements of m.
+// The function fn takes a row/column index and the element value of m
+// at (i, j).
+func (m *CSR) DoNonZero(fn func(i, j int, v float64)) {
+	for i := 0; i < m.r; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			if v := m.data[k]; v != 0 {
+				fn(i, m.ind[k], v)
+			}
+		}
+	}
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements of
+// row i of m. The function fn takes a row/column index and the element value
+// of m at (i, j).
+func (m *CSR) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+		if v := m.data[k]; v != 0 {
+			fn(i, m.ind[k], v)
+		}
+	}
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements of
+// column j of m. The function fn takes a row/column index and the element
+// value of m at (i, j). DoColNonZero is O(r log(nnz/r)) in the number of rows.
+func (m *CSR) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	for i := 0; i < m.r; i++ {
+		if v := compressedAt(m.indptr, m.ind, m.data, i, j); v != 0 {
+			fn(i, j, v)
+		}
+	}
+}
+
+// MulVecTo computes A⋅x or Aᵀ⋅x storing the result into dst.
+func (m *CSR) MulVecTo(dst *VecDense, trans bool, x Vector) {
+	xv, restore := sparseMulVecSetup(dst, m, trans, x)
+	defer restore()
+	compressedMulVec(dst.mat, trans, m.r, m.indptr, m.ind, m.data, xv)
+}
+
+// CSC is a sparse matrix stored in compressed sparse column format.
+//
+// The row indices of the stored elements of column j are held in
+// ind[indptr[j]:indptr[j+1]] and the corresponding values in
+// data[indptr[j]:indptr[j+1]].
+type CSC struct {
+	r, c int
+
+	indptr []int
+	ind    []int
+	data   []float64
+}
+
+// NewCSC returns a new r×c sparse matrix in compressed sparse column format.
+// The row indices of the elements of column j are ind[indptr[j]:indptr[j+1]]
+// and their values are data[indptr[j]:indptr[j+1]]. The slices are used as
+// the backing storage of the returned CSC.
+//
+// NewCSC will panic if r or c is not positive, if indptr does not have
+// length c+1, if indptr is not non-decreasing from zero to len(ind), if ind
+// and data have different lengths, or if the row indices of a column are not
+// strictly increasing and within [0, r).
+func NewCSC(r, c int, indptr, ind []int, data []float64) *CSC {
+	if r <= 0 || c <= 0 {
+		if r == 0 || c == 0 {
+			panic(ErrZeroLength)
+		}
+		panic("mat: negative dimension")
+	}
+	checkCompressed(c, r, indptr, ind, data)
+	return &CSC{r: r, c: c, indptr: indptr, ind: ind, data: data}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *CSC) Dims() (r, c int) {
+	return m.r, m.c
+}
+
+// At returns the element at row i, column j.
+func (m *CSC) At(i, j int) float64 {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	return compressedAt(m.indptr, m.ind, m.data, j, i)
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (m *CSC) T() Matrix {
+	return Transpose{m}
+}
+
+// NNZ returns the number of stored elements in the matrix, including
+// explicitly stored zeros.
+func (m *CSC) NNZ() int {
+	return len(m.data)
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements of m.
+// The function fn takes a row/column index and the element value of m
+// at (i, j).
+func (m *CSC) DoNonZero(fn func(i, j int, v float64)) {
+	for j := 0; j < m.c; j++ {
+		for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+			if v := m.data[k]; v != 0 {
+				fn(m.ind[k], j, v)
+			}
+		}
+	}
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements of
+// row i of m. The function fn takes a row/column index and the element value
+// of m at (i, j). DoRowNonZero is O(c log(nnz/c)) in the number of columns.
+func (m *CSC) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	for j := 0; j < m.c; j++ {
+		if v := compressedAt(m.indptr, m.ind, m.data, j, i); v != 0 {
+			fn(i, j, v)
+		}
+	}
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements of
+// column j of m. The function fn takes a row/column index and the element
+// value of m at (i, j).
+func (m *CSC) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+		if v := m.data[k]; v != 0 {
+			fn(m.ind[k], j, v)
+		}
+	}
+}
+
+// MulVecTo computes A⋅x or Aᵀ⋅x storing the result into dst.
+func (m *CSC) MulVecTo(dst *VecDense, trans bool, x Vector) {
+	xv, restore := sparseMulVecSetup(dst, m, trans, x)
+	defer restore()
+	// A CSC matrix is the transpose of the CSR matrix with
+	// the same index pointers, indices and values.
+	compressedMulVec(dst.mat, !trans, m.c, m.indptr, m.ind, m.data, xv)
+}
+
+// compressedMulVec computes y = A⋅x if trans is false and y = Aᵀ⋅x if trans is
+// true, where A is the matrix with n rows in compressed sparse row format given
+// by indptr, ind and data. The elements of y must be zero on entry.
+func compressedMulVec(y blas64.Vector, trans bool, n int, indptr, ind []int, data []float64, x blas64.Vector) {
+	if trans {
+		for i := 0; i < n; i++ {
+			xi := x.Data[i*x.Inc]
+			if xi == 0 {
+				continue
+			}
+			for k := indptr[i]; k < indptr[i+1]; k++ {
+				y.Data[ind[k]*y.Inc] += data[k] * xi
+			}
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		var sum float64
+		for k := indptr[i]; k < indptr[i+1]; k++ {
+			sum += data[k] * x.Data[ind[k]*x.Inc]
+		}
+		y.Data[i*y.Inc] = sum
+	}
+}
+
+// sparseMulVecSetup checks the dimensions of a sparse matrix-vector product
+// computing a⋅x or aᵀ⋅x into dst, sizes and zeros dst and returns the elements
+// of x in a form that is not altered by writes to dst. The returned restore
+// function must be called once the product has been computed.
+func sparseMulVecSetup(dst *VecDense, a Matrix, trans bool, x Vector) (xv blas64.Vector, restore func()) {
+	r, c := a.Dims()
+	if trans {
+		r, c = c, r
+	}
+	if xr, xc := x.Dims(); xr != c || xc != 1 {
+		panic(ErrShape)
+	}
+	dst.reuseAs(r)
+	if rv, ok := x.(RawVectorer); ok && dst != x {
+		xv = rv.RawVector()
+		dst.checkOverlap(xv)
+		dst.Zero()
+		return xv, func() {}
+	}
+	xs := getFloats(c, false)
+	for i := range xs {
+		xs[i] = x.AtVec(i)
+	}
+	dst.Zero()
+	return blas64.Vector{N: c, Inc: 1, Data: xs}, func() { putFloats(xs) }
+}
+
+// asSparse returns a as a NonZeroDoer and true if a is one of the sparse
+// matrix types. Otherwise it returns nil and false.
+func asSparse(a Matrix) (NonZeroDoer, bool) {
+	switch a := a.(type) {
+	case *COO:
+		return a, true
+	case *CSR:
+		return a, true
+	case *CSC:
+		return a, true
+	}
+	return nil, false
+}
+
+// mulSparseLeft computes the product of the sparse matrix a, or its transpose
+// if aTrans is true, and the matrix b, storing the result into the receiver.
+// The receiver must be correctly sized and must not alias b.
+func (m *Dense) mulSparseLeft(a NonZeroDoer, aTrans bool, b Matrix) {
+	m.Zero()
+	_, bc := b.Dims()
+	bU, bTrans := untranspose(b)
+	rm, raw := bU.(RawMatrixer)
+	var bmat blas64.General
+	if raw {
+		bmat = rm.RawMatrix()
+	}
+	a.DoNonZero(func(i, k int, v float64) {
+		if aTrans {
+			i, k = k, i
+		}
+		// Row i of the result is updated with v times row k of b.
+		row := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+bc]
+		switch {
+		case raw && !bTrans:
+			f64.AxpyUnitary(v, bmat.Data[k*bmat.Stride:k*bmat.Stride+bc], row)
+		case raw && bTrans:
+			f64.AxpyInc(v, bmat.Data[k:], row, uintptr(bc), uintptr(bmat.Stride), 1, 0, 0)
+		default:
+			for j := range row {
+				row[j] += v * b.At(k, j)
+			}
+		}
+	})
+}
+
+// mulSparseRight computes the product of the matrix a and the sparse matrix b,
+// or its transpose if bTrans is true, storing the result into the receiver.
+// The receiver must be correctly sized and must not alias a.
+func (m *Dense) mulSparseRight(a Matrix, b NonZeroDoer, bTrans bool) {
+	m.Zero()
+	ar, _ := a.Dims()
+	aU, aTrans := untranspose(a)
+	rm, raw := aU.(RawMatrixer)
+	var amat blas64.General
+	if raw {
+		amat = rm.RawMatrix()
+	}
+	b.DoNonZero(func(k, j int, v float64) {
+		if bTrans {
+			k, j = j, k
+		}
+		// Column j of the result is updated with v times column k of a.
+		switch {
+		case raw && !aTrans:
+			f64.AxpyInc(v, amat.Data[k:], m.mat.Data[j:], uintptr(ar), uintptr(amat.Stride), uintptr(m.mat.Stride), 0, 0)
+		case raw && aTrans:
+			f64.AxpyInc(v, amat.Data[k*amat.Stride:], m.mat.Data[j:], uintptr(ar), 1, uintptr(m.mat.Stride), 0, 0)
+		default:
+			for i := 0; i < ar; i++ {
+				m.mat.Data[i*m.mat.Stride+j] += v * a.At(i, k)
+			}
+		}
+	})
+}
@@ -0,0 +1,270 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// randSparse returns a random r×c matrix with approximately density*r*c
+// non-zero elements in COO, CSR and CSC formats and the equivalent Dense.
+// The COO matrix holds duplicate entries.
+func randSparse(r, c int, density float64, rnd *rand.Rand) (*COO, *CSR, *CSC, *Dense) {
+	d := NewDense(r, c, nil)
+	coo := NewCOO(r, c, nil, nil, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if rnd.Float64() >= density {
+				continue
+			}
+			v := rnd.NormFloat64()
+			d.Set(i, j, v)
+			// Split the value over two entries to test
+			// summation of duplicates.
+			w := rnd.NormFloat64()
+			coo.Append(i, j, w)
+			coo.Append(i, j, v-w)
+		}
+	}
+	return coo, coo.ToCSR(), coo.ToCSC(), d
+}
+
+func TestSparseAt(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c    int
+		density float64
+	}{
+		{1, 1, 1},
+		{5, 5, 0.3},
+		{10, 3, 0.5},
+		{3, 10, 0.5},
+		{20, 20, 0.05},
+	} {
+		coo, csr, csc, want := randSparse(test.r, test.c, test.density, rnd)
+		for _, m := range []Matrix{coo, csr, csc} {
+			if !EqualApprox(m, want, 1e-14) {
+				t.Errorf("%T: unexpected value for %d×%d matrix:\ngot: %v\nwant:%v",
+					m, test.r, test.c, Formatted(m), Formatted(want))
+			}
+			if !EqualApprox(m.T(), want.T(), 1e-14) {
+				t.Errorf("%T: unexpected transpose value for %d×%d matrix", m, test.r, test.c)
+			}
+		}
+		if csr.NNZ() != csc.NNZ() {
+			t.Errorf("mismatched NNZ: CSR %d CSC %d", csr.NNZ(), csc.NNZ())
+		}
+	}
+}
+
+func TestSparseNonZeroDoer(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const r, c = 12, 9
+	coo, csr, csc, want := randSparse(r, c, 0.3, rnd)
+	for _, m := range []interface {
+		Matrix
+		NonZeroDoer
+		RowNonZeroDoer
+		ColNonZeroDoer
+	}{coo, csr, csc} {
+		got := NewDense(r, c, nil)
+		m.DoNonZero(func(i, j int, v float64) {
+			got.Set(i, j, got.At(i, j)+v)
+		})
+		if !EqualApprox(got, want, 1e-14) {
+			t.Errorf("%T: unexpected DoNonZero result", m)
+		}
+
+		got.Zero()
+		for i := 0; i < r; i++ {
+			m.DoRowNonZero(i, func(ii, j int, v float64) {
+				if ii != i {
+					t.Errorf("%T: unexpected row index in DoRowNonZero: got %d want %d", m, ii, i)
+				}
+				got.Set(ii, j, got.At(ii, j)+v)
+			})
+		}
+		if !EqualApprox(got, want, 1e-14) {
+			t.Errorf("%T: unexpected DoRowNonZero result", m)
+		}
+
+		got.Zero()
+		for j := 0; j < c; j++ {
+			m.DoColNonZero(j, func(i, jj int, v float64) {
+				if jj != j {
+					t.Errorf("%T: unexpected column index in DoColNonZero: got %d want %d", m, jj, j)
+				}
+				got.Set(i, jj, got.At(i, jj)+v)
+			})
+		}
+		if !EqualApprox(got, want, 1e-14) {
+			t.Errorf("%T: unexpected DoColNonZero result", m)
+		}
+	}
+}
+
+func TestSparseMulVec(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c int
+	}{
+		{1, 1},
+		{4, 7},
+		{7, 4},
+		{15, 15},
+	} {
+		coo, csr, csc, dense := randSparse(test.r, test.c, 0.4, rnd)
+		for _, trans := range []bool{false, true} {
+			n := test.c
+			a := Matrix(dense)
+			if trans {
+				n = test.r
+				a = dense.T()
+			}
+			x := NewVecDense(n, nil)
+			for i := 0; i < n; i++ {
+				x.SetVec(i, rnd.NormFloat64())
+			}
+			var want VecDense
+			want.MulVec(a, x)
+
+			for _, m := range []MulVecToer{coo, csr, csc} {
+				var got VecDense
+				m.MulVecTo(&got, trans, x)
+				if !EqualApprox(&got, &want, 1e-12) {
+					t.Errorf("%T: unexpected MulVecTo result for trans=%t", m, trans)
+				}
+
+				// Check dispatch from VecDense.MulVec.
+				var mv VecDense
+				if trans {
+					mv.MulVec(m.T(), x)
+				} else {
+					mv.MulVec(m, x)
+				}
+				if !EqualApprox(&mv, &want, 1e-12) {
+					t.Errorf("%T: unexpected MulVec result for trans=%t", m, trans)
+				}
+
+				// Check products with a non-RawVectorer vector.
+				got.Reset()
+				m.MulVecTo(&got, trans, &basicVector{x.RawVector().Data})
+				if !EqualApprox(&got, &want, 1e-12) {
+					t.Errorf("%T: unexpected MulVecTo result with basicVector for trans=%t", m, trans)
+				}
+			}
+		}
+	}
+}
+
+func TestSparseMulVecAlias(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 10
+	_, csr, _, dense := randSparse(n, n, 0.4, rnd)
+	x := NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		x.SetVec(i, rnd.NormFloat64())
+	}
+	var want VecDense
+	want.MulVec(dense, x)
+	csr.MulVecTo(x, false, x)
+	if !EqualApprox(x, &want, 1e-12) {
+		t.Errorf("unexpected result for aliased MulVecTo")
+	}
+}
+
+func TestSparseMul(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, k, c int
+	}{
+		{1, 1, 1},
+		{5, 6, 7},
+		{7, 3, 2},
+		{12, 12, 12},
+	} {
+		coo, csr, csc, sparse := randSparse(test.r, test.k, 0.3, rnd)
+		b := NewDense(test.k, test.c, nil)
+		for i := 0; i < test.k; i++ {
+			for j := 0; j < test.c; j++ {
+				b.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		bt := DenseCopyOf(b.T())
+
+		var want Dense
+		want.Mul(sparse, b)
+		var wantT Dense
+		wantT.Mul(b.T(), sparse.T())
+		y := NewDense(test.r, test.c, nil)
+		for i := 0; i < test.r; i++ {
+			for j := 0; j < test.c; j++ {
+				y.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		var wantTL Dense
+		wantTL.Mul(sparse.T(), y)
+
+		for _, m := range []Matrix{coo, csr, csc} {
+			// Sparse on the left.
+			for _, bm := range []Matrix{b, bt.T(), (*basicMatrix)(b)} {
+				var got Dense
+				got.Mul(m, bm)
+				if !EqualApprox(&got, &want, 1e-12) {
+					t.Errorf("%T×%T: unexpected result", m, bm)
+				}
+			}
+			var got Dense
+			got.Mul(bt, m.T())
+			if !EqualApprox(&got, &wantT, 1e-12) {
+				t.Errorf("%T: unexpected result for dense×sparseᵀ", m)
+			}
+
+			// Sparse on the right.
+			for _, bm := range []Matrix{bt, b.T(), (*basicMatrix)(bt)} {
+				var got Dense
+				got.Mul(bm, m.T())
+				if !EqualApprox(&got, &wantT, 1e-12) {
+					t.Errorf("%T×%T: unexpected result", bm, m)
+				}
+			}
+			got.Reset()
+			got.Mul(m.T(), y)
+			if !EqualApprox(&got, &wantTL, 1e-12) {
+				t.Errorf("%T: unexpected result for sparseᵀ×dense", m)
+			}
+		}
+	}
+}
+
+func TestNewCompressedPanics(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		indptr []int
+		ind    []int
+		data   []float64
+		want   string
+	}{
+		{name: "short indptr", indptr: []int{0, 1}, ind: []int{0}, data: []float64{1}, want: ErrShape.Error()},
+		{name: "bad start", indptr: []int{1, 1, 1}, ind: []int{0}, data: []float64{1}, want: badSparseIndptr},
+		{name: "decreasing indptr", indptr: []int{0, 2, 1}, ind: []int{0}, data: []float64{1}, want: badSparseIndptr},
+		{name: "indptr beyond ind", indptr: []int{0, 3, 1}, ind: []int{0}, data: []float64{1}, want: badSparseIndptr},
+		{name: "unsorted", indptr: []int{0, 2, 2}, ind: []int{1, 0}, data: []float64{1, 2}, want: badSparseOrder},
+		{name: "out of range", indptr: []int{0, 1, 1}, ind: []int{3}, data: []float64{1}, want: badSparseIndex},
+		{name: "negative index", indptr: []int{0, 1, 1}, ind: []int{-1}, data: []float64{1}, want: badSparseIndex},
+		{name: "mismatched data", indptr: []int{0, 1, 1}, ind: []int{0}, data: []float64{1, 2}, want: ErrShape.Error()},
+	} {
+		panicked, msg := panics(func() { NewCSR(2, 3, test.indptr, test.ind, test.data) })
+		if !panicked || msg != test.want {
+			t.Errorf("unexpected NewCSR panic for %s: got %q want %q", test.name, msg, test.want)
+		}
+		panicked, msg = panics(func() { NewCSC(3, 2, test.indptr, test.ind, test.data) })
+		if !panicked || msg != test.want {
+			t.Errorf("unexpected NewCSC panic for %s: got %q want %q", test.name, msg, test.want)
+		}
+	}
+}
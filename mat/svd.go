@@ -44,6 +44,35 @@ const (
 	SVDFull SVDKind = SVDFullU | SVDFullV
 )
 
+// SVDAlgorithm specifies the algorithm used to compute a singular value
+// decomposition.
+type SVDAlgorithm int
+
+const (
+	// SVDAuto chooses the algorithm based on the size of the matrix and
+	// the requested singular vectors.
+	SVDAuto SVDAlgorithm = iota
+	// SVDQR specifies the implicit QR iteration on the bidiagonal form
+	// as implemented by lapack64.Gesvd.
+	SVDQR
+	// SVDDivideConquer specifies the divide and conquer method as
+	// implemented by lapack64.Gesdd. It is usually considerably faster
+	// than SVDQR for large matrices when singular vectors are computed,
+	// at the cost of additional workspace.
+	SVDDivideConquer
+	// SVDJacobi specifies the preconditioned one-sided Jacobi method as
+	// implemented by lapack64.Gejsv. It is slower than the other methods
+	// but computes also the smallest singular values to high relative
+	// accuracy when the matrix is badly scaled, that is, when A = B*D or
+	// A = D*B where D is diagonal and B is well-conditioned. SVDAuto
+	// never chooses SVDJacobi.
+	SVDJacobi
+)
+
+// svdDivideConquerMin is the smallest min(m,n) for which SVDAuto uses the
+// divide and conquer method when singular vectors are computed.
+const svdDivideConquerMin = 26
+
 // succFact returns whether the receiver contains a successful factorization.
 func (svd *SVD) succFact() bool {
 	return len(svd.s) != 0
@@ -71,6 +100,17 @@ func (svd *SVD) succFact() bool {
 // Factorize returns whether the decomposition succeeded. If the decomposition
 // failed, routines that require a successful factorization will panic.
 func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
+	return svd.FactorizeAlg(a, kind, SVDAuto)
+}
+
+// FactorizeAlg computes the singular value decomposition (SVD) of the input
+// matrix A using the specified algorithm. See the Factorize method for the
+// description of the decomposition and of kind.
+//
+// FactorizeAlg returns whether the decomposition succeeded. If the
+// decomposition failed, routines that require a successful factorization will
+// panic.
+func (svd *SVD) FactorizeAlg(a Matrix, kind SVDKind, alg SVDAlgorithm) (ok bool) {
 	// kill previous factorization
 	svd.s = svd.s[:0]
 	svd.kind = kind
@@ -127,15 +167,160 @@ func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 	svd.kind = kind
 	svd.s = use(svd.s, min(m, n))
 
+	if alg == SVDAuto {
+		alg = SVDQR
+		if kind != SVDNone && min(m, n) >= svdDivideConquerMin {
+			alg = SVDDivideConquer
+		}
+	}
+	switch alg {
+	default:
+		panic("mat: unknown SVD algorithm")
+	case SVDQR:
+		work := []float64{0}
+		lapack64.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, -1)
+		work = getFloats(int(work[0]), false)
+		ok = lapack64.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work))
+		putFloats(work)
+	case SVDDivideConquer:
+		ok = svd.factorizeDivideConquer(aCopy, jobU, jobVT)
+	case SVDJacobi:
+		ok = svd.factorizeJacobi(aCopy, jobU, jobVT)
+	}
+	if !ok {
+		svd.kind = 0
+	}
+	return ok
+}
+
+// factorizeDivideConquer computes the SVD of a using lapack64.Gesdd. Gesdd
+// computes the same kind of left and right singular vectors so the vectors
+// that were not requested in jobU or jobVT are computed into temporary
+// storage and discarded.
+func (svd *SVD) factorizeDivideConquer(a *Dense, jobU, jobVT lapack.SVDJob) (ok bool) {
+	m, n := a.Dims()
+	jobz := lapack.SVDNone
+	switch {
+	case jobU == lapack.SVDAll || jobVT == lapack.SVDAll:
+		jobz = lapack.SVDAll
+	case jobU == lapack.SVDStore || jobVT == lapack.SVDStore:
+		jobz = lapack.SVDStore
+	}
+	ucols, vtrows := min(m, n), min(m, n)
+	if jobz == lapack.SVDAll {
+		ucols, vtrows = m, n
+	}
+
+	u := svd.u
+	if jobU != jobz {
+		u = blas64.General{Rows: m, Cols: ucols, Stride: max(1, ucols)}
+		if jobz != lapack.SVDNone {
+			u.Data = getFloats(m*ucols, false)
+			defer putFloats(u.Data)
+		}
+	}
+	vt := svd.vt
+	if jobVT != jobz {
+		vt = blas64.General{Rows: vtrows, Cols: n, Stride: max(1, n)}
+		if jobz != lapack.SVDNone {
+			vt.Data = getFloats(vtrows*n, false)
+			defer putFloats(vt.Data)
+		}
+	}
+
+	iwork := getInts(4*min(m, n), false)
 	work := []float64{0}
-	lapack64.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, -1)
+	lapack64.Gesdd(jobz, a.mat, u, vt, svd.s, work, -1, iwork)
 	work = getFloats(int(work[0]), false)
-	ok = lapack64.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work))
+	ok = lapack64.Gesdd(jobz, a.mat, u, vt, svd.s, work, len(work), iwork)
 	putFloats(work)
+	putInts(iwork)
 	if !ok {
-		svd.kind = 0
+		return false
 	}
-	return ok
+
+	// Copy the thin vectors out of the full ones if only the thin vectors
+	// were requested.
+	if jobU == lapack.SVDStore && jobz == lapack.SVDAll {
+		for i := 0; i < m; i++ {
+			copy(svd.u.Data[i*svd.u.Stride:i*svd.u.Stride+svd.u.Cols], u.Data[i*u.Stride:])
+		}
+	}
+	if jobVT == lapack.SVDStore && jobz == lapack.SVDAll {
+		for i := 0; i < svd.vt.Rows; i++ {
+			copy(svd.vt.Data[i*svd.vt.Stride:i*svd.vt.Stride+n], vt.Data[i*vt.Stride:])
+		}
+	}
+	return true
+}
+
+// factorizeJacobi computes the SVD of a using lapack64.Gejsv. Gejsv requires
+// m >= n, so if m < n the decomposition of A^T is computed and the roles of
+// the left and right singular vectors are exchanged.
+func (svd *SVD) factorizeJacobi(a *Dense, jobU, jobVT lapack.SVDJob) (ok bool) {
+	m, n := a.Dims()
+	if m < n {
+		a = DenseCopyOf(a.T())
+		jobU, jobVT = jobVT, jobU
+	}
+	r, c := a.Dims()
+
+	// u holds the left singular vectors of the r×c matrix and v holds its
+	// right singular vectors. Gejsv returns V and not V^T, so v is
+	// transposed below if it holds the right singular vectors of A.
+	var u, v blas64.General
+	jobV := lapack.SVDNone
+	if jobVT != lapack.SVDNone {
+		jobV = lapack.SVDAll
+		v = blas64.General{Rows: c, Cols: c, Stride: c}
+	}
+	if jobU != lapack.SVDNone {
+		ucols := c
+		if jobU == lapack.SVDAll {
+			ucols = r
+		}
+		u = blas64.General{Rows: r, Cols: ucols, Stride: ucols}
+	}
+	if m >= n {
+		u.Data = svd.u.Data
+		v.Data = svd.vt.Data
+	} else {
+		// The right singular vectors of A^T are the left singular
+		// vectors of A and fit into svd.u, but the left singular vectors
+		// of A^T need to be transposed into svd.vt.
+		v.Data = svd.u.Data
+		if jobU != lapack.SVDNone {
+			u.Data = getFloats(u.Rows*u.Cols, false)
+			defer putFloats(u.Data)
+		}
+	}
+
+	iwork := getInts(r+c, false)
+	work := []float64{0}
+	lapack64.Gejsv(jobU, jobV, a.mat, u, v, svd.s, work, -1, iwork)
+	work = getFloats(int(work[0]), false)
+	ok = lapack64.Gejsv(jobU, jobV, a.mat, u, v, svd.s, work, len(work), iwork)
+	putFloats(work)
+	putInts(iwork)
+	if !ok {
+		return false
+	}
+
+	if m >= n && jobV != lapack.SVDNone {
+		for i := 0; i < c; i++ {
+			for j := i + 1; j < c; j++ {
+				v.Data[i*c+j], v.Data[j*c+i] = v.Data[j*c+i], v.Data[i*c+j]
+			}
+		}
+	}
+	if m < n && jobU != lapack.SVDNone {
+		for i := 0; i < u.Rows; i++ {
+			for j := 0; j < u.Cols; j++ {
+				svd.vt.Data[j*svd.vt.Stride+i] = u.Data[i*u.Stride+j]
+			}
+		}
+	}
+	return true
 }
 
 // Kind returns the SVDKind of the decomposition. If no decomposition has been
@@ -156,6 +341,28 @@ func (svd *SVD) Cond() float64 {
 	return svd.s[0] / svd.s[len(svd.s)-1]
 }
 
+// Rank returns the effective numerical rank of the factorized matrix, the
+// number of singular values greater than rcond times the largest singular
+// value. If rcond is negative, machine precision is used instead. Rank will
+// panic if the receiver does not contain a successful factorization.
+func (svd *SVD) Rank(rcond float64) int {
+	if !svd.succFact() {
+		panic(badFact)
+	}
+	if rcond < 0 {
+		// Use the machine precision, as Gelss does.
+		rcond = 1.0 / (1 << 53)
+	}
+	thr := rcond * svd.s[0]
+	var rank int
+	for _, v := range svd.s {
+		if v > thr {
+			rank++
+		}
+	}
+	return rank
+}
+
 // Values returns the singular values of the factorized matrix in descending order.
 //
 // If the input slice is non-nil, the values will be stored in-place into
@@ -225,7 +432,7 @@ func (svd *SVD) VTo(dst *Dense) *Dense {
 		panic(badFact)
 	}
 	kind := svd.kind
-	if kind&SVDThinU == 0 && kind&SVDFullV == 0 {
+	if kind&SVDThinV == 0 && kind&SVDFullV == 0 {
 		panic("svd: v not computed during factorization")
 	}
 	r := svd.vt.Rows
@@ -5,6 +5,7 @@
 package mat
 
 import (
+	"math"
 	"testing"
 
 	"golang.org/x/exp/rand"
@@ -168,6 +169,185 @@ func TestSVD(t *testing.T) {
 	}
 }
 
+func TestSVDRank(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, rank int
+	}{
+		{5, 5, 5},
+		{5, 5, 2},
+		{8, 3, 3},
+		{8, 3, 1},
+		{3, 8, 2},
+	} {
+		a := randRankDeficient(test.m, test.n, test.rank, rnd)
+		var svd SVD
+		if !svd.Factorize(a, SVDNone) {
+			t.Fatalf("SVD factorization failed")
+		}
+		if got := svd.Rank(1e-10); got != test.rank {
+			t.Errorf("unexpected rank for %d×%d matrix: got %d want %d", test.m, test.n, got, test.rank)
+		}
+		if got := svd.Rank(2); got != 0 {
+			t.Errorf("unexpected rank with large rcond: got %d want 0", got)
+		}
+	}
+}
+
+func TestSVDAlg(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{5, 5},
+		{5, 3},
+		{3, 5},
+		{40, 40},
+		{60, 30},
+		{30, 60},
+	} {
+		m, n := test.m, test.n
+		a := NewDense(m, n, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+		var want SVD
+		if !want.FactorizeAlg(a, SVDNone, SVDQR) {
+			t.Fatalf("SVD factorization failed")
+		}
+		wantS := want.Values(nil)
+		for _, kind := range []SVDKind{
+			SVDNone, SVDThin, SVDFull,
+			SVDThinU, SVDFullU, SVDThinV, SVDFullV,
+			SVDThinU | SVDFullV, SVDFullU | SVDThinV,
+		} {
+			for _, alg := range []SVDAlgorithm{SVDAuto, SVDQR, SVDDivideConquer, SVDJacobi} {
+				aCopy := DenseCopyOf(a)
+				var svd SVD
+				if !svd.FactorizeAlg(a, kind, alg) {
+					t.Errorf("m=%d,n=%d,kind=%d,alg=%d: SVD factorization failed", m, n, kind, alg)
+					continue
+				}
+				if !Equal(a, aCopy) {
+					t.Errorf("m=%d,n=%d,kind=%d,alg=%d: A changed during call to FactorizeAlg", m, n, kind, alg)
+				}
+				if svd.Kind() != kind {
+					t.Errorf("m=%d,n=%d,kind=%d,alg=%d: unexpected kind %d", m, n, kind, alg, svd.Kind())
+				}
+				s := svd.Values(nil)
+				if !floats.EqualApprox(s, wantS, 1e-12) {
+					t.Errorf("m=%d,n=%d,kind=%d,alg=%d: singular value mismatch", m, n, kind, alg)
+				}
+				var u, v *Dense
+				if kind&(SVDThinU|SVDFullU) != 0 {
+					u = svd.UTo(nil)
+					if !isOrthonormalColumns(u, 1e-12) {
+						t.Errorf("m=%d,n=%d,kind=%d,alg=%d: U not orthonormal", m, n, kind, alg)
+					}
+				}
+				if kind&(SVDThinV|SVDFullV) != 0 {
+					v = svd.VTo(nil)
+					if !isOrthonormalColumns(v, 1e-12) {
+						t.Errorf("m=%d,n=%d,kind=%d,alg=%d: V not orthonormal", m, n, kind, alg)
+					}
+				}
+				if u == nil || v == nil {
+					continue
+				}
+
+				// Reconstruct A from the leading min(m,n) singular vectors.
+				k := min(m, n)
+				sigma := NewDiagDense(k, s)
+				var ans Dense
+				ans.Product(u.Slice(0, m, 0, k), sigma, v.Slice(0, n, 0, k).T())
+				if !EqualApprox(&ans, a, 1e-12) {
+					t.Errorf("m=%d,n=%d,kind=%d,alg=%d: A not recovered", m, n, kind, alg)
+				}
+			}
+		}
+	}
+}
+
+func TestSVDJacobi(t *testing.T) {
+	// The singular values of Q*D where Q has orthonormal columns and D is
+	// diagonal are the diagonal elements of D. With D spread over many
+	// orders of magnitude the small singular values are lost by SVDQR, but
+	// SVDJacobi must compute all of them to high relative accuracy.
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{5, 5},
+		{10, 4},
+		{20, 12},
+		{30, 30},
+	} {
+		m, n := test.m, test.n
+		b := NewDense(m, n, nil)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = rnd.NormFloat64()
+		}
+		var qr QR
+		qr.Factorize(b)
+		var q Dense
+		qr.QTo(&q)
+
+		want := make([]float64, n)
+		for j := range want {
+			want[j] = math.Pow(10, -15*float64(j)/float64(max(1, n-1)))
+		}
+		// Scale the columns of Q in random order so that the grading
+		// of A is not aligned with the bidiagonal reduction.
+		d := make([]float64, n)
+		for j, p := range rnd.Perm(n) {
+			d[j] = want[p]
+		}
+		var a Dense
+		a.Mul(q.Slice(0, m, 0, n), NewDiagDense(n, d))
+
+		for _, trans := range []bool{false, true} {
+			var mat Matrix = &a
+			if trans {
+				mat = a.T()
+			}
+			for _, kind := range []SVDKind{SVDNone, SVDThin, SVDFull} {
+				var svd SVD
+				if !svd.FactorizeAlg(mat, kind, SVDJacobi) {
+					t.Errorf("m=%d,n=%d,trans=%t,kind=%d: SVD factorization failed", m, n, trans, kind)
+					continue
+				}
+				s := svd.Values(nil)
+				for i, v := range s {
+					if math.Abs(v-want[i]) > 1e-12*want[i] {
+						t.Errorf("m=%d,n=%d,trans=%t,kind=%d: singular value %d not accurate, got %v want %v",
+							m, n, trans, kind, i, v, want[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+// isOrthonormalColumns returns whether the columns of q are orthonormal
+// within tol.
+func isOrthonormalColumns(q *Dense, tol float64) bool {
+	_, c := q.Dims()
+	var qtq Dense
+	qtq.Mul(q.T(), q)
+	for i := 0; i < c; i++ {
+		for j := 0; j < c; j++ {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(qtq.At(i, j)-want) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func extractSVD(svd *SVD) (s []float64, u, v *Dense) {
 	return svd.Values(nil), svd.UTo(nil), svd.VTo(nil)
 }
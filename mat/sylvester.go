@@ -0,0 +1,465 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// Sylvester solves the continuous-time Sylvester equation
+//  A * X + X * B = C
+// for X, placing the result in the receiver. A must be m×m, B must be n×n and
+// C must be m×n, otherwise Sylvester will panic.
+//
+// The equation has a unique solution if and only if no eigenvalue of A is the
+// negation of an eigenvalue of B. If A and -B have common or very close
+// eigenvalues, Sylvester returns a Condition error and the receiver holds the
+// solution of a slightly perturbed equation. ErrFailedEigen is returned if the
+// Schur decomposition of a or b fails.
+func (m *Dense) Sylvester(a, b, c Matrix) error {
+	// The implementation is the Bartels-Stewart algorithm
+	// Bartels, R. H. and Stewart, G. W. Solution of the matrix equation
+	// AX + XB = C. Communications of the ACM 15(9), 820-826 (1972).
+	// https://doi.org/10.1145/361573.361582
+	ar, br := sylvesterDims(a, b, c)
+
+	var schurA, schurB Schur
+	if !schurA.Factorize(a, true) || !schurB.Factorize(b, true) {
+		return ErrFailedEigen
+	}
+	ta := schurA.TTo(nil)
+	u := schurA.ZTo(nil)
+	tb := schurB.TTo(nil)
+	v := schurB.ZTo(nil)
+
+	// Transform the equation to
+	//  T_A * Y + Y * T_B = U^T * C * V
+	// where Y = U^T * X * V, and solve it by back-substitution.
+	var f Dense
+	f.Product(u.T(), c, v)
+	scale, ok := lapack64.Trsyl(blas.NoTrans, blas.NoTrans, 1, ta.mat, tb.mat, f.mat)
+
+	m.reuseAs(ar, br)
+	m.Product(u, &f, v.T())
+	if scale != 1 {
+		m.Scale(1/scale, m)
+	}
+	if !ok {
+		return Condition(math.Inf(1))
+	}
+	return nil
+}
+
+// DiscreteSylvester solves the discrete-time Sylvester equation, also known
+// as the Stein equation,
+//  A * X * B - X = C
+// for X, placing the result in the receiver. A must be m×m, B must be n×n and
+// C must be m×n, otherwise DiscreteSylvester will panic.
+//
+// The equation has a unique solution if and only if no product of an
+// eigenvalue of A and an eigenvalue of B is equal to one. If the equation is
+// singular or nearly so, DiscreteSylvester returns a Condition error.
+// ErrFailedEigen is returned if the Schur decomposition of a or b fails.
+func (m *Dense) DiscreteSylvester(a, b, c Matrix) error {
+	ar, br := sylvesterDims(a, b, c)
+
+	var schurA, schurB Schur
+	if !schurA.Factorize(a, true) || !schurB.Factorize(b, true) {
+		return ErrFailedEigen
+	}
+	ta := schurA.TTo(nil)
+	u := schurA.ZTo(nil)
+	tb := schurB.TTo(nil)
+	v := schurB.ZTo(nil)
+
+	var f Dense
+	f.Product(u.T(), c, v)
+	err := steinQuasiTri(ta, tb, false, &f)
+
+	m.reuseAs(ar, br)
+	m.Product(u, &f, v.T())
+	return err
+}
+
+// Lyapunov solves the continuous-time Lyapunov equation
+//  A * X + X * A^T = -Q
+// for the symmetric matrix X, placing the result in the receiver. A must be
+// n×n and Q must be n×n, otherwise Lyapunov will panic.
+//
+// The equation has a unique solution if and only if no two eigenvalues of A
+// sum to zero, which holds in particular when A is stable, that is, when all
+// eigenvalues of A have negative real part. In that case, X is positive
+// semi-definite whenever Q is. If the equation is singular or nearly so,
+// Lyapunov returns a Condition error. ErrFailedEigen is returned if the Schur
+// decomposition of a fails.
+func (s *SymDense) Lyapunov(a Matrix, q Symmetric) error {
+	n, _ := sylvesterDims(a, a.T(), q)
+
+	var schur Schur
+	if !schur.Factorize(a, true) {
+		return ErrFailedEigen
+	}
+	t := schur.TTo(nil)
+	u := schur.ZTo(nil)
+
+	// Transform the equation to
+	//  T * Y + Y * T^T = -U^T * Q * U
+	// where Y = U^T * X * U.
+	var f Dense
+	f.Product(u.T(), q, u)
+	f.Scale(-1, &f)
+	scale, ok := lapack64.Trsyl(blas.NoTrans, blas.Trans, 1, t.mat, t.mat, f.mat)
+
+	var x Dense
+	x.Product(u, &f, u.T())
+	s.reuseAs(n)
+	symmetrizeScaled(s, &x, 1/scale)
+	if !ok {
+		return Condition(math.Inf(1))
+	}
+	return nil
+}
+
+// DiscreteLyapunov solves the discrete-time Lyapunov equation, also known as
+// the symmetric Stein equation,
+//  A * X * A^T - X = -Q
+// for the symmetric matrix X, placing the result in the receiver. A must be
+// n×n and Q must be n×n, otherwise DiscreteLyapunov will panic.
+//
+// The equation has a unique solution if and only if no product of two
+// eigenvalues of A is equal to one, which holds in particular when all
+// eigenvalues of A lie inside the unit circle. In that case, X is positive
+// semi-definite whenever Q is. If the equation is singular or nearly so,
+// DiscreteLyapunov returns a Condition error. ErrFailedEigen is returned if
+// the Schur decomposition of a fails.
+func (s *SymDense) DiscreteLyapunov(a Matrix, q Symmetric) error {
+	n, _ := sylvesterDims(a, a.T(), q)
+
+	var schur Schur
+	if !schur.Factorize(a, true) {
+		return ErrFailedEigen
+	}
+	t := schur.TTo(nil)
+	u := schur.ZTo(nil)
+
+	var f Dense
+	f.Product(u.T(), q, u)
+	f.Scale(-1, &f)
+	err := steinQuasiTri(t, t, true, &f)
+
+	var x Dense
+	x.Product(u, &f, u.T())
+	s.reuseAs(n)
+	symmetrizeScaled(s, &x, 1)
+	return err
+}
+
+// FactorizeLyapunov computes the Cholesky factorization of the solution X of
+// the stable continuous-time Lyapunov equation
+//  A * X + X * A^T = -B * B^T
+// storing the result into the receiver. The factor U with
+//  U^T * U = X
+// is computed directly, without forming X, which gives a factor that is more
+// accurate than the one obtained from the Cholesky factorization of the
+// solution computed by Lyapunov. A must be n×n and B must have n rows,
+// otherwise FactorizeLyapunov will panic.
+//
+// All eigenvalues of A must have negative real part, otherwise
+// FactorizeLyapunov returns ErrNotStable. X is then positive semi-definite
+// and it is positive definite if and only if the pair (A, B) is controllable.
+// If X is singular or nearly so, a Condition error is returned.
+// ErrFailedEigen is returned if the Schur decomposition of a fails.
+func (c *Cholesky) FactorizeLyapunov(a, b Matrix) error {
+	// The implementation is Hammarling's method
+	// Hammarling, S. J. Numerical solution of the stable, non-negative
+	// definite Lyapunov equation. IMA Journal of Numerical Analysis 2(3),
+	// 303-323 (1982). https://doi.org/10.1093/imanum/2.3.303
+	// applied to the real Schur form of A^T. The 2×2 diagonal blocks of
+	// the factor are obtained by solving the corresponding 2×2 Lyapunov
+	// equation and factorizing its solution.
+	n, ac := a.Dims()
+	if n != ac {
+		panic(ErrSquare)
+	}
+	br, p := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+
+	var schur Schur
+	if !schur.Factorize(a.T(), true) {
+		return ErrFailedEigen
+	}
+	for _, v := range schur.Values(nil) {
+		if real(v) >= 0 {
+			return ErrNotStable
+		}
+	}
+	s := schur.TTo(nil)
+	z := schur.ZTo(nil)
+
+	// With A^T = Z * S * Z^T the equation becomes
+	//  S^T * Y + Y * S = -R^T * R
+	// where Y = Z^T * X * Z and R is the n×n upper triangular factor of
+	// B^T * Z, padded with zero rows if B has fewer than n columns.
+	w := NewDense(max(n, p), n, nil)
+	w.Slice(0, p, 0, n).(*Dense).Mul(b.T(), z)
+	var qr QR
+	qr.Factorize(w)
+	r := NewDense(n, n, nil)
+	r.Copy(qr.RTo(nil))
+
+	// Compute the upper triangular U with Y = U^T * U one diagonal block
+	// at a time. If
+	//  S = [S11 S12]  R = [R11 R12]  U = [U11 U12]
+	//      [ 0  S22]      [ 0  R22]      [ 0  U22]
+	// then U11 is the factor of the solution of
+	//  S11^T * Y11 + Y11 * S11 = -R11^T * R11,
+	// V = U12^T solves the Sylvester equation
+	//  S22^T * V + V * M = -R12^T * W - S12^T * U11^T
+	// with W = R11 * U11^-1 and M = U11 * S11 * U11^-1, and U22 is the
+	// factor for the trailing problem with R22 replaced by the triangular
+	// factor of
+	//  R22^T * R22 + (R12 - W * U12)^T * (R12 - W * U12).
+	u := NewDense(n, n, nil)
+	work := getFloats(n, false)
+	defer putFloats(work)
+	blocks := schurBlocks(s)
+	for bk := 0; bk < len(blocks)-1; bk++ {
+		k0, k1 := blocks[bk], blocks[bk+1]
+		pk := k1 - k0
+
+		s11 := s.Slice(k0, k1, k0, k1).(*Dense)
+		r11 := r.Slice(k0, k1, k0, k1).(*Dense)
+		u11 := u.Slice(k0, k1, k0, k1).(*Dense)
+		if pk == 1 {
+			u11.set(0, 0, math.Abs(r11.at(0, 0))/math.Sqrt(-2*s11.at(0, 0)))
+		} else {
+			var y11 Dense
+			y11.Mul(r11.T(), r11)
+			y11.Scale(-1, &y11)
+			scale, _ := lapack64.Trsyl(blas.Trans, blas.NoTrans, 1, s11.mat, s11.mat, y11.mat)
+			y00 := y11.at(0, 0) / scale
+			y01 := (y11.at(0, 1) + y11.at(1, 0)) / (2 * scale)
+			y22 := y11.at(1, 1) / scale
+			if y00 > 0 {
+				u00 := math.Sqrt(y00)
+				u01 := y01 / u00
+				u11.set(0, 0, u00)
+				u11.set(0, 1, u01)
+				u11.set(1, 1, math.Sqrt(math.Max(0, y22-u01*u01)))
+			}
+		}
+		if k1 == n {
+			break
+		}
+
+		r12 := r.Slice(k0, k1, k1, n).(*Dense)
+		var y Dense
+		if u11.at(0, 0) == 0 || u11.at(pk-1, pk-1) == 0 {
+			// The leading block of Y is singular, so U12 is zero.
+			y.Clone(r12)
+		} else {
+			// Form the inverse of the triangular U11.
+			uinv := NewDense(pk, pk, nil)
+			uinv.set(0, 0, 1/u11.at(0, 0))
+			if pk == 2 {
+				uinv.set(1, 1, 1/u11.at(1, 1))
+				uinv.set(0, 1, -u11.at(0, 1)/(u11.at(0, 0)*u11.at(1, 1)))
+			}
+			var w11, m11 Dense
+			w11.Mul(r11, uinv)
+			m11.Product(u11, s11, uinv)
+
+			s12 := s.Slice(k0, k1, k1, n)
+			s22 := s.Slice(k1, n, k1, n).(*Dense)
+			var rhs, tmp Dense
+			rhs.Mul(r12.T(), &w11)
+			tmp.Mul(s12.T(), u11.T())
+			rhs.Add(&rhs, &tmp)
+			rhs.Scale(-1, &rhs)
+			scale, _ := lapack64.Trsyl(blas.Trans, blas.NoTrans, 1, s22.mat, m11.mat, rhs.mat)
+			u12 := u.Slice(k0, k1, k1, n).(*Dense)
+			u12.Scale(1/scale, rhs.T())
+
+			y.Mul(&w11, u12)
+			y.Sub(r12, &y)
+		}
+
+		// Update R22 with the rows of Y using Givens rotations.
+		m := n - k1
+		stride := r.mat.Stride
+		for i := 0; i < pk; i++ {
+			copy(work[:m], y.RawRowView(i))
+			for j := 0; j < m; j++ {
+				jj := (k1+j)*stride + k1 + j
+				cs, sn, rr, _ := blas64.Rotg(r.mat.Data[jj], work[j])
+				r.mat.Data[jj] = rr
+				if j < m-1 {
+					blas64.Rot(
+						blas64.Vector{N: m - j - 1, Data: r.mat.Data[jj+1 : jj+m-j], Inc: 1},
+						blas64.Vector{N: m - j - 1, Data: work[j+1 : m], Inc: 1},
+						cs, sn)
+				}
+			}
+		}
+	}
+
+	// X = Z * U^T * U * Z^T, so the Cholesky factor of X is the triangular
+	// factor of U * Z^T with a positive diagonal.
+	var v Dense
+	v.Mul(u, z.T())
+	qr.Factorize(&v)
+	rv := qr.RTo(nil)
+	if c.chol == nil {
+		c.chol = NewTriDense(n, Upper, nil)
+	} else {
+		c.chol = NewTriDense(n, Upper, use(c.chol.mat.Data, n*n))
+	}
+	for i := 0; i < n; i++ {
+		f := 1.0
+		if rv.at(i, i) < 0 {
+			f = -1
+		}
+		for j := i; j < n; j++ {
+			c.chol.set(i, j, f*rv.at(i, j))
+		}
+	}
+	c.updateCond(-1)
+	if c.cond > ConditionTolerance {
+		return Condition(c.cond)
+	}
+	return nil
+}
+
+// sylvesterDims checks the dimensions of the Sylvester equation with the
+// coefficients a and b and right-hand side c, and returns the orders of a and
+// b.
+func sylvesterDims(a, b, c Matrix) (m, n int) {
+	m, ac := a.Dims()
+	if m != ac {
+		panic(ErrSquare)
+	}
+	n, bc := b.Dims()
+	if n != bc {
+		panic(ErrSquare)
+	}
+	if cr, cc := c.Dims(); cr != m || cc != n {
+		panic(ErrShape)
+	}
+	return m, n
+}
+
+// symmetrizeScaled stores the symmetric part of the n×n matrix x scaled by f
+// into the n×n receiver s.
+func symmetrizeScaled(s *SymDense, x *Dense, f float64) {
+	n := s.Symmetric()
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			s.set(i, j, f*(x.at(i, j)+x.at(j, i))/2)
+		}
+	}
+}
+
+// steinQuasiTri solves the discrete-time Sylvester equation
+//  S * Y * op(T) - Y = F
+// where S and T are upper quasi-triangular matrices in real Schur canonical
+// form and op(T) is T if trans is false and T^T otherwise. On entry f holds
+// the right-hand side F and on return it is overwritten with Y.
+func steinQuasiTri(s, t *Dense, trans bool, f *Dense) error {
+	// The blocks of Y are computed in the order used by the Bartels-Stewart
+	// algorithm. Since S is upper quasi-triangular, the rows of Y are
+	// computed bottom to top. The columns are computed left to right if
+	// op(T) is upper quasi-triangular and right to left otherwise.
+	m, _ := s.Dims()
+	n, _ := t.Dims()
+	sb := schurBlocks(s)
+	tb := schurBlocks(t)
+	nsb := len(sb) - 1
+	ntb := len(tb) - 1
+
+	var p, tmp, rhs, sys, vec, x Dense
+	for lb := 0; lb < ntb; lb++ {
+		l0, l1 := tb[lb], tb[lb+1]
+		if trans {
+			l0, l1 = tb[ntb-1-lb], tb[ntb-lb]
+		}
+		nl := l1 - l0
+
+		// Form P = Y_J * op(T)_Jl where J holds the columns of Y that
+		// have already been computed, and tll = op(T_ll)^T.
+		var tll Matrix = t.Slice(l0, l1, l0, l1)
+		p.Reset()
+		p.reuseAsZeroed(m, nl)
+		if !trans {
+			tll = tll.T()
+			if l0 > 0 {
+				p.Mul(f.Slice(0, m, 0, l0), t.Slice(0, l0, l0, l1))
+			}
+		} else if l1 < n {
+			p.Mul(f.Slice(0, m, l1, n), t.Slice(l0, l1, l1, n).T())
+		}
+
+		for kb := nsb - 1; kb >= 0; kb-- {
+			k0, k1 := sb[kb], sb[kb+1]
+			nk := k1 - k0
+
+			// Form the right-hand side
+			//  F_kl - S_k,k: * P_k:,l - S_k,k1: * Y_k1:,l * op(T_ll).
+			rhs.Reset()
+			rhs.Clone(f.Slice(k0, k1, l0, l1))
+			tmp.Reset()
+			tmp.Mul(s.Slice(k0, k1, k0, m), p.Slice(k0, m, 0, nl))
+			rhs.Sub(&rhs, &tmp)
+			if k1 < m {
+				var sy Dense
+				sy.Mul(s.Slice(k0, k1, k1, m), f.Slice(k1, m, l0, l1))
+				tmp.Reset()
+				tmp.Mul(&sy, tll.T())
+				rhs.Sub(&rhs, &tmp)
+			}
+
+			// Solve the Kronecker product system
+			//  (S_kk ⊗ op(T_ll)^T - I) * vec(Y_kl) = vec(rhs)
+			// with vec taken row-wise.
+			sz := nk * nl
+			sys.Reset()
+			sys.reuseAsZeroed(sz, sz)
+			for i := 0; i < nk; i++ {
+				for j := 0; j < nl; j++ {
+					row := i*nl + j
+					for a := 0; a < nk; a++ {
+						for b := 0; b < nl; b++ {
+							sys.set(row, a*nl+b, s.at(k0+i, k0+a)*tll.At(j, b))
+						}
+					}
+					sys.set(row, row, sys.at(row, row)-1)
+				}
+			}
+			vec.Reset()
+			vec.reuseAs(sz, 1)
+			for i := 0; i < nk; i++ {
+				for j := 0; j < nl; j++ {
+					vec.set(i*nl+j, 0, rhs.at(i, j))
+				}
+			}
+			x.Reset()
+			err := x.Solve(&sys, &vec)
+			if err != nil {
+				return err
+			}
+			for i := 0; i < nk; i++ {
+				for j := 0; j < nl; j++ {
+					f.set(k0+i, l0+j, x.at(i*nl+j, 0))
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+// randStable returns a random n×n matrix whose eigenvalues have negative
+// real part.
+func randStable(n int, rnd *rand.Rand) *Dense {
+	a := randNormDense(n, n, rnd)
+	shift := Norm(a, 2) + 1
+	for i := 0; i < n; i++ {
+		a.set(i, i, a.at(i, i)-shift)
+	}
+	return a
+}
+
+// randContractive returns a random n×n matrix whose eigenvalues lie inside
+// the unit circle.
+func randContractive(n int, rnd *rand.Rand) *Dense {
+	a := randNormDense(n, n, rnd)
+	a.Scale(0.9/Norm(a, 2), a)
+	return a
+}
+
+func TestDenseSylvester(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{1, 2, 3, 5, 10, 40} {
+		for _, n := range []int{1, 2, 3, 7, 20} {
+			a := randNormDense(m, m, rnd)
+			b := randStable(n, rnd)
+			// Shift A so that A and -B have no common eigenvalues.
+			shift := Norm(a, 2) + 1
+			for i := 0; i < m; i++ {
+				a.set(i, i, a.at(i, i)+shift)
+			}
+			c := randNormDense(m, n, rnd)
+
+			var x Dense
+			err := x.Sylvester(a, b.T(), c)
+			if err != nil {
+				t.Errorf("m=%d,n=%d: unexpected error: %v", m, n, err)
+				continue
+			}
+			var got, tmp Dense
+			got.Mul(a, &x)
+			tmp.Mul(&x, b.T())
+			got.Add(&got, &tmp)
+			if !EqualApprox(&got, c, 1e-12*float64(m+n)) {
+				t.Errorf("m=%d,n=%d: A*X + X*B != C", m, n)
+			}
+		}
+	}
+}
+
+func TestDenseDiscreteSylvester(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{1, 2, 3, 5, 10, 40} {
+		for _, n := range []int{1, 2, 3, 7, 20} {
+			a := randContractive(m, rnd)
+			b := randContractive(n, rnd)
+			c := randNormDense(m, n, rnd)
+
+			var x Dense
+			err := x.DiscreteSylvester(a, b, c)
+			if err != nil {
+				t.Errorf("m=%d,n=%d: unexpected error: %v", m, n, err)
+				continue
+			}
+			var got Dense
+			got.Product(a, &x, b)
+			got.Sub(&got, &x)
+			if !EqualApprox(&got, c, 1e-12*float64(m+n)) {
+				t.Errorf("m=%d,n=%d: A*X*B - X != C", m, n)
+			}
+		}
+	}
+
+	// The equation is singular when the product of eigenvalues of A and B
+	// is one.
+	var x Dense
+	err := x.DiscreteSylvester(NewDense(1, 1, []float64{2}), NewDense(1, 1, []float64{0.5}), NewDense(1, 1, []float64{1}))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("unexpected error for singular equation: got %v, want Condition", err)
+	}
+}
+
+func TestSymDenseLyapunov(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 40} {
+		for k := 0; k < 5; k++ {
+			a := randStable(n, rnd)
+			q := randSPD(n, rnd)
+
+			var x SymDense
+			err := x.Lyapunov(a, q)
+			if err != nil {
+				t.Errorf("n=%d: unexpected error: %v", n, err)
+				continue
+			}
+			var got, tmp Dense
+			got.Mul(a, &x)
+			tmp.Mul(&x, a.T())
+			got.Add(&got, &tmp)
+			got.Scale(-1, &got)
+			if !EqualApprox(&got, q, 1e-12*float64(n)) {
+				t.Errorf("n=%d: A*X + X*A^T != -Q", n)
+			}
+			var chol Cholesky
+			if !chol.Factorize(&x) {
+				t.Errorf("n=%d: solution not positive definite", n)
+			}
+		}
+	}
+}
+
+func TestSymDenseDiscreteLyapunov(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 40} {
+		for k := 0; k < 5; k++ {
+			a := randContractive(n, rnd)
+			q := randSPD(n, rnd)
+
+			var x SymDense
+			err := x.DiscreteLyapunov(a, q)
+			if err != nil {
+				t.Errorf("n=%d: unexpected error: %v", n, err)
+				continue
+			}
+			var got Dense
+			got.Product(a, &x, a.T())
+			got.Sub(&got, &x)
+			got.Scale(-1, &got)
+			if !EqualApprox(&got, q, 1e-12*float64(n)) {
+				t.Errorf("n=%d: A*X*A^T - X != -Q", n)
+			}
+			var chol Cholesky
+			if !chol.Factorize(&x) {
+				t.Errorf("n=%d: solution not positive definite", n)
+			}
+		}
+	}
+}
+
+func TestCholeskyFactorizeLyapunov(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, p int
+	}{
+		{1, 1}, {2, 1}, {3, 1}, {5, 1},
+		{2, 2}, {3, 2}, {10, 2}, {10, 4},
+		{3, 3}, {10, 10}, {40, 40}, {5, 8},
+	} {
+		n, p := test.n, test.p
+		for k := 0; k < 5; k++ {
+			a := randStable(n, rnd)
+			b := randNormDense(n, p, rnd)
+
+			var chol Cholesky
+			err := chol.FactorizeLyapunov(a, b)
+			if err != nil {
+				t.Errorf("n=%d,p=%d: unexpected error: %v", n, p, err)
+				continue
+			}
+			u := chol.UTo(nil)
+			for i := 0; i < n; i++ {
+				if u.At(i, i) < 0 {
+					t.Errorf("n=%d,p=%d: negative diagonal element of U", n, p)
+					break
+				}
+			}
+
+			var q SymDense
+			q.SymOuterK(1, b)
+			var want SymDense
+			err = want.Lyapunov(a, &q)
+			if err != nil {
+				t.Errorf("n=%d,p=%d: unexpected error from Lyapunov: %v", n, p, err)
+				continue
+			}
+			got := chol.ToSym(nil)
+			tol := 1e-12 * float64(n) * math.Max(1, Norm(&want, math.Inf(1)))
+			if !EqualApprox(got, &want, tol) {
+				t.Errorf("n=%d,p=%d: U^T*U differs from the solution of the Lyapunov equation", n, p)
+			}
+		}
+	}
+
+	// Unstable matrices are rejected.
+	var chol Cholesky
+	err := chol.FactorizeLyapunov(NewDense(2, 2, []float64{-1, 1, 0, 1}), NewDense(2, 1, []float64{1, 1}))
+	if err != ErrNotStable {
+		t.Errorf("unexpected error for unstable matrix: got %v, want %v", err, ErrNotStable)
+	}
+}
@@ -111,6 +111,25 @@ func (s *SymBandDense) Dims() (r, c int) {
 	return s.mat.N, s.mat.N
 }
 
+// IsZero returns whether the receiver is zero-sized. Zero-sized matrices can be the
+// receiver for size-restricted operations. SymBandDense matrices can be zeroed using Reset.
+func (s *SymBandDense) IsZero() bool {
+	// It must be the case that s.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return s.mat.Stride == 0
+}
+
+// Reset zeros the dimensions of the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (s *SymBandDense) Reset() {
+	s.mat.N = 0
+	s.mat.K = 0
+	s.mat.Stride = 0
+	s.mat.Data = s.mat.Data[:0]
+}
+
 // Symmetric returns the size of the receiver.
 func (s *SymBandDense) Symmetric() int {
 	return s.mat.N
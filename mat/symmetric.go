@@ -570,10 +570,83 @@ func (s *SymDense) GrowSym(n int) Symmetric {
 }
 
 // PowPSD computes a^pow where a is a positive symmetric definite matrix.
+// If pow is positive, a may be positive semi-definite.
 //
-// PowPSD returns an error if the matrix is not  not positive symmetric definite
+// Eigenvalues of a within n*eps*max|λ| of zero, where eps is the machine
+// epsilon, can not be distinguished from zero and are treated as zero.
+//
+// PowPSD returns an error if the matrix is not positive symmetric definite
 // or the Eigendecomposition is not successful.
 func (s *SymDense) PowPSD(a Symmetric, pow float64) error {
+	return s.eigenFunc(a, func(v, tol float64) (float64, error) {
+		if v <= tol {
+			if v < -tol || pow <= 0 {
+				return 0, ErrNotPSD
+			}
+			return 0, nil
+		}
+		return math.Pow(v, pow), nil
+	})
+}
+
+// SqrtPSD computes the unique positive semi-definite square root of the
+// positive semi-definite matrix a, placing the result in the receiver.
+//
+// Eigenvalues of a within n*eps*max|λ| of zero, where eps is the machine
+// epsilon, can not be distinguished from zero and are treated as zero.
+//
+// SqrtPSD returns an error if the matrix has a negative eigenvalue or the
+// eigendecomposition is not successful.
+func (s *SymDense) SqrtPSD(a Symmetric) error {
+	return s.eigenFunc(a, func(v, tol float64) (float64, error) {
+		if v <= tol {
+			if v < -tol {
+				return 0, ErrNotPSD
+			}
+			return 0, nil
+		}
+		return math.Sqrt(v), nil
+	})
+}
+
+// LogPSD computes the principal logarithm of the positive definite matrix a,
+// placing the result in the receiver. The result is symmetric.
+//
+// LogPSD can be used to compute geodesics on the manifold of positive
+// definite matrices. For example, the affine-invariant distance between
+// positive definite matrices A and B is |log(A^{-1/2} * B * A^{-1/2})|_F.
+//
+// LogPSD returns an error if the matrix is not positive symmetric definite
+// or the eigendecomposition is not successful.
+func (s *SymDense) LogPSD(a Symmetric) error {
+	return s.eigenFunc(a, func(v, _ float64) (float64, error) {
+		if v <= 0 {
+			return 0, ErrNotPSD
+		}
+		return math.Log(v), nil
+	})
+}
+
+// FuncSym computes the matrix function f(a) of the symmetric matrix a,
+// placing the result in the receiver. If a has the eigendecomposition
+//  A = V * Λ * V^T
+// then
+//  f(A) = V * f(Λ) * V^T
+// where f is applied to each eigenvalue on the diagonal of Λ.
+//
+// FuncSym returns ErrFailedEigen if the eigendecomposition is not successful.
+func (s *SymDense) FuncSym(f func(float64) float64, a Symmetric) error {
+	return s.eigenFunc(a, func(v, _ float64) (float64, error) {
+		return f(v), nil
+	})
+}
+
+// eigenFunc computes the matrix function of the symmetric matrix a defined
+// by the eigenvalue map f, placing the result in the receiver. f is called
+// with each eigenvalue and the tolerance n*eps*max|λ| below which the
+// eigenvalue is indistinguishable from zero. If f returns a non-nil error
+// for any eigenvalue, eigenFunc returns that error.
+func (s *SymDense) eigenFunc(a Symmetric, f func(v, tol float64) (float64, error)) error {
 	dim := a.Symmetric()
 	s.reuseAs(dim)
 
@@ -583,11 +656,17 @@ func (s *SymDense) PowPSD(a Symmetric, pow float64) error {
 		return ErrFailedEigen
 	}
 	values := eigen.Values(nil)
+	var vmax float64
+	for _, v := range values {
+		vmax = math.Max(vmax, math.Abs(v))
+	}
+	tol := float64(dim) * machEps * vmax
 	for i, v := range values {
-		if v <= 0 {
-			return ErrNotPSD
+		var err error
+		values[i], err = f(v, tol)
+		if err != nil {
+			return err
 		}
-		values[i] = math.Pow(v, pow)
 	}
 	u := eigen.VectorsTo(nil)
 
@@ -0,0 +1,323 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+var (
+	symDense32 *SymDense32
+
+	_ Matrix32    = symDense32
+	_ Symmetric32 = symDense32
+)
+
+// SymDense32 is a single precision symmetric matrix that uses dense storage.
+// SymDense32 matrices are stored in the upper triangle.
+type SymDense32 struct {
+	mat blas32.Symmetric
+	cap int
+}
+
+// NewSymDense32 creates a new single precision symmetric matrix of size n×n.
+// If data == nil, a new slice is allocated for the backing slice. If
+// len(data) == n*n, data is used as the backing slice, and changes to the
+// elements of the returned SymDense32 will be reflected in data. If neither of
+// these is true, NewSymDense32 will panic.
+// NewSymDense32 will panic if n is zero.
+//
+// The data must be arranged in row-major order, i.e. the (i*c + j)-th
+// element in the data slice is the {i, j}-th element in the matrix.
+// Only the values in the upper triangular portion of the matrix are used.
+func NewSymDense32(n int, data []float32) *SymDense32 {
+	if n <= 0 {
+		if n == 0 {
+			panic(ErrZeroLength)
+		}
+		panic("mat: negative dimension")
+	}
+	if data != nil && n*n != len(data) {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]float32, n*n)
+	}
+	return &SymDense32{
+		mat: blas32.Symmetric{
+			N:      n,
+			Stride: n,
+			Data:   data,
+			Uplo:   blas.Upper,
+		},
+		cap: n,
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (s *SymDense32) Dims() (r, c int) {
+	return s.mat.N, s.mat.N
+}
+
+// Caps returns the number of rows and columns in the backing matrix.
+func (s *SymDense32) Caps() (r, c int) {
+	return s.cap, s.cap
+}
+
+// T returns the receiver, the transpose of a symmetric matrix.
+func (s *SymDense32) T() Matrix32 {
+	return s
+}
+
+// Symmetric implements the Symmetric32 interface and returns the number of rows
+// and columns in the matrix.
+func (s *SymDense32) Symmetric() int {
+	return s.mat.N
+}
+
+// RawSymmetric returns the matrix as a blas32.Symmetric. The returned
+// value must be stored in upper triangular format.
+func (s *SymDense32) RawSymmetric() blas32.Symmetric {
+	return s.mat
+}
+
+// SetRawSymmetric sets the underlying blas32.Symmetric used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in the input.
+//
+// The supplied Symmetric must use blas.Upper storage format.
+func (s *SymDense32) SetRawSymmetric(mat blas32.Symmetric) {
+	if mat.Uplo != blas.Upper {
+		panic(badSymTriangle)
+	}
+	s.mat = mat
+	s.cap = mat.N
+}
+
+// Reset zeros the dimensions of the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (s *SymDense32) Reset() {
+	// N and Stride must be zeroed in unison.
+	s.mat.N, s.mat.Stride = 0, 0
+	s.mat.Data = s.mat.Data[:0]
+}
+
+// Zero sets all of the matrix elements to zero.
+func (s *SymDense32) Zero() {
+	for i := 0; i < s.mat.N; i++ {
+		zero32(s.mat.Data[i*s.mat.Stride+i : i*s.mat.Stride+s.mat.N])
+	}
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized matrices can be the
+// receiver for size-restricted operations. SymDense32 matrices can be zeroed using Reset.
+func (s *SymDense32) IsZero() bool {
+	// It must be the case that m.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return s.mat.N == 0
+}
+
+// reuseAs resizes an empty matrix to a n×n matrix,
+// or checks that a non-empty matrix is n×n.
+func (s *SymDense32) reuseAs(n int) {
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	if s.mat.N > s.cap {
+		panic(badSymCap)
+	}
+	if s.IsZero() {
+		s.mat = blas32.Symmetric{
+			N:      n,
+			Stride: n,
+			Data:   use32(s.mat.Data, n*n),
+			Uplo:   blas.Upper,
+		}
+		s.cap = n
+		return
+	}
+	if s.mat.Uplo != blas.Upper {
+		panic(badSymTriangle)
+	}
+	if s.mat.N != n {
+		panic(ErrShape)
+	}
+}
+
+// CopySym makes a copy of elements of a into the receiver. It is similar to the
+// built-in copy; it copies as much as the overlap between the two matrices and
+// returns the number of rows and columns it copied.
+func (s *SymDense32) CopySym(a Symmetric32) int {
+	n := a.Symmetric()
+	n = min(n, s.mat.N)
+	if n == 0 {
+		return 0
+	}
+	switch a := a.(type) {
+	case *SymDense32:
+		amat := a.mat
+		if amat.Uplo != blas.Upper {
+			panic(badSymTriangle)
+		}
+		for i := 0; i < n; i++ {
+			copy(s.mat.Data[i*s.mat.Stride+i:i*s.mat.Stride+n], amat.Data[i*amat.Stride+i:i*amat.Stride+n])
+		}
+	default:
+		for i := 0; i < n; i++ {
+			stmp := s.mat.Data[i*s.mat.Stride : i*s.mat.Stride+n]
+			for j := i; j < n; j++ {
+				stmp[j] = a.At(i, j)
+			}
+		}
+	}
+	return n
+}
+
+// CloneFromSym64 makes a copy of the float64 symmetric matrix a into the
+// receiver, rounding each element to the nearest float32 value and
+// overwriting the previous value of the receiver.
+func (s *SymDense32) CloneFromSym64(a Symmetric) {
+	n := a.Symmetric()
+	s.mat = blas32.Symmetric{
+		N:      n,
+		Stride: n,
+		Data:   use32(s.mat.Data, n*n),
+		Uplo:   blas.Upper,
+	}
+	s.cap = n
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			s.mat.Data[i*n+j] = float32(a.At(i, j))
+		}
+	}
+}
+
+// To64 copies the elements of the receiver into dst as float64 values and
+// returns the result. If dst is nil, a new SymDense is allocated, otherwise
+// dst must be zero-sized or have the same size as the receiver.
+func (s *SymDense32) To64(dst *SymDense) *SymDense {
+	n := s.mat.N
+	if dst == nil {
+		dst = NewSymDense(n, nil)
+	} else {
+		dst.reuseAs(n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			dst.set(i, j, float64(s.mat.Data[i*s.mat.Stride+j]))
+		}
+	}
+	return dst
+}
+
+// AddSym adds the symmetric matrices a and b, placing the result in the
+// receiver.
+func (s *SymDense32) AddSym(a, b Symmetric32) {
+	n := a.Symmetric()
+	if n != b.Symmetric() {
+		panic(ErrShape)
+	}
+	s.reuseAs(n)
+
+	if a, ok := a.(*SymDense32); ok {
+		if b, ok := b.(*SymDense32); ok {
+			amat, bmat := a.mat, b.mat
+			if s != a {
+				s.checkOverlap(generalFromSymmetric32(amat))
+			}
+			if s != b {
+				s.checkOverlap(generalFromSymmetric32(bmat))
+			}
+			for i := 0; i < n; i++ {
+				btmp := bmat.Data[i*bmat.Stride+i : i*bmat.Stride+n]
+				stmp := s.mat.Data[i*s.mat.Stride+i : i*s.mat.Stride+n]
+				for j, v := range amat.Data[i*amat.Stride+i : i*amat.Stride+n] {
+					stmp[j] = v + btmp[j]
+				}
+			}
+			return
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		stmp := s.mat.Data[i*s.mat.Stride : i*s.mat.Stride+n]
+		for j := i; j < n; j++ {
+			stmp[j] = a.At(i, j) + b.At(i, j)
+		}
+	}
+}
+
+// ScaleSym multiplies the elements of a by f, placing the result in the receiver.
+func (s *SymDense32) ScaleSym(f float32, a Symmetric32) {
+	n := a.Symmetric()
+	s.reuseAs(n)
+	if a, ok := a.(*SymDense32); ok {
+		amat := a.mat
+		if s != a {
+			s.checkOverlap(generalFromSymmetric32(amat))
+		}
+		for i := 0; i < n; i++ {
+			stmp := s.mat.Data[i*s.mat.Stride+i : i*s.mat.Stride+n]
+			for j, v := range amat.Data[i*amat.Stride+i : i*amat.Stride+n] {
+				stmp[j] = f * v
+			}
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			s.mat.Data[i*s.mat.Stride+j] = f * a.At(i, j)
+		}
+	}
+}
+
+// SymOuterK calculates the outer product of x with itself and stores
+// the result into the receiver. It is equivalent to the matrix
+// multiplication
+//  s = alpha * x * x^T.
+func (s *SymDense32) SymOuterK(alpha float32, x Matrix32) {
+	n, _ := x.Dims()
+	switch {
+	case s.IsZero():
+		s.mat = blas32.Symmetric{
+			N:      n,
+			Stride: n,
+			Data:   use32(s.mat.Data, n*n),
+			Uplo:   blas.Upper,
+		}
+		s.cap = n
+	case s.mat.Uplo != blas.Upper:
+		panic(badSymTriangle)
+	case s.mat.N == n:
+		if s == x {
+			w := NewSymDense32(n, nil)
+			w.SymOuterK(alpha, x)
+			s.CopySym(w)
+			return
+		}
+		if rm, ok := x.(RawMatrixer32); ok {
+			s.checkOverlap(rm.RawMatrix())
+		}
+	default:
+		panic(ErrShape)
+	}
+
+	xU, trans := untranspose32(x)
+	var g blas32.General
+	if rm, ok := xU.(RawMatrixer32); ok {
+		g = rm.RawMatrix()
+	} else {
+		g = Dense32CopyOf(x).mat
+		trans = false
+	}
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	blas32.Syrk(t, alpha, g, 0, s.mat)
+}
@@ -5,8 +5,11 @@
 package mat
 
 import (
+	"math"
+
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
 )
 
 var (
@@ -365,3 +368,96 @@ func (t *TriBandDense) Trace() float64 {
 	}
 	return tr
 }
+
+// SolveTo solves a triangular system T * X = B or T^T * X = B where T is an
+// n×n triangular band matrix represented by the receiver and B is a given
+// n×nrhs matrix. The solution X is stored into dst. The system is solved
+// directly in band storage, so the cost is proportional to n*k*nrhs where k
+// is the bandwidth of T.
+//
+// If T is singular or near-singular a Condition error is returned. See the
+// documentation for Condition for more information.
+func (t *TriBandDense) SolveTo(dst *Dense, trans bool, b Matrix) error {
+	return solveTriBand(dst, t.mat, trans, b)
+}
+
+// SolveVecTo solves a triangular system T * x = b or T^T * x = b where T is an
+// n×n triangular band matrix represented by the receiver and b is a given
+// n-vector. The solution x is stored into dst.
+//
+// If T is singular or near-singular a Condition error is returned. See the
+// documentation for Condition for more information.
+func (t *TriBandDense) SolveVecTo(dst *VecDense, trans bool, b Vector) error {
+	n, _ := t.Dims()
+	if br, bc := b.Dims(); br != n || bc != 1 {
+		panic(ErrShape)
+	}
+	switch rv := b.(type) {
+	default:
+		dst.reuseAs(n)
+		return t.SolveTo(dst.asDense(), trans, b)
+	case RawVectorer:
+		if dst != b {
+			dst.checkOverlap(rv.RawVector())
+		}
+		dst.reuseAs(n)
+		if dst != b {
+			dst.CopyVec(b)
+		}
+		tA := blas.NoTrans
+		if trans {
+			tA = blas.Trans
+		}
+		ok := lapack64.Tbtrs(tA, t.mat, dst.asGeneral())
+		if !ok {
+			return Condition(math.Inf(1))
+		}
+		return triBandCond(t.mat)
+	}
+}
+
+// solveTriBand solves op(T) * X = B where T is the triangular band matrix
+// tb, and stores the result into dst.
+func solveTriBand(dst *Dense, tb blas64.TriangularBand, trans bool, b Matrix) error {
+	n := tb.N
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+
+	dst.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	var restore func()
+	if dst == bU {
+		dst, restore = dst.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		dst.checkOverlap(rm.RawMatrix())
+	}
+
+	dst.Copy(b)
+	tA := blas.NoTrans
+	if trans {
+		tA = blas.Trans
+	}
+	ok := lapack64.Tbtrs(tA, tb, dst.mat)
+	if !ok {
+		return Condition(math.Inf(1))
+	}
+	return triBandCond(tb)
+}
+
+// triBandCond returns a Condition error if the estimated condition number of
+// the triangular band matrix tb exceeds ConditionTolerance, and nil otherwise.
+func triBandCond(tb blas64.TriangularBand) error {
+	work := getFloats(2*tb.N, false)
+	iwork := getInts(tb.N, false)
+	rcond := lapack64.Tbcon(CondNorm, tb, work, iwork)
+	putFloats(work)
+	putInts(iwork)
+	cond := 1 / rcond
+	if cond > ConditionTolerance {
+		return Condition(cond)
+	}
+	return nil
+}
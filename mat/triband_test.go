@@ -5,9 +5,12 @@
 package mat
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
+	"golang.org/x/exp/rand"
+
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
 )
@@ -410,3 +413,74 @@ func TestTriBandDiagView(t *testing.T) {
 		testDiagView(t, cas, test)
 	}
 }
+
+func TestTriBandDenseSolveTo(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 50} {
+		for _, k := range []int{0, 1, 3} {
+			k := min(k, n-1)
+			for _, kind := range []TriKind{Upper, Lower} {
+				a := NewTriBandDense(n, k, kind, nil)
+				for i := 0; i < n; i++ {
+					for j := max(0, i-k); j <= min(n-1, i+k); j++ {
+						if (kind == Upper && j >= i) || (kind == Lower && j <= i) {
+							a.SetTriBand(i, j, rnd.NormFloat64())
+						}
+					}
+					a.SetTriBand(i, i, math.Copysign(2+rnd.Float64(), a.At(i, i)))
+				}
+				for _, trans := range []bool{false, true} {
+					var op Matrix = a
+					if trans {
+						op = a.T()
+					}
+					want := randNormDense(n, 3, rnd)
+					var b Dense
+					b.Mul(op, want)
+
+					var x Dense
+					err := a.SolveTo(&x, trans, &b)
+					if err != nil {
+						t.Errorf("n=%d,k=%d,kind=%t,trans=%t: unexpected error: %v", n, k, kind, trans, err)
+						continue
+					}
+					if !EqualApprox(&x, want, 1e-12) {
+						t.Errorf("n=%d,k=%d,kind=%t,trans=%t: unexpected solution", n, k, kind, trans)
+					}
+
+					// Dense.Solve must use the band structure.
+					var y Dense
+					err = y.Solve(op, &b)
+					if err != nil {
+						t.Errorf("n=%d,k=%d,kind=%t,trans=%t: unexpected error from Dense.Solve: %v", n, k, kind, trans, err)
+						continue
+					}
+					if !Equal(&x, &y) {
+						t.Errorf("n=%d,k=%d,kind=%t,trans=%t: mismatch between SolveTo and Dense.Solve", n, k, kind, trans)
+					}
+
+					wantVec := randNormVec(n, rnd)
+					var bv VecDense
+					bv.MulVec(op, wantVec)
+					var xv VecDense
+					err = a.SolveVecTo(&xv, trans, &bv)
+					if err != nil {
+						t.Errorf("n=%d,k=%d,kind=%t,trans=%t: unexpected error for vector solve: %v", n, k, kind, trans, err)
+						continue
+					}
+					if !EqualApprox(&xv, wantVec, 1e-12) {
+						t.Errorf("n=%d,k=%d,kind=%t,trans=%t: unexpected vector solution", n, k, kind, trans)
+					}
+				}
+			}
+		}
+	}
+
+	// A zero on the diagonal must be reported.
+	a := NewTriBandDense(2, 1, Upper, []float64{1, 2, 0, 0})
+	var x Dense
+	err := a.SolveTo(&x, false, NewDense(2, 1, []float64{1, 1}))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("unexpected error for singular matrix: got %v, want Condition", err)
+	}
+}
@@ -0,0 +1,447 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const (
+	// lanczosSVDTol is the relative tolerance on the residual norms
+	// of the singular triplets computed by FactorizeLanczos.
+	lanczosSVDTol = 1e-12
+
+	// lanczosSVDMaxIter is the maximum number of restarts performed by
+	// FactorizeLanczos.
+	lanczosSVDMaxIter = 1000
+
+	// machEps is the machine epsilon for float64.
+	machEps = 1.0 / (1 << 53)
+)
+
+// TruncatedSVD is a type for creating and using a truncated singular value
+// decomposition of a matrix, holding its k largest singular values and the
+// corresponding singular vectors.
+//
+// The truncated SVD of rank k of an m×n matrix A is
+//  A_k = U_k * Σ_k * V_k^T,
+// where Σ_k is the k×k diagonal matrix of the k largest singular values of A,
+// and the columns of the m×k matrix U_k and the n×k matrix V_k are the
+// corresponding left and right singular vectors. A_k is the best rank k
+// approximation of A in the 2-norm and the Frobenius norm.
+//
+// The truncated SVD only accesses A through matrix products, so it is
+// efficient for large matrices, and sparse matrices, of low numerical rank.
+type TruncatedSVD struct {
+	kind SVDKind
+
+	s []float64
+	u Dense
+	v Dense
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (t *TruncatedSVD) succFact() bool {
+	return len(t.s) != 0
+}
+
+// FactorizeRandomized computes the truncated SVD of rank k of the m×n matrix
+// a using randomized range finding. The singular values are computed in all
+// cases, while the singular vectors are computed depending on kind, which
+// must be SVDNone, SVDThinU, SVDThinV or SVDThin.
+//
+// An orthonormal basis for the range of A is found by multiplying A by an
+// n×(k+oversample) Gaussian random matrix, improved by power iterations with
+// A*A^T, and the SVD is computed from the projection of A onto the basis.
+// An oversampling of 5 to 10 and one or two power iterations are usually
+// sufficient, more power iterations give more accurate results when the
+// singular values of A decay slowly. The random matrix is generated using
+// the provided source, or the default source from golang.org/x/exp/rand if
+// src is nil.
+//
+// The method is described in
+//  Halko, N., Martinsson, P. G., & Tropp, J. A. (2011). Finding structure with
+//  randomness: Probabilistic algorithms for constructing approximate matrix
+//  decompositions. SIAM review, 53(2), 217-288.
+//
+// FactorizeRandomized panics if k is not in the range [1, min(m,n)], if
+// oversample or power is negative or if kind is invalid. FactorizeRandomized
+// returns whether the decomposition succeeded. If the decomposition failed,
+// routines that require a successful factorization will panic.
+func (t *TruncatedSVD) FactorizeRandomized(a Matrix, k int, kind SVDKind, oversample, power int, src rand.Source) (ok bool) {
+	m, n := a.Dims()
+	t.reset(m, n, k, kind)
+	if oversample < 0 || power < 0 {
+		panic("mat: negative oversampling or power iterations")
+	}
+	normFloat64 := rand.NormFloat64
+	if src != nil {
+		normFloat64 = rand.New(src).NormFloat64
+	}
+
+	l := min(k+oversample, min(m, n))
+	omega := NewDense(n, l, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < l; j++ {
+			omega.set(i, j, normFloat64())
+		}
+	}
+
+	// Find an orthonormal basis Q for the range of A*Ω, using
+	// orthonormalization between the power iterations to preserve the
+	// information in the small singular values.
+	var y, z Dense
+	y.Mul(a, omega)
+	orthonormalize(&y)
+	for i := 0; i < power; i++ {
+		z.Mul(a.T(), &y)
+		orthonormalize(&z)
+		y.Mul(a, &z)
+		orthonormalize(&y)
+	}
+
+	// Compute the SVD of the small matrix B = Q^T*A.
+	var b Dense
+	b.Mul(y.T(), a)
+	var svd SVD
+	ok = svd.Factorize(&b, SVDThin)
+	if !ok {
+		t.s = t.s[:0]
+		return false
+	}
+	copy(t.s, svd.s[:k])
+	if kind&SVDThinU != 0 {
+		ub := svd.UTo(nil)
+		t.u.Mul(&y, ub.Slice(0, l, 0, k))
+	}
+	if kind&SVDThinV != 0 {
+		vb := svd.VTo(nil)
+		t.v.Clone(vb.Slice(0, n, 0, k))
+	}
+	return true
+}
+
+// FactorizeLanczos computes the truncated SVD of rank k of the m×n matrix a
+// using Golub-Kahan-Lanczos bidiagonalization. The singular values are
+// computed in all cases, while the singular vectors are computed depending on
+// kind, which must be SVDNone, SVDThinU, SVDThinV or SVDThin.
+//
+// The bidiagonalization is restarted by keeping the Ritz vectors of the
+// largest singular values and the Lanczos vectors are fully reorthogonalized.
+// The iteration continues until the residual norms of the k singular triplets
+// are small relative to the largest singular value. The starting vector is
+// generated using the provided source, or the default source from
+// golang.org/x/exp/rand if src is nil.
+//
+// The method is described in
+//  Baglama, J., & Reichel, L. (2005). Augmented implicitly restarted Lanczos
+//  bidiagonalization methods. SIAM Journal on Scientific Computing, 27(1),
+//  19-42.
+//
+// FactorizeLanczos panics if k is not in the range [1, min(m,n)] or if kind is
+// invalid. FactorizeLanczos returns whether the decomposition succeeded. If
+// the decomposition failed, routines that require a successful factorization
+// will panic.
+func (t *TruncatedSVD) FactorizeLanczos(a Matrix, k int, kind SVDKind, src rand.Source) (ok bool) {
+	m, n := a.Dims()
+	t.reset(m, n, k, kind)
+	normFloat64 := rand.NormFloat64
+	if src != nil {
+		normFloat64 = rand.New(src).NormFloat64
+	}
+
+	// The m×l matrix P and the n×l matrix Q have orthonormal columns and
+	// satisfy
+	//  A * Q = P * B,
+	//  A^T * P = Q * B^T + r * e_l^T,
+	// where B is l×l and upper triangular and r is orthogonal to Q.
+	l := min(min(m, n), max(2*k, k+10))
+	p := NewDense(m, l, nil)
+	q := NewDense(n, l, nil)
+	b := NewDense(l, l, nil)
+	var (
+		pw, qw VecDense
+		c, r   VecDense
+		beta   float64
+		svd    SVD
+		ub, vb Dense
+		tmp    Dense
+	)
+
+	randomUnit := func(dst *Dense, j int) {
+		rows, _ := dst.Dims()
+		v := NewVecDense(rows, nil)
+		for i := range v.mat.Data {
+			v.mat.Data[i] = normFloat64()
+		}
+		norm0 := Norm(v, 2)
+		gramSchmidt(v, nil, dst.Slice(0, rows, 0, j).(*Dense))
+		norm := Norm(v, 2)
+		if norm <= 1e3*machEps*norm0 {
+			v.Zero()
+		} else {
+			v.ScaleVec(1/norm, v)
+		}
+		dst.SetCol(j, v.mat.Data)
+	}
+
+	randomUnit(q, 0)
+	start := 0
+	for iter := 0; ; iter++ {
+		for j := start; j < l; j++ {
+			// Compute column j of P and B from A*q_j.
+			pw.MulVec(a, q.ColView(j))
+			norm0 := Norm(&pw, 2)
+			gramSchmidt(&pw, &c, p.Slice(0, m, 0, j).(*Dense))
+			for i := 0; i < j; i++ {
+				b.set(i, j, c.AtVec(i))
+			}
+			alpha := Norm(&pw, 2)
+			if alpha <= 1e3*machEps*norm0 {
+				b.set(j, j, 0)
+				randomUnit(p, j)
+			} else {
+				b.set(j, j, alpha)
+				pw.ScaleVec(1/alpha, &pw)
+				p.SetCol(j, pw.mat.Data)
+			}
+
+			// Compute the next column of Q, or the residual r
+			// after the last step, from A^T*p_j.
+			qw.MulVec(a.T(), p.ColView(j))
+			norm0 = Norm(&qw, 2)
+			gramSchmidt(&qw, nil, q.Slice(0, n, 0, j+1).(*Dense))
+			beta = Norm(&qw, 2)
+			if beta <= 1e3*machEps*norm0 {
+				beta = 0
+			}
+			if j == l-1 {
+				r.CloneVec(&qw)
+				break
+			}
+			if beta == 0 {
+				randomUnit(q, j+1)
+				continue
+			}
+			b.set(j, j+1, beta)
+			qw.ScaleVec(1/beta, &qw)
+			q.SetCol(j+1, qw.mat.Data)
+		}
+
+		ok = svd.Factorize(b, SVDThin)
+		if !ok {
+			t.s = t.s[:0]
+			return false
+		}
+		svd.UTo(&ub)
+		svd.VTo(&vb)
+		smax := svd.s[0]
+
+		// The residual norm of the Ritz triplet i is |β * ub[l-1, i]|.
+		var nconv int
+		for i := 0; i < k; i++ {
+			if beta*math.Abs(ub.at(l-1, i)) <= lanczosSVDTol*smax {
+				nconv++
+			}
+		}
+		if nconv == k || iter >= lanczosSVDMaxIter {
+			copy(t.s, svd.s[:k])
+			if kind&SVDThinU != 0 {
+				t.u.Mul(p, ub.Slice(0, l, 0, k))
+			}
+			if kind&SVDThinV != 0 {
+				t.v.Mul(q, vb.Slice(0, l, 0, k))
+			}
+			if nconv < k {
+				t.s = t.s[:0]
+				return false
+			}
+			return true
+		}
+
+		// Restart with the Ritz vectors of the largest singular values.
+		// The first column of B that is then computed is the residual
+		// part of the bidiagonalization.
+		start = max(1, min(k+min(nconv, (l-k)/2), l-1))
+		tmp.Mul(p, ub.Slice(0, l, 0, start))
+		p.Slice(0, m, 0, start).(*Dense).Copy(&tmp)
+		tmp.Reset()
+		tmp.Mul(q, vb.Slice(0, l, 0, start))
+		q.Slice(0, n, 0, start).(*Dense).Copy(&tmp)
+		b.Zero()
+		for i := 0; i < start; i++ {
+			b.set(i, i, svd.s[i])
+		}
+		if beta == 0 {
+			randomUnit(q, start)
+		} else {
+			r.ScaleVec(1/beta, &r)
+			q.SetCol(start, r.mat.Data)
+		}
+		tmp.Reset()
+		ub.Reset()
+		vb.Reset()
+	}
+}
+
+// reset prepares the receiver for a truncated SVD of rank k of an m×n matrix.
+func (t *TruncatedSVD) reset(m, n, k int, kind SVDKind) {
+	if k < 1 || min(m, n) < k {
+		panic("mat: invalid rank for truncated SVD")
+	}
+	if kind&^SVDThin != 0 {
+		panic("mat: invalid kind for truncated SVD")
+	}
+	t.kind = kind
+	t.s = useZeroed(t.s, k)
+	t.u.Reset()
+	t.v.Reset()
+}
+
+// orthonormalize replaces the columns of a with an orthonormal basis of their
+// span computed by a QR factorization.
+func orthonormalize(a *Dense) {
+	r, c := a.Dims()
+	tau := make([]float64, c)
+	work := []float64{0}
+	lapack64.Geqrf(a.mat, tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Geqrf(a.mat, tau, work, len(work))
+	putFloats(work)
+
+	// Form the first c columns of Q by applying it to the first columns
+	// of the identity.
+	q := NewDense(r, c, nil)
+	for i := 0; i < c; i++ {
+		q.set(i, i, 1)
+	}
+	work = []float64{0}
+	lapack64.Ormqr(blas.Left, blas.NoTrans, a.mat, tau, q.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, blas.NoTrans, a.mat, tau, q.mat, work, len(work))
+	putFloats(work)
+	a.Copy(q)
+}
+
+// gramSchmidt orthogonalizes x against the columns of the matrix q, which
+// must be orthonormal, using classical Gram-Schmidt with one step of
+// reorthogonalization. If c is not nil, the projection coefficients q^T*x
+// are stored into c.
+func gramSchmidt(x, c *VecDense, q *Dense) {
+	_, k := q.Dims()
+	if k == 0 {
+		return
+	}
+	if c != nil {
+		c.Reset()
+		c.reuseAs(k)
+		c.Zero()
+	}
+	h := getFloats(k, false)
+	defer putFloats(h)
+	hv := blas64.Vector{N: k, Inc: 1, Data: h}
+	for pass := 0; pass < 2; pass++ {
+		blas64.Gemv(blas.Trans, 1, q.mat, x.mat, 0, hv)
+		blas64.Gemv(blas.NoTrans, -1, q.mat, hv, 1, x.mat)
+		if c != nil {
+			blas64.Axpy(1, hv, c.mat)
+		}
+	}
+}
+
+// Kind returns the SVDKind of the decomposition. If no decomposition has been
+// computed, Kind returns -1.
+func (t *TruncatedSVD) Kind() SVDKind {
+	if !t.succFact() {
+		return -1
+	}
+	return t.kind
+}
+
+// Rank returns the rank k of the truncated decomposition. Rank will panic if
+// the receiver does not contain a successful factorization.
+func (t *TruncatedSVD) Rank() int {
+	if !t.succFact() {
+		panic(badFact)
+	}
+	return len(t.s)
+}
+
+// Values returns the k largest singular values of the factorized matrix in
+// descending order.
+//
+// If the input slice is non-nil, the values will be stored in-place into
+// the slice. In this case, the slice must have length k, and Values will
+// panic with ErrSliceLengthMismatch otherwise. If the input slice is nil, a new
+// slice of the appropriate length will be allocated and returned.
+//
+// Values will panic if the receiver does not contain a successful factorization.
+func (t *TruncatedSVD) Values(s []float64) []float64 {
+	if !t.succFact() {
+		panic(badFact)
+	}
+	if s == nil {
+		s = make([]float64, len(t.s))
+	}
+	if len(s) != len(t.s) {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(s, t.s)
+	return s
+}
+
+// UTo extracts the m×k matrix U_k of left singular vectors from the truncated
+// singular value decomposition. The columns correspond to the singular values
+// as returned from TruncatedSVD.Values.
+//
+// If dst is not nil, U_k is stored in-place into dst, and dst must have size
+// m×k, and UTo panics otherwise. If dst is nil, a new matrix of the appropriate
+// size is allocated and returned.
+func (t *TruncatedSVD) UTo(dst *Dense) *Dense {
+	if !t.succFact() {
+		panic(badFact)
+	}
+	if t.kind&SVDThinU == 0 {
+		panic("svd: u not computed during factorization")
+	}
+	r, c := t.u.Dims()
+	if dst == nil {
+		dst = NewDense(r, c, nil)
+	} else {
+		dst.reuseAs(r, c)
+	}
+	dst.Copy(&t.u)
+	return dst
+}
+
+// VTo extracts the n×k matrix V_k of right singular vectors from the truncated
+// singular value decomposition. The columns correspond to the singular values
+// as returned from TruncatedSVD.Values.
+//
+// If dst is not nil, V_k is stored in-place into dst, and dst must have size
+// n×k, and VTo panics otherwise. If dst is nil, a new matrix of the appropriate
+// size is allocated and returned.
+func (t *TruncatedSVD) VTo(dst *Dense) *Dense {
+	if !t.succFact() {
+		panic(badFact)
+	}
+	if t.kind&SVDThinV == 0 {
+		panic("svd: v not computed during factorization")
+	}
+	r, c := t.v.Dims()
+	if dst == nil {
+		dst = NewDense(r, c, nil)
+	} else {
+		dst.reuseAs(r, c)
+	}
+	dst.Copy(&t.v)
+	return dst
+}
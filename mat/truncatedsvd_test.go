@@ -0,0 +1,171 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestTruncatedSVD(t *testing.T) {
+	t.Parallel()
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, k int
+		rank    int
+	}{
+		{m: 20, n: 20, k: 1, rank: 20},
+		{m: 50, n: 30, k: 5, rank: 30},
+		{m: 30, n: 50, k: 5, rank: 30},
+		{m: 100, n: 10, k: 10, rank: 10},
+		{m: 10, n: 100, k: 4, rank: 10},
+		{m: 60, n: 40, k: 8, rank: 8},
+		{m: 40, n: 60, k: 6, rank: 3},
+	} {
+		a := randLowRank(test.m, test.n, test.rank, rnd)
+		var svd SVD
+		if !svd.Factorize(a, SVDNone) {
+			t.Fatal("unexpected SVD failure")
+		}
+		want := svd.Values(nil)[:test.k]
+
+		for _, method := range []struct {
+			name string
+			tol  float64
+			fact func(*TruncatedSVD, SVDKind) bool
+		}{
+			{
+				name: "Randomized",
+				tol:  1e-8,
+				fact: func(tsvd *TruncatedSVD, kind SVDKind) bool {
+					return tsvd.FactorizeRandomized(a, test.k, kind, 10, 4, rand.NewSource(1))
+				},
+			},
+			{
+				name: "Lanczos",
+				tol:  1e-10,
+				fact: func(tsvd *TruncatedSVD, kind SVDKind) bool {
+					return tsvd.FactorizeLanczos(a, test.k, kind, rand.NewSource(1))
+				},
+			},
+		} {
+			prefix := fmt.Sprintf("%s m=%d,n=%d,k=%d,rank=%d", method.name, test.m, test.n, test.k, test.rank)
+			for _, kind := range []SVDKind{SVDNone, SVDThinU, SVDThinV, SVDThin} {
+				var tsvd TruncatedSVD
+				if !method.fact(&tsvd, kind) {
+					t.Errorf("%s: unexpected factorization failure for kind %d", prefix, kind)
+					continue
+				}
+				if tsvd.Kind() != kind {
+					t.Errorf("%s: unexpected kind: got %d, want %d", prefix, tsvd.Kind(), kind)
+				}
+				if tsvd.Rank() != test.k {
+					t.Errorf("%s: unexpected rank: got %d, want %d", prefix, tsvd.Rank(), test.k)
+				}
+				s := tsvd.Values(nil)
+				if !floats.EqualApprox(s, want, method.tol*want[0]) {
+					t.Errorf("%s: singular value mismatch for kind %d:\ngot  %v\nwant %v", prefix, kind, s, want)
+				}
+				if kind != SVDThin {
+					continue
+				}
+
+				u := tsvd.UTo(nil)
+				v := tsvd.VTo(nil)
+				if r, c := u.Dims(); r != test.m || c != test.k {
+					t.Errorf("%s: unexpected shape of U: got %d×%d, want %d×%d", prefix, r, c, test.m, test.k)
+				}
+				if r, c := v.Dims(); r != test.n || c != test.k {
+					t.Errorf("%s: unexpected shape of V: got %d×%d, want %d×%d", prefix, r, c, test.n, test.k)
+				}
+				var utu, vtv Dense
+				utu.Mul(u.T(), u)
+				vtv.Mul(v.T(), v)
+				eye := eye(test.k)
+				if !EqualApprox(&utu, eye, 1e-12) {
+					t.Errorf("%s: U is not orthonormal", prefix)
+				}
+				if !EqualApprox(&vtv, eye, 1e-12) {
+					t.Errorf("%s: V is not orthonormal", prefix)
+				}
+
+				// Check that A * V = U * Σ.
+				var av, us Dense
+				av.Mul(a, v)
+				us.Mul(u, NewDiagDense(test.k, s))
+				if !EqualApprox(&av, &us, method.tol*want[0]) {
+					t.Errorf("%s: A*V != U*Σ", prefix)
+				}
+			}
+		}
+	}
+}
+
+func TestTruncatedSVDPanics(t *testing.T) {
+	t.Parallel()
+	a := NewDense(5, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 10,
+		1, 0, 0,
+		0, 1, 0,
+	})
+	var tsvd TruncatedSVD
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{"zero rank", func() { tsvd.FactorizeRandomized(a, 0, SVDThin, 5, 1, nil) }},
+		{"rank too large", func() { tsvd.FactorizeLanczos(a, 4, SVDThin, nil) }},
+		{"full kind", func() { tsvd.FactorizeLanczos(a, 2, SVDFull, nil) }},
+		{"negative oversample", func() { tsvd.FactorizeRandomized(a, 2, SVDThin, -1, 1, nil) }},
+		{"negative power", func() { tsvd.FactorizeRandomized(a, 2, SVDThin, 1, -1, nil) }},
+		{"no factorization", func() { (&TruncatedSVD{}).Values(nil) }},
+	} {
+		if panicked, _ := panics(test.fn); !panicked {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+
+	if !tsvd.FactorizeRandomized(a, 2, SVDThinV, 1, 1, nil) {
+		t.Fatal("unexpected factorization failure")
+	}
+	if panicked, _ := panics(func() { tsvd.UTo(nil) }); !panicked {
+		t.Error("expected panic extracting U not computed during factorization")
+	}
+	if panicked, _ := panics(func() { tsvd.VTo(NewDense(3, 3, nil)) }); !panicked {
+		t.Error("expected panic for mismatched V shape")
+	}
+}
+
+// randLowRank returns a random m×n matrix of the given rank whose non-zero
+// singular values decay geometrically from 1.
+func randLowRank(m, n, rank int, rnd *rand.Rand) *Dense {
+	orthonormal := func(r, c int) *Dense {
+		a := NewDense(r, c, nil)
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				a.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		orthonormalize(a)
+		return a
+	}
+	u := orthonormal(m, rank)
+	v := orthonormal(n, rank)
+	s := make([]float64, rank)
+	for i := range s {
+		s[i] = math.Pow(0.8, float64(i))
+	}
+	var us, a Dense
+	us.Mul(u, NewDiagDense(rank, s))
+	a.Mul(&us, v.T())
+	return &a
+}
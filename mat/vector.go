@@ -558,6 +558,9 @@ func (v *VecDense) MulVec(a Matrix, b Vector) {
 
 	// TODO(kortschak): Improve the non-fast paths.
 	switch aU := aU.(type) {
+	case *COO, *CSR, *CSC, *Kronecker, *SymKronecker:
+		aU.(MulVecToer).MulVecTo(v, trans, b)
+		return
 	case Vector:
 		if b.Len() == 1 {
 			// {n,1} x {1,1}
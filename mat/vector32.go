@@ -0,0 +1,446 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+)
+
+var (
+	vector32 *VecDense32
+
+	_ Matrix32 = vector32
+	_ Vector32 = vector32
+)
+
+// VecDense32 represents a single precision column vector.
+type VecDense32 struct {
+	mat blas32.Vector
+	n   int
+	// A BLAS vector can have a negative increment, but allowing this
+	// in the mat type complicates a lot of code, and doesn't gain anything.
+	// VecDense32 must have positive increment in this package.
+}
+
+// NewVecDense32 creates a new VecDense32 of length n. If data == nil,
+// a new slice is allocated for the backing slice. If len(data) == n, data is
+// used as the backing slice, and changes to the elements of the returned VecDense32
+// will be reflected in data. If neither of these is true, NewVecDense32 will panic.
+// NewVecDense32 will panic if n is zero.
+func NewVecDense32(n int, data []float32) *VecDense32 {
+	if n <= 0 {
+		if n == 0 {
+			panic(ErrZeroLength)
+		}
+		panic("mat: negative dimension")
+	}
+	if len(data) != n && data != nil {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]float32, n)
+	}
+	return &VecDense32{
+		mat: blas32.Vector{
+			Inc:  1,
+			Data: data,
+		},
+		n: n,
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix. Columns is always 1
+// for a non-Reset vector.
+func (v *VecDense32) Dims() (r, c int) {
+	if v.IsZero() {
+		return 0, 0
+	}
+	return v.n, 1
+}
+
+// Len returns the length of the vector.
+func (v *VecDense32) Len() int {
+	return v.n
+}
+
+// T performs an implicit transpose by returning the receiver inside a
+// Transpose32.
+func (v *VecDense32) T() Matrix32 {
+	return Transpose32{v}
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized vectors can be the
+// receiver for size-restricted operations. VecDense32 vectors can be zeroed using Reset.
+func (v *VecDense32) IsZero() bool {
+	// It must be the case that v.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return v.mat.Inc == 0
+}
+
+// Reset zeros the length of the vector so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (v *VecDense32) Reset() {
+	// No change of Inc or n to 0 may be
+	// made unless both are set to 0.
+	v.mat.Inc = 0
+	v.n = 0
+	v.mat.Data = v.mat.Data[:0]
+}
+
+// Zero sets all of the vector elements to zero.
+func (v *VecDense32) Zero() {
+	for i := 0; i < v.n; i++ {
+		v.mat.Data[v.mat.Inc*i] = 0
+	}
+}
+
+// RawVector returns the underlying blas32.Vector used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned blas32.Vector.
+func (v *VecDense32) RawVector() blas32.Vector {
+	return v.mat
+}
+
+// ColViewOf reflects the column j of the RawMatrixer32 m, into the receiver
+// backed by the same underlying data. The length of the receiver must either be
+// zero or match the number of rows in m.
+func (v *VecDense32) ColViewOf(m RawMatrixer32, j int) {
+	rm := m.RawMatrix()
+
+	if j >= rm.Cols || j < 0 {
+		panic(ErrColAccess)
+	}
+	if !v.IsZero() && v.n != rm.Rows {
+		panic(ErrShape)
+	}
+
+	v.mat.Inc = rm.Stride
+	v.mat.Data = rm.Data[j : (rm.Rows-1)*rm.Stride+j+1]
+	v.n = rm.Rows
+}
+
+// RowViewOf reflects the row i of the RawMatrixer32 m, into the receiver
+// backed by the same underlying data. The length of the receiver must either be
+// zero or match the number of columns in m.
+func (v *VecDense32) RowViewOf(m RawMatrixer32, i int) {
+	rm := m.RawMatrix()
+
+	if i >= rm.Rows || i < 0 {
+		panic(ErrRowAccess)
+	}
+	if !v.IsZero() && v.n != rm.Cols {
+		panic(ErrShape)
+	}
+
+	v.mat.Inc = 1
+	v.mat.Data = rm.Data[i*rm.Stride : i*rm.Stride+rm.Cols]
+	v.n = rm.Cols
+}
+
+// reuseAs resizes an empty vector to a r×1 vector,
+// or checks that a non-empty matrix is r×1.
+func (v *VecDense32) reuseAs(r int) {
+	if r == 0 {
+		panic(ErrZeroLength)
+	}
+	if v.IsZero() {
+		v.mat = blas32.Vector{
+			Inc:  1,
+			Data: use32(v.mat.Data, r),
+		}
+		v.n = r
+		return
+	}
+	if r != v.n {
+		panic(ErrShape)
+	}
+}
+
+// asDense returns a Dense32 representation of the receiver with the same
+// underlying data.
+func (v *VecDense32) asDense() *Dense32 {
+	return &Dense32{
+		mat:     v.asGeneral(),
+		capRows: v.n,
+		capCols: 1,
+	}
+}
+
+// asGeneral returns a blas32.General representation of the receiver with the
+// same underlying data.
+func (v *VecDense32) asGeneral() blas32.General {
+	return blas32.General{
+		Rows:   v.n,
+		Cols:   1,
+		Stride: v.mat.Inc,
+		Data:   v.mat.Data,
+	}
+}
+
+// CloneVec makes a copy of a into the receiver, overwriting the previous value
+// of the receiver.
+func (v *VecDense32) CloneVec(a Vector32) {
+	if v == a {
+		return
+	}
+	n := a.Len()
+	v.mat = blas32.Vector{
+		Inc:  1,
+		Data: use32(v.mat.Data, n),
+	}
+	v.n = n
+	if a, ok := a.(*VecDense32); ok {
+		blas32.Copy(n, a.mat, v.mat)
+		return
+	}
+	for i := 0; i < n; i++ {
+		v.setVec(i, a.AtVec(i))
+	}
+}
+
+// CopyVec makes a copy of elements of a into the receiver. It is similar to the
+// built-in copy; it copies as much as the overlap between the two vectors and
+// returns the number of elements it copied.
+func (v *VecDense32) CopyVec(a Vector32) int {
+	n := min(v.Len(), a.Len())
+	if v == a {
+		return n
+	}
+	if a, ok := a.(*VecDense32); ok {
+		blas32.Copy(n, a.mat, v.mat)
+		return n
+	}
+	for i := 0; i < n; i++ {
+		v.setVec(i, a.AtVec(i))
+	}
+	return n
+}
+
+// CloneFromVec64 makes a copy of the float64 vector a into the receiver,
+// rounding each element to the nearest float32 value and overwriting the
+// previous value of the receiver.
+func (v *VecDense32) CloneFromVec64(a Vector) {
+	n := a.Len()
+	v.mat = blas32.Vector{
+		Inc:  1,
+		Data: use32(v.mat.Data, n),
+	}
+	v.n = n
+	for i := 0; i < n; i++ {
+		v.mat.Data[i] = float32(a.AtVec(i))
+	}
+}
+
+// To64 copies the elements of the receiver into dst as float64 values and
+// returns the result. If dst is nil, a new VecDense is allocated, otherwise dst
+// must be zero-sized or have the same length as the receiver.
+func (v *VecDense32) To64(dst *VecDense) *VecDense {
+	if dst == nil {
+		dst = NewVecDense(v.n, nil)
+	} else {
+		dst.reuseAs(v.n)
+	}
+	for i := 0; i < v.n; i++ {
+		dst.setVec(i, float64(v.mat.Data[i*v.mat.Inc]))
+	}
+	return dst
+}
+
+// ScaleVec scales the vector a by alpha, placing the result in the receiver.
+func (v *VecDense32) ScaleVec(alpha float32, a Vector32) {
+	n := a.Len()
+
+	if v == a {
+		blas32.Scal(n, alpha, v.mat)
+		return
+	}
+
+	v.reuseAs(n)
+
+	if a, ok := a.(*VecDense32); ok {
+		v.checkOverlap(a.mat)
+		blas32.Copy(n, a.mat, v.mat)
+		blas32.Scal(n, alpha, v.mat)
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		v.setVec(i, alpha*a.AtVec(i))
+	}
+}
+
+// AddScaledVec adds the vectors a and alpha*b, placing the result in the receiver.
+func (v *VecDense32) AddScaledVec(a Vector32, alpha float32, b Vector32) {
+	ar := a.Len()
+	br := b.Len()
+
+	if ar != br {
+		panic(ErrShape)
+	}
+
+	av, aok := a.(*VecDense32)
+	bv, bok := b.(*VecDense32)
+	if aok && v != av {
+		v.checkOverlap(av.mat)
+	}
+	if bok && v != bv {
+		v.checkOverlap(bv.mat)
+	}
+
+	v.reuseAs(ar)
+
+	switch {
+	case v == a && v == b: // v <- v + alpha * v = (alpha + 1) * v
+		blas32.Scal(ar, alpha+1, v.mat)
+	case !aok || !bok: // v <- a + alpha * b without blas32 support.
+		for i := 0; i < ar; i++ {
+			v.setVec(i, a.AtVec(i)+alpha*b.AtVec(i))
+		}
+	case v == b: // v <- a + alpha * v
+		if alpha == 0 {
+			blas32.Copy(ar, av.mat, v.mat)
+			return
+		}
+		blas32.Scal(ar, alpha, v.mat)
+		blas32.Axpy(ar, 1, av.mat, v.mat)
+	default: // v <- a + alpha * b
+		if v != a {
+			blas32.Copy(ar, av.mat, v.mat)
+		}
+		blas32.Axpy(ar, alpha, bv.mat, v.mat)
+	}
+}
+
+// AddVec adds the vectors a and b, placing the result in the receiver.
+func (v *VecDense32) AddVec(a, b Vector32) {
+	v.AddScaledVec(a, 1, b)
+}
+
+// SubVec subtracts the vector b from a, placing the result in the receiver.
+func (v *VecDense32) SubVec(a, b Vector32) {
+	v.AddScaledVec(a, -1, b)
+}
+
+// MulVec computes a * b. The result is stored into the receiver.
+// MulVec panics if the number of columns in a does not equal the number of rows in b
+// or if the number of columns in b does not equal 1.
+func (v *VecDense32) MulVec(a Matrix32, b Vector32) {
+	r, c := a.Dims()
+	br, bc := b.Dims()
+	if c != br || bc != 1 {
+		panic(ErrShape)
+	}
+
+	aU, trans := untranspose32(a)
+	bv, fast := b.(*VecDense32)
+	if fast && v != bv {
+		v.checkOverlap(bv.mat)
+	}
+
+	v.reuseAs(r)
+	var restore func()
+	if v == aU {
+		v, restore = v.isolatedWorkspace(aU.(*VecDense32))
+		defer restore()
+	} else if v == b {
+		v, restore = v.isolatedWorkspace(b)
+		defer restore()
+	}
+
+	switch aU := aU.(type) {
+	case *VecDense32:
+		if fast {
+			if trans {
+				// {1,n} x {n,1}
+				v.setVec(0, blas32.Dot(c, aU.mat, bv.mat))
+				return
+			}
+			// {n,1} x {1,1}
+			v.ScaleVec(bv.at(0), aU)
+			return
+		}
+	case *SymDense32:
+		if fast {
+			amat := aU.RawSymmetric()
+			aU.checkOverlap(v.asGeneral())
+			blas32.Symv(1, amat, bv.mat, 0, v.mat)
+			return
+		}
+	case RawMatrixer32:
+		if fast {
+			amat := aU.RawMatrix()
+			// We don't know that a is a *Dense32, so make
+			// a temporary Dense32 to check overlap.
+			(&Dense32{mat: amat}).checkOverlap(v.asGeneral())
+			t := blas.NoTrans
+			if trans {
+				t = blas.Trans
+			}
+			blas32.Gemv(t, 1, amat, bv.mat, 0, v.mat)
+			return
+		}
+	}
+
+	for i := 0; i < r; i++ {
+		var f float32
+		for j := 0; j < c; j++ {
+			f += a.At(i, j) * b.AtVec(j)
+		}
+		v.setVec(i, f)
+	}
+}
+
+// SolveVec solves the linear least squares problem
+//  minimize over x |b - A*x|_2
+// where A is an m×n matrix A, b is a given m element vector and x is n element
+// solution vector. Solve assumes that A has full rank, that is
+//  rank(A) = min(m,n)
+//
+// See Dense32.Solve for details of the solution.
+//
+// If A is exactly singular, a Condition error is returned.
+func (v *VecDense32) SolveVec(a Matrix32, b Vector32) error {
+	if _, bc := b.Dims(); bc != 1 {
+		panic(ErrShape)
+	}
+	_, c := a.Dims()
+
+	// The Solve implementation is non-trivial, so rather than duplicate the code,
+	// instead recast the VecDense32 values as Dense32 and call the matrix code.
+
+	if bv, ok := b.(*VecDense32); ok {
+		if v != bv {
+			v.checkOverlap(bv.mat)
+		}
+		v.reuseAs(c)
+		m := v.asDense()
+		// We conditionally create bm as m when b and v are identical
+		// to prevent the overlap detection code from identifying m
+		// and bm as overlapping but not identical.
+		bm := m
+		if v != bv {
+			bm = bv.asDense()
+		}
+		return m.Solve(a, bm)
+	}
+
+	v.reuseAs(c)
+	m := v.asDense()
+	return m.Solve(a, b)
+}
+
+func (v *VecDense32) isolatedWorkspace(a Vector32) (n *VecDense32, restore func()) {
+	l := a.Len()
+	if l == 0 {
+		panic(ErrZeroLength)
+	}
+	n = NewVecDense32(l, nil)
+	return n, func() {
+		v.CopyVec(n)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestVecDense32(t *testing.T) {
+	const tol = 1e-5
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{4, 3},
+		{10, 10},
+		{7, 19},
+	} {
+		m, n := test.m, test.n
+		a := randDense32(m, n, rnd)
+		x := randDense32(n, 1, rnd).ColView(0).(*VecDense32)
+		y := randDense32(n, 1, rnd).ColView(0).(*VecDense32)
+		z := randDense32(m, 1, rnd).ColView(0).(*VecDense32)
+		a64 := a.To64(nil)
+		x64 := x.To64(nil)
+		y64 := y.To64(nil)
+		z64 := z.To64(nil)
+
+		var got VecDense32
+		var want VecDense
+		got.MulVec(a, x)
+		want.MulVec(a64, x64)
+		if !EqualApprox(got.To64(nil), &want, tol*float64(n)) {
+			t.Errorf("%d×%d: unexpected MulVec result", m, n)
+		}
+		got.Reset()
+		want.Reset()
+		got.MulVec(a.T(), z)
+		want.MulVec(a64.T(), z64)
+		if !EqualApprox(got.To64(nil), &want, tol*float64(m)) {
+			t.Errorf("%d×%d: unexpected MulVec result with transpose", m, n)
+		}
+
+		got.Reset()
+		want.Reset()
+		got.AddScaledVec(x, 0.5, y)
+		want.AddScaledVec(x64, 0.5, y64)
+		if !EqualApprox(got.To64(nil), &want, tol) {
+			t.Errorf("%d×%d: unexpected AddScaledVec result", m, n)
+		}
+		got.AddScaledVec(x, 2, &got)
+		want.AddScaledVec(x64, 2, &want)
+		if !EqualApprox(got.To64(nil), &want, tol) {
+			t.Errorf("%d×%d: unexpected AddScaledVec result with aliased receiver", m, n)
+		}
+		got.AddVec(&got, y)
+		want.AddVec(&want, y64)
+		if !EqualApprox(got.To64(nil), &want, tol) {
+			t.Errorf("%d×%d: unexpected AddVec result", m, n)
+		}
+		got.SubVec(&got, &got)
+		for i := 0; i < n; i++ {
+			if got.AtVec(i) != 0 {
+				t.Errorf("%d×%d: unexpected non-zero result subtracting vector from itself", m, n)
+				break
+			}
+		}
+		got.CopyVec(x)
+		got.ScaleVec(-2, &got)
+		want.ScaleVec(-2, x64)
+		if !EqualApprox(got.To64(nil), &want, tol) {
+			t.Errorf("%d×%d: unexpected ScaleVec result", m, n)
+		}
+	}
+}
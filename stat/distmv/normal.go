@@ -319,6 +319,42 @@ func NormalRand(x, mean []float64, chol *mat.Cholesky, src rand.Source) []float6
 	return x
 }
 
+// NormalRandPivoted generates a random number with the given mean and the
+// pivoted Cholesky decomposition of the covariance matrix. Unlike NormalRand,
+// the covariance matrix may be singular, so that NormalRandPivoted can sample
+// from a degenerate normal distribution whose samples lie in an affine subspace
+// of dimension chol.Rank().
+// If x is nil, new memory is allocated and returned, otherwise the result is stored
+// in place into x. NormalRandPivoted panics if x is non-nil and not equal to
+// len(mean), or if len(mean) != chol.Symmetric().
+func NormalRandPivoted(x, mean []float64, chol *mat.PivotedCholesky, src rand.Source) []float64 {
+	x = reuseAs(x, len(mean))
+	dim := len(mean)
+	if dim != chol.Symmetric() {
+		panic(badInputLength)
+	}
+	normal := make([]float64, dim)
+	if src == nil {
+		for i := range normal {
+			normal[i] = rand.NormFloat64()
+		}
+	} else {
+		rnd := rand.New(src)
+		for i := range normal {
+			normal[i] = rnd.NormFloat64()
+		}
+	}
+	// With P^T * Σ * P = U^T * U, the vector P * U^T * z has covariance Σ
+	// when z is standard normal. The last n-rank rows of U are zero, so the
+	// corresponding elements of z do not contribute.
+	v := mat.NewVecDense(dim, normal)
+	v.MulVec(chol.RawU().T(), v)
+	for i, p := range chol.Pivot(nil) {
+		x[p] = mean[p] + normal[i]
+	}
+	return x
+}
+
 // ScoreInput returns the gradient of the log-probability with respect to the
 // input x. That is, ScoreInput computes
 //  ∇_x log(p(x))
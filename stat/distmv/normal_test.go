@@ -128,6 +128,82 @@ func TestNormRand(t *testing.T) {
 	}
 }
 
+func TestNormalRandPivoted(t *testing.T) {
+	for _, test := range []struct {
+		mean []float64
+		cov  []float64
+		rank int
+		// null is a vector in the null space of cov, if any, that must
+		// be orthogonal to all samples minus the mean.
+		null []float64
+	}{
+		{
+			mean: []float64{6, 7},
+			cov: []float64{
+				5, 0.9,
+				0.9, 2,
+			},
+			rank: 2,
+		},
+		{
+			// cov = v * v^T with v = [1, 2, -1].
+			mean: []float64{1, -2, 3},
+			cov: []float64{
+				1, 2, -1,
+				2, 4, -2,
+				-1, -2, 1,
+			},
+			rank: 1,
+			null: []float64{2, -1, 0},
+		},
+		{
+			// cov = v * v^T + w * w^T with v = [1, 0, 1] and w = [0, 1, 1].
+			mean: []float64{0, 5, -1},
+			cov: []float64{
+				1, 0, 1,
+				0, 1, 1,
+				1, 1, 2,
+			},
+			rank: 2,
+			null: []float64{1, 1, -1},
+		},
+	} {
+		dim := len(test.mean)
+		cov := mat.NewSymDense(dim, test.cov)
+		var chol mat.PivotedCholesky
+		chol.Factorize(cov, -1)
+		if chol.Rank() != test.rank {
+			t.Fatalf("unexpected rank: got %d, want %d", chol.Rank(), test.rank)
+		}
+
+		src := rand.NewSource(1)
+		nSamples := 1000000
+		samps := mat.NewDense(nSamples, dim, nil)
+		for i := 0; i < nSamples; i++ {
+			x := NormalRandPivoted(samps.RawRowView(i), test.mean, &chol, src)
+			if test.null == nil {
+				continue
+			}
+			floats.Sub(x, test.mean)
+			if d := floats.Dot(x, test.null); math.Abs(d) > 1e-12 {
+				t.Fatalf("sample outside of the support: got dot product %v with null vector", d)
+			}
+			floats.Add(x, test.mean)
+		}
+		estMean := make([]float64, dim)
+		for i := range estMean {
+			estMean[i] = stat.Mean(mat.Col(nil, i, samps), nil)
+		}
+		if !floats.EqualApprox(estMean, test.mean, 1e-2) {
+			t.Errorf("Mean mismatch: want: %v, got %v", test.mean, estMean)
+		}
+		estCov := stat.CovarianceMatrix(nil, samps, nil)
+		if !mat.EqualApprox(estCov, cov, 1e-2) {
+			t.Errorf("Cov mismatch: want: %v, got %v", cov, estCov)
+		}
+	}
+}
+
 func TestNormalQuantile(t *testing.T) {
 	for _, test := range []struct {
 		mean []float64
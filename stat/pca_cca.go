@@ -8,6 +8,8 @@ import (
 	"errors"
 	"math"
 
+	"golang.org/x/exp/rand"
+
 	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/gonum/mat"
 )
@@ -20,6 +22,13 @@ type PC struct {
 	weights []float64
 	svd     *mat.SVD
 	ok      bool
+
+	// k is the number of computed components. If
+	// truncated is true, the components are held
+	// in tsvd rather than svd.
+	k         int
+	truncated bool
+	tsvd      *mat.TruncatedSVD
 }
 
 // PrincipalComponents performs a weighted principal components analysis on the
@@ -43,12 +52,52 @@ func (c *PC) PrincipalComponents(a mat.Matrix, weights []float64) (ok bool) {
 	if c.ok {
 		c.weights = append(c.weights[:0], weights...)
 	}
+	c.k = min(c.n, c.d)
+	c.truncated = false
+	return c.ok
+}
+
+// TruncatedPrincipalComponents performs a weighted principal components
+// analysis on the matrix of the input data in the same way as
+// PrincipalComponents, but computes only the k components with the largest
+// variance using a truncated singular value decomposition. This is more
+// efficient than PrincipalComponents when k is small compared to the number
+// of observations and variables, for example for wide data with many more
+// variables than observations.
+//
+// The truncated decomposition is computed by mat.TruncatedSVD.FactorizeLanczos
+// with a starting vector generated using the provided source, or the default
+// source from golang.org/x/exp/rand if src is nil.
+//
+// TruncatedPrincipalComponents will panic if k is not in the range
+// [1, min(n, d)]. TruncatedPrincipalComponents returns whether the analysis
+// was successful.
+func (c *PC) TruncatedPrincipalComponents(a mat.Matrix, weights []float64, k int, src rand.Source) (ok bool) {
+	c.n, c.d = a.Dims()
+	if weights != nil && len(weights) != c.n {
+		panic("stat: len(weights) != observations")
+	}
+	if k < 1 || min(c.n, c.d) < k {
+		panic("stat: number of components out of range")
+	}
+
+	if c.tsvd == nil {
+		c.tsvd = &mat.TruncatedSVD{}
+	}
+	centered := centerWeighted(a, weights)
+	c.ok = c.tsvd.FactorizeLanczos(centered, k, mat.SVDThinV, src)
+	if c.ok {
+		c.weights = append(c.weights[:0], weights...)
+	}
+	c.k = k
+	c.truncated = true
 	return c.ok
 }
 
 // VectorsTo returns the component direction vectors of a principal components
-// analysis. The vectors are returned in the columns of a d×min(n, d) matrix.
-// If dst is not nil it must either be zero-sized or be a d×min(n, d) matrix.
+// analysis. The vectors are returned in the columns of a d×min(n, d) matrix,
+// or a d×k matrix if the analysis was performed by TruncatedPrincipalComponents.
+// If dst is not nil it must either be zero-sized or be a matrix of that size.
 // dst will  be used as the destination for the direction vector data. If dst
 // is nil, a new mat.Dense is allocated for the destination.
 func (c *PC) VectorsTo(dst *mat.Dense) *mat.Dense {
@@ -57,10 +106,13 @@ func (c *PC) VectorsTo(dst *mat.Dense) *mat.Dense {
 	}
 
 	if dst != nil {
-		if d, n := dst.Dims(); !dst.IsZero() && (d != c.d || n != min(c.n, c.d)) {
+		if d, n := dst.Dims(); !dst.IsZero() && (d != c.d || n != c.k) {
 			panic(mat.ErrShape)
 		}
 	}
+	if c.truncated {
+		return c.tsvd.VTo(dst)
+	}
 	return c.svd.VTo(dst)
 }
 
@@ -69,16 +121,21 @@ func (c *PC) VectorsTo(dst *mat.Dense) *mat.Dense {
 // in descending order.
 // If dst is not nil it is used to store the variances and returned.
 // Vars will panic if the receiver has not successfully performed a principal
-// components analysis or dst is not nil and the length of dst is not min(n, d).
+// components analysis or dst is not nil and the length of dst is not min(n, d),
+// or k if the analysis was performed by TruncatedPrincipalComponents.
 func (c *PC) VarsTo(dst []float64) []float64 {
 	if !c.ok {
 		panic("stat: use of unsuccessful principal components analysis")
 	}
-	if dst != nil && len(dst) != min(c.n, c.d) {
+	if dst != nil && len(dst) != c.k {
 		panic("stat: length of slice does not match analysis")
 	}
 
-	dst = c.svd.Values(dst)
+	if c.truncated {
+		dst = c.tsvd.Values(dst)
+	} else {
+		dst = c.svd.Values(dst)
+	}
 	var f float64
 	if c.weights == nil {
 		f = 1 / float64(c.n-1)
@@ -279,6 +336,17 @@ func (c *CC) RightTo(dst *mat.Dense, spheredSpace bool) *mat.Dense {
 }
 
 func svdFactorizeCentered(work *mat.SVD, m mat.Matrix, weights []float64) (svd *mat.SVD, ok bool) {
+	centered := centerWeighted(m, weights)
+	if work == nil {
+		work = &mat.SVD{}
+	}
+	ok = work.Factorize(centered, mat.SVDThin)
+	return work, ok
+}
+
+// centerWeighted returns a copy of m with its columns centered and its
+// rows scaled by the square root of the corresponding weights.
+func centerWeighted(m mat.Matrix, weights []float64) *mat.Dense {
 	n, d := m.Dims()
 	centered := mat.NewDense(n, d, nil)
 	col := make([]float64, n)
@@ -290,11 +358,7 @@ func svdFactorizeCentered(work *mat.SVD, m mat.Matrix, weights []float64) (svd *
 	for i, w := range weights {
 		floats.Scale(math.Sqrt(w), centered.RawRowView(i))
 	}
-	if work == nil {
-		work = &mat.SVD{}
-	}
-	ok = work.Factorize(centered, mat.SVDThin)
-	return work, ok
+	return centered
 }
 
 // scaleColsReciSqrt scales the columns of cols
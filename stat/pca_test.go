@@ -8,6 +8,8 @@ import (
 	"math"
 	"testing"
 
+	"golang.org/x/exp/rand"
+
 	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/gonum/mat"
 )
@@ -196,6 +198,76 @@ tests:
 	}
 }
 
+func TestTruncatedPrincipalComponents(t *testing.T) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, d, k  int
+		weighted bool
+	}{
+		{n: 10, d: 50, k: 3},
+		{n: 10, d: 50, k: 9, weighted: true},
+		{n: 40, d: 8, k: 2, weighted: true},
+		{n: 20, d: 100, k: 5},
+	} {
+		data := mat.NewDense(test.n, test.d, nil)
+		for i := 0; i < test.n; i++ {
+			for j := 0; j < test.d; j++ {
+				data.Set(i, j, rnd.NormFloat64()*float64(j%7+1))
+			}
+		}
+		var weights []float64
+		if test.weighted {
+			weights = make([]float64, test.n)
+			for i := range weights {
+				weights[i] = rnd.Float64() + 0.5
+			}
+		}
+
+		var full PC
+		if !full.PrincipalComponents(data, weights) {
+			t.Fatal("unexpected PCA failure")
+		}
+		wantVars := full.VarsTo(nil)[:test.k]
+		wantVecs := full.VectorsTo(nil)
+
+		var pc PC
+		for j := 0; j < 2; j++ {
+			if !pc.TruncatedPrincipalComponents(data, weights, test.k, rand.NewSource(uint64(j))) {
+				t.Fatalf("n=%d,d=%d,k=%d: unexpected truncated PCA failure", test.n, test.d, test.k)
+			}
+			vars := pc.VarsTo(nil)
+			if !approxEqual(vars, wantVars, tol*wantVars[0]) {
+				t.Errorf("n=%d,d=%d,k=%d use %d: unexpected variance result got:%v, want:%v",
+					test.n, test.d, test.k, j, vars, wantVars)
+			}
+			vecs := pc.VectorsTo(nil)
+			if r, c := vecs.Dims(); r != test.d || c != test.k {
+				t.Fatalf("n=%d,d=%d,k=%d use %d: unexpected shape of vectors: got %d×%d",
+					test.n, test.d, test.k, j, r, c)
+			}
+			// The direction vectors are unique up to sign.
+			for c := 0; c < test.k; c++ {
+				got := vecs.ColView(c)
+				want := wantVecs.ColView(c)
+				if math.Abs(math.Abs(mat.Dot(got, want))-1) > 1e-8 {
+					t.Errorf("n=%d,d=%d,k=%d use %d: unexpected direction vector %d",
+						test.n, test.d, test.k, j, c)
+				}
+			}
+
+			// Check that reuse for a full analysis is allowed.
+			if !pc.PrincipalComponents(data, weights) {
+				t.Fatal("unexpected PCA failure")
+			}
+			if len(pc.VarsTo(nil)) != min(test.n, test.d) {
+				t.Errorf("n=%d,d=%d,k=%d use %d: unexpected number of components after reuse",
+					test.n, test.d, test.k, j)
+			}
+		}
+	}
+}
+
 func approxEqual(a, b []float64, epsilon float64) bool {
 	if len(a) != len(b) {
 		return false